@@ -0,0 +1,159 @@
+// app/client/admin.go
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/orgs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/version"
+)
+
+// ReloadResult describes the outcome of a configuration reload
+type ReloadResult struct {
+	Reloaded []string               `json:"reloaded"`
+	Errors   map[string]string      `json:"errors,omitempty"`
+	Settings map[string]interface{} `json:"settings"`
+}
+
+// IntegrityProblem is an inconsistency found in a stored report
+type IntegrityProblem struct {
+	ReportID string `json:"reportId"`
+	Problem  string `json:"problem"`
+}
+
+// IntegrityReport is the outcome of an integrity check of the store
+type IntegrityReport struct {
+	Checked  int                `json:"checked"`
+	Problems []IntegrityProblem `json:"problems"`
+}
+
+// RetentionCandidate is a report removed, or to be removed, by the retention limits
+type RetentionCandidate struct {
+	ReportID   string    `json:"reportId"`
+	Cluster    string    `json:"cluster"`
+	UploadedAt time.Time `json:"uploadedAt"`
+	Reason     string    `json:"reason"`
+}
+
+// RetentionRun is the outcome of enforcing the retention limits once
+type RetentionRun struct {
+	StartedAt time.Time            `json:"startedAt"`
+	DryRun    bool                 `json:"dryRun,omitempty"`
+	Checked   int                  `json:"checked"`
+	Removed   []RetentionCandidate `json:"removed"`
+	Errors    []string             `json:"errors,omitempty"`
+}
+
+// ServerVersion returns the version and build details of the dashboard
+func (c *Client) ServerVersion(ctx context.Context) (*version.Info, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/version", nil)
+	if err != nil {
+		return nil, err
+	}
+	var info version.Info
+	if err := c.do(req, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// ListOrgs returns the organizations ordered by name
+func (c *Client) ListOrgs(ctx context.Context) ([]orgs.Org, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/admin/orgs", nil)
+	if err != nil {
+		return nil, err
+	}
+	var list []orgs.Org
+	if err := c.do(req, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Rescore queues every stored report for re-parsing and returns how many were queued
+func (c *Client) Rescore(ctx context.Context) (int, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/reports/rescore", nil)
+	if err != nil {
+		return 0, err
+	}
+	var result struct {
+		Queued int `json:"queued"`
+	}
+	if err := c.do(req, &result); err != nil {
+		return 0, err
+	}
+	return result.Queued, nil
+}
+
+// Reload makes the dashboard re-read its settings file and registries. A
+// partial failure returns the result together with an *Error.
+func (c *Client) Reload(ctx context.Context) (*ReloadResult, error) {
+	req, err := c.newRequest(ctx, http.MethodPost, "/admin/reload", nil)
+	if err != nil {
+		return nil, err
+	}
+	var result ReloadResult
+	err = c.do(req, &result)
+
+	// A partial failure is answered with the full result in the body
+	var apiErr *Error
+	if errors.As(err, &apiErr) && json.Unmarshal(apiErr.body, &result) == nil && len(result.Errors) > 0 {
+		apiErr.Message = "reload failed for some parts"
+		return &result, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// VerifyIntegrity checks every stored report and its raw document
+func (c *Client) VerifyIntegrity(ctx context.Context) (*IntegrityReport, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/admin/integrity", nil)
+	if err != nil {
+		return nil, err
+	}
+	var report IntegrityReport
+	if err := c.do(req, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// RunRetention removes the reports beyond the retention limits now; a dry run
+// only lists them
+func (c *Client) RunRetention(ctx context.Context, dryRun bool) (*RetentionRun, error) {
+	path := "/admin/retention:run"
+	if dryRun {
+		path += "?dryRun=true"
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var run RetentionRun
+	if err := c.do(req, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+// Backup downloads a gzipped tar archive of the reports and registries into w
+// and returns its size
+func (c *Client) Backup(ctx context.Context, w io.Writer) (int64, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/admin/backup", nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.send(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return io.Copy(w, resp.Body)
+}
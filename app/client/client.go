@@ -0,0 +1,124 @@
+// app/client/client.go
+
+// Package client is a Go client for the dashboard API. It covers uploading
+// and listing reports, live scans and the administrative endpoints, so tools
+// built on it don't have to assemble requests by hand.
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIVersion is the dashboard API version the client speaks
+const APIVersion = "v2"
+
+// Client calls the API of a running dashboard
+type Client struct {
+	// BaseURL is the dashboard URL, without the /api prefix
+	BaseURL string
+
+	// APIKey is sent as X-API-Key when set
+	APIKey string
+
+	HTTP *http.Client
+}
+
+// New creates a client for the dashboard at baseURL
+func New(baseURL, apiKey string) *Client {
+	return &Client{
+		BaseURL: baseURL,
+		APIKey:  apiKey,
+		HTTP:    &http.Client{Timeout: 5 * time.Minute},
+	}
+}
+
+// Error is an error response of the API
+type Error struct {
+	StatusCode int
+	Status     string
+	Message    string
+
+	// body is the start of the response body
+	body []byte
+}
+
+// Error returns the status with the message of the response
+func (e *Error) Error() string {
+	return e.Status + ": " + e.Message
+}
+
+// url returns the URL of an API path
+func (c *Client) url(path string) string {
+	return strings.TrimSuffix(c.BaseURL, "/") + "/api/" + APIVersion + path
+}
+
+// newRequest creates a request for an API path
+func (c *Client) newRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.url(path), body)
+	if err != nil {
+		return nil, err
+	}
+	if c.APIKey != "" {
+		req.Header.Set("X-API-Key", c.APIKey)
+	}
+	return req, nil
+}
+
+// send sends a request and returns the response of a successful call; the
+// caller closes its body
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return nil, responseError(resp)
+	}
+	return resp, nil
+}
+
+// do sends a request and decodes a successful JSON response into out
+func (c *Client) do(req *http.Request, out interface{}) error {
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.send(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding response: %w", err)
+	}
+	return nil
+}
+
+// responseError turns an error response into an Error, using the message of
+// the JSON error body when there is one
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+
+	var payload struct {
+		Error string `json:"error"`
+	}
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &payload) == nil && payload.Error != "" {
+		message = payload.Error
+	}
+	return &Error{StatusCode: resp.StatusCode, Status: resp.Status, Message: message, body: body}
+}
@@ -0,0 +1,73 @@
+// app/client/jobs.go
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// jobPollInterval is how often WaitJob checks a running job
+const jobPollInterval = time.Second
+
+// Job is a background job of the server
+type Job struct {
+	ID         string          `json:"id"`
+	Kind       string          `json:"kind"`
+	Status     string          `json:"status"`
+	Error      string          `json:"error,omitempty"`
+	QueuedAt   time.Time       `json:"queuedAt"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt time.Time       `json:"finishedAt"`
+	Result     json.RawMessage `json:"result,omitempty"`
+}
+
+// Done reports whether the job finished
+func (j *Job) Done() bool {
+	return j.Status == "succeeded" || j.Status == "failed"
+}
+
+// GetJob returns the status of a job
+func (c *Client) GetJob(ctx context.Context, id string) (*Job, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/jobs/"+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	var job Job
+	if err := c.do(req, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// WaitJob polls a job until it finishes and decodes the result of a succeeded
+// job into out; a failed job returns its error
+func (c *Client) WaitJob(ctx context.Context, id string, out interface{}) error {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+
+	for {
+		job, err := c.GetJob(ctx, id)
+		if err != nil {
+			return err
+		}
+		if job.Status == "failed" {
+			return errors.New(job.Error)
+		}
+		if job.Done() {
+			if out == nil || len(job.Result) == 0 {
+				return nil
+			}
+			return json.Unmarshal(job.Result, out)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// app/client/reports.go
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// Report is a stored report as returned by the report listing
+type Report struct {
+	ID           string    `json:"id"`
+	Filename     string    `json:"filename"`
+	UploadedAt   time.Time `json:"uploadedAt"`
+	Cluster      string    `json:"cluster,omitempty"`
+	ClusterName  string    `json:"clusterName"`
+	CustomerName string    `json:"customerName"`
+	OverallScore float64   `json:"overallScore"`
+	ReviewStatus string    `json:"reviewStatus,omitempty"`
+
+	// SignatureStatus is the verification status of the report's signature
+	SignatureStatus string `json:"signatureStatus,omitempty"`
+}
+
+// ReportFilter narrows the report listing; empty fields match everything
+type ReportFilter struct {
+	Customer string
+	Cluster  string
+}
+
+// UploadOptions are the optional settings of an upload
+type UploadOptions struct {
+	// Cluster is the registered cluster the report belongs to
+	Cluster string
+
+	// Scoring is the scoring preset to score the report with
+	Scoring string
+
+	// Parser is the parser profile of the report dialect; empty detects it
+	Parser string
+
+	// Signature is a detached GPG or sigstore signature of the document
+	Signature []byte
+
+	// Async parses the report in a background job, which UploadReport polls
+	// until it finishes, so slow parses don't hold a request open
+	Async bool
+}
+
+// UploadReport uploads a report document and returns its parsed summary
+func (c *Client) UploadReport(ctx context.Context, filename string, document io.Reader, options UploadOptions) (*types.ReportSummaryV2, error) {
+	// Stream the multipart body so large reports aren't held in memory
+	body, writer := io.Pipe()
+	form := multipart.NewWriter(writer)
+	go func() {
+		writer.CloseWithError(writeUploadForm(form, options, filename, document))
+	}()
+
+	req, err := c.newRequest(ctx, http.MethodPost, "/parse-report"+options.query(), body)
+	if err != nil {
+		body.Close()
+		return nil, err
+	}
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return c.parseRequest(ctx, req, options)
+}
+
+// query returns the query string of the parse options
+func (o UploadOptions) query() string {
+	query := url.Values{}
+	if o.Scoring != "" {
+		query.Set("scoring", o.Scoring)
+	}
+	if o.Parser != "" {
+		query.Set("parser", o.Parser)
+	}
+	if o.Async {
+		query.Set("async", "true")
+	}
+	if len(query) == 0 {
+		return ""
+	}
+	return "?" + query.Encode()
+}
+
+// parseRequest sends a request parsing an upload and returns the summary,
+// waiting for the job of an asynchronous upload
+func (c *Client) parseRequest(ctx context.Context, req *http.Request, options UploadOptions) (*types.ReportSummaryV2, error) {
+	var summary types.ReportSummaryV2
+	if !options.Async {
+		if err := c.do(req, &summary); err != nil {
+			return nil, err
+		}
+		return &summary, nil
+	}
+
+	var job Job
+	if err := c.do(req, &job); err != nil {
+		return nil, err
+	}
+	if err := c.WaitJob(ctx, job.ID, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// writeUploadForm writes the cluster field, the signature and the report file
+// of an upload; the report comes last so the server knows the others before
+// the file arrives
+func writeUploadForm(form *multipart.Writer, options UploadOptions, filename string, document io.Reader) error {
+	if options.Cluster != "" {
+		if err := form.WriteField("cluster", options.Cluster); err != nil {
+			return err
+		}
+	}
+	if len(options.Signature) > 0 {
+		part, err := form.CreateFormFile("signature", filename+".sig")
+		if err != nil {
+			return err
+		}
+		if _, err := part.Write(options.Signature); err != nil {
+			return err
+		}
+	}
+
+	part, err := form.CreateFormFile("report", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, document); err != nil {
+		return err
+	}
+	return form.Close()
+}
+
+// ListReports returns the stored reports, newest first
+func (c *Client) ListReports(ctx context.Context, filter ReportFilter) ([]Report, error) {
+	query := url.Values{}
+	if filter.Customer != "" {
+		query.Set("customer", filter.Customer)
+	}
+	if filter.Cluster != "" {
+		query.Set("cluster", filter.Cluster)
+	}
+	path := "/reports"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+
+	req, err := c.newRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	var reports []Report
+	if err := c.do(req, &reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
+// Scan runs a live scan of a registered cluster and returns the stored summary
+func (c *Client) Scan(ctx context.Context, cluster string) (*types.ReportSummary, error) {
+	body, err := json.Marshal(map[string]string{"cluster": cluster})
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "/scan", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var summary types.ReportSummary
+	if err := c.do(req, &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
@@ -0,0 +1,134 @@
+// app/client/uploads.go
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// DefaultChunkSize is the chunk size of UploadReportChunked when none is given
+const DefaultChunkSize = 4 << 20
+
+// chunkRetries is how often a chunk is retried before the upload fails; the
+// wait doubles after each attempt
+const chunkRetries = 6
+
+// UploadSession is a chunked upload in progress
+type UploadSession struct {
+	ID        string    `json:"id"`
+	Filename  string    `json:"filename"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+	Cluster   string    `json:"cluster,omitempty"`
+	Offset    int64     `json:"offset"`
+	Complete  bool      `json:"complete"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// UploadReportChunked uploads a report document in chunks, resuming from the
+// offset the dashboard received after a failed chunk, and returns its parsed
+// summary. It suits large documents sent over unreliable connections.
+func (c *Client) UploadReportChunked(ctx context.Context, filename string, document io.ReaderAt, size int64, options UploadOptions, chunkSize int64) (*types.ReportSummaryV2, error) {
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	digest := sha256.New()
+	if _, err := io.Copy(digest, io.NewSectionReader(document, 0, size)); err != nil {
+		return nil, err
+	}
+
+	create, err := json.Marshal(map[string]interface{}{
+		"filename":  filename,
+		"size":      size,
+		"sha256":    hex.EncodeToString(digest.Sum(nil)),
+		"cluster":   options.Cluster,
+		"signature": options.Signature,
+	})
+	if err != nil {
+		return nil, err
+	}
+	req, err := c.newRequest(ctx, http.MethodPost, "/uploads", bytes.NewReader(create))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	var session UploadSession
+	if err := c.do(req, &session); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; session.Offset < size; {
+		next, err := c.sendChunk(ctx, session.ID, document, session.Offset, min(chunkSize, size-session.Offset))
+		if err == nil {
+			session, attempt = *next, 0
+			continue
+		}
+
+		// Client errors other than a wrong offset won't go away by retrying
+		var apiErr *Error
+		if errors.As(err, &apiErr) && apiErr.StatusCode < 500 && apiErr.StatusCode != http.StatusConflict {
+			return nil, err
+		}
+		if attempt++; attempt > chunkRetries {
+			return nil, fmt.Errorf("upload %s failed at offset %d: %w", session.ID, session.Offset, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Duration(1<<(attempt-1)) * time.Second):
+		}
+
+		// Resume from what the dashboard actually received
+		if current, err := c.GetUpload(ctx, session.ID); err == nil {
+			session = *current
+		}
+	}
+
+	req, err = c.newRequest(ctx, http.MethodPost, "/uploads/"+session.ID+"/complete"+options.query(), nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.parseRequest(ctx, req, options)
+}
+
+// GetUpload returns a chunked upload with the offset to resume from
+func (c *Client) GetUpload(ctx context.Context, id string) (*UploadSession, error) {
+	req, err := c.newRequest(ctx, http.MethodGet, "/uploads/"+id, nil)
+	if err != nil {
+		return nil, err
+	}
+	var session UploadSession
+	if err := c.do(req, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// sendChunk sends length bytes of the document from offset
+func (c *Client) sendChunk(ctx context.Context, id string, document io.ReaderAt, offset, length int64) (*UploadSession, error) {
+	req, err := c.newRequest(ctx, http.MethodPatch, "/uploads/"+id, io.NewSectionReader(document, offset, length))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = length
+	req.Header.Set("Content-Type", "application/offset+octet-stream")
+	req.Header.Set("Upload-Offset", strconv.FormatInt(offset, 10))
+
+	var session UploadSession
+	if err := c.do(req, &session); err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
@@ -0,0 +1,265 @@
+// app/server/alerting/alerting.go
+package alerting
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// Default API endpoints
+const (
+	DefaultPagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+	DefaultOpsgenieURL  = "https://api.opsgenie.com/v2/alerts"
+)
+
+// Config holds the alert rules and the services they page
+type Config struct {
+	Rules     []Rule            `yaml:"rules"`
+	PagerDuty []PagerDutyTarget `yaml:"pagerduty"`
+	Opsgenie  []OpsgenieTarget  `yaml:"opsgenie"`
+}
+
+// Enabled reports whether any rule can page a target
+func (c Config) Enabled() bool {
+	return len(c.Rules) > 0 && len(c.PagerDuty)+len(c.Opsgenie) > 0
+}
+
+// Validate checks the rules and that every target they name exists
+func (c Config) Validate() error {
+	names := make(map[string]bool)
+	for _, target := range c.targets() {
+		if target.name() == "" {
+			return fmt.Errorf("an alert target has no name")
+		}
+		if names[target.name()] {
+			return fmt.Errorf("alert target %q is defined twice", target.name())
+		}
+		names[target.name()] = true
+	}
+
+	seen := make(map[string]bool)
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		if seen[rule.Name] {
+			return fmt.Errorf("alert rule %q is defined twice", rule.Name)
+		}
+		seen[rule.Name] = true
+		for _, target := range rule.Targets {
+			if !names[target] {
+				return fmt.Errorf("alert rule %q pages unknown target %q", rule.Name, target)
+			}
+		}
+	}
+	return nil
+}
+
+// target delivers alerts to one service
+type target interface {
+	name() string
+	send(ctx context.Context, alert Alert) error
+}
+
+// targets lists the configured targets
+func (c Config) targets() []target {
+	var targets []target
+	for _, t := range c.PagerDuty {
+		targets = append(targets, t)
+	}
+	for _, t := range c.Opsgenie {
+		targets = append(targets, t)
+	}
+	return targets
+}
+
+// Evaluate returns the alerts the rules raise for a report
+func (c Config) Evaluate(input Input) []Alert {
+	var alerts []Alert
+	for i := range c.Rules {
+		if alert, ok := c.Rules[i].Evaluate(input); ok {
+			alerts = append(alerts, alert)
+		}
+	}
+	return alerts
+}
+
+// Send pages the targets of every alert concurrently, logging failures
+func (c Config) Send(ctx context.Context, alerts []Alert) {
+	var wg sync.WaitGroup
+	for _, alert := range alerts {
+		for _, t := range c.targets() {
+			if !paged(alert, t.name()) {
+				continue
+			}
+			wg.Add(1)
+			go func(t target, alert Alert) {
+				defer wg.Done()
+				if err := t.send(ctx, alert); err != nil {
+					log.Printf("Error sending alert %s for report %s to %s: %v", alert.Rule, alert.ReportID, t.name(), err)
+					return
+				}
+				log.Printf("Sent alert %s for report %s to %s", alert.Rule, alert.ReportID, t.name())
+			}(t, alert)
+		}
+	}
+	wg.Wait()
+}
+
+// paged reports whether an alert goes to the named target
+func paged(alert Alert, name string) bool {
+	if len(alert.targets) == 0 {
+		return true
+	}
+	for _, target := range alert.targets {
+		if target == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PagerDutyTarget triggers incidents through the PagerDuty Events API v2
+type PagerDutyTarget struct {
+	Name       string `yaml:"name"`
+	RoutingKey string `yaml:"routingKey"`
+
+	// URL is the Events API endpoint, DefaultPagerDutyURL when empty
+	URL string `yaml:"url"`
+}
+
+func (t PagerDutyTarget) name() string {
+	return t.Name
+}
+
+// send triggers an incident, deduplicated per rule and cluster
+func (t PagerDutyTarget) send(ctx context.Context, alert Alert) error {
+	event := map[string]interface{}{
+		"routing_key":  t.RoutingKey,
+		"event_action": "trigger",
+		"dedup_key":    alert.DedupKey(),
+		"payload": map[string]interface{}{
+			"summary":        truncate(alert.Summary, 1024),
+			"source":         alert.Cluster,
+			"severity":       alert.Severity,
+			"timestamp":      alert.Time.UTC().Format(time.RFC3339),
+			"component":      "openshift-health-dashboard",
+			"group":          alert.Customer,
+			"class":          alert.Rule,
+			"custom_details": alert,
+		},
+	}
+	if alert.ReportURL != "" {
+		event["links"] = []map[string]string{{"href": alert.ReportURL, "text": "Health check report"}}
+	}
+	return post(ctx, orDefault(t.URL, DefaultPagerDutyURL), nil, event)
+}
+
+// OpsgenieTarget creates alerts through the Opsgenie Alert API
+type OpsgenieTarget struct {
+	Name   string `yaml:"name"`
+	APIKey string `yaml:"apiKey"`
+
+	// URL is the alert endpoint, DefaultOpsgenieURL when empty; EU accounts
+	// use https://api.eu.opsgenie.com/v2/alerts
+	URL string `yaml:"url"`
+}
+
+func (t OpsgenieTarget) name() string {
+	return t.Name
+}
+
+// opsgeniePriorities maps alert severities to Opsgenie priorities
+var opsgeniePriorities = map[string]string{
+	SeverityCritical: "P1",
+	SeverityError:    "P2",
+	SeverityWarning:  "P3",
+	SeverityInfo:     "P5",
+}
+
+// send creates an alert; Opsgenie adds repeats with the same alias to the open alert
+func (t OpsgenieTarget) send(ctx context.Context, alert Alert) error {
+	details := map[string]string{
+		"cluster": alert.Cluster,
+		"report":  alert.ReportID,
+		"score":   fmt.Sprintf("%.1f", alert.Score),
+	}
+	if alert.Previous != nil {
+		details["previousScore"] = fmt.Sprintf("%.1f", *alert.Previous)
+	}
+	if alert.ReportURL != "" {
+		details["reportUrl"] = alert.ReportURL
+	}
+
+	body := map[string]interface{}{
+		"message":     truncate(alert.Summary, 130),
+		"alias":       alert.DedupKey(),
+		"description": strings.Join(alert.Reasons, "\n"),
+		"priority":    opsgeniePriorities[alert.Severity],
+		"source":      "openshift-health-dashboard",
+		"entity":      alert.Cluster,
+		"tags":        []string{"health-dashboard", alert.Rule},
+		"details":     details,
+	}
+	header := http.Header{"Authorization": {"GenieKey " + t.APIKey}}
+	return post(ctx, orDefault(t.URL, DefaultOpsgenieURL), header, body)
+}
+
+// httpClient is shared by all targets
+var httpClient = &http.Client{Timeout: 15 * time.Second, Transport: tracing.Transport(nil)}
+
+// post posts a JSON body and treats any non-2xx response as an error
+func post(ctx context.Context, url string, header http.Header, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error encoding alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting alert: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("target returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
+
+// orDefault returns value, or fallback when it is empty
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// truncate shortens a text to the length limit of a field
+func truncate(text string, limit int) string {
+	if runes := []rune(text); len(runes) > limit {
+		return string(runes[:limit-1]) + "…"
+	}
+	return text
+}
@@ -0,0 +1,252 @@
+// app/server/alerting/rules.go
+
+// Package alerting pages on-call teams when a new report of a cluster
+// regresses. Rules compare a report with the previous report of the same
+// cluster, e.g. "the overall score dropped by more than 10 points" or "there
+// is a new required finding in Security", and the alerts they raise are sent
+// to PagerDuty or Opsgenie.
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// Alert severities, in the terms of the PagerDuty Events API
+const (
+	SeverityCritical = "critical"
+	SeverityError    = "error"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// severities are the accepted rule severities
+var severities = []string{SeverityCritical, SeverityError, SeverityWarning, SeverityInfo}
+
+// Rule raises an alert when any of its conditions holds for a new report
+type Rule struct {
+	Name string `yaml:"name"`
+
+	// Clusters limits the rule to these clusters; empty applies it to every cluster
+	Clusters []string `yaml:"clusters"`
+
+	// ScoreDrop fires when the overall score dropped by more than this many
+	// points since the previous report of the cluster
+	ScoreDrop float64 `yaml:"scoreDrop"`
+
+	// ScoreBelow fires when the overall score is below this value
+	ScoreBelow float64 `yaml:"scoreBelow"`
+
+	// NewRequired fires when a finding is required that wasn't required in the
+	// previous report; Categories limits it to findings of these categories
+	NewRequired bool     `yaml:"newRequired"`
+	Categories  []string `yaml:"categories"`
+
+	// Severity is the severity of the alert, "error" by default
+	Severity string `yaml:"severity"`
+
+	// Targets names the PagerDuty and Opsgenie targets paged; empty pages all of them
+	Targets []string `yaml:"targets"`
+}
+
+// Validate checks that a rule has a name and a condition
+func (r *Rule) Validate() error {
+	if strings.TrimSpace(r.Name) == "" {
+		return errors.New("an alert rule has no name")
+	}
+	if r.ScoreDrop <= 0 && r.ScoreBelow <= 0 && !r.NewRequired {
+		return fmt.Errorf("alert rule %q has no condition; set scoreDrop, scoreBelow or newRequired", r.Name)
+	}
+	if len(r.Categories) > 0 && !r.NewRequired {
+		return fmt.Errorf("alert rule %q lists categories without newRequired", r.Name)
+	}
+	if r.Severity != "" && !isSeverity(r.Severity) {
+		return fmt.Errorf("alert rule %q has severity %q, expected one of %s",
+			r.Name, r.Severity, strings.Join(severities, ", "))
+	}
+	return nil
+}
+
+// Input is a newly stored report with the previous report of its cluster
+type Input struct {
+	Cluster   string
+	Customer  string
+	ReportID  string
+	ReportURL string
+	Time      time.Time
+
+	Score    float64
+	Findings []types.Finding
+
+	// Previous is nil for the first report of a cluster
+	Previous *Previous
+}
+
+// Previous is the report a new report is compared with
+type Previous struct {
+	ReportID string
+	Score    float64
+	Findings []types.Finding
+}
+
+// Alert is a rule that fired for a report
+type Alert struct {
+	Rule      string    `json:"rule"`
+	Severity  string    `json:"severity"`
+	Summary   string    `json:"summary"`
+	Reasons   []string  `json:"reasons"`
+	Cluster   string    `json:"cluster"`
+	Customer  string    `json:"customer,omitempty"`
+	ReportID  string    `json:"reportId"`
+	ReportURL string    `json:"reportUrl,omitempty"`
+	Time      time.Time `json:"time"`
+
+	// Score is the overall score, Previous the score of the previous report
+	Score    float64  `json:"score"`
+	Previous *float64 `json:"previousScore,omitempty"`
+
+	// NewRequired lists the titles of the newly required findings
+	NewRequired []string `json:"newRequired,omitempty"`
+
+	// targets are the target names of the rule
+	targets []string
+}
+
+// DedupKey identifies the incident of a rule and cluster, so repeated
+// regressions update one incident instead of opening another
+func (a Alert) DedupKey() string {
+	return "health-dashboard/" + a.Rule + "/" + strings.ToLower(a.Cluster)
+}
+
+// Evaluate returns the alert a rule raises for a report, if any
+func (r *Rule) Evaluate(input Input) (Alert, bool) {
+	if !r.selects(input.Cluster) {
+		return Alert{}, false
+	}
+
+	alert := Alert{
+		Rule:      r.Name,
+		Severity:  r.Severity,
+		Cluster:   input.Cluster,
+		Customer:  input.Customer,
+		ReportID:  input.ReportID,
+		ReportURL: input.ReportURL,
+		Time:      input.Time,
+		Score:     input.Score,
+		targets:   r.Targets,
+	}
+	if alert.Severity == "" {
+		alert.Severity = SeverityError
+	}
+	if input.Previous != nil {
+		previous := input.Previous.Score
+		alert.Previous = &previous
+	}
+
+	if r.ScoreBelow > 0 && input.Score < r.ScoreBelow {
+		alert.Reasons = append(alert.Reasons, fmt.Sprintf("overall score %.1f%% is below %.1f%%", input.Score, r.ScoreBelow))
+	}
+	if r.ScoreDrop > 0 && input.Previous != nil {
+		if drop := input.Previous.Score - input.Score; drop > r.ScoreDrop {
+			alert.Reasons = append(alert.Reasons, fmt.Sprintf("overall score dropped by %.1f points from %.1f%% to %.1f%%",
+				drop, input.Previous.Score, input.Score))
+		}
+	}
+	if r.NewRequired {
+		alert.NewRequired = r.newRequired(input)
+		if len(alert.NewRequired) > 0 {
+			alert.Reasons = append(alert.Reasons, fmt.Sprintf("%d new required %s: %s", len(alert.NewRequired),
+				plural(len(alert.NewRequired), "finding", "findings"), strings.Join(alert.NewRequired, ", ")))
+		}
+	}
+	if len(alert.Reasons) == 0 {
+		return Alert{}, false
+	}
+
+	alert.Summary = fmt.Sprintf("Health of %s regressed: %s", input.Cluster, alert.Reasons[0])
+	if len(alert.Reasons) > 1 {
+		alert.Summary += fmt.Sprintf(" (and %d more)", len(alert.Reasons)-1)
+	}
+	return alert, true
+}
+
+// newRequired returns the titles of the required findings in the rule's
+// categories that weren't required in the previous report; without a previous
+// report every required finding is new
+func (r *Rule) newRequired(input Input) []string {
+	before := make(map[string]bool)
+	if input.Previous != nil {
+		for _, finding := range input.Previous.Findings {
+			if finding.Status == types.ResultKeyRequired {
+				before[findingKey(finding)] = true
+			}
+		}
+	}
+
+	var titles []string
+	for _, finding := range input.Findings {
+		if finding.Status != types.ResultKeyRequired || before[findingKey(finding)] || !r.inCategories(finding.Category) {
+			continue
+		}
+		titles = append(titles, finding.Title)
+	}
+	sort.Strings(titles)
+	return titles
+}
+
+// selects reports whether the rule applies to a cluster
+func (r *Rule) selects(cluster string) bool {
+	if len(r.Clusters) == 0 {
+		return true
+	}
+	for _, selected := range r.Clusters {
+		if strings.EqualFold(strings.TrimSpace(selected), strings.TrimSpace(cluster)) {
+			return true
+		}
+	}
+	return false
+}
+
+// inCategories reports whether a finding category is one the rule watches
+func (r *Rule) inCategories(category string) bool {
+	if len(r.Categories) == 0 {
+		return true
+	}
+	for _, watched := range r.Categories {
+		if strings.EqualFold(watched, category) {
+			return true
+		}
+	}
+	return false
+}
+
+// findingKey identifies a finding across reports
+func findingKey(finding types.Finding) string {
+	if finding.ID != "" {
+		return strings.ToLower(finding.ID)
+	}
+	return strings.ToLower(strings.TrimSpace(finding.Title))
+}
+
+// isSeverity reports whether a severity is one of the accepted ones
+func isSeverity(severity string) bool {
+	for _, known := range severities {
+		if severity == known {
+			return true
+		}
+	}
+	return false
+}
+
+// plural picks the singular or plural form for a count
+func plural(count int, singular, pluralForm string) string {
+	if count == 1 {
+		return singular
+	}
+	return pluralForm
+}
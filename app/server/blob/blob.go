@@ -0,0 +1,126 @@
+// app/server/blob/blob.go
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotFound is returned when a blob does not exist
+var ErrNotFound = errors.New("blob not found")
+
+// Backend stores opaque binary objects by key
+type Backend interface {
+	// Put streams r into the object named key and returns the number of bytes written
+	Put(ctx context.Context, key string, r io.Reader) (int64, error)
+
+	// Open returns a reader for the object named key
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the object named key
+	Delete(ctx context.Context, key string) error
+}
+
+// FileBackend stores blobs as files below a root directory
+type FileBackend struct {
+	root string
+}
+
+// NewFileBackend creates a filesystem backend rooted at dir
+func NewFileBackend(dir string) (*FileBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating blob directory: %w", err)
+	}
+	return &FileBackend{root: dir}, nil
+}
+
+// Put streams r to a temporary file and moves it into place once complete
+func (b *FileBackend) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, fmt.Errorf("error creating blob directory: %w", err)
+	}
+
+	tempFile, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return 0, fmt.Errorf("error creating blob: %w", err)
+	}
+	defer os.Remove(tempFile.Name())
+	defer tempFile.Close()
+
+	written, err := io.Copy(tempFile, contextReader{ctx: ctx, r: r})
+	if err != nil {
+		return written, fmt.Errorf("error writing blob: %w", err)
+	}
+
+	if err := tempFile.Close(); err != nil {
+		return written, fmt.Errorf("error writing blob: %w", err)
+	}
+	if err := os.Rename(tempFile.Name(), path); err != nil {
+		return written, fmt.Errorf("error writing blob: %w", err)
+	}
+
+	return written, nil
+}
+
+// Open returns a reader for the blob file
+func (b *FileBackend) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening blob: %w", err)
+	}
+	return file, nil
+}
+
+// Delete removes the blob file, ignoring blobs that don't exist
+func (b *FileBackend) Delete(ctx context.Context, key string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error deleting blob: %w", err)
+	}
+	return nil
+}
+
+// path maps a key to a file below the root, rejecting keys that escape it
+func (b *FileBackend) path(key string) (string, error) {
+	clean := filepath.Clean("/" + key)
+	if key == "" || strings.Contains(key, "..") || clean == "/" {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+	return filepath.Join(b.root, clean), nil
+}
+
+// contextReader stops a copy once its context is cancelled
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read reads from the underlying reader unless the context is done
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,69 @@
+// app/server/blob/sql.go
+package blob
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// SQLBackend stores blobs as rows of a SQL database. Blobs are read into
+// memory whole, which suits the report documents of a local installation.
+type SQLBackend struct {
+	db *sql.DB
+}
+
+// NewSQLBackend creates the blob table in db if needed
+func NewSQLBackend(db *sql.DB) (*SQLBackend, error) {
+	_, err := db.Exec(`CREATE TABLE IF NOT EXISTS blobs (
+		key  TEXT PRIMARY KEY,
+		data BLOB NOT NULL
+	)`)
+	if err != nil {
+		return nil, fmt.Errorf("error creating blob table: %w", err)
+	}
+	return &SQLBackend{db: db}, nil
+}
+
+// Put reads r completely and stores it in a single row
+func (b *SQLBackend) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	if key == "" {
+		return 0, fmt.Errorf("invalid blob key: %q", key)
+	}
+
+	data, err := io.ReadAll(contextReader{ctx: ctx, r: r})
+	if err != nil {
+		return int64(len(data)), fmt.Errorf("error writing blob: %w", err)
+	}
+
+	_, err = b.db.ExecContext(ctx, `INSERT INTO blobs (key, data) VALUES (?, ?)
+		ON CONFLICT (key) DO UPDATE SET data = excluded.data`, key, data)
+	if err != nil {
+		return int64(len(data)), fmt.Errorf("error writing blob: %w", err)
+	}
+	return int64(len(data)), nil
+}
+
+// Open returns a reader for the blob row
+func (b *SQLBackend) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	var data []byte
+	err := b.db.QueryRowContext(ctx, `SELECT data FROM blobs WHERE key = ?`, key).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening blob: %w", err)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Delete removes the blob row, ignoring blobs that don't exist
+func (b *SQLBackend) Delete(ctx context.Context, key string) error {
+	if _, err := b.db.ExecContext(ctx, `DELETE FROM blobs WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("error deleting blob: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// app/server/blob/traced.go
+package blob
+
+import (
+	"context"
+	"io"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// tracedBackend records a span for every call to a backend
+type tracedBackend struct {
+	backend Backend
+}
+
+// Traced wraps a backend so its calls show up in traces
+func Traced(backend Backend) Backend {
+	return tracedBackend{backend: backend}
+}
+
+// Put stores an object in a "blob.Put" span
+func (t tracedBackend) Put(ctx context.Context, key string, r io.Reader) (int64, error) {
+	ctx, span := tracing.Start(ctx, "blob.Put", attribute.String("blob.key", key))
+	size, err := t.backend.Put(ctx, key, r)
+	span.SetAttributes(attribute.Int64("blob.size", size))
+	tracing.End(span, err)
+	return size, err
+}
+
+// Open opens an object in a "blob.Open" span; reading it is not part of the span
+func (t tracedBackend) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	ctx, span := tracing.Start(ctx, "blob.Open", attribute.String("blob.key", key))
+	reader, err := t.backend.Open(ctx, key)
+	tracing.End(span, err)
+	return reader, err
+}
+
+// Delete removes an object in a "blob.Delete" span
+func (t tracedBackend) Delete(ctx context.Context, key string) error {
+	ctx, span := tracing.Start(ctx, "blob.Delete", attribute.String("blob.key", key))
+	err := t.backend.Delete(ctx, key)
+	tracing.End(span, err)
+	return err
+}
@@ -0,0 +1,318 @@
+// app/server/branding/branding.go
+
+// Package branding holds the logo, colors, footer and classification banner
+// applied to the HTML pages, published pages and notifications of the
+// dashboard. Like the template packs, the branding is kept in the blob
+// backend so every replica applies the same one.
+package branding
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/blob"
+)
+
+// Blob keys of the settings and the logo
+const (
+	settingsKey = "branding/branding.json"
+	logoKey     = "branding/logo"
+)
+
+// MaxLogoSize is the largest logo accepted, in bytes; logos are embedded in
+// pages, so they should stay small
+const MaxLogoSize = 256 << 10
+
+// Default colors of the pages
+const (
+	DefaultPrimary    = "#151515"
+	DefaultAccent     = "#0066cc"
+	DefaultBackground = "#f5f6f8"
+)
+
+// ErrNoLogo is returned when no logo was uploaded
+var ErrNoLogo = errors.New("no logo uploaded")
+
+// logoTypes are the accepted logo content types
+var logoTypes = []string{"image/png", "image/jpeg", "image/gif", "image/svg+xml"}
+
+// colorPattern matches a hex color such as #0066cc
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Palette holds the colors of the HTML pages; empty colors use the defaults
+type Palette struct {
+	Primary    string `json:"primary,omitempty"`
+	Accent     string `json:"accent,omitempty"`
+	Background string `json:"background,omitempty"`
+}
+
+// Classification is a banner marking the sensitivity of the content, such as
+// "CONFIDENTIAL", shown above and below pages and in notifications
+type Classification struct {
+	Text  string `json:"text"`
+	Color string `json:"color,omitempty"`
+}
+
+// Branding is the branding applied to pages and notifications
+type Branding struct {
+	// Name is the organization shown next to the logo, e.g. "Acme Consulting"
+	Name    string  `json:"name,omitempty"`
+	Palette Palette `json:"palette"`
+	Footer  string  `json:"footer,omitempty"`
+
+	Classification *Classification `json:"classification,omitempty"`
+
+	// Logo describes the uploaded logo; it is set by uploading one
+	Logo *Logo `json:"logo,omitempty"`
+
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// Logo describes the uploaded logo
+type Logo struct {
+	ContentType string    `json:"contentType"`
+	Size        int       `json:"size"`
+	UploadedAt  time.Time `json:"uploadedAt"`
+}
+
+// Validate checks the colors and the lengths of the texts
+func (b *Branding) Validate() error {
+	colors := map[string]string{
+		"primary": b.Palette.Primary, "accent": b.Palette.Accent, "background": b.Palette.Background,
+	}
+	if b.Classification != nil {
+		colors["classification"] = b.Classification.Color
+		if strings.TrimSpace(b.Classification.Text) == "" {
+			return errors.New("the classification banner has no text")
+		}
+	}
+	for name, color := range colors {
+		if color != "" && !colorPattern.MatchString(color) {
+			return fmt.Errorf("the %s color %q is not a hex color like #0066cc", name, color)
+		}
+	}
+	if len(b.Name) > 100 || len(b.Footer) > 500 || b.Classification != nil && len(b.Classification.Text) > 100 {
+		return errors.New("the name and classification are limited to 100 characters, the footer to 500")
+	}
+	return nil
+}
+
+// Colors returns the palette with the defaults filled in
+func (b Branding) Colors() Palette {
+	palette := b.Palette
+	if palette.Primary == "" {
+		palette.Primary = DefaultPrimary
+	}
+	if palette.Accent == "" {
+		palette.Accent = DefaultAccent
+	}
+	if palette.Background == "" {
+		palette.Background = DefaultBackground
+	}
+	return palette
+}
+
+// ClassificationText returns the text of the classification banner, empty when there is none
+func (b Branding) ClassificationText() string {
+	if b.Classification == nil {
+		return ""
+	}
+	return b.Classification.Text
+}
+
+// ClassificationColor returns the color of the classification banner
+func (b Branding) ClassificationColor() string {
+	if b.Classification == nil || b.Classification.Color == "" {
+		return "#c9190b"
+	}
+	return b.Classification.Color
+}
+
+// Store persists the branding in the blob backend and caches it with the logo
+type Store struct {
+	blobs blob.Backend
+
+	mu       sync.RWMutex
+	branding Branding
+	logo     []byte
+}
+
+// New loads the branding stored in the blob backend
+func New(ctx context.Context, blobs blob.Backend) (*Store, error) {
+	s := &Store{blobs: blobs}
+	if err := s.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Reload re-reads the branding and the logo, keeping the current ones if they can't be read
+func (s *Store) Reload(ctx context.Context) error {
+	var branding Branding
+	data, err := s.read(ctx, settingsKey)
+	if err != nil {
+		return fmt.Errorf("error reading branding: %w", err)
+	}
+	if data != nil {
+		if err := json.Unmarshal(data, &branding); err != nil {
+			return fmt.Errorf("error parsing branding: %w", err)
+		}
+	}
+
+	var logo []byte
+	if branding.Logo != nil {
+		if logo, err = s.read(ctx, logoKey); err != nil {
+			return fmt.Errorf("error reading branding logo: %w", err)
+		}
+		if logo == nil {
+			branding.Logo = nil
+		}
+	}
+
+	s.mu.Lock()
+	s.branding = branding
+	s.logo = logo
+	s.mu.Unlock()
+	return nil
+}
+
+// Get returns the branding
+func (s *Store) Get() Branding {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.branding
+}
+
+// Update replaces the name, colors, footer and classification; the logo is kept
+func (s *Store) Update(ctx context.Context, branding Branding) (Branding, error) {
+	if err := branding.Validate(); err != nil {
+		return Branding{}, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	branding.Logo = s.branding.Logo
+	branding.UpdatedAt = &now
+	if err := s.saveLocked(ctx, branding); err != nil {
+		return Branding{}, err
+	}
+	return branding, nil
+}
+
+// SetLogo replaces the logo
+func (s *Store) SetLogo(ctx context.Context, contentType string, logo []byte) (Branding, error) {
+	if !isLogoType(contentType) {
+		return Branding{}, fmt.Errorf("logo type %q is not one of %s", contentType, strings.Join(logoTypes, ", "))
+	}
+	if len(logo) == 0 || len(logo) > MaxLogoSize {
+		return Branding{}, fmt.Errorf("logos must have between 1 and %d bytes", MaxLogoSize)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.blobs.Put(ctx, logoKey, bytes.NewReader(logo)); err != nil {
+		return Branding{}, fmt.Errorf("error saving branding logo: %w", err)
+	}
+
+	branding := s.branding
+	now := time.Now().UTC()
+	branding.Logo = &Logo{ContentType: contentType, Size: len(logo), UploadedAt: now}
+	branding.UpdatedAt = &now
+	if err := s.saveLocked(ctx, branding); err != nil {
+		return Branding{}, err
+	}
+	s.logo = logo
+	return branding, nil
+}
+
+// DeleteLogo removes the logo
+func (s *Store) DeleteLogo(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.branding.Logo == nil {
+		return ErrNoLogo
+	}
+
+	branding := s.branding
+	now := time.Now().UTC()
+	branding.Logo = nil
+	branding.UpdatedAt = &now
+	if err := s.saveLocked(ctx, branding); err != nil {
+		return err
+	}
+	s.logo = nil
+
+	if err := s.blobs.Delete(ctx, logoKey); err != nil && !errors.Is(err, blob.ErrNotFound) {
+		return fmt.Errorf("error deleting branding logo: %w", err)
+	}
+	return nil
+}
+
+// Logo returns the logo with its content type
+func (s *Store) Logo() ([]byte, string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.branding.Logo == nil {
+		return nil, "", ErrNoLogo
+	}
+	return s.logo, s.branding.Logo.ContentType, nil
+}
+
+// LogoDataURI returns the logo as a data URI to embed in pages, empty when there is none
+func (s *Store) LogoDataURI() string {
+	logo, contentType, err := s.Logo()
+	if err != nil {
+		return ""
+	}
+	return "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(logo)
+}
+
+// saveLocked writes the branding and makes it current; callers hold the lock
+func (s *Store) saveLocked(ctx context.Context, branding Branding) error {
+	data, err := json.MarshalIndent(branding, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding branding: %w", err)
+	}
+	if _, err := s.blobs.Put(ctx, settingsKey, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("error saving branding: %w", err)
+	}
+	s.branding = branding
+	return nil
+}
+
+// read returns the contents of a blob, nil when it doesn't exist
+func (s *Store) read(ctx context.Context, key string) ([]byte, error) {
+	reader, err := s.blobs.Open(ctx, key)
+	if errors.Is(err, blob.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// isLogoType reports whether a content type is an accepted logo type
+func isLogoType(contentType string) bool {
+	for _, accepted := range logoTypes {
+		if contentType == accepted {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+// app/server/bundle/bundle.go
+
+// Package bundle reads reports delivered as ZIP archives: the main AsciiDoc
+// document together with the documents it includes and the images it shows.
+// The includes are resolved into a single document the parser reads like any
+// upload, and the other files are kept as attachments of the report, so
+// exports can embed the original figures.
+package bundle
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+const (
+	// MaxFiles bounds the number of files of a bundle
+	MaxFiles = 1000
+
+	// MaxSize bounds the total uncompressed size of the files of a bundle
+	MaxSize = 256 << 20
+)
+
+// ErrInvalid is returned for archives that aren't usable report bundles
+var ErrInvalid = errors.New("invalid report bundle")
+
+// mainNames are the base names preferred for the main document when a bundle
+// has several documents that no other document includes
+var mainNames = []string{"index", "main", "master", "report"}
+
+// IsBundle checks if a filename has the extension of a report bundle
+func IsBundle(filename string) bool {
+	return strings.EqualFold(path.Ext(filename), ".zip")
+}
+
+// File is a file of a bundle
+type File struct {
+	Path        string
+	ContentType string
+	Size        int64
+
+	file *zip.File
+}
+
+// Open returns a reader for the contents of the file
+func (f File) Open() (io.ReadCloser, error) {
+	return f.file.Open()
+}
+
+// Bundle is an opened report bundle
+type Bundle struct {
+	// Main is the path of the main document
+	Main string
+
+	// Attachments are the files that aren't AsciiDoc documents, by path. The
+	// paths are relative to the directory of the main document, like the
+	// targets of its image macros, for the files below it.
+	Attachments []File
+
+	documents map[string]*zip.File
+}
+
+// Open reads the table of contents of a bundle and finds its main document
+func Open(r io.ReaderAt, size int64) (*Bundle, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	b := &Bundle{documents: make(map[string]*zip.File)}
+	var total uint64
+	files := 0
+	for _, file := range archive.File {
+		name, ok := cleanName(file.Name)
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is outside the bundle", ErrInvalid, file.Name)
+		}
+		if name == "" || file.FileInfo().IsDir() || ignored(name) {
+			continue
+		}
+
+		files++
+		total += file.UncompressedSize64
+		if files > MaxFiles || total > MaxSize {
+			return nil, fmt.Errorf("%w: more than %d files or %d bytes", ErrInvalid, MaxFiles, MaxSize)
+		}
+
+		if utils.IsValidAsciiDocFile(strings.ToLower(name)) {
+			b.documents[name] = file
+			continue
+		}
+		contentType := mime.TypeByExtension(strings.ToLower(path.Ext(name)))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		b.Attachments = append(b.Attachments, File{Path: name, ContentType: contentType, Size: int64(file.UncompressedSize64), file: file})
+	}
+	if b.Main, err = b.findMain(); err != nil {
+		return nil, err
+	}
+	b.relativize()
+	sort.Slice(b.Attachments, func(i, j int) bool { return b.Attachments[i].Path < b.Attachments[j].Path })
+	return b, nil
+}
+
+// Document returns the main document with its includes resolved against the
+// bundle, then against fallback if it's set, and the include targets missing
+// from both
+func (b *Bundle) Document(fallback utils.IncludeOpener) ([]byte, []string, error) {
+	content, err := b.read(b.Main)
+	if err != nil {
+		return nil, nil, err
+	}
+	document, unresolved, err := utils.ResolveIncludes(b.Main, content, utils.FallbackIncludes(b.read, fallback))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return document, unresolved, nil
+}
+
+// findMain returns the document no other document includes; of several, the
+// one closest to the root of the bundle, then the one with a conventional name
+func (b *Bundle) findMain() (string, error) {
+	included := make(map[string]bool)
+	for name := range b.documents {
+		content, err := b.read(name)
+		if err != nil {
+			return "", err
+		}
+		for _, target := range utils.IncludeTargets(name, content) {
+			included[target] = true
+		}
+	}
+
+	var candidates []string
+	for name := range b.documents {
+		if !included[name] {
+			candidates = append(candidates, name)
+		}
+	}
+	if len(candidates) == 0 {
+		return "", fmt.Errorf("%w: no main AsciiDoc document", ErrInvalid)
+	}
+
+	// Keep the documents closest to the root
+	sort.Slice(candidates, func(i, j int) bool {
+		di, dj := strings.Count(candidates[i], "/"), strings.Count(candidates[j], "/")
+		if di != dj {
+			return di < dj
+		}
+		return candidates[i] < candidates[j]
+	})
+	depth := strings.Count(candidates[0], "/")
+	shallowest := candidates[:0]
+	for _, name := range candidates {
+		if strings.Count(name, "/") == depth {
+			shallowest = append(shallowest, name)
+		}
+	}
+	if len(shallowest) == 1 {
+		return shallowest[0], nil
+	}
+
+	for _, preferred := range mainNames {
+		for _, name := range shallowest {
+			base := path.Base(name)
+			if strings.EqualFold(strings.TrimSuffix(base, path.Ext(base)), preferred) {
+				return name, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("%w: cannot tell the main document from %s", ErrInvalid, strings.Join(shallowest, ", "))
+}
+
+// relativize makes the paths of the attachments below the directory of the
+// main document relative to it, unless that makes two paths the same
+func (b *Bundle) relativize() {
+	dir := path.Dir(b.Main)
+	if dir == "." {
+		return
+	}
+
+	paths := make(map[string]bool, len(b.Attachments))
+	for _, file := range b.Attachments {
+		paths[file.Path] = true
+	}
+	for i, file := range b.Attachments {
+		relative, ok := strings.CutPrefix(file.Path, dir+"/")
+		if ok && !paths[relative] {
+			b.Attachments[i].Path = relative
+		}
+	}
+}
+
+// read returns the contents of a document of the bundle
+func (b *Bundle) read(name string) ([]byte, error) {
+	file, ok := b.documents[name]
+	if !ok {
+		return nil, fmt.Errorf("%s is not in the bundle", name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", name, err)
+	}
+	defer reader.Close()
+
+	var buf bytes.Buffer
+	if _, err := io.Copy(&buf, io.LimitReader(reader, MaxSize)); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", name, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// cleanName returns the slash-separated path of an archive entry relative to
+// the root of the bundle, and false for entries that would escape it
+func cleanName(name string) (string, bool) {
+	name = strings.ReplaceAll(name, "\\", "/")
+	if strings.HasPrefix(name, "/") {
+		return "", false
+	}
+	for _, element := range strings.Split(name, "/") {
+		if element == ".." {
+			return "", false
+		}
+	}
+	clean := path.Clean(name)
+	if clean == "." {
+		return "", true
+	}
+	return clean, true
+}
+
+// ignored checks for the metadata archivers add, such as __MACOSX folders and
+// dot files
+func ignored(name string) bool {
+	for _, element := range strings.Split(name, "/") {
+		if element == "__MACOSX" || strings.HasPrefix(element, ".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// app/server/clusters/credentials.go
+package clusters
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// Files looked up in a credentials directory. The layout matches a Kubernetes
+// secret mounted as a volume, so a secret with a "kubeconfig" key, or with
+// "token" and optional "ca.crt" keys, can be mounted as-is.
+const (
+	kubeconfigFile = "kubeconfig"
+	tokenFile      = "token"
+	caFile         = "ca.crt"
+)
+
+// loadCredentials builds a REST config for a cluster from its credentials reference
+func loadCredentials(dir string, cluster Cluster) (*rest.Config, error) {
+	if cluster.CredentialsRef == "" {
+		return nil, fmt.Errorf("cluster %s has no credentials reference", cluster.Name)
+	}
+	secretDir := filepath.Join(dir, cluster.CredentialsRef)
+
+	// A full kubeconfig takes precedence over a bare token
+	kubeconfig := filepath.Join(secretDir, kubeconfigFile)
+	if _, err := os.Stat(kubeconfig); err == nil {
+		restConfig, err := clientcmd.BuildConfigFromFlags(cluster.APIURL, kubeconfig)
+		if err != nil {
+			return nil, fmt.Errorf("error loading kubeconfig for cluster %s: %w", cluster.Name, err)
+		}
+		return restConfig, nil
+	}
+
+	token, err := os.ReadFile(filepath.Join(secretDir, tokenFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("credentials %q for cluster %s not found in %s", cluster.CredentialsRef, cluster.Name, dir)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading token for cluster %s: %w", cluster.Name, err)
+	}
+	if cluster.APIURL == "" {
+		return nil, fmt.Errorf("cluster %s needs an apiUrl to use a token", cluster.Name)
+	}
+
+	restConfig := &rest.Config{
+		Host:        cluster.APIURL,
+		BearerToken: strings.TrimSpace(string(token)),
+	}
+
+	// Without a CA bundle the system roots are used
+	ca := filepath.Join(secretDir, caFile)
+	if _, err := os.Stat(ca); err == nil {
+		restConfig.TLSClientConfig.CAFile = ca
+	}
+
+	return restConfig, nil
+}
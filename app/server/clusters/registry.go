@@ -0,0 +1,397 @@
+// app/server/clusters/registry.go
+package clusters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+)
+
+var (
+	// ErrNotFound is returned when a cluster is not registered
+	ErrNotFound = errors.New("cluster not found")
+
+	// ErrExists is returned when registering a cluster name that is already taken
+	ErrExists = errors.New("cluster already registered")
+
+	// ErrManaged is returned when changing a cluster declared in a
+	// HealthDashboard resource through the API
+	ErrManaged = errors.New("cluster is managed by a HealthDashboard resource")
+)
+
+// namePattern restricts cluster names to values that are safe in URLs and file names
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,62}$`)
+
+// clusterIDPattern matches an OpenShift cluster ID, a UUID
+var clusterIDPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// Cluster is a registered cluster. Credentials are never stored in the registry
+// itself; CredentialsRef names a secret mounted under the credentials directory.
+type Cluster struct {
+	Name           string            `json:"name"`
+	APIURL         string            `json:"apiUrl,omitempty"`
+	CredentialsRef string            `json:"credentialsRef,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+
+	// Org is the organization the cluster belongs to, if any
+	Org string `json:"org,omitempty"`
+
+	// InsightsID is the OpenShift cluster ID the cluster reports to Red Hat Insights under
+	InsightsID string `json:"insightsId,omitempty"`
+
+	// PrometheusURL is the Prometheus or Thanos querier endpoint the monitoring
+	// checks query, authenticated with the cluster's credentials
+	PrometheusURL string `json:"prometheusUrl,omitempty"`
+
+	// ManagedBy names the HealthDashboard resource that declares the cluster;
+	// such clusters change only when the resource does
+	ManagedBy string `json:"managedBy,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Registry holds the registered clusters and caches API clients for them
+type Registry struct {
+	path           string
+	credentialsDir string
+	limits         live.Limits
+
+	mu       sync.RWMutex
+	clusters map[string]*Cluster
+	clients  map[string]*live.Clients
+}
+
+// New loads the registry stored at path; credentialsDir holds one directory per
+// credentials reference and limits bound the API requests of each cluster's clients
+func New(path, credentialsDir string, limits live.Limits) (*Registry, error) {
+	r := &Registry{
+		path:           path,
+		credentialsDir: credentialsDir,
+		limits:         limits,
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload re-reads the registry file, replacing the registry contents only if
+// it parses; cached clients are dropped so changed credentials take effect
+func (r *Registry) Reload() error {
+	clusters := make(map[string]*Cluster)
+
+	data, err := os.ReadFile(r.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error reading cluster registry: %w", err)
+	}
+	if err == nil {
+		var list []*Cluster
+		if err := json.Unmarshal(data, &list); err != nil {
+			return fmt.Errorf("error parsing cluster registry: %w", err)
+		}
+		for _, cluster := range list {
+			clusters[key(cluster.Name)] = cluster
+		}
+		log.Printf("Loaded %d registered clusters from %s", len(clusters), r.path)
+	}
+
+	r.mu.Lock()
+	r.clusters = clusters
+	r.clients = make(map[string]*live.Clients)
+	r.mu.Unlock()
+	return nil
+}
+
+// List returns all registered clusters ordered by name
+func (r *Registry) List() []Cluster {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	clusters := make([]Cluster, 0, len(r.clusters))
+	for _, cluster := range r.clusters {
+		clusters = append(clusters, *cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return strings.ToLower(clusters[i].Name) < strings.ToLower(clusters[j].Name)
+	})
+	return clusters
+}
+
+// Get returns a registered cluster by name, ignoring case
+func (r *Registry) Get(name string) (Cluster, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cluster, ok := r.clusters[key(name)]
+	if !ok {
+		return Cluster{}, ErrNotFound
+	}
+	return *cluster, nil
+}
+
+// Resolve returns the registered spelling of a cluster name, or "" when it is not registered
+func (r *Registry) Resolve(name string) string {
+	if name == "" {
+		return ""
+	}
+	cluster, err := r.Get(strings.TrimSpace(name))
+	if err != nil {
+		return ""
+	}
+	return cluster.Name
+}
+
+// Create registers a new cluster
+func (r *Registry) Create(cluster Cluster) (Cluster, error) {
+	cluster.ManagedBy = ""
+	if err := Validate(&cluster); err != nil {
+		return Cluster{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.clusters[key(cluster.Name)]; ok {
+		return Cluster{}, ErrExists
+	}
+
+	cluster.CreatedAt = time.Now().UTC()
+	cluster.UpdatedAt = cluster.CreatedAt
+	r.clusters[key(cluster.Name)] = &cluster
+
+	if err := r.saveLocked(); err != nil {
+		delete(r.clusters, key(cluster.Name))
+		return Cluster{}, err
+	}
+	return cluster, nil
+}
+
+// Update replaces the connection details and labels of a registered cluster
+func (r *Registry) Update(name string, cluster Cluster) (Cluster, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.clusters[key(name)]
+	if !ok {
+		return Cluster{}, ErrNotFound
+	}
+	if existing.ManagedBy != "" {
+		return Cluster{}, ErrManaged
+	}
+
+	// Renaming would orphan the reports and events keyed by the old name
+	cluster.Name = existing.Name
+	if cluster.Org == "" {
+		cluster.Org = existing.Org
+	}
+	if err := Validate(&cluster); err != nil {
+		return Cluster{}, err
+	}
+	cluster.CreatedAt = existing.CreatedAt
+	cluster.UpdatedAt = time.Now().UTC()
+
+	r.clusters[key(name)] = &cluster
+	if err := r.saveLocked(); err != nil {
+		r.clusters[key(name)] = existing
+		return Cluster{}, err
+	}
+
+	// Connection details may have changed
+	delete(r.clients, key(name))
+	return cluster, nil
+}
+
+// Delete unregisters a cluster; its reports are kept
+func (r *Registry) Delete(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.clusters[key(name)]
+	if !ok {
+		return ErrNotFound
+	}
+	if existing.ManagedBy != "" {
+		return ErrManaged
+	}
+
+	delete(r.clusters, key(name))
+	if err := r.saveLocked(); err != nil {
+		r.clusters[key(name)] = existing
+		return err
+	}
+
+	delete(r.clients, key(name))
+	return nil
+}
+
+// Sync makes the clusters managed by manager match the desired ones: missing
+// clusters are created, changed ones updated and the rest removed. Clusters
+// registered through the API keep their definition; their names and invalid
+// definitions are reported in the returned error while the others are applied.
+func (r *Registry) Sync(manager string, desired []Cluster) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var problems []error
+	previous := make(map[string]*Cluster, len(r.clusters))
+	for k, cluster := range r.clusters {
+		previous[k] = cluster
+	}
+
+	now := time.Now().UTC()
+	wanted := make(map[string]bool, len(desired))
+	for _, cluster := range desired {
+		if err := Validate(&cluster); err != nil {
+			problems = append(problems, fmt.Errorf("cluster %q: %w", cluster.Name, err))
+			continue
+		}
+		existing, ok := r.clusters[key(cluster.Name)]
+		if ok && existing.ManagedBy != manager {
+			problems = append(problems, fmt.Errorf("cluster %q: %w", cluster.Name, ErrExists))
+			continue
+		}
+		wanted[key(cluster.Name)] = true
+
+		cluster.ManagedBy = manager
+		cluster.CreatedAt, cluster.UpdatedAt = now, now
+		if ok {
+			cluster.CreatedAt = existing.CreatedAt
+			if sameDefinition(*existing, cluster) {
+				continue
+			}
+		}
+		r.clusters[key(cluster.Name)] = &cluster
+		delete(r.clients, key(cluster.Name))
+	}
+
+	for k, cluster := range r.clusters {
+		if cluster.ManagedBy == manager && !wanted[k] {
+			delete(r.clusters, k)
+			delete(r.clients, k)
+		}
+	}
+
+	if err := r.saveLocked(); err != nil {
+		r.clusters = previous
+		r.clients = make(map[string]*live.Clients)
+		return err
+	}
+	return errors.Join(problems...)
+}
+
+// sameDefinition reports whether two clusters differ only in their timestamps
+func sameDefinition(a, b Cluster) bool {
+	a.CreatedAt, a.UpdatedAt = b.CreatedAt, b.UpdatedAt
+	return reflect.DeepEqual(a, b)
+}
+
+// Clients returns API clients for a registered cluster, connecting on first use
+func (r *Registry) Clients(name string) (*live.Clients, error) {
+	r.mu.RLock()
+	clients, ok := r.clients[key(name)]
+	cluster, registered := r.clusters[key(name)]
+	r.mu.RUnlock()
+
+	if ok {
+		return clients, nil
+	}
+	if !registered {
+		return nil, ErrNotFound
+	}
+
+	restConfig, err := loadCredentials(r.credentialsDir, *cluster)
+	if err != nil {
+		return nil, err
+	}
+	clients, err = live.NewClients(cluster.Name, restConfig, r.limits)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.PrometheusURL != "" {
+		if clients.Prometheus, err = live.NewPrometheus(cluster.PrometheusURL, restConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	r.mu.Lock()
+	r.clients[key(name)] = clients
+	r.mu.Unlock()
+	return clients, nil
+}
+
+// saveLocked writes the registry to disk; r.mu must be held
+func (r *Registry) saveLocked() error {
+	clusters := make([]*Cluster, 0, len(r.clusters))
+	for _, cluster := range r.clusters {
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return key(clusters[i].Name) < key(clusters[j].Name)
+	})
+
+	data, err := json.MarshalIndent(clusters, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding cluster registry: %w", err)
+	}
+
+	// Write to a temp file first so a crash never leaves a truncated registry
+	if err := os.WriteFile(r.path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("error writing cluster registry: %w", err)
+	}
+	if err := os.Rename(r.path+".tmp", r.path); err != nil {
+		return fmt.Errorf("error writing cluster registry: %w", err)
+	}
+	return nil
+}
+
+// Validate checks and normalizes a cluster definition
+func Validate(cluster *Cluster) error {
+	cluster.Name = strings.TrimSpace(cluster.Name)
+	if !namePattern.MatchString(cluster.Name) {
+		return errors.New("name must start with a letter or digit and contain only letters, digits, '.', '_' or '-'")
+	}
+
+	cluster.APIURL = strings.TrimSpace(cluster.APIURL)
+	if cluster.APIURL != "" {
+		parsed, err := url.Parse(cluster.APIURL)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return errors.New("apiUrl must be an https URL")
+		}
+	}
+
+	cluster.CredentialsRef = strings.TrimSpace(cluster.CredentialsRef)
+	if cluster.CredentialsRef != "" && !namePattern.MatchString(cluster.CredentialsRef) {
+		return errors.New("credentialsRef must be a plain secret name")
+	}
+
+	cluster.PrometheusURL = strings.TrimSpace(cluster.PrometheusURL)
+	if cluster.PrometheusURL != "" {
+		parsed, err := url.Parse(cluster.PrometheusURL)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+			return errors.New("prometheusUrl must be an https URL")
+		}
+	}
+
+	cluster.InsightsID = strings.ToLower(strings.TrimSpace(cluster.InsightsID))
+	if cluster.InsightsID != "" && !clusterIDPattern.MatchString(cluster.InsightsID) {
+		return errors.New("insightsId must be the cluster ID, a UUID")
+	}
+	return ValidateLabels(cluster.Labels)
+}
+
+// key normalizes a cluster name for lookups
+func key(name string) string {
+	return strings.ToLower(name)
+}
@@ -0,0 +1,190 @@
+// app/server/demo/demo.go
+
+// Package demo generates synthetic health checks of a small fleet, so the
+// dashboard can be explored without real reports. The data is deterministic:
+// every run produces the same clusters, trends and findings, placed relative
+// to the current time.
+package demo
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scanner"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// Reports per cluster and the time between two of them
+const (
+	reportsPerCluster = 6
+	reportInterval    = 14 * 24 * time.Hour
+)
+
+// Cluster is a synthetic cluster with the trend of its health
+type Cluster struct {
+	Name     string
+	Customer string
+	Labels   map[string]string
+
+	// from and to are the share of healthy items in the first and last report
+	from, to float64
+}
+
+// Report is a synthetic health check of a cluster at a point in time
+type Report struct {
+	Cluster  Cluster
+	Time     time.Time
+	Scan     *scanner.Scan
+	Filename string
+}
+
+// clusters are the synthetic fleet: one improving, one regressing, one steady
+// and one of another customer that is still poor
+var clusters = []Cluster{
+	{Name: "prod-east", Customer: "Acme Corp", Labels: map[string]string{"env": "production", "region": "us-east"}, from: 0.25, to: 0.9},
+	{Name: "prod-west", Customer: "Acme Corp", Labels: map[string]string{"env": "production", "region": "us-west"}, from: 0.85, to: 0.3},
+	{Name: "staging", Customer: "Acme Corp", Labels: map[string]string{"env": "staging", "region": "us-east"}, from: 0.65, to: 0.7},
+	{Name: "edge-lab", Customer: "Globex", Labels: map[string]string{"env": "development", "region": "eu-central"}, from: 0.05, to: 0.3},
+}
+
+// Clusters returns the synthetic clusters
+func Clusters() []Cluster {
+	return append([]Cluster(nil), clusters...)
+}
+
+// item is an evaluated item of the catalog with the status it has when it
+// isn't healthy
+type item struct {
+	category       string
+	title          string
+	status         types.ResultKey
+	healthy        string
+	unhealthy      string
+	recommendation string
+}
+
+// catalog holds the items every synthetic report evaluates
+var catalog = []item{
+	{scanner.CategoryClusterConfig, "Cluster version", types.ResultKeyRecommended,
+		"The cluster runs a supported OpenShift release with the latest z-stream.",
+		"The cluster is two z-streams behind the latest release of its channel.",
+		"Update the cluster to the latest z-stream of its channel."},
+	{scanner.CategoryClusterConfig, "Etcd backup", types.ResultKeyRequired,
+		"Etcd is backed up daily and backups are stored off-cluster.",
+		"No etcd backup has been taken in the last 7 days.",
+		"Schedule daily etcd backups and store them outside the cluster."},
+	{scanner.CategoryClusterConfig, "Time synchronization", types.ResultKeyRecommended,
+		"All nodes synchronize their clocks with the corporate NTP servers.",
+		"Nodes use the default public NTP pool and drift up to 2 seconds.",
+		"Configure chrony on all nodes with a MachineConfig pointing at internal NTP servers."},
+	{scanner.CategoryClusterConfig, "Infrastructure nodes", types.ResultKeyAdvisory,
+		"Router, registry and monitoring run on dedicated infrastructure nodes.",
+		"Infrastructure components share worker nodes with applications.",
+		"Add infrastructure nodes and move the router, registry and monitoring to them."},
+	{scanner.CategorySecurity, "Kubeadmin user", types.ResultKeyRequired,
+		"The kubeadmin user has been removed.",
+		"The kubeadmin user still exists and can log in.",
+		"Remove the kubeadmin secret once an identity provider is configured."},
+	{scanner.CategorySecurity, "Identity provider", types.ResultKeyRequired,
+		"Users log in through the corporate LDAP identity provider.",
+		"Only an htpasswd identity provider with shared accounts is configured.",
+		"Configure the corporate LDAP or OIDC identity provider and remove shared accounts."},
+	{scanner.CategorySecurity, "Network policies", types.ResultKeyRecommended,
+		"Every application namespace has a default-deny network policy.",
+		"Most application namespaces allow all ingress traffic.",
+		"Add a default-deny network policy to the project template and existing namespaces."},
+	{scanner.CategorySecurity, "Audit log policy", types.ResultKeyAdvisory,
+		"The audit policy records request bodies of write requests.",
+		"The default audit policy records metadata only.",
+		"Set the WriteRequestBodies audit profile if compliance requires it."},
+	{scanner.CategoryPerformance, "Node resource reservations", types.ResultKeyRecommended,
+		"System and kubelet resources are reserved on every node.",
+		"No system resources are reserved; nodes became NotReady under load.",
+		"Enable automatic node sizing with a KubeletConfig."},
+	{scanner.CategoryPerformance, "Etcd disk latency", types.ResultKeyRequired,
+		"The 99th percentile of etcd WAL fsync latency is below 10 ms.",
+		"The 99th percentile of etcd WAL fsync latency is 45 ms.",
+		"Move the control plane to storage with lower latency, such as local NVMe disks."},
+	{scanner.CategoryPerformance, "Cluster autoscaling", types.ResultKeyAdvisory,
+		"Machine autoscalers scale the worker pools with demand.",
+		"Worker pools have a fixed size.",
+		"Add a ClusterAutoscaler and MachineAutoscalers for the worker machine sets."},
+	{scanner.CategoryPerformance, "Resource quotas", types.ResultKeyRecommended,
+		"Application namespaces have resource quotas and limit ranges.",
+		"Application namespaces have no resource quotas.",
+		"Add quotas and limit ranges to the project template."},
+	{scanner.CategoryOpReady, "Monitoring storage", types.ResultKeyRecommended,
+		"Prometheus and Alertmanager use persistent storage.",
+		"Prometheus uses ephemeral storage and loses its data on restarts.",
+		"Configure persistent volume claims for Prometheus and Alertmanager."},
+	{scanner.CategoryOpReady, "Alert routing", types.ResultKeyRequired,
+		"Critical alerts are routed to the on-call team.",
+		"Alertmanager has no receivers; alerts are not delivered.",
+		"Configure Alertmanager receivers for critical and warning alerts."},
+	{scanner.CategoryOpReady, "Log forwarding", types.ResultKeyRecommended,
+		"Audit and infrastructure logs are forwarded to the central SIEM.",
+		"Logs are kept on the nodes only.",
+		"Deploy the logging operator and forward audit and infrastructure logs."},
+	{scanner.CategoryOpReady, "Runbooks", types.ResultKeyAdvisory,
+		"Runbooks exist for the critical alerts.",
+		"There are no runbooks for the critical alerts.",
+		"Write runbooks for the critical alerts and link them in the alert annotations."},
+	{scanner.CategoryApplications, "Liveness and readiness probes", types.ResultKeyRecommended,
+		"All deployments define liveness and readiness probes.",
+		"40% of deployments define no readiness probe.",
+		"Add readiness and liveness probes to every deployment."},
+	{scanner.CategoryApplications, "Pod disruption budgets", types.ResultKeyRecommended,
+		"Replicated applications have pod disruption budgets.",
+		"No application defines a pod disruption budget; upgrades cause outages.",
+		"Define pod disruption budgets for every replicated application."},
+	{scanner.CategoryApplications, "Image sources", types.ResultKeyRequired,
+		"Images are pulled from the internal registry only.",
+		"Several deployments pull images from public registries by the latest tag.",
+		"Mirror images to the internal registry and reference them by digest."},
+	{scanner.CategoryApplications, "Deprecated APIs", types.ResultKeyAdvisory,
+		"No workload uses APIs removed in the next release.",
+		"Two workloads use APIs removed in the next release.",
+		"Migrate the workloads to the current API versions before upgrading."},
+}
+
+// Reports returns the synthetic reports of every cluster, oldest first per
+// cluster; the last report of each cluster is about a day before now
+func Reports(now time.Time) []Report {
+	var reports []Report
+	for i, cluster := range clusters {
+		// Every cluster fixes its items in its own order
+		random := rand.New(rand.NewSource(int64(i + 1)))
+		thresholds := make([]float64, len(catalog))
+		for j := range thresholds {
+			thresholds[j] = random.Float64()
+		}
+
+		latest := now.UTC().Truncate(time.Hour).Add(-24*time.Hour - time.Duration(i*5)*time.Hour)
+		for point := 0; point < reportsPerCluster; point++ {
+			progress := float64(point) / float64(reportsPerCluster-1)
+			health := cluster.from + (cluster.to-cluster.from)*progress
+			at := latest.Add(-time.Duration(reportsPerCluster-1-point) * reportInterval)
+
+			scan := &scanner.Scan{ClusterName: cluster.Name, StartedAt: at, FinishedAt: at.Add(3 * time.Minute)}
+			for j, item := range catalog {
+				// Items wobble a little between reports, so steady clusters aren't flat
+				healthy := thresholds[j] < health+0.1*(random.Float64()-0.5)
+				result := scanner.Result{Category: item.category, Item: item.title, Status: types.ResultKeyNoChange, Observation: item.healthy}
+				if !healthy {
+					result.Status = item.status
+					result.Observation = item.unhealthy
+					result.Recommendation = item.recommendation
+				}
+				scan.Results = append(scan.Results, result)
+			}
+
+			reports = append(reports, Report{
+				Cluster:  cluster,
+				Time:     at,
+				Scan:     scan,
+				Filename: "demo-" + cluster.Name + "-" + at.Format("2006-01-02") + ".adoc",
+			})
+		}
+	}
+	return reports
+}
@@ -0,0 +1,140 @@
+// app/server/deploy/env.go
+
+// Package deploy generates the manifests that run the dashboard on OpenShift.
+// It lists every environment variable the server reads, so generated
+// deployments can be checked against the server's configuration surface;
+// add new variables here together with the code in main.go that reads them.
+package deploy
+
+import "sort"
+
+// Variable is an environment variable read by the server
+type Variable struct {
+	Name        string
+	Default     string
+	Description string
+
+	// Secret variables go into a Secret instead of the ConfigMap; the server
+	// also reads them from the file named by <NAME>_FILE
+	Secret bool
+}
+
+// Variables lists the environment variables of the server
+var Variables = []Variable{
+	{Name: "PORT", Default: "8080", Description: "Port to listen on"},
+	{Name: "BIND_ADDRESS", Description: "Interface to listen on; empty listens on all of them"},
+	{Name: "DEBUG", Default: "false", Description: "Log every request and parser decision"},
+	{Name: "PUBLIC_URL", Description: "External URL of the dashboard, used in notification links"},
+	{Name: "STATIC_DIR", Default: "./app/web/static", Description: "Directory of the dashboard UI"},
+	{Name: "STATIC_ASSETS", Default: "auto", Description: "Serve the UI from auto, embedded or disk assets"},
+	{Name: "DATA_DIR", Description: "Directory of the report store and registries"},
+	{Name: "BLOB_DIR", Description: "Directory of the raw uploads; defaults to DATA_DIR"},
+	{Name: "ARCHIVE_DIR", Description: "Cold storage directory the raw uploads of archived reports are moved to; unset keeps them with the other uploads"},
+	{Name: "STORE_DRIVER", Description: "Report store: file or sqlite"},
+	{Name: "STORE_ENCRYPTION_KEY", Description: "Base64 32-byte key encrypting identifiers in the store", Secret: true},
+	{Name: "SETTINGS_FILE", Description: "YAML settings reloaded at runtime: scoring, categories, parser profiles, groups; written by PUT /api/admin/config"},
+	{Name: "SCHEDULES_FILE", Description: "YAML file of read-only scan schedules"},
+	{Name: "KNOWLEDGE_DIR", Description: "Directory of YAML knowledge base files"},
+	{Name: "INCLUDE_DIR", Description: "Directory the include directives of uploaded AsciiDoc documents are resolved against, such as shared chapters of modular reports"},
+	{Name: "SCAN_INCREMENTAL", Default: "false", Description: "Scheduled scans rerun only the checks whose input resources changed since the previous scan"},
+	{Name: "SCAN_WORKERS", Default: "4", Description: "Checks of a live scan that run at once"},
+	{Name: "SCAN_API_QPS", Default: "20", Description: "API requests per second a scan may send to a cluster"},
+	{Name: "SCAN_API_BURST", Default: "40", Description: "API requests a scan may send to a cluster at once"},
+	{Name: "AUTO_REMEDIATION", Default: "false", Description: "Apply the suggested NetworkPolicy, ResourceQuota and LimitRange manifests of findings once a second user approves them"},
+	{Name: "CHECKS_FILE", Description: "YAML file of external checks, commands run by live scans after the built-in checks"},
+	{Name: "CREDENTIALS_DIR", Default: "/etc/health-dashboard/clusters", Description: "Mounted credentials of registered clusters"},
+	{Name: "SCORING_PRESET", Default: "default", Description: "Scoring preset used when nothing else picks one"},
+	{Name: "STATUS_PAGES", Default: "false", Description: "Serve public status pages at /status/{cluster}"},
+	{Name: "REVIEW_REQUIRED", Default: "false", Description: "Hold notifications and exports until a report is approved"},
+	{Name: "GRAPHQL_ENABLED", Default: "false", Description: "Serve the GraphQL API for reports, findings, clusters and trends at /api/graphql"},
+	{Name: "DEMO_MODE", Default: "false", Description: "Seed an empty store with synthetic reports of several clusters to explore the dashboard"},
+	{Name: "READINESS_STRICT", Default: "false", Description: "Fail the readiness probe when any integration is down"},
+	{Name: "RATE_LIMIT_PER_IP", Default: "0", Description: "Requests per second per client address"},
+	{Name: "RATE_LIMIT_PER_KEY", Default: "0", Description: "Requests per second per API key"},
+	{Name: "RATE_LIMIT_API_KEYS", Description: "Comma-separated API keys limited by RATE_LIMIT_PER_KEY", Secret: true},
+	{Name: "RATE_LIMIT_BURST", Default: "0", Description: "Requests a client may make at once"},
+	{Name: "TRUST_PROXY", Default: "false", Description: "Take client addresses from the last X-Forwarded-For entry, added by the router"},
+	{Name: "MAX_UPLOAD_SIZE", Default: "104857600", Description: "Largest accepted upload, in bytes"},
+	{Name: "UPLOAD_SESSION_HOURS", Default: "24", Description: "How long an unfinished chunked upload is kept"},
+	{Name: "RETENTION_MAX_AGE_DAYS", Default: "0", Description: "Remove reports older than this many days"},
+	{Name: "RETENTION_MAX_PER_CLUSTER", Default: "0", Description: "Keep only this many reports per cluster"},
+	{Name: "RETENTION_INTERVAL_MINUTES", Default: "60", Description: "How often the retention limits are enforced"},
+	{Name: "TLS_CERT_FILE", Description: "Certificate to serve HTTPS with"},
+	{Name: "TLS_KEY_FILE", Description: "Private key of TLS_CERT_FILE"},
+	{Name: "TLS_AUTO_RELOAD", Default: "true", Description: "Reload the certificate when the files change"},
+	{Name: "HTTP_REDIRECT_PORT", Description: "Port redirecting plain HTTP to HTTPS"},
+	{Name: "HTTP2_CLEARTEXT", Default: "false", Description: "Accept HTTP/2 without TLS"},
+	{Name: "JOBS_INTERACTIVE_WORKERS", Default: "4", Description: "Workers for uploads and scans"},
+	{Name: "JOBS_BATCH_WORKERS", Default: "1", Description: "Workers for re-scoring and migrations"},
+	{Name: "JOBS_RETENTION_MINUTES", Default: "60", Description: "How long finished jobs are kept"},
+	{Name: "LIVE_MODE", Default: "false", Description: "Scan the cluster the dashboard runs on"},
+	{Name: "KUBECONFIG", Description: "Kubeconfig for live and operator mode; in-cluster credentials without one"},
+	{Name: "LIVE_CLUSTER_NAME", Description: "Name of the live cluster; defaults to its infrastructure name"},
+	{Name: "LIVE_PROMETHEUS_URL", Description: "Prometheus or Thanos querier of the live cluster"},
+	{Name: "SLACK_WEBHOOK_URLS", Description: "Comma-separated Slack webhooks notified of new reports", Secret: true},
+	{Name: "NOTIFY_WEBHOOK_URLS", Description: "Comma-separated webhooks notified of new reports"},
+	{Name: "PAGERDUTY_ROUTING_KEY", Description: "Routing key of the PagerDuty service paged by the alert rules", Secret: true},
+	{Name: "PAGERDUTY_EVENTS_URL", Default: "https://events.pagerduty.com/v2/enqueue", Description: "PagerDuty Events API v2 endpoint; EU accounts use events.eu.pagerduty.com"},
+	{Name: "OPSGENIE_API_KEY", Description: "Opsgenie API key used by the alert rules", Secret: true},
+	{Name: "OPSGENIE_API_URL", Default: "https://api.opsgenie.com/v2/alerts", Description: "Opsgenie alert endpoint; EU accounts use api.eu.opsgenie.com"},
+	{Name: "CATEGORY_SCORE_THRESHOLDS", Description: "Comma-separated category=score thresholds, e.g. Security=80; a category score below its threshold is posted to the webhooks"},
+	{Name: "TEAMS_WEBHOOK_URLS", Description: "Comma-separated Microsoft Teams webhooks notified of the reports of every cluster", Secret: true},
+	{Name: "JIRA_URL", Description: "Jira base URL"},
+	{Name: "JIRA_USER", Description: "Jira Cloud user; empty uses the token as a personal access token"},
+	{Name: "JIRA_API_TOKEN", Description: "Jira API token", Secret: true},
+	{Name: "JIRA_PROJECT", Description: "Jira project key issues are created in"},
+	{Name: "JIRA_ISSUE_TYPE", Default: "Task", Description: "Jira issue type"},
+	{Name: "JIRA_PRIORITY_REQUIRED", Default: "High", Description: "Priority of issues for required changes"},
+	{Name: "JIRA_PRIORITY_RECOMMENDED", Default: "Medium", Description: "Priority of issues for recommended changes"},
+	{Name: "INSIGHTS_URL", Default: "https://console.redhat.com", Description: "Insights API base URL"},
+	{Name: "INSIGHTS_TOKEN_URL", Default: "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token", Description: "Insights token endpoint"},
+	{Name: "INSIGHTS_CLIENT_ID", Description: "Insights service account client ID"},
+	{Name: "INSIGHTS_CLIENT_SECRET", Description: "Insights service account secret", Secret: true},
+	{Name: "INSIGHTS_TOKEN", Description: "Static Insights access token", Secret: true},
+	{Name: "GITOPS_REPO_URL", Description: "HTTPS URL of the repository report summaries are committed to"},
+	{Name: "GITOPS_BRANCH", Default: "main", Description: "Branch report summaries are committed to"},
+	{Name: "GITOPS_PATH", Default: "health-reports", Description: "Directory of the report summaries in the repository"},
+	{Name: "GITOPS_USERNAME", Description: "User authenticating with GITOPS_TOKEN"},
+	{Name: "GITOPS_TOKEN", Description: "Access token of the GitOps repository", Secret: true},
+	{Name: "GITOPS_AUTHOR_NAME", Default: "OpenShift Health Dashboard", Description: "Author of the commits"},
+	{Name: "GITOPS_AUTHOR_EMAIL", Default: "health-dashboard@localhost", Description: "Email of the commit author"},
+	{Name: "CONFLUENCE_URL", Description: "Confluence base URL, including /wiki on Confluence Cloud"},
+	{Name: "CONFLUENCE_USER", Description: "Confluence Cloud user; empty uses the token as a personal access token"},
+	{Name: "CONFLUENCE_API_TOKEN", Description: "Confluence API token", Secret: true},
+	{Name: "CONFLUENCE_SPACE", Description: "Key of the space the cluster pages are published in"},
+	{Name: "CONFLUENCE_PARENT_ID", Description: "ID of the page new cluster pages are created below"},
+	{Name: "CONFLUENCE_TEMPLATE", Description: "Storage-format template of the page body; empty uses the built-in executive summary"},
+	{Name: "SIGNING_GPG_KEYRING", Description: "Keyring of trusted GPG keys for signed uploads"},
+	{Name: "SIGNING_SIGSTORE_KEYS", Description: "Comma-separated trusted cosign public keys"},
+	{Name: "SIGNATURE_REQUIRED", Default: "false", Description: "Reject uploads without a verified signature"},
+	{Name: "OTEL_EXPORTER_OTLP_ENDPOINT", Description: "OTLP/HTTP collector traces are exported to"},
+	{Name: "OTEL_SERVICE_NAME", Default: "openshift-health-dashboard", Description: "Service name in traces"},
+	{Name: "OTEL_TRACES_SAMPLER_ARG", Default: "1", Description: "Fraction of traces recorded"},
+	{Name: "OPERATOR_MODE", Default: "false", Description: "Reconcile clusters, schedules and notifications from a HealthDashboard resource"},
+	{Name: "OPERATOR_NAMESPACE", Description: "Namespace of the HealthDashboard resource; defaults to the pod's namespace"},
+	{Name: "OPERATOR_RESOURCE", Default: "health-dashboard", Description: "Name of the HealthDashboard resource"},
+	{Name: "TENANCY_MODE", Description: "Share the deployment between isolated tenants, picked by path prefix (path) or token claim (claim)"},
+	{Name: "TENANTS", Description: "Comma-separated tenant names; each keeps its data under tenants/<name> in DATA_DIR"},
+	{Name: "TENANT_CLAIM", Default: "tenant", Description: "Claim of the forwarded access token naming the tenant in claim mode"},
+	{Name: "TENANT_SETTINGS_DIR", Description: "Directory of the tenants' settings files, <tenant>.yaml; defaults to each tenant's data directory"},
+}
+
+// Lookup returns the variable with the given name
+func Lookup(name string) (Variable, bool) {
+	for _, variable := range Variables {
+		if variable.Name == name {
+			return variable, true
+		}
+	}
+	return Variable{}, false
+}
+
+// sortedKeys returns the keys of a map in order, for stable output
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
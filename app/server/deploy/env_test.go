@@ -0,0 +1,51 @@
+// app/server/deploy/env_test.go
+package deploy
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestVariablesMatchServer fails when main.go reads a variable missing from
+// Variables, so the generated manifests can't fall behind the server
+func TestVariablesMatchServer(t *testing.T) {
+	file, err := parser.ParseFile(token.NewFileSet(), "../main.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	read := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		call, ok := node.(*ast.CallExpr)
+		if !ok || len(call.Args) == 0 {
+			return true
+		}
+		ident, ok := call.Fun.(*ast.Ident)
+		if !ok || !strings.HasPrefix(ident.Name, "getEnv") && ident.Name != "getSecret" {
+			return true
+		}
+		if literal, ok := call.Args[0].(*ast.BasicLit); ok && literal.Kind == token.STRING {
+			name, _ := strconv.Unquote(literal.Value)
+			read[name] = true
+		}
+		return true
+	})
+	if len(read) == 0 {
+		t.Fatal("found no variables in main.go")
+	}
+
+	for name := range read {
+		if _, ok := Lookup(name); !ok {
+			t.Errorf("main.go reads %s, which is missing from Variables", name)
+		}
+	}
+	for _, variable := range Variables {
+		if !read[variable.Name] {
+			t.Errorf("Variables lists %s, which main.go doesn't read", variable.Name)
+		}
+	}
+}
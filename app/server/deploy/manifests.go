@@ -0,0 +1,391 @@
+// app/server/deploy/manifests.go
+package deploy
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+)
+
+// Defaults of the generated manifests
+const (
+	DefaultName        = "health-dashboard"
+	DefaultImage       = "quay-quay-registry.apps.ocp.rhlab.dev/ayaseen/dashboard:latest"
+	DefaultStorageSize = "1Gi"
+)
+
+const (
+	// settingsDir is where the settings ConfigMap is mounted
+	settingsDir = "/etc/health-dashboard/settings"
+
+	// dataDir is where the data volume is mounted
+	dataDir = "/var/lib/health-dashboard"
+
+	// port is the container port; PORT is not configurable in the manifests
+	// so the Service and the probes always match it
+	port = 8080
+)
+
+// Options configure the generated manifests
+type Options struct {
+	// Name of every object and the app label; defaults to DefaultName
+	Name      string
+	Namespace string
+	Image     string
+
+	// RouteHost is the host of the Route; empty lets the router pick one
+	RouteHost string
+
+	StorageSize  string
+	StorageClass string
+
+	// Settings is the settings file put into a ConfigMap; empty uses the
+	// scoring weights of the preset named by SCORING_PRESET
+	Settings []byte
+
+	// Env and Secrets set server variables, by name, in the ConfigMap and the
+	// Secret; both only accept variables the server reads
+	Env     map[string]string
+	Secrets map[string]string
+
+	// Template generates an OpenShift Template whose parameters are the
+	// name, image, route host, storage size and every secret variable
+	Template bool
+
+	// Operator runs the server in operator mode, adding the HealthDashboard
+	// CRD, an empty HealthDashboard and the service account watching it
+	Operator bool
+}
+
+// object is a Kubernetes object before it is encoded
+type object = map[string]interface{}
+
+// Generate renders the Deployment, Service, Route, ConfigMaps, Secret and
+// PersistentVolumeClaim running the dashboard as a YAML stream, or as a
+// single Template
+func Generate(options Options) ([]byte, error) {
+	if err := options.complete(); err != nil {
+		return nil, err
+	}
+
+	var objects []object
+	if options.Operator {
+		objects = append(objects, customResourceDefinition())
+		objects = append(objects, options.operatorObjects()...)
+	}
+	objects = append(objects,
+		options.settingsConfigMap(),
+		options.envConfigMap(),
+	)
+	if options.Template || len(options.Secrets) > 0 {
+		objects = append(objects, options.secret())
+	}
+	objects = append(objects,
+		options.persistentVolumeClaim(),
+		options.deployment(),
+		options.service(),
+		options.route(),
+	)
+
+	if options.Template {
+		return yaml.Marshal(options.template(objects))
+	}
+
+	var out bytes.Buffer
+	for i, obj := range objects {
+		encoded, err := yaml.Marshal(obj)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding %s: %w", obj["kind"], err)
+		}
+		if i > 0 {
+			out.WriteString("---\n")
+		}
+		out.Write(encoded)
+	}
+	return out.Bytes(), nil
+}
+
+// complete checks the options and fills in the defaults; in template mode
+// the values become parameter references
+func (o *Options) complete() error {
+	for name := range o.Env {
+		variable, ok := Lookup(name)
+		if !ok {
+			return fmt.Errorf("unknown variable %s", name)
+		}
+		if variable.Secret {
+			return fmt.Errorf("%s is a secret, set it as one", name)
+		}
+		if managed(name) {
+			return fmt.Errorf("%s is set by the manifests", name)
+		}
+	}
+	for name := range o.Secrets {
+		if variable, ok := Lookup(name); !ok || !variable.Secret {
+			return fmt.Errorf("%s is not a secret variable", name)
+		}
+	}
+
+	if o.Template {
+		o.Name, o.Image, o.RouteHost, o.StorageSize = "${NAME}", "${IMAGE}", "${ROUTE_HOST}", "${STORAGE_SIZE}"
+		o.Namespace = ""
+		secrets := make(map[string]string)
+		for _, variable := range Variables {
+			if variable.Secret {
+				secrets[variable.Name] = "${" + variable.Name + "}"
+			}
+		}
+		o.Secrets = secrets
+	}
+	if o.Name == "" {
+		o.Name = DefaultName
+	}
+	if o.Image == "" {
+		o.Image = DefaultImage
+	}
+	if o.StorageSize == "" {
+		o.StorageSize = DefaultStorageSize
+	}
+
+	if len(o.Settings) == 0 {
+		preset := o.Env["SCORING_PRESET"]
+		if preset == "" {
+			preset = scoring.DefaultProfileName
+		}
+		profile, err := scoring.Preset(preset)
+		if err != nil {
+			return err
+		}
+		o.Settings = defaultSettings(profile)
+	}
+	return nil
+}
+
+// managed reports whether a variable is set by the manifests themselves
+func managed(name string) bool {
+	switch name {
+	case "PORT", "DATA_DIR", "SETTINGS_FILE", "STATIC_ASSETS", "OPERATOR_MODE":
+		return true
+	}
+	return false
+}
+
+// defaultSettings renders a settings file with the weights of a profile, so
+// they can be tuned in the ConfigMap and reloaded without a restart
+func defaultSettings(profile scoring.Profile) []byte {
+	var out strings.Builder
+	fmt.Fprintf(&out, "# Reloaded on SIGHUP or POST /api/v1/admin/reload\n")
+	fmt.Fprintf(&out, "scoring:\n  name: %s\n  weights:\n", profile.Name)
+	fmt.Fprintf(&out, "    nochange: %g\n    advisory: %g\n    recommended: %g\n    required: %g\n",
+		profile.Weights.NoChange, profile.Weights.Advisory, profile.Weights.Recommended, profile.Weights.Required)
+	if len(profile.CategoryWeights) > 0 {
+		out.WriteString("  categoryWeights:\n")
+		weights := make(map[string]string, len(profile.CategoryWeights))
+		for category, weight := range profile.CategoryWeights {
+			weights[category] = fmt.Sprintf("%g", weight)
+		}
+		for _, category := range sortedKeys(weights) {
+			fmt.Fprintf(&out, "    %q: %s\n", category, weights[category])
+		}
+	}
+	return []byte(out.String())
+}
+
+// metadata returns the metadata of an object named after the suffix
+func (o *Options) metadata(suffix string) object {
+	name := o.Name
+	if suffix != "" {
+		name += "-" + suffix
+	}
+	meta := object{
+		"name":   name,
+		"labels": object{"app": o.Name},
+	}
+	if o.Namespace != "" {
+		meta["namespace"] = o.Namespace
+	}
+	return meta
+}
+
+func (o *Options) settingsConfigMap() object {
+	return object{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   o.metadata("settings"),
+		"data":       object{"settings.yaml": string(o.Settings)},
+	}
+}
+
+func (o *Options) envConfigMap() object {
+	data := object{}
+	for name, value := range o.Env {
+		data[name] = value
+	}
+	return object{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   o.metadata("env"),
+		"data":       data,
+	}
+}
+
+func (o *Options) secret() object {
+	data := object{}
+	for name, value := range o.Secrets {
+		data[name] = value
+	}
+	return object{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   o.metadata("secrets"),
+		"type":       "Opaque",
+		"stringData": data,
+	}
+}
+
+func (o *Options) persistentVolumeClaim() object {
+	spec := object{
+		"accessModes": []string{"ReadWriteOnce"},
+		"resources":   object{"requests": object{"storage": o.StorageSize}},
+	}
+	if o.StorageClass != "" {
+		spec["storageClassName"] = o.StorageClass
+	}
+	return object{
+		"apiVersion": "v1",
+		"kind":       "PersistentVolumeClaim",
+		"metadata":   o.metadata("data"),
+		"spec":       spec,
+	}
+}
+
+func (o *Options) deployment() object {
+	envFrom := []object{{"configMapRef": object{"name": o.Name + "-env"}}}
+	if o.Template || len(o.Secrets) > 0 {
+		envFrom = append(envFrom, object{"secretRef": object{"name": o.Name + "-secrets"}})
+	}
+	probe := func(path string) object {
+		return object{
+			"httpGet":             object{"path": path, "port": "http"},
+			"initialDelaySeconds": 5,
+			"periodSeconds":       10,
+		}
+	}
+
+	container := object{
+		"name":  "dashboard",
+		"image": o.Image,
+		"ports": []object{{"name": "http", "containerPort": port}},
+		"env": []object{
+			{"name": "PORT", "value": fmt.Sprint(port)},
+			{"name": "DATA_DIR", "value": dataDir},
+			{"name": "SETTINGS_FILE", "value": settingsDir + "/settings.yaml"},
+			{"name": "STATIC_ASSETS", "value": "embedded"},
+		},
+		"envFrom":        envFrom,
+		"livenessProbe":  probe("/healthz"),
+		"readinessProbe": probe("/readyz"),
+		"resources": object{
+			"requests": object{"cpu": "100m", "memory": "128Mi"},
+			"limits":   object{"memory": "512Mi"},
+		},
+		"volumeMounts": []object{
+			{"name": "data", "mountPath": dataDir},
+			{"name": "settings", "mountPath": settingsDir, "readOnly": true},
+		},
+	}
+
+	podSpec := object{
+		"containers": []object{container},
+		"volumes": []object{
+			{"name": "data", "persistentVolumeClaim": object{"claimName": o.Name + "-data"}},
+			{"name": "settings", "configMap": object{"name": o.Name + "-settings"}},
+		},
+	}
+	if o.Operator {
+		podSpec["serviceAccountName"] = o.Name
+		container["env"] = append(container["env"].([]object), object{"name": "OPERATOR_MODE", "value": "true"})
+	}
+
+	labels := object{"app": o.Name}
+	return object{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   o.metadata(""),
+		"spec": object{
+			"replicas": 1,
+			// The file store is a single writer, so replicas must not overlap
+			"strategy": object{"type": "Recreate"},
+			"selector": object{"matchLabels": labels},
+			"template": object{
+				"metadata": object{"labels": labels},
+				"spec":     podSpec,
+			},
+		},
+	}
+}
+
+func (o *Options) service() object {
+	return object{
+		"apiVersion": "v1",
+		"kind":       "Service",
+		"metadata":   o.metadata(""),
+		"spec": object{
+			"selector": object{"app": o.Name},
+			"ports":    []object{{"name": "http", "port": port, "targetPort": "http"}},
+		},
+	}
+}
+
+func (o *Options) route() object {
+	spec := object{
+		"to":   object{"kind": "Service", "name": o.Name},
+		"port": object{"targetPort": "http"},
+		"tls": object{
+			"termination":                   "edge",
+			"insecureEdgeTerminationPolicy": "Redirect",
+		},
+	}
+	if o.RouteHost != "" {
+		spec["host"] = o.RouteHost
+	}
+	return object{
+		"apiVersion": "route.openshift.io/v1",
+		"kind":       "Route",
+		"metadata":   o.metadata(""),
+		"spec":       spec,
+	}
+}
+
+// template wraps the objects into an OpenShift Template
+func (o *Options) template(objects []object) object {
+	parameters := []object{
+		{"name": "NAME", "description": "Name of the objects", "value": DefaultName, "required": true},
+		{"name": "IMAGE", "description": "Dashboard image", "value": DefaultImage, "required": true},
+		{"name": "ROUTE_HOST", "description": "Host of the route; empty lets the router pick one"},
+		{"name": "STORAGE_SIZE", "description": "Size of the report volume", "value": DefaultStorageSize, "required": true},
+	}
+	for _, variable := range Variables {
+		if variable.Secret {
+			parameters = append(parameters, object{"name": variable.Name, "description": variable.Description})
+		}
+	}
+
+	return object{
+		"apiVersion": "template.openshift.io/v1",
+		"kind":       "Template",
+		"metadata": object{
+			"name": DefaultName,
+			"annotations": object{
+				"description": "OpenShift Health Dashboard",
+				"tags":        "openshift,health",
+			},
+		},
+		"objects":    objects,
+		"parameters": parameters,
+	}
+}
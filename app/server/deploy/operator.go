@@ -0,0 +1,139 @@
+// app/server/deploy/operator.go
+package deploy
+
+import "github.com/ayaseen/openshift-health-dashboard/app/server/operator"
+
+// customResourceDefinition declares the HealthDashboard resource watched in
+// operator mode; installing it needs cluster-admin, unlike the other objects
+func customResourceDefinition() object {
+	str := object{"type": "string"}
+	strings := object{"type": "array", "items": str}
+	required := func(properties object, names ...string) object {
+		return object{"type": "object", "required": names, "properties": properties}
+	}
+
+	cluster := required(object{
+		"name":           str,
+		"apiUrl":         str,
+		"credentialsRef": str,
+		"labels":         object{"type": "object", "additionalProperties": str},
+		"org":            str,
+		"insightsId":     str,
+		"prometheusUrl":  str,
+	}, "name")
+	teamsWebhook := required(object{
+		"url":      str,
+		"clusters": strings,
+	}, "url")
+	schedule := required(object{
+		"name":    str,
+		"cluster": str,
+		"cron":    str,
+		"suspend": object{"type": "boolean"},
+	}, "name", "cluster", "cron")
+
+	schema := object{
+		"type": "object",
+		"properties": object{
+			"spec": object{
+				"type": "object",
+				"properties": object{
+					"clusters":  object{"type": "array", "items": cluster},
+					"schedules": object{"type": "array", "items": schedule},
+					"notifications": object{
+						"type": "object",
+						"properties": object{
+							"slackWebhookUrls": strings,
+							"webhookUrls":      strings,
+							"teamsWebhooks":    object{"type": "array", "items": teamsWebhook},
+						},
+					},
+				},
+			},
+			"status": object{
+				"type":                                 "object",
+				"x-kubernetes-preserve-unknown-fields": true,
+			},
+		},
+	}
+
+	return object{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   object{"name": operator.Resource + "." + operator.Group},
+		"spec": object{
+			"group": operator.Group,
+			"scope": "Namespaced",
+			"names": object{
+				"kind":     operator.Kind,
+				"listKind": operator.Kind + "List",
+				"plural":   operator.Resource,
+				"singular": "healthdashboard",
+			},
+			"versions": []object{{
+				"name":                     operator.Version,
+				"served":                   true,
+				"storage":                  true,
+				"subresources":             object{"status": object{}},
+				"schema":                   object{"openAPIV3Schema": schema},
+				"additionalPrinterColumns": printerColumns(),
+			}},
+		},
+	}
+}
+
+// printerColumns are shown by "oc get healthdashboards"
+func printerColumns() []object {
+	return []object{
+		{"name": "Ready", "type": "string", "jsonPath": `.status.conditions[?(@.type=="Ready")].status`},
+		{"name": "Clusters", "type": "integer", "jsonPath": ".status.clusters"},
+		{"name": "Schedules", "type": "integer", "jsonPath": ".status.schedules"},
+		{"name": "Age", "type": "date", "jsonPath": ".metadata.creationTimestamp"},
+	}
+}
+
+// operatorObjects are the service account and the permissions to watch the
+// HealthDashboard resource and update its status
+func (o *Options) operatorObjects() []object {
+	return []object{
+		{
+			"apiVersion": "v1",
+			"kind":       "ServiceAccount",
+			"metadata":   o.metadata(""),
+		},
+		{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       "Role",
+			"metadata":   o.metadata("operator"),
+			"rules": []object{
+				{
+					"apiGroups": []string{operator.Group},
+					"resources": []string{operator.Resource},
+					"verbs":     []string{"get", "list", "watch"},
+				},
+				{
+					"apiGroups": []string{operator.Group},
+					"resources": []string{operator.Resource + "/status"},
+					"verbs":     []string{"get", "update", "patch"},
+				},
+			},
+		},
+		{
+			"apiVersion": "rbac.authorization.k8s.io/v1",
+			"kind":       "RoleBinding",
+			"metadata":   o.metadata("operator"),
+			"roleRef": object{
+				"apiGroup": "rbac.authorization.k8s.io",
+				"kind":     "Role",
+				"name":     o.Name + "-operator",
+			},
+			"subjects": []object{{"kind": "ServiceAccount", "name": o.Name}},
+		},
+		{
+			"apiVersion": operator.Group + "/" + operator.Version,
+			"kind":       operator.Kind,
+			"metadata":   o.metadata(""),
+			"spec":       object{"clusters": []object{}, "schedules": []object{}},
+		},
+	}
+}
@@ -0,0 +1,50 @@
+// app/server/groups/groups.go
+
+// Package groups defines cluster groups, such as production clusters or the
+// clusters of one region, as label selectors over the registered clusters.
+package groups
+
+import (
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// namePattern restricts group names to values that are safe in URLs
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Group selects the registered clusters whose labels match a selector
+type Group struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Selector is a Kubernetes label selector, e.g. "env=prod,region in (eu,us)"
+	Selector string `json:"selector"`
+
+	selector labels.Selector
+}
+
+// New validates a group and compiles its selector
+func New(name, description, selector string) (Group, error) {
+	if !namePattern.MatchString(name) {
+		return Group{}, fmt.Errorf("invalid group name %q: use lowercase letters, digits and dashes", name)
+	}
+	if selector == "" {
+		return Group{}, fmt.Errorf("group %q has no selector; use a label selector such as env=prod", name)
+	}
+
+	compiled, err := labels.Parse(selector)
+	if err != nil {
+		return Group{}, fmt.Errorf("group %q has an invalid selector: %w", name, err)
+	}
+	return Group{Name: name, Description: description, Selector: selector, selector: compiled}, nil
+}
+
+// Matches reports whether a cluster with the given labels belongs to the group
+func (g Group) Matches(clusterLabels map[string]string) bool {
+	if g.selector == nil {
+		return false
+	}
+	return g.selector.Matches(labels.Set(clusterLabels))
+}
@@ -0,0 +1,138 @@
+// app/server/i18n/de.go
+package i18n
+
+// german holds the German translations
+var german = map[string]string{
+	// Category names
+	"Infrastructure Setup":    "Infrastruktur-Setup",
+	"Policy Governance":       "Richtlinien-Governance",
+	"Compliance Benchmarking": "Compliance-Benchmarking",
+	"Monitoring":              "Monitoring",
+	"Central Monitoring":      "Zentrales Monitoring",
+	"Build/Deploy Security":   "Build-/Deploy-Sicherheit",
+
+	// Generated descriptions
+	"%s is excellent with best practices in place.":                          "%s ist ausgezeichnet, die Best Practices sind umgesetzt.",
+	"%s is well-configured with only minor improvements needed.":             "%s ist gut konfiguriert, nur kleinere Verbesserungen sind nötig.",
+	"%s meets most requirements but has some areas that could be improved.":  "%s erfüllt die meisten Anforderungen, einige Bereiche lassen sich aber verbessern.",
+	"%s has several areas that need attention to meet best practices.":       "%s hat mehrere Bereiche, die für die Best Practices Aufmerksamkeit brauchen.",
+	"%s requires significant improvements to ensure stability and security.": "%s braucht deutliche Verbesserungen, um Stabilität und Sicherheit zu gewährleisten.",
+
+	// Export documents
+	"Changes Required":    "Änderungen erforderlich",
+	"Changes Recommended": "Änderungen empfohlen",
+	"Advisory":            "Hinweis",
+	"No Change":           "Keine Änderung",
+	"Not Applicable":      "Nicht zutreffend",
+	"Status":              "Status",
+	"Cluster":             "Cluster",
+	"Customer":            "Kunde",
+	"Observation":         "Beobachtung",
+	"Recommendation":      "Empfehlung",
+	"Health check report": "Health-Check-Bericht",
+
+	// Error messages
+	"Method not allowed":                          "Methode nicht erlaubt",
+	"Invalid request body":                        "Ungültiger Request-Body",
+	"Unsupported API version":                     "Nicht unterstützte API-Version",
+	"Unsupported language":                        "Nicht unterstützte Sprache",
+	"Unknown scoring preset":                      "Unbekanntes Scoring-Preset",
+	"Unknown parser profile":                      "Unbekanntes Parser-Profil",
+	"Unknown cluster":                             "Unbekannter Cluster",
+	"Unknown organization":                        "Unbekannte Organisation",
+	"Failed to get file":                          "Datei konnte nicht gelesen werden",
+	"Failed to parse form":                        "Formular konnte nicht gelesen werden",
+	"Failed to process file":                      "Datei konnte nicht verarbeitet werden",
+	"Failed to parse report: %s":                  "Bericht konnte nicht geparst werden: %s",
+	"Failed to store report":                      "Bericht konnte nicht gespeichert werden",
+	"Failed to load report":                       "Bericht konnte nicht geladen werden",
+	"Failed to encode response":                   "Antwort konnte nicht kodiert werden",
+	"Failed to approve report":                    "Bericht konnte nicht freigegeben werden",
+	"Failed to delete cluster":                    "Cluster konnte nicht gelöscht werden",
+	"Failed to delete schedule":                   "Zeitplan konnte nicht gelöscht werden",
+	"Failed to delete waiver":                     "Ausnahme konnte nicht gelöscht werden",
+	"Failed to load cluster events":               "Cluster-Ereignisse konnten nicht geladen werden",
+	"Failed to provision organization":            "Organisation konnte nicht angelegt werden",
+	"Failed to regenerate descriptions":           "Beschreibungen konnten nicht neu erstellt werden",
+	"Failed to save descriptions":                 "Beschreibungen konnten nicht gespeichert werden",
+	"Invalid onboarding request":                  "Ungültige Onboarding-Anfrage",
+	"Job queue is not accepting work":             "Die Job-Warteschlange nimmt keine Aufträge an",
+	"Upload exceeds the maximum size of %d bytes": "Der Upload überschreitet die maximale Größe von %d Bytes",
+	"Invalid file type. Only .adoc, .asciidoc, .md or .html files are allowed": "Ungültiger Dateityp. Nur .adoc-, .asciidoc-, .md- oder .html-Dateien sind erlaubt",
+	"Rate limit exceeded, retry in %d seconds":                                 "Rate-Limit überschritten, erneuter Versuch in %d Sekunden",
+	"Report not found":                   "Bericht nicht gefunden",
+	"Cluster not found":                  "Cluster nicht gefunden",
+	"Group not found":                    "Gruppe nicht gefunden",
+	"Job not found":                      "Job nicht gefunden",
+	"Organization not found":             "Organisation nicht gefunden",
+	"Schedule not found":                 "Zeitplan nicht gefunden",
+	"Waiver not found":                   "Ausnahme nicht gefunden",
+	"Cluster is not registered":          "Der Cluster ist nicht registriert",
+	"Cluster has no insightsId":          "Der Cluster hat keine insightsId",
+	"Report is awaiting review":          "Der Bericht wartet auf Prüfung",
+	"Report is not awaiting review":      "Der Bericht wartet nicht auf Prüfung",
+	"Signatures are limited to %d bytes": "Signaturen sind auf %d Bytes begrenzt",
+	"The report signature is missing or could not be verified":                     "Die Signatur des Berichts fehlt oder konnte nicht verifiziert werden",
+	"No retention limits are configured":                                           "Es sind keine Aufbewahrungsgrenzen konfiguriert",
+	"Git export is not configured":                                                 "Der Git-Export ist nicht konfiguriert",
+	"Git export failed: %s":                                                        "Git-Export fehlgeschlagen: %s",
+	"Upload not found":                                                             "Upload nicht gefunden",
+	"The Upload-Offset header must be the offset of the chunk":                     "Der Header Upload-Offset muss den Offset des Teils angeben",
+	"The chunk must start at offset %d":                                            "Der Teil muss bei Offset %d beginnen",
+	"Failed to store the chunk":                                                    "Der Teil konnte nicht gespeichert werden",
+	"The upload is incomplete: %d of %d bytes received":                            "Der Upload ist unvollständig: %d von %d Bytes empfangen",
+	"The upload is already being completed":                                        "Der Upload wird bereits abgeschlossen",
+	"Request body exceeds the maximum size of %d bytes":                            "Der Anfragetext überschreitet die maximale Größe von %d Bytes",
+	"The upload does not match its SHA-256 digest; start a new upload":             "Der Upload entspricht nicht seiner SHA-256-Prüfsumme; starten Sie einen neuen Upload",
+	"Report has no findings to prioritize; re-parse it first":                      "Der Bericht hat keine Befunde zum Priorisieren; parsen Sie ihn zuerst neu",
+	"Failed to render badge":                                                       "Badge konnte nicht gezeichnet werden",
+	"Confluence publishing is not configured":                                      "Die Veröffentlichung in Confluence ist nicht konfiguriert",
+	"Confluence publishing failed: %s":                                             "Veröffentlichung in Confluence fehlgeschlagen: %s",
+	"Only the latest report of a cluster is published to its page":                 "Nur der neueste Bericht eines Clusters wird auf seiner Seite veröffentlicht",
+	"Template pack not found":                                                      "Vorlagenpaket nicht gefunden",
+	"Template pack is active; deactivate it first":                                 "Das Vorlagenpaket ist aktiv; deaktivieren Sie es zuerst",
+	"Failed to delete template pack":                                               "Vorlagenpaket konnte nicht gelöscht werden",
+	"Failed to activate template pack":                                             "Vorlagenpaket konnte nicht aktiviert werden",
+	"No logo uploaded":                                                             "Es wurde kein Logo hochgeladen",
+	"Failed to delete logo":                                                        "Logo konnte nicht gelöscht werden",
+	"A GraphQL query is required":                                                  "Eine GraphQL-Abfrage ist erforderlich",
+	"sort must be title, category, status or severity, optionally prefixed with -": "sort muss title, category, status oder severity sein, optional mit vorangestelltem -",
+	"limit must be a number from 1 to %d":                                          "limit muss eine Zahl von 1 bis %d sein",
+	"offset must be a number of at least 0":                                        "offset muss eine Zahl ab 0 sein",
+	"Invalid label selector: %s":                                                   "Ungültiger Label-Selektor: %s",
+	"Failed to save labels":                                                        "Labels konnten nicht gespeichert werden",
+	"A tenant is required; use /t/{tenant}/api/":                                   "Ein Mandant ist erforderlich; verwenden Sie /t/{tenant}/api/",
+	"Tenant not found":                                                             "Mandant nicht gefunden",
+	"The access token names no tenant of this dashboard":                           "Das Zugriffstoken nennt keinen Mandanten dieses Dashboards",
+	"archived must be true, false or all":                                          "archived muss true, false oder all sein",
+	"Report is already archived":                                                   "Der Bericht ist bereits archiviert",
+	"Failed to archive report":                                                     "Der Bericht konnte nicht archiviert werden",
+	"Report is not archived":                                                       "Der Bericht ist nicht archiviert",
+	"Report is being archived or restored":                                         "Der Bericht wird gerade archiviert oder wiederhergestellt",
+	"Failed to restore archived report":                                            "Der archivierte Bericht konnte nicht wiederhergestellt werden",
+	"Configuration document is too large":                                          "Das Konfigurationsdokument ist zu groß",
+	"Invalid configuration document: %s":                                           "Ungültiges Konfigurationsdokument: %s",
+	"Failed to export configuration":                                               "Die Konfiguration konnte nicht exportiert werden",
+	"Failed to import configuration":                                               "Die Konfiguration konnte nicht importiert werden",
+	"Settings can only be imported with SETTINGS_FILE set":                         "Einstellungen können nur mit gesetztem SETTINGS_FILE importiert werden",
+	"Settings were imported, but the schedules failed: %s":                         "Die Einstellungen wurden importiert, die Zeitpläne jedoch nicht: %s",
+	"Settings and schedules were imported, but the waivers failed: %s":             "Einstellungen und Zeitpläne wurden importiert, die Ausnahmen jedoch nicht: %s",
+	"Scan failed: %s":                                                              "Scan fehlgeschlagen: %s",
+	"List the findings to resolve":                                                 "Geben Sie die zu behebenden Befunde an",
+	"None of the findings are in the report":                                       "Keiner der Befunde ist im Bericht enthalten",
+	"Report has no findings to simulate; re-parse it first":                        "Der Bericht hat keine Befunde zum Simulieren; parsen Sie ihn zuerst neu",
+	"Report has no findings to describe, re-score it first":                        "Der Bericht hat keine Befunde zum Beschreiben; bewerten Sie ihn zuerst neu",
+	"Jira integration is not configured":                                           "Die Jira-Integration ist nicht konfiguriert",
+	"Insights integration is not configured":                                       "Die Insights-Integration ist nicht konfiguriert",
+	"Insights has no results for the cluster":                                      "Insights hat keine Ergebnisse für den Cluster",
+	"Error fetching Insights recommendations":                                      "Fehler beim Abrufen der Insights-Empfehlungen",
+	"Error saving the recommendations":                                             "Fehler beim Speichern der Empfehlungen",
+	"Error saving the results":                                                     "Fehler beim Speichern der Ergebnisse",
+	"Error reading compliance check results":                                       "Fehler beim Lesen der Compliance-Prüfergebnisse",
+	"Cluster has no report to attach the recommendations to":                       "Der Cluster hat keinen Bericht für die Empfehlungen",
+	"Cluster has no report to attach the results to":                               "Der Cluster hat keinen Bericht für die Ergebnisse",
+	"The cluster has no compliance check results":                                  "Der Cluster hat keine Compliance-Prüfergebnisse",
+	"The file holds no XCCDF rule results":                                         "Die Datei enthält keine XCCDF-Regelergebnisse",
+	"interval must be week or month":                                               "interval muss week oder month sein",
+	"periods must be a number from 1 to %d":                                        "periods muss eine Zahl von 1 bis %d sein",
+}
@@ -0,0 +1,83 @@
+// app/server/i18n/i18n.go
+
+// Package i18n translates the text the server writes itself: generated
+// descriptions, export documents and API error messages. Messages are keyed by
+// their English text, so an untranslated message or an unsupported language
+// falls back to English.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage is used when no supported language is requested
+const DefaultLanguage = "en"
+
+// catalogs holds the translations of each language other than English
+var catalogs = map[string]map[string]string{
+	"de": german,
+	"ja": japanese,
+}
+
+// Languages returns the supported languages, English first
+func Languages() []string {
+	languages := []string{DefaultLanguage}
+	for language := range catalogs {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages[1:])
+	return languages
+}
+
+// Match returns the supported language of a language tag such as "de-AT"
+func Match(tag string) (string, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if base, _, found := strings.Cut(tag, "-"); found {
+		tag = base
+	}
+	if tag == DefaultLanguage {
+		return tag, true
+	}
+	if _, ok := catalogs[tag]; ok {
+		return tag, true
+	}
+	return "", false
+}
+
+// Negotiate picks the supported language an Accept-Language header prefers
+// most, or DefaultLanguage
+func Negotiate(acceptLanguage string) string {
+	best, bestQuality := DefaultLanguage, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		quality := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+
+		language, ok := Match(tag)
+		if ok && quality > bestQuality {
+			best, bestQuality = language, quality
+		}
+	}
+	return best
+}
+
+// T translates a message into a language and formats it with the arguments,
+// if there are any
+func T(language, message string, args ...interface{}) string {
+	if translated, ok := catalogs[language][message]; ok {
+		message = translated
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
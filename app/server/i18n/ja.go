@@ -0,0 +1,138 @@
+// app/server/i18n/ja.go
+package i18n
+
+// japanese holds the Japanese translations
+var japanese = map[string]string{
+	// Category names
+	"Infrastructure Setup":    "インフラストラクチャ構成",
+	"Policy Governance":       "ポリシーガバナンス",
+	"Compliance Benchmarking": "コンプライアンスベンチマーク",
+	"Monitoring":              "モニタリング",
+	"Central Monitoring":      "集中モニタリング",
+	"Build/Deploy Security":   "ビルド/デプロイのセキュリティ",
+
+	// Generated descriptions
+	"%s is excellent with best practices in place.":                          "%sは非常に良好で、ベストプラクティスが適用されています。",
+	"%s is well-configured with only minor improvements needed.":             "%sは適切に構成されており、必要な改善はわずかです。",
+	"%s meets most requirements but has some areas that could be improved.":  "%sはほとんどの要件を満たしていますが、改善の余地がある領域があります。",
+	"%s has several areas that need attention to meet best practices.":       "%sにはベストプラクティスを満たすために対応が必要な領域がいくつかあります。",
+	"%s requires significant improvements to ensure stability and security.": "%sは安定性とセキュリティを確保するために大幅な改善が必要です。",
+
+	// Export documents
+	"Changes Required":    "変更が必要",
+	"Changes Recommended": "変更を推奨",
+	"Advisory":            "アドバイザリー",
+	"No Change":           "変更不要",
+	"Not Applicable":      "該当なし",
+	"Status":              "ステータス",
+	"Cluster":             "クラスター",
+	"Customer":            "顧客",
+	"Observation":         "所見",
+	"Recommendation":      "推奨事項",
+	"Health check report": "ヘルスチェックレポート",
+
+	// Error messages
+	"Method not allowed":                          "許可されていないメソッドです",
+	"Invalid request body":                        "リクエストボディが不正です",
+	"Unsupported API version":                     "サポートされていない API バージョンです",
+	"Unsupported language":                        "サポートされていない言語です",
+	"Unknown scoring preset":                      "不明なスコアリングプリセットです",
+	"Unknown parser profile":                      "不明なパーサープロファイルです",
+	"Unknown cluster":                             "不明なクラスターです",
+	"Unknown organization":                        "不明な組織です",
+	"Failed to get file":                          "ファイルを取得できませんでした",
+	"Failed to parse form":                        "フォームを解析できませんでした",
+	"Failed to process file":                      "ファイルを処理できませんでした",
+	"Failed to parse report: %s":                  "レポートを解析できませんでした: %s",
+	"Failed to store report":                      "レポートを保存できませんでした",
+	"Failed to load report":                       "レポートを読み込めませんでした",
+	"Failed to encode response":                   "レスポンスをエンコードできませんでした",
+	"Failed to approve report":                    "レポートを承認できませんでした",
+	"Failed to delete cluster":                    "クラスターを削除できませんでした",
+	"Failed to delete schedule":                   "スケジュールを削除できませんでした",
+	"Failed to delete waiver":                     "免除を削除できませんでした",
+	"Failed to load cluster events":               "クラスターのイベントを読み込めませんでした",
+	"Failed to provision organization":            "組織を作成できませんでした",
+	"Failed to regenerate descriptions":           "説明を再生成できませんでした",
+	"Failed to save descriptions":                 "説明を保存できませんでした",
+	"Invalid onboarding request":                  "オンボーディングリクエストが不正です",
+	"Job queue is not accepting work":             "ジョブキューが作業を受け付けていません",
+	"Upload exceeds the maximum size of %d bytes": "アップロードが最大サイズ %d バイトを超えています",
+	"Invalid file type. Only .adoc, .asciidoc, .md or .html files are allowed": "ファイル形式が不正です。.adoc、.asciidoc、.md または .html ファイルのみ使用できます",
+	"Rate limit exceeded, retry in %d seconds":                                 "レート制限を超えました。%d 秒後に再試行してください",
+	"Report not found":                   "レポートが見つかりません",
+	"Cluster not found":                  "クラスターが見つかりません",
+	"Group not found":                    "グループが見つかりません",
+	"Job not found":                      "ジョブが見つかりません",
+	"Organization not found":             "組織が見つかりません",
+	"Schedule not found":                 "スケジュールが見つかりません",
+	"Waiver not found":                   "免除が見つかりません",
+	"Cluster is not registered":          "クラスターが登録されていません",
+	"Cluster has no insightsId":          "クラスターに insightsId がありません",
+	"Report is awaiting review":          "レポートはレビュー待ちです",
+	"Report is not awaiting review":      "レポートはレビュー待ちではありません",
+	"Signatures are limited to %d bytes": "署名は %d バイトまでです",
+	"The report signature is missing or could not be verified":                     "レポートの署名がないか、検証できませんでした",
+	"No retention limits are configured":                                           "保持期間の制限が設定されていません",
+	"Git export is not configured":                                                 "Git エクスポートが設定されていません",
+	"Git export failed: %s":                                                        "Git エクスポートに失敗しました: %s",
+	"Upload not found":                                                             "アップロードが見つかりません",
+	"The Upload-Offset header must be the offset of the chunk":                     "Upload-Offset ヘッダーにはチャンクのオフセットを指定してください",
+	"The chunk must start at offset %d":                                            "チャンクはオフセット %d から開始する必要があります",
+	"Failed to store the chunk":                                                    "チャンクを保存できませんでした",
+	"The upload is incomplete: %d of %d bytes received":                            "アップロードが完了していません: %d / %d バイトを受信しました",
+	"The upload is already being completed":                                        "アップロードはすでに完了処理中です",
+	"Request body exceeds the maximum size of %d bytes":                            "リクエスト本文が最大サイズ %d バイトを超えています",
+	"The upload does not match its SHA-256 digest; start a new upload":             "アップロードが SHA-256 ダイジェストと一致しません。新しいアップロードを開始してください",
+	"Report has no findings to prioritize; re-parse it first":                      "レポートに優先順位を付ける検出事項がありません。先に再解析してください",
+	"Failed to render badge":                                                       "バッジを描画できませんでした",
+	"Confluence publishing is not configured":                                      "Confluence への公開は設定されていません",
+	"Confluence publishing failed: %s":                                             "Confluence への公開に失敗しました: %s",
+	"Only the latest report of a cluster is published to its page":                 "クラスターのページに公開されるのは最新のレポートのみです",
+	"Template pack not found":                                                      "テンプレートパックが見つかりません",
+	"Template pack is active; deactivate it first":                                 "テンプレートパックは有効です。先に無効にしてください",
+	"Failed to delete template pack":                                               "テンプレートパックの削除に失敗しました",
+	"Failed to activate template pack":                                             "テンプレートパックの有効化に失敗しました",
+	"No logo uploaded":                                                             "ロゴがアップロードされていません",
+	"Failed to delete logo":                                                        "ロゴの削除に失敗しました",
+	"A GraphQL query is required":                                                  "GraphQL クエリが必要です",
+	"sort must be title, category, status or severity, optionally prefixed with -": "sort は title、category、status、severity のいずれかで、先頭に - を付けることもできます",
+	"limit must be a number from 1 to %d":                                          "limit は 1 から %d までの数値である必要があります",
+	"offset must be a number of at least 0":                                        "offset は 0 以上の数値である必要があります",
+	"Invalid label selector: %s":                                                   "無効なラベルセレクターです: %s",
+	"Failed to save labels":                                                        "ラベルを保存できませんでした",
+	"A tenant is required; use /t/{tenant}/api/":                                   "テナントが必要です。/t/{tenant}/api/ を使用してください",
+	"Tenant not found":                                                             "テナントが見つかりません",
+	"The access token names no tenant of this dashboard":                           "アクセストークンにこのダッシュボードのテナントが含まれていません",
+	"archived must be true, false or all":                                          "archived は true、false、all のいずれかである必要があります",
+	"Report is already archived":                                                   "レポートはすでにアーカイブされています",
+	"Failed to archive report":                                                     "レポートをアーカイブできませんでした",
+	"Report is not archived":                                                       "レポートはアーカイブされていません",
+	"Report is being archived or restored":                                         "レポートはアーカイブまたは復元の処理中です",
+	"Failed to restore archived report":                                            "アーカイブされたレポートを復元できませんでした",
+	"Configuration document is too large":                                          "構成ドキュメントが大きすぎます",
+	"Invalid configuration document: %s":                                           "構成ドキュメントが無効です: %s",
+	"Failed to export configuration":                                               "構成をエクスポートできませんでした",
+	"Failed to import configuration":                                               "構成をインポートできませんでした",
+	"Settings can only be imported with SETTINGS_FILE set":                         "設定は SETTINGS_FILE が設定されている場合にのみインポートできます",
+	"Settings were imported, but the schedules failed: %s":                         "設定はインポートされましたが、スケジュールは失敗しました: %s",
+	"Settings and schedules were imported, but the waivers failed: %s":             "設定とスケジュールはインポートされましたが、除外は失敗しました: %s",
+	"Scan failed: %s":                                                              "スキャンに失敗しました: %s",
+	"List the findings to resolve":                                                 "解決する検出事項を指定してください",
+	"None of the findings are in the report":                                       "指定された検出事項はレポートにありません",
+	"Report has no findings to simulate; re-parse it first":                        "シミュレーションする検出事項がありません。先にレポートを再解析してください",
+	"Report has no findings to describe, re-score it first":                        "説明する検出事項がありません。先にレポートを再スコアリングしてください",
+	"Jira integration is not configured":                                           "Jira 連携が設定されていません",
+	"Insights integration is not configured":                                       "Insights 連携が設定されていません",
+	"Insights has no results for the cluster":                                      "Insights にこのクラスターの結果がありません",
+	"Error fetching Insights recommendations":                                      "Insights の推奨事項の取得中にエラーが発生しました",
+	"Error saving the recommendations":                                             "推奨事項の保存中にエラーが発生しました",
+	"Error saving the results":                                                     "結果の保存中にエラーが発生しました",
+	"Error reading compliance check results":                                       "コンプライアンスチェック結果の読み込み中にエラーが発生しました",
+	"Cluster has no report to attach the recommendations to":                       "推奨事項を添付するレポートがクラスターにありません",
+	"Cluster has no report to attach the results to":                               "結果を添付するレポートがクラスターにありません",
+	"The cluster has no compliance check results":                                  "クラスターにコンプライアンスチェック結果がありません",
+	"The file holds no XCCDF rule results":                                         "ファイルに XCCDF ルールの結果がありません",
+	"interval must be week or month":                                               "interval は week または month である必要があります",
+	"periods must be a number from 1 to %d":                                        "periods は 1 から %d までの数値である必要があります",
+}
@@ -0,0 +1,84 @@
+// app/server/integrations/compliance/cluster.go
+package compliance
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+)
+
+// checkResults is the resource of the Compliance Operator's check results
+var checkResults = schema.GroupVersionResource{
+	Group:    "compliance.openshift.io",
+	Version:  "v1alpha1",
+	Resource: "compliancecheckresults",
+}
+
+// scanLabel names the scan a check result belongs to
+const scanLabel = "compliance.openshift.io/scan-name"
+
+// FromCluster lists the ComplianceCheckResult objects of a cluster in every namespace
+func FromCluster(ctx context.Context, restConfig *rest.Config) ([]Result, error) {
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating dynamic client: %w", err)
+	}
+
+	var results []Result
+	options := metav1.ListOptions{Limit: 500}
+	for {
+		list, err := client.Resource(checkResults).List(ctx, options)
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("the Compliance Operator is not installed: %w", err)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error listing compliance check results: %w", err)
+		}
+
+		for _, item := range list.Items {
+			results = append(results, checkResult(item))
+		}
+		if options.Continue = list.GetContinue(); options.Continue == "" {
+			break
+		}
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNoResults
+	}
+	return results, nil
+}
+
+// checkResult reads a ComplianceCheckResult object; its fields are at the top level
+func checkResult(item unstructured.Unstructured) Result {
+	field := func(name string) string {
+		value, _, _ := unstructured.NestedString(item.Object, name)
+		return value
+	}
+
+	id := field("id")
+	if id == "" {
+		id = item.GetName()
+	}
+
+	// The first line of the description is the rule's title
+	description := strings.TrimSpace(field("description"))
+	title, rest, _ := strings.Cut(description, "\n")
+
+	return Result{
+		ID:           ruleID(id),
+		Title:        strings.TrimSpace(title),
+		Status:       field("status"),
+		Severity:     field("severity"),
+		Description:  strings.TrimSpace(rest),
+		Instructions: field("instructions"),
+		Scan:         item.GetLabels()[scanLabel],
+	}
+}
@@ -0,0 +1,142 @@
+// app/server/integrations/compliance/results.go
+
+// Package compliance imports the results of OpenShift Compliance Operator
+// scans, read from the ComplianceCheckResult objects of a cluster or from
+// exported XCCDF and ARF files, and maps them to findings.
+package compliance
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// Source marks findings imported from compliance scans
+const Source = "compliance-operator"
+
+// ErrNoResults is returned when a cluster or file holds no check results
+var ErrNoResults = errors.New("no compliance check results found")
+
+// Check statuses, as used by ComplianceCheckResult objects
+const (
+	StatusPass          = "PASS"
+	StatusFail          = "FAIL"
+	StatusInfo          = "INFO"
+	StatusManual        = "MANUAL"
+	StatusError         = "ERROR"
+	StatusInconsistent  = "INCONSISTENT"
+	StatusNotApplicable = "NOT-APPLICABLE"
+	StatusSkip          = "SKIP"
+)
+
+// Result is the outcome of one compliance check
+type Result struct {
+	ID           string
+	Title        string
+	Status       string
+	Severity     string
+	Description  string
+	Instructions string
+
+	// Scan is the scan that ran the check, when known; node checks run once per node role
+	Scan string
+}
+
+// Summary counts the results of an import and the score they yield
+type Summary struct {
+	Counts map[string]int `json:"counts"`
+
+	// Score is the percentage of passing checks among the passing and failing
+	// ones; nil when no check passed or failed
+	Score *int `json:"score,omitempty"`
+}
+
+// Summarize counts results by status and scores them
+func Summarize(results []Result) Summary {
+	summary := Summary{Counts: make(map[string]int)}
+	for _, result := range results {
+		summary.Counts[result.Status]++
+	}
+
+	if decided := summary.Counts[StatusPass] + summary.Counts[StatusFail]; decided > 0 {
+		score := summary.Counts[StatusPass] * 100 / decided
+		summary.Score = &score
+	}
+	return summary
+}
+
+// Findings converts results to findings, most severe first. Checks that
+// don't apply or were skipped are left out.
+func Findings(results []Result) []types.Finding {
+	findings := make([]types.Finding, 0, len(results))
+	for _, result := range results {
+		status, ok := result.status()
+		if !ok {
+			continue
+		}
+
+		id := Source + ":" + result.ID
+		if result.Scan != "" {
+			id = Source + ":" + result.Scan + ":" + result.ID
+		}
+		title := result.Title
+		if title == "" {
+			title = result.ID
+		}
+		findings = append(findings, types.Finding{
+			ID:    id,
+			Title: title,
+
+			// The Compliance Benchmarking score is computed from this category
+			Category:       scoring.CategoryPerformance,
+			Status:         status,
+			Severity:       utils.SeverityForStatus(status),
+			Observation:    strings.TrimSpace(result.Description),
+			Recommendation: strings.TrimSpace(result.Instructions),
+			Source:         Source,
+		})
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return statusRank[findings[i].Status] > statusRank[findings[j].Status]
+	})
+	return findings
+}
+
+// statusRank orders finding statuses from the least to the most urgent
+var statusRank = map[types.ResultKey]int{
+	types.ResultKeyNoChange:    0,
+	types.ResultKeyEvaluate:    1,
+	types.ResultKeyAdvisory:    2,
+	types.ResultKeyRecommended: 3,
+	types.ResultKeyRequired:    4,
+}
+
+// status maps a check status to the dashboard's status model. Failing high
+// severity checks require changes, failing medium ones are recommended and
+// the rest advisory; checks needing a person to judge them are to evaluate.
+func (r Result) status() (types.ResultKey, bool) {
+	switch r.Status {
+	case StatusPass:
+		return types.ResultKeyNoChange, true
+	case StatusFail:
+		switch r.Severity {
+		case "high":
+			return types.ResultKeyRequired, true
+		case "medium":
+			return types.ResultKeyRecommended, true
+		default:
+			return types.ResultKeyAdvisory, true
+		}
+	case StatusInfo:
+		return types.ResultKeyAdvisory, true
+	case StatusManual, StatusError, StatusInconsistent:
+		return types.ResultKeyEvaluate, true
+	default:
+		return "", false
+	}
+}
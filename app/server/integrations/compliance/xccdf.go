@@ -0,0 +1,110 @@
+// app/server/integrations/compliance/xccdf.go
+package compliance
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xccdfStatuses maps XCCDF result values to check statuses
+var xccdfStatuses = map[string]string{
+	"pass":          StatusPass,
+	"fixed":         StatusPass,
+	"fail":          StatusFail,
+	"informational": StatusInfo,
+	"notchecked":    StatusManual,
+	"error":         StatusError,
+	"unknown":       StatusError,
+	"notapplicable": StatusNotApplicable,
+	"notselected":   StatusSkip,
+}
+
+// xccdfRule is the part of an XCCDF rule definition that is imported
+type xccdfRule struct {
+	ID       string `xml:"id,attr"`
+	Severity string `xml:"severity,attr"`
+	Title    string `xml:"title"`
+}
+
+// xccdfRuleResult is the outcome of a rule in an XCCDF test result
+type xccdfRuleResult struct {
+	IDRef    string `xml:"idref,attr"`
+	Severity string `xml:"severity,attr"`
+	Result   string `xml:"result"`
+}
+
+// ParseXCCDF reads the rule results of an XCCDF results file, or of an ARF
+// report collection wrapping one, as the Compliance Operator's raw results
+// are exported. Elements are matched by local name, so any XCCDF version works.
+func ParseXCCDF(r io.Reader) ([]Result, error) {
+	decoder := xml.NewDecoder(r)
+	rules := make(map[string]xccdfRule)
+	var ruleResults []xccdfRuleResult
+
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading XCCDF results: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "Rule":
+			var rule xccdfRule
+			if err := decoder.DecodeElement(&rule, &start); err != nil {
+				return nil, fmt.Errorf("error reading XCCDF rule: %w", err)
+			}
+			rules[rule.ID] = rule
+		case "rule-result":
+			var result xccdfRuleResult
+			if err := decoder.DecodeElement(&result, &start); err != nil {
+				return nil, fmt.Errorf("error reading XCCDF rule result: %w", err)
+			}
+			ruleResults = append(ruleResults, result)
+		}
+	}
+
+	if len(ruleResults) == 0 {
+		return nil, ErrNoResults
+	}
+
+	results := make([]Result, 0, len(ruleResults))
+	for _, ruleResult := range ruleResults {
+		status, ok := xccdfStatuses[strings.TrimSpace(ruleResult.Result)]
+		if !ok {
+			return nil, fmt.Errorf("rule %s has unknown result %q", ruleResult.IDRef, ruleResult.Result)
+		}
+
+		rule := rules[ruleResult.IDRef]
+		severity := ruleResult.Severity
+		if severity == "" {
+			severity = rule.Severity
+		}
+		results = append(results, Result{
+			ID:       ruleID(ruleResult.IDRef),
+			Title:    strings.TrimSpace(rule.Title),
+			Status:   status,
+			Severity: severity,
+		})
+	}
+	return results, nil
+}
+
+// ruleID shortens an XCCDF rule ID such as
+// xccdf_org.ssgproject.content_rule_api_server_audit_log_path to the rule
+// name the Compliance Operator uses
+func ruleID(idref string) string {
+	if i := strings.Index(idref, "_rule_"); i >= 0 {
+		return strings.ReplaceAll(idref[i+len("_rule_"):], "_", "-")
+	}
+	return idref
+}
@@ -0,0 +1,263 @@
+// app/server/integrations/confluence/client.go
+
+// Package confluence publishes the executive summary of reports as Confluence
+// pages. Each cluster has one page, found again by a label, which is updated
+// with every new report instead of creating another page.
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// labelPrefix starts the label identifying the page of a cluster
+const labelPrefix = "health-dashboard-"
+
+// Config holds the Confluence connection settings
+type Config struct {
+	// URL is the base URL, including the /wiki path on Confluence Cloud
+	URL   string
+	User  string
+	Token string
+
+	// Space is the key of the space the pages are created in
+	Space string
+
+	// ParentID is the ID of the page new pages are created below; empty
+	// creates them at the top of the space
+	ParentID string
+
+	// Template is a file holding the storage-format body template; empty
+	// uses the built-in executive summary
+	Template string
+}
+
+// Enabled reports whether enough settings are present to publish pages
+func (c Config) Enabled() bool {
+	return c.URL != "" && c.Token != "" && c.Space != ""
+}
+
+// Page is a published page
+type Page struct {
+	ID      string
+	Version int
+	URL     string
+}
+
+// Client is a minimal Confluence REST API client
+type Client struct {
+	config     Config
+	template   *bodyTemplate
+	httpClient *http.Client
+
+	// mu serializes publishes, so two reports of a cluster can't both create its page
+	mu sync.Mutex
+}
+
+// NewClient creates a Confluence client, loading the body template
+func NewClient(config Config) (*Client, error) {
+	template, err := loadTemplate(config.Template)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		config:     config,
+		template:   template,
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: tracing.Transport(nil)},
+	}, nil
+}
+
+// Config returns the client configuration
+func (c *Client) Config() Config {
+	return c.config
+}
+
+// content is the part of a Confluence content object the client uses
+type content struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Links struct {
+		Base  string `json:"base"`
+		WebUI string `json:"webui"`
+	} `json:"_links"`
+}
+
+// Publish renders the page of a cluster and updates it, or creates it when
+// the cluster has no page yet. A non-empty bodyTemplate replaces the
+// configured template for this page.
+func (c *Client) Publish(ctx context.Context, cluster string, data PageData, bodyTemplate string) (*Page, error) {
+	body, err := c.Render(data, bodyTemplate)
+	if err != nil {
+		return nil, err
+	}
+	title := "Health check: " + strings.TrimSpace(cluster)
+	label := ClusterLabel(cluster)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	existing, err := c.findPage(ctx, label)
+	if err != nil {
+		return nil, err
+	}
+
+	storage := map[string]interface{}{
+		"storage": map[string]string{"value": body, "representation": "storage"},
+	}
+	var result content
+	if existing == nil {
+		request := map[string]interface{}{
+			"type":  "page",
+			"title": title,
+			"space": map[string]string{"key": c.config.Space},
+			"body":  storage,
+			"metadata": map[string]interface{}{
+				"labels": []map[string]string{{"prefix": "global", "name": label}},
+			},
+		}
+		if c.config.ParentID != "" {
+			request["ancestors"] = []map[string]string{{"id": c.config.ParentID}}
+		}
+		if err := c.do(ctx, http.MethodPost, "/rest/api/content", request, &result); err != nil {
+			return nil, err
+		}
+	} else {
+		// The page keeps its place in the tree, wherever it was moved to
+		request := map[string]interface{}{
+			"id":      existing.ID,
+			"type":    "page",
+			"title":   title,
+			"body":    storage,
+			"version": map[string]interface{}{"number": existing.Version.Number + 1, "message": data.ReportID},
+		}
+		if err := c.do(ctx, http.MethodPut, "/rest/api/content/"+existing.ID, request, &result); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Page{ID: result.ID, Version: result.Version.Number, URL: c.pageURL(result)}, nil
+}
+
+// Render returns the storage-format body of a page; a non-empty bodyTemplate
+// replaces the configured template
+func (c *Client) Render(data PageData, bodyTemplate string) (string, error) {
+	template := c.template
+	if bodyTemplate != "" {
+		var err error
+		if template, err = parseTemplate(bodyTemplate); err != nil {
+			return "", err
+		}
+	}
+	return template.render(data)
+}
+
+// findPage returns the page carrying the label in the space, or nil if there is none
+func (c *Client) findPage(ctx context.Context, label string) (*content, error) {
+	query := url.Values{}
+	query.Set("cql", fmt.Sprintf(`type = page AND space = "%s" AND label = "%s"`, c.config.Space, label))
+	query.Set("expand", "version")
+	query.Set("limit", "1")
+
+	var result struct {
+		Results []content `json:"results"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/rest/api/content/search?"+query.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Results) == 0 {
+		return nil, nil
+	}
+	return &result.Results[0], nil
+}
+
+// Ping checks that Confluence is reachable and the space is accessible
+func (c *Client) Ping(ctx context.Context) error {
+	var space struct{}
+	return c.do(ctx, http.MethodGet, "/rest/api/space/"+url.PathEscape(c.config.Space), nil, &space)
+}
+
+// ClusterLabel returns the label identifying the page of a cluster; labels
+// are lower case and can't contain spaces
+func ClusterLabel(cluster string) string {
+	var label strings.Builder
+	label.WriteString(labelPrefix)
+	for _, r := range strings.ToLower(strings.TrimSpace(cluster)) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.' {
+			label.WriteRune(r)
+		} else {
+			label.WriteRune('-')
+		}
+	}
+	return label.String()
+}
+
+// pageURL returns the web URL of a page
+func (c *Client) pageURL(page content) string {
+	base := page.Links.Base
+	if base == "" {
+		base = strings.TrimRight(c.config.URL, "/")
+	}
+	if page.Links.WebUI == "" {
+		return base + "/pages/viewpage.action?pageId=" + page.ID
+	}
+	return base + page.Links.WebUI
+}
+
+// do performs an authenticated API request and decodes the JSON response
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error encoding Confluence request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.config.URL, "/")+path, reader)
+	if err != nil {
+		return fmt.Errorf("error creating Confluence request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Confluence Cloud uses user + API token, Data Center uses personal access tokens
+	if c.config.User != "" {
+		req.SetBasicAuth(c.config.User, c.config.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling Confluence: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("confluence returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("error decoding Confluence response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+// app/server/integrations/confluence/template.go
+package confluence
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// defaultTemplate is the built-in executive summary in Confluence storage format
+const defaultTemplate = `{{with .Classification}}<p style="text-align: center;"><strong>{{.}}</strong></p>
+{{end}}<p><ac:structured-macro ac:name="status"><ac:parameter ac:name="colour">{{ragColour .Summary.RAG}}</ac:parameter><ac:parameter ac:name="title">{{printf "%.1f%%" .Summary.OverallScore}}{{with .Summary.Grade}} {{.}}{{end}}</ac:parameter></ac:structured-macro>
+Overall health of <strong>{{.Cluster}}</strong>{{with .Customer}} for {{.}}{{end}}, assessed {{.UploadedAt.Format "January 2, 2006"}}.{{with .PreviousScore}} The previous report scored {{printf "%.1f%%" .}}.{{end}}</p>
+{{with .Summary.Categories}}<h2>Categories</h2>
+<table><tbody>
+<tr><th>Category</th><th>Score</th><th>Grade</th><th>Required</th><th>Recommended</th><th>Advisory</th></tr>
+{{range .}}<tr><td>{{or .Label .Name}}</td><td>{{.Score}}%</td><td>{{.Grade}}</td><td>{{.Required}}</td><td>{{.Recommended}}</td><td>{{.Advisory}}</td></tr>
+{{end}}</tbody></table>
+{{end}}{{with .Summary.ItemsRequired}}<h2>Changes required</h2>
+<ul>{{range .}}<li>{{.}}</li>{{end}}</ul>
+{{end}}{{with .Summary.ItemsRecommended}}<h2>Changes recommended</h2>
+<ul>{{range .}}<li>{{.}}</li>{{end}}</ul>
+{{end}}<p>{{.Summary.NoChangeCount}} items need no change, {{.Summary.NotApplicableCount}} are not applicable.</p>
+{{with .ReportURL}}<p><a href="{{.}}">Open report {{$.ReportID}} in the dashboard</a></p>{{end}}
+{{with .Footer}}<p><em>{{.}}</em></p>
+{{end}}{{with .Classification}}<p style="text-align: center;"><strong>{{.}}</strong></p>
+{{end}}`
+
+// PageData is what the body template renders
+type PageData struct {
+	Cluster    string
+	Customer   string
+	ReportID   string
+	ReportURL  string
+	UploadedAt time.Time
+	Summary    *types.ReportSummary
+
+	// PreviousScore is the overall score of the cluster's previous report, if any
+	PreviousScore *float64
+
+	// Footer and Classification come from the branding, empty when unset
+	Footer         string
+	Classification string
+}
+
+// templateFuncs are available to body templates besides the standard functions
+var templateFuncs = template.FuncMap{
+	// colour names the status macro colour of a score
+	"colour": func(score float64) string {
+		switch {
+		case score >= 80:
+			return "Green"
+		case score >= 60:
+			return "Yellow"
+		default:
+			return "Red"
+		}
+	},
+
+	// ragColour names the status macro colour of a red/amber/green status;
+	// statuses the macro has no colour for are grey
+	"ragColour": func(status string) string {
+		switch strings.ToLower(status) {
+		case "green":
+			return "Green"
+		case "amber", "yellow":
+			return "Yellow"
+		case "red":
+			return "Red"
+		default:
+			return "Grey"
+		}
+	},
+}
+
+// bodyTemplate renders page bodies; the texts of the report are escaped
+type bodyTemplate struct {
+	template *template.Template
+}
+
+// loadTemplate parses the template in path, or the built-in one when path is empty
+func loadTemplate(path string) (*bodyTemplate, error) {
+	text := defaultTemplate
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading Confluence template: %w", err)
+		}
+		text = string(data)
+	}
+	return parseTemplate(text)
+}
+
+// ValidateTemplate checks that a storage-format body template parses
+func ValidateTemplate(text string) error {
+	_, err := parseTemplate(text)
+	return err
+}
+
+// parseTemplate parses a body template
+func parseTemplate(text string) (*bodyTemplate, error) {
+	parsed, err := template.New("page").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing Confluence template: %w", err)
+	}
+	return &bodyTemplate{template: parsed}, nil
+}
+
+// render executes the template for a report
+func (t *bodyTemplate) render(data PageData) (string, error) {
+	var buf bytes.Buffer
+	if err := t.template.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("error rendering Confluence page: %w", err)
+	}
+	return buf.String(), nil
+}
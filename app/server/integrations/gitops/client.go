@@ -0,0 +1,237 @@
+// app/server/integrations/gitops/client.go
+
+// Package gitops commits parsed report summaries to a Git repository, so
+// teams can keep their health history in Git and trigger their own pipelines
+// on changes. Repositories are cloned into memory for each publish; only the
+// tip of the branch is fetched, so the repository size doesn't matter.
+package gitops
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+// Defaults used when the configuration leaves them empty
+const (
+	DefaultBranch      = "main"
+	DefaultPath        = "health-reports"
+	DefaultAuthorName  = "OpenShift Health Dashboard"
+	DefaultAuthorEmail = "health-dashboard@localhost"
+)
+
+// maxAttempts bounds the retries when the branch moved between clone and push
+const maxAttempts = 3
+
+// Config holds the repository settings
+type Config struct {
+	// URL is the HTTPS URL of the repository
+	URL    string
+	Branch string
+
+	// Path is the directory in the repository the reports are written to
+	Path string
+
+	// Username and Token authenticate over HTTPS; most hosting services
+	// accept any username with an access token
+	Username string
+	Token    string
+
+	AuthorName  string
+	AuthorEmail string
+}
+
+// Enabled reports whether a repository is configured
+func (c Config) Enabled() bool {
+	return c.URL != ""
+}
+
+// File is a file to write, relative to the configured path
+type File struct {
+	Name    string
+	Content []byte
+}
+
+// Client publishes files to the configured repository
+type Client struct {
+	config Config
+
+	// mu serializes publishes, which would otherwise race for the branch
+	mu sync.Mutex
+}
+
+// NewClient creates a client, filling in the defaults
+func NewClient(config Config) *Client {
+	if config.Branch == "" {
+		config.Branch = DefaultBranch
+	}
+	if config.Path == "" {
+		config.Path = DefaultPath
+	}
+	if config.AuthorName == "" {
+		config.AuthorName = DefaultAuthorName
+	}
+	if config.AuthorEmail == "" {
+		config.AuthorEmail = DefaultAuthorEmail
+	}
+	return &Client{config: config}
+}
+
+// Config returns the client configuration
+func (c *Client) Config() Config {
+	return c.config
+}
+
+// Publish commits the files to the branch and pushes it, returning the hash of
+// the commit; when the files are unchanged nothing is pushed and the hash of
+// the current tip is returned. A branch that doesn't exist yet is created.
+func (c *Client) Publish(ctx context.Context, files []File, message string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		var hash string
+		hash, err = c.publish(ctx, files, message)
+		if !errors.Is(err, git.ErrNonFastForwardUpdate) {
+			return hash, err
+		}
+		// Someone else pushed meanwhile; start over from the new tip
+	}
+	return "", err
+}
+
+// publish makes a single attempt at committing and pushing the files
+func (c *Client) publish(ctx context.Context, files []File, message string) (string, error) {
+	repo, err := c.checkout(ctx)
+	if err != nil {
+		return "", err
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range files {
+		name := path.Join(c.config.Path, file.Name)
+		if err := writeFile(worktree, name, file.Content); err != nil {
+			return "", fmt.Errorf("error writing %s: %w", name, err)
+		}
+		if _, err := worktree.Add(name); err != nil {
+			return "", fmt.Errorf("error adding %s: %w", name, err)
+		}
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return "", err
+	}
+	if status.IsClean() {
+		head, err := repo.Head()
+		if err != nil {
+			return "", err
+		}
+		return head.Hash().String(), nil
+	}
+
+	hash, err := worktree.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: c.config.AuthorName, Email: c.config.AuthorEmail, When: time.Now()},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error committing: %w", err)
+	}
+
+	branch := plumbing.NewBranchReferenceName(c.config.Branch)
+	err = repo.PushContext(ctx, &git.PushOptions{
+		RemoteName: git.DefaultRemoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(branch + ":" + branch)},
+		Auth:       c.auth(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("error pushing to %s: %w", c.config.URL, err)
+	}
+	return hash.String(), nil
+}
+
+// checkout clones the tip of the branch into memory, or starts an empty
+// repository when the remote or the branch is empty
+func (c *Client) checkout(ctx context.Context) (*git.Repository, error) {
+	branch := plumbing.NewBranchReferenceName(c.config.Branch)
+	repo, err := git.CloneContext(ctx, memory.NewStorage(), memfs.New(), &git.CloneOptions{
+		URL:           c.config.URL,
+		Auth:          c.auth(),
+		ReferenceName: branch,
+		SingleBranch:  true,
+		Depth:         1,
+	})
+	if err == nil {
+		return repo, nil
+	}
+	var noMatch git.NoMatchingRefSpecError
+	if !errors.Is(err, transport.ErrEmptyRemoteRepository) && !errors.As(err, &noMatch) &&
+		!errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, fmt.Errorf("error cloning %s: %w", c.config.URL, err)
+	}
+
+	repo, err = git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{c.config.URL}}); err != nil {
+		return nil, err
+	}
+	if err := repo.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, branch)); err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
+
+// Ping checks that the repository is reachable with the credentials
+func (c *Client) Ping(ctx context.Context) error {
+	remote := git.NewRemote(memory.NewStorage(), &config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{c.config.URL}})
+	_, err := remote.ListContext(ctx, &git.ListOptions{Auth: c.auth()})
+	if err != nil && !errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return fmt.Errorf("error listing %s: %w", c.config.URL, err)
+	}
+	return nil
+}
+
+// auth returns the HTTPS credentials, or nil for a public repository
+func (c *Client) auth() transport.AuthMethod {
+	if c.config.Token == "" {
+		return nil
+	}
+	username := c.config.Username
+	if username == "" {
+		username = "git"
+	}
+	return &githttp.BasicAuth{Username: username, Password: c.config.Token}
+}
+
+// writeFile replaces a file in the worktree, creating its directories
+func writeFile(worktree *git.Worktree, name string, content []byte) error {
+	if err := worktree.Filesystem.MkdirAll(path.Dir(name), 0o755); err != nil {
+		return err
+	}
+	file, err := worktree.Filesystem.Create(name)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(content); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
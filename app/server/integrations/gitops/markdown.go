@@ -0,0 +1,78 @@
+// app/server/integrations/gitops/markdown.go
+package gitops
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// Markdown renders a summary for reading in a repository browser: the scores
+// per category followed by the items that need changes. reportURL links back
+// to the dashboard and may be empty.
+func Markdown(summary *types.ReportSummary, reportURL string) []byte {
+	var out strings.Builder
+
+	title := strings.TrimSpace(summary.ClusterName)
+	if title == "" {
+		title = strings.TrimSpace(summary.CustomerName)
+	}
+	fmt.Fprintf(&out, "# Health check: %s\n\n", title)
+	if summary.CustomerName != "" {
+		fmt.Fprintf(&out, "- **Customer:** %s\n", summary.CustomerName)
+	}
+	fmt.Fprintf(&out, "- **Overall score:** %.1f%%\n", summary.OverallScore)
+	if summary.Grade != "" {
+		fmt.Fprintf(&out, "- **Grade:** %s (%s)\n", summary.Grade, summary.RAG)
+	}
+	if summary.ScoringProfile != "" {
+		fmt.Fprintf(&out, "- **Scoring profile:** %s\n", summary.ScoringProfile)
+	}
+	if reportURL != "" {
+		fmt.Fprintf(&out, "- **Report:** %s\n", reportURL)
+	}
+
+	if len(summary.Categories) > 0 {
+		graded := summary.Grade != ""
+		if graded {
+			out.WriteString("\n## Categories\n\n| Category | Score | Grade |\n| --- | ---: | :---: |\n")
+		} else {
+			out.WriteString("\n## Categories\n\n| Category | Score |\n| --- | ---: |\n")
+		}
+		for _, category := range summary.Categories {
+			label := category.Label
+			if label == "" {
+				label = category.Name
+			}
+			if graded {
+				fmt.Fprintf(&out, "| %s | %d%% | %s (%s) |\n", cell(label), category.Score, cell(category.Grade), cell(category.RAG))
+			} else {
+				fmt.Fprintf(&out, "| %s | %d%% |\n", cell(label), category.Score)
+			}
+		}
+	}
+
+	writeItems(&out, "Changes required", summary.ItemsRequired)
+	writeItems(&out, "Changes recommended", summary.ItemsRecommended)
+	writeItems(&out, "Advisory", summary.ItemsAdvisory)
+
+	fmt.Fprintf(&out, "\n%d items need no change, %d are not applicable.\n", summary.NoChangeCount, summary.NotApplicableCount)
+	return []byte(out.String())
+}
+
+// writeItems writes a section listing items, or nothing when there are none
+func writeItems(out *strings.Builder, heading string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\n## %s (%d)\n\n", heading, len(items))
+	for _, item := range items {
+		fmt.Fprintf(out, "- %s\n", strings.TrimSpace(item))
+	}
+}
+
+// cell escapes text for a table cell
+func cell(text string) string {
+	return strings.ReplaceAll(text, "|", `\|`)
+}
@@ -0,0 +1,207 @@
+// app/server/integrations/insights/client.go
+package insights
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// Default endpoints of the Red Hat Hybrid Cloud Console
+const (
+	DefaultURL      = "https://console.redhat.com"
+	DefaultTokenURL = "https://sso.redhat.com/auth/realms/redhat-external/protocol/openid-connect/token"
+)
+
+// ErrUnknownCluster is returned when Insights has no results for a cluster ID
+var ErrUnknownCluster = errors.New("cluster not known to Insights")
+
+// Config holds the Insights connection settings. A service account's client
+// ID and secret are exchanged for access tokens; a static token is used as is.
+type Config struct {
+	URL      string
+	TokenURL string
+
+	ClientID     string
+	ClientSecret string
+	Token        string
+}
+
+// Enabled reports whether enough settings are present to talk to Insights
+func (c Config) Enabled() bool {
+	return c.Token != "" || (c.ClientID != "" && c.ClientSecret != "")
+}
+
+// Total risk levels of a recommendation
+const (
+	RiskLow       = 1
+	RiskModerate  = 2
+	RiskImportant = 3
+	RiskCritical  = 4
+)
+
+// Recommendation is an Insights Advisor rule hit on a cluster
+type Recommendation struct {
+	RuleID      string    `json:"rule_id"`
+	Description string    `json:"description"`
+	Details     string    `json:"details"`
+	Reason      string    `json:"reason"`
+	Resolution  string    `json:"resolution"`
+	MoreInfo    string    `json:"more_info"`
+	TotalRisk   int       `json:"total_risk"`
+	Tags        []string  `json:"tags"`
+	Disabled    bool      `json:"disabled"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Client is a minimal client of the Insights Advisor API for OpenShift
+type Client struct {
+	config     Config
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expires     time.Time
+}
+
+// NewClient creates a new Insights client
+func NewClient(config Config) *Client {
+	if config.URL == "" {
+		config.URL = DefaultURL
+	}
+	if config.TokenURL == "" {
+		config.TokenURL = DefaultTokenURL
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: tracing.Transport(nil)},
+	}
+}
+
+// Recommendations returns the active recommendations for a cluster, identified
+// by its OpenShift cluster ID; recommendations disabled in Advisor are skipped
+func (c *Client) Recommendations(ctx context.Context, clusterID string) ([]Recommendation, error) {
+	var result struct {
+		Data []Recommendation `json:"data"`
+	}
+	path := "/api/insights-results-aggregator/v2/cluster/" + url.PathEscape(clusterID) + "/reports"
+	if err := c.get(ctx, path, &result); err != nil {
+		return nil, err
+	}
+
+	active := make([]Recommendation, 0, len(result.Data))
+	for _, recommendation := range result.Data {
+		if !recommendation.Disabled {
+			active = append(active, recommendation)
+		}
+	}
+	return active, nil
+}
+
+// pingClusterID is a cluster ID that never exists, used to check access without side effects
+const pingClusterID = "00000000-0000-0000-0000-000000000000"
+
+// Ping checks that Insights is reachable and accepts the credentials; the
+// unknown cluster it asks for is answered with 404 once authenticated
+func (c *Client) Ping(ctx context.Context) error {
+	var result struct{}
+	err := c.get(ctx, "/api/insights-results-aggregator/v2/cluster/"+pingClusterID+"/reports", &result)
+	if errors.Is(err, ErrUnknownCluster) {
+		return nil
+	}
+	return err
+}
+
+// get performs an authenticated API request and decodes the JSON response
+func (c *Client) get(ctx context.Context, path string, out interface{}) error {
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(c.config.URL, "/")+path, nil)
+	if err != nil {
+		return fmt.Errorf("error creating Insights request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling Insights: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("insights has no results for this cluster: %w", ErrUnknownCluster)
+	}
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("insights returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("error decoding Insights response: %w", err)
+	}
+	return nil
+}
+
+// token returns a bearer token, exchanging the service account credentials
+// for a new access token shortly before the current one expires
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.config.Token != "" {
+		return c.config.Token, nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.accessToken != "" && time.Now().Before(c.expires) {
+		return c.accessToken, nil
+	}
+
+	form := url.Values{
+		"grant_type":    {"client_credentials"},
+		"client_id":     {c.config.ClientID},
+		"client_secret": {c.config.ClientSecret},
+		"scope":         {"api.console"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("error creating token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error requesting an Insights access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("token endpoint returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("error decoding token response: %w", err)
+	}
+
+	// Renew a minute early so a token doesn't expire mid-request
+	c.accessToken = result.AccessToken
+	c.expires = time.Now().Add(time.Duration(result.ExpiresIn)*time.Second - time.Minute)
+	return c.accessToken, nil
+}
@@ -0,0 +1,64 @@
+// app/server/integrations/insights/findings.go
+package insights
+
+import (
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// Source marks findings imported from Insights
+const Source = "insights"
+
+// advisorURL is the console page of a recommendation
+const advisorURL = "https://console.redhat.com/openshift/insights/advisor/recommendations/"
+
+// Finding converts a recommendation to a finding. Critical and important
+// risks require changes, moderate ones are recommended and low ones advisory.
+func (r Recommendation) Finding() types.Finding {
+	var status types.ResultKey
+	switch {
+	case r.TotalRisk >= RiskImportant:
+		status = types.ResultKeyRequired
+	case r.TotalRisk == RiskModerate:
+		status = types.ResultKeyRecommended
+	default:
+		status = types.ResultKeyAdvisory
+	}
+
+	observation := strings.TrimSpace(r.Reason)
+	if observation == "" {
+		observation = strings.TrimSpace(r.Details)
+	}
+
+	references := []string{advisorURL + r.RuleID}
+	if r.MoreInfo != "" {
+		references = append(references, r.MoreInfo)
+	}
+
+	return types.Finding{
+		ID:             Source + ":" + r.RuleID,
+		Title:          r.Description,
+		Category:       r.category(),
+		Status:         status,
+		Severity:       utils.SeverityForStatus(status),
+		Observation:    observation,
+		Recommendation: strings.TrimSpace(r.Resolution),
+		References:     references,
+		Source:         Source,
+	}
+}
+
+// category maps the rule's tags to a report category
+func (r Recommendation) category() string {
+	for _, tag := range r.Tags {
+		switch tag {
+		case "security":
+			return "Security"
+		case "performance":
+			return "Performance"
+		}
+	}
+	return "Cluster Config"
+}
@@ -0,0 +1,167 @@
+// app/server/integrations/jira/client.go
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// Config holds the Jira connection settings
+type Config struct {
+	URL       string
+	User      string
+	Token     string
+	Project   string
+	IssueType string
+
+	// Priority names used for each item status
+	PriorityRequired    string
+	PriorityRecommended string
+}
+
+// Enabled reports whether enough settings are present to talk to Jira
+func (c Config) Enabled() bool {
+	return c.URL != "" && c.Token != "" && c.Project != ""
+}
+
+// Client is a minimal Jira REST API client
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// IssueRequest describes an issue to be created
+type IssueRequest struct {
+	Summary     string
+	Description string
+	Priority    string
+	Labels      []string
+}
+
+// NewClient creates a new Jira client
+func NewClient(config Config) *Client {
+	if config.IssueType == "" {
+		config.IssueType = "Task"
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: &http.Client{Timeout: 30 * time.Second, Transport: tracing.Transport(nil)},
+	}
+}
+
+// Config returns the client configuration
+func (c *Client) Config() Config {
+	return c.config
+}
+
+// FindIssueByLabel returns the key of an existing issue carrying the given label, or "" if none exists
+func (c *Client) FindIssueByLabel(ctx context.Context, label string) (string, error) {
+	jql := fmt.Sprintf(`project = "%s" AND labels = "%s"`, c.config.Project, label)
+	query := url.Values{}
+	query.Set("jql", jql)
+	query.Set("fields", "key")
+	query.Set("maxResults", "1")
+
+	var result struct {
+		Issues []struct {
+			Key string `json:"key"`
+		} `json:"issues"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/rest/api/2/search?"+query.Encode(), nil, &result); err != nil {
+		return "", err
+	}
+
+	if len(result.Issues) == 0 {
+		return "", nil
+	}
+	return result.Issues[0].Key, nil
+}
+
+// CreateIssue creates a new issue and returns its key
+func (c *Client) CreateIssue(ctx context.Context, req IssueRequest) (string, error) {
+	fields := map[string]interface{}{
+		"project":     map[string]string{"key": c.config.Project},
+		"summary":     req.Summary,
+		"description": req.Description,
+		"issuetype":   map[string]string{"name": c.config.IssueType},
+		"labels":      req.Labels,
+	}
+	if req.Priority != "" {
+		fields["priority"] = map[string]string{"name": req.Priority}
+	}
+
+	var result struct {
+		Key string `json:"key"`
+	}
+	if err := c.do(ctx, http.MethodPost, "/rest/api/2/issue", map[string]interface{}{"fields": fields}, &result); err != nil {
+		return "", err
+	}
+	return result.Key, nil
+}
+
+// Ping checks that Jira is reachable and accepts the credentials
+func (c *Client) Ping(ctx context.Context) error {
+	var user struct{}
+	return c.do(ctx, http.MethodGet, "/rest/api/2/myself", nil, &user)
+}
+
+// BrowseURL returns the web URL of an issue
+func (c *Client) BrowseURL(key string) string {
+	return strings.TrimRight(c.config.URL, "/") + "/browse/" + key
+}
+
+// do performs an authenticated API request and decodes the JSON response
+func (c *Client) do(ctx context.Context, method, path string, body interface{}, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("error encoding Jira request: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimRight(c.config.URL, "/")+path, reader)
+	if err != nil {
+		return fmt.Errorf("error creating Jira request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	// Jira Cloud uses user + API token, Jira Data Center uses personal access tokens
+	if c.config.User != "" {
+		req.SetBasicAuth(c.config.User, c.config.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error calling Jira: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("jira returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("error decoding Jira response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,348 @@
+// app/server/jobs/queue.go
+package jobs
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+)
+
+// Class is the priority class of a job
+type Class string
+
+const (
+	// ClassInteractive is used for work a user is actively waiting on, such as uploads
+	ClassInteractive Class = "interactive"
+
+	// ClassBatch is used for background work such as bulk imports, exports and re-scores
+	ClassBatch Class = "batch"
+)
+
+// classes lists the priority classes from highest to lowest priority
+var classes = []Class{ClassInteractive, ClassBatch}
+
+// Status is the lifecycle state of a job
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// ErrQueueStopped is returned when submitting to a queue that has been stopped
+var ErrQueueStopped = errors.New("job queue stopped")
+
+// Func is the work performed by a job
+type Func func(ctx context.Context) (interface{}, error)
+
+// Job is a unit of work tracked by the queue
+type Job struct {
+	ID         string    `json:"id"`
+	Kind       string    `json:"kind"`
+	Class      Class     `json:"class"`
+	Status     Status    `json:"status"`
+	Error      string    `json:"error,omitempty"`
+	QueuedAt   time.Time `json:"queuedAt"`
+	StartedAt  time.Time `json:"startedAt,omitempty"`
+	FinishedAt time.Time `json:"finishedAt,omitempty"`
+
+	// Result is the value returned by a succeeded job
+	Result interface{} `json:"result,omitempty"`
+
+	fn     Func
+	result interface{}
+	err    error
+	done   chan struct{}
+}
+
+// Wait blocks until the job finishes or ctx is cancelled and returns the job result
+func (j *Job) Wait(ctx context.Context) (interface{}, error) {
+	select {
+	case <-j.done:
+		return j.result, j.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Config holds the per-class concurrency limits
+type Config struct {
+	InteractiveWorkers int
+	BatchWorkers       int
+
+	// Retention is how long finished jobs can still be looked up
+	Retention time.Duration
+}
+
+// ClassStats holds the metrics for a single priority class
+type ClassStats struct {
+	Limit       int     `json:"limit"`
+	Queued      int     `json:"queued"`
+	Running     int     `json:"running"`
+	Succeeded   int64   `json:"succeeded"`
+	Failed      int64   `json:"failed"`
+	AvgWaitMs   float64 `json:"avgWaitMs"`
+	MaxWaitMs   int64   `json:"maxWaitMs"`
+	AvgRunMs    float64 `json:"avgRunMs"`
+	totalWaitMs int64
+	totalRunMs  int64
+}
+
+// Queue dispatches jobs to workers by priority class. Each class has its own
+// concurrency limit, and batch jobs are only started while no interactive job
+// is waiting, so background work never delays an upload.
+type Queue struct {
+	mu        sync.Mutex
+	limits    map[Class]int
+	pending   map[Class][]*Job
+	stats     map[Class]*ClassStats
+	jobs      map[string]*Job
+	retention time.Duration
+	ctx       context.Context
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	stopped   bool
+}
+
+// NewQueue creates a new job queue
+func NewQueue(config Config) *Queue {
+	if config.InteractiveWorkers <= 0 {
+		config.InteractiveWorkers = 4
+	}
+	if config.BatchWorkers <= 0 {
+		config.BatchWorkers = 1
+	}
+	if config.Retention <= 0 {
+		config.Retention = time.Hour
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	q := &Queue{
+		limits: map[Class]int{
+			ClassInteractive: config.InteractiveWorkers,
+			ClassBatch:       config.BatchWorkers,
+		},
+		pending:   make(map[Class][]*Job),
+		stats:     make(map[Class]*ClassStats),
+		jobs:      make(map[string]*Job),
+		retention: config.Retention,
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	for _, class := range classes {
+		q.stats[class] = &ClassStats{Limit: q.limits[class]}
+	}
+
+	return q
+}
+
+// Submit enqueues a job in the given class
+func (q *Queue) Submit(class Class, kind string, fn Func) (*Job, error) {
+	if _, ok := q.limits[class]; !ok {
+		class = ClassBatch
+	}
+
+	job := &Job{
+		ID:       newJobID(),
+		Kind:     kind,
+		Class:    class,
+		Status:   StatusQueued,
+		QueuedAt: time.Now().UTC(),
+		fn:       fn,
+		done:     make(chan struct{}),
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.stopped {
+		return nil, ErrQueueStopped
+	}
+
+	q.pruneLocked(job.QueuedAt)
+	q.jobs[job.ID] = job
+	q.pending[class] = append(q.pending[class], job)
+	q.stats[class].Queued++
+	q.dispatchLocked()
+
+	return job, nil
+}
+
+// Run submits a job and waits for its result
+func (q *Queue) Run(ctx context.Context, class Class, kind string, fn Func) (interface{}, error) {
+	job, err := q.Submit(class, kind, fn)
+	if err != nil {
+		return nil, err
+	}
+	return job.Wait(ctx)
+}
+
+// Get returns a snapshot of a queued, running or recently finished job
+func (q *Queue) Get(id string) (Job, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job, ok := q.jobs[id]
+	if !ok || job.finishedBefore(time.Now().Add(-q.retention)) {
+		return Job{}, false
+	}
+	return Job{
+		ID:         job.ID,
+		Kind:       job.Kind,
+		Class:      job.Class,
+		Status:     job.Status,
+		Error:      job.Error,
+		QueuedAt:   job.QueuedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+		Result:     job.Result,
+	}, true
+}
+
+// Stats returns a snapshot of the per-class metrics
+func (q *Queue) Stats() map[Class]ClassStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	snapshot := make(map[Class]ClassStats, len(q.stats))
+	for class, stats := range q.stats {
+		s := *stats
+		if finished := s.Succeeded + s.Failed; finished > 0 {
+			s.AvgWaitMs = float64(s.totalWaitMs) / float64(finished)
+			s.AvgRunMs = float64(s.totalRunMs) / float64(finished)
+		}
+		snapshot[class] = s
+	}
+	return snapshot
+}
+
+// Stop cancels running jobs, fails queued ones and waits for workers to exit
+func (q *Queue) Stop() {
+	q.mu.Lock()
+	q.stopped = true
+	for _, class := range classes {
+		for _, job := range q.pending[class] {
+			job.Status = StatusFailed
+			job.FinishedAt = time.Now().UTC()
+			job.err = ErrQueueStopped
+			job.Error = ErrQueueStopped.Error()
+			close(job.done)
+		}
+		q.pending[class] = nil
+		q.stats[class].Queued = 0
+	}
+	q.mu.Unlock()
+
+	q.cancel()
+	q.wg.Wait()
+}
+
+// dispatchLocked starts as many pending jobs as the class limits allow; q.mu must be held
+func (q *Queue) dispatchLocked() {
+	for _, class := range classes {
+		// Hold back lower classes while a higher class still has work waiting
+		if class == ClassBatch && len(q.pending[ClassInteractive]) > 0 {
+			return
+		}
+
+		stats := q.stats[class]
+		for len(q.pending[class]) > 0 && stats.Running < q.limits[class] {
+			job := q.pending[class][0]
+			q.pending[class] = q.pending[class][1:]
+			stats.Queued--
+			stats.Running++
+
+			job.Status = StatusRunning
+			job.StartedAt = time.Now().UTC()
+
+			q.wg.Add(1)
+			go q.run(job)
+		}
+	}
+}
+
+// run executes a job and records its outcome
+func (q *Queue) run(job *Job) {
+	defer q.wg.Done()
+
+	result, err := job.call(q.ctx)
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	job.FinishedAt = time.Now().UTC()
+	job.result = result
+	job.err = err
+	if err == nil {
+		job.Result = result
+	}
+
+	stats := q.stats[job.Class]
+	stats.Running--
+	if err != nil {
+		job.Status = StatusFailed
+		job.Error = err.Error()
+		stats.Failed++
+	} else {
+		job.Status = StatusSucceeded
+		stats.Succeeded++
+	}
+
+	wait := job.StartedAt.Sub(job.QueuedAt).Milliseconds()
+	stats.totalWaitMs += wait
+	stats.totalRunMs += job.FinishedAt.Sub(job.StartedAt).Milliseconds()
+	if wait > stats.MaxWaitMs {
+		stats.MaxWaitMs = wait
+	}
+
+	close(job.done)
+
+	// A slot freed up, start the next job
+	if !q.stopped {
+		q.dispatchLocked()
+	}
+}
+
+// call runs the work of a job. Jobs run outside the request that submitted
+// them, without the panic recovery of net/http, so a panic fails the job
+// instead of taking the server down.
+func (j *Job) call(ctx context.Context) (result interface{}, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Printf("Job %s (%s) panicked: %v\n%s", j.ID, j.Kind, p, debug.Stack())
+			result, err = nil, fmt.Errorf("job panicked: %v", p)
+		}
+	}()
+	return j.fn(ctx)
+}
+
+// pruneLocked forgets the jobs that finished longer than the retention ago; q.mu must be held
+func (q *Queue) pruneLocked(now time.Time) {
+	cutoff := now.Add(-q.retention)
+	for id, job := range q.jobs {
+		if job.finishedBefore(cutoff) {
+			delete(q.jobs, id)
+		}
+	}
+}
+
+// finishedBefore reports whether the job finished before the given time
+func (j *Job) finishedBefore(t time.Time) bool {
+	return !j.FinishedAt.IsZero() && j.FinishedAt.Before(t)
+}
+
+// newJobID generates a random job ID
+func newJobID() string {
+	buf := make([]byte, 8)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
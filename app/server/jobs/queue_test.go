@@ -0,0 +1,35 @@
+// app/server/jobs/queue_test.go
+package jobs
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestPanickingJob(t *testing.T) {
+	q := NewQueue(Config{InteractiveWorkers: 1})
+	defer q.Stop()
+
+	_, err := q.Run(context.Background(), ClassInteractive, "parse", func(ctx context.Context) (interface{}, error) {
+		var summary map[string]int
+		summary["score"] = 1
+		return summary, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "panicked") {
+		t.Fatalf("panicking job returned %v, want a panic error", err)
+	}
+
+	// The worker slot is freed, so the queue keeps running jobs
+	result, err := q.Run(context.Background(), ClassInteractive, "parse", func(ctx context.Context) (interface{}, error) {
+		return "ok", nil
+	})
+	if err != nil || result != "ok" {
+		t.Fatalf("job after the panic returned %v, %v", result, err)
+	}
+
+	stats := q.Stats()[ClassInteractive]
+	if stats.Failed != 1 || stats.Succeeded != 1 || stats.Running != 0 {
+		t.Errorf("stats %+v, want one failed and one succeeded job", stats)
+	}
+}
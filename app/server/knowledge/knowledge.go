@@ -0,0 +1,207 @@
+// app/server/knowledge/knowledge.go
+
+// Package knowledge maps known finding titles to remediation guidance. The
+// built-in catalog is embedded in the binary; a mounted directory of YAML
+// files can add entries or replace built-in ones with the same title.
+package knowledge
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"unicode"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+//go:embed catalog/*.yaml
+var builtin embed.FS
+
+// Effort levels an entry may be estimated at
+var effortLevels = []string{"low", "medium", "high"}
+
+// Entry is the guidance for one finding, matched by its title or an alias
+type Entry struct {
+	Title      string   `yaml:"title"`
+	Aliases    []string `yaml:"aliases"`
+	Steps      []string `yaml:"steps"`
+	References []string `yaml:"references"`
+	Effort     string   `yaml:"effort"`
+
+	// Remediation is the suggested fix, if the finding has a known one
+	Remediation *Remediation `yaml:"remediation"`
+}
+
+// file is the YAML layout of a catalog file
+type file struct {
+	Entries []Entry `yaml:"entries"`
+}
+
+// Catalog holds the knowledge base and swaps it atomically on reload
+type Catalog struct {
+	dir     string
+	entries atomic.Pointer[map[string]*Entry]
+}
+
+// New loads the built-in catalog and the YAML files in dir; an empty dir uses
+// the built-in catalog only
+func New(dir string) (*Catalog, error) {
+	c := &Catalog{dir: dir}
+	if err := c.Reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Reload re-reads the catalog files; on error the current catalog stays in effect
+func (c *Catalog) Reload() error {
+	entries := make(map[string]*Entry)
+	if err := loadFS(entries, builtin, "catalog"); err != nil {
+		return fmt.Errorf("error loading the built-in knowledge base: %w", err)
+	}
+
+	if c.dir != "" {
+		_, err := os.Stat(c.dir)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			log.Printf("Knowledge base directory %s does not exist, using the built-in catalog", c.dir)
+		case err != nil:
+			return fmt.Errorf("error reading knowledge base directory: %w", err)
+		default:
+			if err := loadFS(entries, os.DirFS(c.dir), "."); err != nil {
+				return err
+			}
+		}
+	}
+
+	c.entries.Store(&entries)
+	log.Printf("Loaded knowledge base matching %d titles and aliases", c.Len())
+	return nil
+}
+
+// loadFS adds the entries of every YAML file in a directory; later files
+// replace entries with the same title
+func loadFS(entries map[string]*Entry, fsys fs.FS, dir string) error {
+	names, err := fs.Glob(fsys, path.Join(dir, "*.yaml"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", filepath.Base(name), err)
+		}
+
+		var f file
+		if err := yaml.UnmarshalStrict(data, &f); err != nil {
+			return fmt.Errorf("error parsing %s: %w", filepath.Base(name), err)
+		}
+		for i := range f.Entries {
+			entry := &f.Entries[i]
+			if err := entry.validate(); err != nil {
+				return fmt.Errorf("%s: %w", filepath.Base(name), err)
+			}
+			for _, key := range entry.keys() {
+				entries[key] = entry
+			}
+		}
+	}
+	return nil
+}
+
+// validate checks that an entry can be matched and tells the reader what to do
+func (e *Entry) validate() error {
+	if normalize(e.Title) == "" {
+		return errors.New("an entry has no title")
+	}
+	if len(e.Steps) == 0 {
+		return fmt.Errorf("entry %q has no remediation steps", e.Title)
+	}
+	if e.Effort != "" && !isEffortLevel(e.Effort) {
+		return fmt.Errorf("entry %q has effort %q, expected one of %s",
+			e.Title, e.Effort, strings.Join(effortLevels, ", "))
+	}
+	if e.Remediation != nil {
+		if err := e.Remediation.validate(); err != nil {
+			return fmt.Errorf("entry %q: %w", e.Title, err)
+		}
+	}
+	return nil
+}
+
+// keys returns the normalized titles an entry is matched by
+func (e *Entry) keys() []string {
+	keys := []string{normalize(e.Title)}
+	for _, alias := range e.Aliases {
+		if key := normalize(alias); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// Len returns the number of distinct titles and aliases in the catalog
+func (c *Catalog) Len() int {
+	return len(*c.entries.Load())
+}
+
+// Lookup returns the guidance for a finding, matched by its title or its ID
+func (c *Catalog) Lookup(finding types.Finding) (*types.Guidance, bool) {
+	entries := *c.entries.Load()
+	for _, name := range []string{finding.Title, finding.ID} {
+		if entry, ok := entries[normalize(name)]; ok {
+			return &types.Guidance{
+				Steps:       entry.Steps,
+				References:  entry.References,
+				Effort:      entry.Effort,
+				Remediation: entry.Remediation != nil,
+			}, true
+		}
+	}
+	return nil, false
+}
+
+// Enrich returns a copy of a summary whose findings carry the matching guidance;
+// the summary itself is not changed
+func (c *Catalog) Enrich(summary *types.ReportSummary) *types.ReportSummary {
+	enriched := *summary
+	enriched.Findings = make([]types.Finding, len(summary.Findings))
+	for i, finding := range summary.Findings {
+		if guidance, ok := c.Lookup(finding); ok {
+			finding.Guidance = guidance
+		}
+		enriched.Findings[i] = finding
+	}
+	return &enriched
+}
+
+// normalize lowercases a title and collapses punctuation and spacing, so
+// "Kubeadmin user" and "kubeadmin-user" match the same entry
+func normalize(title string) string {
+	fields := strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// isEffortLevel reports whether an effort is one of the known levels
+func isEffortLevel(effort string) bool {
+	for _, level := range effortLevels {
+		if effort == level {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,109 @@
+// app/server/knowledge/remediation.go
+package knowledge
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// placeholderNamespace fills in the namespace of a remediation requested
+// without one, so the reader sees where it goes
+const placeholderNamespace = "<namespace>"
+
+// Remediation is the suggested fix of an entry. Manifests and commands are Go
+// templates executed with RemediationParams; the dashboard only shows them.
+type Remediation struct {
+	Manifests []ManifestTemplate `yaml:"manifests"`
+	Commands  []string           `yaml:"commands"`
+}
+
+// ManifestTemplate is a suggested YAML manifest with what it does
+type ManifestTemplate struct {
+	Description string `yaml:"description"`
+	YAML        string `yaml:"yaml"`
+}
+
+// RemediationParams are the values the remediation templates are filled in with
+type RemediationParams struct {
+	// Namespace is the namespace namespaced manifests and commands target
+	Namespace string
+}
+
+// validate makes sure the templates parse and the manifests they produce are YAML
+func (r *Remediation) validate() error {
+	if len(r.Manifests) == 0 && len(r.Commands) == 0 {
+		return fmt.Errorf("remediation has no manifests or commands")
+	}
+	if _, err := r.render(RemediationParams{Namespace: placeholderNamespace}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// render executes the templates of a remediation
+func (r *Remediation) render(params RemediationParams) (*types.Remediation, error) {
+	rendered := &types.Remediation{}
+	for i, manifest := range r.Manifests {
+		text, err := execute(manifest.YAML, params)
+		if err != nil {
+			return nil, fmt.Errorf("manifest %d: %w", i+1, err)
+		}
+		var parsed map[string]interface{}
+		if err := yaml.Unmarshal([]byte(text), &parsed); err != nil {
+			return nil, fmt.Errorf("manifest %d: %w", i+1, err)
+		}
+		if parsed["apiVersion"] == nil || parsed["kind"] == nil {
+			return nil, fmt.Errorf("manifest %d has no apiVersion or kind", i+1)
+		}
+		rendered.Manifests = append(rendered.Manifests, types.Manifest{
+			Description: manifest.Description,
+			YAML:        text + "\n",
+		})
+	}
+	for i, command := range r.Commands {
+		text, err := execute(command, params)
+		if err != nil {
+			return nil, fmt.Errorf("command %d: %w", i+1, err)
+		}
+		rendered.Commands = append(rendered.Commands, text)
+	}
+	return rendered, nil
+}
+
+// execute fills in a remediation template
+func execute(text string, params RemediationParams) (string, error) {
+	t, err := template.New("remediation").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b bytes.Buffer
+	if err := t.Execute(&b, params); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// Remediation returns the suggested fix for a finding ID or title, filled in
+// with params; an empty namespace leaves a placeholder
+func (c *Catalog) Remediation(finding string, params RemediationParams) (*types.Remediation, bool, error) {
+	entry, ok := (*c.entries.Load())[normalize(finding)]
+	if !ok || entry.Remediation == nil {
+		return nil, false, nil
+	}
+	if params.Namespace == "" {
+		params.Namespace = placeholderNamespace
+	}
+
+	rendered, err := entry.Remediation.render(params)
+	if err != nil {
+		return nil, true, fmt.Errorf("error rendering the remediation of %q: %w", entry.Title, err)
+	}
+	rendered.Title = entry.Title
+	return rendered, true, nil
+}
@@ -0,0 +1,161 @@
+// app/server/live/client.go
+package live
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	configclient "github.com/openshift/client-go/config/clientset/versioned"
+	mcclient "github.com/openshift/client-go/machineconfiguration/clientset/versioned"
+	operatorclient "github.com/openshift/client-go/operator/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// Config holds the live mode settings
+type Config struct {
+	Enabled     bool
+	Kubeconfig  string
+	ClusterName string
+
+	// PrometheusURL is the Prometheus or Thanos querier endpoint of the
+	// cluster; monitoring checks are skipped without one
+	PrometheusURL string
+}
+
+// Limits bound the load the clients of one cluster put on its API server;
+// zero values keep the client-go defaults of 5 requests per second and a burst of 10
+type Limits struct {
+	QPS   float32
+	Burst int
+}
+
+// Clients bundles the API clients used to inspect a live cluster
+type Clients struct {
+	ClusterName string
+	RestConfig  *rest.Config
+	Kube        kubernetes.Interface
+	Config      configclient.Interface
+	Operator    operatorclient.Interface
+	MachineConf mcclient.Interface
+
+	// Dynamic reads custom resources of add-on operators, such as Velero
+	Dynamic dynamic.Interface
+
+	// Metadata lists only the metadata of objects, to tell cheaply whether
+	// the inputs of a check changed
+	Metadata metadata.Interface
+
+	// Prometheus is nil when the cluster has no Prometheus endpoint configured
+	Prometheus *Prometheus
+}
+
+// Connect creates clients for the cluster described by config, using the
+// in-cluster service account when no kubeconfig is given
+func Connect(config Config, limits Limits) (*Clients, error) {
+	var restConfig *rest.Config
+	var err error
+
+	if config.Kubeconfig != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", config.Kubeconfig)
+	} else {
+		restConfig, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading cluster credentials: %w", err)
+	}
+
+	clients, err := NewClients(config.ClusterName, restConfig, limits)
+	if err != nil {
+		return nil, err
+	}
+	if config.PrometheusURL != "" {
+		if clients.Prometheus, err = NewPrometheus(config.PrometheusURL, restConfig); err != nil {
+			return nil, err
+		}
+	}
+	return clients, nil
+}
+
+// Ping checks that the cluster's API server is reachable with the credentials
+func (c *Clients) Ping(ctx context.Context) error {
+	if err := c.Kube.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error(); err != nil {
+		return fmt.Errorf("error calling the Kubernetes API: %w", err)
+	}
+	return nil
+}
+
+// NewClients creates clients from a REST config, resolving the cluster name if it is empty
+func NewClients(clusterName string, restConfig *rest.Config, limits Limits) (*Clients, error) {
+	// Trace and rate limit the API calls of scans without changing the caller's config
+	restConfig = rest.CopyConfig(restConfig)
+	restConfig.Wrap(tracing.Transport)
+	if limits.QPS > 0 {
+		restConfig.QPS = limits.QPS
+	}
+	if limits.Burst > 0 {
+		restConfig.Burst = limits.Burst
+	}
+
+	kube, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Kubernetes client: %w", err)
+	}
+
+	config, err := configclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OpenShift config client: %w", err)
+	}
+
+	operator, err := operatorclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating OpenShift operator client: %w", err)
+	}
+
+	machineConf, err := mcclient.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating machine config client: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating dynamic client: %w", err)
+	}
+
+	metadataClient, err := metadata.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating metadata client: %w", err)
+	}
+
+	clients := &Clients{
+		ClusterName: clusterName,
+		RestConfig:  restConfig,
+		Kube:        kube,
+		Config:      config,
+		Operator:    operator,
+		MachineConf: machineConf,
+		Dynamic:     dynamicClient,
+		Metadata:    metadataClient,
+	}
+
+	// Fall back to the infrastructure name so timelines have a stable key
+	if clients.ClusterName == "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		infra, err := config.ConfigV1().Infrastructures().Get(ctx, "cluster", metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("cluster name not configured and infrastructure lookup failed: %w", err)
+		}
+		clients.ClusterName = infra.Status.InfrastructureName
+	}
+
+	return clients, nil
+}
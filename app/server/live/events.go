@@ -0,0 +1,183 @@
+// app/server/live/events.go
+package live
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	configinformers "github.com/openshift/client-go/config/informers/externalversions"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// notableEventReasons lists the Kubernetes event reasons worth keeping on the timeline
+var notableEventReasons = map[string]bool{
+	"NodeNotReady":              true,
+	"NodeNotSchedulable":        true,
+	"Rebooted":                  true,
+	"SystemOOM":                 true,
+	"EvictionThresholdMet":      true,
+	"NodeHasDiskPressure":       true,
+	"NodeHasInsufficientMemory": true,
+	"NodeHasInsufficientPID":    true,
+}
+
+// EventSink receives captured cluster events
+type EventSink interface {
+	AppendEvent(event store.ClusterEvent) error
+}
+
+// EventRecorder watches a live cluster and records notable changes between checks
+type EventRecorder struct {
+	clients *Clients
+	sink    EventSink
+	started time.Time
+}
+
+// NewEventRecorder creates a recorder for the given cluster
+func NewEventRecorder(clients *Clients, sink EventSink) *EventRecorder {
+	return &EventRecorder{
+		clients: clients,
+		sink:    sink,
+	}
+}
+
+// Run watches nodes, cluster operators and events until ctx is cancelled
+func (r *EventRecorder) Run(ctx context.Context) {
+	r.started = time.Now()
+
+	kubeInformers := informers.NewSharedInformerFactory(r.clients.Kube, 30*time.Minute)
+	configInformers := configinformers.NewSharedInformerFactory(r.clients.Config, 30*time.Minute)
+
+	kubeInformers.Core().V1().Nodes().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldNode, ok1 := oldObj.(*corev1.Node)
+			newNode, ok2 := newObj.(*corev1.Node)
+			if ok1 && ok2 {
+				r.onNodeUpdate(oldNode, newNode)
+			}
+		},
+	})
+
+	configInformers.Config().V1().ClusterOperators().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldCO, ok1 := oldObj.(*configv1.ClusterOperator)
+			newCO, ok2 := newObj.(*configv1.ClusterOperator)
+			if ok1 && ok2 {
+				r.onClusterOperatorUpdate(oldCO, newCO)
+			}
+		},
+	})
+
+	kubeInformers.Core().V1().Events().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if event, ok := obj.(*corev1.Event); ok {
+				r.onEvent(event)
+			}
+		},
+	})
+
+	kubeInformers.Start(ctx.Done())
+	configInformers.Start(ctx.Done())
+
+	log.Printf("Recording cluster events for %s", r.clients.ClusterName)
+	<-ctx.Done()
+	kubeInformers.Shutdown()
+	configInformers.Shutdown()
+}
+
+// onNodeUpdate records Ready condition transitions
+func (r *EventRecorder) onNodeUpdate(oldNode, newNode *corev1.Node) {
+	oldStatus := nodeConditionStatus(oldNode, corev1.NodeReady)
+	newStatus := nodeConditionStatus(newNode, corev1.NodeReady)
+	if oldStatus == newStatus {
+		return
+	}
+
+	if newStatus == corev1.ConditionTrue {
+		r.record("Node", newNode.Name, "NodeReady", "Node became ready", "info")
+	} else {
+		r.record("Node", newNode.Name, "NodeNotReady",
+			fmt.Sprintf("Node Ready condition changed to %s", newStatus), "warning")
+	}
+}
+
+// onClusterOperatorUpdate records Degraded and Available condition transitions
+func (r *EventRecorder) onClusterOperatorUpdate(oldCO, newCO *configv1.ClusterOperator) {
+	oldDegraded := operatorCondition(oldCO, configv1.OperatorDegraded)
+	newDegraded := operatorCondition(newCO, configv1.OperatorDegraded)
+	if oldDegraded.Status != newDegraded.Status {
+		if newDegraded.Status == configv1.ConditionTrue {
+			r.record("ClusterOperator", newCO.Name, "OperatorDegraded", newDegraded.Message, "critical")
+		} else {
+			r.record("ClusterOperator", newCO.Name, "OperatorRecovered", "Operator is no longer degraded", "info")
+		}
+	}
+
+	oldAvailable := operatorCondition(oldCO, configv1.OperatorAvailable)
+	newAvailable := operatorCondition(newCO, configv1.OperatorAvailable)
+	if oldAvailable.Status == configv1.ConditionTrue && newAvailable.Status != configv1.ConditionTrue {
+		r.record("ClusterOperator", newCO.Name, "OperatorUnavailable", newAvailable.Message, "critical")
+	}
+}
+
+// onEvent records notable warning events emitted after the recorder started
+func (r *EventRecorder) onEvent(event *corev1.Event) {
+	if event.Type != corev1.EventTypeWarning || !notableEventReasons[event.Reason] {
+		return
+	}
+
+	// The initial list replays old events, only keep the ones we haven't seen
+	seen := event.LastTimestamp.Time
+	if seen.IsZero() {
+		seen = event.EventTime.Time
+	}
+	if seen.Before(r.started) {
+		return
+	}
+
+	r.record(event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Reason, event.Message, "warning")
+}
+
+// record stores a single timeline event
+func (r *EventRecorder) record(kind, object, reason, message, severity string) {
+	event := store.ClusterEvent{
+		Time:     time.Now().UTC(),
+		Cluster:  r.clients.ClusterName,
+		Kind:     kind,
+		Object:   object,
+		Reason:   reason,
+		Message:  message,
+		Severity: severity,
+	}
+
+	if err := r.sink.AppendEvent(event); err != nil {
+		log.Printf("Error recording %s event for %s/%s: %v", reason, kind, object, err)
+	}
+}
+
+// nodeConditionStatus returns the status of a node condition, or Unknown if it is missing
+func nodeConditionStatus(node *corev1.Node, conditionType corev1.NodeConditionType) corev1.ConditionStatus {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status
+		}
+	}
+	return corev1.ConditionUnknown
+}
+
+// operatorCondition returns a cluster operator condition, or an Unknown condition if it is missing
+func operatorCondition(co *configv1.ClusterOperator, conditionType configv1.ClusterStatusConditionType) configv1.ClusterOperatorStatusCondition {
+	for _, condition := range co.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition
+		}
+	}
+	return configv1.ClusterOperatorStatusCondition{Type: conditionType, Status: configv1.ConditionUnknown}
+}
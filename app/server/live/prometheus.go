@@ -0,0 +1,112 @@
+// app/server/live/prometheus.go
+package live
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/client-go/rest"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// Sample is one series of an instant query result
+type Sample struct {
+	Labels map[string]string
+	Value  float64
+}
+
+// Prometheus queries a cluster's Prometheus or Thanos querier endpoint
+type Prometheus struct {
+	URL        string
+	httpClient *http.Client
+}
+
+// NewPrometheus creates a client for a Prometheus endpoint that authenticates
+// with the cluster's own credentials, as the OpenShift Thanos querier expects;
+// the token needs the cluster-monitoring-view role
+func NewPrometheus(endpoint string, restConfig *rest.Config) (*Prometheus, error) {
+	parsed, err := url.Parse(endpoint)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return nil, fmt.Errorf("invalid Prometheus URL %q", endpoint)
+	}
+
+	httpClient, err := rest.HTTPClientFor(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Prometheus client: %w", err)
+	}
+	httpClient.Timeout = 30 * time.Second
+	httpClient.Transport = tracing.Transport(httpClient.Transport)
+
+	return &Prometheus{URL: strings.TrimRight(endpoint, "/"), httpClient: httpClient}, nil
+}
+
+// Ping checks that the endpoint answers queries with the cluster's credentials
+func (p *Prometheus) Ping(ctx context.Context) error {
+	_, err := p.Query(ctx, "vector(1)")
+	return err
+}
+
+// Query evaluates an instant PromQL query returning a vector
+func (p *Prometheus) Query(ctx context.Context, query string) ([]Sample, error) {
+	form := url.Values{"query": {query}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL+"/api/v1/query", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("error creating Prometheus request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error calling Prometheus: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		Status    string `json:"status"`
+		Error     string `json:"error"`
+		ErrorType string `json:"errorType"`
+		Data      struct {
+			ResultType string `json:"resultType"`
+			Result     []struct {
+				Metric map[string]string `json:"metric"`
+				Value  [2]interface{}    `json:"value"`
+			} `json:"result"`
+		} `json:"data"`
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10<<20))
+	if err != nil {
+		return nil, fmt.Errorf("error reading Prometheus response: %w", err)
+	}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("prometheus returned %s: %s", resp.Status, strings.TrimSpace(string(body[:min(len(body), 1024)])))
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %s: %s", result.ErrorType, result.Error)
+	}
+	if result.Data.ResultType != "vector" {
+		return nil, fmt.Errorf("prometheus returned a %s, expected a vector", result.Data.ResultType)
+	}
+
+	samples := make([]Sample, 0, len(result.Data.Result))
+	for _, series := range result.Data.Result {
+		text, ok := series.Value[1].(string)
+		if !ok {
+			return nil, fmt.Errorf("prometheus returned a malformed sample value")
+		}
+		value, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("prometheus returned a malformed sample value %q", text)
+		}
+		samples = append(samples, Sample{Labels: series.Metric, Value: value})
+	}
+	return samples, nil
+}
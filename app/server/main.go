@@ -6,23 +6,162 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/ayaseen/openshift-health-dashboard/app/server/alerting"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/confluence"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/gitops"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/insights"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/jira"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/jobs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/operator"
 	"github.com/ayaseen/openshift-health-dashboard/app/server/server"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/signing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/version"
 )
 
 func main() {
 	// Configure logging with file and line information
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
-	log.Println("Starting OpenShift Health Dashboard server")
+	log.Printf("Starting OpenShift Health Dashboard server %s", version.Get())
 
 	// Get configuration from environment variables
 	config := server.Config{
-		StaticDir: getEnv("STATIC_DIR", "./app/web/static"),
-		Port:      getEnv("PORT", "8080"),
-		DebugMode: getEnv("DEBUG", "false") == "true",
+		StaticDir:       getEnv("STATIC_DIR", "./app/web/static"),
+		StaticAssets:    getEnv("STATIC_ASSETS", server.StaticAssetsAuto),
+		Port:            getEnv("PORT", "8080"),
+		DebugMode:       getEnv("DEBUG", "false") == "true",
+		DataDir:         getEnv("DATA_DIR", defaults.DataDir),
+		BlobDir:         getEnv("BLOB_DIR", getEnv("DATA_DIR", defaults.DataDir)),
+		ArchiveDir:      getEnv("ARCHIVE_DIR", ""),
+		BindAddress:     getEnv("BIND_ADDRESS", defaults.BindAddress),
+		StoreDriver:     getEnv("STORE_DRIVER", defaults.StoreDriver),
+		PublicURL:       getEnv("PUBLIC_URL", ""),
+		ScoringPreset:   getEnv("SCORING_PRESET", "default"),
+		StatusPages:     getEnv("STATUS_PAGES", "false") == "true",
+		ReviewRequired:  getEnv("REVIEW_REQUIRED", "false") == "true",
+		DemoMode:        getEnv("DEMO_MODE", "false") == "true",
+		GraphQL:         getEnv("GRAPHQL_ENABLED", "false") == "true",
+		StrictReadiness: getEnv("READINESS_STRICT", "false") == "true",
+		SchedulesFile:   getEnv("SCHEDULES_FILE", ""),
+		SettingsFile:    getEnv("SETTINGS_FILE", ""),
+		KnowledgeDir:    getEnv("KNOWLEDGE_DIR", ""),
+		ChecksFile:      getEnv("CHECKS_FILE", ""),
+		IncludeDir:      getEnv("INCLUDE_DIR", ""),
+		CredentialsDir:  getEnv("CREDENTIALS_DIR", "/etc/health-dashboard/clusters"),
+		EncryptionKey:   getSecret("STORE_ENCRYPTION_KEY"),
+
+		IncrementalScans: getEnv("SCAN_INCREMENTAL", "false") == "true",
+		ScanWorkers:      getEnvInt("SCAN_WORKERS", 4),
+		AutoRemediation:  getEnv("AUTO_REMEDIATION", "false") == "true",
+		ScanLimits: live.Limits{
+			QPS:   float32(getEnvFloat("SCAN_API_QPS", 20)),
+			Burst: getEnvInt("SCAN_API_BURST", 40),
+		},
+		UploadSessionTTL: time.Duration(getEnvInt("UPLOAD_SESSION_HOURS", 24)) * time.Hour,
+		RateLimit: server.RateLimitConfig{
+			PerIP:         getEnvFloat("RATE_LIMIT_PER_IP", 0),
+			PerKey:        getEnvFloat("RATE_LIMIT_PER_KEY", 0),
+			APIKeys:       splitList(getSecret("RATE_LIMIT_API_KEYS")),
+			Burst:         getEnvInt("RATE_LIMIT_BURST", 0),
+			TrustProxy:    getEnv("TRUST_PROXY", "false") == "true",
+			MaxUploadSize: int64(getEnvInt("MAX_UPLOAD_SIZE", 100<<20)),
+		},
+		Retention: server.RetentionConfig{
+			MaxAge:        time.Duration(getEnvInt("RETENTION_MAX_AGE_DAYS", 0)) * 24 * time.Hour,
+			MaxPerCluster: getEnvInt("RETENTION_MAX_PER_CLUSTER", 0),
+			Interval:      time.Duration(getEnvInt("RETENTION_INTERVAL_MINUTES", 60)) * time.Minute,
+		},
+		TLS: server.TLSConfig{
+			CertFile:       getEnv("TLS_CERT_FILE", ""),
+			KeyFile:        getEnv("TLS_KEY_FILE", ""),
+			AutoReload:     getEnv("TLS_AUTO_RELOAD", "true") == "true",
+			RedirectPort:   getEnv("HTTP_REDIRECT_PORT", ""),
+			CleartextHTTP2: getEnv("HTTP2_CLEARTEXT", "false") == "true",
+		},
+		Jobs: jobs.Config{
+			InteractiveWorkers: getEnvInt("JOBS_INTERACTIVE_WORKERS", 4),
+			BatchWorkers:       getEnvInt("JOBS_BATCH_WORKERS", 1),
+			Retention:          time.Duration(getEnvInt("JOBS_RETENTION_MINUTES", 60)) * time.Minute,
+		},
+		Live: live.Config{
+			Enabled:     getEnv("LIVE_MODE", "false") == "true",
+			Kubeconfig:  getEnv("KUBECONFIG", ""),
+			ClusterName: getEnv("LIVE_CLUSTER_NAME", ""),
+
+			PrometheusURL: getEnv("LIVE_PROMETHEUS_URL", ""),
+		},
+		Notify: notify.Config{
+			SlackWebhookURLs: splitList(getSecret("SLACK_WEBHOOK_URLS")),
+			WebhookURLs:      splitList(getEnv("NOTIFY_WEBHOOK_URLS", "")),
+			TeamsWebhooks:    teamsWebhooks(splitList(getSecret("TEAMS_WEBHOOK_URLS"))),
+
+			CategoryThresholds: categoryThresholds(splitList(getEnv("CATEGORY_SCORE_THRESHOLDS", ""))),
+		},
+		Jira: jira.Config{
+			URL:                 getEnv("JIRA_URL", ""),
+			User:                getEnv("JIRA_USER", ""),
+			Token:               getSecret("JIRA_API_TOKEN"),
+			Project:             getEnv("JIRA_PROJECT", ""),
+			IssueType:           getEnv("JIRA_ISSUE_TYPE", "Task"),
+			PriorityRequired:    getEnv("JIRA_PRIORITY_REQUIRED", "High"),
+			PriorityRecommended: getEnv("JIRA_PRIORITY_RECOMMENDED", "Medium"),
+		},
+		Insights: insights.Config{
+			URL:          getEnv("INSIGHTS_URL", insights.DefaultURL),
+			TokenURL:     getEnv("INSIGHTS_TOKEN_URL", insights.DefaultTokenURL),
+			ClientID:     getEnv("INSIGHTS_CLIENT_ID", ""),
+			ClientSecret: getSecret("INSIGHTS_CLIENT_SECRET"),
+			Token:        getSecret("INSIGHTS_TOKEN"),
+		},
+		GitOps: gitops.Config{
+			URL:         getEnv("GITOPS_REPO_URL", ""),
+			Branch:      getEnv("GITOPS_BRANCH", gitops.DefaultBranch),
+			Path:        getEnv("GITOPS_PATH", gitops.DefaultPath),
+			Username:    getEnv("GITOPS_USERNAME", ""),
+			Token:       getSecret("GITOPS_TOKEN"),
+			AuthorName:  getEnv("GITOPS_AUTHOR_NAME", gitops.DefaultAuthorName),
+			AuthorEmail: getEnv("GITOPS_AUTHOR_EMAIL", gitops.DefaultAuthorEmail),
+		},
+		Alerting: alertTargets(getSecret("PAGERDUTY_ROUTING_KEY"), getEnv("PAGERDUTY_EVENTS_URL", alerting.DefaultPagerDutyURL),
+			getSecret("OPSGENIE_API_KEY"), getEnv("OPSGENIE_API_URL", alerting.DefaultOpsgenieURL)),
+		Confluence: confluence.Config{
+			URL:      getEnv("CONFLUENCE_URL", ""),
+			User:     getEnv("CONFLUENCE_USER", ""),
+			Token:    getSecret("CONFLUENCE_API_TOKEN"),
+			Space:    getEnv("CONFLUENCE_SPACE", ""),
+			ParentID: getEnv("CONFLUENCE_PARENT_ID", ""),
+			Template: getEnv("CONFLUENCE_TEMPLATE", ""),
+		},
+		Signing: signing.Config{
+			GPGKeyring:   getEnv("SIGNING_GPG_KEYRING", ""),
+			SigstoreKeys: splitList(getEnv("SIGNING_SIGSTORE_KEYS", "")),
+			Required:     getEnv("SIGNATURE_REQUIRED", "false") == "true",
+		},
+		Tracing: tracing.Config{
+			Endpoint:    getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+			ServiceName: getEnv("OTEL_SERVICE_NAME", tracing.DefaultServiceName),
+			SampleRatio: getEnvFloat("OTEL_TRACES_SAMPLER_ARG", 1),
+		},
+		Operator: operator.Config{
+			Enabled:    getEnv("OPERATOR_MODE", "false") == "true",
+			Kubeconfig: getEnv("KUBECONFIG", ""),
+			Namespace:  getEnv("OPERATOR_NAMESPACE", ""),
+			Name:       getEnv("OPERATOR_RESOURCE", operator.DefaultName),
+		},
+		Tenancy: server.TenancyConfig{
+			Mode:        getEnv("TENANCY_MODE", ""),
+			Tenants:     splitList(getEnv("TENANTS", "")),
+			Claim:       getEnv("TENANT_CLAIM", server.DefaultTenantClaim),
+			SettingsDir: getEnv("TENANT_SETTINGS_DIR", ""),
+		},
 	}
 
 	if config.DebugMode {
@@ -44,6 +183,16 @@ func main() {
 		serverErrors <- s.Start() // <-- This line was causing the error because Start() was missing
 	}()
 
+	// Reload the configuration on SIGHUP
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			log.Println("Received SIGHUP, reloading configuration")
+			s.Reload()
+		}
+	}()
+
 	// Set up graceful shutdown
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
@@ -76,3 +225,89 @@ func getEnv(key, defaultValue string) string {
 	}
 	return value
 }
+
+// getEnvInt gets an integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvFloat gets a decimal environment variable or returns a default value
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getSecret gets a secret from an environment variable, or from the file named by
+// the same variable with a _FILE suffix (e.g. a mounted Kubernetes secret)
+func getSecret(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	path := os.Getenv(key + "_FILE")
+	if path == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Printf("Failed to read %s: %v", path, err)
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// splitList splits a comma-separated setting into its non-empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// teamsWebhooks notifies each Teams webhook of the reports of every cluster
+func teamsWebhooks(urls []string) []notify.TeamsWebhook {
+	var webhooks []notify.TeamsWebhook
+	for _, url := range urls {
+		webhooks = append(webhooks, notify.TeamsWebhook{URL: url})
+	}
+	return webhooks
+}
+
+// categoryThresholds parses category=score entries, skipping invalid ones
+func categoryThresholds(entries []string) map[string]float64 {
+	thresholds := make(map[string]float64)
+	for _, entry := range entries {
+		category, value, _ := strings.Cut(entry, "=")
+		threshold, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || threshold <= 0 || threshold > 100 || strings.TrimSpace(category) == "" {
+			log.Printf("Ignoring invalid category threshold %q", entry)
+			continue
+		}
+		thresholds[strings.TrimSpace(category)] = threshold
+	}
+	return thresholds
+}
+
+// alertTargets pages PagerDuty and Opsgenie as the targets "pagerduty" and
+// "opsgenie" when their keys are set; the rules come from the settings file
+func alertTargets(routingKey, pagerDutyURL, opsgenieKey, opsgenieURL string) alerting.Config {
+	var config alerting.Config
+	if routingKey != "" {
+		config.PagerDuty = []alerting.PagerDutyTarget{{Name: "pagerduty", RoutingKey: routingKey, URL: pagerDutyURL}}
+	}
+	if opsgenieKey != "" {
+		config.Opsgenie = []alerting.OpsgenieTarget{{Name: "opsgenie", APIKey: opsgenieKey, URL: opsgenieURL}}
+	}
+	return config
+}
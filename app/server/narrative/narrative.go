@@ -0,0 +1,166 @@
+// app/server/narrative/narrative.go
+
+// Package narrative writes the prose of a report from its structured data.
+// Category descriptions are rendered from per-language templates fed with the
+// item counts and the most pressing findings of each category, instead of the
+// text the parser lifts out of the document.
+package narrative
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// DefaultLanguage is used when no language is requested
+const DefaultLanguage = "en"
+
+// maxTopFindings bounds the findings named in a description
+const maxTopFindings = 3
+
+// ErrUnsupportedLanguage is returned for a language without templates
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// ErrNoFindings is returned for summaries stored before findings were extracted
+var ErrNoFindings = errors.New("report has no findings")
+
+// CategoryStats is the data a category description is rendered from
+type CategoryStats struct {
+	Name          string
+	Score         int
+	Evaluated     int
+	Required      int
+	Recommended   int
+	Advisory      int
+	NoChange      int
+	NotApplicable int
+
+	// Top lists the titles of the most pressing findings, required items first
+	Top []string
+}
+
+// Descriptions holds the regenerated description of each summary category
+type Descriptions struct {
+	Language      string `json:"language"`
+	Infra         string `json:"infraDescription"`
+	Governance    string `json:"governanceDescription"`
+	Compliance    string `json:"complianceDescription"`
+	Monitoring    string `json:"monitoringDescription"`
+	BuildSecurity string `json:"buildSecurityDescription"`
+}
+
+// Apply writes the descriptions into a summary
+func (d Descriptions) Apply(summary *types.ReportSummary) {
+	summary.Language = d.Language
+	summary.InfraDescription = d.Infra
+	summary.GovernanceDescription = d.Governance
+	summary.ComplianceDescription = d.Compliance
+	summary.MonitoringDescription = d.Monitoring
+	summary.BuildSecurityDescription = d.BuildSecurity
+}
+
+// Languages returns the languages descriptions can be written in
+func Languages() []string {
+	languages := make([]string, 0, len(catalog))
+	for language := range catalog {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Describe renders the category descriptions of a summary in a language; an
+// empty language selects DefaultLanguage
+func Describe(summary *types.ReportSummary, language string) (Descriptions, error) {
+	if language == "" {
+		language = DefaultLanguage
+	}
+	language = strings.ToLower(language)
+
+	tmpl, ok := templates[language]
+	if !ok {
+		return Descriptions{}, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, language)
+	}
+	if len(summary.Findings) == 0 {
+		return Descriptions{}, ErrNoFindings
+	}
+
+	stats := Stats(summary)
+	render := func(name string, score int) (string, error) {
+		category := stats[name]
+		category.Score = score
+
+		var b strings.Builder
+		if err := tmpl.Execute(&b, category); err != nil {
+			return "", fmt.Errorf("error rendering %s description: %w", name, err)
+		}
+		return strings.Join(strings.Fields(b.String()), " "), nil
+	}
+
+	d := Descriptions{Language: language}
+	var err error
+	for _, field := range []struct {
+		target   *string
+		category string
+		score    int
+	}{
+		{&d.Infra, scoring.CategoryClusterConfig, summary.ScoreInfra},
+		{&d.Governance, scoring.CategorySecurity, summary.ScoreGovernance},
+		{&d.Compliance, scoring.CategoryPerformance, summary.ScoreCompliance},
+		{&d.Monitoring, scoring.CategoryOpReady, summary.ScoreMonitoring},
+		{&d.BuildSecurity, scoring.CategoryApplications, summary.ScoreBuildSecurity},
+	} {
+		if *field.target, err = render(field.category, field.score); err != nil {
+			return Descriptions{}, err
+		}
+	}
+	return d, nil
+}
+
+// Stats counts the findings of each category and picks its top findings
+func Stats(summary *types.ReportSummary) map[string]CategoryStats {
+	stats := make(map[string]CategoryStats)
+	for _, category := range []string{
+		scoring.CategoryClusterConfig, scoring.CategorySecurity, scoring.CategoryPerformance,
+		scoring.CategoryOpReady, scoring.CategoryApplications,
+	} {
+		stats[category] = CategoryStats{Name: category}
+	}
+
+	required, recommended := make(map[string][]string), make(map[string][]string)
+	for _, finding := range summary.Findings {
+		category := stats[finding.Category]
+		category.Name = finding.Category
+
+		switch finding.Status {
+		case types.ResultKeyRequired:
+			category.Required++
+			required[finding.Category] = append(required[finding.Category], finding.Title)
+		case types.ResultKeyRecommended:
+			category.Recommended++
+			recommended[finding.Category] = append(recommended[finding.Category], finding.Title)
+		case types.ResultKeyAdvisory:
+			category.Advisory++
+		case types.ResultKeyNoChange:
+			category.NoChange++
+		case types.ResultKeyNotApplicable:
+			category.NotApplicable++
+		}
+		stats[finding.Category] = category
+	}
+
+	for name, category := range stats {
+		category.Evaluated = category.Required + category.Recommended + category.Advisory + category.NoChange
+		top := append(required[name], recommended[name]...)
+		if len(top) > maxTopFindings {
+			top = top[:maxTopFindings]
+		}
+		category.Top = top
+		stats[name] = category
+	}
+	return stats
+}
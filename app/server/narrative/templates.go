@@ -0,0 +1,99 @@
+// app/server/narrative/templates.go
+package narrative
+
+import (
+	"strings"
+	"text/template"
+)
+
+// catalog holds the category description template of each language. Templates
+// are executed with a CategoryStats; whitespace is collapsed afterwards, so
+// they can be laid out freely.
+var catalog = map[string]string{
+	"en": `
+{{- if eq .Evaluated 0}}No items were evaluated for {{.Name}}.
+{{- else}}{{.Name}} scores {{.Score}}/100 across {{.Evaluated}} evaluated {{plural .Evaluated "item" "items"}}.
+{{- if .Required}} {{.Required}} {{plural .Required "item requires" "items require"}} changes{{if .Recommended}} and {{.Recommended}} {{plural .Recommended "has" "have"}} recommended changes{{end}}.
+{{- else if .Recommended}} No changes are required; {{.Recommended}} {{plural .Recommended "item has" "items have"}} recommended changes.
+{{- else}} No changes are required or recommended.{{end}}
+{{- if .Advisory}} {{.Advisory}} advisory {{plural .Advisory "note" "notes"}} should be reviewed.{{end}}
+{{- if .Top}} Most pressing: {{list .Top "and"}}.{{end}}
+{{- end}}`,
+
+	"de": `
+{{- if eq .Evaluated 0}}Für {{.Name}} wurden keine Punkte bewertet.
+{{- else}}{{.Name}} erreicht {{.Score}}/100 bei {{.Evaluated}} {{plural .Evaluated "bewertetem Punkt" "bewerteten Punkten"}}.
+{{- if .Required}} {{.Required}} {{plural .Required "Punkt erfordert" "Punkte erfordern"}} Änderungen{{if .Recommended}}, für {{.Recommended}} weitere werden Änderungen empfohlen{{end}}.
+{{- else if .Recommended}} Es sind keine Änderungen erforderlich; für {{.Recommended}} {{plural .Recommended "Punkt" "Punkte"}} werden Änderungen empfohlen.
+{{- else}} Es sind keine Änderungen erforderlich oder empfohlen.{{end}}
+{{- if .Advisory}} {{.Advisory}} {{plural .Advisory "Hinweis sollte" "Hinweise sollten"}} geprüft werden.{{end}}
+{{- if .Top}} Am dringendsten: {{list .Top "und"}}.{{end}}
+{{- end}}`,
+
+	"es": `
+{{- if eq .Evaluated 0}}No se evaluó ningún elemento de {{.Name}}.
+{{- else}}{{.Name}} obtiene {{.Score}}/100 en {{.Evaluated}} {{plural .Evaluated "elemento evaluado" "elementos evaluados"}}.
+{{- if .Required}} {{.Required}} {{plural .Required "elemento requiere" "elementos requieren"}} cambios{{if .Recommended}} y en {{.Recommended}} se recomiendan cambios{{end}}.
+{{- else if .Recommended}} No se requieren cambios; en {{.Recommended}} {{plural .Recommended "elemento" "elementos"}} se recomiendan cambios.
+{{- else}} No se requieren ni se recomiendan cambios.{{end}}
+{{- if .Advisory}} Conviene revisar {{.Advisory}} {{plural .Advisory "nota informativa" "notas informativas"}}.{{end}}
+{{- if .Top}} Lo más urgente: {{list .Top "y"}}.{{end}}
+{{- end}}`,
+
+	"fr": `
+{{- if eq .Evaluated 0}}Aucun point n'a été évalué pour {{.Name}}.
+{{- else}}{{.Name}} obtient {{.Score}}/100 sur {{.Evaluated}} {{plural .Evaluated "point évalué" "points évalués"}}.
+{{- if .Required}} {{.Required}} {{plural .Required "point nécessite" "points nécessitent"}} des changements{{if .Recommended}} et des changements sont recommandés pour {{.Recommended}}{{end}}.
+{{- else if .Recommended}} Aucun changement n'est requis ; des changements sont recommandés pour {{.Recommended}} {{plural .Recommended "point" "points"}}.
+{{- else}} Aucun changement n'est requis ni recommandé.{{end}}
+{{- if .Advisory}} {{.Advisory}} {{plural .Advisory "note consultative est" "notes consultatives sont"}} à examiner.{{end}}
+{{- if .Top}} Le plus urgent : {{list .Top "et"}}.{{end}}
+{{- end}}`,
+
+	"ja": `
+{{- if eq .Evaluated 0}}{{.Name}}で評価された項目はありません。
+{{- else}}{{.Name}}は評価対象の{{.Evaluated}}項目で{{.Score}}/100です。
+{{- if .Required}}{{.Required}}項目で変更が必要です{{if .Recommended}}。また、{{.Recommended}}項目で変更を推奨します{{end}}。
+{{- else if .Recommended}}必要な変更はありませんが、{{.Recommended}}項目で変更を推奨します。
+{{- else}}必要な変更も推奨する変更もありません。{{end}}
+{{- if .Advisory}}{{.Advisory}}件のアドバイザリーを確認してください。{{end}}
+{{- if .Top}}最優先: {{list .Top "および"}}。{{end}}
+{{- end}}`,
+}
+
+// templates holds the parsed catalog
+var templates = parseCatalog()
+
+// parseCatalog parses every template of the catalog, panicking on errors since
+// the catalog is compiled in
+func parseCatalog() map[string]*template.Template {
+	funcs := template.FuncMap{
+		"plural": plural,
+		"list":   list,
+	}
+
+	parsed := make(map[string]*template.Template, len(catalog))
+	for language, text := range catalog {
+		parsed[language] = template.Must(template.New(language).Funcs(funcs).Parse(text))
+	}
+	return parsed
+}
+
+// plural picks the singular or plural form for a count
+func plural(count int, singular, pluralForm string) string {
+	if count == 1 {
+		return singular
+	}
+	return pluralForm
+}
+
+// list joins items into a sentence fragment, e.g. "a, b and c"
+func list(items []string, conjunction string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " " + conjunction + " " + items[len(items)-1]
+}
@@ -0,0 +1,162 @@
+// app/server/notify/notify.go
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// EventType identifies what triggered a notification
+type EventType string
+
+const (
+	// EventReportUploaded fires when a report is uploaded through the API
+	EventReportUploaded EventType = "report.uploaded"
+
+	// EventScanCompleted fires when a scheduled live scan has been stored
+	EventScanCompleted EventType = "scan.completed"
+)
+
+// Event is the payload describing a newly ingested report
+type Event struct {
+	Type             EventType `json:"type"`
+	Time             time.Time `json:"time"`
+	ReportID         string    `json:"reportId"`
+	ReportURL        string    `json:"reportUrl"`
+	ClusterName      string    `json:"clusterName"`
+	CustomerName     string    `json:"customerName"`
+	OverallScore     float64   `json:"overallScore"`
+	PreviousScore    *float64  `json:"previousScore,omitempty"`
+	ScoreDelta       *float64  `json:"scoreDelta,omitempty"`
+	RequiredCount    int       `json:"requiredCount"`
+	RecommendedCount int       `json:"recommendedCount"`
+	AdvisoryCount    int       `json:"advisoryCount"`
+
+	// TopRequired lists the first required items in remediation order
+	TopRequired []string `json:"topRequired,omitempty"`
+
+	// Branding styles chat messages; it isn't part of the webhook payload
+	Branding *Branding `json:"-"`
+}
+
+// Branding is what chat messages show of the branding
+type Branding struct {
+	Name           string
+	Footer         string
+	Classification string
+
+	// LogoURL is the absolute URL of the logo, empty when there is none
+	LogoURL string
+}
+
+// Notifier delivers events to a single target
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, event Event) error
+}
+
+// Config holds the notification targets
+type Config struct {
+	SlackWebhookURLs []string
+	WebhookURLs      []string
+	TeamsWebhooks    []TeamsWebhook
+
+	// CategoryThresholds maps category names or labels to the score below
+	// which the generic webhooks receive a threshold event
+	CategoryThresholds map[string]float64
+}
+
+// Targets returns the number of notification targets
+func (c Config) Targets() int {
+	return len(c.SlackWebhookURLs) + len(c.WebhookURLs) + len(c.TeamsWebhooks)
+}
+
+// Dispatcher fans events out to all configured notifiers
+type Dispatcher struct {
+	notifiers atomic.Pointer[[]Notifier]
+	config    atomic.Pointer[Config]
+}
+
+// NewDispatcher creates a dispatcher for the configured targets
+func NewDispatcher(config Config) *Dispatcher {
+	d := &Dispatcher{}
+	d.Configure(config)
+	return d
+}
+
+// Configure replaces the notification targets; sends in flight finish with the old ones
+func (d *Dispatcher) Configure(config Config) {
+	var notifiers []Notifier
+	for _, url := range config.SlackWebhookURLs {
+		notifiers = append(notifiers, &SlackNotifier{WebhookURL: url})
+	}
+	for _, url := range config.WebhookURLs {
+		notifiers = append(notifiers, &WebhookNotifier{URL: url})
+	}
+	for _, webhook := range config.TeamsWebhooks {
+		notifiers = append(notifiers, &TeamsNotifier{Webhook: webhook})
+	}
+	d.notifiers.Store(&notifiers)
+	d.config.Store(&config)
+}
+
+// Enabled reports whether any notifier is configured
+func (d *Dispatcher) Enabled() bool {
+	return len(*d.notifiers.Load()) > 0
+}
+
+// Send delivers the event to every notifier concurrently, logging failures
+func (d *Dispatcher) Send(ctx context.Context, event Event) {
+	var wg sync.WaitGroup
+	for _, notifier := range *d.notifiers.Load() {
+		wg.Add(1)
+		go func(notifier Notifier) {
+			defer wg.Done()
+
+			if err := notifier.Notify(ctx, event); err != nil {
+				log.Printf("Error sending %s notification for report %s: %v", notifier.Name(), event.ReportID, err)
+			}
+		}(notifier)
+	}
+	wg.Wait()
+}
+
+// httpClient is shared by all notifiers
+var httpClient = &http.Client{Timeout: 15 * time.Second, Transport: tracing.Transport(nil)}
+
+// postJSON posts a JSON body and treats any non-2xx response as an error
+func postJSON(ctx context.Context, url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("error encoding payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("target returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
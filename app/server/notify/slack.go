@@ -0,0 +1,64 @@
+// app/server/notify/slack.go
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SlackNotifier posts events to a Slack incoming webhook
+type SlackNotifier struct {
+	WebhookURL string
+}
+
+// Name returns the notifier name used in logs
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+// Notify posts a formatted message for the event
+func (n *SlackNotifier) Notify(ctx context.Context, event Event) error {
+	return postJSON(ctx, n.WebhookURL, map[string]string{"text": slackMessage(event)})
+}
+
+// slackMessage renders the event as Slack mrkdwn text
+func slackMessage(event Event) string {
+	var b strings.Builder
+	if event.Branding != nil && event.Branding.Classification != "" {
+		fmt.Fprintf(&b, "*[%s]*\n", event.Branding.Classification)
+	}
+
+	title := "New health check report"
+	if event.Type == EventScanCompleted {
+		title = "Scheduled health scan completed"
+	}
+
+	cluster := event.ClusterName
+	if cluster == "" {
+		cluster = "unknown cluster"
+	}
+	fmt.Fprintf(&b, "*%s* for *%s*", title, cluster)
+	if event.CustomerName != "" {
+		fmt.Fprintf(&b, " (%s)", event.CustomerName)
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "Overall score: *%.1f%%*", event.OverallScore)
+	if event.ScoreDelta != nil {
+		fmt.Fprintf(&b, " (%+.1f since previous report)", *event.ScoreDelta)
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, ":red_circle: %d required  :large_yellow_circle: %d recommended  :large_blue_circle: %d advisory",
+		event.RequiredCount, event.RecommendedCount, event.AdvisoryCount)
+
+	if event.ReportURL != "" {
+		fmt.Fprintf(&b, "\n<%s|View report>", event.ReportURL)
+	}
+	if event.Branding != nil && event.Branding.Footer != "" {
+		fmt.Fprintf(&b, "\n_%s_", event.Branding.Footer)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,150 @@
+// app/server/notify/teams.go
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// TeamsWebhook is a Microsoft Teams incoming webhook, optionally limited to
+// the reports of some clusters
+type TeamsWebhook struct {
+	URL string `json:"url" yaml:"url"`
+
+	// Clusters selects the clusters notified of; empty notifies of every cluster
+	Clusters []string `json:"clusters,omitempty" yaml:"clusters"`
+}
+
+// TeamsNotifier posts events as adaptive cards to a Microsoft Teams webhook
+type TeamsNotifier struct {
+	Webhook TeamsWebhook
+}
+
+// Name returns the notifier name used in logs
+func (n *TeamsNotifier) Name() string {
+	return "teams"
+}
+
+// Notify posts an adaptive card for the event, unless the webhook is limited
+// to other clusters
+func (n *TeamsNotifier) Notify(ctx context.Context, event Event) error {
+	if !n.selects(event.ClusterName) {
+		return nil
+	}
+	return postJSON(ctx, n.Webhook.URL, map[string]interface{}{
+		"type": "message",
+		"attachments": []map[string]interface{}{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content":     teamsCard(event),
+		}},
+	})
+}
+
+// selects reports whether the webhook is notified of a cluster
+func (n *TeamsNotifier) selects(cluster string) bool {
+	if len(n.Webhook.Clusters) == 0 {
+		return true
+	}
+	for _, selected := range n.Webhook.Clusters {
+		if strings.EqualFold(strings.TrimSpace(selected), strings.TrimSpace(cluster)) {
+			return true
+		}
+	}
+	return false
+}
+
+// teamsCard renders the event as an adaptive card: the score with its change,
+// the item counts and the top required items, framed by the branding
+func teamsCard(event Event) map[string]interface{} {
+	title := "New health check report"
+	if event.Type == EventScanCompleted {
+		title = "Scheduled health scan completed"
+	}
+	cluster := strings.TrimSpace(event.ClusterName)
+	if cluster == "" {
+		cluster = "unknown cluster"
+	}
+	subtitle := cluster
+	if event.CustomerName != "" {
+		subtitle += " (" + event.CustomerName + ")"
+	}
+
+	score := fmt.Sprintf("%.1f%%", event.OverallScore)
+	if event.ScoreDelta != nil {
+		score += fmt.Sprintf(" (%+.1f since previous report)", *event.ScoreDelta)
+	}
+	facts := []map[string]string{
+		{"title": "Overall score", "value": score},
+	}
+	if event.PreviousScore != nil {
+		facts = append(facts, map[string]string{"title": "Previous score", "value": fmt.Sprintf("%.1f%%", *event.PreviousScore)})
+	}
+	facts = append(facts,
+		map[string]string{"title": "Required", "value": fmt.Sprint(event.RequiredCount)},
+		map[string]string{"title": "Recommended", "value": fmt.Sprint(event.RecommendedCount)},
+		map[string]string{"title": "Advisory", "value": fmt.Sprint(event.AdvisoryCount)},
+	)
+
+	var body []map[string]interface{}
+	if branding := event.Branding; branding != nil {
+		if branding.Classification != "" {
+			body = append(body, map[string]interface{}{
+				"type": "TextBlock", "text": branding.Classification, "weight": "Bolder", "color": "Attention",
+				"horizontalAlignment": "Center", "wrap": true,
+			})
+		}
+		if branding.LogoURL != "" {
+			body = append(body, map[string]interface{}{
+				"type": "Image", "url": branding.LogoURL, "size": "Small", "altText": branding.Name,
+			})
+		}
+	}
+	body = append(body, []map[string]interface{}{
+		{"type": "TextBlock", "text": title, "weight": "Bolder", "size": "Medium", "wrap": true},
+		{"type": "TextBlock", "text": subtitle, "isSubtle": true, "spacing": "None", "wrap": true},
+		{"type": "TextBlock", "text": fmt.Sprintf("%.1f%%", event.OverallScore), "size": "ExtraLarge",
+			"weight": "Bolder", "color": teamsScoreColor(event.OverallScore)},
+		{"type": "FactSet", "facts": facts},
+	}...)
+	if len(event.TopRequired) > 0 {
+		body = append(body, map[string]interface{}{
+			"type": "TextBlock", "text": "Top required changes", "weight": "Bolder", "spacing": "Medium",
+		})
+		for _, item := range event.TopRequired {
+			body = append(body, map[string]interface{}{
+				"type": "TextBlock", "text": "- " + item, "wrap": true, "spacing": "Small",
+			})
+		}
+	}
+
+	if event.Branding != nil && event.Branding.Footer != "" {
+		body = append(body, map[string]interface{}{
+			"type": "TextBlock", "text": event.Branding.Footer, "size": "Small", "isSubtle": true,
+			"spacing": "Medium", "wrap": true,
+		})
+	}
+
+	card := map[string]interface{}{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if event.ReportURL != "" {
+		card["actions"] = []map[string]string{{"type": "Action.OpenUrl", "title": "View report", "url": event.ReportURL}}
+	}
+	return card
+}
+
+// teamsScoreColor picks the adaptive card text color of a score
+func teamsScoreColor(score float64) string {
+	switch {
+	case score >= 80:
+		return "Good"
+	case score >= 60:
+		return "Warning"
+	default:
+		return "Attention"
+	}
+}
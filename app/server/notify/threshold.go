@@ -0,0 +1,82 @@
+// app/server/notify/threshold.go
+package notify
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventCategoryBelowThreshold fires when a category score of a new report
+// falls below the threshold set for the category
+const EventCategoryBelowThreshold EventType = "category.below_threshold"
+
+// ThresholdEvent is the payload describing a category score that fell below
+// its threshold
+type ThresholdEvent struct {
+	Type          EventType `json:"type"`
+	Time          time.Time `json:"time"`
+	ReportID      string    `json:"reportId"`
+	ReportURL     string    `json:"reportUrl"`
+	ClusterName   string    `json:"clusterName"`
+	CustomerName  string    `json:"customerName"`
+	Category      string    `json:"category"`
+	CategoryLabel string    `json:"categoryLabel"`
+	Threshold     float64   `json:"threshold"`
+	Score         int       `json:"score"`
+
+	// PreviousScore is the category score of the cluster's previous report;
+	// nil for the first report or when the category is new
+	PreviousScore *int `json:"previousScore,omitempty"`
+
+	// Findings are the required and recommended findings of the category
+	Findings []ContributingFinding `json:"findings"`
+}
+
+// ContributingFinding is an actionable finding in a category below its threshold
+type ContributingFinding struct {
+	ID       string `json:"id,omitempty"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Severity string `json:"severity,omitempty"`
+
+	// New is set when the finding wasn't actionable in the previous report
+	New bool `json:"new"`
+}
+
+// Threshold returns the threshold of a category, matching its name or label
+// case-insensitively
+func (c Config) Threshold(name, label string) (float64, bool) {
+	for category, threshold := range c.CategoryThresholds {
+		if strings.EqualFold(category, name) || label != "" && strings.EqualFold(category, label) {
+			return threshold, true
+		}
+	}
+	return 0, false
+}
+
+// Thresholds returns the configuration the threshold events are sent with
+func (d *Dispatcher) Thresholds() Config {
+	return *d.config.Load()
+}
+
+// SendThreshold posts threshold events to the generic webhooks concurrently,
+// logging failures; chat notifiers only receive report events
+func (d *Dispatcher) SendThreshold(ctx context.Context, events []ThresholdEvent) {
+	var wg sync.WaitGroup
+	for _, url := range d.config.Load().WebhookURLs {
+		for _, event := range events {
+			wg.Add(1)
+			go func(url string, event ThresholdEvent) {
+				defer wg.Done()
+
+				if err := postJSON(ctx, url, event); err != nil {
+					log.Printf("Error sending %s threshold event for report %s: %v", event.Category, event.ReportID, err)
+				}
+			}(url, event)
+		}
+	}
+	wg.Wait()
+}
@@ -0,0 +1,21 @@
+// app/server/notify/webhook.go
+package notify
+
+import (
+	"context"
+)
+
+// WebhookNotifier posts the raw event as JSON to a generic webhook
+type WebhookNotifier struct {
+	URL string
+}
+
+// Name returns the notifier name used in logs
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+// Notify posts the event payload
+func (n *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	return postJSON(ctx, n.URL, event)
+}
@@ -0,0 +1,237 @@
+// app/server/operator/operator.go
+
+// Package operator runs the dashboard as the operator of its own
+// configuration: it watches one HealthDashboard resource and hands its spec
+// to the server whenever it changes, so clusters, schedules and notification
+// targets can be managed with GitOps instead of through the API.
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// DefaultName is the name of the watched resource when none is configured
+const DefaultName = "health-dashboard"
+
+const (
+	// resyncInterval is how often the spec is applied again without a change,
+	// repairing drift such as a registry restored from an old backup
+	resyncInterval = 10 * time.Minute
+
+	// retryInterval is how long a failed reconcile waits before the next try
+	retryInterval = 30 * time.Second
+
+	// namespaceFile holds the namespace of the pod's service account
+	namespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
+// Config holds the operator mode settings
+type Config struct {
+	Enabled    bool
+	Kubeconfig string
+
+	// Namespace of the watched resource; defaults to the pod's namespace
+	Namespace string
+
+	// Name of the watched resource; defaults to DefaultName
+	Name string
+}
+
+// Result counts what a reconcile applied
+type Result struct {
+	Clusters  int
+	Schedules int
+}
+
+// ReconcileFunc applies the spec of a resource. It is called with nil once
+// the resource is deleted, to remove everything it declared. An error with a
+// partial result means part of the spec could not be applied.
+type ReconcileFunc func(ctx context.Context, dashboard *HealthDashboard) (Result, error)
+
+// Operator watches a HealthDashboard resource and reconciles the server from it
+type Operator struct {
+	config    Config
+	client    dynamic.Interface
+	reconcile ReconcileFunc
+	trigger   chan struct{}
+}
+
+// New connects to the cluster the resource lives in, using the in-cluster
+// service account when no kubeconfig is given
+func New(config Config, reconcile ReconcileFunc) (*Operator, error) {
+	var restConfig *rest.Config
+	var err error
+	if config.Kubeconfig != "" {
+		restConfig, err = clientcmd.BuildConfigFromFlags("", config.Kubeconfig)
+	} else {
+		restConfig, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading cluster credentials: %w", err)
+	}
+
+	if config.Name == "" {
+		config.Name = DefaultName
+	}
+	if config.Namespace == "" {
+		data, err := os.ReadFile(namespaceFile)
+		if err != nil {
+			return nil, errors.New("the namespace of the HealthDashboard resource is required outside a pod")
+		}
+		config.Namespace = strings.TrimSpace(string(data))
+	}
+
+	client, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Kubernetes client: %w", err)
+	}
+	return &Operator{
+		config:    config,
+		client:    client,
+		reconcile: reconcile,
+		trigger:   make(chan struct{}, 1),
+	}, nil
+}
+
+// Resource returns the namespace and name of the watched resource
+func (o *Operator) Resource() string {
+	return o.config.Namespace + "/" + o.config.Name
+}
+
+// Run watches the resource and reconciles on every change of its spec until
+// the context is cancelled
+func (o *Operator) Run(ctx context.Context) {
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(o.client, resyncInterval, o.config.Namespace,
+		func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", o.config.Name).String()
+		})
+	informer := factory.ForResource(GroupVersionResource)
+	informer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(interface{}) { o.enqueue() },
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			// Status updates don't change the generation; resyncs repeat the
+			// same resource version and are applied again on purpose
+			previous, current := oldObj.(*unstructured.Unstructured), newObj.(*unstructured.Unstructured)
+			if previous.GetGeneration() != current.GetGeneration() || previous.GetResourceVersion() == current.GetResourceVersion() {
+				o.enqueue()
+			}
+		},
+		DeleteFunc: func(interface{}) { o.enqueue() },
+	})
+
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.Informer().HasSynced) {
+		return
+	}
+	log.Printf("Operator mode: reconciling from HealthDashboard %s", o.Resource())
+
+	// Apply the current state once even if the resource doesn't exist, so
+	// clusters of a resource deleted while the server was down are removed
+	o.enqueue()
+	var retry <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-o.trigger:
+		case <-retry:
+		}
+
+		retry = nil
+		if err := o.reconcileOnce(ctx, informer.Lister()); err != nil {
+			log.Printf("Error reconciling HealthDashboard %s, retrying in %s: %v", o.Resource(), retryInterval, err)
+			retry = time.After(retryInterval)
+		}
+	}
+}
+
+// enqueue requests a reconcile, coalescing requests made while one is pending
+func (o *Operator) enqueue() {
+	select {
+	case o.trigger <- struct{}{}:
+	default:
+	}
+}
+
+// reconcileOnce applies the cached resource and records the outcome in its status
+func (o *Operator) reconcileOnce(ctx context.Context, lister cache.GenericLister) error {
+	obj, err := lister.ByNamespace(o.config.Namespace).Get(o.config.Name)
+	if apierrors.IsNotFound(err) {
+		_, err := o.reconcile(ctx, nil)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	object := obj.(*unstructured.Unstructured).DeepCopy()
+	var dashboard HealthDashboard
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, &dashboard); err != nil {
+		return o.updateStatus(ctx, object, dashboard.Status, Result{}, fmt.Errorf("invalid resource: %w", err))
+	}
+
+	result, reconcileErr := o.reconcile(ctx, &dashboard)
+	if err := o.updateStatus(ctx, object, dashboard.Status, result, reconcileErr); err != nil {
+		return err
+	}
+	return reconcileErr
+}
+
+// updateStatus writes the outcome of a reconcile to the resource
+func (o *Operator) updateStatus(ctx context.Context, object *unstructured.Unstructured, status Status, result Result, reconcileErr error) error {
+	now := metav1.Now()
+	status.ObservedGeneration = object.GetGeneration()
+	status.Clusters = result.Clusters
+	status.Schedules = result.Schedules
+	status.LastReconciled = &now
+
+	condition := metav1.Condition{
+		Type:               ConditionReady,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: object.GetGeneration(),
+		Reason:             "Reconciled",
+		Message:            fmt.Sprintf("Applied %d clusters and %d schedules", result.Clusters, result.Schedules),
+	}
+	if reconcileErr != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ReconcileFailed"
+		condition.Message = reconcileErr.Error()
+	}
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	encoded, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&status)
+	if err != nil {
+		return fmt.Errorf("error encoding status: %w", err)
+	}
+	if err := unstructured.SetNestedField(object.Object, encoded, "status"); err != nil {
+		return fmt.Errorf("error encoding status: %w", err)
+	}
+
+	_, err = o.client.Resource(GroupVersionResource).Namespace(o.config.Namespace).UpdateStatus(ctx, object, metav1.UpdateOptions{})
+	if apierrors.IsConflict(err) {
+		// The resource changed meanwhile; its update event reconciles again
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error updating status: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+// app/server/operator/types.go
+package operator
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+)
+
+// API group, version and names of the HealthDashboard resource
+const (
+	Group    = "health.ayaseen.io"
+	Version  = "v1alpha1"
+	Kind     = "HealthDashboard"
+	Resource = "healthdashboards"
+)
+
+// GroupVersionResource identifies HealthDashboard resources to the dynamic client
+var GroupVersionResource = schema.GroupVersionResource{Group: Group, Version: Version, Resource: Resource}
+
+// ConditionReady is the condition reporting whether the last reconcile applied
+// the whole spec
+const ConditionReady = "Ready"
+
+// HealthDashboard declares the configuration of a dashboard
+type HealthDashboard struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   Spec   `json:"spec,omitempty"`
+	Status Status `json:"status,omitempty"`
+}
+
+// Spec is the desired configuration
+type Spec struct {
+	Clusters      []ClusterSpec  `json:"clusters,omitempty"`
+	Schedules     []ScheduleSpec `json:"schedules,omitempty"`
+	Notifications Notifications  `json:"notifications,omitempty"`
+}
+
+// ClusterSpec declares a registered cluster; CredentialsRef names a secret
+// mounted under CREDENTIALS_DIR, as for clusters registered through the API
+type ClusterSpec struct {
+	Name           string            `json:"name"`
+	APIURL         string            `json:"apiUrl,omitempty"`
+	CredentialsRef string            `json:"credentialsRef,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	Org            string            `json:"org,omitempty"`
+	InsightsID     string            `json:"insightsId,omitempty"`
+	PrometheusURL  string            `json:"prometheusUrl,omitempty"`
+}
+
+// ScheduleSpec declares a scan schedule; the name keeps its run history
+// across reconciles
+type ScheduleSpec struct {
+	Name    string `json:"name"`
+	Cluster string `json:"cluster"`
+	Cron    string `json:"cron"`
+	Suspend bool   `json:"suspend,omitempty"`
+}
+
+// Notifications are targets notified in addition to those of the settings
+type Notifications struct {
+	SlackWebhookURLs []string              `json:"slackWebhookUrls,omitempty"`
+	WebhookURLs      []string              `json:"webhookUrls,omitempty"`
+	TeamsWebhooks    []notify.TeamsWebhook `json:"teamsWebhooks,omitempty"`
+}
+
+// Status is the outcome of the last reconcile
+type Status struct {
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Clusters           int                `json:"clusters"`
+	Schedules          int                `json:"schedules"`
+	LastReconciled     *metav1.Time       `json:"lastReconciled,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+}
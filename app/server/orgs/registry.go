@@ -0,0 +1,216 @@
+// app/server/orgs/registry.go
+package orgs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+)
+
+var (
+	// ErrNotFound is returned when an organization does not exist
+	ErrNotFound = errors.New("organization not found")
+
+	// ErrExists is returned when creating an organization whose name is taken
+	ErrExists = errors.New("organization already exists")
+)
+
+// namePattern restricts organization names to values that are safe in URLs and file names
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Integration types that can be attached to an organization
+const (
+	IntegrationJira    = "jira"
+	IntegrationSlack   = "slack"
+	IntegrationWebhook = "webhook"
+)
+
+// Integration is an integration slot of an organization. Onboarding creates
+// disabled placeholders that are filled in once the customer provides details.
+type Integration struct {
+	Type     string            `json:"type"`
+	Enabled  bool              `json:"enabled"`
+	Settings map[string]string `json:"settings,omitempty"`
+}
+
+// Org is a customer organization owning a set of clusters
+type Org struct {
+	Name           string          `json:"name"`
+	DisplayName    string          `json:"displayName"`
+	ScoringProfile scoring.Profile `json:"scoringProfile"`
+	Integrations   []Integration   `json:"integrations"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+// Registry persists organizations in a JSON file
+type Registry struct {
+	path string
+	mu   sync.RWMutex
+	orgs map[string]*Org
+}
+
+// New loads the organizations stored at path
+func New(path string) (*Registry, error) {
+	r := &Registry{path: path}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload re-reads the organizations file, replacing the registry contents only if it parses
+func (r *Registry) Reload() error {
+	orgs := make(map[string]*Org)
+
+	data, err := os.ReadFile(r.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error reading organizations: %w", err)
+	}
+	if err == nil {
+		var list []*Org
+		if err := json.Unmarshal(data, &list); err != nil {
+			return fmt.Errorf("error parsing organizations: %w", err)
+		}
+		for _, org := range list {
+			orgs[org.Name] = org
+		}
+		log.Printf("Loaded %d organizations from %s", len(orgs), r.path)
+	}
+
+	r.mu.Lock()
+	r.orgs = orgs
+	r.mu.Unlock()
+	return nil
+}
+
+// List returns all organizations ordered by name
+func (r *Registry) List() []Org {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	orgs := make([]Org, 0, len(r.orgs))
+	for _, org := range r.orgs {
+		orgs = append(orgs, *org)
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].Name < orgs[j].Name })
+	return orgs
+}
+
+// Get returns an organization by name
+func (r *Registry) Get(name string) (Org, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	org, ok := r.orgs[name]
+	if !ok {
+		return Org{}, ErrNotFound
+	}
+	return *org, nil
+}
+
+// Create adds a new organization
+func (r *Registry) Create(org Org) (Org, error) {
+	if err := Validate(&org); err != nil {
+		return Org{}, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.orgs[org.Name]; ok {
+		return Org{}, ErrExists
+	}
+
+	org.CreatedAt = time.Now().UTC()
+	r.orgs[org.Name] = &org
+	if err := r.saveLocked(); err != nil {
+		delete(r.orgs, org.Name)
+		return Org{}, err
+	}
+	return org, nil
+}
+
+// Delete removes an organization
+func (r *Registry) Delete(name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.orgs[name]
+	if !ok {
+		return ErrNotFound
+	}
+
+	delete(r.orgs, name)
+	if err := r.saveLocked(); err != nil {
+		r.orgs[name] = existing
+		return err
+	}
+	return nil
+}
+
+// Validate checks and normalizes an organization definition
+func Validate(org *Org) error {
+	org.Name = strings.TrimSpace(org.Name)
+	if !namePattern.MatchString(org.Name) {
+		return errors.New("organization name must be lowercase letters, digits or '-'")
+	}
+	if org.DisplayName == "" {
+		org.DisplayName = org.Name
+	}
+
+	// A bare preset name picks up the preset's weights
+	if org.ScoringProfile.Name == "" {
+		org.ScoringProfile = scoring.Default()
+	} else if preset, err := scoring.Preset(org.ScoringProfile.Name); err == nil && org.ScoringProfile.Weights == (scoring.Weights{}) {
+		org.ScoringProfile = preset
+	}
+	if err := org.ScoringProfile.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for _, integration := range org.Integrations {
+		switch integration.Type {
+		case IntegrationJira, IntegrationSlack, IntegrationWebhook:
+		default:
+			return fmt.Errorf("unknown integration type %q", integration.Type)
+		}
+		if seen[integration.Type] {
+			return fmt.Errorf("integration %q is listed twice", integration.Type)
+		}
+		seen[integration.Type] = true
+	}
+	return nil
+}
+
+// saveLocked writes the organizations to disk; r.mu must be held
+func (r *Registry) saveLocked() error {
+	orgs := make([]*Org, 0, len(r.orgs))
+	for _, org := range r.orgs {
+		orgs = append(orgs, org)
+	}
+	sort.Slice(orgs, func(i, j int) bool { return orgs[i].Name < orgs[j].Name })
+
+	data, err := json.MarshalIndent(orgs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding organizations: %w", err)
+	}
+
+	// Write to a temp file first so a crash never leaves a truncated file
+	if err := os.WriteFile(r.path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("error writing organizations: %w", err)
+	}
+	if err := os.Rename(r.path+".tmp", r.path); err != nil {
+		return fmt.Errorf("error writing organizations: %w", err)
+	}
+	return nil
+}
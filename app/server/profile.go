@@ -0,0 +1,10 @@
+// app/server/profile.go
+package main
+
+// profile holds the configuration defaults that differ between build profiles;
+// each can still be overridden from the environment
+type profile struct {
+	DataDir     string
+	StoreDriver string
+	BindAddress string
+}
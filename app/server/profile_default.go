@@ -0,0 +1,11 @@
+// app/server/profile_default.go
+//go:build !standalone
+
+package main
+
+// defaults are the configuration defaults of the container deployment, where
+// the environment is set by the pod spec
+var defaults = profile{
+	DataDir:     "/tmp/health-reports",
+	StoreDriver: "file",
+}
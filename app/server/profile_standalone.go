@@ -0,0 +1,27 @@
+// app/server/profile_standalone.go
+//go:build standalone
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// defaults are the configuration defaults of the standalone binary built by
+// build-standalone.sh, meant to be run on a laptop without any setup: data is
+// kept in one SQLite file in the user's data directory and the server only
+// listens on the loopback interface
+var defaults = profile{
+	DataDir:     standaloneDataDir(),
+	StoreDriver: "sqlite",
+	BindAddress: "127.0.0.1",
+}
+
+// standaloneDataDir returns a per-user directory for the dashboard data
+func standaloneDataDir() string {
+	if dir, err := os.UserConfigDir(); err == nil {
+		return filepath.Join(dir, "health-dashboard")
+	}
+	return filepath.Join(os.TempDir(), "health-dashboard")
+}
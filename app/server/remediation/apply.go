@@ -0,0 +1,94 @@
+// app/server/remediation/apply.go
+package remediation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// fieldManager owns the fields the dashboard applies
+const fieldManager = "health-dashboard-remediation"
+
+// safeKinds are the kinds a remediation may apply, by apiVersion and kind.
+// They are namespaced and only restrict the workloads of their namespace, so
+// applying them can't give anyone more access.
+var safeKinds = map[schema.GroupVersionKind]schema.GroupVersionResource{
+	{Group: "networking.k8s.io", Version: "v1", Kind: "NetworkPolicy"}: {Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+	{Version: "v1", Kind: "ResourceQuota"}:                             {Version: "v1", Resource: "resourcequotas"},
+	{Version: "v1", Kind: "LimitRange"}:                                {Version: "v1", Resource: "limitranges"},
+}
+
+// ErrUnsafe is returned for manifests a remediation may not apply
+var ErrUnsafe = errors.New("remediation can't be applied automatically")
+
+// Check parses the manifests of a remediation and makes sure they are all of
+// a safe kind and target the namespace
+func Check(manifests []types.Manifest, namespace string) ([]*unstructured.Unstructured, error) {
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("%w: it has no manifests, only commands to run by hand", ErrUnsafe)
+	}
+
+	objects := make([]*unstructured.Unstructured, 0, len(manifests))
+	for i, manifest := range manifests {
+		object := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal([]byte(manifest.YAML), &object.Object); err != nil {
+			return nil, fmt.Errorf("error parsing manifest %d: %w", i+1, err)
+		}
+		gvk := object.GroupVersionKind()
+		switch {
+		case safeKinds[gvk].Resource == "":
+			return nil, fmt.Errorf("%w: manifest %d is a %s", ErrUnsafe, i+1, gvk.Kind)
+		case object.GetName() == "":
+			return nil, fmt.Errorf("%w: manifest %d has no name", ErrUnsafe, i+1)
+		case object.GetNamespace() != namespace:
+			return nil, fmt.Errorf("%w: manifest %d targets namespace %q instead of %q", ErrUnsafe, i+1, object.GetNamespace(), namespace)
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
+
+// Apply applies the manifests of a remediation with server-side apply. All of
+// them are dry-run first, so a manifest the cluster rejects changes nothing;
+// it returns the objects applied, as kind namespace/name.
+func Apply(ctx context.Context, client dynamic.Interface, remediation Remediation) ([]string, error) {
+	objects, err := Check(remediation.Manifests, remediation.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := applyAll(ctx, client, objects, true); err != nil {
+		return nil, err
+	}
+	return applyAll(ctx, client, objects, false)
+}
+
+// applyAll applies objects in order, stopping at the first failure
+func applyAll(ctx context.Context, client dynamic.Interface, objects []*unstructured.Unstructured, dryRun bool) ([]string, error) {
+	options := metav1.ApplyOptions{FieldManager: fieldManager}
+	if dryRun {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+
+	var applied []string
+	for _, object := range objects {
+		resource := safeKinds[object.GroupVersionKind()]
+		name := fmt.Sprintf("%s %s/%s", object.GetKind(), object.GetNamespace(), object.GetName())
+		if _, err := client.Resource(resource).Namespace(object.GetNamespace()).Apply(ctx, object.GetName(), object, options); err != nil {
+			if dryRun {
+				return nil, fmt.Errorf("dry run of %s failed: %w", name, err)
+			}
+			return applied, fmt.Errorf("error applying %s: %w", name, err)
+		}
+		applied = append(applied, name)
+	}
+	return applied, nil
+}
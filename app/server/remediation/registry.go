@@ -0,0 +1,212 @@
+// app/server/remediation/registry.go
+
+// Package remediation applies the suggested manifests of the knowledge base
+// to clusters. A remediation is requested for a finding and a namespace,
+// waits for an explicit approval by someone other than the requester, and is
+// only then applied; manifests outside a small set of namespaced, additive
+// kinds are never accepted.
+package remediation
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+var (
+	// ErrNotFound is returned when a remediation does not exist
+	ErrNotFound = errors.New("remediation not found")
+
+	// ErrNotPending is returned when deciding on a remediation that was already decided
+	ErrNotPending = errors.New("remediation is not awaiting approval")
+)
+
+// Status is the state of a remediation
+type Status string
+
+const (
+	// StatusPending remediations wait for approval
+	StatusPending Status = "pending"
+
+	// StatusApproved remediations are being applied
+	StatusApproved Status = "approved"
+
+	// StatusApplied remediations were applied to the cluster
+	StatusApplied Status = "applied"
+
+	// StatusFailed remediations were approved but could not be applied
+	StatusFailed Status = "failed"
+
+	// StatusRejected remediations were turned down and never applied
+	StatusRejected Status = "rejected"
+)
+
+// Remediation is a request to apply the suggested manifests of a finding
+type Remediation struct {
+	ID        string           `json:"id"`
+	Cluster   string           `json:"cluster"`
+	Finding   string           `json:"finding"`
+	Namespace string           `json:"namespace"`
+	Title     string           `json:"title"`
+	Manifests []types.Manifest `json:"manifests"`
+	Status    Status           `json:"status"`
+
+	RequestedBy string    `json:"requestedBy,omitempty"`
+	RequestedAt time.Time `json:"requestedAt"`
+
+	// DecidedBy approved or rejected the remediation, with an optional comment
+	DecidedBy string     `json:"decidedBy,omitempty"`
+	DecidedAt *time.Time `json:"decidedAt,omitempty"`
+	Comment   string     `json:"comment,omitempty"`
+
+	// Applied lists the objects applied, as kind namespace/name; Error is why
+	// applying failed
+	Applied   []string   `json:"applied,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	AppliedAt *time.Time `json:"appliedAt,omitempty"`
+}
+
+// Registry persists remediations in a JSON file
+type Registry struct {
+	path         string
+	mu           sync.RWMutex
+	remediations map[string]*Remediation
+}
+
+// New loads the remediations stored at path
+func New(path string) (*Registry, error) {
+	r := &Registry{path: path, remediations: make(map[string]*Remediation)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return r, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading remediations: %w", err)
+	}
+
+	var list []*Remediation
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("error parsing remediations: %w", err)
+	}
+	for _, remediation := range list {
+		// A remediation approved when the server stopped may have been applied in part
+		if remediation.Status == StatusApproved {
+			remediation.Status = StatusFailed
+			remediation.Error = "the server stopped while the remediation was being applied"
+		}
+		r.remediations[remediation.ID] = remediation
+	}
+	log.Printf("Loaded %d remediations from %s", len(r.remediations), path)
+	return r, nil
+}
+
+// List returns all remediations, newest first
+func (r *Registry) List() []Remediation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	remediations := make([]Remediation, 0, len(r.remediations))
+	for _, remediation := range r.remediations {
+		remediations = append(remediations, *remediation)
+	}
+	sort.Slice(remediations, func(i, j int) bool {
+		if !remediations[i].RequestedAt.Equal(remediations[j].RequestedAt) {
+			return remediations[i].RequestedAt.After(remediations[j].RequestedAt)
+		}
+		return remediations[i].ID < remediations[j].ID
+	})
+	return remediations
+}
+
+// Get returns a remediation by ID
+func (r *Registry) Get(id string) (Remediation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	remediation, ok := r.remediations[id]
+	if !ok {
+		return Remediation{}, ErrNotFound
+	}
+	return *remediation, nil
+}
+
+// Create adds a pending remediation with a generated ID
+func (r *Registry) Create(remediation Remediation) (Remediation, error) {
+	remediation.ID = newID()
+	remediation.Status = StatusPending
+	remediation.RequestedAt = time.Now().UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.remediations[remediation.ID] = &remediation
+	if err := r.saveLocked(); err != nil {
+		delete(r.remediations, remediation.ID)
+		return Remediation{}, err
+	}
+	return remediation, nil
+}
+
+// Update changes a remediation and saves it; change sees the current version
+// and nothing is saved when it returns an error
+func (r *Registry) Update(id string, change func(*Remediation) error) (Remediation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.remediations[id]
+	if !ok {
+		return Remediation{}, ErrNotFound
+	}
+
+	updated := *existing
+	if err := change(&updated); err != nil {
+		return Remediation{}, err
+	}
+
+	r.remediations[id] = &updated
+	if err := r.saveLocked(); err != nil {
+		r.remediations[id] = existing
+		return Remediation{}, err
+	}
+	return updated, nil
+}
+
+// saveLocked writes the remediations to disk; r.mu must be held
+func (r *Registry) saveLocked() error {
+	remediations := make([]*Remediation, 0, len(r.remediations))
+	for _, remediation := range r.remediations {
+		remediations = append(remediations, remediation)
+	}
+	sort.Slice(remediations, func(i, j int) bool { return remediations[i].ID < remediations[j].ID })
+
+	data, err := json.MarshalIndent(remediations, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding remediations: %w", err)
+	}
+
+	// Write to a temp file first so a crash never leaves a truncated file
+	if err := os.WriteFile(r.path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("error writing remediations: %w", err)
+	}
+	if err := os.Rename(r.path+".tmp", r.path); err != nil {
+		return fmt.Errorf("error writing remediations: %w", err)
+	}
+	return nil
+}
+
+// newID returns a random remediation ID
+func newID() string {
+	buf := make([]byte, 6)
+	rand.Read(buf)
+	return "rem-" + hex.EncodeToString(buf)
+}
@@ -0,0 +1,149 @@
+// app/server/scanner/authentication.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// kubeadminSecret holds the password of the temporary installer user
+const kubeadminSecret = "kubeadmin"
+
+// broadGroups contain every user, or every authenticated user, of the cluster
+var broadGroups = map[string]bool{
+	"system:authenticated":       true,
+	"system:authenticated:oauth": true,
+	"system:unauthenticated":     true,
+}
+
+// privilegedRoles let their subjects change or read everything they apply to
+var privilegedRoles = map[string]bool{
+	"cluster-admin":  true,
+	"admin":          true,
+	"edit":           true,
+	"cluster-reader": true,
+}
+
+// AuthenticationCheck verifies the kubeadmin user is removed, users log in
+// through an identity provider and no broad group holds privileged roles
+type AuthenticationCheck struct{}
+
+// ID returns the check identifier
+func (c *AuthenticationCheck) ID() string {
+	return "authentication"
+}
+
+// Inputs returns the resources the check reads
+func (c *AuthenticationCheck) Inputs() []Input {
+	return []Input{
+		{Resource: secretResource, Namespace: "kube-system"},
+		{Resource: oauthResource},
+		{Resource: clusterRoleBindingResource},
+	}
+}
+
+// Run inspects the kubeadmin secret, the OAuth configuration and the cluster role bindings
+func (c *AuthenticationCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	kubeadmin := Result{
+		Category:    CategorySecurity,
+		Item:        "kubeadmin User",
+		Status:      types.ResultKeyNoChange,
+		Observation: "The kubeadmin user has been removed",
+	}
+	_, err := clients.Kube.CoreV1().Secrets("kube-system").Get(ctx, kubeadminSecret, metav1.GetOptions{})
+	switch {
+	case err == nil:
+		kubeadmin.Status = types.ResultKeyRequired
+		kubeadmin.Observation = "The kubeadmin user created by the installer still exists"
+		kubeadmin.Recommendation = "Once cluster administrators log in through an identity provider, remove the user with " +
+			"`oc delete secret kubeadmin -n kube-system`; its password is often shared and never expires."
+	case !apierrors.IsNotFound(err):
+		return nil, fmt.Errorf("error getting kubeadmin secret: %w", err)
+	}
+
+	oauth, err := clients.Config.ConfigV1().OAuths().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting OAuth configuration: %w", err)
+	}
+	providers := Result{
+		Category: CategorySecurity,
+		Item:     "Identity Providers",
+		Status:   types.ResultKeyNoChange,
+	}
+	if len(oauth.Spec.IdentityProviders) == 0 {
+		providers.Status = types.ResultKeyRequired
+		providers.Observation = "No identity provider is configured, so only kubeadmin and certificate users can log in"
+		providers.Recommendation = "Configure an identity provider such as LDAP, OpenID Connect or HTPasswd in the " +
+			"cluster OAuth resource and grant cluster-admin to a group of named administrators."
+	} else {
+		names := make([]string, 0, len(oauth.Spec.IdentityProviders))
+		for _, provider := range oauth.Spec.IdentityProviders {
+			names = append(names, fmt.Sprintf("%s (%s)", provider.Name, provider.Type))
+		}
+		providers.Observation = fmt.Sprintf("Users log in through %s", strings.Join(names, ", "))
+	}
+
+	bindings, err := clients.Kube.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing cluster role bindings: %w", err)
+	}
+
+	return []Result{kubeadmin, providers, groupBindingResult(bindings.Items)}, nil
+}
+
+// groupBindingResult reports cluster role bindings that grant privileged
+// roles, or project self-provisioning, to every user
+func groupBindingResult(bindings []rbacv1.ClusterRoleBinding) Result {
+	result := Result{
+		Category: CategorySecurity,
+		Item:     "Cluster Role Group Bindings",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var privileged, selfProvisioning []string
+	var adminGroups int
+	for _, binding := range bindings {
+		if binding.RoleRef.Kind != "ClusterRole" {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			if subject.Kind != rbacv1.GroupKind {
+				continue
+			}
+			switch {
+			case broadGroups[subject.Name] && privilegedRoles[binding.RoleRef.Name]:
+				privileged = append(privileged, fmt.Sprintf("%s to %s (%s)", binding.RoleRef.Name, subject.Name, binding.Name))
+			case broadGroups[subject.Name] && binding.RoleRef.Name == "self-provisioner":
+				selfProvisioning = append(selfProvisioning, fmt.Sprintf("%s (%s)", subject.Name, binding.Name))
+			case binding.RoleRef.Name == "cluster-admin" && !strings.HasPrefix(subject.Name, "system:"):
+				adminGroups++
+			}
+		}
+	}
+	sort.Strings(privileged)
+	sort.Strings(selfProvisioning)
+
+	switch {
+	case len(privileged) > 0:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("Privileged cluster roles are granted to every user: %s", strings.Join(privileged, "; "))
+		result.Recommendation = "Remove the bindings and grant the roles to named groups from the identity provider instead."
+	case len(selfProvisioning) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("Every user may create projects through the self-provisioner role: %s", strings.Join(selfProvisioning, ", "))
+		result.Recommendation = "Remove self-provisioner from system:authenticated:oauth and grant it to the groups " +
+			"that need projects, or provide projects through a request process."
+	default:
+		result.Observation = fmt.Sprintf("No broad group holds a privileged cluster role; %d groups are cluster administrators", adminGroups)
+	}
+	return result
+}
@@ -0,0 +1,234 @@
+// app/server/scanner/availability.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// replicatedWorkload is a Deployment or StatefulSet that runs several replicas
+// to stay available
+type replicatedWorkload struct {
+	namespace string
+	name      string
+	replicas  int32
+	labels    map[string]string
+}
+
+// AvailabilityCheck verifies multi-replica workloads of user namespaces are
+// protected by a PodDisruptionBudget and spread across nodes and zones
+type AvailabilityCheck struct{}
+
+// ID returns the check identifier
+func (c *AvailabilityCheck) ID() string {
+	return "availability"
+}
+
+// Inputs returns the resources the check reads
+func (c *AvailabilityCheck) Inputs() []Input {
+	return []Input{
+		{Resource: deploymentResource},
+		{Resource: statefulSetResource},
+		{Resource: policyv1.SchemeGroupVersion.WithResource("poddisruptionbudgets")},
+		{Resource: podResource},
+		{Resource: nodeResource},
+	}
+}
+
+// Run inspects the Deployments, StatefulSets, disruption budgets and pods of
+// user namespaces, and the zones of the nodes
+func (c *AvailabilityCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	deployments, err := clients.Kube.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing deployments: %w", err)
+	}
+	statefulSets, err := clients.Kube.AppsV1().StatefulSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing stateful sets: %w", err)
+	}
+	budgets, err := clients.Kube.PolicyV1().PodDisruptionBudgets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pod disruption budgets: %w", err)
+	}
+	pods, err := clients.Kube.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods: %w", err)
+	}
+	nodes, err := clients.Kube.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	scope := scopeFrom(ctx)
+	var workloads []replicatedWorkload
+	for _, deployment := range deployments.Items {
+		if !scope.covers(deployment.Namespace) || deployment.Spec.Replicas == nil || *deployment.Spec.Replicas < 2 {
+			continue
+		}
+		workloads = append(workloads, replicatedWorkload{
+			namespace: deployment.Namespace,
+			name:      "deployment/" + deployment.Name,
+			replicas:  *deployment.Spec.Replicas,
+			labels:    deployment.Spec.Template.Labels,
+		})
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if !scope.covers(statefulSet.Namespace) || statefulSet.Spec.Replicas == nil || *statefulSet.Spec.Replicas < 2 {
+			continue
+		}
+		workloads = append(workloads, replicatedWorkload{
+			namespace: statefulSet.Namespace,
+			name:      "statefulset/" + statefulSet.Name,
+			replicas:  *statefulSet.Spec.Replicas,
+			labels:    statefulSet.Spec.Template.Labels,
+		})
+	}
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].namespace != workloads[j].namespace {
+			return workloads[i].namespace < workloads[j].namespace
+		}
+		return workloads[i].name < workloads[j].name
+	})
+
+	return []Result{
+		disruptionBudgetResult(workloads, budgets.Items),
+		replicaSpreadResult(workloads, pods.Items, nodes.Items),
+	}, nil
+}
+
+// disruptionBudgetResult reports replicated workloads no PodDisruptionBudget
+// selects, so a node drain may evict all their replicas at once
+func disruptionBudgetResult(workloads []replicatedWorkload, budgets []policyv1.PodDisruptionBudget) Result {
+	result := Result{
+		Category: CategoryApplications,
+		Item:     "Pod Disruption Budgets",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if len(workloads) == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "No workload of a user namespace runs more than one replica"
+		return result
+	}
+
+	attachment := &Attachment{
+		Title:   "Replicated workloads without a PodDisruptionBudget",
+		Columns: []string{"Namespace", "Workload", "Replicas"},
+	}
+	for _, workload := range workloads {
+		covered := false
+		for _, budget := range budgets {
+			if budget.Namespace != workload.namespace || budget.Spec.Selector == nil {
+				continue
+			}
+			selector, err := metav1.LabelSelectorAsSelector(budget.Spec.Selector)
+			if err != nil || selector.Empty() {
+				continue
+			}
+			if selector.Matches(labels.Set(workload.labels)) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			attachment.Rows = append(attachment.Rows, []string{workload.namespace, workload.name, strconv.Itoa(int(workload.replicas))})
+		}
+	}
+
+	if len(attachment.Rows) == 0 {
+		result.Observation = fmt.Sprintf("All %d replicated workloads are protected by a PodDisruptionBudget", len(workloads))
+		return result
+	}
+
+	result.Status = types.ResultKeyRecommended
+	result.Observation = fmt.Sprintf("%d of %d replicated workloads have no PodDisruptionBudget, so node drains during "+
+		"upgrades may evict all their replicas at once", len(attachment.Rows), len(workloads))
+	result.Recommendation = "Add a PodDisruptionBudget with maxUnavailable: 1 selecting the pods of each workload; " +
+		"avoid budgets that allow no disruption, as they block node drains."
+	result.Attachment = attachment
+	return result
+}
+
+// replicaSpreadResult reports replicated workloads whose running pods share a
+// node, or a zone while the cluster spans several
+func replicaSpreadResult(workloads []replicatedWorkload, pods []corev1.Pod, nodes []corev1.Node) Result {
+	result := Result{
+		Category: CategoryApplications,
+		Item:     "Replica Spread",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if len(workloads) == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "No workload of a user namespace runs more than one replica"
+		return result
+	}
+
+	zones := make(map[string]string, len(nodes))
+	clusterZones := make(map[string]bool)
+	for _, node := range nodes {
+		if zone := node.Labels[zoneLabel]; zone != "" {
+			zones[node.Name] = zone
+			clusterZones[zone] = true
+		}
+	}
+
+	// Nodes and zones of the running pods by namespace and workload
+	placed := make(map[string]map[string]bool)
+	placedZones := make(map[string]map[string]bool)
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning || pod.Spec.NodeName == "" {
+			continue
+		}
+		key := pod.Namespace + "/" + workloadName(pod)
+		if placed[key] == nil {
+			placed[key] = make(map[string]bool)
+			placedZones[key] = make(map[string]bool)
+		}
+		placed[key][pod.Spec.NodeName] = true
+		if zone := zones[pod.Spec.NodeName]; zone != "" {
+			placedZones[key][zone] = true
+		}
+	}
+
+	attachment := &Attachment{
+		Title:   "Replicated workloads concentrated on one node or zone",
+		Columns: []string{"Namespace", "Workload", "Replicas", "Nodes", "Zones"},
+	}
+	for _, workload := range workloads {
+		key := workload.namespace + "/" + workload.name
+		nodeCount, zoneCount := len(placed[key]), len(placedZones[key])
+		if nodeCount == 0 {
+			continue
+		}
+		if nodeCount == 1 || (len(clusterZones) > 1 && zoneCount == 1) {
+			attachment.Rows = append(attachment.Rows, []string{
+				workload.namespace, workload.name, strconv.Itoa(int(workload.replicas)),
+				strconv.Itoa(nodeCount), strconv.Itoa(zoneCount),
+			})
+		}
+	}
+
+	if len(attachment.Rows) == 0 {
+		result.Observation = fmt.Sprintf("The running replicas of all %d replicated workloads are spread across nodes and zones", len(workloads))
+		return result
+	}
+
+	result.Status = types.ResultKeyRecommended
+	result.Observation = fmt.Sprintf("%d of %d replicated workloads run all their replicas on one node or zone, so a "+
+		"single failure stops them", len(attachment.Rows), len(workloads))
+	result.Recommendation = "Add topologySpreadConstraints on kubernetes.io/hostname and " + zoneLabel + ", or " +
+		"preferred pod anti-affinity, to the pod templates of the workloads."
+	result.Attachment = attachment
+	return result
+}
@@ -0,0 +1,282 @@
+// app/server/scanner/backup.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+const (
+	// CriticalNamespaceLabel marks the namespaces that backup schedules must
+	// include; without labelled namespaces every user namespace is critical
+	CriticalNamespaceLabel = "health.ayaseen.io/critical"
+
+	// backupMaxAge is how old the last successful backup may be
+	backupMaxAge = 48 * time.Hour
+
+	// etcdBackupScript is the script of the control plane nodes that takes an etcd snapshot
+	etcdBackupScript = "cluster-backup.sh"
+)
+
+var (
+	// veleroSchedules and veleroBackups are the resources of Velero, which
+	// OADP installs, to schedule and record backups
+	veleroSchedules = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "schedules"}
+	veleroBackups   = schema.GroupVersionResource{Group: "velero.io", Version: "v1", Resource: "backups"}
+)
+
+// BackupCheck verifies etcd is backed up on a schedule, OADP or Velero backs
+// up the applications, and the backup schedules include the critical namespaces
+type BackupCheck struct{}
+
+// ID returns the check identifier
+func (c *BackupCheck) ID() string {
+	return "backup"
+}
+
+// Run inspects the CronJobs of the cluster and the Velero schedules and backups
+func (c *BackupCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	cronJobs, err := clients.Kube.BatchV1().CronJobs(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing cron jobs: %w", err)
+	}
+	namespaces, err := clients.Kube.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing namespaces: %w", err)
+	}
+
+	now := time.Now()
+	scope := scopeFrom(ctx)
+	results := []Result{etcdBackupResult(cronJobs.Items, now)}
+
+	// Without the Velero resources neither OADP nor Velero is installed
+	schedules, err := clients.Dynamic.Resource(veleroSchedules).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return append(results,
+			Result{
+				Category:    CategoryOpReady,
+				Item:        "Application Backup",
+				Status:      types.ResultKeyRequired,
+				Observation: "Neither OADP nor Velero is installed, so no application data or resources are backed up",
+				Recommendation: "Install the OADP operator, configure a DataProtectionApplication with object storage " +
+					"and schedule backups of the application namespaces.",
+			},
+			namespaceCoverageResult(namespaces.Items, nil, scope),
+		), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listing Velero schedules: %w", err)
+	}
+	backups, err := clients.Dynamic.Resource(veleroBackups).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing Velero backups: %w", err)
+	}
+
+	return append(results,
+		applicationBackupResult(schedules.Items, backups.Items, now),
+		namespaceCoverageResult(namespaces.Items, schedules.Items, scope),
+	), nil
+}
+
+// etcdBackupResult looks for a CronJob that runs the etcd backup script and
+// reports when it last succeeded
+func etcdBackupResult(cronJobs []batchv1.CronJob, now time.Time) Result {
+	result := Result{
+		Category: CategoryOpReady,
+		Item:     "etcd Backup",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var found []batchv1.CronJob
+	for _, cronJob := range cronJobs {
+		if etcdBackupJob(cronJob) {
+			found = append(found, cronJob)
+		}
+	}
+
+	if len(found) == 0 {
+		result.Status = types.ResultKeyRequired
+		result.Observation = "No CronJob takes etcd snapshots, so the cluster state can't be restored after losing the control plane"
+		result.Recommendation = "Schedule " + etcdBackupScript + " on a control plane node with a CronJob and copy the " +
+			"snapshots off the cluster, as described in the OpenShift etcd backup documentation."
+		return result
+	}
+
+	// Judge the schedule that succeeded last
+	var last *metav1.Time
+	var names []string
+	for _, cronJob := range found {
+		name := cronJob.Namespace + "/" + cronJob.Name
+		if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+			name += " (suspended)"
+		} else if succeeded := cronJob.Status.LastSuccessfulTime; succeeded != nil && (last == nil || succeeded.After(last.Time)) {
+			last = succeeded
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	switch {
+	case last == nil:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("etcd backup CronJobs exist but none has succeeded: %s", strings.Join(names, ", "))
+		result.Recommendation = "Check the jobs of the etcd backup CronJob with `oc get jobs` and their logs, and resume suspended CronJobs."
+	case now.Sub(last.Time) > backupMaxAge:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("The last successful etcd backup finished on %s, more than %.0f hours ago (%s)",
+			last.Format("2006-01-02 15:04"), backupMaxAge.Hours(), strings.Join(names, ", "))
+		result.Recommendation = "Find out why the recent etcd backup jobs failed; a restore from an old snapshot loses every change since."
+	default:
+		result.Observation = fmt.Sprintf("etcd is backed up by %s; the last backup succeeded on %s",
+			strings.Join(names, ", "), last.Format("2006-01-02 15:04"))
+	}
+	return result
+}
+
+// etcdBackupJob reports whether a CronJob backs up etcd, by its name or the
+// command of its containers
+func etcdBackupJob(cronJob batchv1.CronJob) bool {
+	if strings.Contains(cronJob.Name, "etcd") && strings.Contains(cronJob.Name, "backup") {
+		return true
+	}
+	for _, container := range cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers {
+		command := strings.Join(append(append([]string{}, container.Command...), container.Args...), " ")
+		if strings.Contains(command, etcdBackupScript) {
+			return true
+		}
+	}
+	return false
+}
+
+// applicationBackupResult reports the Velero schedules and the age of the
+// last completed backup
+func applicationBackupResult(schedules, backups []unstructured.Unstructured, now time.Time) Result {
+	result := Result{
+		Category: CategoryOpReady,
+		Item:     "Application Backup",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var active int
+	for _, schedule := range schedules {
+		if paused, _, _ := unstructured.NestedBool(schedule.Object, "spec", "paused"); !paused {
+			active++
+		}
+	}
+	if active == 0 {
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("OADP or Velero is installed but none of its %d schedules is active", len(schedules))
+		result.Recommendation = "Create a Velero Schedule for the application namespaces, or unpause the existing ones."
+		return result
+	}
+
+	var last time.Time
+	for _, backup := range backups {
+		if phase, _, _ := unstructured.NestedString(backup.Object, "status", "phase"); phase != "Completed" {
+			continue
+		}
+		completed, _, _ := unstructured.NestedString(backup.Object, "status", "completionTimestamp")
+		if finished, err := time.Parse(time.RFC3339, completed); err == nil && finished.After(last) {
+			last = finished
+		}
+	}
+
+	switch {
+	case last.IsZero():
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("%d Velero schedules are active but no backup has completed", active)
+		result.Recommendation = "Check the failed or partially failed backups with `velero backup describe` and the " +
+			"BackupStorageLocation of the DataProtectionApplication."
+	case now.Sub(last) > backupMaxAge:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("%d Velero schedules are active but the last backup completed on %s, more than %.0f hours ago",
+			active, last.Format("2006-01-02 15:04"), backupMaxAge.Hours())
+		result.Recommendation = "Find out why the recent backups didn't complete with `velero backup describe --details`."
+	default:
+		result.Observation = fmt.Sprintf("%d Velero schedules are active; the last backup completed on %s",
+			active, last.Format("2006-01-02 15:04"))
+	}
+	return result
+}
+
+// namespaceCoverageResult reports critical namespaces no active Velero
+// schedule includes
+func namespaceCoverageResult(namespaces []corev1.Namespace, schedules []unstructured.Unstructured, scope *namespaceScope) Result {
+	result := Result{
+		Category: CategoryOpReady,
+		Item:     "Backup Namespace Coverage",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var labelled, user []string
+	for _, namespace := range namespaces {
+		if _, ok := namespace.Labels[CriticalNamespaceLabel]; ok {
+			labelled = append(labelled, namespace.Name)
+		}
+		if scope.covers(namespace.Name) {
+			user = append(user, namespace.Name)
+		}
+	}
+	critical, kind := labelled, "namespaces labelled "+CriticalNamespaceLabel
+	if len(critical) == 0 {
+		critical, kind = user, "user namespaces"
+	}
+	if len(critical) == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "The cluster has no user namespaces to back up"
+		return result
+	}
+
+	var uncovered []string
+	for _, namespace := range critical {
+		if !scheduled(schedules, namespace) {
+			uncovered = append(uncovered, namespace)
+		}
+	}
+	sort.Strings(uncovered)
+
+	if len(uncovered) == 0 {
+		result.Observation = fmt.Sprintf("Active backup schedules include all %d %s", len(critical), kind)
+		return result
+	}
+
+	result.Status = types.ResultKeyRequired
+	result.Observation = fmt.Sprintf("%d of %d %s aren't included in an active backup schedule: %s",
+		len(uncovered), len(critical), kind, strings.Join(uncovered, ", "))
+	result.Recommendation = "Add the namespaces to the includedNamespaces of a Velero Schedule, and label the namespaces " +
+		"that must be restorable with " + CriticalNamespaceLabel + " so this check follows them."
+	return result
+}
+
+// scheduled reports whether an active Velero schedule includes a namespace; a
+// schedule without included namespaces, or with "*", includes every namespace
+func scheduled(schedules []unstructured.Unstructured, namespace string) bool {
+	for _, schedule := range schedules {
+		if paused, _, _ := unstructured.NestedBool(schedule.Object, "spec", "paused"); paused {
+			continue
+		}
+		included, _, _ := unstructured.NestedStringSlice(schedule.Object, "spec", "template", "includedNamespaces")
+		excluded, _, _ := unstructured.NestedStringSlice(schedule.Object, "spec", "template", "excludedNamespaces")
+		if slices.Contains(excluded, namespace) {
+			continue
+		}
+		if len(included) == 0 || slices.Contains(included, "*") || slices.Contains(included, namespace) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,300 @@
+// app/server/scanner/certificates.go
+package scanner
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+const (
+	// CertificateLabel marks secrets and config maps holding user-provided
+	// certificates, such as application or proxy CAs, for the certificate check
+	CertificateLabel = "health.ayaseen.io/certificate"
+
+	// certificateRequiredDays and certificateRecommendedDays are the days
+	// remaining below which an expiring certificate is required or recommended
+	// to be renewed
+	certificateRequiredDays    = 14
+	certificateRecommendedDays = 30
+
+	// ingressOperatorNamespace holds the IngressControllers and the ingress CA
+	ingressOperatorNamespace = "openshift-ingress-operator"
+
+	// ingressNamespace holds the serving certificates of the routers
+	ingressNamespace = "openshift-ingress"
+
+	// configNamespace holds the certificates users provide to the platform
+	configNamespace = "openshift-config"
+)
+
+// internalSigners are the platform CAs whose rotation keeps the internal
+// certificates valid, by namespace and secret name
+var internalSigners = []struct {
+	namespace, name string
+}{
+	{"openshift-kube-apiserver-operator", "kube-apiserver-to-kubelet-signer"},
+	{"openshift-kube-apiserver-operator", "kube-control-plane-signer"},
+	{"openshift-kube-apiserver-operator", "loadbalancer-serving-signer"},
+	{"openshift-kube-apiserver-operator", "localhost-serving-signer"},
+	{"openshift-kube-apiserver-operator", "service-network-serving-signer"},
+	{"openshift-kube-apiserver-operator", "node-system-admin-signer"},
+	{"openshift-config", "etcd-signer"},
+	{"openshift-service-ca", "signing-key"},
+	{ingressOperatorNamespace, "router-ca"},
+}
+
+// certificate is a parsed certificate and where it was found
+type certificate struct {
+	source   string
+	subject  string
+	notAfter time.Time
+}
+
+// CertificateCheck looks for certificates that expire soon: the API server
+// and ingress serving certificates, the internal signers and the
+// user-provided certificates labelled with CertificateLabel
+type CertificateCheck struct{}
+
+// ID returns the check identifier
+func (c *CertificateCheck) ID() string {
+	return "certificates"
+}
+
+// Run collects the certificates of each group and judges each group by the
+// certificate that expires first
+func (c *CertificateCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	apiServer, err := c.apiServerCertificates(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+	ingress, err := c.ingressCertificates(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+	signers, err := c.signerCertificates(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+	user, err := c.labelledCertificates(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return []Result{
+		expiryResult("API Server Certificates", apiServer, now,
+			"Renew the named certificates of the APIServer resource by updating their secrets in "+configNamespace+
+				"; clients reject the API once they expire."),
+		expiryResult("Ingress Certificates", ingress, now,
+			"Replace the default certificate secrets of the IngressControllers before they expire, or routes "+
+				"without their own certificate stop working."),
+		expiryResult("Internal CA Certificates", signers, now,
+			"The operators rotate these signers automatically; one close to expiry means the rotation is stuck, "+
+				"so check the kube-apiserver, etcd, service-ca and ingress operators and contact Red Hat support."),
+		expiryResult("User-Provided Certificates", user, now,
+			"Renew the certificates labelled "+CertificateLabel+" and update their secrets or config maps."),
+	}, nil
+}
+
+// apiServerCertificates reads the named serving certificates of the API server
+func (c *CertificateCheck) apiServerCertificates(ctx context.Context, clients *live.Clients) ([]certificate, error) {
+	apiServer, err := clients.Config.ConfigV1().APIServers().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting API server configuration: %w", err)
+	}
+
+	var certificates []certificate
+	for _, named := range apiServer.Spec.ServingCerts.NamedCertificates {
+		found, err := secretCertificates(ctx, clients, configNamespace, named.ServingCertificate.Name)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, found...)
+	}
+	return certificates, nil
+}
+
+// ingressCertificates reads the default certificate of every IngressController
+func (c *CertificateCheck) ingressCertificates(ctx context.Context, clients *live.Clients) ([]certificate, error) {
+	controllers, err := clients.Operator.OperatorV1().IngressControllers(ingressOperatorNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ingress controllers: %w", err)
+	}
+
+	var certificates []certificate
+	for _, controller := range controllers.Items {
+		// Without a default certificate the operator generates router-certs-<name>
+		name := "router-certs-" + controller.Name
+		if controller.Spec.DefaultCertificate != nil {
+			name = controller.Spec.DefaultCertificate.Name
+		}
+		found, err := secretCertificates(ctx, clients, ingressNamespace, name)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, found...)
+	}
+	return certificates, nil
+}
+
+// signerCertificates reads the internal signers that exist on the cluster
+func (c *CertificateCheck) signerCertificates(ctx context.Context, clients *live.Clients) ([]certificate, error) {
+	var certificates []certificate
+	for _, signer := range internalSigners {
+		found, err := secretCertificates(ctx, clients, signer.namespace, signer.name)
+		if err != nil {
+			return nil, err
+		}
+		certificates = append(certificates, found...)
+	}
+	return certificates, nil
+}
+
+// labelledCertificates reads the secrets and config maps labelled with CertificateLabel
+func (c *CertificateCheck) labelledCertificates(ctx context.Context, clients *live.Clients) ([]certificate, error) {
+	options := metav1.ListOptions{LabelSelector: CertificateLabel}
+
+	secrets, err := clients.Kube.CoreV1().Secrets(metav1.NamespaceAll).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("error listing labelled secrets: %w", err)
+	}
+	configMaps, err := clients.Kube.CoreV1().ConfigMaps(metav1.NamespaceAll).List(ctx, options)
+	if err != nil {
+		return nil, fmt.Errorf("error listing labelled config maps: %w", err)
+	}
+
+	var certificates []certificate
+	for _, secret := range secrets.Items {
+		certificates = append(certificates, secretPEM(&secret)...)
+	}
+	for _, configMap := range configMaps.Items {
+		source := "configmap/" + configMap.Namespace + "/" + configMap.Name
+		for _, key := range sortedKeys(configMap.Data) {
+			certificates = append(certificates, parsePEM(source, []byte(configMap.Data[key]))...)
+		}
+	}
+	return certificates, nil
+}
+
+// secretCertificates reads the certificates of a secret; a missing secret has none
+func secretCertificates(ctx context.Context, clients *live.Clients, namespace, name string) ([]certificate, error) {
+	secret, err := clients.Kube.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error getting secret %s/%s: %w", namespace, name, err)
+	}
+	return secretPEM(secret), nil
+}
+
+// secretPEM parses the certificates in every key of a secret
+func secretPEM(secret *corev1.Secret) []certificate {
+	source := "secret/" + secret.Namespace + "/" + secret.Name
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var certificates []certificate
+	for _, key := range keys {
+		certificates = append(certificates, parsePEM(source, secret.Data[key])...)
+	}
+	return certificates
+}
+
+// parsePEM returns the certificates of a PEM bundle; private keys and
+// malformed blocks are skipped
+func parsePEM(source string, data []byte) []certificate {
+	var certificates []certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return certificates
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		parsed, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		subject := parsed.Subject.CommonName
+		if subject == "" {
+			subject = parsed.Subject.String()
+		}
+		certificates = append(certificates, certificate{source: source, subject: subject, notAfter: parsed.NotAfter})
+	}
+}
+
+// expiryResult judges a group of certificates by the days they have left
+func expiryResult(item string, certificates []certificate, now time.Time, recommendation string) Result {
+	result := Result{
+		Category: CategorySecurity,
+		Item:     item,
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if len(certificates) == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "No certificates of this kind were found"
+		return result
+	}
+
+	sort.Slice(certificates, func(i, j int) bool { return certificates[i].notAfter.Before(certificates[j].notAfter) })
+
+	var expiring []string
+	for _, cert := range certificates {
+		days := int(cert.notAfter.Sub(now).Hours() / 24)
+		if days >= certificateRecommendedDays {
+			break
+		}
+		switch {
+		case !cert.notAfter.After(now):
+			expiring = append(expiring, fmt.Sprintf("%s in %s expired on %s", cert.subject, cert.source, cert.notAfter.Format("2006-01-02")))
+		default:
+			expiring = append(expiring, fmt.Sprintf("%s in %s expires in %d days", cert.subject, cert.source, days))
+		}
+		if days < certificateRequiredDays {
+			result.Status = types.ResultKeyRequired
+		} else if result.Status != types.ResultKeyRequired {
+			result.Status = types.ResultKeyRecommended
+		}
+	}
+
+	if len(expiring) == 0 {
+		first := certificates[0]
+		result.Observation = fmt.Sprintf("%d certificates are valid for more than %d days; the first to expire is %s in %s on %s",
+			len(certificates), certificateRecommendedDays, first.subject, first.source, first.notAfter.Format("2006-01-02"))
+		return result
+	}
+
+	result.Observation = fmt.Sprintf("%d of %d certificates expire within %d days: %s",
+		len(expiring), len(certificates), certificateRecommendedDays, strings.Join(expiring, "; "))
+	result.Recommendation = recommendation
+	return result
+}
+
+// sortedKeys returns the keys of a string map in order
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,77 @@
+// app/server/scanner/clusterversion.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// ClusterVersionCheck verifies the cluster version operator is healthy and on an update channel
+type ClusterVersionCheck struct{}
+
+// ID returns the check identifier
+func (c *ClusterVersionCheck) ID() string {
+	return "cluster-version"
+}
+
+// Inputs returns the resources the check reads
+func (c *ClusterVersionCheck) Inputs() []Input {
+	return []Input{
+		{Resource: clusterVersionResource},
+	}
+}
+
+// Run inspects the ClusterVersion resource
+func (c *ClusterVersionCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	cv, err := clients.Config.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting cluster version: %w", err)
+	}
+
+	version := cv.Status.Desired.Version
+	var results []Result
+
+	// Cluster version operator health
+	health := Result{
+		Category:    CategoryClusterConfig,
+		Item:        "Cluster Version",
+		Status:      types.ResultKeyNoChange,
+		Observation: fmt.Sprintf("Cluster is running OpenShift %s", version),
+	}
+	for _, condition := range cv.Status.Conditions {
+		if condition.Type == configv1.OperatorDegraded && condition.Status == configv1.ConditionTrue ||
+			condition.Type == "Failing" && condition.Status == configv1.ConditionTrue {
+			health.Status = types.ResultKeyRequired
+			health.Observation = fmt.Sprintf("Cluster version operator reports %s: %s", condition.Type, condition.Message)
+			health.Recommendation = "Investigate the cluster version operator conditions with `oc adm upgrade` and resolve the failure."
+			break
+		}
+	}
+	results = append(results, health)
+
+	// Update channel configuration
+	channel := Result{
+		Category:    CategoryClusterConfig,
+		Item:        "Update Channel",
+		Status:      types.ResultKeyNoChange,
+		Observation: fmt.Sprintf("Cluster is subscribed to the %s channel", cv.Spec.Channel),
+	}
+	if cv.Spec.Channel == "" {
+		channel.Status = types.ResultKeyRecommended
+		channel.Observation = "No update channel is configured"
+		channel.Recommendation = "Set an update channel (e.g. stable-4.x) so the cluster receives update recommendations."
+	} else if len(cv.Status.AvailableUpdates) > 0 {
+		channel.Status = types.ResultKeyAdvisory
+		channel.Observation = fmt.Sprintf("%d updates are available in the %s channel", len(cv.Status.AvailableUpdates), cv.Spec.Channel)
+		channel.Recommendation = "Plan an update to the latest available z-stream release."
+	}
+	results = append(results, channel)
+
+	return results, nil
+}
@@ -0,0 +1,161 @@
+// app/server/scanner/dns.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+const (
+	// dnsNamespace and dnsDaemonSet locate the CoreDNS pods managed by the DNS operator
+	dnsNamespace = "openshift-dns"
+	dnsDaemonSet = "dns-default"
+)
+
+// DNSCheck verifies the cluster DNS operator, the CoreDNS pods and the forwarding setup
+type DNSCheck struct{}
+
+// ID returns the check identifier
+func (c *DNSCheck) ID() string {
+	return "dns"
+}
+
+// Inputs returns the resources the check reads
+func (c *DNSCheck) Inputs() []Input {
+	return []Input{
+		{Resource: clusterOperatorResource},
+		{Resource: operatorv1.GroupVersion.WithResource("dnses")},
+		{Resource: daemonSetResource, Namespace: dnsNamespace},
+	}
+}
+
+// Run inspects the DNS operator, its CoreDNS daemon set and the default DNS resource
+func (c *DNSCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	health, err := c.healthResult(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	dns, err := clients.Operator.OperatorV1().DNSes().Get(ctx, "default", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting DNS operator configuration: %w", err)
+	}
+
+	return []Result{health, forwardingResult(dns), upstreamResult(dns)}, nil
+}
+
+// healthResult checks the DNS cluster operator conditions and that CoreDNS runs on every node
+func (c *DNSCheck) healthResult(ctx context.Context, clients *live.Clients) (Result, error) {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Cluster DNS",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	operator, err := clients.Config.ConfigV1().ClusterOperators().Get(ctx, "dns", metav1.GetOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("error getting DNS cluster operator: %w", err)
+	}
+	for _, condition := range operator.Status.Conditions {
+		if condition.Type == configv1.OperatorDegraded && condition.Status == configv1.ConditionTrue ||
+			condition.Type == configv1.OperatorAvailable && condition.Status == configv1.ConditionFalse {
+			result.Status = types.ResultKeyRequired
+			result.Observation = fmt.Sprintf("DNS operator reports %s=%s: %s", condition.Type, condition.Status, condition.Message)
+			result.Recommendation = "Investigate the DNS operator with `oc describe clusteroperator dns` and the pods in " + dnsNamespace + "."
+			return result, nil
+		}
+	}
+
+	daemonSet, err := clients.Kube.AppsV1().DaemonSets(dnsNamespace).Get(ctx, dnsDaemonSet, metav1.GetOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("error getting CoreDNS daemon set: %w", err)
+	}
+
+	desired, ready := daemonSet.Status.DesiredNumberScheduled, daemonSet.Status.NumberReady
+	if ready < desired {
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("Only %d of %d CoreDNS pods are ready", ready, desired)
+		result.Recommendation = "Check the unready dns-default pods; nodes without a local CoreDNS pod fall back to other nodes and add latency."
+		return result, nil
+	}
+
+	result.Observation = fmt.Sprintf("DNS operator is available and all %d CoreDNS pods are ready", desired)
+	return result, nil
+}
+
+// forwardingResult validates the per-zone forwarding servers of the default DNS resource
+func forwardingResult(dns *operatorv1.DNS) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "DNS Forwarding",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if len(dns.Spec.Servers) == 0 {
+		result.Observation = "No zone-specific forwarding is configured; all external names resolve through the upstream resolvers"
+		return result
+	}
+
+	var zones, problems []string
+	for _, server := range dns.Spec.Servers {
+		switch {
+		case len(server.Zones) == 0:
+			problems = append(problems, fmt.Sprintf("%s has no zones", server.Name))
+		case len(server.ForwardPlugin.Upstreams) == 0:
+			problems = append(problems, fmt.Sprintf("%s has no upstreams", server.Name))
+		case len(server.ForwardPlugin.Upstreams) == 1:
+			problems = append(problems, fmt.Sprintf("%s forwards to a single upstream", server.Name))
+		}
+		zones = append(zones, fmt.Sprintf("%s -> %s",
+			strings.Join(server.Zones, ", "), strings.Join(server.ForwardPlugin.Upstreams, ", ")))
+	}
+
+	result.Observation = fmt.Sprintf("Forwarding is configured for %s", strings.Join(zones, "; "))
+	if len(problems) > 0 {
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("%s. Issues: %s", result.Observation, strings.Join(problems, "; "))
+		result.Recommendation = "Give every forwarding server at least one zone and two upstream resolvers so name " +
+			"resolution survives the loss of a single DNS server."
+	}
+	return result
+}
+
+// upstreamResult reports how CoreDNS resolves names outside the cluster and the forwarded zones
+func upstreamResult(dns *operatorv1.DNS) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "DNS Upstream Resolvers",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	upstreams := dns.Spec.UpstreamResolvers.Upstreams
+	var addresses []string
+	for _, upstream := range upstreams {
+		if upstream.Type == operatorv1.NetworkResolverType {
+			addresses = append(addresses, fmt.Sprintf("%s:%d", upstream.Address, upstream.Port))
+		} else {
+			addresses = append(addresses, "node resolv.conf")
+		}
+	}
+
+	if len(addresses) == 0 {
+		result.Observation = "CoreDNS forwards external queries to the resolvers in each node's /etc/resolv.conf"
+		return result
+	}
+
+	result.Observation = fmt.Sprintf("CoreDNS forwards external queries to %s", strings.Join(addresses, ", "))
+	if len(upstreams) == 1 && upstreams[0].Type == operatorv1.NetworkResolverType {
+		result.Status = types.ResultKeyAdvisory
+		result.Recommendation = "Add a second upstream resolver or include the node resolv.conf so external name " +
+			"resolution doesn't depend on a single server."
+	}
+	return result
+}
@@ -0,0 +1,158 @@
+// app/server/scanner/etcd.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// etcdMembersAvailable is the condition of the etcd operator that counts the available members
+const etcdMembersAvailable = "EtcdMembersAvailable"
+
+// etcdQueries are the Prometheus queries of the etcd check
+var etcdQueries = []metricQuery{
+	{
+		item:     "etcd Database Size",
+		category: CategoryClusterConfig,
+		severe:   true,
+		query:    `max by (pod) (etcd_mvcc_db_total_size_in_bytes{job="etcd"} / etcd_server_quota_backend_bytes{job="etcd"})`,
+		labels:   []string{"pod"}, warn: 0.7, critical: 0.9, format: formatPercent,
+		recommendation: "Defragment the members one at a time as described in the OpenShift etcd documentation and " +
+			"find what fills the database, such as many events, secrets or custom resources; at the quota etcd " +
+			"raises a NOSPACE alarm and the cluster becomes read-only.",
+	},
+	{
+		item:     "etcd Disk Fsync Latency",
+		category: CategoryPerformance,
+		severe:   true,
+		query: `histogram_quantile(0.99, sum by (instance, le) (rate(` +
+			`etcd_disk_wal_fsync_duration_seconds_bucket{job="etcd"}[10m])))`,
+		labels: []string{"instance"}, warn: 0.01, critical: 0.02, format: formatSeconds,
+		recommendation: "etcd needs storage with a 99th percentile fsync below 10ms; move the control plane " +
+			"to faster disks, such as SSDs or premium volumes, and keep other I/O off them.",
+	},
+}
+
+// EtcdCheck verifies the etcd members are healthy, their database has room
+// and fast storage, and the API server encrypts the resources it stores
+type EtcdCheck struct{}
+
+// ID returns the check identifier
+func (c *EtcdCheck) ID() string {
+	return "etcd"
+}
+
+// Run inspects the etcd operator, the API server encryption and, when the
+// cluster has a Prometheus endpoint, the database size and fsync latency
+func (c *EtcdCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	etcd, err := clients.Operator.OperatorV1().Etcds().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting etcd operator status: %w", err)
+	}
+
+	encryption, err := c.encryptionResult(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []Result{membersResult(etcd)}
+	for _, query := range etcdQueries {
+		if clients.Prometheus == nil {
+			results = append(results, Result{
+				Category:    query.resultCategory(),
+				Item:        query.item,
+				Status:      types.ResultKeyNotApplicable,
+				Observation: "The cluster has no Prometheus endpoint configured, so etcd metrics can't be read",
+			})
+			continue
+		}
+		results = append(results, query.run(ctx, clients.Prometheus))
+	}
+	return append(results, encryption), nil
+}
+
+// membersResult reports the member health the etcd operator observes
+func membersResult(etcd *operatorv1.Etcd) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "etcd Member Health",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var degraded []string
+	available := "the etcd operator doesn't report member availability"
+	for _, condition := range etcd.Status.Conditions {
+		switch {
+		case condition.Type == etcdMembersAvailable && condition.Status == operatorv1.ConditionFalse:
+			result.Status = types.ResultKeyRequired
+			result.Observation = fmt.Sprintf("etcd quorum is at risk: %s", condition.Message)
+			result.Recommendation = "Restore the unavailable members following the OpenShift procedure for replacing " +
+				"an unhealthy etcd member, and take an etcd backup before changing the control plane."
+			return result
+		case condition.Type == etcdMembersAvailable:
+			available = condition.Message
+		case strings.HasSuffix(condition.Type, "Degraded") && condition.Status == operatorv1.ConditionTrue:
+			degraded = append(degraded, fmt.Sprintf("%s: %s", condition.Type, condition.Message))
+		}
+	}
+
+	if len(degraded) > 0 {
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("The etcd operator is degraded (%s)", strings.Join(degraded, "; "))
+		result.Recommendation = "Investigate with `oc describe etcd cluster` and the pods in openshift-etcd before the " +
+			"degradation costs a member."
+		return result
+	}
+
+	result.Observation = fmt.Sprintf("etcd members are healthy: %s", available)
+	return result
+}
+
+// encryptionResult reports whether the API server encrypts the resources it
+// stores in etcd and whether the encryption has finished
+func (c *EtcdCheck) encryptionResult(ctx context.Context, clients *live.Clients) (Result, error) {
+	result := Result{
+		Category: CategorySecurity,
+		Item:     "etcd Encryption at Rest",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	apiServer, err := clients.Config.ConfigV1().APIServers().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("error getting API server configuration: %w", err)
+	}
+
+	encryption := apiServer.Spec.Encryption.Type
+	if encryption == "" || encryption == configv1.EncryptionTypeIdentity {
+		result.Status = types.ResultKeyRecommended
+		result.Observation = "Secrets, config maps, routes and OAuth tokens are stored in etcd without encryption"
+		result.Recommendation = "Set spec.encryption.type of the cluster APIServer resource to aesgcm or aescbc, " +
+			"or KMS where supported, so etcd backups and disks don't expose secrets."
+		return result, nil
+	}
+
+	// The operator reports progress while it re-encrypts the stored resources
+	operator, err := clients.Operator.OperatorV1().KubeAPIServers().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("error getting kube-apiserver operator status: %w", err)
+	}
+	for _, condition := range operator.Status.Conditions {
+		if condition.Type == "Encrypted" && condition.Status != operatorv1.ConditionTrue {
+			result.Status = types.ResultKeyAdvisory
+			result.Observation = fmt.Sprintf("Encryption with %s is configured but not complete: %s", encryption, condition.Message)
+			result.Recommendation = "Follow the Encrypted condition of the kubeapiserver operator until every resource is re-encrypted."
+			return result, nil
+		}
+	}
+
+	result.Observation = fmt.Sprintf("The API server encrypts the resources it stores in etcd with %s", encryption)
+	return result, nil
+}
@@ -0,0 +1,260 @@
+// app/server/scanner/external.go
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+const (
+	// defaultExternalTimeout bounds an external check without its own timeout
+	defaultExternalTimeout = time.Minute
+
+	// externalWaitDelay is how long a timed out check's children may keep its
+	// output open before they are abandoned
+	externalWaitDelay = 5 * time.Second
+
+	// maxExternalStderr is how much of the error output of a failed external
+	// check is kept in its result
+	maxExternalStderr = 512
+
+	// maxExternalOutput bounds the results an external check prints; a check
+	// printing more fails
+	maxExternalOutput = 10 << 20
+)
+
+// resultCategories are the categories an external check may report under
+var resultCategories = []string{
+	CategoryClusterConfig,
+	CategorySecurity,
+	CategoryPerformance,
+	CategoryOpReady,
+	CategoryApplications,
+}
+
+// resultStatuses are the statuses an external check may report
+var resultStatuses = []types.ResultKey{
+	types.ResultKeyNoChange,
+	types.ResultKeyRecommended,
+	types.ResultKeyRequired,
+	types.ResultKeyAdvisory,
+	types.ResultKeyNotApplicable,
+	types.ResultKeyEvaluate,
+}
+
+// ExternalCheck runs an executable that inspects the cluster on its own, so
+// organizations can add checks without changing the dashboard. The executable
+// gets a kubeconfig of the scanned cluster in KUBECONFIG and its name in
+// CLUSTER_NAME, and prints {"results": [...]} to stdout, each result with the
+// category, item, status, observation and recommendation fields of a live
+// check. A non-zero exit fails the check.
+type ExternalCheck struct {
+	Name    string            `yaml:"id"`
+	Command string            `yaml:"command"`
+	Args    []string          `yaml:"args"`
+	Env     map[string]string `yaml:"env"`
+	Timeout time.Duration     `yaml:"timeout"`
+}
+
+// externalChecksFile is the YAML layout of the external checks file
+type externalChecksFile struct {
+	Checks []*ExternalCheck `yaml:"checks"`
+}
+
+// externalOutput is what an external check prints to stdout
+type externalOutput struct {
+	Results []Result `json:"results"`
+}
+
+// LoadExternalChecks reads the external checks declared in a YAML file; their
+// IDs must be unique and differ from those of the built-in checks
+func LoadExternalChecks(path string) ([]*ExternalCheck, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading external checks: %w", err)
+	}
+
+	var file externalChecksFile
+	if err := yaml.UnmarshalStrict(data, &file); err != nil {
+		return nil, fmt.Errorf("error parsing external checks in %s: %w", path, err)
+	}
+
+	ids := make(map[string]bool)
+	for _, check := range DefaultChecks() {
+		ids[check.ID()] = true
+	}
+	for _, check := range file.Checks {
+		switch {
+		case check.Name == "":
+			return nil, fmt.Errorf("external check without an id in %s", path)
+		case ids[check.Name]:
+			return nil, fmt.Errorf("duplicate check id %s in %s", check.Name, path)
+		case check.Command == "":
+			return nil, fmt.Errorf("external check %s has no command", check.Name)
+		case check.Timeout < 0:
+			return nil, fmt.Errorf("external check %s has a negative timeout", check.Name)
+		}
+		ids[check.Name] = true
+	}
+	return file.Checks, nil
+}
+
+// ID returns the check identifier
+func (c *ExternalCheck) ID() string {
+	return c.Name
+}
+
+// Run executes the command with credentials for the cluster and parses its results
+func (c *ExternalCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	if clients.RestConfig == nil {
+		return nil, errors.New("no credentials to pass to the external check")
+	}
+
+	dir, err := os.MkdirTemp("", "external-check-")
+	if err != nil {
+		return nil, fmt.Errorf("error creating kubeconfig directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	kubeconfig := filepath.Join(dir, "kubeconfig")
+	if err := clientcmd.WriteToFile(kubeconfigFor(clients.RestConfig), kubeconfig); err != nil {
+		return nil, fmt.Errorf("error writing kubeconfig: %w", err)
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultExternalTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// The command gets a minimal environment, not the secrets of the server
+	cmd := exec.CommandContext(ctx, c.Command, c.Args...)
+	cmd.Dir = dir
+	cmd.WaitDelay = externalWaitDelay
+	cmd.Env = []string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + dir,
+		"KUBECONFIG=" + kubeconfig,
+		"CLUSTER_NAME=" + clients.ClusterName,
+	}
+	for name, value := range c.Env {
+		cmd.Env = append(cmd.Env, name+"="+value)
+	}
+
+	var stderr stderrTail
+	cmd.Stderr = &stderr
+	pipe, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, fmt.Errorf("external check failed: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("external check failed: %w", err)
+	}
+
+	// Read one byte past the limit to detect oversized output, then stop the
+	// command instead of waiting for it to finish printing
+	stdout, readErr := io.ReadAll(io.LimitReader(pipe, maxExternalOutput+1))
+	tooLarge := len(stdout) > maxExternalOutput
+	if tooLarge {
+		cancel()
+	}
+	err = cmd.Wait()
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		return nil, fmt.Errorf("external check timed out after %s", timeout)
+	case tooLarge:
+		return nil, fmt.Errorf("external check printed more than %d bytes", maxExternalOutput)
+	case err != nil:
+		if message := strings.TrimSpace(string(stderr.data)); message != "" {
+			return nil, fmt.Errorf("external check failed: %w: %s", err, message)
+		}
+		return nil, fmt.Errorf("external check failed: %w", err)
+	case readErr != nil:
+		return nil, fmt.Errorf("error reading external check output: %w", readErr)
+	}
+
+	var output externalOutput
+	if err := json.Unmarshal(stdout, &output); err != nil {
+		return nil, fmt.Errorf("error parsing external check output: %w", err)
+	}
+	for i, result := range output.Results {
+		if err := validateResult(result); err != nil {
+			return nil, fmt.Errorf("invalid result %d of external check: %w", i+1, err)
+		}
+	}
+	return output.Results, nil
+}
+
+// stderrTail keeps the end of the error output of an external check, where
+// the reason it failed usually is
+type stderrTail struct {
+	data []byte
+}
+
+// Write appends to the output and drops what precedes the last maxExternalStderr bytes
+func (t *stderrTail) Write(p []byte) (int, error) {
+	t.data = append(t.data, p...)
+	if len(t.data) > maxExternalStderr {
+		t.data = t.data[len(t.data)-maxExternalStderr:]
+	}
+	return len(p), nil
+}
+
+// validateResult makes sure a result of an external check scores like one of
+// a built-in check
+func validateResult(result Result) error {
+	switch {
+	case strings.TrimSpace(result.Item) == "":
+		return errors.New("missing item")
+	case !slices.Contains(resultCategories, result.Category):
+		return fmt.Errorf("unknown category %q", result.Category)
+	case !slices.Contains(resultStatuses, result.Status):
+		return fmt.Errorf("unknown status %q", result.Status)
+	}
+	return nil
+}
+
+// kubeconfigFor converts the REST configuration of a cluster into a
+// kubeconfig an external command can use
+func kubeconfigFor(config *rest.Config) clientcmdapi.Config {
+	kubeconfig := clientcmdapi.NewConfig()
+	kubeconfig.Clusters["cluster"] = &clientcmdapi.Cluster{
+		Server:                   config.Host,
+		CertificateAuthority:     config.CAFile,
+		CertificateAuthorityData: config.CAData,
+		InsecureSkipTLSVerify:    config.Insecure,
+		TLSServerName:            config.ServerName,
+	}
+	kubeconfig.AuthInfos["user"] = &clientcmdapi.AuthInfo{
+		Token:                 config.BearerToken,
+		TokenFile:             config.BearerTokenFile,
+		ClientCertificate:     config.CertFile,
+		ClientCertificateData: config.CertData,
+		ClientKey:             config.KeyFile,
+		ClientKeyData:         config.KeyData,
+		Username:              config.Username,
+		Password:              config.Password,
+		Exec:                  config.ExecProvider,
+	}
+	kubeconfig.Contexts["cluster"] = &clientcmdapi.Context{Cluster: "cluster", AuthInfo: "user"}
+	kubeconfig.CurrentContext = "cluster"
+	return *kubeconfig
+}
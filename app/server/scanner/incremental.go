@@ -0,0 +1,174 @@
+// app/server/scanner/incremental.go
+package scanner
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+const (
+	// incrementalMaxAge is how long cached results are reused; older ones are
+	// recomputed even if their inputs look unchanged
+	incrementalMaxAge = 24 * time.Hour
+
+	// fingerprintPageSize is how many objects a metadata list request returns at once
+	fingerprintPageSize = 500
+)
+
+// Resources read by several incremental checks
+var (
+	podResource                = corev1.SchemeGroupVersion.WithResource("pods")
+	nodeResource               = corev1.SchemeGroupVersion.WithResource("nodes")
+	namespaceResource          = corev1.SchemeGroupVersion.WithResource("namespaces")
+	secretResource             = corev1.SchemeGroupVersion.WithResource("secrets")
+	configMapResource          = corev1.SchemeGroupVersion.WithResource("configmaps")
+	deploymentResource         = appsv1.SchemeGroupVersion.WithResource("deployments")
+	statefulSetResource        = appsv1.SchemeGroupVersion.WithResource("statefulsets")
+	daemonSetResource          = appsv1.SchemeGroupVersion.WithResource("daemonsets")
+	clusterRoleBindingResource = rbacv1.SchemeGroupVersion.WithResource("clusterrolebindings")
+	clusterOperatorResource    = configv1.GroupVersion.WithResource("clusteroperators")
+	clusterVersionResource     = configv1.GroupVersion.WithResource("clusterversions")
+	infrastructureResource     = configv1.GroupVersion.WithResource("infrastructures")
+	oauthResource              = configv1.GroupVersion.WithResource("oauths")
+)
+
+// Input is a resource a check reads, in one namespace or in all of them
+type Input struct {
+	Resource  schema.GroupVersionResource
+	Namespace string
+}
+
+// IncrementalCheck is a check whose results depend only on the resources it
+// reads, so an incremental scan can reuse them while those are unchanged.
+// Checks that query Prometheus, compare against the current time or run
+// external commands must not implement it.
+type IncrementalCheck interface {
+	Check
+
+	// Inputs returns the resources the check reads
+	Inputs() []Input
+}
+
+// cachedResults are the results of a check with the fingerprint of its inputs
+type cachedResults struct {
+	fingerprint string
+	results     []Result
+	storedAt    time.Time
+}
+
+// resultCache keeps the latest results of the incremental checks of each cluster
+type resultCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResults
+}
+
+// newResultCache creates an empty result cache
+func newResultCache() *resultCache {
+	return &resultCache{entries: make(map[string]cachedResults)}
+}
+
+// get returns the cached results of a check if they were computed from inputs
+// with the same fingerprint and are recent enough
+func (c *resultCache) get(cluster, check, fingerprint string) ([]Result, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[cluster+"/"+check]
+	if !ok || entry.fingerprint != fingerprint || time.Since(entry.storedAt) > incrementalMaxAge {
+		return nil, false
+	}
+	return entry.results, true
+}
+
+// put stores the results of a check
+func (c *resultCache) put(cluster, check, fingerprint string, results []Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[cluster+"/"+check] = cachedResults{fingerprint: fingerprint, results: results, storedAt: time.Now()}
+}
+
+// fingerprinter hashes the names and resource versions of the inputs of
+// checks, listing only object metadata. It remembers each input for the rest
+// of the scan, so checks sharing inputs, like pods, list them once; checks
+// running at the same time take turns. The scope of the scan is part of every
+// fingerprint, so scoped and unscoped scans never share results.
+type fingerprinter struct {
+	clients *live.Clients
+	scope   types.ScanScope
+
+	mu     sync.Mutex
+	inputs map[Input]string
+}
+
+// newFingerprinter creates a fingerprinter for one scan of a cluster
+func newFingerprinter(clients *live.Clients, scope types.ScanScope) *fingerprinter {
+	return &fingerprinter{clients: clients, scope: scope, inputs: make(map[Input]string)}
+}
+
+// of returns the fingerprint of a set of inputs
+func (f *fingerprinter) of(ctx context.Context, inputs []Input) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	hash := sha256.New()
+	if !f.scope.Empty() {
+		fmt.Fprintf(hash, "scope|%q|%q|%q\n", f.scope.IncludeNamespaces, f.scope.ExcludeNamespaces, f.scope.NamespaceSelector)
+	}
+	// Which namespaces a selector matches changes with their labels
+	if f.scope.NamespaceSelector != "" {
+		inputs = append(slices.Clone(inputs), Input{Resource: namespaceResource})
+	}
+	for _, input := range inputs {
+		fingerprint, ok := f.inputs[input]
+		if !ok {
+			var err error
+			if fingerprint, err = f.list(ctx, input); err != nil {
+				return "", err
+			}
+			f.inputs[input] = fingerprint
+		}
+		fmt.Fprintf(hash, "%s|%s|%s\n", input.Resource, input.Namespace, fingerprint)
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// list hashes the names and resource versions of the objects of one input; a
+// resource whose operator isn't installed has a fingerprint of its own
+func (f *fingerprinter) list(ctx context.Context, input Input) (string, error) {
+	hash := sha256.New()
+	options := metav1.ListOptions{Limit: fingerprintPageSize}
+	for {
+		list, err := f.clients.Metadata.Resource(input.Resource).Namespace(input.Namespace).List(ctx, options)
+		if apierrors.IsNotFound(err) {
+			return "absent", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("error listing %s metadata: %w", input.Resource.GroupResource(), err)
+		}
+		for _, item := range list.Items {
+			fmt.Fprintf(hash, "%s/%s@%s\n", item.Namespace, item.Name, item.ResourceVersion)
+		}
+		if list.Continue == "" {
+			break
+		}
+		options.Continue = list.Continue
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,329 @@
+// app/server/scanner/ingress.go
+package scanner
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// routerPodLabel selects the router pods of an IngressController by its name
+const routerPodLabel = "ingresscontroller.operator.openshift.io/deployment-ingresscontroller"
+
+// routes are the OpenShift routes served by the ingress controllers
+var routes = schema.GroupVersionResource{Group: "route.openshift.io", Version: "v1", Resource: "routes"}
+
+// routerSaturationQuery compares the CPU the routers use to the four HAProxy
+// threads they run by default; a router near it queues connections
+var routerSaturationQuery = metricQuery{
+	item:     "Router Saturation",
+	category: CategoryClusterConfig,
+	query: `sum by (pod) (rate(container_cpu_usage_seconds_total{namespace="openshift-ingress",` +
+		`container="router"}[10m])) / 4`,
+	labels: []string{"pod"}, warn: 0.7, critical: 0.9, format: formatPercent,
+	recommendation: "Scale the IngressController out with more replicas, or raise tuningOptions.threadCount on " +
+		"nodes with spare CPU, and dedicate infra nodes to the routers.",
+}
+
+// IngressCheck verifies the ingress controllers are redundant, serve a valid
+// default certificate and a modern TLS profile, routes don't fall back to
+// plain HTTP and the routers have headroom
+type IngressCheck struct{}
+
+// ID returns the check identifier
+func (c *IngressCheck) ID() string {
+	return "ingress"
+}
+
+// Run inspects the IngressControllers, their router pods and certificates, the
+// routes of user namespaces and, with a Prometheus endpoint, router CPU usage
+func (c *IngressCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	controllers, err := clients.Operator.OperatorV1().IngressControllers(ingressOperatorNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing ingress controllers: %w", err)
+	}
+	pods, err := clients.Kube.CoreV1().Pods(ingressNamespace).List(ctx, metav1.ListOptions{LabelSelector: routerPodLabel})
+	if err != nil {
+		return nil, fmt.Errorf("error listing router pods: %w", err)
+	}
+
+	// Single node clusters run one router by design
+	singleReplica := false
+	infra, err := clients.Config.ConfigV1().Infrastructures().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error getting infrastructure: %w", err)
+	}
+	if err == nil {
+		singleReplica = infra.Status.InfrastructureTopology == configv1.SingleReplicaTopologyMode
+	}
+
+	certificate, err := c.defaultCertificateResult(ctx, clients, controllers.Items)
+	if err != nil {
+		return nil, err
+	}
+	termination, err := c.routeTerminationResult(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	saturation := Result{
+		Category:    routerSaturationQuery.resultCategory(),
+		Item:        routerSaturationQuery.item,
+		Status:      types.ResultKeyNotApplicable,
+		Observation: "The cluster has no Prometheus endpoint configured, so router usage can't be read",
+	}
+	if clients.Prometheus != nil {
+		saturation = routerSaturationQuery.run(ctx, clients.Prometheus)
+	}
+
+	return []Result{
+		replicaResult(controllers.Items, pods.Items, singleReplica),
+		certificate,
+		tlsProfileResult(controllers.Items),
+		termination,
+		saturation,
+	}, nil
+}
+
+// replicaResult reports ingress controllers that are short of available
+// replicas, have a single one, or run their routers on too few nodes
+func replicaResult(controllers []operatorv1.IngressController, pods []corev1.Pod, singleReplica bool) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Ingress Controller Replicas",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	nodes := make(map[string]map[string]bool)
+	for _, pod := range pods {
+		name := pod.Labels[routerPodLabel]
+		if nodes[name] == nil {
+			nodes[name] = make(map[string]bool)
+		}
+		if pod.Spec.NodeName != "" && pod.Status.Phase == corev1.PodRunning {
+			nodes[name][pod.Spec.NodeName] = true
+		}
+	}
+
+	var unavailable, single, colocated, healthy []string
+	for _, controller := range controllers {
+		desired := int32(2)
+		if controller.Spec.Replicas != nil {
+			desired = *controller.Spec.Replicas
+		}
+		available := controller.Status.AvailableReplicas
+		switch {
+		case available < desired:
+			unavailable = append(unavailable, fmt.Sprintf("%s (%d of %d available)", controller.Name, available, desired))
+		case desired < 2 && !singleReplica:
+			single = append(single, controller.Name)
+		case len(nodes[controller.Name]) < int(available):
+			colocated = append(colocated, fmt.Sprintf("%s (%d replicas on %d nodes)", controller.Name, available, len(nodes[controller.Name])))
+		default:
+			healthy = append(healthy, fmt.Sprintf("%s (%d replicas)", controller.Name, available))
+		}
+	}
+	sort.Strings(unavailable)
+	sort.Strings(single)
+	sort.Strings(colocated)
+
+	switch {
+	case len(controllers) == 0:
+		result.Status = types.ResultKeyRequired
+		result.Observation = "No IngressController exists, so routes are not served"
+		result.Recommendation = "Restore the default IngressController in " + ingressOperatorNamespace + "."
+	case len(unavailable) > 0:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("Ingress controllers are missing replicas: %s", strings.Join(unavailable, ", "))
+		result.Recommendation = "Check why the router pods don't start with `oc get pods -n openshift-ingress` and " +
+			"that the nodeSelector of the IngressController matches enough nodes."
+	case len(single) > 0 || len(colocated) > 0:
+		result.Status = types.ResultKeyRecommended
+		var problems []string
+		if len(single) > 0 {
+			problems = append(problems, "a single replica: "+strings.Join(single, ", "))
+		}
+		if len(colocated) > 0 {
+			problems = append(problems, "replicas sharing nodes: "+strings.Join(colocated, ", "))
+		}
+		result.Observation = fmt.Sprintf("Ingress controllers have %s", strings.Join(problems, "; "))
+		result.Recommendation = "Run at least two router replicas on separate nodes, for example three infra nodes, " +
+			"so a node failure or drain doesn't interrupt ingress traffic."
+	default:
+		result.Observation = fmt.Sprintf("All ingress controllers have their replicas available on separate nodes: %s", strings.Join(healthy, ", "))
+	}
+	return result
+}
+
+// defaultCertificateResult checks the default certificates of the ingress
+// controllers are custom, current and valid for their wildcard domain
+func (c *IngressCheck) defaultCertificateResult(ctx context.Context, clients *live.Clients, controllers []operatorv1.IngressController) (Result, error) {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Ingress Default Certificate",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	now := time.Now()
+	var invalid, generated, valid []string
+	for _, controller := range controllers {
+		if controller.Spec.DefaultCertificate == nil {
+			generated = append(generated, controller.Name)
+			continue
+		}
+
+		name := controller.Spec.DefaultCertificate.Name
+		secret, err := clients.Kube.CoreV1().Secrets(ingressNamespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			invalid = append(invalid, fmt.Sprintf("%s (secret %s is missing)", controller.Name, name))
+			continue
+		}
+		if err != nil {
+			return Result{}, fmt.Errorf("error getting secret %s/%s: %w", ingressNamespace, name, err)
+		}
+
+		leaf := leafCertificate(secret.Data[corev1.TLSCertKey])
+		host := "check." + controller.Status.Domain
+		switch {
+		case leaf == nil:
+			invalid = append(invalid, fmt.Sprintf("%s (secret %s holds no certificate)", controller.Name, name))
+		case !leaf.NotAfter.After(now):
+			invalid = append(invalid, fmt.Sprintf("%s (expired on %s)", controller.Name, leaf.NotAfter.Format("2006-01-02")))
+		case controller.Status.Domain != "" && leaf.VerifyHostname(host) != nil:
+			invalid = append(invalid, fmt.Sprintf("%s (not valid for *.%s)", controller.Name, controller.Status.Domain))
+		default:
+			valid = append(valid, controller.Name)
+		}
+	}
+
+	switch {
+	case len(invalid) > 0:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("Ingress controllers serve an invalid default certificate: %s", strings.Join(invalid, "; "))
+		result.Recommendation = "Replace the secret referenced by spec.defaultCertificate with a current certificate " +
+			"for the wildcard domain of the IngressController."
+	case len(generated) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("Ingress controllers serve the self-signed certificate generated by the operator: %s", strings.Join(generated, ", "))
+		result.Recommendation = "Configure a certificate signed by a trusted CA as spec.defaultCertificate, so users " +
+			"and clients don't need to trust the ingress CA."
+	case len(valid) > 0:
+		result.Observation = fmt.Sprintf("Ingress controllers serve a valid custom certificate: %s", strings.Join(valid, ", "))
+	default:
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "No IngressController exists"
+	}
+	return result, nil
+}
+
+// leafCertificate parses the first certificate of a PEM bundle
+func leafCertificate(data []byte) *x509.Certificate {
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			return nil
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		if leaf, err := x509.ParseCertificate(block.Bytes); err == nil {
+			return leaf
+		}
+	}
+}
+
+// tlsProfileResult reports ingress controllers that accept TLS versions older than 1.2
+func tlsProfileResult(controllers []operatorv1.IngressController) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Ingress TLS Profile",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var weak []string
+	for _, controller := range controllers {
+		profile := controller.Status.TLSProfile
+		if profile == nil {
+			continue
+		}
+		if profile.MinTLSVersion == configv1.VersionTLS10 || profile.MinTLSVersion == configv1.VersionTLS11 {
+			weak = append(weak, fmt.Sprintf("%s (%s)", controller.Name, profile.MinTLSVersion))
+		}
+	}
+	sort.Strings(weak)
+
+	if len(weak) == 0 {
+		result.Observation = "All ingress controllers require TLS 1.2 or newer"
+		return result
+	}
+
+	result.Status = types.ResultKeyRecommended
+	result.Observation = fmt.Sprintf("Ingress controllers accept deprecated TLS versions: %s", strings.Join(weak, ", "))
+	result.Recommendation = "Set spec.tlsSecurityProfile of the IngressControllers to the Intermediate or Modern " +
+		"profile once the clients support TLS 1.2."
+	return result
+}
+
+// routeTerminationResult reports routes of user namespaces served over plain
+// HTTP or that allow HTTP next to their TLS termination
+func (c *IngressCheck) routeTerminationResult(ctx context.Context, clients *live.Clients) (Result, error) {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Route TLS Termination",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	list, err := clients.Dynamic.Resource(routes).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("error listing routes: %w", err)
+	}
+
+	scope := scopeFrom(ctx)
+	plain, allowed := newNamespaceCounts(), newNamespaceCounts()
+	var total int
+	for _, route := range list.Items {
+		if !scope.covers(route.GetNamespace()) {
+			continue
+		}
+		total++
+		termination, found, _ := unstructured.NestedString(route.Object, "spec", "tls", "termination")
+		insecure, _, _ := unstructured.NestedString(route.Object, "spec", "tls", "insecureEdgeTerminationPolicy")
+		switch {
+		case !found || termination == "":
+			plain.add(route.GetNamespace())
+		case insecure == "Allow":
+			allowed.add(route.GetNamespace())
+		}
+	}
+
+	switch {
+	case len(plain) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("Routes in user namespaces serve plain HTTP without TLS (%s)", plain.details("routes"))
+		result.Recommendation = "Add edge, reencrypt or passthrough TLS termination to the routes; plain HTTP exposes " +
+			"credentials and session cookies."
+	case len(allowed) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("Routes in user namespaces allow plain HTTP next to TLS (%s)", allowed.details("routes"))
+		result.Recommendation = "Set insecureEdgeTerminationPolicy of the routes to Redirect so clients always use TLS."
+	default:
+		result.Observation = fmt.Sprintf("All %d routes in user namespaces require TLS", total)
+	}
+	return result, nil
+}
@@ -0,0 +1,254 @@
+// app/server/scanner/logging.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+const (
+	// collectorSelector selects the log collector daemon sets of both logging generations
+	collectorSelector = "app.kubernetes.io/component=collector"
+
+	// minLogRetentionDays is the shortest log retention that still covers a
+	// long weekend of incidents
+	minLogRetentionDays = 7
+)
+
+var (
+	// logForwarders are the ClusterLogForwarders of Logging 6 and of Logging 5
+	logForwarders = []schema.GroupVersionResource{
+		{Group: "observability.openshift.io", Version: "v1", Resource: "clusterlogforwarders"},
+		{Group: "logging.openshift.io", Version: "v1", Resource: "clusterlogforwarders"},
+	}
+
+	// clusterLoggings are the ClusterLogging instances of Logging 5
+	clusterLoggings = schema.GroupVersionResource{Group: "logging.openshift.io", Version: "v1", Resource: "clusterloggings"}
+
+	// lokiStacks are the log stores of the Loki operator
+	lokiStacks = schema.GroupVersionResource{Group: "loki.grafana.com", Version: "v1", Resource: "lokistacks"}
+
+	// internalOutputs are the output types that keep logs in the cluster
+	internalOutputs = map[string]bool{
+		"lokiStack": true,
+		"default":   true,
+	}
+)
+
+// LoggingCheck verifies the cluster collects its logs, forwards them to a
+// system outside the cluster, keeps them long enough and that the collectors run
+type LoggingCheck struct{}
+
+// ID returns the check identifier
+func (c *LoggingCheck) ID() string {
+	return "logging"
+}
+
+// Inputs returns the resources the check reads
+func (c *LoggingCheck) Inputs() []Input {
+	return []Input{
+		{Resource: logForwarders[0]},
+		{Resource: logForwarders[1]},
+		{Resource: clusterLoggings},
+		{Resource: lokiStacks},
+		{Resource: daemonSetResource},
+	}
+}
+
+// Run inspects the log forwarders, the Loki stores and the collector daemon sets
+func (c *LoggingCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	var forwarders []unstructured.Unstructured
+	for _, resource := range logForwarders {
+		found, err := listOptional(ctx, clients, resource)
+		if err != nil {
+			return nil, err
+		}
+		forwarders = append(forwarders, found...)
+	}
+	legacy, err := listOptional(ctx, clients, clusterLoggings)
+	if err != nil {
+		return nil, err
+	}
+	stores, err := listOptional(ctx, clients, lokiStacks)
+	if err != nil {
+		return nil, err
+	}
+	collectors, err := clients.Kube.AppsV1().DaemonSets(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: collectorSelector})
+	if err != nil {
+		return nil, fmt.Errorf("error listing log collectors: %w", err)
+	}
+
+	installation := Result{
+		Category: CategoryOpReady,
+		Item:     "Logging Installation",
+		Status:   types.ResultKeyNoChange,
+	}
+	if len(forwarders) == 0 && len(legacy) == 0 {
+		installation.Status = types.ResultKeyRequired
+		installation.Observation = "Cluster logging is not installed, so container, node and audit logs are only kept on the nodes"
+		installation.Recommendation = "Install the Red Hat OpenShift Logging operator with a ClusterLogForwarder, and the " +
+			"Loki operator or an external log system to store the logs."
+		return []Result{installation}, nil
+	}
+	installation.Observation = fmt.Sprintf("Cluster logging is installed with %d log forwarders and %d LokiStacks", len(forwarders), len(stores))
+
+	return []Result{
+		installation,
+		logForwardingResult(forwarders),
+		logRetentionResult(stores),
+		collectorResult(collectors.Items),
+	}, nil
+}
+
+// listOptional lists a custom resource in all namespaces; a resource whose
+// operator isn't installed has no instances
+func listOptional(ctx context.Context, clients *live.Clients, resource schema.GroupVersionResource) ([]unstructured.Unstructured, error) {
+	list, err := clients.Dynamic.Resource(resource).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error listing %s: %w", resource.GroupResource(), err)
+	}
+	return list.Items, nil
+}
+
+// logForwardingResult reports whether any pipeline sends logs to a system
+// outside the cluster, which keeps them when the cluster is lost
+func logForwardingResult(forwarders []unstructured.Unstructured) Result {
+	result := Result{
+		Category: CategoryOpReady,
+		Item:     "Log Forwarding",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var external []string
+	for _, forwarder := range forwarders {
+		outputs, _, _ := unstructured.NestedSlice(forwarder.Object, "spec", "outputs")
+		for _, output := range outputs {
+			fields, ok := output.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _, _ := unstructured.NestedString(fields, "name")
+			kind, _, _ := unstructured.NestedString(fields, "type")
+			url, _, _ := unstructured.NestedString(fields, "url")
+			if internalOutputs[kind] || strings.Contains(url, ".svc") {
+				continue
+			}
+			external = append(external, fmt.Sprintf("%s (%s)", name, kind))
+		}
+	}
+	sort.Strings(external)
+
+	if len(external) == 0 {
+		result.Status = types.ResultKeyRecommended
+		result.Observation = "Logs are only stored in the cluster; no output forwards them to an external system"
+		result.Recommendation = "Add an output to the ClusterLogForwarder for the central log system, such as Splunk, " +
+			"Elasticsearch, Kafka or syslog, at least for the audit and infrastructure logs."
+		return result
+	}
+
+	result.Observation = fmt.Sprintf("Logs are forwarded to %s", strings.Join(external, ", "))
+	return result
+}
+
+// logRetentionResult reports LokiStacks without a global retention or with one
+// too short to investigate incidents
+func logRetentionResult(stores []unstructured.Unstructured) Result {
+	result := Result{
+		Category: CategoryOpReady,
+		Item:     "Log Retention",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if len(stores) == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "No LokiStack stores logs in the cluster"
+		return result
+	}
+
+	var unlimited, short, configured []string
+	for _, store := range stores {
+		name := store.GetNamespace() + "/" + store.GetName()
+		days, found, _ := unstructured.NestedInt64(store.Object, "spec", "limits", "global", "retention", "days")
+		switch {
+		case !found:
+			unlimited = append(unlimited, name)
+		case days < minLogRetentionDays:
+			short = append(short, fmt.Sprintf("%s (%d days)", name, days))
+		default:
+			configured = append(configured, fmt.Sprintf("%s (%d days)", name, days))
+		}
+	}
+	sort.Strings(unlimited)
+	sort.Strings(short)
+	sort.Strings(configured)
+
+	switch {
+	case len(unlimited) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("LokiStacks have no retention and grow until their object storage fills: %s", strings.Join(unlimited, ", "))
+		result.Recommendation = "Set spec.limits.global.retention.days, and per-tenant retention for audit logs where required."
+	case len(short) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("LokiStacks keep logs for less than %d days: %s", minLogRetentionDays, strings.Join(short, ", "))
+		result.Recommendation = fmt.Sprintf("Raise spec.limits.global.retention.days to at least %d, or forward the logs to "+
+			"an external system that keeps them longer.", minLogRetentionDays)
+	default:
+		result.Observation = fmt.Sprintf("LokiStacks keep logs for %s", strings.Join(configured, ", "))
+	}
+	return result
+}
+
+// collectorResult reports collector daemon sets that don't run on every node
+func collectorResult(collectors []appsv1.DaemonSet) Result {
+	result := Result{
+		Category: CategoryOpReady,
+		Item:     "Log Collector Health",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if len(collectors) == 0 {
+		result.Status = types.ResultKeyRequired
+		result.Observation = "No log collector runs, so logs are not collected despite the logging configuration"
+		result.Recommendation = "Check the status conditions of the ClusterLogForwarder with `oc describe`; an invalid " +
+			"forwarder or missing service account permissions keep the operator from deploying the collectors."
+		return result
+	}
+
+	var unhealthy, healthy []string
+	for _, collector := range collectors {
+		name := collector.Namespace + "/" + collector.Name
+		status := collector.Status
+		if status.NumberReady < status.DesiredNumberScheduled {
+			unhealthy = append(unhealthy, fmt.Sprintf("%s (%d of %d ready)", name, status.NumberReady, status.DesiredNumberScheduled))
+		} else {
+			healthy = append(healthy, fmt.Sprintf("%s (%d pods)", name, status.NumberReady))
+		}
+	}
+	sort.Strings(unhealthy)
+	sort.Strings(healthy)
+
+	if len(unhealthy) > 0 {
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("Log collectors are not ready on every node, so their logs are lost: %s", strings.Join(unhealthy, ", "))
+		result.Recommendation = "Check the failing collector pods and their logs; tolerations missing for tainted nodes " +
+			"and unreachable outputs are the usual causes."
+		return result
+	}
+
+	result.Observation = fmt.Sprintf("Log collectors are ready on every node: %s", strings.Join(healthy, ", "))
+	return result
+}
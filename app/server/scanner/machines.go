@@ -0,0 +1,329 @@
+// app/server/scanner/machines.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// zoneLabel holds the failure domain of a node
+const zoneLabel = "topology.kubernetes.io/zone"
+
+// controlPlaneRoles mark the control plane nodes, under their current and legacy name
+var controlPlaneRoles = []string{
+	"node-role.kubernetes.io/control-plane",
+	"node-role.kubernetes.io/master",
+}
+
+var (
+	// machineSets are the groups of worker machines of the Machine API
+	machineSets = schema.GroupVersionResource{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machinesets"}
+
+	// machineHealthChecks replace the machines whose nodes stay unhealthy
+	machineHealthChecks = schema.GroupVersionResource{Group: "machine.openshift.io", Version: "v1beta1", Resource: "machinehealthchecks"}
+
+	// clusterAutoscalers enable autoscaling of the cluster; only "default" is honored
+	clusterAutoscalers = schema.GroupVersionResource{Group: "autoscaling.openshift.io", Version: "v1", Resource: "clusterautoscalers"}
+
+	// machineAutoscalers set the replica bounds of a machine set
+	machineAutoscalers = schema.GroupVersionResource{Group: "autoscaling.openshift.io", Version: "v1beta1", Resource: "machineautoscalers"}
+)
+
+// MachineCheck verifies unhealthy machines are replaced, autoscaling is
+// bounded and the control plane survives the loss of a failure domain
+type MachineCheck struct{}
+
+// ID returns the check identifier
+func (c *MachineCheck) ID() string {
+	return "machines"
+}
+
+// Inputs returns the resources the check reads
+func (c *MachineCheck) Inputs() []Input {
+	return []Input{
+		{Resource: machineSets},
+		{Resource: machineHealthChecks},
+		{Resource: clusterAutoscalers},
+		{Resource: machineAutoscalers},
+		{Resource: nodeResource},
+		{Resource: infrastructureResource},
+	}
+}
+
+// Run inspects the machine sets, their health checks and autoscalers, and the
+// zones of the control plane nodes
+func (c *MachineCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	sets, err := listOptional(ctx, clients, machineSets)
+	if err != nil {
+		return nil, err
+	}
+	healthChecks, err := listOptional(ctx, clients, machineHealthChecks)
+	if err != nil {
+		return nil, err
+	}
+	autoscalers, err := listOptional(ctx, clients, clusterAutoscalers)
+	if err != nil {
+		return nil, err
+	}
+	bounds, err := listOptional(ctx, clients, machineAutoscalers)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := clients.Kube.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	// Single node clusters have one failure domain by design
+	singleReplica := false
+	infra, err := clients.Config.ConfigV1().Infrastructures().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("error getting infrastructure: %w", err)
+	}
+	if err == nil {
+		singleReplica = infra.Status.ControlPlaneTopology == configv1.SingleReplicaTopologyMode
+	}
+
+	return []Result{
+		machineHealthCheckResult(sets, healthChecks),
+		autoscalerResult(sets, autoscalers, bounds),
+		failureDomainResult(nodes.Items, singleReplica),
+	}, nil
+}
+
+// machineHealthCheckResult reports machine sets whose machines no
+// MachineHealthCheck selects, so failed nodes stay until someone replaces them
+func machineHealthCheckResult(sets, healthChecks []unstructured.Unstructured) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Machine Health Checks",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if len(sets) == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "The cluster has no machine sets, so its machines are not managed by the Machine API"
+		return result
+	}
+
+	var selectors []labels.Selector
+	for _, healthCheck := range healthChecks {
+		if selector, ok := specSelector(healthCheck); ok {
+			selectors = append(selectors, selector)
+		}
+	}
+
+	var uncovered []string
+	for _, set := range sets {
+		machineLabels, _, _ := unstructured.NestedStringMap(set.Object, "spec", "template", "metadata", "labels")
+		covered := false
+		for _, selector := range selectors {
+			if selector.Matches(labels.Set(machineLabels)) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			uncovered = append(uncovered, set.GetName())
+		}
+	}
+	sort.Strings(uncovered)
+
+	switch {
+	case len(healthChecks) == 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("No MachineHealthCheck exists for the %d machine sets, so failed nodes are never replaced automatically", len(sets))
+		result.Recommendation = "Create a MachineHealthCheck in openshift-machine-api selecting the worker machines, " +
+			"with a maxUnhealthy limit so a zone outage doesn't trigger mass remediation."
+	case len(uncovered) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("No MachineHealthCheck selects the machines of %s", strings.Join(uncovered, ", "))
+		result.Recommendation = "Extend the selector of a MachineHealthCheck, or add one, to cover the machine sets."
+	default:
+		result.Observation = fmt.Sprintf("%d MachineHealthChecks cover all %d machine sets", len(healthChecks), len(sets))
+	}
+	return result
+}
+
+// specSelector reads spec.selector of a resource; an empty selector matches everything
+func specSelector(object unstructured.Unstructured) (labels.Selector, bool) {
+	fields, _, _ := unstructured.NestedMap(object.Object, "spec", "selector")
+	var selector metav1.LabelSelector
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(fields, &selector); err != nil {
+		return nil, false
+	}
+	parsed, err := metav1.LabelSelectorAsSelector(&selector)
+	if err != nil {
+		return nil, false
+	}
+	return parsed, true
+}
+
+// autoscalerResult reports machine autoscalers without a ClusterAutoscaler to
+// act on them, autoscalers of missing machine sets and an unbounded cluster size
+func autoscalerResult(sets, autoscalers, bounds []unstructured.Unstructured) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Cluster Autoscaler",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	existing := make(map[string]bool, len(sets))
+	for _, set := range sets {
+		existing[set.GetName()] = true
+	}
+
+	var clusterAutoscaler *unstructured.Unstructured
+	for i := range autoscalers {
+		if autoscalers[i].GetName() == "default" {
+			clusterAutoscaler = &autoscalers[i]
+		}
+	}
+
+	var orphaned, inverted []string
+	for _, bound := range bounds {
+		name := bound.GetName()
+		target, _, _ := unstructured.NestedString(bound.Object, "spec", "scaleTargetRef", "name")
+		minReplicas, _, _ := unstructured.NestedInt64(bound.Object, "spec", "minReplicas")
+		maxReplicas, _, _ := unstructured.NestedInt64(bound.Object, "spec", "maxReplicas")
+		switch {
+		case !existing[target]:
+			orphaned = append(orphaned, fmt.Sprintf("%s (%s)", name, target))
+		case maxReplicas < minReplicas:
+			inverted = append(inverted, fmt.Sprintf("%s (%d-%d)", name, minReplicas, maxReplicas))
+		}
+	}
+	sort.Strings(orphaned)
+	sort.Strings(inverted)
+
+	switch {
+	case clusterAutoscaler == nil && len(bounds) > 0:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("%d MachineAutoscalers exist, but without a ClusterAutoscaler named default none of them scales", len(bounds))
+		result.Recommendation = "Create the ClusterAutoscaler named default with resource limits for the cluster, or " +
+			"remove the MachineAutoscalers if autoscaling is not wanted."
+	case len(orphaned) > 0 || len(inverted) > 0:
+		result.Status = types.ResultKeyRecommended
+		var problems []string
+		if len(orphaned) > 0 {
+			problems = append(problems, "target missing machine sets: "+strings.Join(orphaned, ", "))
+		}
+		if len(inverted) > 0 {
+			problems = append(problems, "have a maximum below their minimum: "+strings.Join(inverted, ", "))
+		}
+		result.Observation = "MachineAutoscalers " + strings.Join(problems, "; ")
+		result.Recommendation = "Point each MachineAutoscaler at an existing machine set in openshift-machine-api with " +
+			"minReplicas not above maxReplicas."
+	case clusterAutoscaler == nil:
+		result.Observation = "Autoscaling is not configured; the machine sets are scaled manually"
+	case len(bounds) == 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = "The ClusterAutoscaler exists, but no MachineAutoscaler lets it scale a machine set"
+		result.Recommendation = "Create a MachineAutoscaler for each machine set that should scale with the workload."
+	default:
+		maxNodes, found, _ := unstructured.NestedInt64(clusterAutoscaler.Object, "spec", "resourceLimits", "maxNodesTotal")
+		if !found {
+			result.Status = types.ResultKeyAdvisory
+			result.Observation = fmt.Sprintf("The ClusterAutoscaler scales %d machine sets without a limit on the total number of nodes", len(bounds))
+			result.Recommendation = "Set spec.resourceLimits.maxNodesTotal so a runaway workload can't grow the cluster, " +
+				"and its cost, without bound."
+			return result
+		}
+		result.Observation = fmt.Sprintf("The ClusterAutoscaler scales %d machine sets up to %d nodes", len(bounds), maxNodes)
+	}
+	return result
+}
+
+// failureDomainResult reports a control plane whose nodes share a zone while
+// the cluster spans several, so losing that zone loses the etcd quorum
+func failureDomainResult(nodes []corev1.Node, singleReplica bool) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Control Plane Failure Domains",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if singleReplica {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "The cluster runs a single control plane node by design"
+		return result
+	}
+
+	clusterZones := make(map[string]bool)
+	controlPlaneZones := make(map[string]int)
+	controlPlane, unlabeled := 0, 0
+	for _, node := range nodes {
+		zone := node.Labels[zoneLabel]
+		if zone != "" {
+			clusterZones[zone] = true
+		}
+		if !controlPlaneNode(node) {
+			continue
+		}
+		controlPlane++
+		if zone == "" {
+			unlabeled++
+			continue
+		}
+		controlPlaneZones[zone]++
+	}
+
+	if controlPlane == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "No control plane nodes are visible, as with a hosted control plane"
+		return result
+	}
+	if unlabeled > 0 {
+		result.Status = types.ResultKeyEvaluate
+		result.Observation = fmt.Sprintf("%d of %d control plane nodes have no %s label, so their failure domains are unknown", unlabeled, controlPlane, zoneLabel)
+		result.Recommendation = "Confirm the control plane nodes run in separate racks, hosts or power domains."
+		return result
+	}
+
+	var spread []string
+	for zone, count := range controlPlaneZones {
+		spread = append(spread, fmt.Sprintf("%s (%d)", zone, count))
+	}
+	sort.Strings(spread)
+
+	wanted := min(len(clusterZones), controlPlane)
+	switch {
+	case len(controlPlaneZones) < wanted:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("The %d control plane nodes use %d of the cluster's %d zones: %s", controlPlane,
+			len(controlPlaneZones), len(clusterZones), strings.Join(spread, ", "))
+		result.Recommendation = "Spread the control plane machines across zones through the failure domains of the " +
+			"ControlPlaneMachineSet, so losing one zone keeps the etcd quorum."
+	case len(controlPlaneZones) == 1:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("The cluster runs in the single zone %s, so a zone outage stops it", spread[0])
+		result.Recommendation = "Use a multi-zone installation for workloads that must survive the loss of a data center."
+	default:
+		result.Observation = fmt.Sprintf("The control plane nodes are spread across zones: %s", strings.Join(spread, ", "))
+	}
+	return result
+}
+
+// controlPlaneNode returns true for nodes with a control plane role
+func controlPlaneNode(node corev1.Node) bool {
+	for _, role := range controlPlaneRoles {
+		if _, ok := node.Labels[role]; ok {
+			return true
+		}
+	}
+	return false
+}
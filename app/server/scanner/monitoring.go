@@ -0,0 +1,301 @@
+// app/server/scanner/monitoring.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+const (
+	// monitoringNamespace holds the platform monitoring stack and its configuration
+	monitoringNamespace = "openshift-monitoring"
+
+	// monitoringConfigMap configures the platform monitoring stack
+	monitoringConfigMap = "cluster-monitoring-config"
+
+	// alertmanagerSecret holds the configuration of the platform Alertmanager
+	alertmanagerSecret = "alertmanager-main"
+
+	// defaultRetention is the Prometheus retention when none is configured
+	defaultRetention = 15 * 24 * time.Hour
+
+	// minRetention and maxRetention bound a retention that covers a long
+	// weekend of incidents without filling the volume
+	minRetention = 7 * 24 * time.Hour
+	maxRetention = 90 * 24 * time.Hour
+)
+
+// prometheusDuration matches the durations Prometheus accepts, such as 15d or 2w
+var prometheusDuration = regexp.MustCompile(`^(\d+)(ms|s|m|h|d|w|y)$`)
+
+// prometheusUnits are the lengths of the Prometheus duration units
+var prometheusUnits = map[string]time.Duration{
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+	"d":  24 * time.Hour,
+	"w":  7 * 24 * time.Hour,
+	"y":  365 * 24 * time.Hour,
+}
+
+// monitoringConfig is the part of cluster-monitoring-config the check reads
+type monitoringConfig struct {
+	EnableUserWorkload bool `yaml:"enableUserWorkload"`
+	PrometheusK8s      struct {
+		Retention           string      `yaml:"retention"`
+		RetentionSize       string      `yaml:"retentionSize"`
+		VolumeClaimTemplate interface{} `yaml:"volumeClaimTemplate"`
+	} `yaml:"prometheusK8s"`
+	AlertmanagerMain struct {
+		VolumeClaimTemplate interface{} `yaml:"volumeClaimTemplate"`
+	} `yaml:"alertmanagerMain"`
+}
+
+// alertmanagerRoute is a node of the Alertmanager routing tree
+type alertmanagerRoute struct {
+	Receiver string              `yaml:"receiver"`
+	Matchers []string            `yaml:"matchers"`
+	Match    map[string]string   `yaml:"match"`
+	Routes   []alertmanagerRoute `yaml:"routes"`
+}
+
+// alertmanagerConfig is the part of the Alertmanager configuration the check reads
+type alertmanagerConfig struct {
+	Route     alertmanagerRoute        `yaml:"route"`
+	Receivers []map[string]interface{} `yaml:"receivers"`
+}
+
+// MonitoringConfigCheck verifies the platform monitoring stack is configured
+// for production: user workload monitoring, alert receivers, persistent
+// storage and a sensible retention
+type MonitoringConfigCheck struct{}
+
+// ID returns the check identifier
+func (c *MonitoringConfigCheck) ID() string {
+	return "monitoring-config"
+}
+
+// Inputs returns the resources the check reads
+func (c *MonitoringConfigCheck) Inputs() []Input {
+	return []Input{
+		{Resource: configMapResource, Namespace: monitoringNamespace},
+		{Resource: secretResource, Namespace: monitoringNamespace},
+	}
+}
+
+// Run reads the cluster monitoring config map and the Alertmanager configuration
+func (c *MonitoringConfigCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	var config monitoringConfig
+	configMap, err := clients.Kube.CoreV1().ConfigMaps(monitoringNamespace).Get(ctx, monitoringConfigMap, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		// Without the config map the stack runs with its defaults
+	case err != nil:
+		return nil, fmt.Errorf("error getting %s: %w", monitoringConfigMap, err)
+	default:
+		if err := yaml.Unmarshal([]byte(configMap.Data["config.yaml"]), &config); err != nil {
+			return []Result{{
+				Category:       CategoryOpReady,
+				Item:           "Monitoring Configuration",
+				Status:         types.ResultKeyRequired,
+				Observation:    fmt.Sprintf("%s can't be parsed, so the monitoring operator ignores it: %v", monitoringConfigMap, err),
+				Recommendation: "Fix the YAML of config.yaml in the config map; the operator reports the error in its Degraded condition.",
+			}}, nil
+		}
+	}
+
+	receivers, err := c.receiverResult(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Result{
+		userWorkloadResult(config),
+		receivers,
+		monitoringStorageResult(config),
+		retentionResult(config),
+	}, nil
+}
+
+// userWorkloadResult reports whether applications can be monitored by the platform stack
+func userWorkloadResult(config monitoringConfig) Result {
+	result := Result{
+		Category:    CategoryOpReady,
+		Item:        "User Workload Monitoring",
+		Status:      types.ResultKeyNoChange,
+		Observation: "User workload monitoring is enabled",
+	}
+	if !config.EnableUserWorkload {
+		result.Status = types.ResultKeyRecommended
+		result.Observation = "User workload monitoring is disabled, so application metrics and alerts aren't collected"
+		result.Recommendation = "Set enableUserWorkload: true in " + monitoringConfigMap + " so teams can monitor " +
+			"their applications with ServiceMonitors and PrometheusRules."
+	}
+	return result
+}
+
+// receiverResult reports whether the Alertmanager routes alerts to a receiver
+// that notifies anyone
+func (c *MonitoringConfigCheck) receiverResult(ctx context.Context, clients *live.Clients) (Result, error) {
+	result := Result{
+		Category: CategoryOpReady,
+		Item:     "Alertmanager Receivers",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	secret, err := clients.Kube.CoreV1().Secrets(monitoringNamespace).Get(ctx, alertmanagerSecret, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		result.Status = types.ResultKeyEvaluate
+		result.Observation = "The Alertmanager configuration secret doesn't exist"
+		return result, nil
+	}
+	if err != nil {
+		return Result{}, fmt.Errorf("error getting %s: %w", alertmanagerSecret, err)
+	}
+
+	var config alertmanagerConfig
+	if err := yaml.Unmarshal(secret.Data["alertmanager.yaml"], &config); err != nil {
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("The Alertmanager configuration can't be parsed: %v", err)
+		result.Recommendation = "Fix alertmanager.yaml in the " + alertmanagerSecret + " secret; Alertmanager keeps its last valid configuration."
+		return result, nil
+	}
+
+	// A receiver notifies when it has at least one integration, like email_configs
+	notifying := make(map[string]bool)
+	for _, receiver := range config.Receivers {
+		name, _ := receiver["name"].(string)
+		for key, value := range receiver {
+			if strings.HasSuffix(key, "_configs") && value != nil {
+				notifying[name] = true
+			}
+		}
+	}
+
+	var used []string
+	collectReceivers(config.Route, &used)
+	var active []string
+	for _, name := range used {
+		if notifying[name] {
+			active = append(active, name)
+		}
+	}
+	sort.Strings(active)
+
+	if len(active) == 0 {
+		result.Status = types.ResultKeyRequired
+		result.Observation = "Every route of the Alertmanager ends in a receiver without integrations, so alerts notify nobody"
+		result.Recommendation = "Configure a receiver with email, PagerDuty, Slack or webhook settings under " +
+			"Administration > Cluster Settings > Alertmanager and route the critical alerts to it."
+		return result, nil
+	}
+
+	result.Observation = fmt.Sprintf("Alerts are routed to the receivers %s", strings.Join(active, ", "))
+	return result, nil
+}
+
+// collectReceivers lists the receivers of a routing tree, except the one of the
+// Watchdog alert, which is meant to fire continuously into a dead man's switch
+func collectReceivers(route alertmanagerRoute, receivers *[]string) {
+	watchdog := route.Match["alertname"] == "Watchdog"
+	for _, matcher := range route.Matchers {
+		if strings.ReplaceAll(strings.ReplaceAll(matcher, " ", ""), `"`, "") == "alertname=Watchdog" {
+			watchdog = true
+		}
+	}
+	if route.Receiver != "" && !watchdog {
+		*receivers = append(*receivers, route.Receiver)
+	}
+	for _, child := range route.Routes {
+		collectReceivers(child, receivers)
+	}
+}
+
+// monitoringStorageResult reports whether Prometheus and Alertmanager keep
+// their data on persistent volumes
+func monitoringStorageResult(config monitoringConfig) Result {
+	result := Result{
+		Category: CategoryOpReady,
+		Item:     "Monitoring Persistent Storage",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	switch {
+	case config.PrometheusK8s.VolumeClaimTemplate == nil:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = "Prometheus stores its metrics in emptyDir volumes and loses them whenever its pods move"
+		result.Recommendation = "Add a volumeClaimTemplate to prometheusK8s, and alertmanagerMain, in " +
+			monitoringConfigMap + " using a block storage class."
+	case config.AlertmanagerMain.VolumeClaimTemplate == nil:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = "Prometheus uses persistent volumes, but Alertmanager loses its silences when its pods move"
+		result.Recommendation = "Add a volumeClaimTemplate to alertmanagerMain in " + monitoringConfigMap + "."
+	default:
+		result.Observation = "Prometheus and Alertmanager keep their data on persistent volumes"
+	}
+	return result
+}
+
+// retentionResult reports a Prometheus retention that is too short to
+// investigate incidents or long enough to fill the volume
+func retentionResult(config monitoringConfig) Result {
+	result := Result{
+		Category: CategoryOpReady,
+		Item:     "Prometheus Retention",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	retention, configured := defaultRetention, config.PrometheusK8s.Retention
+	if configured != "" {
+		parsed, err := parsePrometheusDuration(configured)
+		if err != nil {
+			result.Status = types.ResultKeyRecommended
+			result.Observation = fmt.Sprintf("The retention %q is not a valid duration", configured)
+			result.Recommendation = "Set prometheusK8s.retention to a duration like 15d."
+			return result
+		}
+		retention = parsed
+	}
+	days := int(retention.Hours() / 24)
+
+	switch {
+	case retention < minRetention:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("Prometheus keeps metrics for %d days, too short to investigate incidents after a weekend", days)
+		result.Recommendation = "Raise prometheusK8s.retention to at least 7d, or forward the metrics to long-term storage."
+	case retention > maxRetention && config.PrometheusK8s.RetentionSize == "":
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("Prometheus keeps metrics for %d days without a size limit", days)
+		result.Recommendation = "Set prometheusK8s.retentionSize below the volume size so Prometheus drops old blocks " +
+			"instead of filling the volume, or keep long-term metrics in Thanos or remote storage."
+	default:
+		result.Observation = fmt.Sprintf("Prometheus keeps metrics for %d days", days)
+	}
+	return result
+}
+
+// parsePrometheusDuration parses a duration in the Prometheus syntax, like 15d
+func parsePrometheusDuration(value string) (time.Duration, error) {
+	match := prometheusDuration.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	count, err := strconv.Atoi(match[1])
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(count) * prometheusUnits[match[2]], nil
+}
@@ -0,0 +1,208 @@
+// app/server/scanner/networkpolicy.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// namespacePolicies is the ingress isolation of one user namespace
+type namespacePolicies struct {
+	policies    int
+	defaultDeny bool
+	workloads   map[string]bool
+	exposed     map[string]bool
+}
+
+// NetworkPolicyCheck finds user namespaces without a default-deny ingress
+// policy and workloads that no ingress policy selects, so any pod in the
+// cluster can reach them
+type NetworkPolicyCheck struct{}
+
+// ID returns the check identifier
+func (c *NetworkPolicyCheck) ID() string {
+	return "network-policies"
+}
+
+// Inputs returns the resources the check reads
+func (c *NetworkPolicyCheck) Inputs() []Input {
+	return []Input{
+		{Resource: namespaceResource},
+		{Resource: podResource},
+		{Resource: networkingv1.SchemeGroupVersion.WithResource("networkpolicies")},
+	}
+}
+
+// Run matches the pods of every user namespace against its network policies
+// and attaches the breakdown by namespace
+func (c *NetworkPolicyCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	namespaces, err := clients.Kube.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing namespaces: %w", err)
+	}
+	policies, err := clients.Kube.NetworkingV1().NetworkPolicies(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing network policies: %w", err)
+	}
+	pods, err := clients.Kube.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods: %w", err)
+	}
+
+	scope := scopeFrom(ctx)
+	coverage := make(map[string]*namespacePolicies)
+	for _, namespace := range namespaces.Items {
+		if !scope.covers(namespace.Name) {
+			continue
+		}
+		coverage[namespace.Name] = &namespacePolicies{workloads: make(map[string]bool), exposed: make(map[string]bool)}
+	}
+
+	ingress := make(map[string][]labels.Selector)
+	for _, policy := range policies.Items {
+		entry, ok := coverage[policy.Namespace]
+		if !ok || !isolatesIngress(policy) {
+			continue
+		}
+		entry.policies++
+		if defaultDeny(policy) {
+			entry.defaultDeny = true
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&policy.Spec.PodSelector)
+		if err != nil {
+			continue
+		}
+		ingress[policy.Namespace] = append(ingress[policy.Namespace], selector)
+	}
+
+	for _, pod := range pods.Items {
+		entry, ok := coverage[pod.Namespace]
+		if !ok || pod.Spec.HostNetwork || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		workload := workloadName(pod)
+		entry.workloads[workload] = true
+		if !selected(ingress[pod.Namespace], pod.Labels) {
+			entry.exposed[workload] = true
+		}
+	}
+
+	return []Result{coverageResult(coverage)}, nil
+}
+
+// coverageResult judges the namespaces by their ingress isolation
+func coverageResult(coverage map[string]*namespacePolicies) Result {
+	result := Result{
+		Category: CategorySecurity,
+		Item:     "Network Policy Coverage",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if len(coverage) == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "The cluster has no user namespaces"
+		return result
+	}
+
+	names := make([]string, 0, len(coverage))
+	for name := range coverage {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attachment := &Attachment{
+		Title:   "Network policy coverage by namespace",
+		Columns: []string{"Namespace", "Ingress Policies", "Default Deny", "Workloads", "Exposed Workloads"},
+	}
+	var open, workloads, exposed int
+	for _, name := range names {
+		entry := coverage[name]
+		if !entry.defaultDeny {
+			open++
+		}
+		workloads += len(entry.workloads)
+		exposed += len(entry.exposed)
+
+		deny := "no"
+		if entry.defaultDeny {
+			deny = "yes"
+		}
+		exposedNames := make([]string, 0, len(entry.exposed))
+		for workload := range entry.exposed {
+			exposedNames = append(exposedNames, workload)
+		}
+		sort.Strings(exposedNames)
+		attachment.Rows = append(attachment.Rows, []string{
+			name, strconv.Itoa(entry.policies), deny, strconv.Itoa(len(entry.workloads)), strings.Join(exposedNames, ", "),
+		})
+	}
+
+	if open == 0 && exposed == 0 {
+		result.Observation = fmt.Sprintf("All %d user namespaces deny ingress by default and every workload is selected by a policy", len(names))
+		return result
+	}
+
+	result.Status = types.ResultKeyRecommended
+	result.Observation = fmt.Sprintf("%d of %d user namespaces have no default-deny ingress policy, and %d of %d workloads "+
+		"accept traffic from any pod because no ingress policy selects them", open, len(names), exposed, workloads)
+	result.Recommendation = "Add a default-deny ingress NetworkPolicy to every user namespace, for example through the " +
+		"project template, and allow the required traffic explicitly, including from the openshift-ingress and " +
+		"openshift-monitoring namespaces."
+	result.Attachment = attachment
+	return result
+}
+
+// isolatesIngress reports whether a policy restricts ingress traffic; a
+// policy without policy types always does
+func isolatesIngress(policy networkingv1.NetworkPolicy) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return true
+	}
+	for _, policyType := range policy.Spec.PolicyTypes {
+		if policyType == networkingv1.PolicyTypeIngress {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultDeny reports whether a policy selects every pod of its namespace and
+// allows no ingress traffic
+func defaultDeny(policy networkingv1.NetworkPolicy) bool {
+	selector := policy.Spec.PodSelector
+	return len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0 && len(policy.Spec.Ingress) == 0
+}
+
+// selected reports whether any of the selectors matches the pod labels
+func selected(selectors []labels.Selector, podLabels map[string]string) bool {
+	for _, selector := range selectors {
+		if selector.Matches(labels.Set(podLabels)) {
+			return true
+		}
+	}
+	return false
+}
+
+// workloadName names the workload a pod belongs to by its controller, so the
+// replicas of a deployment count once
+func workloadName(pod corev1.Pod) string {
+	owner := metav1.GetControllerOf(&pod)
+	if owner == nil {
+		return "pod/" + pod.Name
+	}
+	if hash := pod.Labels["pod-template-hash"]; owner.Kind == "ReplicaSet" && hash != "" {
+		return "deployment/" + strings.TrimSuffix(owner.Name, "-"+hash)
+	}
+	return strings.ToLower(owner.Kind) + "/" + owner.Name
+}
@@ -0,0 +1,293 @@
+// app/server/scanner/nodes.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+const (
+	// kubeletMaxSkew is how many minor versions a kubelet may trail the API
+	// server; OpenShift relies on it while updating from one EUS release to the next
+	kubeletMaxSkew = 2
+
+	// clockSyncQuery reports whether the kernel of each node considers its
+	// clock synchronized by chrony
+	clockSyncQuery = `min by (instance) (node_timex_sync_status)`
+)
+
+// nodePressureConditions are the conditions under which the kubelet starts evicting pods
+var nodePressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+}
+
+// expectedTaintPrefixes are the taint keys set by Kubernetes and OpenShift
+// themselves, such as node conditions and dedicated control plane or infra roles
+var expectedTaintPrefixes = []string{
+	"node.kubernetes.io/",
+	"node-role.kubernetes.io/",
+}
+
+// NodeCheck verifies the nodes are ready, free of resource pressure, on a
+// supported kubelet version, synchronized in time and without unexpected taints
+type NodeCheck struct{}
+
+// ID returns the check identifier
+func (c *NodeCheck) ID() string {
+	return "nodes"
+}
+
+// Run inspects the node list, the API server version and, when the cluster
+// has a Prometheus endpoint, the clock synchronization of each node
+func (c *NodeCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	nodes, err := clients.Kube.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	server, err := clients.Kube.Discovery().ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("error getting API server version: %w", err)
+	}
+
+	return []Result{
+		readinessResult(nodes.Items),
+		pressureResult(nodes.Items),
+		kubeletSkewResult(nodes.Items, server.GitVersion),
+		clockSyncResult(ctx, clients),
+		taintResult(nodes.Items),
+	}, nil
+}
+
+// readinessResult reports nodes that are not ready or cordoned
+func readinessResult(nodes []corev1.Node) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Node Readiness",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var notReady, cordoned []string
+	for _, node := range nodes {
+		if !nodeCondition(node, corev1.NodeReady) {
+			notReady = append(notReady, node.Name)
+		}
+		if node.Spec.Unschedulable {
+			cordoned = append(cordoned, node.Name)
+		}
+	}
+
+	switch {
+	case len(notReady) > 0:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("%d of %d nodes are not ready: %s", len(notReady), len(nodes), strings.Join(notReady, ", "))
+		result.Recommendation = "Check the kubelet and CRI-O on the affected nodes with `oc describe node` and " +
+			"`oc adm node-logs`, and replace nodes that don't recover."
+	case len(cordoned) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("All %d nodes are ready; %d are cordoned: %s", len(nodes), len(cordoned), strings.Join(cordoned, ", "))
+		result.Recommendation = "Uncordon the nodes with `oc adm uncordon` once their maintenance is done, so their capacity is used."
+	default:
+		result.Observation = fmt.Sprintf("All %d nodes are ready and schedulable", len(nodes))
+	}
+	return result
+}
+
+// pressureResult reports nodes under memory, disk or PID pressure
+func pressureResult(nodes []corev1.Node) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Node Resource Pressure",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var pressured []string
+	for _, node := range nodes {
+		var conditions []string
+		for _, condition := range nodePressureConditions {
+			if nodeCondition(node, condition) {
+				conditions = append(conditions, string(condition))
+			}
+		}
+		if len(conditions) > 0 {
+			pressured = append(pressured, fmt.Sprintf("%s (%s)", node.Name, strings.Join(conditions, ", ")))
+		}
+	}
+
+	if len(pressured) == 0 {
+		result.Observation = fmt.Sprintf("None of the %d nodes reports memory, disk or PID pressure", len(nodes))
+		return result
+	}
+
+	result.Status = types.ResultKeyRequired
+	result.Observation = fmt.Sprintf("%d of %d nodes report resource pressure and evict pods: %s", len(pressured), len(nodes), strings.Join(pressured, "; "))
+	result.Recommendation = "Free disk space in /var (images, logs), review memory requests against usage and raise " +
+		"the PID limit or node size; the kubelet evicts pods until the pressure clears."
+	return result
+}
+
+// kubeletSkewResult compares the kubelet version of each node to the API server
+func kubeletSkewResult(nodes []corev1.Node, serverVersion string) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Kubelet Version Skew",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	server, err := version.ParseGeneric(serverVersion)
+	if err != nil {
+		result.Status = types.ResultKeyEvaluate
+		result.Observation = fmt.Sprintf("The API server version %q could not be parsed", serverVersion)
+		return result
+	}
+
+	var unsupported, trailing []string
+	for _, node := range nodes {
+		kubelet, err := version.ParseGeneric(node.Status.NodeInfo.KubeletVersion)
+		if err != nil {
+			unsupported = append(unsupported, fmt.Sprintf("%s (unknown version %q)", node.Name, node.Status.NodeInfo.KubeletVersion))
+			continue
+		}
+		skew := int(server.Minor()) - int(kubelet.Minor())
+		switch {
+		case kubelet.Major() != server.Major() || skew < 0 || skew > kubeletMaxSkew:
+			unsupported = append(unsupported, fmt.Sprintf("%s (%s)", node.Name, node.Status.NodeInfo.KubeletVersion))
+		case skew > 0:
+			trailing = append(trailing, fmt.Sprintf("%s (%s)", node.Name, node.Status.NodeInfo.KubeletVersion))
+		}
+	}
+
+	switch {
+	case len(unsupported) > 0:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("The API server runs %s; these kubelets are newer or more than %d minor versions older: %s",
+			serverVersion, kubeletMaxSkew, strings.Join(unsupported, ", "))
+		result.Recommendation = "Bring the nodes up to date through their MachineConfigPools; kubelets outside the " +
+			"supported skew can fail to run pods or register with the API server."
+	case len(trailing) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("The API server runs %s; %d kubelets trail it by a minor version: %s",
+			serverVersion, len(trailing), strings.Join(trailing, ", "))
+		result.Recommendation = "Finish the update of the MachineConfigPools, including paused ones, so every node runs the cluster version."
+	default:
+		result.Observation = fmt.Sprintf("All %d kubelets run the minor version of the API server (%s)", len(nodes), serverVersion)
+	}
+	return result
+}
+
+// clockSyncResult reports nodes whose kernel doesn't consider the clock
+// synchronized, as exported by the node exporter
+func clockSyncResult(ctx context.Context, clients *live.Clients) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Node Clock Synchronization",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if clients.Prometheus == nil {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "The cluster has no Prometheus endpoint configured, so the chrony sync state can't be read"
+		return result
+	}
+
+	samples, err := clients.Prometheus.Query(ctx, clockSyncQuery)
+	if err != nil {
+		result.Status = types.ResultKeyEvaluate
+		result.Observation = fmt.Sprintf("Query could not be completed: %v", err)
+		return result
+	}
+	if len(samples) == 0 {
+		result.Status = types.ResultKeyEvaluate
+		result.Observation = "Prometheus returned no clock synchronization data"
+		return result
+	}
+
+	var unsynced []string
+	for _, sample := range samples {
+		if sample.Value < 1 {
+			unsynced = append(unsynced, sample.Labels["instance"])
+		}
+	}
+	sort.Strings(unsynced)
+
+	if len(unsynced) == 0 {
+		result.Observation = fmt.Sprintf("Chrony keeps the clocks of all %d monitored nodes synchronized", len(samples))
+		return result
+	}
+
+	result.Status = types.ResultKeyRequired
+	result.Observation = fmt.Sprintf("%d of %d nodes report an unsynchronized clock: %s", len(unsynced), len(samples), strings.Join(unsynced, ", "))
+	result.Recommendation = "Check `chronyc sources` on the affected nodes and that they reach the NTP servers of the " +
+		"chrony MachineConfig; unsynchronized clocks break certificate validation and etcd."
+	return result
+}
+
+// taintResult reports taints that Kubernetes and OpenShift don't set themselves
+func taintResult(nodes []corev1.Node) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Node Taints",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var evicting, restricting []string
+	for _, node := range nodes {
+		for _, taint := range node.Spec.Taints {
+			if expectedTaint(taint) {
+				continue
+			}
+			entry := fmt.Sprintf("%s (%s:%s)", node.Name, taint.Key, taint.Effect)
+			if taint.Effect == corev1.TaintEffectNoExecute {
+				evicting = append(evicting, entry)
+			} else {
+				restricting = append(restricting, entry)
+			}
+		}
+	}
+
+	switch {
+	case len(evicting) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("Custom NoExecute taints evict pods without a toleration: %s", strings.Join(evicting, ", "))
+		result.Recommendation = "Confirm the NoExecute taints are intended and that platform daemon sets tolerate them; " +
+			"remove leftovers with `oc adm taint nodes <node> <key>-`."
+	case len(restricting) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("Custom taints restrict scheduling: %s", strings.Join(restricting, ", "))
+		result.Recommendation = "Confirm the taints dedicate the nodes on purpose and document which workloads tolerate them."
+	default:
+		result.Observation = "Nodes only carry the taints set by Kubernetes and OpenShift"
+	}
+	return result
+}
+
+// expectedTaint reports whether a taint is set by Kubernetes or OpenShift themselves
+func expectedTaint(taint corev1.Taint) bool {
+	for _, prefix := range expectedTaintPrefixes {
+		if strings.HasPrefix(taint.Key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeCondition reports whether a node condition is true
+func nodeCondition(node corev1.Node, conditionType corev1.NodeConditionType) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// app/server/scanner/operators.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// monitoringOperators are the cluster operators scored under monitoring
+// rather than the infrastructure setup
+var monitoringOperators = map[string]bool{
+	"monitoring": true,
+	"insights":   true,
+}
+
+// ClusterOperatorCheck reports the conditions of every cluster operator
+type ClusterOperatorCheck struct{}
+
+// ID returns the check identifier
+func (c *ClusterOperatorCheck) ID() string {
+	return "cluster-operators"
+}
+
+// Inputs returns the resources the check reads
+func (c *ClusterOperatorCheck) Inputs() []Input {
+	return []Input{
+		{Resource: clusterOperatorResource},
+	}
+}
+
+// Run produces one result per cluster operator, ordered by name
+func (c *ClusterOperatorCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	operators, err := clients.Config.ConfigV1().ClusterOperators().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing cluster operators: %w", err)
+	}
+
+	sort.Slice(operators.Items, func(i, j int) bool { return operators.Items[i].Name < operators.Items[j].Name })
+
+	results := make([]Result, 0, len(operators.Items))
+	for _, operator := range operators.Items {
+		results = append(results, operatorResult(operator))
+	}
+	return results, nil
+}
+
+// operatorResult judges a cluster operator by its worst condition: unavailable
+// is required, degraded recommended and progressing advisory
+func operatorResult(operator configv1.ClusterOperator) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     fmt.Sprintf("Cluster Operator %s", operator.Name),
+		Status:   types.ResultKeyNoChange,
+	}
+	if monitoringOperators[operator.Name] {
+		result.Category = CategoryOpReady
+	}
+
+	conditions := make(map[configv1.ClusterStatusConditionType]configv1.ClusterOperatorStatusCondition)
+	for _, condition := range operator.Status.Conditions {
+		conditions[condition.Type] = condition
+	}
+	describe := func(condition configv1.ClusterOperatorStatusCondition) string {
+		message := strings.TrimSpace(condition.Message)
+		if message == "" {
+			message = condition.Reason
+		}
+		return fmt.Sprintf("%s is %s=%s: %s", operator.Name, condition.Type, condition.Status, message)
+	}
+
+	if available, ok := conditions[configv1.OperatorAvailable]; !ok || available.Status != configv1.ConditionTrue {
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("%s is not available", operator.Name)
+		if ok {
+			result.Observation = describe(available)
+		}
+		result.Recommendation = fmt.Sprintf("Investigate with `oc describe clusteroperator %s` and the operator's pods; "+
+			"the component it manages is down.", operator.Name)
+		return result
+	}
+	if degraded := conditions[configv1.OperatorDegraded]; degraded.Status == configv1.ConditionTrue {
+		result.Status = types.ResultKeyRecommended
+		result.Observation = describe(degraded)
+		result.Recommendation = fmt.Sprintf("Resolve the cause reported by `oc describe clusteroperator %s`; degraded "+
+			"operators block updates and may lose availability.", operator.Name)
+		return result
+	}
+	if progressing := conditions[configv1.OperatorProgressing]; progressing.Status == configv1.ConditionTrue {
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = describe(progressing)
+		result.Recommendation = "Let the rollout finish and scan again; an operator that keeps progressing outside an update needs investigation."
+		return result
+	}
+
+	version := "no reported version"
+	for _, operand := range operator.Status.Versions {
+		if operand.Name == "operator" {
+			version = operand.Version
+		}
+	}
+	result.Observation = fmt.Sprintf("%s is available, not degraded and settled (%s)", operator.Name, version)
+	return result
+}
@@ -0,0 +1,185 @@
+// app/server/scanner/prometheus.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// metricQuery is a curated PromQL query with the thresholds that make its
+// worst series a finding: above warn is advisory, above critical recommended
+type metricQuery struct {
+	item  string
+	query string
+
+	// category is the report category of the item, CategoryOpReady when empty
+	category string
+
+	// severe raises the findings to recommended above warn and required above critical
+	severe bool
+
+	// labels name the series in the observation, e.g. the node instance
+	labels   []string
+	warn     float64
+	critical float64
+	format   func(float64) string
+
+	recommendation string
+}
+
+// monitoringQueries are the queries of the monitoring check. Thresholds follow
+// the upstream etcd and OpenShift sizing guidance and are deliberately loose,
+// so a finding marks sustained pressure rather than a busy minute.
+var monitoringQueries = []metricQuery{
+	{
+		item: "API server request latency",
+		query: `histogram_quantile(0.99, sum by (le, verb) (rate(` +
+			`apiserver_request_duration_seconds_bucket{job="apiserver",verb!~"WATCH|CONNECT"}[10m])))`,
+		labels: []string{"verb"}, warn: 1, critical: 4, format: formatSeconds,
+		recommendation: "Look for clients flooding the API server with `oc adm top` and the API Performance " +
+			"dashboard, and check the control plane nodes for CPU and disk pressure.",
+	},
+	{
+		item:   "Node CPU saturation",
+		query:  `1 - avg by (instance) (rate(node_cpu_seconds_total{mode="idle"}[10m]))`,
+		labels: []string{"instance"}, warn: 0.8, critical: 0.9, format: formatPercent,
+		recommendation: "Rebalance workloads, review CPU requests and limits, or add nodes to the saturated pool.",
+	},
+	{
+		item:   "Node memory saturation",
+		query:  `1 - node_memory_MemAvailable_bytes / node_memory_MemTotal_bytes`,
+		labels: []string{"instance"}, warn: 0.85, critical: 0.95, format: formatPercent,
+		recommendation: "Review memory requests against actual usage and add capacity before the kubelet starts evicting pods.",
+	},
+}
+
+// PrometheusCheck runs the monitoring queries against the cluster's Prometheus
+type PrometheusCheck struct{}
+
+// ID returns the check identifier
+func (c *PrometheusCheck) ID() string {
+	return "prometheus"
+}
+
+// Run evaluates each monitoring query; clusters without a Prometheus endpoint are skipped
+func (c *PrometheusCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	if clients.Prometheus == nil {
+		return nil, nil
+	}
+
+	results := make([]Result, 0, len(monitoringQueries))
+	for _, query := range monitoringQueries {
+		results = append(results, query.run(ctx, clients.Prometheus))
+	}
+	return results, nil
+}
+
+// run evaluates the query; a failing query, e.g. for a metric the cluster
+// doesn't collect, only affects its own item
+func (q metricQuery) run(ctx context.Context, prometheus *live.Prometheus) Result {
+	samples, err := prometheus.Query(ctx, q.query)
+	if err != nil {
+		return Result{
+			Category:    q.resultCategory(),
+			Item:        q.item,
+			Status:      types.ResultKeyEvaluate,
+			Observation: fmt.Sprintf("Query could not be completed: %v", err),
+		}
+	}
+	return q.result(samples)
+}
+
+// resultCategory returns the report category of the query's item
+func (q metricQuery) resultCategory() string {
+	if q.category == "" {
+		return CategoryOpReady
+	}
+	return q.category
+}
+
+// result turns the samples of a query into a result judged by its worst series
+func (q metricQuery) result(samples []live.Sample) Result {
+	result := Result{
+		Category: q.resultCategory(),
+		Item:     q.item,
+		Status:   types.ResultKeyNoChange,
+	}
+
+	// NaN samples come from histograms without observations in the window
+	var breaching []live.Sample
+	var worst *live.Sample
+	for i, sample := range samples {
+		if math.IsNaN(sample.Value) {
+			continue
+		}
+		if worst == nil || sample.Value > worst.Value {
+			worst = &samples[i]
+		}
+		if sample.Value > q.warn {
+			breaching = append(breaching, sample)
+		}
+	}
+
+	if worst == nil {
+		result.Status = types.ResultKeyEvaluate
+		result.Observation = "Prometheus returned no data for this metric"
+		return result
+	}
+	if len(breaching) == 0 {
+		result.Observation = fmt.Sprintf("Highest value is %s, below the %s threshold",
+			q.format(worst.Value), q.format(q.warn))
+		return result
+	}
+
+	warnStatus, criticalStatus := types.ResultKeyAdvisory, types.ResultKeyRecommended
+	if q.severe {
+		warnStatus, criticalStatus = types.ResultKeyRecommended, types.ResultKeyRequired
+	}
+	result.Status = warnStatus
+	if worst.Value > q.critical {
+		result.Status = criticalStatus
+	}
+
+	sort.Slice(breaching, func(i, j int) bool { return breaching[i].Value > breaching[j].Value })
+	series := make([]string, 0, len(breaching))
+	for _, sample := range breaching {
+		series = append(series, fmt.Sprintf("%s (%s)", q.seriesName(sample), q.format(sample.Value)))
+	}
+	result.Observation = fmt.Sprintf("Above the %s threshold (%s is critical): %s",
+		q.format(q.warn), q.format(q.critical), strings.Join(series, ", "))
+	result.Recommendation = q.recommendation
+	return result
+}
+
+// seriesName names a series by the query's labels
+func (q metricQuery) seriesName(sample live.Sample) string {
+	values := make([]string, 0, len(q.labels))
+	for _, label := range q.labels {
+		if value := sample.Labels[label]; value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return "all"
+	}
+	return strings.Join(values, "/")
+}
+
+// formatSeconds formats a duration in seconds with a readable unit
+func formatSeconds(seconds float64) string {
+	if seconds < 1 {
+		return fmt.Sprintf("%.1fms", seconds*1000)
+	}
+	return fmt.Sprintf("%.2fs", seconds)
+}
+
+// formatPercent formats a ratio as a percentage
+func formatPercent(ratio float64) string {
+	return fmt.Sprintf("%.0f%%", ratio*100)
+}
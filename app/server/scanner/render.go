@@ -0,0 +1,241 @@
+// app/server/scanner/render.go
+package scanner
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// statusMarkers maps each status to the cell color and label used by the report template
+var statusMarkers = map[types.ResultKey][2]string{
+	types.ResultKeyRequired:      {"#FF0000", "Changes Required"},
+	types.ResultKeyRecommended:   {"#FEFE20", "Changes Recommended"},
+	types.ResultKeyAdvisory:      {"#80E5FF", "Advisory"},
+	types.ResultKeyNoChange:      {"#00FF00", "No Change"},
+	types.ResultKeyNotApplicable: {"#A6B9BF", "Not Applicable"},
+	types.ResultKeyEvaluate:      {"#FFFFFF", "To Be Evaluated"},
+}
+
+// RenderAsciiDoc renders a scan as an AsciiDoc health check document using the
+// same Summary table markers as the consulting template, so the result can be
+// stored and re-parsed like any uploaded report. The category cell is written
+// right before the status cell because the category counter keys off the last
+// plain cell preceding each status marker.
+func RenderAsciiDoc(scan *Scan) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("= OpenShift Health Check Report\n\n")
+	fmt.Fprintf(&b, "Live scan of cluster '%s' performed on %s.\n\n",
+		scan.ClusterName, scan.StartedAt.Format("2006-01-02 15:04 MST"))
+	if scan.Scope != nil {
+		fmt.Fprintf(&b, "Workload checks were limited to %s.\n\n", DescribeScope(*scan.Scope))
+	}
+
+	renderResults(&b, scan.Results)
+
+	if scan.Upgrade != nil {
+		renderUpgrade(&b, scan.Upgrade)
+	}
+	if len(scan.Diagnostics) > 0 {
+		renderDiagnostics(&b, scan.Diagnostics)
+	}
+
+	return b.Bytes()
+}
+
+// RenderDocument renders results that don't come from a scan, such as the
+// findings merged from several reports, as a health check document introduced
+// by intro; labels are listed in the labels attribute so they survive parsing
+func RenderDocument(intro string, labels map[string]string, results []Result) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("= OpenShift Health Check Report\n")
+	if len(labels) > 0 {
+		keys := make([]string, 0, len(labels))
+		for key := range labels {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		pairs := make([]string, 0, len(keys))
+		for _, key := range keys {
+			pairs = append(pairs, key+"="+labels[key])
+		}
+		fmt.Fprintf(&b, ":labels: %s\n", strings.Join(pairs, ", "))
+	}
+	fmt.Fprintf(&b, "\n%s\n\n", intro)
+
+	renderResults(&b, results)
+	return b.Bytes()
+}
+
+// renderResults writes the Summary table of results and the detail sections
+// its cross-references point to
+func renderResults(b *bytes.Buffer, results []Result) {
+	b.WriteString("= Summary\n\n")
+	b.WriteString("[cols=\"3,4,2,2\", options=header]\n")
+	b.WriteString("|===\n")
+	b.WriteString("|*Item Evaluated*\n|*Observed Result*\n|*Category*\n|*Status*\n\n")
+
+	for _, result := range results {
+		marker := marker(result.Status)
+
+		b.WriteString("// ------------------------ITEM START\n")
+		fmt.Fprintf(b, "|<<%s>>\n", cellText(result.Item))
+		fmt.Fprintf(b, "|%s\n", cellText(result.Observation))
+		fmt.Fprintf(b, "|%s\n", cellText(result.Category))
+		fmt.Fprintf(b, "|{set:cellbgcolor:%s}\n%s\n", marker[0], marker[1])
+		b.WriteString("// ------------------------ITEM END\n\n")
+	}
+
+	b.WriteString("|===\n")
+
+	// Detail sections carry the recommendations and are the targets of the cross-references
+	for _, result := range results {
+		if result.Recommendation == "" && result.Attachment == nil {
+			continue
+		}
+		fmt.Fprintf(b, "\n= %s\n\n", cellText(result.Item))
+		fmt.Fprintf(b, "*Observation*\n\n%s\n\n", result.Observation)
+		if result.Recommendation != "" {
+			fmt.Fprintf(b, "*Recommendation*\n\n%s\n", result.Recommendation)
+		}
+		if result.Attachment != nil {
+			renderAttachment(b, result.Attachment)
+		}
+	}
+}
+
+// upgradeLabels name the statuses of the update readiness section; they
+// differ from the summary labels so the section isn't scored a second time
+var upgradeLabels = map[types.ResultKey]string{
+	types.ResultKeyRequired:      "Blocker",
+	types.ResultKeyRecommended:   "Risk",
+	types.ResultKeyAdvisory:      "Advisory",
+	types.ResultKeyNoChange:      "Ready",
+	types.ResultKeyNotApplicable: "Not Applicable",
+}
+
+// renderUpgrade writes the update readiness as its own section after the details
+func renderUpgrade(b *bytes.Buffer, upgrade *UpgradeReadiness) {
+	verdict := "has blockers that must be resolved first"
+	if upgrade.Ready {
+		verdict = "has no blockers"
+	}
+	b.WriteString("\n= Upgrade Readiness\n\n")
+	fmt.Fprintf(b, "The update from %s to %s (channel %s) %s.\n\n", upgrade.CurrentVersion, upgrade.TargetVersion,
+		cellText(upgrade.Channel), verdict)
+	b.WriteString("[cols=\"2,1,4,4\", options=header]\n|===\n")
+	writeRow(b, []string{"Check", "Status", "Result", "Action"})
+	for _, result := range upgrade.Results {
+		label, ok := upgradeLabels[result.Status]
+		if !ok {
+			label = "Unknown"
+		}
+		writeRow(b, []string{result.Item, label, result.Observation, result.Recommendation})
+	}
+	b.WriteString("|===\n")
+}
+
+// renderDiagnostics writes how long each check took, whether an incremental
+// scan reused its results and why it failed, as a table without status markers
+func renderDiagnostics(b *bytes.Buffer, diagnostics []CheckDiagnostic) {
+	b.WriteString("\n= Scan Diagnostics\n\n")
+	b.WriteString("[cols=\"2,1,1,4\", options=header]\n|===\n")
+	writeRow(b, []string{"Check", "Duration", "Results", "Note"})
+	for _, diagnostic := range diagnostics {
+		note := diagnostic.Error
+		if diagnostic.Reused {
+			note = "Reused, inputs unchanged"
+		}
+		writeRow(b, []string{
+			diagnostic.ID,
+			diagnostic.Duration.Round(time.Millisecond).String(),
+			strconv.Itoa(diagnostic.Results),
+			note,
+		})
+	}
+	b.WriteString("|===\n")
+}
+
+// renderAttachment writes an attachment as a titled table; it carries no
+// status markers, so the parser leaves it out of the summary
+func renderAttachment(b *bytes.Buffer, attachment *Attachment) {
+	fmt.Fprintf(b, "\n.%s\n", cellText(attachment.Title))
+	b.WriteString("[options=header]\n|===\n")
+	writeRow(b, attachment.Columns)
+	for _, row := range attachment.Rows {
+		writeRow(b, row)
+	}
+	b.WriteString("|===\n")
+}
+
+// writeRow writes the cells of a table row on one line
+func writeRow(b *bytes.Buffer, cells []string) {
+	escaped := make([]string, 0, len(cells))
+	for _, cell := range cells {
+		escaped = append(escaped, cellText(cell))
+	}
+	fmt.Fprintf(b, "|%s\n", strings.Join(escaped, " |"))
+}
+
+// templateFuncs are available to document templates besides the standard functions
+var templateFuncs = template.FuncMap{
+	// cell makes a value safe to place in a single-line table cell
+	"cell": cellText,
+
+	// color and label return the cell color and label marking a status
+	"color": func(status types.ResultKey) string { return marker(status)[0] },
+	"label": func(status types.ResultKey) string { return marker(status)[1] },
+
+	// scope describes the namespace scope of a scoped scan
+	"scope": DescribeScope,
+}
+
+// ParseTemplate parses a document template. Templates are executed with a
+// Scan and must produce the Summary table markers of the report template, or
+// those of a parser profile, so the document can be parsed again.
+func ParseTemplate(text string) (*template.Template, error) {
+	parsed, err := template.New("document").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing document template: %w", err)
+	}
+	return parsed, nil
+}
+
+// RenderTemplate renders a scan with a document template instead of the
+// built-in AsciiDoc document
+func RenderTemplate(t *template.Template, scan *Scan) ([]byte, error) {
+	var b bytes.Buffer
+	if err := t.Execute(&b, scan); err != nil {
+		return nil, fmt.Errorf("error rendering document template: %w", err)
+	}
+	return b.Bytes(), nil
+}
+
+// marker returns the cell color and label of a status, those of "to be
+// evaluated" for unknown ones
+func marker(status types.ResultKey) [2]string {
+	if marker, ok := statusMarkers[status]; ok {
+		return marker
+	}
+	return statusMarkers[types.ResultKeyEvaluate]
+}
+
+// cellText makes a value safe to place in a single-line table cell
+func cellText(value string) string {
+	value = strings.Join(strings.Fields(value), " ")
+	value = strings.ReplaceAll(value, "|", "\\|")
+	value = strings.ReplaceAll(value, "<<", "")
+	value = strings.ReplaceAll(value, ">>", "")
+	if value == "" {
+		value = "-"
+	}
+	return value
+}
@@ -0,0 +1,302 @@
+// app/server/scanner/resources.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+const (
+	// memoryLimitOvercommit is the ratio of memory limits to allocatable memory
+	// above which a node risks OOM kills when its pods use what they may
+	memoryLimitOvercommit = 1.5
+
+	// requestSaturation is the ratio of requests to allocatable CPU or memory
+	// above which a node has no room left to schedule on
+	requestSaturation = 0.9
+)
+
+// nodeUsage sums the requests and limits of the pods scheduled on a node
+type nodeUsage struct {
+	allocatable corev1.ResourceList
+	requests    corev1.ResourceList
+	limits      corev1.ResourceList
+}
+
+// ResourceCheck verifies user namespaces have quotas and limit ranges, their
+// containers declare requests and limits, and nodes aren't overcommitted
+type ResourceCheck struct{}
+
+// ID returns the check identifier
+func (c *ResourceCheck) ID() string {
+	return "resources"
+}
+
+// Inputs returns the resources the check reads
+func (c *ResourceCheck) Inputs() []Input {
+	return []Input{
+		{Resource: namespaceResource},
+		{Resource: podResource},
+		{Resource: nodeResource},
+		{Resource: corev1.SchemeGroupVersion.WithResource("resourcequotas")},
+		{Resource: corev1.SchemeGroupVersion.WithResource("limitranges")},
+	}
+}
+
+// Run inspects the namespaces, pods and nodes of the cluster
+func (c *ResourceCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	namespaces, err := clients.Kube.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing namespaces: %w", err)
+	}
+	quotas, err := clients.Kube.CoreV1().ResourceQuotas(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing resource quotas: %w", err)
+	}
+	limitRanges, err := clients.Kube.CoreV1().LimitRanges(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing limit ranges: %w", err)
+	}
+	pods, err := clients.Kube.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods: %w", err)
+	}
+	nodes, err := clients.Kube.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	scope := scopeFrom(ctx)
+	var running []corev1.Pod
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodSucceeded && pod.Status.Phase != corev1.PodFailed {
+			running = append(running, pod)
+		}
+	}
+
+	return []Result{
+		quotaResult(namespaces.Items, quotas.Items, limitRanges.Items, scope),
+		requestsResult(running, scope),
+		overcommitResult(nodes.Items, running),
+	}, nil
+}
+
+// quotaResult reports user namespaces without a ResourceQuota or LimitRange
+func quotaResult(namespaces []corev1.Namespace, quotas []corev1.ResourceQuota, limitRanges []corev1.LimitRange, scope *namespaceScope) Result {
+	result := Result{
+		Category: CategoryApplications,
+		Item:     "Namespace Quotas and Limit Ranges",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	hasQuota := make(map[string]bool)
+	for _, quota := range quotas {
+		hasQuota[quota.Namespace] = true
+	}
+	hasLimitRange := make(map[string]bool)
+	for _, limitRange := range limitRanges {
+		hasLimitRange[limitRange.Namespace] = true
+	}
+
+	var total int
+	var neither, noQuota, noLimitRange []string
+	for _, namespace := range namespaces {
+		if !scope.covers(namespace.Name) {
+			continue
+		}
+		total++
+		switch {
+		case !hasQuota[namespace.Name] && !hasLimitRange[namespace.Name]:
+			neither = append(neither, namespace.Name)
+		case !hasQuota[namespace.Name]:
+			noQuota = append(noQuota, namespace.Name)
+		case !hasLimitRange[namespace.Name]:
+			noLimitRange = append(noLimitRange, namespace.Name)
+		}
+	}
+	sort.Strings(neither)
+
+	if total == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "The cluster has no user namespaces"
+		return result
+	}
+
+	switch {
+	case len(neither) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("%d of %d user namespaces have neither a ResourceQuota nor a LimitRange (%s); "+
+			"%d more lack a ResourceQuota and %d a LimitRange", len(neither), total, strings.Join(neither, ", "),
+			len(noQuota), len(noLimitRange))
+	case len(noQuota)+len(noLimitRange) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("Of %d user namespaces, %d lack a ResourceQuota and %d a LimitRange",
+			total, len(noQuota), len(noLimitRange))
+	default:
+		result.Observation = fmt.Sprintf("All %d user namespaces have a ResourceQuota and a LimitRange", total)
+		return result
+	}
+	result.Recommendation = "Add a ResourceQuota and a LimitRange with default requests and limits to the project " +
+		"template, and to the existing namespaces, so one team can't exhaust the capacity of the cluster."
+	return result
+}
+
+// requestsResult reports workloads of user namespaces whose containers don't
+// declare CPU and memory requests or a memory limit
+func requestsResult(pods []corev1.Pod, scope *namespaceScope) Result {
+	result := Result{
+		Category: CategoryApplications,
+		Item:     "Container Requests and Limits",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	workloads := make(map[string]bool)
+	noRequests := make(map[string]bool)
+	noLimits := make(map[string]bool)
+	for _, pod := range pods {
+		if !scope.covers(pod.Namespace) {
+			continue
+		}
+		workload := pod.Namespace + "/" + workloadName(pod)
+		workloads[workload] = true
+		for _, container := range pod.Spec.Containers {
+			requests := container.Resources.Requests
+			if requests.Cpu().IsZero() || requests.Memory().IsZero() {
+				noRequests[workload] = true
+			}
+			if container.Resources.Limits.Memory().IsZero() {
+				noLimits[workload] = true
+			}
+		}
+	}
+
+	if len(workloads) == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "No workloads run in user namespaces"
+		return result
+	}
+
+	switch {
+	case len(noRequests) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("%d of %d workloads in user namespaces have containers without CPU or memory "+
+			"requests, and %d without a memory limit", len(noRequests), len(workloads), len(noLimits))
+		result.Recommendation = "Set CPU and memory requests on every container so the scheduler places pods by their " +
+			"real needs, and memory limits so a leaking container can't starve its node; LimitRanges can supply defaults."
+	case len(noLimits) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("All %d workloads in user namespaces declare requests; %d have containers "+
+			"without a memory limit", len(workloads), len(noLimits))
+		result.Recommendation = "Set memory limits on the containers so a leaking container is restarted instead of " +
+			"pressuring its node."
+	default:
+		result.Observation = fmt.Sprintf("All %d workloads in user namespaces declare requests and memory limits", len(workloads))
+	}
+	return result
+}
+
+// overcommitResult compares the requests and limits of the pods on each node
+// to its allocatable CPU and memory
+func overcommitResult(nodes []corev1.Node, pods []corev1.Pod) Result {
+	result := Result{
+		Category: CategoryPerformance,
+		Item:     "Node Overcommit",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	usage := make(map[string]*nodeUsage)
+	for _, node := range nodes {
+		usage[node.Name] = &nodeUsage{
+			allocatable: node.Status.Allocatable,
+			requests:    corev1.ResourceList{},
+			limits:      corev1.ResourceList{},
+		}
+	}
+	for _, pod := range pods {
+		node, ok := usage[pod.Spec.NodeName]
+		if !ok {
+			continue
+		}
+		for _, container := range pod.Spec.Containers {
+			addResources(node.requests, container.Resources.Requests)
+			addResources(node.limits, container.Resources.Limits)
+		}
+	}
+
+	names := make([]string, 0, len(usage))
+	for name := range usage {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var overcommitted, saturated []string
+	var maxMemoryLimits float64
+	for _, name := range names {
+		node := usage[name]
+		memoryLimits := ratio(node.limits, node.allocatable, corev1.ResourceMemory)
+		if memoryLimits > maxMemoryLimits {
+			maxMemoryLimits = memoryLimits
+		}
+		if memoryLimits > memoryLimitOvercommit {
+			overcommitted = append(overcommitted, fmt.Sprintf("%s (memory limits at %s)", name, formatPercent(memoryLimits)))
+		}
+		cpuRequests := ratio(node.requests, node.allocatable, corev1.ResourceCPU)
+		memoryRequests := ratio(node.requests, node.allocatable, corev1.ResourceMemory)
+		if cpuRequests > requestSaturation || memoryRequests > requestSaturation {
+			saturated = append(saturated, fmt.Sprintf("%s (CPU requests at %s, memory requests at %s)",
+				name, formatPercent(cpuRequests), formatPercent(memoryRequests)))
+		}
+	}
+
+	if len(names) == 0 {
+		result.Status = types.ResultKeyEvaluate
+		result.Observation = "The cluster reported no nodes"
+		return result
+	}
+
+	switch {
+	case len(overcommitted) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("%d of %d nodes have memory limits above %s of their allocatable memory: %s",
+			len(overcommitted), len(names), formatPercent(memoryLimitOvercommit), strings.Join(overcommitted, ", "))
+		result.Recommendation = "Bring memory limits closer to the requests of the workloads or add capacity; when " +
+			"the pods of an overcommitted node use their limits the kernel kills containers."
+	case len(saturated) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("%d of %d nodes have more than %s of their CPU or memory requested: %s",
+			len(saturated), len(names), formatPercent(requestSaturation), strings.Join(saturated, ", "))
+		result.Recommendation = "Add nodes or enable the cluster autoscaler before new pods stay pending, and review " +
+			"requests that exceed the actual usage."
+	default:
+		result.Observation = fmt.Sprintf("No node of %d is overcommitted; the highest memory limit ratio is %s",
+			len(names), formatPercent(maxMemoryLimits))
+	}
+	return result
+}
+
+// addResources adds quantities to a running total
+func addResources(total, add corev1.ResourceList) {
+	for name, quantity := range add {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
+
+// ratio divides the used quantity of a resource by the allocatable one
+func ratio(used, allocatable corev1.ResourceList, name corev1.ResourceName) float64 {
+	capacity, ok := allocatable[name]
+	if !ok || capacity.IsZero() {
+		return 0
+	}
+	quantity := used[name]
+	return float64(quantity.MilliValue()) / float64(capacity.MilliValue())
+}
@@ -0,0 +1,261 @@
+// app/server/scanner/scanner.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// Report categories used by the live checks; these match the category column of
+// the AsciiDoc summary table so scan results score exactly like uploaded reports
+const (
+	CategoryClusterConfig = "Cluster Config"
+	CategorySecurity      = "Security"
+	CategoryPerformance   = "Performance"
+	CategoryOpReady       = "Op-Ready"
+	CategoryApplications  = "Applications"
+)
+
+// Result is the outcome of a single evaluated item
+type Result struct {
+	Category       string          `json:"category"`
+	Item           string          `json:"item"`
+	Status         types.ResultKey `json:"status"`
+	Observation    string          `json:"observation"`
+	Recommendation string          `json:"recommendation"`
+	Attachment     *Attachment     `json:"attachment,omitempty"`
+}
+
+// Attachment is a table of supporting data for a result, such as a breakdown
+// by namespace, rendered below the recommendation of the result's detail section
+type Attachment struct {
+	Title   string     `json:"title"`
+	Columns []string   `json:"columns"`
+	Rows    [][]string `json:"rows"`
+}
+
+// Check is a single live health check
+type Check interface {
+	// ID returns a stable identifier for the check
+	ID() string
+
+	// Run inspects the cluster and returns one or more results
+	Run(ctx context.Context, clients *live.Clients) ([]Result, error)
+}
+
+// Scan is the output of running all checks against a cluster
+type Scan struct {
+	ClusterName string    `json:"clusterName"`
+	StartedAt   time.Time `json:"startedAt"`
+	FinishedAt  time.Time `json:"finishedAt"`
+	Results     []Result  `json:"results"`
+
+	// Upgrade is the update readiness of the cluster, nil when it couldn't be assessed
+	Upgrade *UpgradeReadiness `json:"upgrade,omitempty"`
+
+	// Scope is the namespace scope of the workload-level checks, nil when they
+	// looked at every user namespace
+	Scope *types.ScanScope `json:"scope,omitempty"`
+
+	// Diagnostics describe how each check ran, in the order of the checks
+	Diagnostics []CheckDiagnostic `json:"diagnostics"`
+}
+
+// CheckDiagnostic records how a check ran during a scan
+type CheckDiagnostic struct {
+	ID       string        `json:"id"`
+	Duration time.Duration `json:"duration"`
+	Results  int           `json:"results"`
+
+	// Reused is true when an incremental scan took the results from an
+	// earlier scan because the inputs of the check hadn't changed
+	Reused bool `json:"reused,omitempty"`
+
+	// Error is why the check could not be completed
+	Error string `json:"error,omitempty"`
+}
+
+// Options tune a single scan
+type Options struct {
+	// UpgradeTarget is the OpenShift version the update readiness is assessed
+	// for; empty assesses the latest available update
+	UpgradeTarget string
+
+	// Incremental reuses the results of checks whose input resources haven't
+	// changed since the scanner last ran them against the cluster
+	Incremental bool
+
+	// Workers is how many checks run at once; zero runs them one by one
+	Workers int
+
+	// Scope limits the workload-level checks to some namespaces
+	Scope types.ScanScope
+}
+
+// CheckInfo describes a check the scanner runs
+type CheckInfo struct {
+	ID string `json:"id"`
+
+	// External is true for checks run as commands declared in the checks file
+	External bool `json:"external"`
+}
+
+// Scanner runs a set of checks against live clusters
+type Scanner struct {
+	checks []Check
+	cache  *resultCache
+}
+
+// New creates a scanner with the given checks, or the default set when none are given
+func New(checks ...Check) *Scanner {
+	if len(checks) == 0 {
+		checks = DefaultChecks()
+	}
+	return &Scanner{checks: checks, cache: newResultCache()}
+}
+
+// Checks describes the checks the scanner runs, in order
+func (s *Scanner) Checks() []CheckInfo {
+	infos := make([]CheckInfo, 0, len(s.checks))
+	for _, check := range s.checks {
+		_, external := check.(*ExternalCheck)
+		infos = append(infos, CheckInfo{ID: check.ID(), External: external})
+	}
+	return infos
+}
+
+// DefaultChecks returns the built-in live checks
+func DefaultChecks() []Check {
+	return []Check{
+		&ClusterVersionCheck{},
+		&ClusterOperatorCheck{},
+		&NodeCheck{},
+		&MachineCheck{},
+		&EtcdCheck{},
+		&CertificateCheck{},
+		&AuthenticationCheck{},
+		&SecurityContextCheck{},
+		&NetworkPolicyCheck{},
+		&ResourceCheck{},
+		&AvailabilityCheck{},
+		&BackupCheck{},
+		&StorageCheck{},
+		&IngressCheck{},
+		&TimeSyncCheck{},
+		&DNSCheck{},
+		&MonitoringConfigCheck{},
+		&LoggingCheck{},
+		&PrometheusCheck{},
+	}
+}
+
+// Scan runs the checks against the cluster, up to options.Workers at once, and
+// assesses the update readiness; a failing check is reported as an item
+// needing evaluation rather than aborting the whole scan
+func (s *Scanner) Scan(ctx context.Context, clients *live.Clients, options Options) (*Scan, error) {
+	scan := &Scan{
+		ClusterName: clients.ClusterName,
+		StartedAt:   time.Now().UTC(),
+		Diagnostics: make([]CheckDiagnostic, len(s.checks)),
+	}
+
+	scope, err := resolveScope(ctx, clients, options.Scope)
+	if err != nil {
+		return nil, err
+	}
+	if scope != nil {
+		scan.Scope = &scope.ScanScope
+		ctx = withScope(ctx, scope)
+	}
+
+	// Each check writes its own slot, so the results keep the order of the checks
+	results := make([][]Result, len(s.checks))
+	fingerprints := newFingerprinter(clients, options.Scope)
+	workers := make(chan struct{}, max(options.Workers, 1))
+	var wg sync.WaitGroup
+	for i, check := range s.checks {
+		select {
+		case workers <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-workers }()
+			results[i], scan.Diagnostics[i] = s.runCheck(ctx, check, clients, fingerprints, options)
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	for _, checkResults := range results {
+		scan.Results = append(scan.Results, checkResults...)
+	}
+
+	upgrade, err := AssessUpgrade(ctx, clients, options.UpgradeTarget)
+	if err != nil {
+		log.Printf("Upgrade readiness of cluster %s could not be assessed: %v", clients.ClusterName, err)
+	}
+	scan.Upgrade = upgrade
+
+	scan.FinishedAt = time.Now().UTC()
+	log.Printf("Scanned cluster %s with %d checks in %s", clients.ClusterName, len(s.checks),
+		scan.FinishedAt.Sub(scan.StartedAt).Round(time.Millisecond))
+	return scan, nil
+}
+
+// runCheck runs a single check, or reuses its earlier results in an
+// incremental scan when its inputs are unchanged
+func (s *Scanner) runCheck(ctx context.Context, check Check, clients *live.Clients, fingerprints *fingerprinter, options Options) (results []Result, diagnostic CheckDiagnostic) {
+	ctx, span := tracing.Start(ctx, "check", attribute.String("check", check.ID()))
+	started := time.Now()
+	diagnostic.ID = check.ID()
+	var err error
+	defer func() {
+		diagnostic.Duration = time.Since(started)
+		diagnostic.Results = len(results)
+		span.SetAttributes(attribute.Bool("reused", diagnostic.Reused))
+		tracing.End(span, err)
+	}()
+
+	var fingerprint string
+	if incremental, ok := check.(IncrementalCheck); ok && options.Incremental && clients.Metadata != nil {
+		var fingerprintErr error
+		if fingerprint, fingerprintErr = fingerprints.of(ctx, incremental.Inputs()); fingerprintErr != nil {
+			log.Printf("Inputs of check %s on cluster %s could not be fingerprinted: %v", check.ID(), clients.ClusterName, fingerprintErr)
+		} else if cached, ok := s.cache.get(clients.ClusterName, check.ID(), fingerprint); ok {
+			diagnostic.Reused = true
+			return cached, diagnostic
+		}
+	}
+
+	results, err = check.Run(ctx, clients)
+	if err != nil {
+		log.Printf("Check %s failed on cluster %s: %v", check.ID(), clients.ClusterName, err)
+		diagnostic.Error = err.Error()
+		return []Result{{
+			Category:    CategoryClusterConfig,
+			Item:        check.ID(),
+			Status:      types.ResultKeyEvaluate,
+			Observation: fmt.Sprintf("Check could not be completed: %v", err),
+		}}, diagnostic
+	}
+	if fingerprint != "" {
+		s.cache.put(clients.ClusterName, check.ID(), fingerprint, results)
+	}
+	return results, diagnostic
+}
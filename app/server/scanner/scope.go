@@ -0,0 +1,129 @@
+// app/server/scanner/scope.go
+package scanner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// ErrInvalidScope is returned for a scan scope with a malformed namespace
+// pattern or label selector
+var ErrInvalidScope = errors.New("invalid scan scope")
+
+// scopeKey is the context key of the namespace scope of a scan
+type scopeKey struct{}
+
+// namespaceScope is a scan scope resolved against the namespaces of the cluster
+type namespaceScope struct {
+	types.ScanScope
+
+	// selected holds the namespaces matching the selector, nil without one
+	selected map[string]bool
+}
+
+// ValidateScope checks the namespace patterns and the label selector of a scope
+func ValidateScope(scope types.ScanScope) error {
+	for _, patterns := range [][]string{scope.IncludeNamespaces, scope.ExcludeNamespaces} {
+		for _, pattern := range patterns {
+			if strings.TrimSpace(pattern) == "" {
+				return fmt.Errorf("%w: empty namespace pattern", ErrInvalidScope)
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("%w: namespace pattern %q: %v", ErrInvalidScope, pattern, err)
+			}
+		}
+	}
+	if _, err := labels.Parse(scope.NamespaceSelector); err != nil {
+		return fmt.Errorf("%w: namespace selector: %v", ErrInvalidScope, err)
+	}
+	return nil
+}
+
+// DescribeScope summarizes a scope in a sentence fragment such as
+// "namespaces matching team-*, excluding team-sandbox"
+func DescribeScope(scope types.ScanScope) string {
+	parts := []string{"all user namespaces"}
+	if len(scope.IncludeNamespaces) > 0 {
+		parts[0] = "namespaces matching " + strings.Join(scope.IncludeNamespaces, ", ")
+	}
+	if len(scope.ExcludeNamespaces) > 0 {
+		parts = append(parts, "excluding "+strings.Join(scope.ExcludeNamespaces, ", "))
+	}
+	if scope.NamespaceSelector != "" {
+		parts = append(parts, "labelled "+scope.NamespaceSelector)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// resolveScope validates a scope and lists the namespaces its selector
+// matches; an empty scope resolves to nil, which covers every user namespace
+func resolveScope(ctx context.Context, clients *live.Clients, scope types.ScanScope) (*namespaceScope, error) {
+	if scope.Empty() {
+		return nil, nil
+	}
+	if err := ValidateScope(scope); err != nil {
+		return nil, err
+	}
+
+	resolved := &namespaceScope{ScanScope: scope}
+	if scope.NamespaceSelector != "" {
+		list, err := clients.Kube.CoreV1().Namespaces().List(ctx, metav1.ListOptions{LabelSelector: scope.NamespaceSelector})
+		if err != nil {
+			return nil, fmt.Errorf("error listing namespaces: %w", err)
+		}
+		resolved.selected = make(map[string]bool, len(list.Items))
+		for _, namespace := range list.Items {
+			resolved.selected[namespace.Name] = true
+		}
+	}
+	return resolved, nil
+}
+
+// withScope returns a context carrying the namespace scope of a scan
+func withScope(ctx context.Context, scope *namespaceScope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// scopeFrom returns the namespace scope of the scan running in ctx, nil when
+// the scan isn't scoped
+func scopeFrom(ctx context.Context) *namespaceScope {
+	scope, _ := ctx.Value(scopeKey{}).(*namespaceScope)
+	return scope
+}
+
+// covers reports whether the workload-level checks look at a namespace;
+// platform namespaces are never covered
+func (s *namespaceScope) covers(namespace string) bool {
+	if platformNamespace(namespace) {
+		return false
+	}
+	if s == nil {
+		return true
+	}
+	if len(s.IncludeNamespaces) > 0 && !matchesAny(s.IncludeNamespaces, namespace) {
+		return false
+	}
+	if matchesAny(s.ExcludeNamespaces, namespace) {
+		return false
+	}
+	return s.selected == nil || s.selected[namespace]
+}
+
+// matchesAny reports whether a namespace matches one of the glob patterns
+func matchesAny(patterns []string, namespace string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, namespace); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+// app/server/scanner/security.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// sccAnnotation records the SecurityContextConstraints a pod was admitted with
+const sccAnnotation = "openshift.io/scc"
+
+// permissiveSCCs are the SecurityContextConstraints that let pods run as any
+// user or with host access
+var permissiveSCCs = map[string]bool{
+	"anyuid":           true,
+	"privileged":       true,
+	"hostmount-anyuid": true,
+	"hostaccess":       true,
+	"hostnetwork":      true,
+}
+
+// SecurityContextCheck audits the pods and bindings of user namespaces for
+// privileges beyond the restricted defaults; the platform's own namespaces
+// need them and are left out
+type SecurityContextCheck struct{}
+
+// ID returns the check identifier
+func (c *SecurityContextCheck) ID() string {
+	return "security-context"
+}
+
+// Inputs returns the resources the check reads
+func (c *SecurityContextCheck) Inputs() []Input {
+	return []Input{
+		{Resource: podResource},
+		{Resource: clusterRoleBindingResource},
+	}
+}
+
+// Run inspects the pods of every user namespace and the cluster role bindings
+func (c *SecurityContextCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	pods, err := clients.Kube.CoreV1().Pods(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing pods: %w", err)
+	}
+	bindings, err := clients.Kube.RbacV1().ClusterRoleBindings().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing cluster role bindings: %w", err)
+	}
+
+	scope := scopeFrom(ctx)
+	privileged, host, scc := newNamespaceCounts(), newNamespaceCounts(), newNamespaceCounts()
+	for _, pod := range pods.Items {
+		if !scope.covers(pod.Namespace) || pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		if privilegedPod(pod) {
+			privileged.add(pod.Namespace)
+		}
+		if hostAccess(pod) {
+			host.add(pod.Namespace)
+		}
+		if name := pod.Annotations[sccAnnotation]; permissiveSCCs[name] {
+			scc.add(pod.Namespace + " (" + name + ")")
+		}
+	}
+
+	results := []Result{
+		privileged.result("Privileged Containers", types.ResultKeyRequired,
+			"No pod in a user namespace runs a privileged container",
+			"Pods in user namespaces run privileged containers",
+			"Remove privileged: true from the containers, or move the workload to a dedicated, audited namespace; "+
+				"a privileged container has full access to its node."),
+		host.result("Host Network and Host Path", types.ResultKeyRecommended,
+			"No pod in a user namespace shares a host namespace or mounts a host path",
+			"Pods in user namespaces use the host network, PID or IPC namespace or mount host paths",
+			"Replace hostPath volumes with persistent volumes and drop hostNetwork, hostPID and hostIPC unless "+
+				"the workload is a node agent that needs them."),
+		scc.result("Permissive SCCs", types.ResultKeyRecommended,
+			"Pods in user namespaces run under restricted SCCs",
+			"Pods in user namespaces were admitted with a permissive SCC",
+			"Run the workloads under restricted-v2 by fixing images that need root, and remove the `oc adm policy "+
+				"add-scc-to-user` grants of anyuid and privileged."),
+		clusterAdminResult(bindings.Items),
+	}
+	return results, nil
+}
+
+// privilegedPod reports whether a pod has a privileged container
+func privilegedPod(pod corev1.Pod) bool {
+	containers := append(append([]corev1.Container{}, pod.Spec.InitContainers...), pod.Spec.Containers...)
+	for _, container := range containers {
+		if container.SecurityContext != nil && container.SecurityContext.Privileged != nil && *container.SecurityContext.Privileged {
+			return true
+		}
+	}
+	return false
+}
+
+// hostAccess reports whether a pod shares a host namespace or mounts a host path
+func hostAccess(pod corev1.Pod) bool {
+	if pod.Spec.HostNetwork || pod.Spec.HostPID || pod.Spec.HostIPC {
+		return true
+	}
+	for _, volume := range pod.Spec.Volumes {
+		if volume.HostPath != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// clusterAdminResult reports service accounts of user namespaces bound to cluster-admin
+func clusterAdminResult(bindings []rbacv1.ClusterRoleBinding) Result {
+	result := Result{
+		Category: CategorySecurity,
+		Item:     "Cluster Admin Service Accounts",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var granted []string
+	for _, binding := range bindings {
+		if binding.RoleRef.Kind != "ClusterRole" || binding.RoleRef.Name != "cluster-admin" {
+			continue
+		}
+		for _, subject := range binding.Subjects {
+			switch {
+			case subject.Kind == rbacv1.ServiceAccountKind && !platformNamespace(subject.Namespace):
+				granted = append(granted, fmt.Sprintf("%s/%s (%s)", subject.Namespace, subject.Name, binding.Name))
+			case subject.Kind == rbacv1.GroupKind && strings.HasPrefix(subject.Name, "system:serviceaccounts"):
+				granted = append(granted, fmt.Sprintf("group %s (%s)", subject.Name, binding.Name))
+			}
+		}
+	}
+	sort.Strings(granted)
+
+	if len(granted) == 0 {
+		result.Observation = "No service account of a user namespace is bound to cluster-admin"
+		return result
+	}
+
+	result.Status = types.ResultKeyRequired
+	result.Observation = fmt.Sprintf("%d service accounts are bound to cluster-admin: %s", len(granted), strings.Join(granted, ", "))
+	result.Recommendation = "Replace the cluster-admin bindings with roles limited to the resources and namespaces " +
+		"the automation needs; a leaked token of these accounts controls the whole cluster."
+	return result
+}
+
+// platformNamespace reports whether a namespace belongs to the platform
+func platformNamespace(namespace string) bool {
+	return namespace == "openshift" || strings.HasPrefix(namespace, "openshift-") || strings.HasPrefix(namespace, "kube-")
+}
+
+// namespaceCounts counts findings by namespace
+type namespaceCounts map[string]int
+
+// newNamespaceCounts creates an empty count
+func newNamespaceCounts() namespaceCounts {
+	return make(namespaceCounts)
+}
+
+// add counts one finding in a namespace
+func (c namespaceCounts) add(namespace string) {
+	c[namespace]++
+}
+
+// result reports the counted namespaces with the given status, or no change
+// and the clean observation when there are none
+func (c namespaceCounts) result(item string, status types.ResultKey, clean, observation, recommendation string) Result {
+	result := Result{
+		Category: CategorySecurity,
+		Item:     item,
+		Status:   types.ResultKeyNoChange,
+	}
+	if len(c) == 0 {
+		result.Observation = clean
+		return result
+	}
+
+	result.Status = status
+	result.Observation = fmt.Sprintf("%s (%s)", observation, c.details("pods"))
+	result.Recommendation = recommendation
+	return result
+}
+
+// details lists the counts by namespace in order, e.g. "team-a: 2 pods"
+func (c namespaceCounts) details(noun string) string {
+	namespaces := make([]string, 0, len(c))
+	for namespace := range c {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	details := make([]string, 0, len(namespaces))
+	for _, namespace := range namespaces {
+		details = append(details, fmt.Sprintf("%s: %d %s", namespace, c[namespace], noun))
+	}
+	return strings.Join(details, ", ")
+}
@@ -0,0 +1,232 @@
+// app/server/scanner/storage.go
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	operatorv1 "github.com/openshift/api/operator/v1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// defaultClassAnnotation marks the StorageClass used by claims without one
+const defaultClassAnnotation = "storageclass.kubernetes.io/is-default-class"
+
+// volumeCapacityQuery finds the persistent volumes that are filling up
+var volumeCapacityQuery = metricQuery{
+	item:     "Persistent Volume Capacity",
+	category: CategoryClusterConfig,
+	severe:   true,
+	query: `max by (namespace, persistentvolumeclaim) (kubelet_volume_stats_used_bytes / ` +
+		`kubelet_volume_stats_capacity_bytes)`,
+	labels: []string{"namespace", "persistentvolumeclaim"}, warn: 0.8, critical: 0.9, format: formatPercent,
+	recommendation: "Expand the claims, if their StorageClass allows volume expansion, or clean up the data; " +
+		"applications fail to write once a volume is full.",
+}
+
+// StorageCheck verifies claims are bound, volumes are healthy, a default
+// StorageClass exists, the CSI drivers work and volumes have free space
+type StorageCheck struct{}
+
+// ID returns the check identifier
+func (c *StorageCheck) ID() string {
+	return "storage"
+}
+
+// Run inspects the claims, volumes, storage classes and CSI drivers, and when
+// the cluster has a Prometheus endpoint, the usage of the volumes
+func (c *StorageCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	claims, err := clients.Kube.CoreV1().PersistentVolumeClaims(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing persistent volume claims: %w", err)
+	}
+	volumes, err := clients.Kube.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing persistent volumes: %w", err)
+	}
+	classes, err := clients.Kube.StorageV1().StorageClasses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing storage classes: %w", err)
+	}
+	drivers, err := clients.Operator.OperatorV1().ClusterCSIDrivers().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing cluster CSI drivers: %w", err)
+	}
+
+	capacity := Result{
+		Category:    volumeCapacityQuery.resultCategory(),
+		Item:        volumeCapacityQuery.item,
+		Status:      types.ResultKeyNotApplicable,
+		Observation: "The cluster has no Prometheus endpoint configured, so volume usage can't be read",
+	}
+	if clients.Prometheus != nil {
+		capacity = volumeCapacityQuery.run(ctx, clients.Prometheus)
+	}
+
+	return []Result{
+		claimResult(claims.Items),
+		volumeResult(volumes.Items),
+		defaultClassResult(classes.Items),
+		csiDriverResult(drivers.Items),
+		capacity,
+	}, nil
+}
+
+// claimResult reports claims that are pending or lost their volume
+func claimResult(claims []corev1.PersistentVolumeClaim) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Persistent Volume Claims",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var pending, lost []string
+	for _, claim := range claims {
+		name := claim.Namespace + "/" + claim.Name
+		switch claim.Status.Phase {
+		case corev1.ClaimPending:
+			pending = append(pending, name)
+		case corev1.ClaimLost:
+			lost = append(lost, name)
+		}
+	}
+	sort.Strings(pending)
+	sort.Strings(lost)
+
+	switch {
+	case len(lost) > 0:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("%d claims lost their volume: %s", len(lost), strings.Join(lost, ", "))
+		result.Recommendation = "Restore the deleted persistent volumes from backup or recreate the claims; pods " +
+			"using a lost claim can't start."
+	case len(pending) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("%d of %d claims are not bound: %s", len(pending), len(claims), strings.Join(pending, ", "))
+		result.Recommendation = "Check the events of the claims with `oc describe pvc`; a missing StorageClass, " +
+			"exhausted capacity or a failing provisioner keeps them pending."
+	default:
+		result.Observation = fmt.Sprintf("All %d claims are bound", len(claims))
+	}
+	return result
+}
+
+// volumeResult reports volumes that failed or were released by their claim
+func volumeResult(volumes []corev1.PersistentVolume) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Persistent Volumes",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var failed, released []string
+	for _, volume := range volumes {
+		switch volume.Status.Phase {
+		case corev1.VolumeFailed:
+			failed = append(failed, volume.Name)
+		case corev1.VolumeReleased:
+			released = append(released, volume.Name)
+		}
+	}
+	sort.Strings(failed)
+	sort.Strings(released)
+
+	switch {
+	case len(failed) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("%d volumes failed to be reclaimed: %s", len(failed), strings.Join(failed, ", "))
+		result.Recommendation = "Check the status message of the volumes with `oc describe pv`, remove the backing " +
+			"storage manually if needed and delete the volumes."
+	case len(released) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("%d volumes were released by their claims and hold storage: %s", len(released), strings.Join(released, ", "))
+		result.Recommendation = "Delete the released volumes of the Retain policy once their data is no longer needed, " +
+			"or bind them to a new claim."
+	default:
+		result.Observation = fmt.Sprintf("None of the %d persistent volumes is failed or released", len(volumes))
+	}
+	return result
+}
+
+// defaultClassResult reports whether exactly one StorageClass is the default
+func defaultClassResult(classes []storagev1.StorageClass) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Default StorageClass",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var defaults []string
+	for _, class := range classes {
+		if class.Annotations[defaultClassAnnotation] == "true" {
+			defaults = append(defaults, class.Name)
+		}
+	}
+	sort.Strings(defaults)
+
+	switch len(defaults) {
+	case 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("None of the %d storage classes is the default", len(classes))
+		result.Recommendation = "Annotate one StorageClass with " + defaultClassAnnotation + "=true; claims without " +
+			"a storageClassName stay pending otherwise."
+	case 1:
+		result.Observation = fmt.Sprintf("%s is the default StorageClass", defaults[0])
+	default:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("%d storage classes are marked as the default: %s", len(defaults), strings.Join(defaults, ", "))
+		result.Recommendation = "Keep the default annotation on one StorageClass so new claims land on predictable storage."
+	}
+	return result
+}
+
+// csiDriverResult reports the CSI drivers the storage operator manages by
+// their availability and degradation conditions
+func csiDriverResult(drivers []operatorv1.ClusterCSIDriver) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "CSI Drivers",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if len(drivers) == 0 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "The storage operator manages no CSI drivers on this platform"
+		return result
+	}
+
+	var unavailable, degraded, names []string
+	for _, driver := range drivers {
+		names = append(names, driver.Name)
+		for _, condition := range driver.Status.Conditions {
+			switch {
+			case strings.HasSuffix(condition.Type, "Available") && condition.Status == operatorv1.ConditionFalse:
+				unavailable = append(unavailable, fmt.Sprintf("%s (%s: %s)", driver.Name, condition.Type, condition.Message))
+			case strings.HasSuffix(condition.Type, "Degraded") && condition.Status == operatorv1.ConditionTrue:
+				degraded = append(degraded, fmt.Sprintf("%s (%s: %s)", driver.Name, condition.Type, condition.Message))
+			}
+		}
+	}
+	sort.Strings(names)
+
+	switch {
+	case len(unavailable) > 0:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("CSI drivers are unavailable: %s", strings.Join(unavailable, "; "))
+		result.Recommendation = "Check the driver controller and node pods in openshift-cluster-csi-drivers; volumes " +
+			"of an unavailable driver can't be provisioned, attached or mounted."
+	case len(degraded) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("CSI drivers are degraded: %s", strings.Join(degraded, "; "))
+		result.Recommendation = "Investigate with `oc describe clustercsidriver` and the driver pods before volume operations start failing."
+	default:
+		result.Observation = fmt.Sprintf("All CSI drivers are available and not degraded: %s", strings.Join(names, ", "))
+	}
+	return result
+}
@@ -0,0 +1,266 @@
+// app/server/scanner/timesync.go
+package scanner
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+const (
+	// chronyConfPath is where RHCOS reads its chrony configuration
+	chronyConfPath = "/etc/chrony.conf"
+
+	// machineConfigRoleLabel holds the pool role a MachineConfig applies to
+	machineConfigRoleLabel = "machineconfiguration.openshift.io/role"
+
+	// nodeLeaseNamespace holds the leases kubelets renew with their own clocks
+	nodeLeaseNamespace = "kube-node-lease"
+
+	// maxClockSkew is how far a node's lease renewals may stray from the other
+	// nodes; kubelets renew every 10 seconds, so smaller offsets are just noise
+	maxClockSkew = 30 * time.Second
+)
+
+// timeSyncRoles are the pools that each need a chrony configuration
+var timeSyncRoles = []string{"master", "worker"}
+
+// TimeSyncCheck verifies nodes are configured for NTP and their clocks agree
+type TimeSyncCheck struct{}
+
+// ID returns the check identifier
+func (c *TimeSyncCheck) ID() string {
+	return "time-sync"
+}
+
+// Run inspects the chrony MachineConfigs and the node lease renewal times
+func (c *TimeSyncCheck) Run(ctx context.Context, clients *live.Clients) ([]Result, error) {
+	chrony, err := c.chronyResult(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	drift, err := c.driftResult(ctx, clients)
+	if err != nil {
+		return nil, err
+	}
+
+	return []Result{chrony, drift}, nil
+}
+
+// chronyResult reports which pools carry a chrony configuration and the servers they use
+func (c *TimeSyncCheck) chronyResult(ctx context.Context, clients *live.Clients) (Result, error) {
+	configs, err := clients.MachineConf.MachineconfigurationV1().MachineConfigs().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("error listing machine configs: %w", err)
+	}
+
+	servers := make(map[string][]string)
+	for _, mc := range configs.Items {
+		// Rendered configs merge the sources, so they would only repeat them
+		role := mc.Labels[machineConfigRoleLabel]
+		if role == "" || strings.HasPrefix(mc.Name, "rendered-") {
+			continue
+		}
+
+		content, ok := ignitionFile(mc.Spec.Config.Raw, chronyConfPath)
+		if !ok {
+			continue
+		}
+		servers[role] = append(servers[role], chronyServers(content)...)
+	}
+
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "NTP Configuration",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var missing, configured []string
+	for _, role := range timeSyncRoles {
+		list, ok := servers[role]
+		if !ok {
+			missing = append(missing, role)
+			continue
+		}
+		if len(list) == 0 {
+			list = []string{"no servers"}
+		}
+		configured = append(configured, fmt.Sprintf("%s: %s", role, strings.Join(list, ", ")))
+	}
+
+	switch {
+	case len(configured) == 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = "No MachineConfig provides a chrony configuration; nodes use the default public NTP pool"
+		result.Recommendation = "Create 99-master-chrony and 99-worker-chrony MachineConfigs that point " +
+			chronyConfPath + " at the organization's NTP servers, especially for disconnected clusters."
+	case len(missing) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("Chrony is configured for %s but not for the %s pool",
+			strings.Join(configured, "; "), strings.Join(missing, ", "))
+		result.Recommendation = "Apply the same chrony MachineConfig to every pool so all nodes sync from the same time source."
+	default:
+		result.Observation = fmt.Sprintf("Chrony is configured through MachineConfigs (%s)", strings.Join(configured, "; "))
+	}
+
+	return result, nil
+}
+
+// driftResult compares the lease renewal times of the ready nodes, which kubelets
+// stamp with their own clocks, to spot nodes whose time has drifted
+func (c *TimeSyncCheck) driftResult(ctx context.Context, clients *live.Clients) (Result, error) {
+	nodes, err := clients.Kube.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("error listing nodes: %w", err)
+	}
+
+	ready := make(map[string]bool)
+	for _, node := range nodes.Items {
+		for _, condition := range node.Status.Conditions {
+			if condition.Type == corev1.NodeReady && condition.Status == corev1.ConditionTrue {
+				ready[node.Name] = true
+			}
+		}
+	}
+
+	leases, err := clients.Kube.CoordinationV1().Leases(nodeLeaseNamespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("error listing node leases: %w", err)
+	}
+
+	renewals := make(map[string]time.Time)
+	for _, lease := range leases.Items {
+		if ready[lease.Name] && lease.Spec.RenewTime != nil {
+			renewals[lease.Name] = lease.Spec.RenewTime.Time
+		}
+	}
+
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Node Time Drift",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	if len(renewals) < 2 {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "Fewer than two ready nodes renew leases, so clock drift can't be compared"
+		return result, nil
+	}
+
+	reference := medianTime(renewals)
+	var drifted []string
+	var worst time.Duration
+	for name, renewed := range renewals {
+		offset := renewed.Sub(reference)
+		if offset < 0 {
+			offset = -offset
+		}
+		if offset > worst {
+			worst = offset
+		}
+		if offset > maxClockSkew {
+			drifted = append(drifted, fmt.Sprintf("%s (%s)", name, offset.Round(time.Second)))
+		}
+	}
+	sort.Strings(drifted)
+
+	if len(drifted) > 0 {
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("%d of %d nodes are more than %s away from the other nodes: %s",
+			len(drifted), len(renewals), maxClockSkew, strings.Join(drifted, ", "))
+		result.Recommendation = "Check `chronyc tracking` and `chronyc sources` on the affected nodes; clock drift " +
+			"breaks certificate validation, etcd leader election and log correlation."
+		return result, nil
+	}
+
+	result.Observation = fmt.Sprintf("Clocks of %d ready nodes agree within %s (largest offset %s)",
+		len(renewals), maxClockSkew, worst.Round(time.Second))
+	return result, nil
+}
+
+// ignitionConfig is the part of an Ignition config that holds files
+type ignitionConfig struct {
+	Storage struct {
+		Files []struct {
+			Path     string `json:"path"`
+			Contents struct {
+				Source string `json:"source"`
+			} `json:"contents"`
+		} `json:"files"`
+	} `json:"storage"`
+}
+
+// ignitionFile returns the contents of a file written by a MachineConfig's Ignition config
+func ignitionFile(raw []byte, path string) (string, bool) {
+	if len(raw) == 0 {
+		return "", false
+	}
+
+	var config ignitionConfig
+	if err := json.Unmarshal(raw, &config); err != nil {
+		return "", false
+	}
+
+	for _, file := range config.Storage.Files {
+		if file.Path == path {
+			return decodeDataURL(file.Contents.Source), true
+		}
+	}
+	return "", false
+}
+
+// decodeDataURL decodes the data: URLs Ignition uses for inline file contents
+func decodeDataURL(source string) string {
+	header, data, ok := strings.Cut(strings.TrimPrefix(source, "data:"), ",")
+	if !ok {
+		return ""
+	}
+
+	if strings.HasSuffix(header, ";base64") {
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return ""
+		}
+		return string(decoded)
+	}
+
+	decoded, err := url.PathUnescape(data)
+	if err != nil {
+		return ""
+	}
+	return decoded
+}
+
+// chronyServers returns the server and pool directives of a chrony configuration
+func chronyServers(content string) []string {
+	var servers []string
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && (fields[0] == "server" || fields[0] == "pool") {
+			servers = append(servers, fields[1])
+		}
+	}
+	return servers
+}
+
+// medianTime returns the median of a set of timestamps
+func medianTime(times map[string]time.Time) time.Time {
+	sorted := make([]time.Time, 0, len(times))
+	for _, t := range times {
+		sorted = append(sorted, t)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+	return sorted[len(sorted)/2]
+}
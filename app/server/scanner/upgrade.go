@@ -0,0 +1,375 @@
+// app/server/scanner/upgrade.go
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	mcfgv1 "github.com/openshift/api/machineconfiguration/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// ErrInvalidTarget is returned for a target version that can't be parsed or
+// is older than the cluster
+var ErrInvalidTarget = errors.New("invalid target version")
+
+// kubeMinorOffset converts an OpenShift 4 minor version to the Kubernetes
+// minor version it ships, e.g. 4.16 runs Kubernetes 1.29
+const kubeMinorOffset = 13
+
+var (
+	// apiRequestCounts records the requests to every API of the cluster and
+	// the Kubernetes release that removes it
+	apiRequestCounts = schema.GroupVersionResource{Group: "apiserver.openshift.io", Version: "v1", Resource: "apirequestcounts"}
+
+	// clusterServiceVersions are the operators installed through OLM
+	clusterServiceVersions = schema.GroupVersionResource{Group: "operators.coreos.com", Version: "v1alpha1", Resource: "clusterserviceversions"}
+)
+
+// UpgradeReadiness is the assessment of a cluster update to a target version,
+// rendered as its own section of the scan document
+type UpgradeReadiness struct {
+	CurrentVersion string   `json:"currentVersion"`
+	TargetVersion  string   `json:"targetVersion"`
+	Channel        string   `json:"channel"`
+	Channels       []string `json:"channels"`
+	// Ready is false while any result requires changes before the update
+	Ready   bool     `json:"ready"`
+	Results []Result `json:"results"`
+}
+
+// AssessUpgrade evaluates the blockers of an update to target: deprecated
+// APIs still in use, operators that don't allow it, MachineConfigPools that
+// haven't finished updating and the update channels offering it. Without a
+// target the latest available update, or else the next minor version, is assessed.
+func AssessUpgrade(ctx context.Context, clients *live.Clients, target string) (*UpgradeReadiness, error) {
+	cv, err := clients.Config.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error getting cluster version: %w", err)
+	}
+
+	current, err := version.ParseGeneric(cv.Status.Desired.Version)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cluster version %q: %w", cv.Status.Desired.Version, err)
+	}
+	if target == "" {
+		target = defaultTarget(cv, current)
+	}
+	targetVersion, err := version.ParseGeneric(target)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidTarget, target)
+	}
+	if targetVersion.LessThan(current) {
+		return nil, fmt.Errorf("%w: %s is older than the cluster version %s", ErrInvalidTarget, target, cv.Status.Desired.Version)
+	}
+
+	deprecated, err := deprecatedAPIResult(ctx, clients, current, targetVersion)
+	if err != nil {
+		return nil, err
+	}
+	operators, err := operatorUpgradeableResult(ctx, clients, current, targetVersion)
+	if err != nil {
+		return nil, err
+	}
+	pools, err := clients.MachineConf.MachineconfigurationV1().MachineConfigPools().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing machine config pools: %w", err)
+	}
+
+	readiness := &UpgradeReadiness{
+		CurrentVersion: cv.Status.Desired.Version,
+		TargetVersion:  target,
+		Channel:        cv.Spec.Channel,
+		Channels:       cv.Status.Desired.Channels,
+		Ready:          true,
+		Results: []Result{
+			updateChannelResult(cv, current, targetVersion, target),
+			deprecated,
+			operators,
+			machineConfigPoolResult(pools.Items),
+		},
+	}
+	for _, result := range readiness.Results {
+		if result.Status == types.ResultKeyRequired {
+			readiness.Ready = false
+		}
+	}
+	return readiness, nil
+}
+
+// defaultTarget picks the latest available update, or the next minor version
+// when the cluster is on the latest release of its channel
+func defaultTarget(cv *configv1.ClusterVersion, current *version.Version) string {
+	var latest *version.Version
+	for _, update := range cv.Status.AvailableUpdates {
+		if candidate, err := version.ParseGeneric(update.Version); err == nil && (latest == nil || latest.LessThan(candidate)) {
+			latest = candidate
+		}
+	}
+	if latest != nil {
+		return latest.String()
+	}
+	return fmt.Sprintf("%d.%d", current.Major(), current.Minor()+1)
+}
+
+// updateChannelResult reports whether the update service offers the target,
+// or which channel would offer its minor version
+func updateChannelResult(cv *configv1.ClusterVersion, current, target *version.Version, requested string) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Update Path",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	// A target with a patch version must be an update the service recommends
+	for _, update := range cv.Status.AvailableUpdates {
+		if update.Version == requested {
+			result.Observation = fmt.Sprintf("%s is a recommended update from %s in the %s channel", requested, cv.Status.Desired.Version, cv.Spec.Channel)
+			return result
+		}
+	}
+	for _, update := range cv.Status.ConditionalUpdates {
+		if update.Release.Version != requested {
+			continue
+		}
+		risks := make([]string, 0, len(update.Risks))
+		for _, risk := range update.Risks {
+			risks = append(risks, risk.Name)
+		}
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("%s is only a conditional update from %s because of known risks: %s",
+			requested, cv.Status.Desired.Version, strings.Join(risks, ", "))
+		result.Recommendation = "Read the linked risks with `oc adm upgrade --include-not-recommended` and confirm " +
+			"they don't apply to the cluster, or wait for a release without them."
+		return result
+	}
+
+	minor := fmt.Sprintf("-%d.%d", target.Major(), target.Minor())
+	var offering []string
+	for _, channel := range cv.Status.Desired.Channels {
+		if strings.HasSuffix(channel, minor) {
+			offering = append(offering, channel)
+		}
+	}
+	// Suggest the channel of the same kind, such as stable or eus, first
+	kind := strings.SplitN(cv.Spec.Channel, "-", 2)[0] + "-"
+	sort.Slice(offering, func(i, j int) bool {
+		if strings.HasPrefix(offering[i], kind) != strings.HasPrefix(offering[j], kind) {
+			return strings.HasPrefix(offering[i], kind)
+		}
+		return offering[i] < offering[j]
+	})
+
+	switch {
+	case len(strings.Split(requested, ".")) > 2:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("%s is not offered as an update from %s in the %s channel", requested, cv.Status.Desired.Version, cv.Spec.Channel)
+		result.Recommendation = "Choose a target from `oc adm upgrade`, updating through intermediate releases if needed."
+	case target.Minor() == current.Minor():
+		result.Observation = fmt.Sprintf("The cluster already runs %d.%d", target.Major(), target.Minor())
+	case strings.HasSuffix(cv.Spec.Channel, minor):
+		result.Observation = fmt.Sprintf("The cluster follows the %s channel, which offers %s", cv.Spec.Channel, requested)
+	case len(offering) > 0:
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("The %s channel doesn't offer %s; the release is in %s", cv.Spec.Channel, requested, strings.Join(offering, ", "))
+		result.Recommendation = fmt.Sprintf("Switch the channel with `oc adm upgrade channel %s` when the other blockers are resolved.", offering[0])
+	default:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("No update channel offers %s for %s yet", requested, cv.Status.Desired.Version)
+		result.Recommendation = "Update to the latest release of the current minor version first, or wait until the update path is published."
+	}
+	return result
+}
+
+// deprecatedAPIResult reports APIs that are still requested and removed by
+// the Kubernetes release of the target
+func deprecatedAPIResult(ctx context.Context, clients *live.Clients, current, target *version.Version) (Result, error) {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Deprecated API Usage",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	counts, err := clients.Dynamic.Resource(apiRequestCounts).List(ctx, metav1.ListOptions{})
+	if apierrors.IsNotFound(err) {
+		result.Status = types.ResultKeyNotApplicable
+		result.Observation = "The cluster doesn't record API request counts"
+		return result, nil
+	}
+	if err != nil {
+		return Result{}, fmt.Errorf("error listing API request counts: %w", err)
+	}
+
+	currentKube := int(current.Minor()) + kubeMinorOffset
+	targetKube := int(target.Minor()) + kubeMinorOffset
+
+	var used []string
+	for _, count := range counts.Items {
+		removed, _, _ := unstructured.NestedString(count.Object, "status", "removedInRelease")
+		release, err := version.ParseGeneric(removed)
+		if err != nil || int(release.Minor()) <= currentKube || int(release.Minor()) > targetKube {
+			continue
+		}
+		requests, _, _ := unstructured.NestedInt64(count.Object, "status", "requestCount")
+		if requests == 0 {
+			continue
+		}
+		used = append(used, fmt.Sprintf("%s (removed in Kubernetes %s, %d requests in the last 24 hours)", count.GetName(), removed, requests))
+	}
+	sort.Strings(used)
+
+	if len(used) == 0 {
+		result.Observation = fmt.Sprintf("No API removed up to Kubernetes 1.%d is still requested", targetKube)
+		return result, nil
+	}
+
+	result.Status = types.ResultKeyRequired
+	result.Observation = fmt.Sprintf("%d APIs removed by the target release are still requested: %s", len(used), strings.Join(used, "; "))
+	result.Recommendation = "Find the clients in the requestsLastHour of each APIRequestCount, move them to the " +
+		"replacement APIs, and acknowledge the removal in the admin-acks config map once none remain."
+	return result, nil
+}
+
+// operatorUpgradeableResult reports cluster operators that don't allow a minor
+// update and OLM operators whose maximum OpenShift version is below the target
+func operatorUpgradeableResult(ctx context.Context, clients *live.Clients, current, target *version.Version) (Result, error) {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "Operator Upgradeability",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	operators, err := clients.Config.ConfigV1().ClusterOperators().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return Result{}, fmt.Errorf("error listing cluster operators: %w", err)
+	}
+	var blocked []string
+	for _, operator := range operators.Items {
+		for _, condition := range operator.Status.Conditions {
+			if condition.Type == configv1.OperatorUpgradeable && condition.Status == configv1.ConditionFalse {
+				blocked = append(blocked, fmt.Sprintf("%s (%s)", operator.Name, condition.Message))
+			}
+		}
+	}
+
+	csvs, err := clients.Dynamic.Resource(clusterServiceVersions).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return Result{}, fmt.Errorf("error listing cluster service versions: %w", err)
+	}
+	if err == nil {
+		blocked = append(blocked, unsupportedOperators(csvs.Items, target)...)
+	}
+	sort.Strings(blocked)
+
+	switch {
+	case len(blocked) == 0:
+		result.Observation = fmt.Sprintf("All operators allow the update to %d.%d", target.Major(), target.Minor())
+	case target.Minor() == current.Minor():
+		// Upgradeable=False only blocks minor updates
+		result.Status = types.ResultKeyAdvisory
+		result.Observation = fmt.Sprintf("Operators would block a minor update: %s", strings.Join(blocked, "; "))
+		result.Recommendation = "Resolve the conditions before planning the next minor update."
+	default:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("%d operators block the update: %s", len(blocked), strings.Join(blocked, "; "))
+		result.Recommendation = "Resolve the Upgradeable conditions of the cluster operators and update the OLM " +
+			"operators to versions that support the target release before updating the cluster."
+	}
+	return result, nil
+}
+
+// unsupportedOperators lists the OLM operators whose olm.maxOpenShiftVersion
+// property is below the target; copies of cluster-wide operators are skipped
+func unsupportedOperators(csvs []unstructured.Unstructured, target *version.Version) []string {
+	seen := make(map[string]bool)
+	var unsupported []string
+	for _, csv := range csvs {
+		if _, copied := csv.GetLabels()["olm.copiedFrom"]; copied || seen[csv.GetName()] {
+			continue
+		}
+		seen[csv.GetName()] = true
+
+		var properties []struct {
+			Type  string          `json:"type"`
+			Value json.RawMessage `json:"value"`
+		}
+		if err := json.Unmarshal([]byte(csv.GetAnnotations()["olm.properties"]), &properties); err != nil {
+			continue
+		}
+		for _, property := range properties {
+			if property.Type != "olm.maxOpenShiftVersion" {
+				continue
+			}
+			// The value is written both as a string and as a number
+			value := strings.Trim(string(property.Value), `"`)
+			maximum, err := version.ParseGeneric(value)
+			if err == nil && (maximum.Major() < target.Major() || maximum.Major() == target.Major() && maximum.Minor() < target.Minor()) {
+				unsupported = append(unsupported, fmt.Sprintf("%s (supports up to OpenShift %s)", csv.GetName(), value))
+			}
+		}
+	}
+	return unsupported
+}
+
+// machineConfigPoolResult reports pools that are paused, degraded or haven't
+// finished rolling out their configuration
+func machineConfigPoolResult(pools []mcfgv1.MachineConfigPool) Result {
+	result := Result{
+		Category: CategoryClusterConfig,
+		Item:     "MachineConfigPool Updates",
+		Status:   types.ResultKeyNoChange,
+	}
+
+	var pending, paused []string
+	for _, pool := range pools {
+		degraded, updating := false, false
+		for _, condition := range pool.Status.Conditions {
+			switch {
+			case condition.Type == mcfgv1.MachineConfigPoolDegraded && condition.Status == corev1.ConditionTrue:
+				degraded = true
+			case condition.Type == mcfgv1.MachineConfigPoolUpdating && condition.Status == corev1.ConditionTrue:
+				updating = true
+			}
+		}
+		switch {
+		case degraded:
+			pending = append(pending, fmt.Sprintf("%s (degraded, %d of %d machines degraded)", pool.Name, pool.Status.DegradedMachineCount, pool.Status.MachineCount))
+		case updating || pool.Status.UpdatedMachineCount < pool.Status.MachineCount:
+			pending = append(pending, fmt.Sprintf("%s (%d of %d machines updated)", pool.Name, pool.Status.UpdatedMachineCount, pool.Status.MachineCount))
+		}
+		if pool.Spec.Paused {
+			paused = append(paused, pool.Name)
+		}
+	}
+	sort.Strings(pending)
+	sort.Strings(paused)
+
+	switch {
+	case len(pending) > 0:
+		result.Status = types.ResultKeyRequired
+		result.Observation = fmt.Sprintf("MachineConfigPools haven't finished their last update: %s", strings.Join(pending, ", "))
+		result.Recommendation = "Let the pools finish updating and fix degraded nodes with `oc describe mcp` before " +
+			"starting a cluster update."
+	case len(paused) > 0:
+		result.Status = types.ResultKeyRecommended
+		result.Observation = fmt.Sprintf("MachineConfigPools are paused and won't update their nodes: %s", strings.Join(paused, ", "))
+		result.Recommendation = "Unpause the pools during the maintenance window; nodes of a paused pool stay on the old " +
+			"release, and a later minor update needs them current."
+	default:
+		result.Observation = fmt.Sprintf("All %d MachineConfigPools are up to date and not paused", len(pools))
+	}
+	return result
+}
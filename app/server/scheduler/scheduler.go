@@ -0,0 +1,360 @@
+// app/server/scheduler/scheduler.go
+package scheduler
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v2"
+)
+
+// ErrNotFound is returned when a schedule does not exist
+var ErrNotFound = errors.New("schedule not found")
+
+// ErrReadOnly is returned when modifying a schedule not created through the API
+var ErrReadOnly = errors.New("schedule is defined in the config file or a HealthDashboard resource")
+
+// Schedule runs a live scan of a cluster on a cron expression
+type Schedule struct {
+	ID      string `json:"id" yaml:"id"`
+	Cluster string `json:"cluster" yaml:"cluster"`
+	Cron    string `json:"cron" yaml:"cron"`
+	Enabled bool   `json:"enabled" yaml:"enabled"`
+
+	// Source is "file" for schedules from the config file, "operator" for
+	// schedules declared in a HealthDashboard resource and "api" otherwise
+	Source string `json:"source" yaml:"-"`
+
+	LastRun      *time.Time `json:"lastRun,omitempty" yaml:"-"`
+	NextRun      *time.Time `json:"nextRun,omitempty" yaml:"-"`
+	LastReportID string     `json:"lastReportId,omitempty" yaml:"-"`
+	LastError    string     `json:"lastError,omitempty" yaml:"-"`
+}
+
+// RunFunc performs a scan of the named cluster and returns the stored report ID
+type RunFunc func(ctx context.Context, cluster string) (string, error)
+
+// Scheduler runs schedules and persists the ones created through the API
+type Scheduler struct {
+	mu        sync.Mutex
+	cron      *cron.Cron
+	run       RunFunc
+	statePath string
+	schedules map[string]*Schedule
+	entries   map[string]cron.EntryID
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+// parser accepts standard five-field expressions and descriptors like @daily
+var parser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// New creates a scheduler; configFile (YAML, optional) holds read-only schedules and
+// statePath holds schedules created through the API
+func New(run RunFunc, configFile, statePath string) (*Scheduler, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Scheduler{
+		cron:      cron.New(cron.WithParser(parser)),
+		run:       run,
+		statePath: statePath,
+		schedules: make(map[string]*Schedule),
+		entries:   make(map[string]cron.EntryID),
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+
+	if configFile != "" {
+		if err := s.loadFile(configFile, "file", yaml.Unmarshal); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.loadFile(statePath, "api", json.Unmarshal); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// loadFile adds the schedules from a YAML or JSON list
+func (s *Scheduler) loadFile(path, source string, unmarshal func([]byte, interface{}) error) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading schedules: %w", err)
+	}
+
+	var schedules []*Schedule
+	if err := unmarshal(data, &schedules); err != nil {
+		return fmt.Errorf("error parsing schedules in %s: %w", path, err)
+	}
+
+	for _, schedule := range schedules {
+		schedule.Source = source
+		if schedule.ID == "" {
+			schedule.ID = newScheduleID()
+		}
+		if err := s.addLocked(schedule); err != nil {
+			return fmt.Errorf("invalid schedule %s in %s: %w", schedule.ID, path, err)
+		}
+	}
+
+	log.Printf("Loaded %d schedules from %s", len(schedules), path)
+	return nil
+}
+
+// Start begins running schedules
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop stops the scheduler and waits for running scans to finish
+func (s *Scheduler) Stop() {
+	s.cancel()
+	<-s.cron.Stop().Done()
+}
+
+// List returns all schedules ordered by cluster
+func (s *Scheduler) List() []Schedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules := make([]Schedule, 0, len(s.schedules))
+	for id := range s.schedules {
+		schedules = append(schedules, s.snapshotLocked(id))
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		if schedules[i].Cluster != schedules[j].Cluster {
+			return schedules[i].Cluster < schedules[j].Cluster
+		}
+		return schedules[i].ID < schedules[j].ID
+	})
+	return schedules
+}
+
+// Create adds a new schedule and persists it
+func (s *Scheduler) Create(schedule Schedule) (Schedule, error) {
+	if schedule.Cluster == "" {
+		return Schedule{}, errors.New("cluster is required")
+	}
+
+	schedule.ID = newScheduleID()
+	schedule.Source = "api"
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.addLocked(&schedule); err != nil {
+		return Schedule{}, err
+	}
+	if err := s.saveLocked(); err != nil {
+		s.removeLocked(schedule.ID)
+		return Schedule{}, err
+	}
+
+	return s.snapshotLocked(schedule.ID), nil
+}
+
+// Delete removes a schedule created through the API
+func (s *Scheduler) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule, ok := s.schedules[id]
+	if !ok {
+		return ErrNotFound
+	}
+	if schedule.Source != "api" {
+		return ErrReadOnly
+	}
+
+	s.removeLocked(id)
+	return s.saveLocked()
+}
+
+// Sync replaces the schedules of a source other than the API with the given
+// ones, keeping the run history of schedules whose ID and definition are
+// unchanged. Nothing is replaced when a schedule is invalid.
+func (s *Scheduler) Sync(source string, schedules []Schedule) error {
+	for _, schedule := range schedules {
+		if schedule.ID == "" || schedule.Cluster == "" {
+			return errors.New("schedules need an ID and a cluster")
+		}
+		if _, err := parser.Parse(schedule.Cron); err != nil {
+			return fmt.Errorf("invalid cron expression %q in schedule %s: %w", schedule.Cron, schedule.ID, err)
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, schedule := range schedules {
+		if existing, ok := s.schedules[schedule.ID]; ok && existing.Source != source {
+			return fmt.Errorf("schedule ID %s is already used", schedule.ID)
+		}
+	}
+
+	wanted := make(map[string]bool, len(schedules))
+	for _, schedule := range schedules {
+		wanted[schedule.ID] = true
+		if existing, ok := s.schedules[schedule.ID]; ok {
+			if existing.Cluster == schedule.Cluster && existing.Cron == schedule.Cron && existing.Enabled == schedule.Enabled {
+				continue
+			}
+			s.removeLocked(schedule.ID)
+		}
+		schedule.Source = source
+		if err := s.addLocked(&schedule); err != nil {
+			return err
+		}
+	}
+
+	for id, schedule := range s.schedules {
+		if schedule.Source == source && !wanted[id] {
+			s.removeLocked(id)
+		}
+	}
+	return nil
+}
+
+// Replace replaces the schedules created through the API with the given ones
+// and persists them, generating missing IDs and keeping the run history of
+// unchanged schedules. Nothing is replaced when a schedule is invalid.
+func (s *Scheduler) Replace(schedules []Schedule) error {
+	schedules = append([]Schedule{}, schedules...)
+	seen := make(map[string]bool, len(schedules))
+	for i := range schedules {
+		if err := Validate(schedules[i]); err != nil {
+			return err
+		}
+		if schedules[i].ID == "" {
+			schedules[i].ID = newScheduleID()
+		}
+		if seen[schedules[i].ID] {
+			return fmt.Errorf("schedule %s is listed twice", schedules[i].ID)
+		}
+		seen[schedules[i].ID] = true
+	}
+	if err := s.Sync("api", schedules); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.saveLocked()
+}
+
+// Validate checks the cluster and the cron expression of a schedule
+func Validate(schedule Schedule) error {
+	if schedule.Cluster == "" {
+		return errors.New("cluster is required")
+	}
+	if _, err := parser.Parse(schedule.Cron); err != nil {
+		return fmt.Errorf("invalid cron expression %q: %w", schedule.Cron, err)
+	}
+	return nil
+}
+
+// addLocked validates a schedule and registers it with cron; s.mu must be held
+// unless the scheduler has not been started
+func (s *Scheduler) addLocked(schedule *Schedule) error {
+	if _, err := parser.Parse(schedule.Cron); err != nil {
+		return fmt.Errorf("invalid cron expression %q: %w", schedule.Cron, err)
+	}
+
+	s.schedules[schedule.ID] = schedule
+	if !schedule.Enabled {
+		return nil
+	}
+
+	id := schedule.ID
+	entryID, err := s.cron.AddFunc(schedule.Cron, func() { s.execute(id) })
+	if err != nil {
+		delete(s.schedules, id)
+		return err
+	}
+	s.entries[id] = entryID
+	return nil
+}
+
+// snapshotLocked copies a schedule and fills in its next run; s.mu must be held
+func (s *Scheduler) snapshotLocked(id string) Schedule {
+	snapshot := *s.schedules[id]
+	if entryID, ok := s.entries[id]; ok {
+		if next := s.cron.Entry(entryID).Next; !next.IsZero() {
+			snapshot.NextRun = &next
+		}
+	}
+	return snapshot
+}
+
+// removeLocked unregisters a schedule; s.mu must be held
+func (s *Scheduler) removeLocked(id string) {
+	if entryID, ok := s.entries[id]; ok {
+		s.cron.Remove(entryID)
+		delete(s.entries, id)
+	}
+	delete(s.schedules, id)
+}
+
+// saveLocked writes the API-managed schedules to the state file; s.mu must be held
+func (s *Scheduler) saveLocked() error {
+	schedules := []*Schedule{}
+	for _, schedule := range s.schedules {
+		if schedule.Source == "api" {
+			schedules = append(schedules, schedule)
+		}
+	}
+
+	data, err := json.MarshalIndent(schedules, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding schedules: %w", err)
+	}
+	if err := os.WriteFile(s.statePath, data, 0o644); err != nil {
+		return fmt.Errorf("error writing schedules: %w", err)
+	}
+	return nil
+}
+
+// execute runs a scheduled scan and records its outcome
+func (s *Scheduler) execute(id string) {
+	s.mu.Lock()
+	schedule, ok := s.schedules[id]
+	if !ok {
+		s.mu.Unlock()
+		return
+	}
+	cluster := schedule.Cluster
+	s.mu.Unlock()
+
+	log.Printf("Running scheduled scan %s for cluster %s", id, cluster)
+	reportID, err := s.run(s.ctx, cluster)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	schedule.LastRun = &now
+	schedule.LastReportID = reportID
+	schedule.LastError = ""
+	if err != nil {
+		schedule.LastError = err.Error()
+		log.Printf("Scheduled scan %s for cluster %s failed: %v", id, cluster, err)
+	}
+}
+
+// newScheduleID generates a random schedule ID
+func newScheduleID() string {
+	buf := make([]byte, 6)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
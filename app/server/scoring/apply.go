@@ -0,0 +1,88 @@
+// app/server/scoring/apply.go
+package scoring
+
+import (
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// Apply re-scores a summary from its findings with the given profile and
+// records the profile name. The default profile leaves the parser's scores
+// untouched, as do summaries stored before findings were extracted; Apply
+// reports whether the scores were recomputed.
+//
+// Category score: the integer part of the mean item weight of the category,
+// counting only required, recommended, advisory and no-change items.
+//
+// Overall score: the mean item weight over all scored items, or, when the
+// profile has category weights, the weighted mean of the category scores of
+// the categories that have scored items.
+func Apply(summary *types.ReportSummary, profile Profile) bool {
+	summary.ScoringProfile = profile.Name
+	if profile.IsDefault() {
+		return false
+	}
+	return Recompute(summary, profile)
+}
+
+// Recompute scores a summary from its findings like Apply, with the default
+// profile too, for summaries whose findings changed since they were parsed.
+// Summaries without findings are left untouched; Recompute reports whether
+// the scores were recomputed.
+func Recompute(summary *types.ReportSummary, profile Profile) bool {
+	if len(summary.Findings) == 0 {
+		return false
+	}
+
+	points := make(map[string]float64)
+	counts := make(map[string]int)
+	var totalPoints float64
+	var totalCount int
+
+	for _, finding := range summary.Findings {
+		switch finding.Status {
+		case types.ResultKeyRequired, types.ResultKeyRecommended, types.ResultKeyAdvisory, types.ResultKeyNoChange:
+		default:
+			continue
+		}
+
+		weight := profile.Weight(finding.Status)
+		points[finding.Category] += weight
+		counts[finding.Category]++
+		totalPoints += weight
+		totalCount++
+	}
+
+	categoryScore := func(category string) int {
+		if counts[category] == 0 {
+			return 0
+		}
+		return int(points[category] / float64(counts[category]))
+	}
+
+	summary.ScoreInfra = categoryScore(CategoryClusterConfig)
+	summary.ScoreGovernance = categoryScore(CategorySecurity)
+	summary.ScoreCompliance = categoryScore(CategoryPerformance)
+	summary.ScoreMonitoring = categoryScore(CategoryOpReady)
+	summary.ScoreBuildSecurity = categoryScore(CategoryApplications)
+
+	summary.OverallScore = 0
+	if len(profile.CategoryWeights) == 0 {
+		if totalCount > 0 {
+			summary.OverallScore = totalPoints / float64(totalCount)
+		}
+		return true
+	}
+
+	var weighted, weights float64
+	for category, weight := range profile.CategoryWeights {
+		if counts[category] == 0 {
+			continue
+		}
+		weighted += weight * float64(categoryScore(category))
+		weights += weight
+	}
+	if weights > 0 {
+		summary.OverallScore = weighted / weights
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+// app/server/scoring/categories.go
+package scoring
+
+import (
+	"sort"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// standardCategory ties a template category to its legacy summary fields
+type standardCategory struct {
+	name        string
+	label       string
+	field       string
+	score       func(summary *types.ReportSummary) int
+	description func(summary *types.ReportSummary) string
+}
+
+// standardCategories are the template categories, in display order
+var standardCategories = []standardCategory{
+	{CategoryClusterConfig, "Infrastructure Setup", utils.FieldScoreInfra,
+		func(s *types.ReportSummary) int { return s.ScoreInfra },
+		func(s *types.ReportSummary) string { return s.InfraDescription }},
+	{CategorySecurity, "Policy Governance", utils.FieldScoreGovernance,
+		func(s *types.ReportSummary) int { return s.ScoreGovernance },
+		func(s *types.ReportSummary) string { return s.GovernanceDescription }},
+	{CategoryPerformance, "Compliance Benchmarking", utils.FieldScoreCompliance,
+		func(s *types.ReportSummary) int { return s.ScoreCompliance },
+		func(s *types.ReportSummary) string { return s.ComplianceDescription }},
+	{CategoryOpReady, "Central Monitoring", utils.FieldScoreMonitoring,
+		func(s *types.ReportSummary) int { return s.ScoreMonitoring },
+		func(s *types.ReportSummary) string { return s.MonitoringDescription }},
+	{CategoryApplications, "Build/Deploy Security", utils.FieldScoreBuildSecurity,
+		func(s *types.ReportSummary) int { return s.ScoreBuildSecurity },
+		func(s *types.ReportSummary) string { return s.BuildSecurityDescription }},
+}
+
+// Categorize lists the categories of a summary in its Categories field. The
+// standard categories take their score and description from the legacy
+// fields, so they match whatever produced those; every other category found
+// in the findings is scored from its findings with the profile and gets a
+// generated description. Uncategorized findings are left out.
+func Categorize(summary *types.ReportSummary, profile Profile) {
+	counted := make(map[string]*types.CategoryScore)
+	var custom []string
+	for _, finding := range summary.Findings {
+		if finding.Category == "" {
+			continue
+		}
+		category, ok := counted[finding.Category]
+		if !ok {
+			category = &types.CategoryScore{Name: finding.Category, Label: finding.Category}
+			counted[finding.Category] = category
+			if !isStandard(finding.Category) {
+				custom = append(custom, finding.Category)
+			}
+		}
+
+		switch finding.Status {
+		case types.ResultKeyRequired:
+			category.Required++
+		case types.ResultKeyRecommended:
+			category.Recommended++
+		case types.ResultKeyAdvisory:
+			category.Advisory++
+		case types.ResultKeyNoChange:
+			category.NoChange++
+		case types.ResultKeyNotApplicable:
+			category.NotApplicable++
+		}
+	}
+
+	categories := make([]types.CategoryScore, 0, len(standardCategories)+len(custom))
+	for _, standard := range standardCategories {
+		category := types.CategoryScore{Name: standard.name}
+		if found, ok := counted[standard.name]; ok {
+			category = *found
+		}
+		category.Label = standard.label
+		category.Field = standard.field
+		category.Score = standard.score(summary)
+		category.Description = standard.description(summary)
+		categories = append(categories, category)
+	}
+
+	sort.Strings(custom)
+	for _, name := range custom {
+		category := *counted[name]
+		category.Score = categoryScore(category, profile)
+		category.Description = utils.GenerateDescriptionIn(summary.Language, name, category.Score)
+		categories = append(categories, category)
+	}
+	summary.Categories = categories
+}
+
+// categoryScore is the integer part of the mean item weight of a category,
+// like the standard category scores of Recompute
+func categoryScore(category types.CategoryScore, profile Profile) int {
+	count := category.Required + category.Recommended + category.Advisory + category.NoChange
+	if count == 0 {
+		return 0
+	}
+	points := float64(category.Required)*profile.Weight(types.ResultKeyRequired) +
+		float64(category.Recommended)*profile.Weight(types.ResultKeyRecommended) +
+		float64(category.Advisory)*profile.Weight(types.ResultKeyAdvisory) +
+		float64(category.NoChange)*profile.Weight(types.ResultKeyNoChange)
+	return int(points / float64(count))
+}
+
+// isStandard reports whether a category is one of the template categories
+func isStandard(name string) bool {
+	for _, standard := range standardCategories {
+		if standard.name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,120 @@
+// app/server/scoring/grades.go
+package scoring
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// colorPattern matches the "#RRGGBB" colors bands are drawn in
+var colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// Band is a grade given to the scores from Min up to the Min of the next band
+type Band struct {
+	Grade string  `json:"grade" yaml:"grade"`
+	Min   float64 `json:"min" yaml:"min"`
+
+	// Color is the "#RRGGBB" color the grade is drawn in; optional
+	Color string `json:"color,omitempty" yaml:"color,omitempty"`
+}
+
+// Grades are the bands scores are graded with: letter grades and a
+// red/amber/green status, so reports read like internal scorecards
+type Grades struct {
+	Letters []Band `json:"letters"`
+	RAG     []Band `json:"rag"`
+}
+
+// DefaultGrades returns the A-F letter grades of the status page and the
+// red/amber/green status of the Confluence page
+func DefaultGrades() Grades {
+	return Grades{
+		Letters: []Band{
+			{Grade: "A", Min: 90, Color: "#3e8635"},
+			{Grade: "B", Min: 80, Color: "#5ba352"},
+			{Grade: "C", Min: 70, Color: "#f0ab00"},
+			{Grade: "D", Min: 60, Color: "#ec7a08"},
+			{Grade: "F", Min: 0, Color: "#c9190b"},
+		},
+		RAG: []Band{
+			{Grade: "Green", Min: 80, Color: "#3e8635"},
+			{Grade: "Amber", Min: 60, Color: "#f0ab00"},
+			{Grade: "Red", Min: 0, Color: "#c9190b"},
+		},
+	}
+}
+
+// Validate checks that both sets of bands grade every score from 0 to 100
+func (g Grades) Validate() error {
+	if err := validateBands("letter", g.Letters); err != nil {
+		return err
+	}
+	return validateBands("RAG", g.RAG)
+}
+
+// validateBands checks a set of bands: named, distinct, within 0-100 and
+// with one starting at 0
+func validateBands(kind string, bands []Band) error {
+	if len(bands) == 0 {
+		return fmt.Errorf("%s grades need at least one band", kind)
+	}
+	grades := make(map[string]bool)
+	mins := make(map[float64]bool)
+	for _, band := range bands {
+		switch {
+		case band.Grade == "":
+			return fmt.Errorf("%s grade bands must have a grade", kind)
+		case grades[band.Grade]:
+			return fmt.Errorf("%s grade %q is defined twice", kind, band.Grade)
+		case band.Min < 0 || band.Min > 100:
+			return fmt.Errorf("minimum %g of %s grade %q must be between 0 and 100", band.Min, kind, band.Grade)
+		case mins[band.Min]:
+			return fmt.Errorf("two %s grades start at %g", kind, band.Min)
+		case band.Color != "" && !colorPattern.MatchString(band.Color):
+			return fmt.Errorf("color %q of %s grade %q must be #RRGGBB", band.Color, kind, band.Grade)
+		}
+		grades[band.Grade] = true
+		mins[band.Min] = true
+	}
+	if !mins[0] {
+		return errors.New(kind + " grades need a band starting at 0")
+	}
+	return nil
+}
+
+// Letter returns the letter grade of a score
+func (g Grades) Letter(score float64) Band {
+	return bandOf(g.Letters, score)
+}
+
+// Status returns the red/amber/green status of a score
+func (g Grades) Status(score float64) Band {
+	return bandOf(g.RAG, score)
+}
+
+// bandOf returns the band with the highest minimum the score reaches
+func bandOf(bands []Band, score float64) Band {
+	var found Band
+	ok := false
+	for _, band := range bands {
+		if score >= band.Min && (!ok || band.Min > found.Min) {
+			found, ok = band, true
+		}
+	}
+	return found
+}
+
+// Grade sets the letter grade and status of the overall score and of every
+// category of a summary
+func Grade(summary *types.ReportSummary, grades Grades) {
+	summary.Grade = grades.Letter(summary.OverallScore).Grade
+	summary.RAG = grades.Status(summary.OverallScore).Grade
+	for i := range summary.Categories {
+		score := float64(summary.Categories[i].Score)
+		summary.Categories[i].Grade = grades.Letter(score).Grade
+		summary.Categories[i].RAG = grades.Status(score).Grade
+	}
+}
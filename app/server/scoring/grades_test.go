@@ -0,0 +1,79 @@
+// app/server/scoring/grades_test.go
+package scoring
+
+import (
+	"testing"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+func TestDefaultGrades(t *testing.T) {
+	grades := DefaultGrades()
+	if err := grades.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		score  float64
+		letter string
+		rag    string
+	}{
+		{100, "A", "Green"},
+		{90, "A", "Green"},
+		{89.9, "B", "Green"},
+		{80, "B", "Green"},
+		{79.9, "C", "Amber"},
+		{60, "D", "Amber"},
+		{59.9, "F", "Red"},
+		{0, "F", "Red"},
+	}
+	for _, test := range tests {
+		if got := grades.Letter(test.score).Grade; got != test.letter {
+			t.Errorf("Letter(%g) = %s, want %s", test.score, got, test.letter)
+		}
+		if got := grades.Status(test.score).Grade; got != test.rag {
+			t.Errorf("Status(%g) = %s, want %s", test.score, got, test.rag)
+		}
+	}
+}
+
+func TestGradesValidate(t *testing.T) {
+	rag := DefaultGrades().RAG
+	tests := []struct {
+		name    string
+		letters []Band
+	}{
+		{"empty", nil},
+		{"unnamed", []Band{{Min: 0}}},
+		{"duplicate grade", []Band{{Grade: "Pass", Min: 50}, {Grade: "Pass", Min: 0}}},
+		{"duplicate minimum", []Band{{Grade: "Pass", Min: 0}, {Grade: "Fail", Min: 0}}},
+		{"out of range", []Band{{Grade: "Pass", Min: 101}, {Grade: "Fail", Min: 0}}},
+		{"no band at 0", []Band{{Grade: "Pass", Min: 50}}},
+		{"bad color", []Band{{Grade: "Pass", Min: 0, Color: "green"}}},
+	}
+	for _, test := range tests {
+		if err := (Grades{Letters: test.letters, RAG: rag}).Validate(); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestGrade(t *testing.T) {
+	// Bands are matched by minimum, not by their order in the settings
+	grades := Grades{
+		Letters: []Band{{Grade: "Fail", Min: 0}, {Grade: "Pass", Min: 75}},
+		RAG:     []Band{{Grade: "Red", Min: 0}, {Grade: "Green", Min: 50}},
+	}
+	summary := &types.ReportSummary{
+		OverallScore: 76,
+		Categories:   []types.CategoryScore{{Name: CategorySecurity, Score: 40}},
+	}
+	Grade(summary, grades)
+
+	if summary.Grade != "Pass" || summary.RAG != "Green" {
+		t.Errorf("overall graded %s/%s, want Pass/Green", summary.Grade, summary.RAG)
+	}
+	if category := summary.Categories[0]; category.Grade != "Fail" || category.RAG != "Red" {
+		t.Errorf("category graded %s/%s, want Fail/Red", category.Grade, category.RAG)
+	}
+}
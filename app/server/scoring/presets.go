@@ -0,0 +1,82 @@
+// app/server/scoring/presets.go
+package scoring
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Names of the presets shipped with the dashboard
+const (
+	PresetStrictProduction = "strict-production"
+	PresetLenientSandbox   = "lenient-sandbox"
+	PresetSecurityFocused  = "security-focused"
+)
+
+// presets holds the built-in profiles by name
+var presets = map[string]Profile{
+	DefaultProfileName: Default(),
+
+	// strict-production penalizes anything short of "no change" more heavily,
+	// for clusters where open recommendations are a real risk
+	PresetStrictProduction: {
+		Name: PresetStrictProduction,
+		Weights: Weights{
+			NoChange:    100,
+			Advisory:    70,
+			Recommended: 30,
+			Required:    0,
+		},
+	},
+
+	// lenient-sandbox still counts required changes against the score, but
+	// gives partial credit so sandboxes aren't graded like production
+	PresetLenientSandbox: {
+		Name: PresetLenientSandbox,
+		Weights: Weights{
+			NoChange:    100,
+			Advisory:    95,
+			Recommended: 75,
+			Required:    40,
+		},
+	},
+
+	// security-focused uses the default item weights but makes the Security
+	// category count three times and Cluster Config one and a half times as
+	// much as the others in the overall score
+	PresetSecurityFocused: {
+		Name: PresetSecurityFocused,
+		Weights: Weights{
+			NoChange:    100,
+			Advisory:    80,
+			Recommended: 40,
+			Required:    0,
+		},
+		CategoryWeights: map[string]float64{
+			CategorySecurity:      3,
+			CategoryClusterConfig: 1.5,
+			CategoryPerformance:   1,
+			CategoryOpReady:       1,
+			CategoryApplications:  1,
+		},
+	},
+}
+
+// Preset returns a built-in profile by name
+func Preset(name string) (Profile, error) {
+	profile, ok := presets[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown scoring preset %q", name)
+	}
+	return profile.clone(), nil
+}
+
+// Presets returns all built-in profiles ordered by name
+func Presets() []Profile {
+	profiles := make([]Profile, 0, len(presets))
+	for _, profile := range presets {
+		profiles = append(profiles, profile.clone())
+	}
+	sort.Slice(profiles, func(i, j int) bool { return profiles[i].Name < profiles[j].Name })
+	return profiles
+}
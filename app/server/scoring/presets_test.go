@@ -0,0 +1,123 @@
+// app/server/scoring/presets_test.go
+package scoring
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// sampleSummary has one item of every scored status in Cluster Config, a
+// required and a no-change item in Security, and a not applicable item that
+// must be ignored:
+//
+//	Cluster Config: nochange, advisory, recommended, required
+//	Security:       required, nochange
+//	Performance:    na
+func sampleSummary() *types.ReportSummary {
+	finding := func(category string, status types.ResultKey) types.Finding {
+		return types.Finding{Category: category, Status: status}
+	}
+	return &types.ReportSummary{
+		OverallScore: 1,
+		Findings: []types.Finding{
+			finding(CategoryClusterConfig, types.ResultKeyNoChange),
+			finding(CategoryClusterConfig, types.ResultKeyAdvisory),
+			finding(CategoryClusterConfig, types.ResultKeyRecommended),
+			finding(CategoryClusterConfig, types.ResultKeyRequired),
+			finding(CategorySecurity, types.ResultKeyRequired),
+			finding(CategorySecurity, types.ResultKeyNoChange),
+			finding(CategoryPerformance, types.ResultKeyNotApplicable),
+		},
+	}
+}
+
+func TestPresetScores(t *testing.T) {
+	tests := []struct {
+		preset     string
+		overall    float64
+		infra      int
+		governance int
+	}{
+		// (100+70+30+0)/4 = 50 and (0+100)/2 = 50; overall (200+100)/6 = 50
+		{PresetStrictProduction, 50, 50, 50},
+
+		// (100+95+75+40)/4 = 77.5 -> 77 and (40+100)/2 = 70; overall (310+140)/6 = 75
+		{PresetLenientSandbox, 75, 77, 70},
+
+		// (100+80+40+0)/4 = 55 and (0+100)/2 = 50; overall weighted by
+		// category: (1.5*55 + 3*50) / (1.5+3) = 51.67
+		{PresetSecurityFocused, 232.5 / 4.5, 55, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.preset, func(t *testing.T) {
+			profile, err := Preset(tt.preset)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			summary := sampleSummary()
+			if !Apply(summary, profile) {
+				t.Fatal("expected scores to be recomputed")
+			}
+
+			if math.Abs(summary.OverallScore-tt.overall) > 1e-9 {
+				t.Errorf("overall score = %v, want %v", summary.OverallScore, tt.overall)
+			}
+			if summary.ScoreInfra != tt.infra {
+				t.Errorf("infra score = %d, want %d", summary.ScoreInfra, tt.infra)
+			}
+			if summary.ScoreGovernance != tt.governance {
+				t.Errorf("governance score = %d, want %d", summary.ScoreGovernance, tt.governance)
+			}
+			if summary.ScoreCompliance != 0 {
+				t.Errorf("compliance score = %d, want 0 for a category with only N/A items", summary.ScoreCompliance)
+			}
+			if summary.ScoringProfile != tt.preset {
+				t.Errorf("scoring profile = %q, want %q", summary.ScoringProfile, tt.preset)
+			}
+		})
+	}
+}
+
+func TestDefaultPresetKeepsParserScores(t *testing.T) {
+	summary := sampleSummary()
+	if Apply(summary, Default()) {
+		t.Fatal("default profile should not recompute scores")
+	}
+	if summary.OverallScore != 1 {
+		t.Errorf("overall score changed to %v", summary.OverallScore)
+	}
+	if summary.ScoringProfile != DefaultProfileName {
+		t.Errorf("scoring profile = %q, want %q", summary.ScoringProfile, DefaultProfileName)
+	}
+}
+
+func TestApplyWithoutFindings(t *testing.T) {
+	profile, _ := Preset(PresetStrictProduction)
+	summary := &types.ReportSummary{OverallScore: 42}
+	if Apply(summary, profile) {
+		t.Fatal("summaries without findings can't be re-scored")
+	}
+	if summary.OverallScore != 42 {
+		t.Errorf("overall score changed to %v", summary.OverallScore)
+	}
+}
+
+func TestPresetIsACopy(t *testing.T) {
+	profile, _ := Preset(PresetSecurityFocused)
+	profile.CategoryWeights[CategorySecurity] = 100
+
+	again, _ := Preset(PresetSecurityFocused)
+	if again.CategoryWeights[CategorySecurity] != 3 {
+		t.Error("modifying a returned preset changed the built-in preset")
+	}
+}
+
+func TestUnknownPreset(t *testing.T) {
+	if _, err := Preset("does-not-exist"); err == nil {
+		t.Error("expected an error for an unknown preset")
+	}
+}
@@ -0,0 +1,111 @@
+// app/server/scoring/priorities.go
+package scoring
+
+import (
+	"sort"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// Sources of the impact and effort of a priority
+const (
+	// SourceReport is used for levels read from the columns of the summary table
+	SourceReport = "report"
+
+	// SourceKnowledge is used for efforts estimated by the knowledge base
+	SourceKnowledge = "knowledge"
+
+	// SourceSeverity is used for impacts implied by the status of a finding
+	SourceSeverity = "severity"
+
+	// SourceDefault is used for efforts nothing estimated, which count as medium
+	SourceDefault = "default"
+)
+
+// levelRank orders the impact and effort levels from low to high
+var levelRank = map[string]int{
+	utils.LevelLow:    1,
+	utils.LevelMedium: 2,
+	utils.LevelHigh:   3,
+}
+
+// Priority is an actionable finding ranked for remediation
+type Priority struct {
+	Rank    int           `json:"rank"`
+	Finding types.Finding `json:"finding"`
+	Impact  string        `json:"impact"`
+	Effort  string        `json:"effort"`
+
+	// ImpactSource and EffortSource tell where the levels come from
+	ImpactSource string `json:"impactSource"`
+	EffortSource string `json:"effortSource"`
+}
+
+// Prioritize ranks the required, recommended and advisory findings for
+// remediation: high impact before low impact, and at the same impact low
+// effort before high effort, so quick wins come first. The impact and effort
+// columns of the report take precedence; otherwise the impact follows the
+// severity and the effort comes from the knowledge base guidance, or counts as
+// medium. Findings that rank the same keep the order of the report.
+func Prioritize(findings []types.Finding) []Priority {
+	priorities := make([]Priority, 0, len(findings))
+	for _, finding := range findings {
+		if !isActionable(finding.Status) {
+			continue
+		}
+		priority := Priority{Finding: finding, Impact: finding.Impact, Effort: finding.Effort,
+			ImpactSource: SourceReport, EffortSource: SourceReport}
+
+		if priority.Impact == "" {
+			priority.Impact = impactForSeverity(finding.Severity)
+			priority.ImpactSource = SourceSeverity
+		}
+		if priority.Effort == "" {
+			priority.Effort, priority.EffortSource = utils.LevelMedium, SourceDefault
+			if finding.Guidance != nil && levelRank[finding.Guidance.Effort] > 0 {
+				priority.Effort, priority.EffortSource = finding.Guidance.Effort, SourceKnowledge
+			}
+		}
+		priorities = append(priorities, priority)
+	}
+
+	sort.SliceStable(priorities, func(i, j int) bool {
+		a, b := priorities[i], priorities[j]
+		if levelRank[a.Impact] != levelRank[b.Impact] {
+			return levelRank[a.Impact] > levelRank[b.Impact]
+		}
+		if levelRank[a.Effort] != levelRank[b.Effort] {
+			return levelRank[a.Effort] < levelRank[b.Effort]
+		}
+		return statusUrgency[a.Finding.Status] > statusUrgency[b.Finding.Status]
+	})
+	for i := range priorities {
+		priorities[i].Rank = i + 1
+	}
+	return priorities
+}
+
+// statusUrgency breaks ties between findings of the same impact and effort
+var statusUrgency = map[types.ResultKey]int{
+	types.ResultKeyAdvisory:    1,
+	types.ResultKeyRecommended: 2,
+	types.ResultKeyRequired:    3,
+}
+
+// impactForSeverity is the impact assumed for a finding without an impact column
+func impactForSeverity(severity types.Severity) string {
+	switch severity {
+	case types.SeverityHigh:
+		return utils.LevelHigh
+	case types.SeverityMedium:
+		return utils.LevelMedium
+	default:
+		return utils.LevelLow
+	}
+}
+
+// isActionable reports whether a finding is one of the summary items to act on
+func isActionable(status types.ResultKey) bool {
+	return statusUrgency[status] > 0
+}
@@ -0,0 +1,102 @@
+// app/server/scoring/profile.go
+package scoring
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// Template categories, as they appear in the category column of a report
+const (
+	CategoryClusterConfig = "Cluster Config"
+	CategorySecurity      = "Security"
+	CategoryPerformance   = "Performance"
+	CategoryOpReady       = "Op-Ready"
+	CategoryApplications  = "Applications"
+)
+
+// Weights are the points an item earns for each status, out of 100. Not
+// applicable items are excluded from scoring entirely.
+type Weights struct {
+	NoChange    float64 `json:"nochange"`
+	Advisory    float64 `json:"advisory"`
+	Recommended float64 `json:"recommended"`
+	Required    float64 `json:"required"`
+}
+
+// Profile is a named set of scoring weights
+type Profile struct {
+	Name    string  `json:"name"`
+	Weights Weights `json:"weights"`
+
+	// CategoryWeights, when set, make the overall score a weighted average of
+	// the category scores instead of an average over all items
+	CategoryWeights map[string]float64 `json:"categoryWeights,omitempty"`
+}
+
+// DefaultProfileName is the profile matching the parser's built-in weights
+const DefaultProfileName = "default"
+
+// Default returns the profile used by the parser when scoring uploaded reports
+func Default() Profile {
+	return Profile{
+		Name: DefaultProfileName,
+		Weights: Weights{
+			NoChange:    100,
+			Advisory:    80,
+			Recommended: 50,
+			Required:    0,
+		},
+	}
+}
+
+// Validate checks that every weight is within 0-100
+func (p Profile) Validate() error {
+	if p.Name == "" {
+		return errors.New("scoring profile name is required")
+	}
+	for _, weight := range []float64{p.Weights.NoChange, p.Weights.Advisory, p.Weights.Recommended, p.Weights.Required} {
+		if weight < 0 || weight > 100 {
+			return errors.New("scoring weights must be between 0 and 100")
+		}
+	}
+	for category, weight := range p.CategoryWeights {
+		if weight < 0 {
+			return fmt.Errorf("category weight for %s must not be negative", category)
+		}
+	}
+	return nil
+}
+
+// IsDefault reports whether the profile scores exactly like the parser
+func (p Profile) IsDefault() bool {
+	return p.Weights == Default().Weights && len(p.CategoryWeights) == 0
+}
+
+// Weight returns the points for an item status
+func (p Profile) Weight(status types.ResultKey) float64 {
+	switch status {
+	case types.ResultKeyNoChange:
+		return p.Weights.NoChange
+	case types.ResultKeyAdvisory:
+		return p.Weights.Advisory
+	case types.ResultKeyRecommended:
+		return p.Weights.Recommended
+	default:
+		return p.Weights.Required
+	}
+}
+
+// clone returns a copy that doesn't share the category weight map
+func (p Profile) clone() Profile {
+	if p.CategoryWeights != nil {
+		weights := make(map[string]float64, len(p.CategoryWeights))
+		for category, weight := range p.CategoryWeights {
+			weights[category] = weight
+		}
+		p.CategoryWeights = weights
+	}
+	return p
+}
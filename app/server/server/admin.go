@@ -0,0 +1,312 @@
+// app/server/server/admin.go
+package server
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/orgs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/version"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/waivers"
+)
+
+// reloadResult describes the outcome of a configuration reload
+type reloadResult struct {
+	Reloaded []string               `json:"reloaded"`
+	Errors   map[string]string      `json:"errors,omitempty"`
+	Settings map[string]interface{} `json:"settings"`
+}
+
+// HandleReload reloads the settings file, the cluster and organization
+// registries, the waivers, the template packs, the branding and the
+// knowledge base
+func (s *Server) HandleReload(w http.ResponseWriter, r *http.Request) {
+	result := s.Reload()
+
+	status := http.StatusOK
+	if len(result.Errors) > 0 {
+		status = http.StatusInternalServerError
+	}
+	writeJSON(w, status, result)
+}
+
+// Reload re-reads every reloadable part of the configuration. Each part is
+// swapped in atomically when it is valid; a part that fails keeps its current
+// value and is reported, so one bad file doesn't block the others.
+func (s *Server) Reload() reloadResult {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	result := reloadResult{Reloaded: []string{}, Errors: make(map[string]string)}
+
+	if _, err := s.settings.Reload(); err != nil {
+		result.Errors["settings"] = err.Error()
+	} else {
+		s.configureNotifier()
+		result.Reloaded = append(result.Reloaded, "settings")
+	}
+
+	if err := s.clusters.Reload(); err != nil {
+		result.Errors["clusters"] = err.Error()
+	} else {
+		result.Reloaded = append(result.Reloaded, "clusters")
+	}
+
+	if err := s.orgs.Reload(); err != nil {
+		result.Errors["orgs"] = err.Error()
+	} else {
+		result.Reloaded = append(result.Reloaded, "orgs")
+	}
+
+	if err := s.waivers.Reload(); err != nil {
+		result.Errors["waivers"] = err.Error()
+	} else {
+		result.Reloaded = append(result.Reloaded, "waivers")
+	}
+
+	if err := s.templates.Reload(s.ctx); err != nil {
+		result.Errors["templates"] = err.Error()
+	} else {
+		result.Reloaded = append(result.Reloaded, "templates")
+	}
+
+	if err := s.branding.Reload(s.ctx); err != nil {
+		result.Errors["branding"] = err.Error()
+	} else {
+		result.Reloaded = append(result.Reloaded, "branding")
+	}
+
+	if err := s.knowledge.Reload(); err != nil {
+		result.Errors["knowledge"] = err.Error()
+	} else {
+		result.Reloaded = append(result.Reloaded, "knowledge")
+	}
+
+	// Each tenant reloads its own settings and registries
+	for name, tenant := range s.tenants {
+		tenantResult := tenant.Reload()
+		for part, err := range tenantResult.Errors {
+			result.Errors["tenants/"+name+"/"+part] = err
+		}
+	}
+
+	for part, err := range result.Errors {
+		log.Printf("Error reloading %s, keeping the current configuration: %s", part, err)
+	}
+	log.Printf("Reloaded configuration: %v", result.Reloaded)
+
+	result.Settings = s.settings.Current().Describe()
+	return result
+}
+
+// buildInfo is the response of the version endpoint; the alias keeps the
+// version package reachable where a parameter named version shadows it
+type buildInfo = version.Info
+
+// HandleVersion returns the version, commit, build date and Go version of the server
+func (s *Server) HandleVersion(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, version.Get())
+}
+
+// HandleListOrgs returns the organizations ordered by name
+func (s *Server) HandleListOrgs(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.orgs.List())
+}
+
+// integrityProblem is an inconsistency found in a stored report
+type integrityProblem struct {
+	ReportID string `json:"reportId"`
+	Problem  string `json:"problem"`
+}
+
+// integrityResult is the outcome of an integrity check of the store
+type integrityResult struct {
+	Checked  int                `json:"checked"`
+	Problems []integrityProblem `json:"problems"`
+}
+
+// HandleVerifyIntegrity checks that every stored report is complete and that
+// its raw document can still be read back
+func (s *Server) HandleVerifyIntegrity(w http.ResponseWriter, r *http.Request) {
+	result := integrityResult{Problems: []integrityProblem{}}
+	for _, report := range s.store.List() {
+		result.Checked++
+		for _, problem := range s.verifyReport(r, report) {
+			result.Problems = append(result.Problems, integrityProblem{ReportID: report.ID, Problem: problem})
+		}
+	}
+
+	log.Printf("Verified %d reports, %d problems found", result.Checked, len(result.Problems))
+	writeJSON(w, http.StatusOK, result)
+}
+
+// verifyReport returns the problems of a single report
+func (s *Server) verifyReport(r *http.Request, report *store.Report) []string {
+	var problems []string
+	if report.Summary == nil {
+		problems = append(problems, "report has no summary")
+	} else if report.Summary.ReportID != report.ID {
+		problems = append(problems, fmt.Sprintf("summary belongs to report %q", report.Summary.ReportID))
+	}
+
+	if report.Cluster != "" {
+		if _, err := s.clusters.Get(report.Cluster); err != nil {
+			problems = append(problems, fmt.Sprintf("cluster %q is not registered", report.Cluster))
+		}
+	}
+
+	// Read the whole document so truncated or undecryptable blobs are caught too
+	raw, err := s.store.OpenRaw(r.Context(), report.ID)
+	if err != nil {
+		return append(problems, fmt.Sprintf("raw document cannot be opened: %v", err))
+	}
+	defer raw.Close()
+	if _, err := io.Copy(io.Discard, raw); err != nil {
+		problems = append(problems, fmt.Sprintf("raw document cannot be read: %v", err))
+	}
+	return problems
+}
+
+// backupManifest describes the contents of a backup archive
+type backupManifest struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Reports   int       `json:"reports"`
+	Clusters  int       `json:"clusters"`
+	Orgs      int       `json:"orgs"`
+	Waivers   int       `json:"waivers"`
+}
+
+// backupContents is a snapshot of everything a backup archive holds
+type backupContents struct {
+	reports  []*store.Report
+	clusters []clusters.Cluster
+	orgs     []orgs.Org
+	waivers  []waivers.Waiver
+}
+
+// HandleBackup streams a gzipped tar archive of the stored reports with their
+// raw documents and attachments, the cluster and organization registries and the waivers. Report records
+// are written decrypted, so the archive must be protected like the data
+// directory itself.
+func (s *Server) HandleBackup(w http.ResponseWriter, r *http.Request) {
+	contents := backupContents{
+		reports:  s.store.List(),
+		clusters: s.clusters.List(),
+		orgs:     s.orgs.List(),
+		waivers:  s.waivers.List(),
+	}
+
+	createdAt := time.Now().UTC()
+	filename := "health-dashboard-" + createdAt.Format("20060102-150405") + ".tar.gz"
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	// The response is committed once streaming starts, so later errors can
+	// only be logged and the client sees a truncated archive
+	gz := gzip.NewWriter(w)
+	archive := tar.NewWriter(gz)
+	err := s.writeBackup(r, archive, createdAt, contents)
+	if err == nil {
+		err = archive.Close()
+	}
+	if err == nil {
+		err = gz.Close()
+	}
+	if err != nil {
+		log.Printf("Error writing backup: %v", err)
+		return
+	}
+	log.Printf("Wrote backup with %d reports", len(contents.reports))
+}
+
+// writeBackup writes the entries of a backup archive
+func (s *Server) writeBackup(r *http.Request, archive *tar.Writer, createdAt time.Time, contents backupContents) error {
+	manifest := backupManifest{
+		CreatedAt: createdAt,
+		Reports:   len(contents.reports),
+		Clusters:  len(contents.clusters),
+		Orgs:      len(contents.orgs),
+		Waivers:   len(contents.waivers),
+	}
+	if err := writeTarJSON(archive, "manifest.json", createdAt, manifest); err != nil {
+		return err
+	}
+	if err := writeTarJSON(archive, "clusters.json", createdAt, contents.clusters); err != nil {
+		return err
+	}
+	if err := writeTarJSON(archive, "orgs.json", createdAt, contents.orgs); err != nil {
+		return err
+	}
+	if err := writeTarJSON(archive, "waivers.json", createdAt, contents.waivers); err != nil {
+		return err
+	}
+
+	for _, report := range contents.reports {
+		if err := writeTarJSON(archive, "reports/"+report.ID+".json", report.LastModified(), report); err != nil {
+			return err
+		}
+
+		raw, err := s.store.OpenRaw(r.Context(), report.ID)
+		if err != nil {
+			// A missing document shouldn't make the rest of the backup unusable
+			log.Printf("Backup skips the raw document of report %s: %v", report.ID, err)
+			continue
+		}
+		data, err := io.ReadAll(raw)
+		raw.Close()
+		if err != nil {
+			log.Printf("Backup skips the raw document of report %s: %v", report.ID, err)
+			continue
+		}
+
+		name := "raw/" + report.ID + path.Ext(report.RawKey)
+		if path.Ext(report.RawKey) == "" {
+			name += ".adoc"
+		}
+		if err := writeTarFile(archive, name, report.UploadedAt, data); err != nil {
+			return err
+		}
+
+		for _, attachment := range report.Attachments {
+			data, err := s.readAttachment(r.Context(), report.ID, attachment.Path)
+			if err != nil {
+				log.Printf("Backup skips attachment %s of report %s: %v", attachment.Path, report.ID, err)
+				continue
+			}
+			if err := writeTarFile(archive, store.AttachmentKey(report.ID, attachment.Path), report.UploadedAt, data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeTarJSON adds an indented JSON document to an archive
+func writeTarJSON(archive *tar.Writer, name string, modTime time.Time, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding %s: %w", name, err)
+	}
+	return writeTarFile(archive, name, modTime, data)
+}
+
+// writeTarFile adds a regular file to an archive
+func writeTarFile(archive *tar.Writer, name string, modTime time.Time, data []byte) error {
+	header := &tar.Header{Name: name, Mode: 0o644, Size: int64(len(data)), ModTime: modTime, Typeflag: tar.TypeReg}
+	if err := archive.WriteHeader(header); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+	if _, err := archive.Write(data); err != nil {
+		return fmt.Errorf("error writing %s: %w", name, err)
+	}
+	return nil
+}
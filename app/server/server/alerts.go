@@ -0,0 +1,51 @@
+// app/server/server/alerts.go
+package server
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/alerting"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// evaluateAlerts runs the alert rules against a newly stored report and the
+// previous report of its cluster, paging the targets of the rules that fire
+func (s *Server) evaluateAlerts(report *store.Report) {
+	config := s.settings.Current().Alerting
+	if !config.Enabled() || report.Summary == nil {
+		return
+	}
+
+	summary := s.presentSummary(report, report.Summary)
+	input := alerting.Input{
+		Cluster:   strings.TrimSpace(report.ClusterKey()),
+		Customer:  summary.CustomerName,
+		ReportID:  report.ID,
+		ReportURL: s.reportURL(report.ID),
+		Time:      report.UploadedAt,
+		Score:     summary.OverallScore,
+		Findings:  summary.Findings,
+	}
+	if previous := s.previousReport(report); previous != nil && previous.Summary != nil {
+		input.Previous = &alerting.Previous{
+			ReportID: previous.ID,
+			Score:    previous.Summary.OverallScore,
+			Findings: s.presentSummary(previous, previous.Summary).Findings,
+		}
+	}
+
+	alerts := config.Evaluate(input)
+	if len(alerts) == 0 {
+		return
+	}
+	log.Printf("Report %s raised %d alerts", report.ID, len(alerts))
+
+	go func() {
+		ctx, cancel := context.WithTimeout(s.ctx, time.Minute)
+		defer cancel()
+		config.Send(ctx, alerts)
+	}()
+}
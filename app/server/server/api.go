@@ -0,0 +1,556 @@
+// app/server/server/api.go
+package server
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/branding"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/jobs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/openapi"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/orgs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/remediation"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scanner"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scheduler"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/templates"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/waivers"
+)
+
+// API versions served under /api/<version>/
+const (
+	apiV1 = "v1"
+	apiV2 = "v2"
+
+	// apiDefaultVersion serves unversioned /api/ requests, which the SPA still uses
+	apiDefaultVersion = apiV1
+)
+
+// maxJSONBody bounds the body of the routes taking a JSON document; uploads
+// and the routes consuming other media types apply limits of their own
+const maxJSONBody = 1 << 20
+
+// apiMediaTypePrefix is the vendor media type used to select a version through
+// the Accept header, e.g. application/vnd.health-dashboard.v2+json
+const apiMediaTypePrefix = "application/vnd.health-dashboard."
+
+// setupAPI mounts a router per API version, plus the unversioned /api/ prefix
+// which picks a version by content negotiation
+func (s *Server) setupAPI(mux *http.ServeMux) {
+	versions := map[string]http.Handler{
+		apiV1: s.apiRouter(apiV1),
+		apiV2: s.apiRouter(apiV2),
+	}
+
+	for version, router := range versions {
+		prefix := "/api/" + version
+		mux.Handle(prefix+"/", withLocale(withAPIVersion(version, http.StripPrefix(prefix, router))))
+	}
+
+	mux.Handle(apiDocsPath, apiDocsHandler())
+
+	mux.Handle("/api/", withLocale(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addVary(w.Header(), "Accept")
+
+		version, ok := negotiateAPIVersion(r.Header.Get("Accept"))
+		router, known := versions[version]
+		if !ok || !known {
+			writeError(w, http.StatusNotAcceptable, "Unsupported API version")
+			return
+		}
+
+		withAPIVersion(version, http.StripPrefix("/api", router)).ServeHTTP(w, r)
+	})))
+}
+
+// apiRouter returns the routes of one API version
+func (s *Server) apiRouter(version string) *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, route := range s.apiRoutes(version) {
+		handler := route.handler
+		if route.deprecated {
+			handler = deprecated(handler)
+		}
+		if route.doc.request != nil {
+			handler = limitJSONBody(handler)
+		}
+		mux.HandleFunc(route.pattern, routeSpan(version, route.pattern, handler))
+	}
+	mux.HandleFunc("GET /openapi.json", s.openAPIHandler(version))
+	return mux
+}
+
+// apiRoutes lists the routes of one API version with their documentation.
+// Most handlers are shared; only routes whose schema changed differ between versions.
+func (s *Server) apiRoutes(version string) []apiRoute {
+	var routes []apiRoute
+
+	switch version {
+	case apiV1:
+		routes = append(routes,
+			apiRoute{pattern: "/parse-report", handler: s.HandleReportUpload, deprecated: true, doc: routeDoc{
+				method: http.MethodPost, tag: tagReports, summary: "Upload and parse a report",
+				upload: true, query: []openapi.Parameter{scoringParam, parserParam, asyncParam}, response: types.ReportSummary{},
+			}},
+			apiRoute{pattern: "POST /uploads/{id}/complete", handler: s.HandleCompleteUpload, doc: routeDoc{
+				tag: tagReports, summary: "Verify a chunked upload against its digest and parse it",
+				query: []openapi.Parameter{scoringParam, parserParam, asyncParam}, response: types.ReportSummary{},
+			}},
+			apiRoute{pattern: "GET /reports/{id}", handler: s.HandleGetReport, deprecated: true, doc: routeDoc{
+				tag: tagReports, summary: "Get a stored report",
+				query: []openapi.Parameter{scoringParam}, response: store.Report{},
+			}},
+		)
+	case apiV2:
+		routes = append(routes,
+			apiRoute{pattern: "POST /parse-report", handler: s.HandleReportUploadV2, doc: routeDoc{
+				tag: tagReports, summary: "Upload and parse a report",
+				upload: true, query: []openapi.Parameter{scoringParam, parserParam, asyncParam}, response: types.ReportSummaryV2{},
+			}},
+			apiRoute{pattern: "POST /uploads/{id}/complete", handler: s.HandleCompleteUploadV2, doc: routeDoc{
+				tag: tagReports, summary: "Verify a chunked upload against its digest and parse it",
+				query: []openapi.Parameter{scoringParam, parserParam, asyncParam}, response: types.ReportSummaryV2{},
+			}},
+			apiRoute{pattern: "GET /reports/{id}", handler: s.HandleGetReportV2, doc: routeDoc{
+				tag: tagReports, summary: "Get a stored report",
+				query: []openapi.Parameter{scoringParam}, response: reportV2{},
+			}},
+		)
+	}
+
+	routes = append(routes,
+		apiRoute{pattern: "POST /uploads", handler: s.HandleCreateUpload, doc: routeDoc{
+			tag: tagReports, summary: "Start a chunked upload of a document with its size and SHA-256 digest",
+			request: createUploadRequest{}, status: http.StatusCreated, response: uploadSessionResponse{},
+		}},
+		apiRoute{pattern: "GET /uploads/{id}", handler: s.HandleGetUpload, doc: routeDoc{
+			tag: tagReports, summary: "Get the offset to resume a chunked upload from",
+			response: uploadSessionResponse{},
+		}},
+		apiRoute{pattern: "PATCH /uploads/{id}", handler: s.HandleUploadChunk, doc: routeDoc{
+			tag: tagReports, summary: "Append a chunk at the offset given in the Upload-Offset header",
+			consumes: "application/offset+octet-stream", response: uploadSessionResponse{},
+		}},
+		apiRoute{pattern: "DELETE /uploads/{id}", handler: s.HandleDeleteUpload, doc: routeDoc{
+			tag: tagReports, summary: "Abandon a chunked upload",
+			status: http.StatusNoContent,
+		}},
+		apiRoute{pattern: "GET /reports", handler: s.HandleListReports, doc: routeDoc{
+			tag: tagReports, summary: "List stored reports, newest first",
+			query: []openapi.Parameter{
+				queryParam("customer", "Only reports of this customer"),
+				queryParam("cluster", "Only reports of this cluster"),
+				labelsParam,
+				archivedParam,
+			},
+			response: []reportListEntry{},
+		}},
+		apiRoute{pattern: "POST /reports/{id}/simulate", handler: s.HandleSimulateReport, doc: routeDoc{
+			tag: tagReports, summary: "Recalculate the scores of a report as if findings were resolved",
+			request: simulateRequest{}, response: simulateResponse{},
+		}},
+		apiRoute{pattern: "GET /reports/{id}/findings", handler: s.HandleListFindings, doc: routeDoc{
+			tag: tagReports, summary: "List the findings of a report, filtered, sorted and paged; the match count is in X-Total-Count",
+			query: []openapi.Parameter{
+				queryParam("status", "Comma-separated statuses, such as required,recommended"),
+				queryParam("category", "Comma-separated categories"),
+				queryParam("q", "Text searched in the ID, title, observation and recommendation"),
+				queryParam("sort", "title, category, status or severity; prefix with - to reverse. Document order by default"),
+				queryParam("limit", fmt.Sprintf("Findings per page, %d by default and at most %d", findingsDefaultLimit, findingsMaxLimit)),
+				queryParam("offset", "Number of findings to skip"),
+			},
+			response: []types.Finding{},
+		}},
+		apiRoute{pattern: "GET /findings/{id}/remediation", handler: s.HandleFindingRemediation, doc: routeDoc{
+			tag: tagReports, summary: "Suggest manifests and oc commands fixing a finding; they are never applied",
+			query: []openapi.Parameter{
+				queryParam("namespace", "Namespace the manifests and commands target; a placeholder when empty"),
+			},
+			response: types.Remediation{},
+		}},
+		apiRoute{pattern: "GET /remediations", handler: s.HandleListRemediations, doc: routeDoc{
+			tag: tagClusters, summary: "List the requested remediations, newest first",
+			response: []remediation.Remediation{},
+		}},
+		apiRoute{pattern: "POST /remediations", handler: s.HandleCreateRemediation, doc: routeDoc{
+			tag: tagClusters, summary: "Request that the suggested manifests of a finding be applied to a namespace once approved",
+			request: remediationRequest{}, status: http.StatusCreated, response: remediation.Remediation{},
+		}},
+		apiRoute{pattern: "GET /remediations/{id}", handler: s.HandleGetRemediation, doc: routeDoc{
+			tag: tagClusters, summary: "Get a remediation with its approval and apply result",
+			response: remediation.Remediation{},
+		}},
+		apiRoute{pattern: "POST /remediations/{id}/approve", handler: s.HandleApproveRemediation, doc: routeDoc{
+			tag: tagClusters, summary: "Approve a pending remediation and apply it; the approver must differ from the requester",
+			request: decisionRequest{}, response: remediation.Remediation{},
+		}},
+		apiRoute{pattern: "POST /remediations/{id}/reject", handler: s.HandleRejectRemediation, doc: routeDoc{
+			tag: tagClusters, summary: "Reject a pending remediation",
+			request: decisionRequest{}, response: remediation.Remediation{},
+		}},
+		apiRoute{pattern: "GET /reports/{id}/labels", handler: s.HandleGetReportLabels, doc: routeDoc{
+			tag: tagReports, summary: "Get the labels of a report with those it inherits from its cluster and document",
+			response: reportLabels{},
+		}},
+		apiRoute{pattern: "PUT /reports/{id}/labels", handler: s.HandleSetReportLabels, doc: routeDoc{
+			tag: tagReports, summary: "Replace the labels set on a report; they override those of the cluster and document",
+			request: setLabelsRequest{}, response: reportLabels{},
+		}},
+		apiRoute{pattern: "GET /reports/{id}/priorities", handler: s.HandleReportPriorities, doc: routeDoc{
+			tag: tagReports, summary: "List the open findings in remediation order, high impact and low effort first",
+			response: prioritiesResponse{},
+		}},
+		apiRoute{pattern: "GET /reports/{id}/attachments/{path...}", handler: s.HandleGetAttachment, doc: routeDoc{
+			tag: tagReports, summary: "Download a file uploaded with the report in a ZIP bundle, such as a figure",
+			download: "application/octet-stream",
+		}},
+		apiRoute{pattern: "GET /reports/{id}/badge.svg", handler: s.HandleReportBadge, doc: routeDoc{
+			tag: tagReports, summary: "Draw the overall score of a report as a badge",
+			query: []openapi.Parameter{badgeLabelParam}, download: "image/svg+xml",
+		}},
+		apiRoute{pattern: "POST /reports/{id}/jira", handler: s.HandleCreateJiraIssues, doc: routeDoc{
+			tag: tagReports, summary: "Create Jira issues for the required and recommended items",
+			response: jiraExportResponse{},
+		}},
+		apiRoute{pattern: "POST /reports/{id}/git", handler: s.HandleExportToGit, doc: routeDoc{
+			tag: tagReports, summary: "Commit the summary as JSON and Markdown to the GitOps repository",
+			response: gitExportResponse{},
+		}},
+		apiRoute{pattern: "POST /reports/{id}/confluence", handler: s.HandlePublishToConfluence, doc: routeDoc{
+			tag: tagReports, summary: "Publish the executive summary to the Confluence page of the cluster",
+			response: store.ConfluencePage{},
+		}},
+		apiRoute{pattern: "POST /reports/merge", handler: s.HandleMergeReports, doc: routeDoc{
+			tag: tagReports, summary: "Merge reports delivered as several documents into a consolidated report",
+			request: mergeRequest{}, status: http.StatusCreated, response: store.Report{},
+		}},
+		apiRoute{pattern: "POST /reports/rescore", handler: s.HandleRescoreReports, doc: routeDoc{
+			tag: tagReports, summary: "Queue every stored report for re-parsing",
+			status: http.StatusAccepted, response: map[string]int{},
+		}},
+		apiRoute{pattern: "POST /reports/{id}/approve", handler: s.HandleApproveReport, doc: routeDoc{
+			tag: tagReports, summary: "Approve a report held for review",
+			request: approveRequest{}, response: map[string]interface{}{},
+		}},
+		apiRoute{pattern: "POST /reports/{id}/archive", handler: s.HandleArchiveReport, doc: routeDoc{
+			tag: tagReports, summary: "Archive a report: it is left out of trends and overviews but stays retrievable",
+			request: archiveRequest{}, response: archiveResponse{},
+		}},
+		apiRoute{pattern: "POST /reports/{id}/unarchive", handler: s.HandleUnarchiveReport, doc: routeDoc{
+			tag: tagReports, summary: "Return an archived report to trends and overviews",
+			response: archiveResponse{},
+		}},
+		apiRoute{pattern: "POST /reports/{id}/compliance:import", handler: s.HandleImportReportCompliance, doc: routeDoc{
+			tag: tagReports, summary: "Import an exported XCCDF or ARF results file into a report",
+			consumes: "application/xml", response: complianceImportResponse{},
+		}},
+		apiRoute{pattern: "POST /reports/{id}/descriptions:regenerate", handler: s.HandleRegenerateDescriptions, doc: routeDoc{
+			tag: tagReports, summary: "Rewrite the category descriptions from the findings",
+			query:   []openapi.Parameter{queryParam("language", "Language of the descriptions")},
+			request: regenerateDescriptionsRequest{}, response: map[string]interface{}{},
+		}},
+		apiRoute{pattern: "GET /jobs/stats", handler: s.HandleJobStats, doc: routeDoc{
+			tag: tagAdmin, summary: "Get job queue metrics per class",
+			response: map[jobs.Class]jobs.ClassStats{},
+		}},
+		apiRoute{pattern: "POST /validate-report", handler: s.HandleValidateReport, doc: routeDoc{
+			tag: tagReports, summary: "Check a report document against the template without storing it",
+			upload: true, query: []openapi.Parameter{parserParam}, response: validateReportResponse{},
+		}},
+		apiRoute{pattern: "GET /jobs/{id}", handler: s.HandleGetJob, doc: routeDoc{
+			tag: tagReports, summary: "Get the status of a job and the result once it succeeded",
+			response: jobs.Job{},
+		}},
+		apiRoute{pattern: "GET /extraction/stats", handler: s.HandleExtractionStats, doc: routeDoc{
+			tag: tagAdmin, summary: "Count the parser strategies that produced the stored scores",
+			response: extractionStats{},
+		}},
+		apiRoute{pattern: "GET /waivers", handler: s.HandleListWaivers, doc: routeDoc{
+			tag: tagAdmin, summary: "List the finding waivers, newest first",
+			query: []openapi.Parameter{
+				queryParam("cluster", "Only waivers that apply to this cluster"),
+				queryParam("active", "true to list only waivers in effect"),
+			},
+			response: []waiverEntry{},
+		}},
+		apiRoute{pattern: "POST /waivers", handler: s.HandleCreateWaiver, doc: routeDoc{
+			tag: tagAdmin, summary: "Suppress findings by ID, title or category, optionally per cluster and until a date",
+			request: waivers.Waiver{}, status: http.StatusCreated, response: waiverEntry{},
+		}},
+		apiRoute{pattern: "GET /waivers/{id}", handler: s.HandleGetWaiver, doc: routeDoc{
+			tag: tagAdmin, summary: "Get a waiver",
+			response: waiverEntry{},
+		}},
+		apiRoute{pattern: "DELETE /waivers/{id}", handler: s.HandleDeleteWaiver, doc: routeDoc{
+			tag: tagAdmin, summary: "Delete a waiver, restoring the findings it suppressed",
+			status: http.StatusNoContent,
+		}},
+		apiRoute{pattern: "GET /parser-profiles", handler: s.HandleListParserProfiles, doc: routeDoc{
+			tag: tagAdmin, summary: "List the parser profiles an upload can name",
+			response: []utils.ParserProfile{},
+		}},
+		apiRoute{pattern: "GET /template-packs", handler: s.HandleListTemplatePacks, doc: routeDoc{
+			tag: tagAdmin, summary: "List the template packs of parser profiles and report templates",
+			response: []templatePackEntry{},
+		}},
+		apiRoute{pattern: "GET /template-packs/{name}", handler: s.HandleGetTemplatePack, doc: routeDoc{
+			tag: tagAdmin, summary: "Get a template pack",
+			response: templatePackEntry{},
+		}},
+		apiRoute{pattern: "PUT /template-packs/{name}", handler: s.HandlePutTemplatePack, doc: routeDoc{
+			tag: tagAdmin, summary: "Upload a template pack, replacing the one with the same name",
+			request: templates.Pack{}, status: http.StatusCreated, response: templatePackEntry{},
+		}},
+		apiRoute{pattern: "DELETE /template-packs/{name}", handler: s.HandleDeleteTemplatePack, doc: routeDoc{
+			tag: tagAdmin, summary: "Delete a template pack that isn't active",
+			status: http.StatusNoContent,
+		}},
+		apiRoute{pattern: "POST /template-packs/{name}/activate", handler: s.HandleActivateTemplatePack, doc: routeDoc{
+			tag: tagAdmin, summary: "Use the parser profiles and templates of a pack for new reports",
+			response: []templatePackEntry{},
+		}},
+		apiRoute{pattern: "POST /template-packs:deactivate", handler: s.HandleDeactivateTemplatePack, doc: routeDoc{
+			tag: tagAdmin, summary: "Go back to the built-in templates",
+			response: []templatePackEntry{},
+		}},
+		apiRoute{pattern: "GET /clusters", handler: s.HandleListClusters, doc: routeDoc{
+			tag: tagClusters, summary: "List registered clusters with their latest report",
+			query:    []openapi.Parameter{labelsParam},
+			response: []clusterEntry{},
+		}},
+		apiRoute{pattern: "POST /clusters", handler: s.HandleCreateCluster, doc: routeDoc{
+			tag: tagClusters, summary: "Register a cluster",
+			request: clusters.Cluster{}, status: http.StatusCreated, response: clusters.Cluster{},
+		}},
+		apiRoute{pattern: "GET /clusters/{name}", handler: s.HandleGetCluster, doc: routeDoc{
+			tag: tagClusters, summary: "Get a registered cluster",
+			response: clusterEntry{},
+		}},
+		apiRoute{pattern: "PUT /clusters/{name}", handler: s.HandleUpdateCluster, doc: routeDoc{
+			tag: tagClusters, summary: "Update a registered cluster",
+			request: clusters.Cluster{}, response: clusters.Cluster{},
+		}},
+		apiRoute{pattern: "DELETE /clusters/{name}", handler: s.HandleDeleteCluster, doc: routeDoc{
+			tag: tagClusters, summary: "Unregister a cluster, keeping its reports",
+			status: http.StatusNoContent,
+		}},
+		apiRoute{pattern: "GET /clusters/{name}/badge.svg", handler: s.HandleClusterBadge, doc: routeDoc{
+			tag: tagClusters, summary: "Draw the overall score of the latest report of a cluster as a badge",
+			query: []openapi.Parameter{badgeLabelParam}, download: "image/svg+xml",
+		}},
+		apiRoute{pattern: "GET /clusters/{name}/timeline", handler: s.HandleClusterTimeline, doc: routeDoc{
+			tag: tagClusters, summary: "Get the report history of a cluster with captured events",
+			response: timelineResponse{},
+		}},
+		apiRoute{pattern: "POST /clusters/{name}/insights:import", handler: s.HandleImportInsights, doc: routeDoc{
+			tag: tagClusters, summary: "Import the Insights Advisor recommendations of a cluster into its latest report",
+			response: insightsImportResponse{},
+		}},
+		apiRoute{pattern: "POST /clusters/{name}/compliance:import", handler: s.HandleImportClusterCompliance, doc: routeDoc{
+			tag: tagClusters, summary: "Import the Compliance Operator check results of a cluster into its latest report",
+			response: complianceImportResponse{},
+		}},
+		apiRoute{pattern: "GET /groups", handler: s.HandleListGroups, doc: routeDoc{
+			tag: tagClusters, summary: "List the cluster groups with their members",
+			response: []groupEntry{},
+		}},
+		apiRoute{pattern: "GET /groups/{name}/summary", handler: s.HandleGroupSummary, doc: routeDoc{
+			tag: tagClusters, summary: "Aggregate the scores and open findings of a cluster group",
+			query:    []openapi.Parameter{labelsParam},
+			response: groupSummary{},
+		}},
+		apiRoute{pattern: "GET /groups/{name}/trend", handler: s.HandleGroupTrend, doc: routeDoc{
+			tag: tagClusters, summary: "Get the average score of a cluster group per week or month",
+			query: []openapi.Parameter{
+				queryParam("interval", "Period length: week (default) or month"),
+				queryParam("periods", "Number of periods, 12 by default"),
+				labelsParam,
+			},
+			response: groupTrend{},
+		}},
+		apiRoute{pattern: "GET /groups/{name}/heatmap", handler: s.HandleGroupHeatmap, doc: routeDoc{
+			tag: tagClusters, summary: "Get the category scores of every cluster in a group",
+			query:    []openapi.Parameter{labelsParam},
+			response: groupHeatmap{},
+		}},
+		apiRoute{pattern: "POST /onboarding", handler: s.HandleOnboarding, doc: routeDoc{
+			tag: tagOnboarding, summary: "Provision an organization with its clusters",
+			request: onboardingRequest{}, status: http.StatusCreated, response: onboardingResponse{},
+		}},
+		apiRoute{pattern: "POST /onboarding/validate", handler: s.HandleValidateOnboarding, doc: routeDoc{
+			tag: tagOnboarding, summary: "Validate an onboarding request without provisioning it",
+			request: onboardingRequest{}, response: map[string]bool{},
+		}},
+		apiRoute{pattern: "GET /onboarding/{org}", handler: s.HandleOnboardingStatus, doc: routeDoc{
+			tag: tagOnboarding, summary: "Get the onboarding checklist of an organization",
+			response: onboardingResponse{},
+		}},
+		apiRoute{pattern: "GET /scoring/presets", handler: s.HandleListScoringPresets, doc: routeDoc{
+			tag: tagAdmin, summary: "List the scoring presets",
+			response: map[string]interface{}{},
+		}},
+		apiRoute{pattern: "GET /version", handler: s.HandleVersion, doc: routeDoc{
+			tag: tagAdmin, summary: "Get the version, commit, build date and Go version of the server",
+			response: buildInfo{},
+		}},
+		apiRoute{pattern: "POST /admin/reload", handler: s.HandleReload, doc: routeDoc{
+			tag: tagAdmin, summary: "Reload the settings file, the registries, the waivers, the template packs, the branding and the knowledge base",
+			response: reloadResult{},
+		}},
+		apiRoute{pattern: "GET /admin/config", handler: s.HandleExportConfig, doc: routeDoc{
+			tag: tagAdmin, summary: "Export the settings, API schedules and waivers as one YAML document, webhook URLs included",
+			download: "application/yaml",
+		}},
+		apiRoute{pattern: "PUT /admin/config", handler: s.HandleImportConfig, doc: routeDoc{
+			tag: tagAdmin, summary: "Replace the settings file, API schedules and waivers with an exported YAML document",
+			consumes: "application/yaml", response: configImportResult{},
+		}},
+		apiRoute{pattern: "GET /admin/orgs", handler: s.HandleListOrgs, doc: routeDoc{
+			tag: tagAdmin, summary: "List the organizations",
+			response: []orgs.Org{},
+		}},
+		apiRoute{pattern: "GET /admin/branding", handler: s.HandleGetBranding, doc: routeDoc{
+			tag: tagAdmin, summary: "Get the branding of status pages, published pages and notifications",
+			response: branding.Branding{},
+		}},
+		apiRoute{pattern: "PUT /admin/branding", handler: s.HandleUpdateBranding, doc: routeDoc{
+			tag: tagAdmin, summary: "Set the name, colors, footer and classification banner; the logo is kept",
+			request: branding.Branding{}, response: branding.Branding{},
+		}},
+		apiRoute{pattern: "PUT /admin/branding/logo", handler: s.HandleUploadLogo, doc: routeDoc{
+			tag: tagAdmin, summary: "Upload the logo as a PNG, JPEG, GIF or SVG image of up to 256 KiB",
+			consumes: "image/*", response: branding.Branding{},
+		}},
+		apiRoute{pattern: "DELETE /admin/branding/logo", handler: s.HandleDeleteLogo, doc: routeDoc{
+			tag: tagAdmin, summary: "Remove the logo",
+			status: http.StatusNoContent,
+		}},
+		apiRoute{pattern: "GET /branding/logo", handler: s.HandleGetLogo, doc: routeDoc{
+			tag: tagAdmin, summary: "Get the logo",
+			download: "image/*",
+		}},
+		apiRoute{pattern: "GET /admin/integrity", handler: s.HandleVerifyIntegrity, doc: routeDoc{
+			tag: tagAdmin, summary: "Check every stored report and its raw document",
+			response: integrityResult{},
+		}},
+		apiRoute{pattern: "GET /admin/backup", handler: s.HandleBackup, doc: routeDoc{
+			tag: tagAdmin, summary: "Download a backup archive of the reports and registries",
+			download: "application/gzip",
+		}},
+		apiRoute{pattern: "GET /admin/audit", handler: s.HandleListAudit, doc: routeDoc{
+			tag: tagAdmin, summary: "Get the audit log of remediation requests, approvals and apply results",
+			response: []store.AuditEntry{},
+		}},
+		apiRoute{pattern: "GET /admin/retention", handler: s.HandleRetentionStatus, doc: routeDoc{
+			tag: tagAdmin, summary: "Get the retention policy, the last cleanup and the reports it would remove next",
+			response: retentionStatus{},
+		}},
+		apiRoute{pattern: "POST /admin/retention:run", handler: s.HandleRunRetention, doc: routeDoc{
+			tag: tagAdmin, summary: "Remove the reports beyond the retention limits now",
+			query:    []openapi.Parameter{queryParam("dryRun", "true to only list the reports that would be removed")},
+			response: retentionRun{},
+		}},
+		apiRoute{pattern: "POST /scan", handler: s.HandleScan, doc: routeDoc{
+			tag: tagScans, summary: "Run a live scan of a cluster and store the report",
+			request: scanRequest{}, response: types.ReportSummary{},
+		}},
+		apiRoute{pattern: "GET /checks", handler: s.HandleListChecks, doc: routeDoc{
+			tag: tagScans, summary: "List the built-in and external checks of live scans",
+			response: []scanner.CheckInfo{},
+		}},
+		apiRoute{pattern: "GET /clusters/{name}/upgrade-readiness", handler: s.HandleUpgradeReadiness, doc: routeDoc{
+			tag: tagScans, summary: "Assess the blockers of updating a cluster to a target OpenShift version",
+			query:    []openapi.Parameter{queryParam("target", "OpenShift version to update to, the latest available update by default")},
+			response: scanner.UpgradeReadiness{},
+		}},
+		apiRoute{pattern: "GET /schedules", handler: s.HandleListSchedules, doc: routeDoc{
+			tag: tagScans, summary: "List scan schedules",
+			response: []scheduler.Schedule{},
+		}},
+		apiRoute{pattern: "POST /schedules", handler: s.HandleCreateSchedule, doc: routeDoc{
+			tag: tagScans, summary: "Create a scan schedule",
+			request: scheduler.Schedule{}, status: http.StatusCreated, response: scheduler.Schedule{},
+		}},
+		apiRoute{pattern: "DELETE /schedules/{id}", handler: s.HandleDeleteSchedule, doc: routeDoc{
+			tag: tagScans, summary: "Delete a scan schedule created through the API",
+			status: http.StatusNoContent,
+		}},
+	)
+
+	// The GraphQL API is opt-in
+	if s.config.GraphQL {
+		routes = append(routes, apiRoute{pattern: "POST /graphql", handler: s.HandleGraphQL, doc: routeDoc{
+			tag: tagReports, summary: "Query reports, findings, clusters and trends with GraphQL",
+			request: graphQLRequest{}, response: graphQLResponse{},
+		}})
+	}
+	return routes
+}
+
+// withAPIVersion reports the version that served a request
+func withAPIVersion(version string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("API-Version", version)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// deprecated marks a route as superseded by the same path in the next API
+// version, using the Deprecation header and a successor-version link
+func deprecated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Add("Link", "</api/"+apiV2+r.URL.Path+`>; rel="successor-version"`)
+		next(w, r)
+	}
+}
+
+// limitJSONBody rejects a JSON request body larger than maxJSONBody. Bodies
+// without a length are cut off at the limit, failing to decode.
+func limitJSONBody(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxJSONBody {
+			writeErrorf(w, http.StatusRequestEntityTooLarge, "Request body exceeds the maximum size of %d bytes", maxJSONBody)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxJSONBody)
+		next(w, r)
+	}
+}
+
+// routeSpan names the request's trace span after the versioned route pattern
+func routeSpan(version, pattern string, next http.HandlerFunc) http.HandlerFunc {
+	path := pattern
+	if _, withoutMethod, ok := strings.Cut(pattern, " "); ok {
+		path = withoutMethod
+	}
+	route := "/api/" + version + path
+	return func(w http.ResponseWriter, r *http.Request) {
+		tracing.Route(r, route)
+		next(w, r)
+	}
+}
+
+// negotiateAPIVersion picks the API version from an Accept header, falling back
+// to the default when the client doesn't ask for a vendor media type
+func negotiateAPIVersion(accept string) (string, bool) {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil || !strings.HasPrefix(mediaType, apiMediaTypePrefix) {
+			continue
+		}
+
+		version := strings.TrimSuffix(strings.TrimPrefix(mediaType, apiMediaTypePrefix), "+json")
+		if version == "" {
+			return "", false
+		}
+		return version, true
+	}
+	return apiDefaultVersion, true
+}
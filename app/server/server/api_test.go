@@ -0,0 +1,32 @@
+// app/server/server/api_test.go
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestJSONBodyLimit(t *testing.T) {
+	router := newGoldenServer(t).apiRouter(apiV1)
+	body := `{"name": "` + strings.Repeat("x", maxJSONBody) + `"}`
+
+	// A body announcing its length is rejected before it's read
+	r := httptest.NewRequest(http.MethodPut, "/template-packs/large", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("body of %d bytes: status %d, want %d", len(body), w.Code, http.StatusRequestEntityTooLarge)
+	}
+
+	// One without a length is cut off at the limit
+	r = httptest.NewRequest(http.MethodPut, "/template-packs/large", io.MultiReader(strings.NewReader(body)))
+	r.ContentLength = -1
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, r)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("body of unknown length: status %d, want %d", w.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,133 @@
+// app/server/server/archive.go
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// archivedParam selects archived reports in report listings
+var archivedParam = queryParam("archived", "true lists only archived reports, all lists archived and active reports; by default archived reports are left out")
+
+// archiveRequest is the optional body of an archive request
+type archiveRequest struct {
+	ArchivedBy string `json:"archivedBy"`
+	Reason     string `json:"reason"`
+}
+
+// archiveResponse is the archive state of a report after a change
+type archiveResponse struct {
+	ID      string         `json:"id"`
+	Archive *store.Archive `json:"archive"`
+}
+
+// activeReports returns the reports that are not archived, newest first.
+// Trends, overviews and comparisons only look at these, while archived
+// reports stay retrievable by ID.
+func (s *Server) activeReports() []*store.Report {
+	var reports []*store.Report
+	for _, report := range s.store.List() {
+		if !report.Archived() {
+			reports = append(reports, report)
+		}
+	}
+	return reports
+}
+
+// listedReports returns the reports selected by the archived query parameter,
+// writing an error response when it is invalid
+func (s *Server) listedReports(w http.ResponseWriter, r *http.Request) ([]*store.Report, bool) {
+	switch r.URL.Query().Get("archived") {
+	case "", "false":
+		return s.activeReports(), true
+	case "all":
+		return s.store.List(), true
+	case "true":
+		var reports []*store.Report
+		for _, report := range s.store.List() {
+			if report.Archived() {
+				reports = append(reports, report)
+			}
+		}
+		return reports, true
+	default:
+		writeError(w, http.StatusBadRequest, "archived must be true, false or all")
+		return nil, false
+	}
+}
+
+// HandleArchiveReport takes a report out of trends and overviews as an
+// alternative to deleting it; the raw document moves to cold storage when
+// ARCHIVE_DIR is set
+func (s *Server) HandleArchiveReport(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	var req archiveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	// Prefer the identity set by an authenticating proxy such as oauth-proxy
+	archivedBy := forwardedUser(r)
+	if archivedBy == "" {
+		archivedBy = strings.TrimSpace(req.ArchivedBy)
+	}
+
+	archived, err := s.store.Archive(r.Context(), report.ID, archivedBy, strings.TrimSpace(req.Reason))
+	if errors.Is(err, store.ErrArchived) {
+		writeError(w, http.StatusConflict, "Report is already archived")
+		return
+	}
+	if errors.Is(err, store.ErrArchiving) {
+		writeError(w, http.StatusConflict, "Report is being archived or restored")
+		return
+	}
+	if err != nil {
+		log.Printf("Error archiving report %s: %v", report.ID, err)
+		writeError(w, http.StatusInternalServerError, "Failed to archive report")
+		return
+	}
+
+	if archived.Archive.ColdKey != "" {
+		log.Printf("Report %s archived, raw document moved to cold storage", report.ID)
+	} else {
+		log.Printf("Report %s archived", report.ID)
+	}
+	writeJSON(w, http.StatusOK, archiveResponse{ID: archived.ID, Archive: archived.Archive})
+}
+
+// HandleUnarchiveReport returns an archived report to trends and overviews
+func (s *Server) HandleUnarchiveReport(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	restored, err := s.store.Unarchive(r.Context(), report.ID)
+	if errors.Is(err, store.ErrNotArchived) {
+		writeError(w, http.StatusConflict, "Report is not archived")
+		return
+	}
+	if errors.Is(err, store.ErrArchiving) {
+		writeError(w, http.StatusConflict, "Report is being archived or restored")
+		return
+	}
+	if err != nil {
+		log.Printf("Error restoring archived report %s: %v", report.ID, err)
+		writeError(w, http.StatusInternalServerError, "Failed to restore archived report")
+		return
+	}
+
+	log.Printf("Report %s restored from the archive", report.ID)
+	writeJSON(w, http.StatusOK, archiveResponse{ID: restored.ID, Archive: restored.Archive})
+}
@@ -0,0 +1,156 @@
+// app/server/server/badges.go
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"log"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// badgeLabel is the left-hand text of a badge when no label is requested
+const badgeLabel = "health"
+
+// maxBadgeLabel bounds the length of a requested label, in characters
+const maxBadgeLabel = 40
+
+// badgeUnknownColor is the color of badges without a score
+const badgeUnknownColor = "#8a8d90"
+
+// badgeTemplate draws a flat badge in the style of shields.io; the texts are
+// escaped before rendering
+var badgeTemplate = template.Must(template.New("badge").Parse(`<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="20" role="img" aria-label="{{.Label}}: {{.Message}}">
+<title>{{.Label}}: {{.Message}}</title>
+<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="{{.Width}}" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="{{.LabelWidth}}" height="20" fill="#555"/><rect x="{{.LabelWidth}}" width="{{.MessageWidth}}" height="20" fill="{{.Color}}"/><rect width="{{.Width}}" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="{{.LabelX}}" y="15" fill="#010101" fill-opacity=".3">{{.Label}}</text><text x="{{.LabelX}}" y="14">{{.Label}}</text>
+<text x="{{.MessageX}}" y="15" fill="#010101" fill-opacity=".3">{{.Message}}</text><text x="{{.MessageX}}" y="14">{{.Message}}</text>
+</g>
+</svg>
+`))
+
+// badge holds the escaped texts and the layout of a badge
+type badge struct {
+	Label        string
+	Message      string
+	Color        string
+	Width        int
+	LabelWidth   int
+	MessageWidth int
+	LabelX       float64
+	MessageX     float64
+}
+
+// newBadge lays out a badge; the widths are estimated from the characters,
+// since the font isn't available to measure them
+func newBadge(label, message, color string) badge {
+	b := badge{
+		Label:        html.EscapeString(label),
+		Message:      html.EscapeString(message),
+		Color:        color,
+		LabelWidth:   textWidth(label) + 10,
+		MessageWidth: textWidth(message) + 10,
+	}
+	b.Width = b.LabelWidth + b.MessageWidth
+	b.LabelX = float64(b.LabelWidth) / 2
+	b.MessageX = float64(b.LabelWidth) + float64(b.MessageWidth)/2
+	return b
+}
+
+// textWidth estimates the width of a text in 11px Verdana
+func textWidth(text string) int {
+	width := 0.0
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune("iljI.,:;!|'", r):
+			width += 3.5
+		case strings.ContainsRune("frt()[] -", r):
+			width += 4.5
+		case strings.ContainsRune("mwMW%@", r):
+			width += 10.5
+		case r >= 'A' && r <= 'Z':
+			width += 7.5
+		default:
+			width += 7
+		}
+	}
+	return int(width + 0.5)
+}
+
+// HandleReportBadge draws the overall score of a report as an SVG badge
+func (s *Server) HandleReportBadge(w http.ResponseWriter, r *http.Request) {
+	report, err := s.store.Get(r.PathValue("id"))
+	if err != nil {
+		writeBadge(w, r, http.StatusNotFound, badgeLabelOf(r), "not found", badgeUnknownColor)
+		return
+	}
+
+	summary := s.presentSummary(report, report.Summary)
+	color := bandColor(s.settings.Current().Grades.Letter(summary.OverallScore))
+	writeBadge(w, r, http.StatusOK, badgeLabelOf(r), fmt.Sprintf("%.0f%%", summary.OverallScore), color)
+}
+
+// HandleClusterBadge draws the overall score of the latest report of a
+// cluster as an SVG badge, so it follows new reports
+func (s *Server) HandleClusterBadge(w http.ResponseWriter, r *http.Request) {
+	cluster := r.PathValue("name")
+	if registered := s.clusters.Resolve(cluster); registered != "" {
+		cluster = registered
+	}
+
+	report := s.latestReport(cluster)
+	if report == nil {
+		writeBadge(w, r, http.StatusNotFound, badgeLabelOf(r), "no report", badgeUnknownColor)
+		return
+	}
+
+	summary := s.presentSummary(report, report.Summary)
+	color := bandColor(s.settings.Current().Grades.Letter(summary.OverallScore))
+	writeBadge(w, r, http.StatusOK, badgeLabelOf(r), fmt.Sprintf("%.0f%%", summary.OverallScore), color)
+}
+
+// badgeLabelOf returns the label requested with the label parameter
+func badgeLabelOf(r *http.Request) string {
+	label := strings.TrimSpace(r.URL.Query().Get("label"))
+	if label == "" || !utf8.ValidString(label) {
+		return badgeLabel
+	}
+	if runes := []rune(label); len(runes) > maxBadgeLabel {
+		label = string(runes[:maxBadgeLabel])
+	}
+	return label
+}
+
+// writeBadge renders a badge with a content ETag, so wikis and READMEs
+// embedding it revalidate cheaply and pick up new scores at once; there is no
+// Last-Modified date, as waivers and rescoring change the score of a report
+// after its upload
+func writeBadge(w http.ResponseWriter, r *http.Request, status int, label, message, color string) {
+	var buf bytes.Buffer
+	if err := badgeTemplate.Execute(&buf, newBadge(label, message, color)); err != nil {
+		log.Printf("Error rendering badge: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to render badge")
+		return
+	}
+
+	w.Header().Set("Content-Type", "image/svg+xml; charset=utf-8")
+	w.Header().Set("Cache-Control", cacheRevalidate)
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+		w.Write(buf.Bytes())
+		return
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(buf.Bytes()))
+}
@@ -0,0 +1,113 @@
+// app/server/server/branding.go
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/branding"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+)
+
+// HandleGetBranding returns the branding applied to pages and notifications
+func (s *Server) HandleGetBranding(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.branding.Get())
+}
+
+// HandleUpdateBranding replaces the name, colors, footer and classification
+// banner; the logo is uploaded separately
+func (s *Server) HandleUpdateBranding(w http.ResponseWriter, r *http.Request) {
+	var update branding.Branding
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	updated, err := s.branding.Update(r.Context(), update)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Printf("Updated the branding")
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// HandleUploadLogo replaces the logo with the image in the request body
+func (s *Server) HandleUploadLogo(w http.ResponseWriter, r *http.Request) {
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	logo, err := io.ReadAll(http.MaxBytesReader(w, r.Body, branding.MaxLogoSize))
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		writeErrorf(w, http.StatusRequestEntityTooLarge, "Upload exceeds the maximum size of %d bytes", tooLarge.Limit)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	updated, err := s.branding.SetLogo(r.Context(), contentType, logo)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	log.Printf("Uploaded a %s logo of %d bytes", contentType, len(logo))
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// HandleDeleteLogo removes the logo
+func (s *Server) HandleDeleteLogo(w http.ResponseWriter, r *http.Request) {
+	err := s.branding.DeleteLogo(r.Context())
+	if errors.Is(err, branding.ErrNoLogo) {
+		writeError(w, http.StatusNotFound, "No logo uploaded")
+		return
+	}
+	if err != nil {
+		log.Printf("Error deleting the logo: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to delete logo")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleGetLogo serves the logo, for notifications that link to it
+func (s *Server) HandleGetLogo(w http.ResponseWriter, r *http.Request) {
+	logo, contentType, err := s.branding.Logo()
+	if err != nil {
+		writeError(w, http.StatusNotFound, "No logo uploaded")
+		return
+	}
+
+	// SVG logos may hold scripts; they only ever run as images
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", cacheRevalidate)
+	w.Header().Set("ETag", fmt.Sprintf(`"logo-%d"`, s.branding.Get().Logo.UploadedAt.UnixNano()))
+	w.Write(logo)
+}
+
+// notifyBranding returns the branding of notifications; the logo is linked,
+// so it is only included when a public URL is configured
+func (s *Server) notifyBranding() *notify.Branding {
+	current := s.branding.Get()
+	result := &notify.Branding{
+		Name:           current.Name,
+		Footer:         current.Footer,
+		Classification: current.ClassificationText(),
+	}
+	if current.Logo != nil && s.config.PublicURL != "" {
+		result.LogoURL = fmt.Sprintf("%s/api/branding/logo?v=%d", strings.TrimRight(s.config.PublicURL, "/"), current.Logo.UploadedAt.Unix())
+	}
+	if *result == (notify.Branding{}) {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,134 @@
+// app/server/server/bundles.go
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/bundle"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// errInvalidBundle wraps the reasons an uploaded ZIP bundle can't be read
+var errInvalidBundle = errors.New("invalid report bundle")
+
+// unpackBundle replaces an uploaded ZIP bundle by its main document with the
+// includes resolved, from the bundle or the include directory, stored as the raw document of the report, and stores the
+// other files as attachments. On success upload names the resolved document
+// and the bundle itself is removed.
+func (s *Server) unpackBundle(ctx context.Context, id string, upload *uploadedFile) ([]store.Attachment, error) {
+	// Reading a ZIP archive needs random access, which blob backends don't offer
+	spool, err := os.CreateTemp("", "bundle-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("error unpacking bundle: %w", err)
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	reader, err := s.blobs.Open(ctx, upload.Key)
+	if err != nil {
+		return nil, err
+	}
+	size, err := io.Copy(spool, reader)
+	reader.Close()
+	if err != nil {
+		return nil, fmt.Errorf("error unpacking bundle: %w", err)
+	}
+
+	opened, err := bundle.Open(spool, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidBundle, err)
+	}
+	document, unresolved, err := opened.Document(s.parseOptions().Includes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidBundle, err)
+	}
+	for _, target := range unresolved {
+		log.Printf("Bundle %s: include %s of %s not found", upload.Filename, target, opened.Main)
+	}
+
+	var attachments []store.Attachment
+	for _, file := range opened.Attachments {
+		if err := s.putAttachment(ctx, id, file); err != nil {
+			s.store.DeleteAttachments(context.Background(), id, attachments)
+			return nil, err
+		}
+		attachments = append(attachments, store.Attachment{Path: file.Path, ContentType: file.ContentType, Size: file.Size})
+	}
+
+	key := store.RawKey(id, ".adoc")
+	if _, err := s.blobs.Put(ctx, key, bytes.NewReader(document)); err != nil {
+		s.store.DeleteAttachments(context.Background(), id, attachments)
+		return nil, fmt.Errorf("error storing bundle document: %w", err)
+	}
+	if err := s.blobs.Delete(ctx, upload.Key); err != nil {
+		log.Printf("Error removing unpacked bundle %s: %v", upload.Key, err)
+	}
+
+	log.Printf("Unpacked bundle %s: main document %s, %d attachments", upload.Filename, opened.Main, len(attachments))
+	upload.Key = key
+	return attachments, nil
+}
+
+// putAttachment stores a file of a bundle as an attachment of a report
+func (s *Server) putAttachment(ctx context.Context, id string, file bundle.File) error {
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("%w: error reading %s: %w", errInvalidBundle, file.Path, err)
+	}
+	defer reader.Close()
+
+	if _, err := s.blobs.Put(ctx, store.AttachmentKey(id, file.Path), reader); err != nil {
+		return fmt.Errorf("error storing attachment %s: %w", file.Path, err)
+	}
+	return nil
+}
+
+// readAttachment returns the contents of an attachment of a report
+func (s *Server) readAttachment(ctx context.Context, id, path string) ([]byte, error) {
+	reader, err := s.store.OpenAttachment(ctx, id, path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+// HandleGetAttachment returns a file uploaded with a report in a bundle, such
+// as a figure, so exports can embed the original
+func (s *Server) HandleGetAttachment(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+	attachment, ok := report.Attachment(r.PathValue("path"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Attachment not found")
+		return
+	}
+
+	reader, err := s.store.OpenAttachment(r.Context(), report.ID, attachment.Path)
+	if err != nil {
+		log.Printf("Error reading attachment %s of report %s: %v", attachment.Path, report.ID, err)
+		writeError(w, http.StatusInternalServerError, "Failed to read the attachment")
+		return
+	}
+	defer reader.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	// Files of a bundle are untrusted; scripts in an SVG or HTML file must not run
+	w.Header().Set("Content-Security-Policy", "sandbox")
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, reader); err != nil {
+		log.Printf("Error writing attachment %s of report %s: %v", attachment.Path, report.ID, err)
+	}
+}
@@ -0,0 +1,173 @@
+// app/server/server/clusters.go
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// clusterLatestReport summarizes the newest report of a registered cluster
+type clusterLatestReport struct {
+	ID           string    `json:"id"`
+	UploadedAt   time.Time `json:"uploadedAt"`
+	OverallScore float64   `json:"overallScore"`
+}
+
+// clusterEntry is a registered cluster together with its report history, as
+// shown in the fleet overview
+type clusterEntry struct {
+	clusters.Cluster
+	ReportCount  int                  `json:"reportCount"`
+	LatestReport *clusterLatestReport `json:"latestReport,omitempty"`
+}
+
+// HandleListClusters returns the registered clusters with their latest report,
+// optionally selected by a label selector
+func (s *Server) HandleListClusters(w http.ResponseWriter, r *http.Request) {
+	selector, ok := labelSelector(w, r)
+	if !ok {
+		return
+	}
+	registered := selectClusters(s.clusters.List(), selector)
+
+	// Group reports by registered cluster; reports are newest first
+	reports := make(map[string][]*store.Report)
+	for _, report := range s.activeReports() {
+		if report.Cluster != "" {
+			name := strings.ToLower(report.Cluster)
+			reports[name] = append(reports[name], report)
+		}
+	}
+
+	entries := make([]clusterEntry, 0, len(registered))
+	for _, cluster := range registered {
+		entries = append(entries, newClusterEntry(cluster, reports[strings.ToLower(cluster.Name)]))
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// HandleGetCluster returns a single registered cluster
+func (s *Server) HandleGetCluster(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := s.lookupCluster(w, r)
+	if !ok {
+		return
+	}
+
+	var reports []*store.Report
+	for _, report := range s.activeReports() {
+		if strings.EqualFold(report.Cluster, cluster.Name) {
+			reports = append(reports, report)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, newClusterEntry(cluster, reports))
+}
+
+// HandleCreateCluster registers a cluster
+func (s *Server) HandleCreateCluster(w http.ResponseWriter, r *http.Request) {
+	var cluster clusters.Cluster
+	if err := json.NewDecoder(r.Body).Decode(&cluster); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if cluster.Org != "" {
+		if _, err := s.orgs.Get(cluster.Org); err != nil {
+			writeError(w, http.StatusBadRequest, "Unknown organization")
+			return
+		}
+	}
+
+	created, err := s.clusters.Create(cluster)
+	if errors.Is(err, clusters.ErrExists) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// HandleUpdateCluster replaces the connection details and labels of a registered cluster
+func (s *Server) HandleUpdateCluster(w http.ResponseWriter, r *http.Request) {
+	var cluster clusters.Cluster
+	if err := json.NewDecoder(r.Body).Decode(&cluster); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if cluster.Org != "" {
+		if _, err := s.orgs.Get(cluster.Org); err != nil {
+			writeError(w, http.StatusBadRequest, "Unknown organization")
+			return
+		}
+	}
+
+	updated, err := s.clusters.Update(r.PathValue("name"), cluster)
+	if errors.Is(err, clusters.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Cluster not found")
+		return
+	}
+	if errors.Is(err, clusters.ErrManaged) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// HandleDeleteCluster unregisters a cluster; its reports are kept
+func (s *Server) HandleDeleteCluster(w http.ResponseWriter, r *http.Request) {
+	err := s.clusters.Delete(r.PathValue("name"))
+	if errors.Is(err, clusters.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Cluster not found")
+		return
+	}
+	if errors.Is(err, clusters.ErrManaged) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to delete cluster")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lookupCluster loads the cluster named by the {name} path value, writing an error response if it can't
+func (s *Server) lookupCluster(w http.ResponseWriter, r *http.Request) (clusters.Cluster, bool) {
+	cluster, err := s.clusters.Get(r.PathValue("name"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Cluster not found")
+		return clusters.Cluster{}, false
+	}
+	return cluster, true
+}
+
+// newClusterEntry builds the overview entry for a cluster from its reports, newest first
+func newClusterEntry(cluster clusters.Cluster, reports []*store.Report) clusterEntry {
+	entry := clusterEntry{Cluster: cluster, ReportCount: len(reports)}
+	if len(reports) > 0 {
+		entry.LatestReport = &clusterLatestReport{
+			ID:           reports[0].ID,
+			UploadedAt:   reports[0].UploadedAt,
+			OverallScore: reports[0].Summary.OverallScore,
+		}
+	}
+	return entry
+}
@@ -0,0 +1,115 @@
+// app/server/server/compliance.go
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/compliance"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// complianceImportResponse is returned by the compliance import endpoints
+type complianceImportResponse struct {
+	Report     string    `json:"report"`
+	ImportedAt time.Time `json:"importedAt"`
+	compliance.Summary
+
+	// Findings is the number of findings added to the report
+	Findings int `json:"findings"`
+}
+
+// HandleImportClusterCompliance reads the Compliance Operator's check results
+// from a registered cluster and attaches them to its latest report
+func (s *Server) HandleImportClusterCompliance(w http.ResponseWriter, r *http.Request) {
+	cluster, ok := s.lookupCluster(w, r)
+	if !ok {
+		return
+	}
+
+	report := s.latestReport(cluster.Name)
+	if report == nil {
+		writeError(w, http.StatusConflict, "Cluster has no report to attach the results to")
+		return
+	}
+
+	clients, err := s.clientsFor(cluster.Name)
+	if err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	results, err := compliance.FromCluster(r.Context(), clients.RestConfig)
+	if errors.Is(err, compliance.ErrNoResults) {
+		writeError(w, http.StatusNotFound, "The cluster has no compliance check results")
+		return
+	}
+	if err != nil {
+		log.Printf("Error reading compliance results of cluster %s: %v", cluster.Name, err)
+		writeError(w, http.StatusBadGateway, "Error reading compliance check results")
+		return
+	}
+
+	s.importCompliance(w, report, results)
+}
+
+// HandleImportReportCompliance attaches the results of an exported XCCDF or
+// ARF file, sent as the request body, to a report
+func (s *Server) HandleImportReportCompliance(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	if s.config.RateLimit.MaxUploadSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, s.config.RateLimit.MaxUploadSize)
+	}
+	results, err := compliance.ParseXCCDF(r.Body)
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeErrorf(w, http.StatusRequestEntityTooLarge, "Upload exceeds the maximum size of %d bytes", tooLarge.Limit)
+		return
+	case errors.Is(err, compliance.ErrNoResults):
+		writeError(w, http.StatusBadRequest, "The file holds no XCCDF rule results")
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	s.importCompliance(w, report, results)
+}
+
+// importCompliance stores compliance results with a report; a measured score
+// replaces the Compliance Benchmarking score extracted from the document
+func (s *Server) importCompliance(w http.ResponseWriter, report *store.Report, results []compliance.Result) {
+	summary := compliance.Summarize(results)
+	imported := &store.Import{
+		ImportedAt: time.Now().UTC(),
+		Findings:   compliance.Findings(results),
+	}
+	if summary.Score != nil {
+		imported.Scores = map[string]int{utils.FieldScoreCompliance: *summary.Score}
+	}
+
+	if err := s.attachImport(report, compliance.Source, imported); err != nil {
+		log.Printf("Error saving compliance results for report %s: %v", report.ID, err)
+		writeError(w, http.StatusInternalServerError, "Error saving the results")
+		return
+	}
+
+	if s.config.DebugMode {
+		log.Printf("Imported %d compliance check results into report %s", len(results), report.ID)
+	}
+
+	writeJSON(w, http.StatusOK, complianceImportResponse{
+		Report:     report.ID,
+		ImportedAt: imported.ImportedAt,
+		Summary:    summary,
+		Findings:   len(imported.Findings),
+	})
+}
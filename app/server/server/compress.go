@@ -0,0 +1,275 @@
+// app/server/server/compress.go
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	// minCompressSize is the smallest response body worth compressing
+	minCompressSize = 1024
+
+	// brotliLevel trades ratio for speed on dynamic responses
+	brotliLevel = 5
+)
+
+// compressibleTypes lists the content types that compress well; images,
+// archives and already encoded content are left alone
+var compressibleTypes = []string{
+	"text/",
+	"application/json",
+	"application/javascript",
+	"application/xml",
+	"application/problem+json",
+	"image/svg+xml",
+}
+
+var (
+	gzipWriters = sync.Pool{New: func() interface{} {
+		return gzip.NewWriter(io.Discard)
+	}}
+	brotliWriters = sync.Pool{New: func() interface{} {
+		return brotli.NewWriterLevel(io.Discard, brotliLevel)
+	}}
+)
+
+// compressHandler compresses responses with brotli or gzip, depending on what
+// the client accepts. Small bodies, incompressible content types and responses
+// that already carry a Content-Encoding are passed through unchanged.
+func compressHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		addVary(w.Header(), "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.Close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// compressWriter buffers the start of a response until it knows whether the
+// body is large and compressible enough, then either compresses or passes through
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	status   int
+	buf      []byte
+	decided  bool
+	encoder  io.WriteCloser
+}
+
+// WriteHeader records the status; headers are sent once the encoding is decided
+func (cw *compressWriter) WriteHeader(status int) {
+	if cw.status == 0 {
+		cw.status = status
+	}
+}
+
+// Write buffers until minCompressSize bytes are available, then streams
+func (cw *compressWriter) Write(p []byte) (int, error) {
+	if cw.status == 0 {
+		cw.status = http.StatusOK
+	}
+
+	if !cw.decided {
+		if !cw.eligibleHeaders() {
+			cw.passThrough()
+			return cw.ResponseWriter.Write(p)
+		}
+
+		cw.buf = append(cw.buf, p...)
+		if len(cw.buf) < minCompressSize {
+			return len(p), nil
+		}
+		if err := cw.decide(); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	if cw.encoder != nil {
+		return cw.encoder.Write(p)
+	}
+	return cw.ResponseWriter.Write(p)
+}
+
+// Flush sends whatever has been buffered so streaming responses keep working
+func (cw *compressWriter) Flush() {
+	if !cw.decided && cw.status != 0 {
+		cw.decide()
+	}
+
+	switch encoder := cw.encoder.(type) {
+	case *gzip.Writer:
+		encoder.Flush()
+	case *brotli.Writer:
+		encoder.Flush()
+	}
+
+	if flusher, ok := cw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Close finishes the response, sending short bodies uncompressed
+func (cw *compressWriter) Close() error {
+	if !cw.decided {
+		if cw.status == 0 {
+			return nil
+		}
+		cw.passThrough()
+	}
+
+	if cw.encoder == nil {
+		return nil
+	}
+
+	err := cw.encoder.Close()
+	switch encoder := cw.encoder.(type) {
+	case *gzip.Writer:
+		gzipWriters.Put(encoder)
+	case *brotli.Writer:
+		brotliWriters.Put(encoder)
+	}
+	cw.encoder = nil
+	return err
+}
+
+// decide compresses the buffered body if it qualifies and passes it through otherwise
+func (cw *compressWriter) decide() error {
+	header := cw.Header()
+
+	// Set the type now so net/http doesn't sniff the compressed bytes
+	if header.Get("Content-Type") == "" {
+		header.Set("Content-Type", http.DetectContentType(cw.buf))
+	}
+
+	if len(cw.buf) < minCompressSize || !isCompressibleType(header.Get("Content-Type")) {
+		cw.passThrough()
+		return nil
+	}
+
+	cw.decided = true
+	header.Del("Content-Length")
+	header.Set("Content-Encoding", cw.encoding)
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		// The compressed body is a different representation of the same content
+		header.Set("ETag", "W/"+etag)
+	}
+	cw.ResponseWriter.WriteHeader(cw.status)
+
+	switch cw.encoding {
+	case "br":
+		encoder := brotliWriters.Get().(*brotli.Writer)
+		encoder.Reset(cw.ResponseWriter)
+		cw.encoder = encoder
+	default:
+		encoder := gzipWriters.Get().(*gzip.Writer)
+		encoder.Reset(cw.ResponseWriter)
+		cw.encoder = encoder
+	}
+
+	buf := cw.buf
+	cw.buf = nil
+	_, err := cw.encoder.Write(buf)
+	return err
+}
+
+// passThrough sends the headers and any buffered bytes unchanged
+func (cw *compressWriter) passThrough() {
+	cw.decided = true
+	cw.ResponseWriter.WriteHeader(cw.status)
+	if len(cw.buf) > 0 {
+		cw.ResponseWriter.Write(cw.buf)
+		cw.buf = nil
+	}
+}
+
+// eligibleHeaders reports whether the status and headers allow compression
+func (cw *compressWriter) eligibleHeaders() bool {
+	switch {
+	case cw.status < http.StatusOK,
+		cw.status == http.StatusNoContent,
+		cw.status == http.StatusNotModified,
+		cw.status == http.StatusPartialContent:
+		return false
+	}
+
+	header := cw.Header()
+	if header.Get("Content-Encoding") != "" || header.Get("Content-Range") != "" {
+		return false
+	}
+
+	// Bodies known to be short aren't worth buffering
+	if length, err := strconv.Atoi(header.Get("Content-Length")); err == nil && length < minCompressSize {
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	return contentType == "" || isCompressibleType(contentType)
+}
+
+// negotiateEncoding picks brotli over gzip from an Accept-Encoding header
+func negotiateEncoding(accept string) string {
+	gzipOK, brotliOK := false, false
+	for _, part := range strings.Split(accept, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.ReplaceAll(strings.TrimSpace(params), " ", "") == "q=0" {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(coding)) {
+		case "br":
+			brotliOK = true
+		case "gzip":
+			gzipOK = true
+		}
+	}
+
+	switch {
+	case brotliOK:
+		return "br"
+	case gzipOK:
+		return "gzip"
+	default:
+		return ""
+	}
+}
+
+// isCompressibleType reports whether a content type benefits from compression
+func isCompressibleType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, prefix := range compressibleTypes {
+		if strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// addVary adds a value to the Vary header unless it is already listed
+func addVary(header http.Header, value string) {
+	for _, existing := range header.Values("Vary") {
+		for _, field := range strings.Split(existing, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), value) {
+				return
+			}
+		}
+	}
+	header.Add("Vary", value)
+}
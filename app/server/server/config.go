@@ -0,0 +1,132 @@
+// app/server/server/config.go
+package server
+
+import (
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scheduler"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/settings"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/waivers"
+)
+
+// configMaxDocument bounds the size of an imported configuration document
+const configMaxDocument = 4 << 20
+
+// configDocument is the configuration of an instance as one YAML document, so
+// it can be kept in version control and promoted from staging to production.
+// Without the schedules and waivers it is a valid settings file.
+type configDocument struct {
+	settings.File `yaml:",inline"`
+
+	// Schedules are the scan schedules created through the API; those of the
+	// schedules file and of a HealthDashboard resource belong to their sources
+	Schedules []scheduler.Schedule `yaml:"schedules"`
+
+	// Waivers are all waivers, expired ones included
+	Waivers []waivers.Waiver `yaml:"waivers"`
+}
+
+// configImportResult describes an applied configuration document
+type configImportResult struct {
+	Schedules int                    `json:"schedules"`
+	Waivers   int                    `json:"waivers"`
+	Settings  map[string]interface{} `json:"settings"`
+}
+
+// HandleExportConfig returns the scoring profile, category mappings, parser
+// profiles, groups, notification and alerting targets, schedules and waivers
+// as one YAML document. It carries the webhook URLs and alerting keys.
+func (s *Server) HandleExportConfig(w http.ResponseWriter, r *http.Request) {
+	document := configDocument{
+		File:      s.settings.Export(),
+		Schedules: []scheduler.Schedule{},
+		Waivers:   s.waivers.List(),
+	}
+	for _, schedule := range s.scheduler.List() {
+		if schedule.Source == "api" {
+			schedule.LastRun, schedule.NextRun, schedule.LastReportID, schedule.LastError = nil, nil, "", ""
+			document.Schedules = append(document.Schedules, schedule)
+		}
+	}
+
+	data, err := yaml.Marshal(document)
+	if err != nil {
+		log.Printf("Error encoding configuration: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to export configuration")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("Content-Disposition", `attachment; filename="dashboard-config.yaml"`)
+	w.Write(data)
+}
+
+// HandleImportConfig replaces the settings file, the API schedules and the
+// waivers with those of a document exported by HandleExportConfig. The whole
+// document is validated first, so an invalid one changes nothing.
+func (s *Server) HandleImportConfig(w http.ResponseWriter, r *http.Request) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, configMaxDocument))
+	if err != nil {
+		writeError(w, http.StatusRequestEntityTooLarge, "Configuration document is too large")
+		return
+	}
+
+	var document configDocument
+	if err := yaml.UnmarshalStrict(data, &document); err != nil {
+		writeErrorf(w, http.StatusBadRequest, "Invalid configuration document: %s", err)
+		return
+	}
+
+	if err := s.settings.Validate(document.File); err != nil {
+		writeErrorf(w, http.StatusBadRequest, "Invalid configuration document: %s", err)
+		return
+	}
+	for _, schedule := range document.Schedules {
+		if err := scheduler.Validate(schedule); err != nil {
+			writeErrorf(w, http.StatusBadRequest, "Invalid configuration document: %s", err)
+			return
+		}
+	}
+	if err := waivers.CheckReplacement(document.Waivers); err != nil {
+		writeErrorf(w, http.StatusBadRequest, "Invalid configuration document: %s", err)
+		return
+	}
+
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	current, err := s.settings.Save(document.File)
+	if errors.Is(err, settings.ErrNoFile) {
+		writeError(w, http.StatusConflict, "Settings can only be imported with SETTINGS_FILE set")
+		return
+	}
+	if err != nil {
+		log.Printf("Error importing settings: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to import configuration")
+		return
+	}
+	s.configureNotifier()
+
+	if err := s.scheduler.Replace(document.Schedules); err != nil {
+		log.Printf("Error importing schedules, settings were imported: %v", err)
+		writeErrorf(w, http.StatusInternalServerError, "Settings were imported, but the schedules failed: %s", err)
+		return
+	}
+	if err := s.waivers.Replace(document.Waivers); err != nil {
+		log.Printf("Error importing waivers, settings and schedules were imported: %v", err)
+		writeErrorf(w, http.StatusInternalServerError, "Settings and schedules were imported, but the waivers failed: %s", err)
+		return
+	}
+
+	log.Printf("Imported configuration: %d schedules, %d waivers", len(document.Schedules), len(document.Waivers))
+	writeJSON(w, http.StatusOK, configImportResult{
+		Schedules: len(document.Schedules),
+		Waivers:   len(document.Waivers),
+		Settings:  current.Describe(),
+	})
+}
@@ -0,0 +1,111 @@
+// app/server/server/confluence.go
+package server
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/confluence"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// errNotLatestReport is returned when publishing a report older than the
+// latest report of its cluster, which owns the page
+var errNotLatestReport = errors.New("a newer report of the cluster exists")
+
+// HandlePublishToConfluence publishes the executive summary of a report to
+// the Confluence page of its cluster now, e.g. for reports stored before the
+// integration was configured
+func (s *Server) HandlePublishToConfluence(w http.ResponseWriter, r *http.Request) {
+	if s.confluence == nil {
+		writeError(w, http.StatusServiceUnavailable, "Confluence publishing is not configured")
+		return
+	}
+
+	report, ok := s.lookupReport(w, r)
+	if !ok || !s.requireApproved(w, report) {
+		return
+	}
+
+	page, err := s.publishPage(r.Context(), report)
+	if errors.Is(err, errNotLatestReport) {
+		writeError(w, http.StatusConflict, "Only the latest report of a cluster is published to its page")
+		return
+	}
+	if err != nil {
+		log.Printf("Error publishing report %s to Confluence: %v", report.ID, err)
+		writeErrorf(w, http.StatusBadGateway, "Confluence publishing failed: %s", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// publishToConfluence publishes a newly stored report in the background
+func (s *Server) publishToConfluence(report *store.Report) {
+	if s.confluence == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(s.ctx, 2*time.Minute)
+		defer cancel()
+		if _, err := s.publishPage(ctx, report); err != nil && !errors.Is(err, errNotLatestReport) {
+			log.Printf("Error publishing report %s to Confluence: %v", report.ID, err)
+		}
+	}()
+}
+
+// publishPage writes the executive summary of a report to the page of its
+// cluster. An older report doesn't replace the page of a newer one.
+func (s *Server) publishPage(ctx context.Context, report *store.Report) (page *store.ConfluencePage, err error) {
+	ctx, span := tracing.Start(ctx, "confluence.Publish", attribute.String("report.id", report.ID))
+	defer func() { tracing.End(span, err) }()
+
+	if latest := s.latestReport(report.ClusterKey()); latest != nil && latest.UploadedAt.After(report.UploadedAt) {
+		return nil, errNotLatestReport
+	}
+
+	published, err := s.confluence.Publish(ctx, report.ClusterKey(), s.confluencePageData(report), s.pageTemplate())
+	if err != nil {
+		return nil, err
+	}
+
+	page = &store.ConfluencePage{ID: published.ID, Version: published.Version, URL: published.URL, PublishedAt: time.Now().UTC()}
+	if _, err := s.store.Mutate(report.ID, func(report *store.Report) error {
+		report.ConfluencePage = page
+		return nil
+	}); err != nil {
+		log.Printf("Error saving the Confluence page of report %s: %v", report.ID, err)
+	}
+	return page, nil
+}
+
+// confluencePageData returns what the page of a report shows: the summary as
+// the dashboard presents it, the branding and the previous score of the cluster
+func (s *Server) confluencePageData(report *store.Report) confluence.PageData {
+	brand := s.branding.Get()
+	data := confluence.PageData{
+		Cluster:    strings.TrimSpace(report.ClusterKey()),
+		Customer:   report.Summary.CustomerName,
+		ReportID:   report.ID,
+		UploadedAt: report.UploadedAt,
+		Summary:    s.presentSummary(report, report.Summary),
+
+		Footer:         brand.Footer,
+		Classification: brand.ClassificationText(),
+	}
+	if s.config.PublicURL != "" {
+		data.ReportURL = s.reportURL(report.ID)
+	}
+	if previous := s.previousReport(report); previous != nil {
+		score := previous.Summary.OverallScore
+		data.PreviousScore = &score
+	}
+	return data
+}
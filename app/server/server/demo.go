@@ -0,0 +1,85 @@
+// app/server/server/demo.go
+package server
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/demo"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/i18n"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// seedDemo registers the synthetic clusters and stores their reports when
+// the store is empty, so restarts don't add the same history again
+func (s *Server) seedDemo(ctx context.Context) error {
+	if len(s.store.List()) > 0 {
+		log.Printf("Demo mode: the store already holds reports, not seeding")
+		return nil
+	}
+
+	for _, cluster := range demo.Clusters() {
+		_, err := s.clusters.Create(clusters.Cluster{Name: cluster.Name, Labels: cluster.Labels})
+		if err != nil && !errors.Is(err, clusters.ErrExists) {
+			return fmt.Errorf("error registering demo cluster %s: %w", cluster.Name, err)
+		}
+	}
+
+	reports := demo.Reports(time.Now())
+	for _, generated := range reports {
+		if err := s.storeDemoReport(ctx, generated); err != nil {
+			return err
+		}
+	}
+	log.Printf("Demo mode: seeded %d reports of %d clusters", len(reports), len(demo.Clusters()))
+	return nil
+}
+
+// storeDemoReport stores a synthetic report like a scan, dated to the time of
+// its health check; notifications and exports are skipped
+func (s *Server) storeDemoReport(ctx context.Context, generated demo.Report) error {
+	id := store.NewID()
+	key := store.RawKey(id, ".adoc")
+
+	// The document is a rendered scan, so demo reports can be re-parsed like any other
+	document, err := s.renderScan(generated.Scan)
+	if err != nil {
+		return err
+	}
+	document = bytes.Replace(document, []byte("Live scan of"), []byte("Synthetic demo scan of"), 1)
+	if _, err := s.blobs.Put(ctx, key, bytes.NewReader(document)); err != nil {
+		return fmt.Errorf("error storing demo document: %w", err)
+	}
+
+	summary, err := s.parseStoredDocument(ctx, key, utils.ParserProfileDefault, i18n.DefaultLanguage)
+	if err != nil {
+		s.blobs.Delete(context.Background(), key)
+		return fmt.Errorf("error parsing demo report of %s: %w", generated.Cluster.Name, err)
+	}
+	summary.ClusterName = generated.Cluster.Name
+	summary.CustomerName = generated.Cluster.Customer
+
+	cluster := s.clusters.Resolve(generated.Cluster.Name)
+	profile, _ := s.scoringProfile("", cluster)
+	s.applyScoring(summary, profile)
+
+	// Trends and comparisons follow the time of the health check
+	if _, err := s.store.Create(&store.Report{
+		ID:         id,
+		Filename:   generated.Filename,
+		UploadedAt: generated.Time,
+		RawKey:     key,
+		Cluster:    cluster,
+		Summary:    summary,
+	}); err != nil {
+		s.blobs.Delete(context.Background(), key)
+		return err
+	}
+	return nil
+}
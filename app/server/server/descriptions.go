@@ -0,0 +1,76 @@
+// app/server/server/descriptions.go
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/i18n"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/narrative"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// regenerateDescriptionsRequest is the optional body of a description regeneration
+type regenerateDescriptionsRequest struct {
+	Language string `json:"language"`
+}
+
+// HandleRegenerateDescriptions rewrites the category descriptions of a report
+// from its findings, replacing the text captured at parse time. The language
+// comes from the body or the "language" query parameter, falling back to the
+// language negotiated for the response.
+func (s *Server) HandleRegenerateDescriptions(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	var req regenerateDescriptionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Language == "" {
+		req.Language = r.URL.Query().Get("language")
+	}
+	if req.Language == "" {
+		req.Language = locale(r)
+	}
+
+	descriptions, err := narrative.Describe(report.Summary, req.Language)
+	switch {
+	case errors.Is(err, narrative.ErrUnsupportedLanguage):
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error":     i18n.T(locale(r), "Unsupported language"),
+			"languages": narrative.Languages(),
+		})
+		return
+	case errors.Is(err, narrative.ErrNoFindings):
+		writeError(w, http.StatusUnprocessableEntity, "Report has no findings to describe, re-score it first")
+		return
+	case err != nil:
+		log.Printf("Error regenerating descriptions of report %s: %v", report.ID, err)
+		writeError(w, http.StatusInternalServerError, "Failed to regenerate descriptions")
+		return
+	}
+
+	// Replace the summary rather than editing it, as readers may hold the old one
+	if _, err := s.store.Mutate(report.ID, func(report *store.Report) error {
+		summary := *report.Summary
+		descriptions.Apply(&summary)
+		report.Summary = &summary
+		return nil
+	}); err != nil {
+		log.Printf("Error saving descriptions of report %s: %v", report.ID, err)
+		writeError(w, http.StatusInternalServerError, "Failed to save descriptions")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":           report.ID,
+		"descriptions": descriptions,
+	})
+}
@@ -0,0 +1,58 @@
+// app/server/server/extraction.go
+package server
+
+import (
+	"net/http"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// strategyUnrecorded counts reports parsed before strategies were recorded, and live scans
+const strategyUnrecorded = "unrecorded"
+
+// extractionStats counts, per score field, how many stored reports got the
+// value from each parser strategy
+type extractionStats struct {
+	Reports       int                       `json:"reports"`
+	FallbackOrder []string                  `json:"fallbackOrder"`
+	Fields        map[string]map[string]int `json:"fields"`
+}
+
+// HandleExtractionStats returns which parser strategies produced the scores of
+// the stored reports, to measure how often the parser has to fall back
+func (s *Server) HandleExtractionStats(w http.ResponseWriter, r *http.Request) {
+	stats := extractionStats{
+		FallbackOrder: s.parseOptions().FallbackOrder,
+		Fields:        make(map[string]map[string]int, len(utils.ScoreFields)),
+	}
+	for _, field := range utils.ScoreFields {
+		stats.Fields[field] = make(map[string]int)
+	}
+
+	for _, report := range s.store.List() {
+		if report.Summary == nil {
+			continue
+		}
+		stats.Reports++
+		for _, field := range utils.ScoreFields {
+			strategy := report.Summary.Extraction[field]
+			if strategy == "" {
+				strategy = strategyUnrecorded
+			}
+			stats.Fields[field][strategy]++
+		}
+	}
+
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// HandleListParserProfiles returns the standard template followed by the
+// configured report dialects and those of the active template pack
+func (s *Server) HandleListParserProfiles(w http.ResponseWriter, r *http.Request) {
+	profiles := []utils.ParserProfile{{
+		Name:        utils.ParserProfileDefault,
+		Description: "Standard health check report template",
+	}}
+	profiles = append(profiles, s.parseOptions().Profiles...)
+	writeJSON(w, http.StatusOK, profiles)
+}
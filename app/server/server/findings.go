@@ -0,0 +1,222 @@
+// app/server/server/findings.go
+package server
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/knowledge"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// Findings paging
+const (
+	findingsDefaultLimit = 50
+	findingsMaxLimit     = 500
+)
+
+// findingSorts compare two findings by a sort key of GET /reports/{id}/findings;
+// status and severity put the most urgent findings first
+var findingSorts = map[string]func(a, b types.Finding) int{
+	"title": func(a, b types.Finding) int {
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	},
+	"category": func(a, b types.Finding) int {
+		return strings.Compare(strings.ToLower(a.Category), strings.ToLower(b.Category))
+	},
+	"status":   func(a, b types.Finding) int { return statusRank(a.Status) - statusRank(b.Status) },
+	"severity": func(a, b types.Finding) int { return severityRank(b.Severity) - severityRank(a.Severity) },
+}
+
+// statusRank orders statuses from the most to the least urgent
+func statusRank(status types.ResultKey) int {
+	switch status {
+	case types.ResultKeyRequired:
+		return 0
+	case types.ResultKeyRecommended:
+		return 1
+	case types.ResultKeyAdvisory:
+		return 2
+	case types.ResultKeyEvaluate:
+		return 3
+	case types.ResultKeyNoChange:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// findingsQuery is the filter, order and page of a findings request
+type findingsQuery struct {
+	statuses   map[string]bool
+	categories map[string]bool
+	text       string
+	sort       string
+	descending bool
+	limit      int
+	offset     int
+}
+
+// parseFindingsQuery reads the query parameters of GET /reports/{id}/findings,
+// writing an error response when one is invalid
+func parseFindingsQuery(w http.ResponseWriter, values url.Values) (findingsQuery, bool) {
+	query := findingsQuery{
+		statuses:   listParam(values.Get("status")),
+		categories: listParam(values.Get("category")),
+		text:       strings.ToLower(strings.TrimSpace(values.Get("q"))),
+		limit:      findingsDefaultLimit,
+	}
+
+	if value := values.Get("sort"); value != "" {
+		query.sort, query.descending = strings.TrimPrefix(value, "-"), strings.HasPrefix(value, "-")
+		if _, ok := findingSorts[query.sort]; !ok {
+			writeError(w, http.StatusBadRequest, "sort must be title, category, status or severity, optionally prefixed with -")
+			return query, false
+		}
+	}
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > findingsMaxLimit {
+			writeErrorf(w, http.StatusBadRequest, "limit must be a number from 1 to %d", findingsMaxLimit)
+			return query, false
+		}
+		query.limit = limit
+	}
+	if value := values.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			writeError(w, http.StatusBadRequest, "offset must be a number of at least 0")
+			return query, false
+		}
+		query.offset = offset
+	}
+	return query, true
+}
+
+// listParam splits a comma-separated parameter into a set of lower-case values
+func listParam(value string) map[string]bool {
+	if value == "" {
+		return nil
+	}
+	set := make(map[string]bool)
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.ToLower(strings.TrimSpace(item)); item != "" {
+			set[item] = true
+		}
+	}
+	return set
+}
+
+// matches reports whether a finding passes the filters; the text is searched
+// in the ID, title, observation and recommendation
+func (q findingsQuery) matches(finding types.Finding) bool {
+	if q.statuses != nil && !q.statuses[strings.ToLower(string(finding.Status))] ||
+		q.categories != nil && !q.categories[strings.ToLower(finding.Category)] {
+		return false
+	}
+	if q.text == "" {
+		return true
+	}
+	for _, field := range []string{finding.ID, finding.Title, finding.Observation, finding.Recommendation} {
+		if strings.Contains(strings.ToLower(field), q.text) {
+			return true
+		}
+	}
+	return false
+}
+
+// HandleListFindings lists the findings of a report with filters, an order and
+// a page. Waived findings are left out, like in the report. The number of
+// matching findings is in the X-Total-Count header and the neighbouring pages
+// are linked in the Link header.
+func (s *Server) HandleListFindings(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	query, ok := parseFindingsQuery(w, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	findings := []types.Finding{}
+	for _, finding := range s.presentSummary(report, report.Summary).Findings {
+		if query.matches(finding) {
+			findings = append(findings, finding)
+		}
+	}
+
+	// Without a sort key findings stay in document order
+	if compare := findingSorts[query.sort]; compare != nil {
+		sort.SliceStable(findings, func(i, j int) bool {
+			if query.descending {
+				return compare(findings[j], findings[i]) < 0
+			}
+			return compare(findings[i], findings[j]) < 0
+		})
+	}
+
+	total := len(findings)
+	start := min(query.offset, total)
+	end := min(start+query.limit, total)
+
+	w.Header().Set("X-Total-Count", strconv.Itoa(total))
+	if links := findingsLinks(r, query, total); links != "" {
+		w.Header().Set("Link", links)
+	}
+	writeJSON(w, http.StatusOK, findings[start:end])
+}
+
+// HandleFindingRemediation returns the suggested manifests and commands fixing
+// a finding, matched by its ID or title like the knowledge base guidance.
+// They are only generated for review; nothing is applied to any cluster.
+func (s *Server) HandleFindingRemediation(w http.ResponseWriter, r *http.Request) {
+	namespace := r.URL.Query().Get("namespace")
+	if namespace != "" {
+		if problems := validation.IsDNS1123Label(namespace); len(problems) > 0 {
+			writeErrorf(w, http.StatusBadRequest, "Invalid namespace: %s", strings.Join(problems, "; "))
+			return
+		}
+	}
+
+	remediation, ok, err := s.knowledge.Remediation(r.PathValue("id"), knowledge.RemediationParams{Namespace: namespace})
+	if err != nil {
+		log.Printf("Error generating remediation: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to generate the remediation")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "No known remediation for this finding")
+		return
+	}
+	writeJSON(w, http.StatusOK, remediation)
+}
+
+// findingsLinks returns the Link header pointing at the next and previous
+// pages; RequestURI keeps the /api prefix the router stripped from the path
+func findingsLinks(r *http.Request, query findingsQuery, total int) string {
+	path, _, _ := strings.Cut(r.RequestURI, "?")
+	link := func(offset int, rel string) string {
+		values := r.URL.Query()
+		values.Set("offset", strconv.Itoa(offset))
+		values.Set("limit", strconv.Itoa(query.limit))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, path, values.Encode(), rel)
+	}
+
+	var links []string
+	if query.offset+query.limit < total {
+		links = append(links, link(query.offset+query.limit, "next"))
+	}
+	if query.offset > 0 {
+		links = append(links, link(max(query.offset-query.limit, 0), "prev"))
+	}
+	return strings.Join(links, ", ")
+}
@@ -0,0 +1,149 @@
+// app/server/server/gitops.go
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/gitops"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// unsafePathChars are replaced in the directory names of clusters
+var unsafePathChars = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// gitExportResponse is returned by the Git export endpoint
+type gitExportResponse struct {
+	Repository string   `json:"repository"`
+	Branch     string   `json:"branch"`
+	Commit     string   `json:"commit"`
+	Files      []string `json:"files"`
+}
+
+// HandleExportToGit commits the summary of a report to the GitOps repository
+// now, e.g. for reports stored before the integration was configured
+func (s *Server) HandleExportToGit(w http.ResponseWriter, r *http.Request) {
+	if s.gitops == nil {
+		writeError(w, http.StatusServiceUnavailable, "Git export is not configured")
+		return
+	}
+
+	report, ok := s.lookupReport(w, r)
+	if !ok || !s.requireApproved(w, report) {
+		return
+	}
+
+	response, err := s.exportToGit(r.Context(), report)
+	if err != nil {
+		log.Printf("Error exporting report %s to Git: %v", report.ID, err)
+		writeErrorf(w, http.StatusBadGateway, "Git export failed: %s", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// publishToGit exports a newly stored report in the background
+func (s *Server) publishToGit(report *store.Report) {
+	if s.gitops == nil {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(s.ctx, 2*time.Minute)
+		defer cancel()
+		if _, err := s.exportToGit(ctx, report); err != nil {
+			log.Printf("Error exporting report %s to Git: %v", report.ID, err)
+		}
+	}()
+}
+
+// exportToGit commits the summary of a report as JSON and Markdown, once under
+// its upload time and once as the latest report of its cluster, so pipelines
+// can watch a single file per cluster
+func (s *Server) exportToGit(ctx context.Context, report *store.Report) (result *gitExportResponse, err error) {
+	ctx, span := tracing.Start(ctx, "gitops.Export", attribute.String("report.id", report.ID))
+	defer func() { tracing.End(span, err) }()
+
+	summary, markdown, err := s.gitSummary(report)
+	if err != nil {
+		return nil, err
+	}
+
+	dir := gitClusterDir(report.ClusterKey())
+	base := report.UploadedAt.UTC().Format("2006-01-02T150405Z") + "-" + report.ID
+	files := []gitops.File{
+		{Name: dir + "/" + base + ".json", Content: summary},
+		{Name: dir + "/" + base + ".md", Content: markdown},
+		{Name: dir + "/latest.json", Content: summary},
+		{Name: dir + "/latest.md", Content: markdown},
+	}
+
+	// The figures of a bundle are committed next to the summary of the report
+	for _, attachment := range report.Attachments {
+		data, err := s.readAttachment(ctx, report.ID, attachment.Path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading attachment %s: %w", attachment.Path, err)
+		}
+		files = append(files, gitops.File{Name: dir + "/" + base + "/" + attachment.Path, Content: data})
+	}
+
+	message := fmt.Sprintf("Health check of %s: %.1f%%\n\nReport %s, uploaded %s",
+		strings.TrimSpace(report.ClusterKey()), report.Summary.OverallScore, report.ID, report.UploadedAt.UTC().Format(time.RFC3339))
+	commit, err := s.gitops.Publish(ctx, files, message)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &store.GitExport{Commit: commit, ExportedAt: time.Now().UTC()}
+	if _, err := s.store.Mutate(report.ID, func(report *store.Report) error {
+		report.GitExport = export
+		return nil
+	}); err != nil {
+		log.Printf("Error saving the Git commit of report %s: %v", report.ID, err)
+	}
+
+	config := s.gitops.Config()
+	result = &gitExportResponse{Repository: config.URL, Branch: config.Branch, Commit: commit}
+	for _, file := range files {
+		result.Files = append(result.Files, config.Path+"/"+file.Name)
+	}
+	return result, nil
+}
+
+// gitSummary returns the summary of a report as committed to the repository,
+// in JSON and in Markdown
+func (s *Server) gitSummary(report *store.Report) ([]byte, []byte, error) {
+	// Grade a copy; grades follow the settings, so they are never stored
+	graded := *report.Summary
+	graded.Categories = append([]types.CategoryScore(nil), report.Summary.Categories...)
+	scoring.Grade(&graded, s.settings.Current().Grades)
+
+	summary, err := json.MarshalIndent(&graded, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("error encoding summary: %w", err)
+	}
+	reportURL := ""
+	if s.config.PublicURL != "" {
+		reportURL = s.reportURL(report.ID)
+	}
+	return summary, gitops.Markdown(&graded, reportURL), nil
+}
+
+// gitClusterDir returns the directory of a cluster's reports in the repository
+func gitClusterDir(cluster string) string {
+	dir := strings.Trim(unsafePathChars.ReplaceAllString(strings.ToLower(cluster), "-"), "-.")
+	if dir == "" {
+		return "unknown"
+	}
+	return dir
+}
@@ -0,0 +1,331 @@
+// app/server/server/golden_test.go
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/confluence"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// updateGolden rewrites the golden files with the current exports, so a
+// change to an export shows up as a diff of the golden files to review:
+//
+//	go test ./server -run TestGoldenExports -update
+var updateGolden = flag.Bool("update", false, "rewrite the golden files of the exports")
+
+// goldenDir holds the fixture reports and the golden files of their exports,
+// named after the fixture and the export, e.g. production.git.md
+const goldenDir = "testdata/golden"
+
+// goldenUploadedAt is the upload time of the fixtures, so exports showing
+// dates stay the same
+var goldenUploadedAt = time.Date(2025, time.March, 14, 9, 30, 0, 0, time.UTC)
+
+// goldenReportID replaces the generated ID of a fixture report in its exports
+const goldenReportID = "REPORT-ID"
+
+// goldenExports are the exports compared with golden files. Each is compared
+// by its structure, not byte by byte, so formatting that doesn't change what
+// readers see doesn't fail the test. The server renders no PDF or CSV, so
+// those formats get golden files along with the export that produces them.
+var goldenExports = []struct {
+	name      string
+	render    func(s *Server, report *store.Report) ([]byte, error)
+	structure func(content []byte) ([]string, error)
+}{
+	{"git.json", func(s *Server, report *store.Report) ([]byte, error) {
+		summary, _, err := s.gitSummary(report)
+		return summary, err
+	}, jsonStructure},
+	{"git.md", func(s *Server, report *store.Report) ([]byte, error) {
+		_, markdown, err := s.gitSummary(report)
+		return markdown, err
+	}, markdownStructure},
+	{"confluence.html", func(s *Server, report *store.Report) ([]byte, error) {
+		client, err := confluence.NewClient(confluence.Config{})
+		if err != nil {
+			return nil, err
+		}
+		body, err := client.Render(s.confluencePageData(report), s.pageTemplate())
+		return []byte(body), err
+	}, htmlStructure},
+	{"status.html", func(s *Server, report *store.Report) ([]byte, error) {
+		return s.renderStatusPage(report.ClusterKey(), report)
+	}, htmlStructure},
+}
+
+func TestGoldenExports(t *testing.T) {
+	output := log.Writer()
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(output) })
+
+	fixtures, err := filepath.Glob(filepath.Join(goldenDir, "*.*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newGoldenServer(t)
+
+	for _, fixture := range fixtures {
+		name := filepath.Base(fixture)
+		if strings.Count(name, ".") > 1 {
+			// A golden file
+			continue
+		}
+		base := strings.TrimSuffix(name, filepath.Ext(name))
+
+		t.Run(base, func(t *testing.T) {
+			report := uploadGoldenFixture(t, s, fixture)
+			for _, export := range goldenExports {
+				t.Run(export.name, func(t *testing.T) {
+					got, err := export.render(s, report)
+					if err != nil {
+						t.Fatal(err)
+					}
+					got = bytes.ReplaceAll(got, []byte(report.ID), []byte(goldenReportID))
+					compareGolden(t, filepath.Join(goldenDir, base+"."+export.name), got, export.structure)
+				})
+			}
+		})
+	}
+}
+
+// newGoldenServer starts a server storing its data in a temporary directory,
+// with a public URL so exports link to the dashboard
+func newGoldenServer(t *testing.T) *Server {
+	t.Helper()
+	dir := t.TempDir()
+	static := filepath.Join(dir, "static")
+	if err := os.MkdirAll(static, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(static, "index.html"), []byte("<!doctype html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewServer(Config{
+		DataDir:       dir,
+		BlobDir:       filepath.Join(dir, "blobs"),
+		StaticDir:     static,
+		PublicURL:     "https://health.example.com",
+		ScoringPreset: "default",
+	})
+	if err := s.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Shutdown(t.Context()) })
+	return s
+}
+
+// uploadGoldenFixture uploads a fixture through the API like a user would and
+// returns the stored report, dated goldenUploadedAt
+func uploadGoldenFixture(t *testing.T, s *Server, path string) *store.Report {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("report", filepath.Base(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(content)
+	form.Close()
+
+	request := httptest.NewRequest(http.MethodPost, "/api/v2/parse-report", &body)
+	request.Header.Set("Content-Type", form.FormDataContentType())
+	response := httptest.NewRecorder()
+	s.handler.ServeHTTP(response, request)
+	if response.Code != http.StatusOK {
+		t.Fatalf("uploading %s: %d %s", path, response.Code, response.Body)
+	}
+
+	var uploaded struct {
+		ReportID string `json:"reportId"`
+	}
+	if err := json.Unmarshal(response.Body.Bytes(), &uploaded); err != nil {
+		t.Fatal(err)
+	}
+	report, err := s.store.Mutate(uploaded.ReportID, func(report *store.Report) error {
+		report.UploadedAt = goldenUploadedAt
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+// compareGolden compares the structure of an export with that of its golden
+// file, or rewrites the golden file with -update
+func compareGolden(t *testing.T, path string, got []byte, structure func([]byte) ([]string, error)) {
+	t.Helper()
+	if *updateGolden {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	golden, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%v; run with -update to create it", err)
+	}
+	want, err := structure(golden)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	have, err := structure(got)
+	if err != nil {
+		t.Fatalf("reading the export: %v", err)
+	}
+
+	for i := 0; i < len(want) || i < len(have); i++ {
+		var wantLine, haveLine string
+		if i < len(want) {
+			wantLine = want[i]
+		}
+		if i < len(have) {
+			haveLine = have[i]
+		}
+		if wantLine != haveLine {
+			t.Fatalf("export differs from %s at element %d:\n got: %s\nwant: %s\nrun with -update if the change is intended",
+				path, i+1, haveLine, wantLine)
+		}
+	}
+}
+
+// jsonStructure lists the values of a JSON document by their path, such as
+// findings[0].status = "required", so key order and indentation don't matter
+func jsonStructure(content []byte) ([]string, error) {
+	var value interface{}
+	if err := json.Unmarshal(content, &value); err != nil {
+		return nil, err
+	}
+	var lines []string
+	flattenJSON("$", value, &lines)
+	return lines, nil
+}
+
+// flattenJSON appends the leaves of a JSON value below path
+func flattenJSON(path string, value interface{}, lines *[]string) {
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			flattenJSON(path+"."+key, value[key], lines)
+		}
+	case []interface{}:
+		*lines = append(*lines, fmt.Sprintf("%s: %d elements", path, len(value)))
+		for i, element := range value {
+			flattenJSON(fmt.Sprintf("%s[%d]", path, i), element, lines)
+		}
+	default:
+		encoded, _ := json.Marshal(value)
+		*lines = append(*lines, path+" = "+string(encoded))
+	}
+}
+
+var (
+	// markdownSpacePattern matches runs of whitespace, which render as one space
+	markdownSpacePattern = regexp.MustCompile(`\s+`)
+
+	// markdownRulePattern matches the separator row of a table
+	markdownRulePattern = regexp.MustCompile(`^\|(\s*:?-+:?\s*\|)+$`)
+)
+
+// markdownStructure lists the blocks of a Markdown document: headings, table
+// rows by cell, list items and paragraph lines, ignoring blank lines, table
+// alignment and whitespace
+func markdownStructure(content []byte) ([]string, error) {
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(markdownSpacePattern.ReplaceAllString(line, " "))
+		switch {
+		case line == "", markdownRulePattern.MatchString(line):
+		case strings.HasPrefix(line, "#"):
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			lines = append(lines, fmt.Sprintf("heading %d: %s", level, strings.TrimSpace(line[level:])))
+		case strings.HasPrefix(line, "|"):
+			cells := strings.Split(strings.Trim(line, "|"), " | ")
+			for i := range cells {
+				cells[i] = strings.TrimSpace(cells[i])
+			}
+			lines = append(lines, "row: "+strings.Join(cells, " | "))
+		case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "):
+			lines = append(lines, "item: "+line[2:])
+		default:
+			lines = append(lines, "text: "+line)
+		}
+	}
+	return lines, nil
+}
+
+// htmlAttributes are the attributes that are content rather than presentation
+var htmlAttributes = []string{"href", "src", "alt", "ac:name"}
+
+// htmlStructure lists the elements of an HTML document as an outline, each
+// with its content attributes and own text; styles, classes and whitespace
+// between elements are left out
+func htmlStructure(content []byte) ([]string, error) {
+	document, err := goquery.NewDocumentFromReader(bytes.NewReader(content))
+	if err != nil {
+		return nil, err
+	}
+	var lines []string
+	outlineHTML(document.Find("html"), 0, &lines)
+	return lines, nil
+}
+
+// outlineHTML appends the outline of an element and its children
+func outlineHTML(element *goquery.Selection, depth int, lines *[]string) {
+	name := goquery.NodeName(element)
+	if name == "style" || name == "script" {
+		return
+	}
+
+	line := strings.Repeat("  ", depth) + name
+	for _, attribute := range htmlAttributes {
+		if value, ok := element.Attr(attribute); ok {
+			line += fmt.Sprintf(" %s=%q", attribute, value)
+		}
+	}
+	var text strings.Builder
+	element.Contents().Each(func(_ int, child *goquery.Selection) {
+		if goquery.NodeName(child) == "#text" {
+			text.WriteString(child.Text())
+		}
+	})
+	if own := strings.TrimSpace(markdownSpacePattern.ReplaceAllString(text.String(), " ")); own != "" {
+		line += ": " + own
+	}
+	*lines = append(*lines, line)
+
+	element.Children().Each(func(_ int, child *goquery.Selection) {
+		outlineHTML(child, depth+1, lines)
+	})
+}
@@ -0,0 +1,708 @@
+// app/server/server/graphql.go
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/graph-gophers/graphql-go"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// GraphQL limits
+const (
+	// graphQLDefaultPage and graphQLMaxPage bound the nodes of a connection
+	graphQLDefaultPage = 50
+	graphQLMaxPage     = 500
+
+	// graphQLMaxDepth bounds the nesting of a query, e.g. report.previous.previous...
+	graphQLMaxDepth = 10
+
+	// graphQLMaxNodes bounds the nodes of connections and trends a query
+	// resolves in total; pages nested in pages multiply within the depth bound
+	graphQLMaxNodes = 10000
+
+	// graphQLMaxRequest bounds the size of a request body
+	graphQLMaxRequest = 1 << 20
+)
+
+// graphQLSchema is served at /api/graphql. Connections page with first and
+// after, where after is the endCursor of the previous page.
+const graphQLSchema = `
+schema {
+	query: Query
+}
+
+scalar Time
+
+type Query {
+	# Stored reports that are not archived, newest first; labels is a label selector such as "environment=prod"
+	reports(cluster: String, customer: String, labels: String, since: Time, until: Time, minScore: Float, maxScore: Float, first: Int, after: String): ReportConnection!
+	report(id: ID!): Report
+	# Registered clusters; labels is a label selector such as "environment=prod"
+	clusters(labels: String, first: Int, after: String): ClusterConnection!
+	cluster(name: String!): Cluster
+	# Score history of a cluster, oldest first
+	trend(cluster: String!, since: Time, until: Time): [TrendPoint!]!
+}
+
+type PageInfo {
+	hasNextPage: Boolean!
+	endCursor: String
+}
+
+type ReportConnection {
+	totalCount: Int!
+	pageInfo: PageInfo!
+	nodes: [Report!]!
+}
+
+type Report {
+	id: ID!
+	filename: String!
+	uploadedAt: Time!
+	# The registered cluster, or the cluster name in the document
+	cluster: String!
+	customer: String!
+	# The labels of the cluster, of the document and set through the API, in increasing precedence
+	labels: [Label!]!
+	overallScore: Float!
+	# The letter grade and red/amber/green status of the overall score by the configured grade bands
+	grade: String!
+	rag: String!
+	categories: [Category!]!
+	findings(status: String, category: String, severity: String, first: Int, after: String): FindingConnection!
+	# The previous report of the same cluster
+	previous: Report
+	# Archived reports are left out of listings, clusters and trends
+	archived: Boolean!
+}
+
+type Category {
+	name: String!
+	label: String!
+	score: Int!
+	grade: String!
+	rag: String!
+	description: String!
+	required: Int!
+	recommended: Int!
+	advisory: Int!
+	noChange: Int!
+	notApplicable: Int!
+}
+
+type FindingConnection {
+	totalCount: Int!
+	pageInfo: PageInfo!
+	nodes: [Finding!]!
+}
+
+type Finding {
+	id: String!
+	title: String!
+	category: String!
+	status: String!
+	severity: String!
+	observation: String!
+	recommendation: String!
+	references: [String!]!
+	impact: String!
+	effort: String!
+	source: String!
+}
+
+type ClusterConnection {
+	totalCount: Int!
+	pageInfo: PageInfo!
+	nodes: [Cluster!]!
+}
+
+type Cluster {
+	name: String!
+	labels: [Label!]!
+	org: String!
+	reportCount: Int!
+	latestReport: Report
+	reports(since: Time, until: Time, first: Int, after: String): ReportConnection!
+	trend(since: Time, until: Time): [TrendPoint!]!
+}
+
+type Label {
+	key: String!
+	value: String!
+}
+
+type TrendPoint {
+	reportId: ID!
+	uploadedAt: Time!
+	overallScore: Float!
+	categories: [Category!]!
+}
+`
+
+// errInvalidCursor is returned for an after argument that isn't an endCursor
+var errInvalidCursor = errors.New("invalid cursor")
+
+// newGraphQLSchema parses the schema with the server as the root resolver
+func (s *Server) newGraphQLSchema() *graphql.Schema {
+	return graphql.MustParseSchema(graphQLSchema, &graphQLQuery{s: s}, graphql.MaxDepth(graphQLMaxDepth))
+}
+
+// graphQLRequest is the body of a GraphQL request
+type graphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// graphQLResponse is the result of a GraphQL request
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data,omitempty"`
+	Errors []struct {
+		Message string `json:"message"`
+
+		// Path names the field of the error by field names and list indices
+		Path []interface{} `json:"path,omitempty"`
+	} `json:"errors,omitempty"`
+}
+
+// HandleGraphQL executes a GraphQL query
+func (s *Server) HandleGraphQL(w http.ResponseWriter, r *http.Request) {
+	var req graphQLRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, graphQLMaxRequest)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Query) == "" {
+		writeError(w, http.StatusBadRequest, "A GraphQL query is required")
+		return
+	}
+
+	// Errors of a query are part of the response, like the GraphQL over HTTP spec says
+	budget := new(atomic.Int64)
+	budget.Store(graphQLMaxNodes)
+	ctx := context.WithValue(r.Context(), graphQLBudgetKey{}, budget)
+	writeJSON(w, http.StatusOK, s.graphQL.Exec(ctx, req.Query, req.OperationName, req.Variables))
+}
+
+// graphQLBudgetKey is the context key of the nodes a query may still resolve
+type graphQLBudgetKey struct{}
+
+// spendNodes takes nodes from the budget of the query, failing the field once
+// the query resolves more than graphQLMaxNodes; fields resolve concurrently,
+// so the budget is shared atomically
+func spendNodes(ctx context.Context, nodes int) error {
+	budget, ok := ctx.Value(graphQLBudgetKey{}).(*atomic.Int64)
+	if !ok {
+		return nil
+	}
+	if budget.Add(-int64(nodes)) < 0 {
+		return fmt.Errorf("the query resolves more than %d nodes; ask for smaller pages", graphQLMaxNodes)
+	}
+	return nil
+}
+
+// graphQLQuery resolves the fields of Query
+type graphQLQuery struct {
+	s *Server
+}
+
+// page is the first and after arguments of a connection
+type page struct {
+	First *int32
+	After *string
+}
+
+// window returns the bounds of the page within total nodes
+func (p page) window(total int) (int, int, error) {
+	limit := graphQLDefaultPage
+	if p.First != nil {
+		if *p.First < 0 || *p.First > graphQLMaxPage {
+			return 0, 0, fmt.Errorf("first must be a number from 0 to %d", graphQLMaxPage)
+		}
+		limit = int(*p.First)
+	}
+	start := 0
+	if p.After != nil {
+		offset, err := decodeCursor(*p.After)
+		if err != nil {
+			return 0, 0, err
+		}
+		start = offset + 1
+	}
+	start = min(start, total)
+	return start, min(start+limit, total), nil
+}
+
+// encodeCursor and decodeCursor turn an offset into an opaque cursor and back
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte("offset:" + strconv.Itoa(offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errInvalidCursor
+	}
+	value, ok := strings.CutPrefix(string(data), "offset:")
+	if !ok {
+		return 0, errInvalidCursor
+	}
+	offset, err := strconv.Atoi(value)
+	if err != nil || offset < 0 {
+		return 0, errInvalidCursor
+	}
+	return offset, nil
+}
+
+// pageInfo resolves PageInfo
+type pageInfo struct {
+	end, total int
+}
+
+func (p pageInfo) HasNextPage() bool {
+	return p.end < p.total
+}
+
+func (p pageInfo) EndCursor() *string {
+	if p.end == 0 {
+		return nil
+	}
+	cursor := encodeCursor(p.end - 1)
+	return &cursor
+}
+
+// reportConnection resolves ReportConnection
+type reportConnection struct {
+	nodes []*reportResolver
+	info  pageInfo
+}
+
+func (c *reportConnection) TotalCount() int32 {
+	return int32(c.info.total)
+}
+
+func (c *reportConnection) PageInfo() pageInfo {
+	return c.info
+}
+
+func (c *reportConnection) Nodes() []*reportResolver {
+	return c.nodes
+}
+
+// newReportConnection pages the reports
+func newReportConnection(ctx context.Context, reports []*reportResolver, p page) (*reportConnection, error) {
+	start, end, err := p.window(len(reports))
+	if err != nil {
+		return nil, err
+	}
+	if err := spendNodes(ctx, end-start); err != nil {
+		return nil, err
+	}
+	return &reportConnection{nodes: reports[start:end], info: pageInfo{end: end, total: len(reports)}}, nil
+}
+
+// reportFilter selects reports by their cluster, customer, labels, time and score
+type reportFilter struct {
+	Cluster  *string
+	Customer *string
+	Labels   *string
+	Since    *graphql.Time
+	Until    *graphql.Time
+	MinScore *float64
+	MaxScore *float64
+}
+
+// matches reports whether a report passes the filter; the score is only
+// presented when a score filter is set
+func (f reportFilter) matches(report *reportResolver, selector labels.Selector) bool {
+	if f.Cluster != nil && !strings.EqualFold(strings.TrimSpace(report.report.ClusterKey()), strings.TrimSpace(*f.Cluster)) ||
+		f.Customer != nil && report.report.Summary.CustomerName != *f.Customer ||
+		f.Since != nil && report.report.UploadedAt.Before(f.Since.Time) ||
+		f.Until != nil && report.report.UploadedAt.After(f.Until.Time) ||
+		!selector.Empty() && !selector.Matches(labels.Set(report.s.effectiveLabels(report.report))) {
+		return false
+	}
+	if f.MinScore != nil && report.OverallScore() < *f.MinScore ||
+		f.MaxScore != nil && report.OverallScore() > *f.MaxScore {
+		return false
+	}
+	return true
+}
+
+// reports returns the stored reports passing the filter, newest first
+func (q *graphQLQuery) reports(filter reportFilter) ([]*reportResolver, error) {
+	selector, err := parseSelector(filter.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	var reports []*reportResolver
+	for _, report := range q.s.activeReports() {
+		resolver := &reportResolver{s: q.s, report: report}
+		if filter.matches(resolver, selector) {
+			reports = append(reports, resolver)
+		}
+	}
+	return reports, nil
+}
+
+// parseSelector parses an optional label selector argument
+func parseSelector(selector *string) (labels.Selector, error) {
+	if selector == nil {
+		return labels.Everything(), nil
+	}
+	parsed, err := labels.Parse(*selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+	return parsed, nil
+}
+
+// Reports resolves Query.reports
+func (q *graphQLQuery) Reports(ctx context.Context, args struct {
+	reportFilter
+	page
+}) (*reportConnection, error) {
+	reports, err := q.reports(args.reportFilter)
+	if err != nil {
+		return nil, err
+	}
+	return newReportConnection(ctx, reports, args.page)
+}
+
+// Report resolves Query.report
+func (q *graphQLQuery) Report(args struct{ ID graphql.ID }) (*reportResolver, error) {
+	report, err := q.s.store.Get(string(args.ID))
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &reportResolver{s: q.s, report: report}, nil
+}
+
+// Clusters resolves Query.clusters
+func (q *graphQLQuery) Clusters(ctx context.Context, args struct {
+	Labels *string
+	page
+}) (*clusterConnection, error) {
+	selector, err := parseSelector(args.Labels)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*clusterResolver
+	for _, cluster := range selectClusters(q.s.clusters.List(), selector) {
+		selected = append(selected, &clusterResolver{q: q, cluster: cluster})
+	}
+
+	start, end, err := args.page.window(len(selected))
+	if err != nil {
+		return nil, err
+	}
+	if err := spendNodes(ctx, end-start); err != nil {
+		return nil, err
+	}
+	return &clusterConnection{nodes: selected[start:end], info: pageInfo{end: end, total: len(selected)}}, nil
+}
+
+// Cluster resolves Query.cluster
+func (q *graphQLQuery) Cluster(args struct{ Name string }) *clusterResolver {
+	cluster, err := q.s.clusters.Get(args.Name)
+	if err != nil {
+		return nil
+	}
+	return &clusterResolver{q: q, cluster: cluster}
+}
+
+// trendRange is the time arguments of a trend
+type trendRange struct {
+	Since *graphql.Time
+	Until *graphql.Time
+}
+
+// Trend resolves Query.trend
+func (q *graphQLQuery) Trend(ctx context.Context, args struct {
+	Cluster string
+	trendRange
+}) ([]*reportResolver, error) {
+	return q.trend(ctx, args.Cluster, args.trendRange)
+}
+
+// trend returns the reports of a cluster within the range, oldest first
+func (q *graphQLQuery) trend(ctx context.Context, cluster string, within trendRange) ([]*reportResolver, error) {
+	// Without a label selector the filter never fails
+	points, _ := q.reports(reportFilter{Cluster: &cluster, Since: within.Since, Until: within.Until})
+	if err := spendNodes(ctx, len(points)); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(points, func(i, j int) bool {
+		return points[i].report.UploadedAt.Before(points[j].report.UploadedAt)
+	})
+	return points, nil
+}
+
+// reportResolver resolves Report and TrendPoint; the summary is presented
+// with waivers and categories once, the first time a field needs it
+type reportResolver struct {
+	s      *Server
+	report *store.Report
+
+	once      sync.Once
+	presented *types.ReportSummary
+}
+
+// summary returns the presented summary of the report
+func (r *reportResolver) summary() *types.ReportSummary {
+	r.once.Do(func() {
+		r.presented = r.s.presentSummary(r.report, r.report.Summary)
+	})
+	return r.presented
+}
+
+func (r *reportResolver) ID() graphql.ID {
+	return graphql.ID(r.report.ID)
+}
+
+func (r *reportResolver) ReportID() graphql.ID {
+	return r.ID()
+}
+
+func (r *reportResolver) Filename() string {
+	return r.report.Filename
+}
+
+func (r *reportResolver) UploadedAt() graphql.Time {
+	return graphql.Time{Time: r.report.UploadedAt}
+}
+
+func (r *reportResolver) Cluster() string {
+	return strings.TrimSpace(r.report.ClusterKey())
+}
+
+func (r *reportResolver) Customer() string {
+	return r.report.Summary.CustomerName
+}
+
+// Labels resolves Report.labels, sorted by key
+func (r *reportResolver) Labels() []labelResolver {
+	return sortedLabels(r.s.effectiveLabels(r.report))
+}
+
+func (r *reportResolver) OverallScore() float64 {
+	return r.summary().OverallScore
+}
+
+func (r *reportResolver) Grade() string {
+	return r.summary().Grade
+}
+
+func (r *reportResolver) RAG() string {
+	return r.summary().RAG
+}
+
+func (r *reportResolver) Categories() []categoryResolver {
+	categories := make([]categoryResolver, 0, len(r.summary().Categories))
+	for _, category := range r.summary().Categories {
+		categories = append(categories, categoryResolver{category})
+	}
+	return categories
+}
+
+// Findings resolves Report.findings; status, category and severity match
+// case-insensitively
+func (r *reportResolver) Findings(ctx context.Context, args struct {
+	Status   *string
+	Category *string
+	Severity *string
+	page
+}) (*findingConnection, error) {
+	var findings []findingResolver
+	for _, finding := range r.summary().Findings {
+		if args.Status != nil && !strings.EqualFold(string(finding.Status), *args.Status) ||
+			args.Category != nil && !strings.EqualFold(finding.Category, *args.Category) ||
+			args.Severity != nil && !strings.EqualFold(string(finding.Severity), *args.Severity) {
+			continue
+		}
+		findings = append(findings, findingResolver{finding})
+	}
+
+	start, end, err := args.page.window(len(findings))
+	if err != nil {
+		return nil, err
+	}
+	if err := spendNodes(ctx, end-start); err != nil {
+		return nil, err
+	}
+	return &findingConnection{nodes: findings[start:end], info: pageInfo{end: end, total: len(findings)}}, nil
+}
+
+func (r *reportResolver) Previous() *reportResolver {
+	previous := r.s.previousReport(r.report)
+	if previous == nil {
+		return nil
+	}
+	return &reportResolver{s: r.s, report: previous}
+}
+
+func (r *reportResolver) Archived() bool {
+	return r.report.Archived()
+}
+
+// categoryResolver resolves Category
+type categoryResolver struct {
+	category types.CategoryScore
+}
+
+func (c categoryResolver) Name() string         { return c.category.Name }
+func (c categoryResolver) Label() string        { return c.category.Label }
+func (c categoryResolver) Score() int32         { return int32(c.category.Score) }
+func (c categoryResolver) Grade() string        { return c.category.Grade }
+func (c categoryResolver) RAG() string          { return c.category.RAG }
+func (c categoryResolver) Description() string  { return c.category.Description }
+func (c categoryResolver) Required() int32      { return int32(c.category.Required) }
+func (c categoryResolver) Recommended() int32   { return int32(c.category.Recommended) }
+func (c categoryResolver) Advisory() int32      { return int32(c.category.Advisory) }
+func (c categoryResolver) NoChange() int32      { return int32(c.category.NoChange) }
+func (c categoryResolver) NotApplicable() int32 { return int32(c.category.NotApplicable) }
+
+// findingConnection resolves FindingConnection
+type findingConnection struct {
+	nodes []findingResolver
+	info  pageInfo
+}
+
+func (c *findingConnection) TotalCount() int32 {
+	return int32(c.info.total)
+}
+
+func (c *findingConnection) PageInfo() pageInfo {
+	return c.info
+}
+
+func (c *findingConnection) Nodes() []findingResolver {
+	return c.nodes
+}
+
+// findingResolver resolves Finding
+type findingResolver struct {
+	finding types.Finding
+}
+
+func (f findingResolver) ID() string             { return f.finding.ID }
+func (f findingResolver) Title() string          { return f.finding.Title }
+func (f findingResolver) Category() string       { return f.finding.Category }
+func (f findingResolver) Status() string         { return string(f.finding.Status) }
+func (f findingResolver) Severity() string       { return string(f.finding.Severity) }
+func (f findingResolver) Observation() string    { return f.finding.Observation }
+func (f findingResolver) Recommendation() string { return f.finding.Recommendation }
+func (f findingResolver) Impact() string         { return f.finding.Impact }
+func (f findingResolver) Effort() string         { return f.finding.Effort }
+func (f findingResolver) Source() string         { return f.finding.Source }
+
+func (f findingResolver) References() []string {
+	if f.finding.References == nil {
+		return []string{}
+	}
+	return f.finding.References
+}
+
+// clusterConnection resolves ClusterConnection
+type clusterConnection struct {
+	nodes []*clusterResolver
+	info  pageInfo
+}
+
+func (c *clusterConnection) TotalCount() int32 {
+	return int32(c.info.total)
+}
+
+func (c *clusterConnection) PageInfo() pageInfo {
+	return c.info
+}
+
+func (c *clusterConnection) Nodes() []*clusterResolver {
+	return c.nodes
+}
+
+// clusterResolver resolves Cluster
+type clusterResolver struct {
+	q       *graphQLQuery
+	cluster clusters.Cluster
+}
+
+// reports returns the reports of the cluster within the range, newest first
+func (c *clusterResolver) reports(since, until *graphql.Time) []*reportResolver {
+	reports, _ := c.q.reports(reportFilter{Cluster: &c.cluster.Name, Since: since, Until: until})
+	return reports
+}
+
+func (c *clusterResolver) Name() string {
+	return c.cluster.Name
+}
+
+func (c *clusterResolver) Org() string {
+	return c.cluster.Org
+}
+
+// Labels resolves Cluster.labels, sorted by key
+func (c *clusterResolver) Labels() []labelResolver {
+	return sortedLabels(c.cluster.Labels)
+}
+
+func (c *clusterResolver) ReportCount() int32 {
+	return int32(len(c.reports(nil, nil)))
+}
+
+func (c *clusterResolver) LatestReport() *reportResolver {
+	report := c.q.s.latestReport(c.cluster.Name)
+	if report == nil {
+		return nil
+	}
+	return &reportResolver{s: c.q.s, report: report}
+}
+
+func (c *clusterResolver) Reports(ctx context.Context, args struct {
+	trendRange
+	page
+}) (*reportConnection, error) {
+	return newReportConnection(ctx, c.reports(args.Since, args.Until), args.page)
+}
+
+func (c *clusterResolver) Trend(ctx context.Context, args struct{ trendRange }) ([]*reportResolver, error) {
+	return c.q.trend(ctx, c.cluster.Name, args.trendRange)
+}
+
+// sortedLabels returns a label set as Labels sorted by key
+func sortedLabels(set map[string]string) []labelResolver {
+	sorted := make([]labelResolver, 0, len(set))
+	for key, value := range set {
+		sorted = append(sorted, labelResolver{key: key, value: value})
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].key < sorted[j].key })
+	return sorted
+}
+
+// labelResolver resolves Label
+type labelResolver struct {
+	key, value string
+}
+
+func (l labelResolver) Key() string   { return l.key }
+func (l labelResolver) Value() string { return l.value }
@@ -0,0 +1,64 @@
+// app/server/server/graphql_test.go
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+func TestGraphQLNodeBudget(t *testing.T) {
+	output := log.Writer()
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(output) })
+
+	s := newGoldenServer(t)
+	s.graphQL = s.newGraphQLSchema()
+
+	// More findings in total than the budget, but few enough per report for one page
+	findings := make([]types.Finding, graphQLMaxPage)
+	for i := range findings {
+		findings[i] = types.Finding{ID: fmt.Sprintf("F-%d", i), Title: "Finding", Status: types.ResultKeyRequired}
+	}
+	for i := 0; i <= graphQLMaxNodes/graphQLMaxPage; i++ {
+		_, err := s.store.Create(&store.Report{
+			ID:       store.NewID(),
+			Filename: "report.adoc",
+			Summary:  &types.ReportSummary{ClusterName: fmt.Sprintf("cluster-%d", i), Findings: findings},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	query := func(query string) graphQLResponse {
+		t.Helper()
+		body, _ := json.Marshal(graphQLRequest{Query: query})
+		w := httptest.NewRecorder()
+		s.HandleGraphQL(w, httptest.NewRequest(http.MethodPost, "/api/graphql", strings.NewReader(string(body))))
+		var response graphQLResponse
+		if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+			t.Fatal(err)
+		}
+		return response
+	}
+
+	// Every page is within the page limit, but together they exceed the budget
+	response := query(`{ reports(first: 500) { nodes { findings(first: 500) { nodes { id } } } } }`)
+	if len(response.Errors) == 0 || !strings.Contains(response.Errors[0].Message, "more than") {
+		t.Errorf("query beyond the node budget returned errors %+v", response.Errors)
+	}
+
+	response = query(`{ reports(first: 5) { nodes { findings(first: 500) { nodes { id } } } } }`)
+	if len(response.Errors) > 0 {
+		t.Errorf("query within the node budget returned errors %+v", response.Errors)
+	}
+}
@@ -0,0 +1,374 @@
+// app/server/server/groups.go
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/groups"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// Trend intervals
+const (
+	trendWeek  = "week"
+	trendMonth = "month"
+)
+
+// maxTrendPeriods bounds the periods a trend can span
+const maxTrendPeriods = 104
+
+// groupCategory is a score category shown in group rollups
+type groupCategory struct {
+	Key   string `json:"key"`
+	Label string `json:"label"`
+	score func(summary *types.ReportSummary) int
+}
+
+// groupCategories are the categories of the rollups, in display order
+var groupCategories = []groupCategory{
+	{Key: utils.FieldScoreInfra, Label: "Infrastructure Setup", score: func(s *types.ReportSummary) int { return s.ScoreInfra }},
+	{Key: utils.FieldScoreGovernance, Label: "Policy Governance", score: func(s *types.ReportSummary) int { return s.ScoreGovernance }},
+	{Key: utils.FieldScoreCompliance, Label: "Compliance Benchmarking", score: func(s *types.ReportSummary) int { return s.ScoreCompliance }},
+	{Key: utils.FieldScoreMonitoring, Label: "Central Monitoring", score: func(s *types.ReportSummary) int { return s.ScoreMonitoring }},
+	{Key: utils.FieldScoreBuildSecurity, Label: "Build/Deploy Security", score: func(s *types.ReportSummary) int { return s.ScoreBuildSecurity }},
+}
+
+// findingCounts counts the open findings by status
+type findingCounts struct {
+	Required    int `json:"required"`
+	Recommended int `json:"recommended"`
+	Advisory    int `json:"advisory"`
+}
+
+// add adds the open findings of a summary
+func (c *findingCounts) add(summary *types.ReportSummary) {
+	v2 := summary.V2()
+	c.Required += len(v2.ItemsRequired)
+	c.Recommended += len(v2.ItemsRecommended)
+	c.Advisory += len(v2.ItemsAdvisory)
+}
+
+// groupEntry is a cluster group in the group listing
+type groupEntry struct {
+	groups.Group
+	Clusters     []string `json:"clusters"`
+	AverageScore *float64 `json:"averageScore"`
+}
+
+// groupCluster is a member of a group with its latest report
+type groupCluster struct {
+	Name         string               `json:"name"`
+	Labels       map[string]string    `json:"labels,omitempty"`
+	LatestReport *clusterLatestReport `json:"latestReport,omitempty"`
+	Scores       map[string]int       `json:"scores,omitempty"`
+	OpenFindings findingCounts        `json:"openFindings"`
+}
+
+// groupSummary aggregates the latest report of every cluster in a group
+type groupSummary struct {
+	groups.Group
+	ClusterCount     int                `json:"clusterCount"`
+	ReportedClusters int                `json:"reportedClusters"`
+	AverageScore     *float64           `json:"averageScore"`
+	CategoryAverages map[string]float64 `json:"categoryAverages"`
+	OpenFindings     findingCounts      `json:"openFindings"`
+	Clusters         []groupCluster     `json:"clusters"`
+}
+
+// trendPoint is the group average at the end of one period
+type trendPoint struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+
+	// AverageScore averages the latest report of each cluster as of the end of
+	// the period; it is null before any cluster of the group was assessed
+	AverageScore     *float64 `json:"averageScore"`
+	ReportedClusters int      `json:"reportedClusters"`
+}
+
+// groupTrend is the score history of a group
+type groupTrend struct {
+	Group    string       `json:"group"`
+	Interval string       `json:"interval"`
+	Points   []trendPoint `json:"points"`
+}
+
+// heatmapRow holds the category scores of one cluster, in the order of the categories
+type heatmapRow struct {
+	Cluster  string `json:"cluster"`
+	ReportID string `json:"reportId"`
+	Scores   []int  `json:"scores"`
+}
+
+// groupHeatmap is a cluster by category score matrix of a group
+type groupHeatmap struct {
+	Group      string          `json:"group"`
+	Categories []groupCategory `json:"categories"`
+	Rows       []heatmapRow    `json:"rows"`
+
+	// Unreported lists the clusters of the group that have no report yet
+	Unreported []string `json:"unreported"`
+}
+
+// HandleListGroups returns the cluster groups with their members and current average score
+func (s *Server) HandleListGroups(w http.ResponseWriter, r *http.Request) {
+	reports := s.reportsByCluster()
+	registered := s.clusters.List()
+
+	entries := []groupEntry{}
+	for _, group := range s.settings.Current().Groups {
+		entry := groupEntry{Group: group, Clusters: []string{}}
+		var latest []*store.Report
+		for _, cluster := range groupMembers(group, registered) {
+			entry.Clusters = append(entry.Clusters, cluster.Name)
+			if history := reports[strings.ToLower(cluster.Name)]; len(history) > 0 {
+				latest = append(latest, history[0])
+			}
+		}
+		entry.AverageScore = averageOverall(latest)
+		entries = append(entries, entry)
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// HandleGroupSummary aggregates the scores and open findings of a group
+func (s *Server) HandleGroupSummary(w http.ResponseWriter, r *http.Request) {
+	group, ok := s.lookupGroup(w, r)
+	if !ok {
+		return
+	}
+	selector, ok := labelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	reports := s.reportsByCluster()
+	summary := groupSummary{
+		Group:            group,
+		CategoryAverages: make(map[string]float64),
+		Clusters:         []groupCluster{},
+	}
+
+	var latest []*store.Report
+	for _, cluster := range groupMembers(group, selectClusters(s.clusters.List(), selector)) {
+		member := groupCluster{Name: cluster.Name, Labels: cluster.Labels}
+		if history := reports[strings.ToLower(cluster.Name)]; len(history) > 0 {
+			report := history[0]
+			latest = append(latest, report)
+			member.LatestReport = newClusterEntry(cluster, history).LatestReport
+			member.Scores = make(map[string]int, len(groupCategories))
+			for _, category := range groupCategories {
+				member.Scores[category.Key] = category.score(report.Summary)
+			}
+			member.OpenFindings.add(report.Summary)
+			summary.OpenFindings.add(report.Summary)
+		}
+		summary.Clusters = append(summary.Clusters, member)
+	}
+
+	summary.ClusterCount = len(summary.Clusters)
+	summary.ReportedClusters = len(latest)
+	summary.AverageScore = averageOverall(latest)
+	if len(latest) > 0 {
+		for _, category := range groupCategories {
+			total := 0
+			for _, report := range latest {
+				total += category.score(report.Summary)
+			}
+			summary.CategoryAverages[category.Key] = float64(total) / float64(len(latest))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// HandleGroupTrend returns the average score of a group per week or month
+func (s *Server) HandleGroupTrend(w http.ResponseWriter, r *http.Request) {
+	group, ok := s.lookupGroup(w, r)
+	if !ok {
+		return
+	}
+	selector, ok := labelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	interval := r.URL.Query().Get("interval")
+	if interval == "" {
+		interval = trendWeek
+	}
+	if interval != trendWeek && interval != trendMonth {
+		writeError(w, http.StatusBadRequest, "interval must be week or month")
+		return
+	}
+
+	periods := 12
+	if value := r.URL.Query().Get("periods"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 || parsed > maxTrendPeriods {
+			writeErrorf(w, http.StatusBadRequest, "periods must be a number from 1 to %d", maxTrendPeriods)
+			return
+		}
+		periods = parsed
+	}
+
+	// Each member's history, oldest first, to carry its latest report forward
+	reports := s.reportsByCluster()
+	var histories [][]*store.Report
+	for _, cluster := range groupMembers(group, selectClusters(s.clusters.List(), selector)) {
+		history := append([]*store.Report(nil), reports[strings.ToLower(cluster.Name)]...)
+		sort.Slice(history, func(i, j int) bool { return history[i].UploadedAt.Before(history[j].UploadedAt) })
+		histories = append(histories, history)
+	}
+
+	trend := groupTrend{Group: group.Name, Interval: interval, Points: []trendPoint{}}
+	for _, start := range trendPeriods(time.Now().UTC(), interval, periods) {
+		end := nextPeriod(start, interval)
+		point := trendPoint{Start: start, End: end}
+
+		var latest []*store.Report
+		for _, history := range histories {
+			if report := latestBefore(history, end); report != nil {
+				latest = append(latest, report)
+			}
+		}
+		point.ReportedClusters = len(latest)
+		point.AverageScore = averageOverall(latest)
+		trend.Points = append(trend.Points, point)
+	}
+
+	writeJSON(w, http.StatusOK, trend)
+}
+
+// HandleGroupHeatmap returns the latest category scores of every cluster in a group
+func (s *Server) HandleGroupHeatmap(w http.ResponseWriter, r *http.Request) {
+	group, ok := s.lookupGroup(w, r)
+	if !ok {
+		return
+	}
+	selector, ok := labelSelector(w, r)
+	if !ok {
+		return
+	}
+
+	reports := s.reportsByCluster()
+	heatmap := groupHeatmap{
+		Group:      group.Name,
+		Categories: groupCategories,
+		Rows:       []heatmapRow{},
+		Unreported: []string{},
+	}
+	for _, cluster := range groupMembers(group, selectClusters(s.clusters.List(), selector)) {
+		history := reports[strings.ToLower(cluster.Name)]
+		if len(history) == 0 {
+			heatmap.Unreported = append(heatmap.Unreported, cluster.Name)
+			continue
+		}
+
+		row := heatmapRow{Cluster: cluster.Name, ReportID: history[0].ID}
+		for _, category := range groupCategories {
+			row.Scores = append(row.Scores, category.score(history[0].Summary))
+		}
+		heatmap.Rows = append(heatmap.Rows, row)
+	}
+
+	writeJSON(w, http.StatusOK, heatmap)
+}
+
+// lookupGroup resolves the group named in the request path, writing a 404 if it doesn't exist
+func (s *Server) lookupGroup(w http.ResponseWriter, r *http.Request) (groups.Group, bool) {
+	group, ok := s.settings.Current().Group(r.PathValue("name"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Group not found")
+	}
+	return group, ok
+}
+
+// reportsByCluster groups the reports of registered clusters by lowercased
+// cluster name, newest first
+func (s *Server) reportsByCluster() map[string][]*store.Report {
+	reports := make(map[string][]*store.Report)
+	for _, report := range s.activeReports() {
+		if report.Cluster != "" && report.Summary != nil {
+			name := strings.ToLower(report.Cluster)
+			reports[name] = append(reports[name], report)
+		}
+	}
+	return reports
+}
+
+// groupMembers returns the registered clusters a group selects
+func groupMembers(group groups.Group, registered []clusters.Cluster) []clusters.Cluster {
+	var members []clusters.Cluster
+	for _, cluster := range registered {
+		if group.Matches(cluster.Labels) {
+			members = append(members, cluster)
+		}
+	}
+	return members
+}
+
+// averageOverall returns the mean overall score of reports, or nil without reports
+func averageOverall(reports []*store.Report) *float64 {
+	if len(reports) == 0 {
+		return nil
+	}
+	total := 0.0
+	for _, report := range reports {
+		total += report.Summary.OverallScore
+	}
+	average := total / float64(len(reports))
+	return &average
+}
+
+// latestBefore returns the newest report of an oldest-first history uploaded before t
+func latestBefore(history []*store.Report, t time.Time) *store.Report {
+	var latest *store.Report
+	for _, report := range history {
+		if !report.UploadedAt.Before(t) {
+			break
+		}
+		latest = report
+	}
+	return latest
+}
+
+// trendPeriods returns the start of the last n periods up to and including the current one, oldest first
+func trendPeriods(now time.Time, interval string, n int) []time.Time {
+	var start time.Time
+	switch interval {
+	case trendMonth:
+		start = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		// Weeks start on Monday
+		day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		start = day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	}
+
+	periods := make([]time.Time, n)
+	for i := n - 1; i >= 0; i-- {
+		periods[i] = start
+		if interval == trendMonth {
+			start = start.AddDate(0, -1, 0)
+		} else {
+			start = start.AddDate(0, 0, -7)
+		}
+	}
+	return periods
+}
+
+// nextPeriod returns the start of the period after the one starting at start
+func nextPeriod(start time.Time, interval string) time.Time {
+	if interval == trendMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
@@ -0,0 +1,70 @@
+// app/server/server/imports.go
+package server
+
+import (
+	"sort"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// attachImport stores the findings imported from a source with a report,
+// replacing the previous import from the same source
+func (s *Server) attachImport(report *store.Report, source string, imported *store.Import) error {
+	_, err := s.store.Mutate(report.ID, func(report *store.Report) error {
+		if report.Imported == nil {
+			report.Imported = make(map[string]*store.Import)
+		}
+		report.Imported[source] = imported
+		return nil
+	})
+	return err
+}
+
+// withImported returns a copy of a summary whose findings include the
+// findings imported into the report, with the imported scores replacing the
+// extracted ones; the summary itself is not changed
+func withImported(report *store.Report, summary *types.ReportSummary) *types.ReportSummary {
+	if len(report.Imported) == 0 {
+		return summary
+	}
+
+	// Merge the sources in a stable order
+	sources := make([]string, 0, len(report.Imported))
+	for source := range report.Imported {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	merged := *summary
+	merged.Findings = append([]types.Finding{}, summary.Findings...)
+	for _, source := range sources {
+		merged.Findings = append(merged.Findings, report.Imported[source].Findings...)
+	}
+	applyImportedScores(report, &merged)
+	return &merged
+}
+
+// applyImportedScores replaces the scores extracted from the document with
+// the ones measured by the imports, recording the source as their strategy
+func applyImportedScores(report *store.Report, summary *types.ReportSummary) {
+	extraction := make(map[string]string, len(summary.Extraction))
+	for field, strategy := range summary.Extraction {
+		extraction[field] = strategy
+	}
+
+	changed := false
+	for source, imported := range report.Imported {
+		for field, score := range imported.Scores {
+			if target := utils.CategoryScore(summary, field); target != nil {
+				*target = score
+				extraction[field] = source
+				changed = true
+			}
+		}
+	}
+	if changed {
+		summary.Extraction = extraction
+	}
+}
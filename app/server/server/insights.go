@@ -0,0 +1,99 @@
+// app/server/server/insights.go
+package server
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/insights"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// insightsImportResponse is returned by the Insights import endpoint
+type insightsImportResponse struct {
+	Report     string          `json:"report"`
+	ClusterID  string          `json:"clusterId"`
+	ImportedAt time.Time       `json:"importedAt"`
+	Findings   []types.Finding `json:"findings"`
+}
+
+// HandleImportInsights pulls the Insights Advisor recommendations of a
+// registered cluster and attaches them to its latest report, replacing the
+// previous import
+func (s *Server) HandleImportInsights(w http.ResponseWriter, r *http.Request) {
+	if s.insights == nil {
+		writeError(w, http.StatusServiceUnavailable, "Insights integration is not configured")
+		return
+	}
+
+	cluster, ok := s.lookupCluster(w, r)
+	if !ok {
+		return
+	}
+	if cluster.InsightsID == "" {
+		writeError(w, http.StatusConflict, "Cluster has no insightsId")
+		return
+	}
+
+	report := s.latestReport(cluster.Name)
+	if report == nil {
+		writeError(w, http.StatusConflict, "Cluster has no report to attach the recommendations to")
+		return
+	}
+
+	recommendations, err := s.insights.Recommendations(r.Context(), cluster.InsightsID)
+	if errors.Is(err, insights.ErrUnknownCluster) {
+		writeError(w, http.StatusNotFound, "Insights has no results for the cluster")
+		return
+	}
+	if err != nil {
+		log.Printf("Error fetching Insights recommendations for cluster %s: %v", cluster.Name, err)
+		writeError(w, http.StatusBadGateway, "Error fetching Insights recommendations")
+		return
+	}
+
+	findings := make([]types.Finding, 0, len(recommendations))
+	for _, recommendation := range recommendations {
+		findings = append(findings, recommendation.Finding())
+	}
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRank(findings[i].Severity) > severityRank(findings[j].Severity)
+	})
+
+	imported := &store.Import{ImportedAt: time.Now().UTC(), Findings: findings}
+	if err := s.attachImport(report, insights.Source, imported); err != nil {
+		log.Printf("Error saving Insights recommendations for report %s: %v", report.ID, err)
+		writeError(w, http.StatusInternalServerError, "Error saving the recommendations")
+		return
+	}
+
+	if s.config.DebugMode {
+		log.Printf("Imported %d Insights recommendations for cluster %s into report %s",
+			len(findings), cluster.Name, report.ID)
+	}
+
+	writeJSON(w, http.StatusOK, insightsImportResponse{
+		Report:     report.ID,
+		ClusterID:  cluster.InsightsID,
+		ImportedAt: imported.ImportedAt,
+		Findings:   findings,
+	})
+}
+
+// severityRank orders severities from none to high
+func severityRank(severity types.Severity) int {
+	switch severity {
+	case types.SeverityHigh:
+		return 3
+	case types.SeverityMedium:
+		return 2
+	case types.SeverityLow:
+		return 1
+	default:
+		return 0
+	}
+}
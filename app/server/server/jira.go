@@ -0,0 +1,179 @@
+// app/server/server/jira.go
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/i18n"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/jira"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// jiraIssueResult describes the outcome for a single exported item
+type jiraIssueResult struct {
+	Item   string `json:"item"`
+	Status string `json:"status"`
+	Key    string `json:"key"`
+	URL    string `json:"url"`
+}
+
+// jiraExportResponse is returned by the Jira export endpoint
+type jiraExportResponse struct {
+	Created  []jiraIssueResult `json:"created"`
+	Existing []jiraIssueResult `json:"existing"`
+	Failed   []jiraIssueResult `json:"failed"`
+}
+
+// HandleCreateJiraIssues creates one Jira issue per required and recommended item of a report
+func (s *Server) HandleCreateJiraIssues(w http.ResponseWriter, r *http.Request) {
+	if s.jira == nil {
+		writeError(w, http.StatusServiceUnavailable, "Jira integration is not configured")
+		return
+	}
+
+	report, ok := s.lookupReport(w, r)
+	if !ok || !s.requireApproved(w, report) {
+		return
+	}
+
+	// Collect the issue keys apart from the stored report, which other
+	// requests may be reading
+	issues := make(map[string]string)
+
+	response := jiraExportResponse{
+		Created:  []jiraIssueResult{},
+		Existing: []jiraIssueResult{},
+		Failed:   []jiraIssueResult{},
+	}
+
+	exportItems := func(items []string, status types.ResultKey, priority string) {
+		for _, item := range items {
+			result := jiraIssueResult{Item: item, Status: string(status)}
+			dedupeKey := jiraDedupeKey(report.ClusterKey(), status, item)
+
+			// Skip items we already exported for this report
+			key, found := issues[dedupeKey]
+			if !found {
+				key, found = report.JiraIssues[dedupeKey]
+			}
+			if found {
+				result.Key, result.URL = key, s.jira.BrowseURL(key)
+				response.Existing = append(response.Existing, result)
+				continue
+			}
+
+			// Check Jira itself so re-exports from another report don't duplicate issues
+			key, err := s.jira.FindIssueByLabel(r.Context(), dedupeKey)
+			if err != nil {
+				log.Printf("Error searching Jira for %s: %v", dedupeKey, err)
+				response.Failed = append(response.Failed, result)
+				continue
+			}
+
+			if key != "" {
+				issues[dedupeKey] = key
+				result.Key, result.URL = key, s.jira.BrowseURL(key)
+				response.Existing = append(response.Existing, result)
+				continue
+			}
+
+			key, err = s.jira.CreateIssue(r.Context(), s.jiraIssueRequest(report, item, status, priority, dedupeKey, locale(r)))
+			if err != nil {
+				log.Printf("Error creating Jira issue for %q: %v", item, err)
+				response.Failed = append(response.Failed, result)
+				continue
+			}
+
+			issues[dedupeKey] = key
+			result.Key, result.URL = key, s.jira.BrowseURL(key)
+			response.Created = append(response.Created, result)
+		}
+	}
+
+	config := s.jira.Config()
+	exportItems(report.Summary.ItemsRequired, types.ResultKeyRequired, config.PriorityRequired)
+	exportItems(report.Summary.ItemsRecommended, types.ResultKeyRecommended, config.PriorityRecommended)
+
+	// Remember the created issues so the next run can skip them without querying Jira
+	if len(issues) > 0 {
+		if _, err := s.store.Mutate(report.ID, func(report *store.Report) error {
+			if report.JiraIssues == nil {
+				report.JiraIssues = make(map[string]string, len(issues))
+			}
+			for dedupeKey, key := range issues {
+				report.JiraIssues[dedupeKey] = key
+			}
+			return nil
+		}); err != nil {
+			log.Printf("Error saving Jira issue keys for report %s: %v", report.ID, err)
+		}
+	}
+
+	if s.config.DebugMode {
+		log.Printf("Jira export for report %s: %d created, %d existing, %d failed",
+			report.ID, len(response.Created), len(response.Existing), len(response.Failed))
+	}
+
+	status := http.StatusOK
+	if len(response.Failed) > 0 {
+		status = http.StatusBadGateway
+	}
+	writeJSON(w, status, response)
+}
+
+// jiraIssueRequest builds the issue for a single report item, labelled in the
+// given language
+func (s *Server) jiraIssueRequest(report *store.Report, item string, status types.ResultKey, priority, dedupeKey, language string) jira.IssueRequest {
+	name, observation := utils.SplitItem(item)
+
+	statusLabel := "Changes Required"
+	if status == types.ResultKeyRecommended {
+		statusLabel = "Changes Recommended"
+	}
+	label := func(text string) string { return i18n.T(language, text) }
+
+	cluster := report.ClusterKey()
+	summary := name
+	if cluster != "" {
+		summary = fmt.Sprintf("[%s] %s", cluster, name)
+	}
+
+	var description strings.Builder
+	fmt.Fprintf(&description, "*%s:* %s\n", label("Status"), label(statusLabel))
+	if cluster != "" {
+		fmt.Fprintf(&description, "*%s:* %s\n", label("Cluster"), cluster)
+	}
+	if report.Summary.CustomerName != "" {
+		fmt.Fprintf(&description, "*%s:* %s\n", label("Customer"), report.Summary.CustomerName)
+	}
+	if observation != "" {
+		fmt.Fprintf(&description, "\n*%s:*\n%s\n", label("Observation"), observation)
+	}
+	fmt.Fprintf(&description, "\n*%s:* %s\n", label("Health check report"), s.reportURL(report.ID))
+
+	return jira.IssueRequest{
+		Summary:     summary,
+		Description: description.String(),
+		Priority:    priority,
+		Labels:      []string{"openshift-health-check", dedupeKey},
+	}
+}
+
+// reportURL returns the link to a stored report, absolute when a public URL is configured
+func (s *Server) reportURL(id string) string {
+	return strings.TrimRight(s.config.PublicURL, "/") + "/api/reports/" + id
+}
+
+// jiraDedupeKey returns a stable label identifying an item on a cluster
+func jiraDedupeKey(clusterName string, status types.ResultKey, item string) string {
+	name, _ := utils.SplitItem(item)
+	sum := sha256.Sum256([]byte(strings.ToLower(clusterName + "|" + string(status) + "|" + name)))
+	return "ohd-" + hex.EncodeToString(sum[:6])
+}
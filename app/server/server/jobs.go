@@ -0,0 +1,81 @@
+// app/server/server/jobs.go
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/jobs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/version"
+)
+
+// HandleRescoreReports re-parses every stored report in the batch class
+func (s *Server) HandleRescoreReports(w http.ResponseWriter, r *http.Request) {
+	reports := s.store.List()
+
+	queued := 0
+	for _, report := range reports {
+		_, err := s.queue.Submit(jobs.ClassBatch, "rescore", func(ctx context.Context) (interface{}, error) {
+			return nil, s.rescoreReport(ctx, report)
+		})
+		if err != nil {
+			writeError(w, http.StatusServiceUnavailable, "Job queue is not accepting work")
+			return
+		}
+		queued++
+	}
+
+	log.Printf("Queued %d reports for re-scoring", queued)
+	writeJSON(w, http.StatusAccepted, map[string]int{"queued": queued})
+}
+
+// HandleJobStats returns the per-class job queue metrics
+func (s *Server) HandleJobStats(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.queue.Stats())
+}
+
+// HandleGetJob returns the status of a job and, once it succeeded, its
+// result; finished jobs are kept for the configured retention
+func (s *Server) HandleGetJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.queue.Get(r.PathValue("id"))
+	if !ok {
+		writeError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
+
+// rescoreReport re-parses the raw document of a stored report with the current parser
+func (s *Server) rescoreReport(ctx context.Context, report *store.Report) error {
+	raw, err := s.store.OpenRaw(ctx, report.ID)
+	if err != nil {
+		return fmt.Errorf("error reading raw report %s: %w", report.ID, err)
+	}
+	defer raw.Close()
+
+	summary, err := s.parseReport(ctx, raw, utils.FormatForFilename(report.RawKey), report.Summary.ParserProfile, report.Summary.Language)
+	if err != nil {
+		return fmt.Errorf("error re-parsing report %s: %w", report.ID, err)
+	}
+	summary.ReportID = report.ID
+
+	// Keep the profile the report was scored with
+	profile, err := scoring.Preset(report.Summary.ScoringProfile)
+	if err != nil {
+		profile, _ = s.scoringProfile("", report.Cluster)
+	}
+	s.applyScoring(summary, profile)
+
+	// Replace the summary on a copy, as readers may hold the stored report
+	_, err = s.store.Mutate(report.ID, func(report *store.Report) error {
+		report.Summary = summary
+		report.ServerVersion = version.Version
+		return nil
+	})
+	return err
+}
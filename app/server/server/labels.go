@@ -0,0 +1,125 @@
+// app/server/server/labels.go
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// labelsParam documents the label selector of the list, overview and trend routes
+var labelsParam = queryParam("labels", `Kubernetes label selector, such as "environment=prod,region in (emea,apac)"`)
+
+// reportLabels is the labels of a report
+type reportLabels struct {
+	Report string `json:"report"`
+
+	// Labels are the labels set through the API
+	Labels map[string]string `json:"labels"`
+
+	// Effective merges the labels of the cluster, of the document and those
+	// set through the API, in increasing precedence
+	Effective map[string]string `json:"effective"`
+}
+
+// setLabelsRequest replaces the labels set through the API
+type setLabelsRequest struct {
+	Labels map[string]string `json:"labels"`
+}
+
+// labelSelector parses the labels query parameter, writing an error response
+// when it is invalid; without the parameter every label set matches
+func labelSelector(w http.ResponseWriter, r *http.Request) (labels.Selector, bool) {
+	value := r.URL.Query().Get("labels")
+	if value == "" {
+		return labels.Everything(), true
+	}
+	selector, err := labels.Parse(value)
+	if err != nil {
+		writeErrorf(w, http.StatusBadRequest, "Invalid label selector: %s", err.Error())
+		return nil, false
+	}
+	return selector, true
+}
+
+// effectiveLabels merges the labels of a report's registered cluster, of its
+// document and those set through the API
+func (s *Server) effectiveLabels(report *store.Report) map[string]string {
+	var clusterLabels map[string]string
+	if report.Cluster != "" {
+		if cluster, err := s.clusters.Get(report.Cluster); err == nil {
+			clusterLabels = cluster.Labels
+		}
+	}
+	return clusters.MergeLabels(clusterLabels, report.Summary.Labels, report.Labels)
+}
+
+// selectClusters returns the clusters whose labels match a selector
+func selectClusters(registered []clusters.Cluster, selector labels.Selector) []clusters.Cluster {
+	if selector.Empty() {
+		return registered
+	}
+	var selected []clusters.Cluster
+	for _, cluster := range registered {
+		if selector.Matches(labels.Set(cluster.Labels)) {
+			selected = append(selected, cluster)
+		}
+	}
+	return selected
+}
+
+// HandleGetReportLabels returns the labels of a report
+func (s *Server) HandleGetReportLabels(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.newReportLabels(report))
+}
+
+// HandleSetReportLabels replaces the labels set on a report through the API
+func (s *Server) HandleSetReportLabels(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	var req setLabelsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := clusters.ValidateLabels(req.Labels); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := s.store.Mutate(report.ID, func(report *store.Report) error {
+		report.Labels = req.Labels
+		if len(report.Labels) == 0 {
+			report.Labels = nil
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error saving labels of report %s: %v", report.ID, err)
+		writeError(w, http.StatusInternalServerError, "Failed to save labels")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.newReportLabels(report))
+}
+
+// newReportLabels lists the labels of a report
+func (s *Server) newReportLabels(report *store.Report) reportLabels {
+	own := report.Labels
+	if own == nil {
+		own = map[string]string{}
+	}
+	return reportLabels{Report: report.ID, Labels: own, Effective: s.effectiveLabels(report)}
+}
@@ -0,0 +1,42 @@
+// app/server/server/locale.go
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/i18n"
+)
+
+// localeKey is the context key of the negotiated response language
+type localeKey struct{}
+
+// withLocale negotiates the language of API responses and passes it on in
+// the request context
+func withLocale(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		language := negotiateLocale(w, r)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), localeKey{}, language)))
+	})
+}
+
+// negotiateLocale picks the response language from the "lang" query parameter
+// or the Accept-Language header and announces it with Content-Language, which
+// writeError translates its messages into
+func negotiateLocale(w http.ResponseWriter, r *http.Request) string {
+	language, ok := i18n.Match(r.URL.Query().Get("lang"))
+	if !ok {
+		language = i18n.Negotiate(r.Header.Get("Accept-Language"))
+		addVary(w.Header(), "Accept-Language")
+	}
+	w.Header().Set("Content-Language", language)
+	return language
+}
+
+// locale returns the language negotiated for a request
+func locale(r *http.Request) string {
+	if language, ok := r.Context().Value(localeKey{}).(string); ok {
+		return language
+	}
+	return i18n.DefaultLanguage
+}
@@ -0,0 +1,260 @@
+// app/server/server/merge.go
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/i18n"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/jobs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scanner"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// errMixedClusters is returned when merging reports of different registered clusters
+var errMixedClusters = errors.New("the reports belong to different clusters")
+
+// mergeRequest is the body of POST /reports/merge
+type mergeRequest struct {
+	// Reports are the IDs of the reports to merge, in order of precedence
+	Reports []string `json:"reports"`
+
+	// Cluster is the registered cluster of the consolidated report; by
+	// default it's the cluster of the merged reports
+	Cluster string `json:"cluster,omitempty"`
+
+	// Scoring is the scoring preset, like the scoring parameter of uploads
+	Scoring string `json:"scoring,omitempty"`
+}
+
+// HandleMergeReports combines reports delivered as several documents, such as
+// separate infrastructure, security and application health checks, into a
+// single consolidated report with a recomputed overall score
+func (s *Server) HandleMergeReports(w http.ResponseWriter, r *http.Request) {
+	var req mergeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Scoring != "" {
+		if _, err := scoring.Preset(req.Scoring); err != nil {
+			writeError(w, http.StatusBadRequest, "Unknown scoring preset")
+			return
+		}
+	}
+
+	ids := make([]string, 0, len(req.Reports))
+	seen := make(map[string]bool)
+	for _, id := range req.Reports {
+		if id = strings.TrimSpace(id); id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) < 2 {
+		writeError(w, http.StatusBadRequest, "Merging needs at least two reports")
+		return
+	}
+
+	reports := make([]*store.Report, 0, len(ids))
+	for _, id := range ids {
+		report, err := s.store.Get(id)
+		if errors.Is(err, store.ErrNotFound) {
+			writeErrorf(w, http.StatusNotFound, "Report %s not found", id)
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to load report")
+			return
+		}
+		reports = append(reports, report)
+	}
+
+	result, err := s.queue.Run(r.Context(), jobs.ClassInteractive, "merge", func(ctx context.Context) (interface{}, error) {
+		return s.mergeReports(ctx, reports, req.Cluster, req.Scoring)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errUnknownCluster):
+			writeError(w, http.StatusBadRequest, "Unknown cluster")
+		case errors.Is(err, errMixedClusters):
+			writeError(w, http.StatusBadRequest, "The reports belong to different clusters")
+		case errors.Is(err, errStoreReport):
+			writeError(w, http.StatusInternalServerError, "Failed to store report")
+		default:
+			writeErrorf(w, http.StatusInternalServerError, "Failed to merge reports: %s", err)
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, result)
+}
+
+// mergeReports stores a consolidated report of the findings of reports. Its
+// document is rendered from the merged findings, so it can be re-scored and
+// backed up like any other report.
+func (s *Server) mergeReports(ctx context.Context, reports []*store.Report, requestedCluster, requestedProfile string) (report *store.Report, err error) {
+	ctx, span := tracing.Start(ctx, "mergeReports", attribute.Int("reports", len(reports)))
+	defer func() { tracing.End(span, err) }()
+
+	cluster, err := s.mergedCluster(reports, requestedCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]*types.ReportSummary, 0, len(reports))
+	ids := make([]string, 0, len(reports))
+	for _, merged := range reports {
+		summaries = append(summaries, merged.Summary)
+		ids = append(ids, merged.ID)
+	}
+
+	// Names and labels come from the first report that has them; labels of
+	// earlier reports win
+	var clusterName, customerName string
+	labels := make(map[string]string)
+	for i := len(summaries) - 1; i >= 0; i-- {
+		if name := strings.TrimSpace(summaries[i].ClusterName); name != "" {
+			clusterName = name
+		}
+		if name := strings.TrimSpace(summaries[i].CustomerName); name != "" {
+			customerName = name
+		}
+		for key, value := range summaries[i].Labels {
+			labels[key] = value
+		}
+	}
+	switch {
+	case clusterName == "":
+		clusterName = cluster
+	case cluster == "":
+		cluster = s.clusters.Resolve(clusterName)
+	}
+
+	findings := mergeFindings(summaries)
+	results := make([]scanner.Result, 0, len(findings))
+	for _, finding := range findings {
+		results = append(results, scanner.Result{
+			Category:       finding.Category,
+			Item:           finding.Title,
+			Status:         finding.Status,
+			Observation:    finding.Observation,
+			Recommendation: finding.Recommendation,
+		})
+	}
+	intro := fmt.Sprintf("Consolidated health check merged from %d documents.", len(reports))
+	if clusterName != "" {
+		intro = fmt.Sprintf("Consolidated health check of cluster '%s' merged from %d documents.", clusterName, len(reports))
+	}
+
+	id := store.NewID()
+	key := store.RawKey(id, ".adoc")
+	document := scanner.RenderDocument(intro, labels, results)
+	if _, err := s.blobs.Put(ctx, key, bytes.NewReader(document)); err != nil {
+		return nil, fmt.Errorf("error storing merged document: %w", err)
+	}
+
+	summary, err := s.parseStoredDocument(ctx, key, utils.ParserProfileDefault, i18n.DefaultLanguage)
+	if err != nil {
+		s.blobs.Delete(context.Background(), key)
+		return nil, err
+	}
+	summary.ClusterName = clusterName
+	summary.CustomerName = customerName
+
+	profile, _ := s.scoringProfile(requestedProfile, cluster)
+	s.applyScoring(summary, profile)
+
+	filename := "merged-report.adoc"
+	if clusterName != "" {
+		filename = fmt.Sprintf("merged-%s.adoc", clusterName)
+	}
+	report, err = s.store.Create(&store.Report{
+		ID:         id,
+		Filename:   filename,
+		RawKey:     key,
+		Cluster:    cluster,
+		Summary:    summary,
+		MergedFrom: ids,
+	})
+	if err != nil {
+		s.blobs.Delete(context.Background(), key)
+		return nil, fmt.Errorf("%w: %v", errStoreReport, err)
+	}
+
+	s.notifyReport(report, notify.EventReportUploaded)
+	log.Printf("Merged reports %s into report %s (%d findings)", strings.Join(ids, ", "), report.ID, len(summary.Findings))
+	return report, nil
+}
+
+// mergedCluster returns the registered cluster of a consolidated report: the
+// requested one, or the one the merged reports belong to
+func (s *Server) mergedCluster(reports []*store.Report, requested string) (string, error) {
+	if requested != "" {
+		cluster := s.clusters.Resolve(requested)
+		if cluster == "" {
+			return "", errUnknownCluster
+		}
+		return cluster, nil
+	}
+
+	cluster := ""
+	for _, report := range reports {
+		switch {
+		case report.Cluster == "":
+		case cluster == "":
+			cluster = report.Cluster
+		case cluster != report.Cluster:
+			return "", errMixedClusters
+		}
+	}
+	return cluster, nil
+}
+
+// mergeFindings combines the findings of summaries into one list, keeping a
+// single finding per ID, or title when there's no ID. Of duplicates the most
+// urgent one is kept; categories that only differ in case are spelled the way
+// they were first seen.
+func mergeFindings(summaries []*types.ReportSummary) []types.Finding {
+	var merged []types.Finding
+	index := make(map[string]int)
+	categories := make(map[string]string)
+
+	for _, summary := range summaries {
+		for _, finding := range summary.Findings {
+			category := strings.ToLower(strings.TrimSpace(finding.Category))
+			if spelled, ok := categories[category]; ok {
+				finding.Category = spelled
+			} else {
+				categories[category] = finding.Category
+			}
+
+			key := strings.ToLower(strings.TrimSpace(finding.ID))
+			if key == "" {
+				key = strings.ToLower(strings.TrimSpace(finding.Title))
+			}
+			i, ok := index[key]
+			if !ok {
+				index[key] = len(merged)
+				merged = append(merged, finding)
+				continue
+			}
+			if statusRank(finding.Status) < statusRank(merged[i].Status) {
+				merged[i] = finding
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,100 @@
+// app/server/server/migrate.go
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/blob"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/jobs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/version"
+)
+
+// migrationFindings backfills the structured findings of reports stored with
+// only flat item strings
+const migrationFindings = "findings-v1"
+
+// startMigrations queues the one-time migrations of stored reports as a batch
+// job, so startup isn't delayed by re-parsing old documents
+func (s *Server) startMigrations() {
+	var pending []*store.Report
+	for _, report := range s.store.List() {
+		if !report.Migrated(migrationFindings) && len(report.Summary.Findings) == 0 {
+			pending = append(pending, report)
+		}
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	log.Printf("Queued %d stored reports for migration to structured findings", len(pending))
+	_, err := s.queue.Submit(jobs.ClassBatch, "migrate", func(ctx context.Context) (interface{}, error) {
+		var total utils.LegacyMatch
+		migrated := 0
+		for _, report := range pending {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			match, err := s.migrateFindings(ctx, report)
+			if err != nil {
+				// Leave the report for the next start
+				log.Printf("Error migrating report %s: %v", report.ID, err)
+				continue
+			}
+			total.Matched += match.Matched
+			total.Unmatched += match.Unmatched
+			total.Skipped += match.Skipped
+			migrated++
+		}
+
+		log.Printf("Migrated %d of %d reports to structured findings: %d items matched, %d kept from the stored summary, %d new findings skipped",
+			migrated, len(pending), total.Matched, total.Unmatched, total.Skipped)
+		return total, nil
+	})
+	if err != nil {
+		log.Printf("Error queueing report migration: %v", err)
+	}
+}
+
+// migrateFindings re-parses the raw document of a report and merges the parsed
+// findings with its stored item strings. Reports whose raw document is gone
+// get findings built from the stored items alone.
+func (s *Server) migrateFindings(ctx context.Context, report *store.Report) (utils.LegacyMatch, error) {
+	summary := *report.Summary
+
+	raw, err := s.store.OpenRaw(ctx, report.ID)
+	switch {
+	case errors.Is(err, blob.ErrNotFound):
+		log.Printf("Raw document of report %s is missing, building findings from the stored items", report.ID)
+		summary.Findings = nil
+	case err != nil:
+		return utils.LegacyMatch{}, fmt.Errorf("error reading raw report: %w", err)
+	default:
+		parsed, err := s.parseReport(ctx, raw, utils.FormatForFilename(report.RawKey), report.Summary.ParserProfile, report.Summary.Language)
+		raw.Close()
+		if err != nil {
+			return utils.LegacyMatch{}, fmt.Errorf("error re-parsing report: %w", err)
+		}
+		s.settings.Current().MapCategories(parsed)
+		summary.Findings = parsed.Findings
+	}
+
+	match := utils.MergeLegacyItems(&summary, summary.Findings)
+
+	// The summary was copied above and replaces the stored one on a copy of
+	// the report, as readers may hold both
+	if _, err := s.store.Mutate(report.ID, func(report *store.Report) error {
+		report.Summary = &summary
+		report.ServerVersion = version.Version
+		report.Migrations = append(report.Migrations, migrationFindings)
+		return nil
+	}); err != nil {
+		return utils.LegacyMatch{}, err
+	}
+	return match, nil
+}
@@ -0,0 +1,92 @@
+// app/server/server/notifications.go
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// notifyTopRequired is the number of required items named in notifications
+const notifyTopRequired = 3
+
+// notifyReport sends a notification for a newly stored report, evaluates the
+// alert rules and category thresholds and exports it to Git and Confluence in the background, or holds
+// all of them until the report is approved when the review gate is enabled
+func (s *Server) notifyReport(report *store.Report, eventType notify.EventType) {
+	if s.config.ReviewRequired && report.Review == nil {
+		s.holdForReview(report, eventType)
+		return
+	}
+
+	s.evaluateAlerts(report)
+	s.publishToGit(report)
+	s.publishToConfluence(report)
+	s.notifyThresholds(report)
+	if !s.notifier.Enabled() {
+		return
+	}
+
+	summary := report.Summary
+	event := notify.Event{
+		Type:             eventType,
+		Time:             time.Now().UTC(),
+		ReportID:         report.ID,
+		ReportURL:        s.reportURL(report.ID),
+		ClusterName:      report.ClusterKey(),
+		CustomerName:     summary.CustomerName,
+		OverallScore:     summary.OverallScore,
+		RequiredCount:    len(summary.ItemsRequired),
+		RecommendedCount: len(summary.ItemsRecommended),
+		AdvisoryCount:    len(summary.ItemsAdvisory),
+		TopRequired:      s.topRequired(report, notifyTopRequired),
+		Branding:         s.notifyBranding(),
+	}
+
+	if previous := s.previousReport(report); previous != nil {
+		previousScore := previous.Summary.OverallScore
+		delta := summary.OverallScore - previousScore
+		event.PreviousScore = &previousScore
+		event.ScoreDelta = &delta
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(s.ctx, time.Minute)
+		defer cancel()
+		s.notifier.Send(ctx, event)
+	}()
+}
+
+// topRequired returns the titles of the first required items of a report in
+// remediation order; reports without findings list their item strings
+func (s *Server) topRequired(report *store.Report, limit int) []string {
+	var titles []string
+	for _, priority := range scoring.Prioritize(s.presentSummary(report, report.Summary).Findings) {
+		if priority.Finding.Status == types.ResultKeyRequired && len(titles) < limit {
+			titles = append(titles, priority.Finding.Title)
+		}
+	}
+	if len(report.Summary.Findings) == 0 {
+		titles = report.Summary.ItemsRequired[:min(limit, len(report.Summary.ItemsRequired))]
+	}
+	return titles
+}
+
+// previousReport returns the latest report for the same cluster uploaded before the given one
+func (s *Server) previousReport(report *store.Report) *store.Report {
+	// Reports are sorted newest first, so the first older match is the previous report
+	for _, candidate := range s.activeReports() {
+		if candidate.ID == report.ID || !candidate.UploadedAt.Before(report.UploadedAt) {
+			continue
+		}
+		if strings.EqualFold(candidate.ClusterKey(), report.ClusterKey()) {
+			return candidate
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+// app/server/server/onboarding.go
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/i18n"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/orgs"
+)
+
+// onboardingRequest provisions an organization with its first clusters in one call
+type onboardingRequest struct {
+	Org      orgs.Org           `json:"org"`
+	Clusters []clusters.Cluster `json:"clusters"`
+
+	// Integrations lists the integration placeholders to create; all known
+	// types are created when it is omitted
+	Integrations []string `json:"integrations"`
+}
+
+// onboardingStep is a single item of the onboarding checklist
+type onboardingStep struct {
+	Step   string `json:"step"`
+	Done   bool   `json:"done"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// onboardingResponse describes a provisioned organization and what is left to do
+type onboardingResponse struct {
+	Org       orgs.Org           `json:"org"`
+	Clusters  []clusters.Cluster `json:"clusters"`
+	Checklist []onboardingStep   `json:"checklist"`
+}
+
+// HandleOnboarding validates and provisions an organization, its clusters, the
+// default scoring profile and integration placeholders; nothing is kept if any part fails
+func (s *Server) HandleOnboarding(w http.ResponseWriter, r *http.Request) {
+	req, ok := s.decodeOnboarding(w, r)
+	if !ok {
+		return
+	}
+
+	org, err := s.orgs.Create(req.Org)
+	if err != nil {
+		writeOnboardingError(w, err)
+		return
+	}
+
+	created := make([]clusters.Cluster, 0, len(req.Clusters))
+	for _, cluster := range req.Clusters {
+		cluster.Org = org.Name
+		registered, err := s.clusters.Create(cluster)
+		if err != nil {
+			s.rollbackOnboarding(org, created)
+			writeOnboardingError(w, err)
+			return
+		}
+		created = append(created, registered)
+	}
+
+	log.Printf("Onboarded organization %s with %d clusters", org.Name, len(created))
+	writeJSON(w, http.StatusCreated, onboardingResponse{
+		Org:       org,
+		Clusters:  created,
+		Checklist: s.onboardingChecklist(org, created),
+	})
+}
+
+// HandleValidateOnboarding checks an onboarding request without provisioning anything
+func (s *Server) HandleValidateOnboarding(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.decodeOnboarding(w, r); !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]bool{"valid": true})
+}
+
+// HandleOnboardingStatus returns the onboarding checklist of an organization
+func (s *Server) HandleOnboardingStatus(w http.ResponseWriter, r *http.Request) {
+	org, err := s.orgs.Get(r.PathValue("org"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Organization not found")
+		return
+	}
+
+	var orgClusters []clusters.Cluster
+	for _, cluster := range s.clusters.List() {
+		if cluster.Org == org.Name {
+			orgClusters = append(orgClusters, cluster)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, onboardingResponse{
+		Org:       org,
+		Clusters:  orgClusters,
+		Checklist: s.onboardingChecklist(org, orgClusters),
+	})
+}
+
+// decodeOnboarding reads and validates an onboarding request, writing an error
+// response listing every problem if it is invalid
+func (s *Server) decodeOnboarding(w http.ResponseWriter, r *http.Request) (*onboardingRequest, bool) {
+	var req onboardingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return nil, false
+	}
+
+	var problems []string
+
+	if req.Integrations == nil {
+		req.Integrations = []string{orgs.IntegrationJira, orgs.IntegrationSlack, orgs.IntegrationWebhook}
+	}
+	req.Org.Integrations = nil
+	for _, integration := range req.Integrations {
+		req.Org.Integrations = append(req.Org.Integrations, orgs.Integration{Type: integration})
+	}
+
+	if err := orgs.Validate(&req.Org); err != nil {
+		problems = append(problems, fmt.Sprintf("org: %v", err))
+	} else if _, err := s.orgs.Get(req.Org.Name); err == nil {
+		problems = append(problems, fmt.Sprintf("org: %s already exists", req.Org.Name))
+	}
+
+	seen := make(map[string]bool)
+	for i := range req.Clusters {
+		cluster := &req.Clusters[i]
+		if err := clusters.Validate(cluster); err != nil {
+			problems = append(problems, fmt.Sprintf("clusters[%d]: %v", i, err))
+			continue
+		}
+		if seen[strings.ToLower(cluster.Name)] {
+			problems = append(problems, fmt.Sprintf("clusters[%d]: %s is listed twice", i, cluster.Name))
+		}
+		seen[strings.ToLower(cluster.Name)] = true
+		if s.clusters.Resolve(cluster.Name) != "" {
+			problems = append(problems, fmt.Sprintf("clusters[%d]: %s is already registered", i, cluster.Name))
+		}
+	}
+
+	if len(problems) > 0 {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error":    i18n.T(locale(r), "Invalid onboarding request"),
+			"problems": problems,
+		})
+		return nil, false
+	}
+	return &req, true
+}
+
+// rollbackOnboarding removes what a failed onboarding call already created
+func (s *Server) rollbackOnboarding(org orgs.Org, created []clusters.Cluster) {
+	for _, cluster := range created {
+		if err := s.clusters.Delete(cluster.Name); err != nil {
+			log.Printf("Error rolling back cluster %s: %v", cluster.Name, err)
+		}
+	}
+	if err := s.orgs.Delete(org.Name); err != nil {
+		log.Printf("Error rolling back organization %s: %v", org.Name, err)
+	}
+}
+
+// onboardingChecklist reports which setup steps of an organization are complete
+func (s *Server) onboardingChecklist(org orgs.Org, orgClusters []clusters.Cluster) []onboardingStep {
+	withCredentials := 0
+	names := make(map[string]bool)
+	for _, cluster := range orgClusters {
+		if cluster.CredentialsRef != "" {
+			withCredentials++
+		}
+		names[strings.ToLower(cluster.Name)] = true
+	}
+
+	reports := 0
+	for _, report := range s.activeReports() {
+		if names[strings.ToLower(report.Cluster)] {
+			reports++
+		}
+	}
+
+	enabled := 0
+	for _, integration := range org.Integrations {
+		if integration.Enabled {
+			enabled++
+		}
+	}
+
+	return []onboardingStep{
+		{Step: "organization", Done: true, Detail: org.DisplayName},
+		{Step: "scoring-profile", Done: org.ScoringProfile.Name != "", Detail: org.ScoringProfile.Name},
+		{Step: "clusters", Done: len(orgClusters) > 0, Detail: fmt.Sprintf("%d registered", len(orgClusters))},
+		{Step: "credentials", Done: len(orgClusters) > 0 && withCredentials == len(orgClusters),
+			Detail: fmt.Sprintf("%d of %d clusters have a credentials reference", withCredentials, len(orgClusters))},
+		{Step: "first-report", Done: reports > 0, Detail: fmt.Sprintf("%d reports", reports)},
+		{Step: "integrations", Done: len(org.Integrations) == 0 || enabled == len(org.Integrations),
+			Detail: fmt.Sprintf("%d of %d enabled", enabled, len(org.Integrations))},
+	}
+}
+
+// writeOnboardingError maps a provisioning error to a response
+func writeOnboardingError(w http.ResponseWriter, err error) {
+	if errors.Is(err, orgs.ErrExists) || errors.Is(err, clusters.ErrExists) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	log.Printf("Error provisioning organization: %v", err)
+	writeError(w, http.StatusInternalServerError, "Failed to provision organization")
+}
@@ -0,0 +1,197 @@
+// app/server/server/openapi.go
+package server
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/swaggest/swgui"
+	"github.com/swaggest/swgui/v5emb"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/openapi"
+)
+
+// Operation tags of the API documentation
+const (
+	tagReports    = "Reports"
+	tagClusters   = "Clusters"
+	tagScans      = "Scans"
+	tagOnboarding = "Onboarding"
+	tagAdmin      = "Admin"
+)
+
+// apiDocsPath serves the Swagger UI
+const apiDocsPath = "/api/docs/"
+
+// apiRoute is an API route together with its documentation
+type apiRoute struct {
+	pattern    string
+	handler    http.HandlerFunc
+	deprecated bool
+	doc        routeDoc
+}
+
+// routeDoc documents an API route for the OpenAPI document
+type routeDoc struct {
+	// method is documented for patterns that match any method
+	method  string
+	tag     string
+	summary string
+	query   []openapi.Parameter
+
+	// request is a value of the JSON request body type; upload documents a
+	// multipart report upload instead, and consumes the media type of a raw
+	// request body
+	request  interface{}
+	upload   bool
+	consumes string
+
+	// status is the success status, 200 by default; response is a value of
+	// the JSON response body type; download is the media type of a binary
+	// response body instead
+	status   int
+	response interface{}
+	download string
+}
+
+// apiError is the body of every error response
+type apiError struct {
+	Error string `json:"error"`
+}
+
+// scoringParam selects a scoring preset
+var scoringParam = queryParam("scoring", "Scoring preset to score the report with")
+
+// parserParam documents the parser profile parameter of the upload routes
+var parserParam = queryParam("parser", `Parser profile of the report dialect; "auto" (the default) detects it, "default" is the standard template`)
+
+// asyncParam documents the asynchronous mode of the upload routes
+var asyncParam = queryParam("async", "true to parse in the background: the response is a 202 with the job, "+
+	"whose result is the summary once it succeeded")
+
+// badgeLabelParam documents the label of the badge routes
+var badgeLabelParam = queryParam("label", `Text on the left of the badge, "health" by default`)
+
+// pathParamPattern matches the wildcards of a route pattern
+var pathParamPattern = regexp.MustCompile(`\{([^}.]+)(\.\.\.)?\}`)
+
+// operationWordPattern matches the words an operation ID is built from
+var operationWordPattern = regexp.MustCompile(`[A-Za-z0-9]+`)
+
+// queryParam documents an optional string query parameter
+func queryParam(name, description string) openapi.Parameter {
+	return openapi.Parameter{Name: name, In: "query", Description: description, Schema: &openapi.Schema{Type: "string"}}
+}
+
+// openAPIHandler serves the OpenAPI document of an API version
+func (s *Server) openAPIHandler(version string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		writeCachedJSON(w, r, s.openAPIDocument(pathPrefix(r), version))
+	}
+}
+
+// openAPIDocument describes the routes of an API version served under prefix
+func (s *Server) openAPIDocument(prefix, version string) *openapi.Document {
+	doc := openapi.New(openapi.Info{
+		Title:       "OpenShift Health Dashboard API",
+		Description: "Upload, score and track OpenShift health check reports.",
+		Version:     version,
+	})
+	doc.Servers = []openapi.Server{{URL: prefix + "/api/" + version}}
+	for _, tag := range []string{tagReports, tagClusters, tagScans, tagOnboarding, tagAdmin} {
+		doc.Tags = append(doc.Tags, openapi.Tag{Name: tag})
+	}
+
+	for _, route := range s.apiRoutes(version) {
+		method, path, found := strings.Cut(route.pattern, " ")
+		if !found {
+			method, path = route.doc.method, route.pattern
+		}
+		doc.Add(method, pathParamPattern.ReplaceAllString(path, "{$1}"), route.operation(doc, method, path))
+	}
+	return doc
+}
+
+// operation builds the OpenAPI operation of a route
+func (route apiRoute) operation(doc *openapi.Document, method, path string) *openapi.Operation {
+	op := &openapi.Operation{
+		OperationID: operationID(method, path),
+		Summary:     route.doc.summary,
+		Tags:        []string{route.doc.tag},
+		Deprecated:  route.deprecated,
+		Responses: map[string]openapi.Response{
+			"default": {Description: "Error", Content: doc.JSONContent(apiError{})},
+		},
+	}
+
+	for _, match := range pathParamPattern.FindAllStringSubmatch(path, -1) {
+		op.Parameters = append(op.Parameters, openapi.Parameter{
+			Name: match[1], In: "path", Required: true, Schema: &openapi.Schema{Type: "string"},
+		})
+	}
+	op.Parameters = append(op.Parameters, route.doc.query...)
+
+	switch {
+	case route.doc.upload:
+		op.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content: map[string]openapi.MediaType{"multipart/form-data": {Schema: &openapi.Schema{
+				Type: "object",
+				Properties: map[string]*openapi.Schema{
+					"report":  {Type: "string", Format: "binary", Description: "AsciiDoc report document"},
+					"cluster": {Type: "string", Description: "Registered cluster the report belongs to"},
+					"signature": {Type: "string", Format: "binary",
+						Description: "Detached GPG or sigstore signature of the report; must precede the report part"},
+				},
+				Required: []string{"report"},
+			}}},
+		}
+	case route.doc.consumes != "":
+		op.RequestBody = &openapi.RequestBody{
+			Required: true,
+			Content:  map[string]openapi.MediaType{route.doc.consumes: {Schema: &openapi.Schema{Type: "string", Format: "binary"}}},
+		}
+	case route.doc.request != nil:
+		op.RequestBody = &openapi.RequestBody{Content: doc.JSONContent(route.doc.request)}
+	}
+
+	status := route.doc.status
+	if status == 0 {
+		status = http.StatusOK
+	}
+	response := openapi.Response{Description: http.StatusText(status)}
+	switch {
+	case route.doc.download != "":
+		response.Content = map[string]openapi.MediaType{route.doc.download: {Schema: &openapi.Schema{Type: "string", Format: "binary"}}}
+	case route.doc.response != nil:
+		response.Content = doc.JSONContent(route.doc.response)
+	}
+	op.Responses[strconv.Itoa(status)] = response
+
+	return op
+}
+
+// operationID derives an operation ID such as postReportsIdApprove from a route
+func operationID(method, path string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, word := range operationWordPattern.FindAllString(path, -1) {
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// apiDocsHandler serves the embedded Swagger UI, offering the document of each API version
+func apiDocsHandler() http.Handler {
+	return v5emb.NewHandlerWithConfig(swgui.Config{
+		Title:       "OpenShift Health Dashboard API",
+		SwaggerJSON: "../" + apiV2 + "/openapi.json",
+		BasePath:    apiDocsPath,
+		ShowTopBar:  true,
+		SettingsUI: map[string]string{
+			"urls": `[{url: "../` + apiV2 + `/openapi.json", name: "` + apiV2 + `"}, {url: "../` + apiV1 + `/openapi.json", name: "` + apiV1 + `"}]`,
+		},
+	})
+}
@@ -0,0 +1,115 @@
+// app/server/server/operator.go
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/operator"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scheduler"
+)
+
+// scheduleSourceOperator marks schedules declared in the HealthDashboard resource
+const scheduleSourceOperator = "operator"
+
+// startOperator watches the HealthDashboard resource in operator mode
+func (s *Server) startOperator() error {
+	if !s.config.Operator.Enabled {
+		return nil
+	}
+
+	op, err := operator.New(s.config.Operator, s.reconcileDashboard)
+	if err != nil {
+		return fmt.Errorf("failed to start operator mode: %w", err)
+	}
+	s.operator = op
+	go op.Run(s.ctx)
+	return nil
+}
+
+// reconcileDashboard makes the cluster registry, the schedules and the extra
+// notification targets match a HealthDashboard resource; a nil resource
+// removes everything a deleted resource declared. Clusters and schedules are
+// applied independently, so one invalid entry doesn't hold back the others.
+func (s *Server) reconcileDashboard(ctx context.Context, dashboard *operator.HealthDashboard) (operator.Result, error) {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	var spec operator.Spec
+	if dashboard != nil {
+		spec = dashboard.Spec
+	}
+	var problems []error
+
+	desired := make([]clusters.Cluster, 0, len(spec.Clusters))
+	for _, cluster := range spec.Clusters {
+		if cluster.Org != "" {
+			if _, err := s.orgs.Get(cluster.Org); err != nil {
+				problems = append(problems, fmt.Errorf("cluster %q: unknown organization %q", cluster.Name, cluster.Org))
+				continue
+			}
+		}
+		desired = append(desired, clusters.Cluster{
+			Name:           cluster.Name,
+			APIURL:         cluster.APIURL,
+			CredentialsRef: cluster.CredentialsRef,
+			Labels:         cluster.Labels,
+			Org:            cluster.Org,
+			InsightsID:     cluster.InsightsID,
+			PrometheusURL:  cluster.PrometheusURL,
+		})
+	}
+	if err := s.clusters.Sync(s.operator.Resource(), desired); err != nil {
+		problems = append(problems, err)
+	}
+
+	schedules := make([]scheduler.Schedule, 0, len(spec.Schedules))
+	for _, schedule := range spec.Schedules {
+		if schedule.Name == "" {
+			problems = append(problems, errors.New("schedules need a name"))
+			continue
+		}
+		schedules = append(schedules, scheduler.Schedule{
+			ID:      scheduleSourceOperator + "-" + schedule.Name,
+			Cluster: schedule.Cluster,
+			Cron:    schedule.Cron,
+			Enabled: !schedule.Suspend,
+		})
+	}
+	if err := s.scheduler.Sync(scheduleSourceOperator, schedules); err != nil {
+		problems = append(problems, err)
+		schedules = nil
+	}
+
+	s.managedNotify = notify.Config{
+		SlackWebhookURLs: spec.Notifications.SlackWebhookURLs,
+		WebhookURLs:      spec.Notifications.WebhookURLs,
+		TeamsWebhooks:    spec.Notifications.TeamsWebhooks,
+	}
+	s.configureNotifier()
+
+	result := operator.Result{Schedules: len(schedules)}
+	for _, cluster := range s.clusters.List() {
+		if cluster.ManagedBy == s.operator.Resource() {
+			result.Clusters++
+		}
+	}
+	log.Printf("Reconciled HealthDashboard %s: %d clusters, %d schedules, %d problems",
+		s.operator.Resource(), result.Clusters, result.Schedules, len(problems))
+	return result, errors.Join(problems...)
+}
+
+// configureNotifier points the notifier at the targets of the settings and
+// of the HealthDashboard resource
+func (s *Server) configureNotifier() {
+	config := s.settings.Current().Notify
+	config.SlackWebhookURLs = append(slices.Clone(config.SlackWebhookURLs), s.managedNotify.SlackWebhookURLs...)
+	config.WebhookURLs = append(slices.Clone(config.WebhookURLs), s.managedNotify.WebhookURLs...)
+	config.TeamsWebhooks = append(slices.Clone(config.TeamsWebhooks), s.managedNotify.TeamsWebhooks...)
+	s.notifier.Configure(config)
+}
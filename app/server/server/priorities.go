@@ -0,0 +1,35 @@
+// app/server/server/priorities.go
+package server
+
+import (
+	"net/http"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+)
+
+// prioritiesResponse is the remediation order of the open findings of a report
+type prioritiesResponse struct {
+	Report     string             `json:"report"`
+	Priorities []scoring.Priority `json:"priorities"`
+}
+
+// HandleReportPriorities lists the open findings of a report in the order to
+// remediate them, high impact and low effort first. Waived findings are left
+// out and the knowledge base estimates the effort where the report has none.
+func (s *Server) HandleReportPriorities(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	summary := s.presentSummary(report, report.Summary)
+	if len(summary.Findings) == 0 {
+		writeError(w, http.StatusConflict, "Report has no findings to prioritize; re-parse it first")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, prioritiesResponse{
+		Report:     report.ID,
+		Priorities: scoring.Prioritize(summary.Findings),
+	})
+}
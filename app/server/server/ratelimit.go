@@ -0,0 +1,199 @@
+// app/server/server/ratelimit.go
+package server
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// limiterIdleTimeout is how long an unused client limiter is kept
+	limiterIdleTimeout = 10 * time.Minute
+
+	// limiterSweepInterval is how often idle limiters are dropped
+	limiterSweepInterval = time.Minute
+)
+
+// RateLimitConfig holds the API rate limits; a zero rate disables that limit
+type RateLimitConfig struct {
+	// PerIP is the sustained requests per second allowed from one client address
+	PerIP float64
+	// PerKey is the sustained requests per second allowed for one API key
+	PerKey float64
+	// APIKeys are the keys limited by PerKey; requests with any other key are
+	// limited by address, so made-up keys can't be used to dodge the limit
+	APIKeys []string
+	// Burst is the number of requests a client may make at once
+	Burst int
+
+	// TrustProxy takes the client address from the last X-Forwarded-For entry,
+	// the one added by the proxy in front of the dashboard, such as the
+	// OpenShift router; it is only safe behind a proxy that appends it
+	TrustProxy bool
+
+	// MaxUploadSize bounds the body of report uploads, in bytes
+	MaxUploadSize int64
+}
+
+// rateLimiter keeps a token bucket per client
+type rateLimiter struct {
+	rate  rate.Limit
+	burst int
+
+	mu        sync.Mutex
+	clients   map[string]*clientLimiter
+	lastSweep time.Time
+}
+
+// clientLimiter is the token bucket of a single client
+type clientLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// newRateLimiter returns a limiter, or nil when the rate is zero
+func newRateLimiter(perSecond float64, burst int) *rateLimiter {
+	if perSecond <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = int(math.Ceil(perSecond))
+	}
+	return &rateLimiter{
+		rate:    rate.Limit(perSecond),
+		burst:   burst,
+		clients: make(map[string]*clientLimiter),
+	}
+}
+
+// allow takes a token for the client, returning how long to wait if there is none
+func (l *rateLimiter) allow(client string) (bool, time.Duration) {
+	now := time.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.lastSweep) > limiterSweepInterval {
+		for key, entry := range l.clients {
+			if now.Sub(entry.lastSeen) > limiterIdleTimeout {
+				delete(l.clients, key)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	entry, ok := l.clients[client]
+	if !ok {
+		entry = &clientLimiter{limiter: rate.NewLimiter(l.rate, l.burst)}
+		l.clients[client] = entry
+	}
+	entry.lastSeen = now
+
+	reservation := entry.limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// apiLimiter applies the per-key and per-IP rate limits to API requests;
+// clients presenting an API key are limited by key instead of by address
+type apiLimiter struct {
+	perIP      *rateLimiter
+	perKey     *rateLimiter
+	knownKeys  map[string]bool
+	trustProxy bool
+}
+
+// newAPILimiter returns the limiter of the API, or nil when both rates are zero
+func newAPILimiter(config RateLimitConfig) *apiLimiter {
+	perIP := newRateLimiter(config.PerIP, config.Burst)
+	perKey := newRateLimiter(config.PerKey, config.Burst)
+	if perIP == nil && perKey == nil {
+		return nil
+	}
+
+	knownKeys := make(map[string]bool, len(config.APIKeys))
+	for _, key := range config.APIKeys {
+		knownKeys[key] = true
+	}
+	return &apiLimiter{perIP: perIP, perKey: perKey, knownKeys: knownKeys, trustProxy: config.TrustProxy}
+}
+
+// limit rate limits the API requests passed to next. Requests to a tenant in
+// path mode are limited once the tenant prefix is stripped, with the buckets
+// of the deployment, so a client can't dodge the limit by switching tenants.
+func (l *apiLimiter) limit(next http.Handler) http.Handler {
+	if l == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		limiter, client := l.perIP, "ip:"+clientAddress(r, l.trustProxy)
+		if key := apiKey(r); l.knownKeys[key] {
+			sum := sha256.Sum256([]byte(key))
+			limiter, client = l.perKey, "key:"+hex.EncodeToString(sum[:8])
+		}
+
+		if limiter != nil {
+			if ok, wait := limiter.allow(client); !ok {
+				retryAfter := int(math.Ceil(wait.Seconds()))
+				w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+				negotiateLocale(w, r)
+				writeErrorf(w, http.StatusTooManyRequests, "Rate limit exceeded, retry in %d seconds", retryAfter)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// apiKey returns the API key presented with a request, if any
+func apiKey(r *http.Request) string {
+	if key := r.Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+	return ""
+}
+
+// clientAddress returns the address a request came from. Behind a trusted
+// proxy that's the last X-Forwarded-For entry: proxies append the address they
+// were reached from, while the entries before it are whatever the client sent.
+func clientAddress(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if values := r.Header.Values("X-Forwarded-For"); len(values) > 0 {
+			forwarded := values[len(values)-1]
+			if i := strings.LastIndex(forwarded, ","); i >= 0 {
+				forwarded = forwarded[i+1:]
+			}
+			if forwarded = strings.TrimSpace(forwarded); forwarded != "" {
+				return forwarded
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
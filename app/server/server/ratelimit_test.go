@@ -0,0 +1,52 @@
+// app/server/server/ratelimit_test.go
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLimitSpoofedForwardedFor(t *testing.T) {
+	limiter := newAPILimiter(RateLimitConfig{PerIP: 0.001, Burst: 1, TrustProxy: true})
+	handler := limiter.limit(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// Each request claims another address; the router appends the real one
+	for i, forwarded := range []string{"198.51.100.1, 203.0.113.7", "198.51.100.2, 203.0.113.7"} {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/reports", nil)
+		r.Header.Set("X-Forwarded-For", forwarded)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+
+		want := http.StatusOK
+		if i > 0 {
+			want = http.StatusTooManyRequests
+		}
+		if w.Code != want {
+			t.Errorf("request with X-Forwarded-For %q: status %d, want %d", forwarded, w.Code, want)
+		}
+	}
+}
+
+func TestClientAddress(t *testing.T) {
+	tests := []struct {
+		forwarded  []string
+		trustProxy bool
+		want       string
+	}{
+		{nil, true, "192.0.2.1"},
+		{[]string{"203.0.113.7"}, false, "192.0.2.1"},
+		{[]string{"203.0.113.7"}, true, "203.0.113.7"},
+		{[]string{"198.51.100.1, 203.0.113.7"}, true, "203.0.113.7"},
+		{[]string{"198.51.100.1", "203.0.113.7"}, true, "203.0.113.7"},
+	}
+	for _, test := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/api/v1/reports", nil)
+		for _, value := range test.forwarded {
+			r.Header.Add("X-Forwarded-For", value)
+		}
+		if got := clientAddress(r, test.trustProxy); got != test.want {
+			t.Errorf("clientAddress(%q, %t) = %s, want %s", test.forwarded, test.trustProxy, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+// app/server/server/readiness.go
+package server
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// readinessTimeout bounds each dependency check
+	readinessTimeout = 5 * time.Second
+
+	// readinessCacheTTL is how long check results are reused, so frequent
+	// probes don't turn into a stream of requests to Jira or Insights
+	readinessCacheTTL = 10 * time.Second
+)
+
+// Readiness statuses
+const (
+	readinessReady    = "ready"
+	readinessDegraded = "degraded"
+	readinessNotReady = "not ready"
+)
+
+// dependencyCheck is the outcome of checking one dependency
+type dependencyCheck struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+
+	// Critical dependencies make the server not ready when they fail; the
+	// others only degrade it
+	Critical  bool   `json:"critical"`
+	Error     string `json:"error,omitempty"`
+	LatencyMS int64  `json:"latencyMs"`
+}
+
+// readinessResult is the body of /readyz
+type readinessResult struct {
+	Status    string            `json:"status"`
+	CheckedAt *time.Time        `json:"checkedAt,omitempty"`
+	Checks    []dependencyCheck `json:"checks,omitempty"`
+}
+
+// dependency is a downstream service the server relies on
+type dependency struct {
+	name     string
+	critical bool
+	ping     func(ctx context.Context) error
+}
+
+// readiness caches the last dependency checks
+type readiness struct {
+	mu     sync.Mutex
+	result *readinessResult
+}
+
+// dependencies lists the configured downstream services: the report store is
+// critical, integrations only degrade the features that use them
+func (s *Server) dependencies() []dependency {
+	deps := []dependency{{name: "storage", critical: true, ping: s.store.Ping}}
+	if s.live != nil {
+		deps = append(deps, dependency{name: "kubernetes", ping: s.live.Ping})
+		if s.live.Prometheus != nil {
+			deps = append(deps, dependency{name: "prometheus", ping: s.live.Prometheus.Ping})
+		}
+	}
+	if s.jira != nil {
+		deps = append(deps, dependency{name: "jira", ping: s.jira.Ping})
+	}
+	if s.insights != nil {
+		deps = append(deps, dependency{name: "insights", ping: s.insights.Ping})
+	}
+	if s.gitops != nil {
+		deps = append(deps, dependency{name: "git", ping: s.gitops.Ping})
+	}
+	if s.confluence != nil {
+		deps = append(deps, dependency{name: "confluence", ping: s.confluence.Ping})
+	}
+	return deps
+}
+
+// checkReadiness checks every dependency concurrently, reusing recent results
+func (s *Server) checkReadiness(ctx context.Context) *readinessResult {
+	s.readiness.mu.Lock()
+	defer s.readiness.mu.Unlock()
+	if cached := s.readiness.result; cached != nil && time.Since(*cached.CheckedAt) < readinessCacheTTL {
+		return cached
+	}
+
+	deps := s.dependencies()
+	checks := make([]dependencyCheck, len(deps))
+	var wg sync.WaitGroup
+	for i, dep := range deps {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, readinessTimeout)
+			defer cancel()
+
+			started := time.Now()
+			err := dep.ping(checkCtx)
+			checks[i] = dependencyCheck{
+				Name:      dep.name,
+				Status:    "ok",
+				Critical:  dep.critical || s.config.StrictReadiness,
+				LatencyMS: time.Since(started).Milliseconds(),
+			}
+			if err != nil {
+				checks[i].Status = "failed"
+				checks[i].Error = err.Error()
+			}
+		}()
+	}
+	wg.Wait()
+
+	now := time.Now().UTC()
+	result := &readinessResult{Status: readinessReady, CheckedAt: &now, Checks: checks}
+	for _, check := range checks {
+		if check.Status == "ok" {
+			continue
+		}
+		if check.Critical {
+			result.Status = readinessNotReady
+			break
+		}
+		result.Status = readinessDegraded
+	}
+	s.readiness.result = result
+	return result
+}
+
+// handleReadyz answers the readiness probe: 503 until initialization is done
+// or while a critical dependency fails, 200 otherwise, including when an
+// integration is down and the server is degraded
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if !s.isReady.Load() {
+		writeJSON(w, http.StatusServiceUnavailable, readinessResult{Status: readinessNotReady})
+		return
+	}
+
+	result := s.checkReadiness(r.Context())
+	status := http.StatusOK
+	if result.Status == readinessNotReady {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, result)
+}
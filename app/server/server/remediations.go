@@ -0,0 +1,288 @@
+// app/server/server/remediations.go
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/knowledge"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/remediation"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// remediationTimeout bounds applying the manifests of an approved remediation
+const remediationTimeout = 2 * time.Minute
+
+// remediationRequest is the body of POST /remediations
+type remediationRequest struct {
+	Cluster   string `json:"cluster"`
+	Finding   string `json:"finding"`
+	Namespace string `json:"namespace"`
+}
+
+// decisionRequest is the optional body of approving or rejecting a remediation
+type decisionRequest struct {
+	Comment string `json:"comment,omitempty"`
+}
+
+// HandleListRemediations returns all remediations, newest first
+func (s *Server) HandleListRemediations(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.remediations.List())
+}
+
+// HandleGetRemediation returns a single remediation
+func (s *Server) HandleGetRemediation(w http.ResponseWriter, r *http.Request) {
+	found, err := s.remediations.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Remediation not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, found)
+}
+
+// HandleCreateRemediation requests that the suggested manifests of a finding be
+// applied to a namespace of a cluster; nothing is applied until it's approved.
+// The requester must be identified by an authenticating proxy, or nobody could
+// tell whether the approver is someone else.
+func (s *Server) HandleCreateRemediation(w http.ResponseWriter, r *http.Request) {
+	if !s.config.AutoRemediation {
+		writeError(w, http.StatusForbidden, "Automatic remediation is disabled")
+		return
+	}
+	requestedBy := forwardedUser(r)
+	if requestedBy == "" {
+		writeError(w, http.StatusUnauthorized, "Requesting a remediation requires an identity from the authenticating proxy")
+		return
+	}
+
+	var req remediationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if problems := validation.IsDNS1123Label(req.Namespace); len(problems) > 0 {
+		writeErrorf(w, http.StatusBadRequest, "Invalid namespace: %s", strings.Join(problems, "; "))
+		return
+	}
+	clients, err := s.clientsFor(req.Cluster)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	suggested, ok, err := s.knowledge.Remediation(req.Finding, knowledge.RemediationParams{Namespace: req.Namespace})
+	if err != nil {
+		log.Printf("Error generating remediation: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to generate the remediation")
+		return
+	}
+	if !ok {
+		writeError(w, http.StatusNotFound, "No known remediation for this finding")
+		return
+	}
+	if _, err := remediation.Check(suggested.Manifests, req.Namespace); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	created, err := s.remediations.Create(remediation.Remediation{
+		Cluster:     clients.ClusterName,
+		Finding:     req.Finding,
+		Namespace:   req.Namespace,
+		Title:       suggested.Title,
+		Manifests:   suggested.Manifests,
+		RequestedBy: requestedBy,
+	})
+	if err != nil {
+		log.Printf("Error saving remediation: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to save the remediation")
+		return
+	}
+
+	s.audit(requestedBy, "remediation.requested", created, "pending", "")
+	log.Printf("Remediation %s of %q requested for %s/%s", created.ID, created.Title, created.Cluster, created.Namespace)
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// HandleApproveRemediation approves a pending remediation and applies its
+// manifests to the cluster; the approver must be known and differ from the
+// requester, so remediations requested without an identity can't be approved
+func (s *Server) HandleApproveRemediation(w http.ResponseWriter, r *http.Request) {
+	if !s.config.AutoRemediation {
+		writeError(w, http.StatusForbidden, "Automatic remediation is disabled")
+		return
+	}
+
+	req, approver, ok := decodeDecision(w, r, "Approving")
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC()
+	approved, err := s.remediations.Update(r.PathValue("id"), func(pending *remediation.Remediation) error {
+		switch {
+		case pending.Status != remediation.StatusPending:
+			return remediation.ErrNotPending
+		case pending.RequestedBy == "":
+			return errAnonymousRequest
+		case strings.EqualFold(pending.RequestedBy, approver):
+			return errSelfApproval
+		}
+		pending.Status = remediation.StatusApproved
+		pending.DecidedBy = approver
+		pending.DecidedAt = &now
+		pending.Comment = strings.TrimSpace(req.Comment)
+		return nil
+	})
+	if !writeDecisionError(w, err) {
+		return
+	}
+	s.audit(approver, "remediation.approved", approved, "approved", approved.Comment)
+
+	// Applying continues if the client goes away, so it's never left half done
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), remediationTimeout)
+	defer cancel()
+
+	var applied []string
+	clients, err := s.clientsFor(approved.Cluster)
+	if err == nil {
+		applied, err = remediation.Apply(ctx, clients.Dynamic, approved)
+	}
+
+	done := time.Now().UTC()
+	result, updateErr := s.remediations.Update(approved.ID, func(current *remediation.Remediation) error {
+		current.Status = remediation.StatusApplied
+		current.Applied = applied
+		current.AppliedAt = &done
+		if err != nil {
+			current.Status = remediation.StatusFailed
+			current.Error = err.Error()
+		}
+		return nil
+	})
+	if updateErr != nil {
+		log.Printf("Error saving the result of remediation %s: %v", approved.ID, updateErr)
+		result = approved
+	}
+
+	if err != nil {
+		log.Printf("Remediation %s approved by %s failed: %v", approved.ID, approver, err)
+		s.audit(approver, "remediation.applied", approved, "failed", err.Error())
+	} else {
+		log.Printf("Remediation %s approved by %s applied %d objects", approved.ID, approver, len(applied))
+		s.audit(approver, "remediation.applied", approved, "applied", strings.Join(applied, ", "))
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// HandleRejectRemediation turns down a pending remediation
+func (s *Server) HandleRejectRemediation(w http.ResponseWriter, r *http.Request) {
+	if !s.config.AutoRemediation {
+		writeError(w, http.StatusForbidden, "Automatic remediation is disabled")
+		return
+	}
+
+	req, reviewer, ok := decodeDecision(w, r, "Rejecting")
+	if !ok {
+		return
+	}
+
+	now := time.Now().UTC()
+	rejected, err := s.remediations.Update(r.PathValue("id"), func(pending *remediation.Remediation) error {
+		if pending.Status != remediation.StatusPending {
+			return remediation.ErrNotPending
+		}
+		pending.Status = remediation.StatusRejected
+		pending.DecidedBy = reviewer
+		pending.DecidedAt = &now
+		pending.Comment = strings.TrimSpace(req.Comment)
+		return nil
+	})
+	if !writeDecisionError(w, err) {
+		return
+	}
+
+	s.audit(reviewer, "remediation.rejected", rejected, "rejected", rejected.Comment)
+	log.Printf("Remediation %s rejected by %s", rejected.ID, reviewer)
+	writeJSON(w, http.StatusOK, rejected)
+}
+
+// HandleListAudit returns the audit log, oldest entry first
+func (s *Server) HandleListAudit(w http.ResponseWriter, r *http.Request) {
+	entries, err := s.store.ListAudit()
+	if err != nil {
+		log.Printf("Error reading the audit log: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to read the audit log")
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// errSelfApproval is returned when the requester of a remediation approves it
+var errSelfApproval = errors.New("a remediation must be approved by someone other than its requester")
+
+// errAnonymousRequest is returned when approving a remediation without a
+// requester. The requester comes from the identity header of the proxy, and
+// without one the approver can't be told apart from it.
+var errAnonymousRequest = errors.New("the remediation was requested without an identity; request it again")
+
+// decodeDecision reads the optional body of an approval or rejection and the
+// identity of who decides, set by an authenticating proxy such as oauth-proxy;
+// action names the decision in the error when there is none
+func decodeDecision(w http.ResponseWriter, r *http.Request, action string) (decisionRequest, string, bool) {
+	decidedBy := forwardedUser(r)
+	if decidedBy == "" {
+		writeError(w, http.StatusUnauthorized, action+" a remediation requires an identity from the authenticating proxy")
+		return decisionRequest{}, "", false
+	}
+
+	var req decisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return req, "", false
+	}
+	return req, decidedBy, true
+}
+
+// writeDecisionError writes the response for a failed decision on a
+// remediation; it returns true when there was no error
+func writeDecisionError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, remediation.ErrNotFound):
+		writeError(w, http.StatusNotFound, "Remediation not found")
+	case errors.Is(err, remediation.ErrNotPending):
+		writeError(w, http.StatusConflict, err.Error())
+	case errors.Is(err, errSelfApproval), errors.Is(err, errAnonymousRequest):
+		writeError(w, http.StatusForbidden, err.Error())
+	default:
+		log.Printf("Error saving remediation decision: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to save the decision")
+	}
+	return false
+}
+
+// audit records an action on a remediation in the audit log
+func (s *Server) audit(actor, action string, target remediation.Remediation, outcome, detail string) {
+	err := s.store.AppendAudit(store.AuditEntry{
+		Time:    time.Now().UTC(),
+		Actor:   actor,
+		Action:  action,
+		Cluster: target.Cluster,
+		Target:  target.ID,
+		Outcome: outcome,
+		Detail:  detail,
+	})
+	if err != nil {
+		log.Printf("Error writing the audit log for remediation %s: %v", target.ID, err)
+	}
+}
@@ -0,0 +1,132 @@
+// app/server/server/reports.go
+package server
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// reportListEntry is the compact form of a stored report used in listings
+type reportListEntry struct {
+	ID           string    `json:"id"`
+	Filename     string    `json:"filename"`
+	UploadedAt   time.Time `json:"uploadedAt"`
+	Cluster      string    `json:"cluster,omitempty"`
+	ClusterName  string    `json:"clusterName"`
+	CustomerName string    `json:"customerName"`
+	OverallScore float64   `json:"overallScore"`
+	ReviewStatus string    `json:"reviewStatus,omitempty"`
+	Archived     bool      `json:"archived,omitempty"`
+
+	// Labels are the effective labels of the report
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// SignatureStatus is the verification status of the report's signature
+	SignatureStatus string `json:"signatureStatus,omitempty"`
+}
+
+// HandleListReports returns the stored reports, newest first, optionally
+// filtered by exact customer and cluster names and by a label selector;
+// archived reports are only listed when asked for
+func (s *Server) HandleListReports(w http.ResponseWriter, r *http.Request) {
+	selector, ok := labelSelector(w, r)
+	if !ok {
+		return
+	}
+	reports, ok := s.listedReports(w, r)
+	if !ok {
+		return
+	}
+	customer := r.URL.Query().Get("customer")
+	cluster := r.URL.Query().Get("cluster")
+
+	entries := make([]reportListEntry, 0, len(reports))
+	for _, report := range reports {
+		if customer != "" && report.Summary.CustomerName != customer ||
+			cluster != "" && report.ClusterKey() != cluster {
+			continue
+		}
+		reportLabels := s.effectiveLabels(report)
+		if !selector.Matches(labels.Set(reportLabels)) {
+			continue
+		}
+		entry := reportListEntry{
+			ID:           report.ID,
+			Filename:     report.Filename,
+			UploadedAt:   report.UploadedAt,
+			Cluster:      report.Cluster,
+			ClusterName:  report.Summary.ClusterName,
+			CustomerName: report.Summary.CustomerName,
+			OverallScore: report.Summary.OverallScore,
+			ReviewStatus: reviewStatus(report),
+			Archived:     report.Archived(),
+		}
+		if len(reportLabels) > 0 {
+			entry.Labels = reportLabels
+		}
+		if report.Signature != nil {
+			entry.SignatureStatus = report.Signature.Status
+		}
+		entries = append(entries, entry)
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// HandleGetReport returns a single stored report
+func (s *Server) HandleGetReport(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	summary, ok := s.rescoredSummary(w, r, report)
+	if !ok {
+		return
+	}
+	enriched := *report
+	enriched.Summary = s.presentSummary(report, summary)
+
+	writeCachedJSON(w, r, &enriched)
+}
+
+// HandleGetReportV2 returns a single stored report with the v2 summary
+func (s *Server) HandleGetReportV2(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	summary, ok := s.rescoredSummary(w, r, report)
+	if !ok {
+		return
+	}
+
+	writeCachedJSON(w, r, reportV2{Report: report, Summary: s.presentSummary(report, summary).V2()})
+}
+
+// reportV2 is a stored report whose summary uses the v2 schema
+type reportV2 struct {
+	*store.Report
+	Summary *types.ReportSummaryV2 `json:"summary"`
+}
+
+// lookupReport loads the report named by the {id} path value, writing an error response if it can't
+func (s *Server) lookupReport(w http.ResponseWriter, r *http.Request) (*store.Report, bool) {
+	report, err := s.store.Get(r.PathValue("id"))
+	if errors.Is(err, store.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Report not found")
+		return nil, false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to load report")
+		return nil, false
+	}
+	return report, true
+}
@@ -0,0 +1,35 @@
+// app/server/server/response.go
+package server
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/i18n"
+)
+
+// writeJSON encodes v as the JSON response body with the given status code
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		log.Printf("Error encoding JSON: %v", err)
+	}
+}
+
+// writeError writes a JSON error body in the same shape used by the upload
+// handler, translated into the language negotiated for the response
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": i18n.T(w.Header().Get("Content-Language"), message)})
+}
+
+// writeErrorf writes an error body whose message is formatted after translation
+func writeErrorf(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]string{"error": i18n.T(w.Header().Get("Content-Language"), format, args...)})
+}
@@ -0,0 +1,218 @@
+// app/server/server/retention.go
+package server
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// defaultRetentionInterval is how often the janitor runs when no interval is configured
+const defaultRetentionInterval = time.Hour
+
+// RetentionConfig bounds how many stored reports are kept; a zero limit
+// disables it. Exports are rendered on request and job results are pruned by
+// the job queue, so reports and their raw documents are all that piles up.
+type RetentionConfig struct {
+	// MaxAge removes reports uploaded longer ago; the latest report of each
+	// cluster is always kept so its status doesn't disappear
+	MaxAge time.Duration
+
+	// MaxPerCluster keeps only the newest reports of each cluster
+	MaxPerCluster int
+
+	// Interval is how often the janitor enforces the limits
+	Interval time.Duration
+}
+
+// Enabled reports whether any retention limit is set
+func (c RetentionConfig) Enabled() bool {
+	return c.MaxAge > 0 || c.MaxPerCluster > 0
+}
+
+// retentionCandidate is a report that exceeds a retention limit
+type retentionCandidate struct {
+	ReportID   string    `json:"reportId"`
+	Cluster    string    `json:"cluster"`
+	UploadedAt time.Time `json:"uploadedAt"`
+
+	// Reason is "maxAge" or "maxPerCluster"
+	Reason string `json:"reason"`
+}
+
+// retentionRun is the outcome of enforcing the retention limits once
+type retentionRun struct {
+	StartedAt time.Time            `json:"startedAt"`
+	DryRun    bool                 `json:"dryRun,omitempty"`
+	Checked   int                  `json:"checked"`
+	Removed   []retentionCandidate `json:"removed"`
+	Errors    []string             `json:"errors,omitempty"`
+}
+
+// retentionStatus describes the retention policy and the last janitor run
+type retentionStatus struct {
+	Enabled       bool          `json:"enabled"`
+	MaxAgeDays    float64       `json:"maxAgeDays,omitempty"`
+	MaxPerCluster int           `json:"maxPerCluster,omitempty"`
+	Interval      string        `json:"interval,omitempty"`
+	LastRun       *retentionRun `json:"lastRun,omitempty"`
+
+	// Pending lists the reports the next run would remove
+	Pending []retentionCandidate `json:"pending"`
+}
+
+// janitor enforces the retention limits in the background
+type janitor struct {
+	mu      sync.Mutex
+	lastRun *retentionRun
+}
+
+// startJanitor enforces the retention limits now and then at every interval
+// until the server shuts down
+func (s *Server) startJanitor() {
+	if !s.config.Retention.Enabled() {
+		return
+	}
+	interval := s.config.Retention.Interval
+	if interval <= 0 {
+		interval = defaultRetentionInterval
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.enforceRetention(s.ctx, false)
+			select {
+			case <-s.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	log.Printf("Retention enabled: reports older than %s or beyond %d per cluster are removed every %s",
+		s.config.Retention.MaxAge, s.config.Retention.MaxPerCluster, interval)
+}
+
+// retentionCandidates lists the stored reports that exceed a retention limit
+func (s *Server) retentionCandidates(now time.Time) []retentionCandidate {
+	config := s.config.Retention
+	if !config.Enabled() {
+		return []retentionCandidate{}
+	}
+
+	// Archived reports are kept for audit, so only active reports count; they
+	// are sorted newest first, so each cluster's reports are too
+	byCluster := make(map[string][]*store.Report)
+	var clusters []string
+	for _, report := range s.activeReports() {
+		key := strings.ToLower(report.ClusterKey())
+		if _, ok := byCluster[key]; !ok {
+			clusters = append(clusters, key)
+		}
+		byCluster[key] = append(byCluster[key], report)
+	}
+	sort.Strings(clusters)
+
+	candidates := []retentionCandidate{}
+	for _, key := range clusters {
+		for i, report := range byCluster[key] {
+			reason := ""
+			switch {
+			case config.MaxPerCluster > 0 && i >= config.MaxPerCluster:
+				reason = "maxPerCluster"
+			case config.MaxAge > 0 && i > 0 && now.Sub(report.UploadedAt) > config.MaxAge:
+				reason = "maxAge"
+			}
+			if reason != "" {
+				candidates = append(candidates, retentionCandidate{
+					ReportID:   report.ID,
+					Cluster:    report.ClusterKey(),
+					UploadedAt: report.UploadedAt,
+					Reason:     reason,
+				})
+			}
+		}
+	}
+	return candidates
+}
+
+// enforceRetention removes the reports that exceed a retention limit, or only
+// lists them in a dry run
+func (s *Server) enforceRetention(ctx context.Context, dryRun bool) *retentionRun {
+	s.janitor.mu.Lock()
+	defer s.janitor.mu.Unlock()
+
+	run := &retentionRun{
+		StartedAt: time.Now().UTC(),
+		DryRun:    dryRun,
+		Checked:   len(s.activeReports()),
+		Removed:   []retentionCandidate{},
+	}
+	for _, candidate := range s.retentionCandidates(run.StartedAt) {
+		if dryRun {
+			run.Removed = append(run.Removed, candidate)
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if err := s.store.Delete(ctx, candidate.ReportID); err != nil {
+			log.Printf("Error removing report %s: %v", candidate.ReportID, err)
+			run.Errors = append(run.Errors, candidate.ReportID+": "+err.Error())
+			continue
+		}
+		run.Removed = append(run.Removed, candidate)
+	}
+
+	if !dryRun {
+		s.janitor.lastRun = run
+		if len(run.Removed) > 0 || len(run.Errors) > 0 {
+			log.Printf("Retention removed %d of %d reports, %d errors", len(run.Removed), run.Checked, len(run.Errors))
+		}
+	}
+	return run
+}
+
+// HandleRetentionStatus returns the retention policy, the last janitor run
+// and the reports the next run would remove
+func (s *Server) HandleRetentionStatus(w http.ResponseWriter, r *http.Request) {
+	config := s.config.Retention
+	status := retentionStatus{
+		Enabled:       config.Enabled(),
+		MaxAgeDays:    config.MaxAge.Hours() / 24,
+		MaxPerCluster: config.MaxPerCluster,
+		Pending:       s.retentionCandidates(time.Now().UTC()),
+	}
+	if status.Enabled {
+		interval := config.Interval
+		if interval <= 0 {
+			interval = defaultRetentionInterval
+		}
+		status.Interval = interval.String()
+	}
+
+	s.janitor.mu.Lock()
+	status.LastRun = s.janitor.lastRun
+	s.janitor.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, status)
+}
+
+// HandleRunRetention enforces the retention limits now; with dryRun=true it
+// only lists the reports that would be removed
+func (s *Server) HandleRunRetention(w http.ResponseWriter, r *http.Request) {
+	if !s.config.Retention.Enabled() {
+		writeError(w, http.StatusConflict, "No retention limits are configured")
+		return
+	}
+
+	run := s.enforceRetention(r.Context(), r.URL.Query().Get("dryRun") == "true")
+	writeJSON(w, http.StatusOK, run)
+}
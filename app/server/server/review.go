@@ -0,0 +1,135 @@
+// app/server/server/review.go
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// approveRequest is the optional body of an approval
+type approveRequest struct {
+	// Reviewer is used when no authenticating proxy sets X-Forwarded-User and
+	// the review gate is off; with the gate on it is ignored
+	Reviewer string `json:"reviewer"`
+	Comment  string `json:"comment"`
+}
+
+// errNotAwaitingReview is returned when approving a report that isn't held
+var errNotAwaitingReview = errors.New("report is not awaiting review")
+
+// HandleApproveReport signs off a report held by the review gate and sends the
+// notification that was withheld. With the gate on, the reviewer must be
+// identified by an authenticating proxy, so nobody can approve as someone else.
+func (s *Server) HandleApproveReport(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	var req approveRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	reviewer := forwardedUser(r)
+	if reviewer == "" && s.config.ReviewRequired {
+		writeError(w, http.StatusUnauthorized, "Approving a report requires an identity from the authenticating proxy")
+		return
+	}
+	if reviewer == "" {
+		reviewer = strings.TrimSpace(req.Reviewer)
+	}
+
+	var heldEvent notify.EventType
+	now := time.Now().UTC()
+	approved, err := s.store.Mutate(report.ID, func(report *store.Report) error {
+		if !report.AwaitingReview() {
+			return errNotAwaitingReview
+		}
+		heldEvent = notify.EventType(report.Review.HeldEvent)
+		report.Review = &store.Review{
+			Status:     store.ReviewApproved,
+			Reviewer:   reviewer,
+			Comment:    strings.TrimSpace(req.Comment),
+			ReviewedAt: &now,
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, errNotAwaitingReview):
+		writeError(w, http.StatusConflict, "Report is not awaiting review")
+		return
+	case err != nil:
+		log.Printf("Error saving approval of report %s: %v", report.ID, err)
+		writeError(w, http.StatusInternalServerError, "Failed to approve report")
+		return
+	}
+
+	log.Printf("Report %s approved by %s", approved.ID, reviewerName(reviewer))
+	if heldEvent != "" {
+		s.notifyReport(approved, heldEvent)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":     approved.ID,
+		"review": approved.Review,
+	})
+}
+
+// holdForReview marks a new report as awaiting review, remembering the
+// notification to send once it is approved
+func (s *Server) holdForReview(report *store.Report, eventType notify.EventType) {
+	_, err := s.store.Mutate(report.ID, func(report *store.Report) error {
+		report.Review = &store.Review{
+			Status:    store.ReviewPending,
+			HeldEvent: string(eventType),
+		}
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error holding report %s for review: %v", report.ID, err)
+		return
+	}
+	log.Printf("Report %s is awaiting review before notifications and exports", report.ID)
+}
+
+// requireApproved writes a conflict response and returns false if the report
+// is still awaiting review
+func (s *Server) requireApproved(w http.ResponseWriter, report *store.Report) bool {
+	if report.AwaitingReview() {
+		writeError(w, http.StatusConflict, "Report is awaiting review")
+		return false
+	}
+	return true
+}
+
+// reviewStatus returns the review status of a report, or "" if it was never held
+func reviewStatus(report *store.Report) string {
+	if report.Review == nil {
+		return ""
+	}
+	return report.Review.Status
+}
+
+// reviewerName returns a reviewer for log messages
+func reviewerName(reviewer string) string {
+	if reviewer == "" {
+		return "an anonymous reviewer"
+	}
+	return reviewer
+}
+
+// forwardedUser returns the user an authenticating proxy such as oauth-proxy
+// identified, or "" without one
+func forwardedUser(r *http.Request) string {
+	return strings.TrimSpace(r.Header.Get("X-Forwarded-User"))
+}
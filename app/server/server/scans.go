@@ -0,0 +1,250 @@
+// app/server/server/scans.go
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/i18n"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/jobs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scanner"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scheduler"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// errClusterUnavailable is returned when a scan targets a cluster we have no credentials for
+var errClusterUnavailable = errors.New("cluster is not available for live scans")
+
+// scanRequest is the body of the on-demand scan endpoint
+type scanRequest struct {
+	Cluster string `json:"cluster"`
+
+	// TargetVersion is the OpenShift version the update readiness section is
+	// assessed for, the latest available update when empty
+	TargetVersion string `json:"targetVersion,omitempty"`
+
+	// Incremental reuses the results of checks whose inputs haven't changed
+	// since the last scan of the cluster
+	Incremental bool `json:"incremental,omitempty"`
+
+	// The namespace lists and selector limiting the workload-level checks
+	types.ScanScope
+}
+
+// HandleScan runs an on-demand live scan and returns the stored summary
+func (s *Server) HandleScan(w http.ResponseWriter, r *http.Request) {
+	var req scanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if err := scanner.ValidateScope(req.ScanScope); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := s.runScan(r.Context(), req.Cluster, scanner.Options{
+		UpgradeTarget: req.TargetVersion,
+		Incremental:   req.Incremental,
+		Scope:         req.ScanScope,
+	}, jobs.ClassInteractive)
+	if errors.Is(err, errClusterUnavailable) {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("Error scanning cluster %s: %v", req.Cluster, err)
+		writeErrorf(w, http.StatusInternalServerError, "Scan failed: %s", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, s.presentSummary(report, report.Summary))
+}
+
+// HandleUpgradeReadiness assesses the blockers of updating a cluster to the
+// target version without running the other checks or storing a report
+func (s *Server) HandleUpgradeReadiness(w http.ResponseWriter, r *http.Request) {
+	clients, err := s.clientsFor(r.PathValue("name"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	readiness, err := scanner.AssessUpgrade(r.Context(), clients, r.URL.Query().Get("target"))
+	if errors.Is(err, scanner.ErrInvalidTarget) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		log.Printf("Error assessing upgrade readiness of cluster %s: %v", clients.ClusterName, err)
+		writeErrorf(w, http.StatusInternalServerError, "Scan failed: %s", err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, readiness)
+}
+
+// HandleListChecks returns the checks live scans run, in order
+func (s *Server) HandleListChecks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.scanner.Checks())
+}
+
+// HandleListSchedules returns all scan schedules
+func (s *Server) HandleListSchedules(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, s.scheduler.List())
+}
+
+// HandleCreateSchedule adds a scan schedule
+func (s *Server) HandleCreateSchedule(w http.ResponseWriter, r *http.Request) {
+	var schedule scheduler.Schedule
+	if err := json.NewDecoder(r.Body).Decode(&schedule); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if _, err := s.clusters.Get(schedule.Cluster); err != nil && !s.isLiveCluster(schedule.Cluster) {
+		writeError(w, http.StatusBadRequest, "Cluster is not registered")
+		return
+	}
+
+	created, err := s.scheduler.Create(schedule)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// HandleDeleteSchedule removes a scan schedule created through the API
+func (s *Server) HandleDeleteSchedule(w http.ResponseWriter, r *http.Request) {
+	err := s.scheduler.Delete(r.PathValue("id"))
+	switch {
+	case errors.Is(err, scheduler.ErrNotFound):
+		writeError(w, http.StatusNotFound, "Schedule not found")
+	case errors.Is(err, scheduler.ErrReadOnly):
+		writeError(w, http.StatusConflict, err.Error())
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "Failed to delete schedule")
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// runScheduledScan is the scheduler callback; scheduled scans run in the batch class
+func (s *Server) runScheduledScan(ctx context.Context, cluster string) (string, error) {
+	report, err := s.runScan(ctx, cluster, scanner.Options{Incremental: s.config.IncrementalScans}, jobs.ClassBatch)
+	if err != nil {
+		return "", err
+	}
+	return report.ID, nil
+}
+
+// runScan scans a cluster, stores the result as a report and sends notifications
+func (s *Server) runScan(ctx context.Context, cluster string, options scanner.Options, class jobs.Class) (*store.Report, error) {
+	clients, err := s.clientsFor(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	options.Workers = s.config.ScanWorkers
+	result, err := s.queue.Run(ctx, class, "scan", func(ctx context.Context) (interface{}, error) {
+		return s.scanAndStore(ctx, clients, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report := result.(*store.Report)
+	s.notifyReport(report, notify.EventScanCompleted)
+	return report, nil
+}
+
+// scanAndStore runs the live checks and stores the rendered document like an upload
+func (s *Server) scanAndStore(ctx context.Context, clients *live.Clients, options scanner.Options) (report *store.Report, err error) {
+	ctx, span := tracing.Start(ctx, "scanAndStore", attribute.String("cluster", clients.ClusterName))
+	defer func() { tracing.End(span, err) }()
+
+	scan, err := s.scanner.Scan(ctx, clients, options)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the rendered document so the scan can be re-scored like any upload
+	id := store.NewID()
+	key := store.RawKey(id, ".adoc")
+	document, err := s.renderScan(scan)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.blobs.Put(ctx, key, bytes.NewReader(document)); err != nil {
+		return nil, fmt.Errorf("error storing scan document: %w", err)
+	}
+
+	summary, err := s.parseStoredDocument(ctx, key, utils.ParserProfileDefault, i18n.DefaultLanguage)
+	if err != nil {
+		s.blobs.Delete(context.Background(), key)
+		return nil, err
+	}
+	summary.ClusterName = clients.ClusterName
+
+	cluster := s.clusters.Resolve(clients.ClusterName)
+	profile, _ := s.scoringProfile("", cluster)
+	s.applyScoring(summary, profile)
+
+	// Record the scope with the report, as re-scoring replaces the summary
+	report, err = s.store.Create(&store.Report{
+		ID:       id,
+		Filename: fmt.Sprintf("live-scan-%s.adoc", clients.ClusterName),
+		RawKey:   key,
+		Cluster:  cluster,
+		Summary:  summary,
+		Scope:    scan.Scope,
+	})
+	if err != nil {
+		s.blobs.Delete(context.Background(), key)
+		return nil, err
+	}
+
+	log.Printf("Stored live scan of %s as report %s (%d results)", clients.ClusterName, report.ID, len(scan.Results))
+	return report, nil
+}
+
+// isLiveCluster reports whether name is the cluster the server runs on in live mode
+func (s *Server) isLiveCluster(name string) bool {
+	return s.live != nil && strings.EqualFold(name, s.live.ClusterName)
+}
+
+// clientsFor returns the API clients for a registered cluster, or for the
+// cluster the server runs on in live mode
+func (s *Server) clientsFor(cluster string) (*live.Clients, error) {
+	if cluster == "" || s.isLiveCluster(cluster) {
+		if s.live == nil {
+			return nil, fmt.Errorf("%w: no cluster given", errClusterUnavailable)
+		}
+		return s.live, nil
+	}
+
+	clients, err := s.clusters.Clients(cluster)
+	if errors.Is(err, clusters.ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", errClusterUnavailable, cluster)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errClusterUnavailable, err)
+	}
+	return clients, nil
+}
@@ -0,0 +1,63 @@
+// app/server/server/scoring.go
+package server
+
+import (
+	"net/http"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// HandleListScoringPresets returns the built-in scoring presets
+func (s *Server) HandleListScoringPresets(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"default": s.settings.Current().Scoring.Name,
+		"presets": scoring.Presets(),
+	})
+}
+
+// scoringProfile picks the profile for a report: the requested preset, then
+// the profile of the cluster's organization, then the server default
+func (s *Server) scoringProfile(requested, cluster string) (scoring.Profile, error) {
+	if requested != "" {
+		return scoring.Preset(requested)
+	}
+
+	if cluster != "" {
+		if registered, err := s.clusters.Get(cluster); err == nil && registered.Org != "" {
+			if org, err := s.orgs.Get(registered.Org); err == nil {
+				return org.ScoringProfile, nil
+			}
+		}
+	}
+
+	return s.settings.Current().Scoring, nil
+}
+
+// applyScoring maps custom template categories to the configured ones, scores
+// a freshly parsed summary with the given profile and lists its categories
+func (s *Server) applyScoring(summary *types.ReportSummary, profile scoring.Profile) {
+	s.settings.Current().MapCategories(summary)
+	scoring.Apply(summary, profile)
+	scoring.Categorize(summary, profile)
+}
+
+// rescoredSummary returns the report summary, re-scored with the preset named
+// by the "scoring" query parameter if there is one; the stored report is not changed
+func (s *Server) rescoredSummary(w http.ResponseWriter, r *http.Request, report *store.Report) (*types.ReportSummary, bool) {
+	requested := r.URL.Query().Get("scoring")
+	if requested == "" {
+		return report.Summary, true
+	}
+
+	profile, err := scoring.Preset(requested)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Unknown scoring preset")
+		return nil, false
+	}
+
+	summary := *report.Summary
+	scoring.Apply(&summary, profile)
+	return &summary, true
+}
@@ -3,47 +3,250 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/graph-gophers/graphql-go"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/alerting"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/blob"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/branding"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/bundle"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/clusters"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/confluence"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/gitops"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/insights"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/jira"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/jobs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/knowledge"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/operator"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/orgs"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/remediation"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scanner"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scheduler"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/settings"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/signing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/templates"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
 	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/uploads"
 	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/waivers"
+	"github.com/ayaseen/openshift-health-dashboard/app/web"
 )
 
 // Config holds server configuration
 type Config struct {
 	StaticDir string
+
+	// StaticAssets picks where the dashboard UI is served from: "auto" uses
+	// the assets compiled in with -tags embedassets and falls back to
+	// StaticDir, "embedded" requires compiled-in assets and "disk" always
+	// serves StaticDir, e.g. while developing the UI
+	StaticAssets string
+
 	Port      string
 	DebugMode bool
+	DataDir   string
+	BlobDir   string
+	PublicURL string
+
+	// ArchiveDir is an optional cold storage directory, such as a cheaper
+	// volume, that the raw documents of archived reports are moved to
+	ArchiveDir string
+
+	// BindAddress is the interface to listen on; empty listens on all of them
+	BindAddress string
+
+	// StoreDriver selects where reports and raw uploads are kept: "file" uses
+	// DataDir and BlobDir, "sqlite" keeps both in a single database file in DataDir
+	StoreDriver string
+
+	// SchedulesFile is an optional YAML file of read-only scan schedules
+	SchedulesFile string
+
+	// SettingsFile is an optional YAML file of settings that can be reloaded
+	// at runtime: scoring, category mappings, the extraction order, parser
+	// profiles, cluster groups and notification targets
+	SettingsFile string
+
+	// KnowledgeDir is an optional directory of YAML knowledge base files that
+	// add to or replace the built-in remediation guidance
+	KnowledgeDir string
+
+	// ChecksFile is an optional YAML file of external checks, commands that
+	// live scans run after the built-in checks
+	ChecksFile string
+
+	// IncludeDir is an optional directory the include directives of uploaded
+	// AsciiDoc documents are resolved against, after the files of a bundle
+	IncludeDir string
+
+	// IncrementalScans makes scheduled scans reuse the results of checks
+	// whose input resources haven't changed since the previous scan
+	IncrementalScans bool
+
+	// ScanWorkers is how many checks of a scan run at once
+	ScanWorkers int
+
+	// ScanLimits rate limit the API requests to each scanned cluster
+	ScanLimits live.Limits
+
+	// AutoRemediation allows applying the suggested manifests of safe
+	// remediations to clusters once they are approved through the API
+	AutoRemediation bool
+
+	// ScoringPreset names the scoring preset used when neither the request nor
+	// the cluster's organization picks one
+	ScoringPreset string
+
+	// StatusPages enables the public, unauthenticated status page of each cluster
+	StatusPages bool
+
+	// GraphQL serves the GraphQL API at /api/graphql
+	GraphQL bool
+
+	// DemoMode seeds an empty store with synthetic reports of a small fleet
+	DemoMode bool
+
+	// ReviewRequired holds notifications and exports of new reports until a
+	// reviewer approves the parsed results
+	ReviewRequired bool
+
+	// StrictReadiness makes every dependency critical, so /readyz fails when
+	// an integration such as Jira is down instead of reporting degraded
+	StrictReadiness bool
+
+	// CredentialsDir holds one mounted secret per registered cluster credentials reference
+	CredentialsDir string
+
+	// EncryptionKey is a base64-encoded 32-byte key; when set, customer and
+	// cluster identifiers are encrypted in the report store
+	EncryptionKey string
+
+	// UploadSessionTTL is how long an unfinished chunked upload is kept
+	UploadSessionTTL time.Duration
+
+	TLS        TLSConfig
+	RateLimit  RateLimitConfig
+	Retention  RetentionConfig
+	Jobs       jobs.Config
+	Live       live.Config
+	Notify     notify.Config
+	Jira       jira.Config
+	Insights   insights.Config
+	GitOps     gitops.Config
+	Confluence confluence.Config
+	Alerting   alerting.Config
+	Signing    signing.Config
+	Tracing    tracing.Config
+	Operator   operator.Config
+	Tenancy    TenancyConfig
 }
 
 // Server represents the HTTP server
 type Server struct {
-	config     Config
-	handler    http.Handler
-	httpServer *http.Server
-	isReady    atomic.Bool
+	config       Config
+	handler      http.Handler
+	httpServer   *http.Server
+	isReady      atomic.Bool
+	store        *store.Store
+	clusters     *clusters.Registry
+	orgs         *orgs.Registry
+	waivers      *waivers.Registry
+	remediations *remediation.Registry
+	templates    *templates.Registry
+	branding     *branding.Store
+	graphQL      *graphql.Schema
+	uploads      *uploads.Manager
+	blobs        blob.Backend
+	queue        *jobs.Queue
+	live         *live.Clients
+	notifier     *notify.Dispatcher
+	settings     *settings.Manager
+	knowledge    *knowledge.Catalog
+	signing      *signing.Verifier
+	janitor      janitor
+	readiness    readiness
+	reloadMu     sync.Mutex
+	jira         *jira.Client
+	insights     *insights.Client
+	gitops       *gitops.Client
+	confluence   *confluence.Client
+	scanner      *scanner.Scanner
+	scheduler    *scheduler.Scheduler
+	operator     *operator.Operator
+
+	// managedNotify holds the notification targets of the HealthDashboard
+	// resource, added to those of the settings; guarded by reloadMu
+	managedNotify notify.Config
+
+	assets   *staticAssets
+	certs    *certReloader
+	redirect *http.Server
+	db       *sql.DB
+
+	// mux routes the requests of a tenant server, without the middleware of the deployment
+	mux *http.ServeMux
+
+	// tenants holds the server of every tenant when the deployment is shared
+	tenants map[string]*Server
+
+	// limiter rate limits the API, of the deployment and of its tenants
+	limiter *apiLimiter
+
+	// stopTracing flushes the spans not exported yet
+	stopTracing func(context.Context) error
+
+	// ctx is cancelled on shutdown to stop background work
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewServer creates a new server instance
 func NewServer(config Config) *Server {
 	// Create the server
 	s := &Server{
-		config: config,
+		config:   config,
+		queue:    jobs.NewQueue(config.Jobs),
+		notifier: notify.NewDispatcher(config.Notify),
+		scanner:  scanner.New(),
 	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
 
 	// Set the server as not ready initially
 	s.isReady.Store(false)
 
+	// Set up optional integrations
+	if config.Jira.Enabled() {
+		s.jira = jira.NewClient(config.Jira)
+	}
+	if config.Insights.Enabled() {
+		s.insights = insights.NewClient(config.Insights)
+	}
+	if config.GitOps.Enabled() {
+		s.gitops = gitops.NewClient(config.GitOps)
+	}
+
 	// Set up the HTTP handler
 	s.setupHandler()
 
@@ -52,15 +255,200 @@ func NewServer(config Config) *Server {
 
 // Initialize performs any necessary initialization before the server starts
 func (s *Server) Initialize() error {
-	// Check if static directory exists
-	if _, err := os.Stat(s.config.StaticDir); os.IsNotExist(err) {
-		return fmt.Errorf("static directory does not exist: %s", s.config.StaticDir)
+	if err := s.checkStaticAssets(); err != nil {
+		return err
+	}
+
+	if s.config.Tenancy.Enabled() {
+		if err := s.config.Tenancy.Validate(); err != nil {
+			return fmt.Errorf("invalid tenancy configuration: %w", err)
+		}
+	}
+
+	defaultProfile, err := scoring.Preset(s.config.ScoringPreset)
+	if err != nil {
+		return fmt.Errorf("invalid SCORING_PRESET: %w", err)
+	}
+
+	// Load the reloadable settings on top of the environment
+	s.settings, err = settings.NewManager(s.config.SettingsFile, settings.Settings{
+		Scoring:  defaultProfile,
+		Grades:   scoring.DefaultGrades(),
+		Notify:   s.config.Notify,
+		Alerting: s.config.Alerting,
+	})
+	if err != nil {
+		return fmt.Errorf("invalid SETTINGS_FILE: %w", err)
+	}
+	s.configureNotifier()
+
+	s.knowledge, err = knowledge.New(s.config.KnowledgeDir)
+	if err != nil {
+		return fmt.Errorf("invalid KNOWLEDGE_DIR: %w", err)
+	}
+
+	if s.config.ChecksFile != "" {
+		external, err := scanner.LoadExternalChecks(s.config.ChecksFile)
+		if err != nil {
+			return fmt.Errorf("invalid CHECKS_FILE: %w", err)
+		}
+		checks := scanner.DefaultChecks()
+		for _, check := range external {
+			checks = append(checks, check)
+		}
+		s.scanner = scanner.New(checks...)
+		log.Printf("Loaded %d external checks from %s", len(external), s.config.ChecksFile)
+	}
+
+	s.stopTracing, err = tracing.Setup(s.ctx, s.config.Tracing)
+	if err != nil {
+		return fmt.Errorf("invalid tracing configuration: %w", err)
+	}
+	if s.config.Tracing.Enabled() {
+		log.Printf("Exporting traces to %s", s.config.Tracing.Endpoint)
+	}
+
+	s.signing, err = signing.New(s.config.Signing)
+	if err != nil {
+		return fmt.Errorf("invalid signing configuration: %w", err)
+	}
+
+	// The Confluence client loads its page template, which may be invalid
+	if s.config.Confluence.Enabled() {
+		s.confluence, err = confluence.NewClient(s.config.Confluence)
+		if err != nil {
+			return fmt.Errorf("invalid CONFLUENCE_TEMPLATE: %w", err)
+		}
+	}
+
+	// Load the TLS certificate up front so a bad pair fails startup
+	if s.config.TLS.Enabled() {
+		certs, err := newCertReloader(s.config.TLS)
+		if err != nil {
+			return err
+		}
+		s.certs = certs
+	}
+
+	// Open the report records and the blob backend holding raw uploads
+	records, blobs, err := s.openStorage()
+	if err != nil {
+		return err
+	}
+	s.blobs = blobs
+
+	// Open the report store, encrypting identifiers when a key is configured
+	var cipher *store.Cipher
+	if s.config.EncryptionKey != "" {
+		if cipher, err = store.NewCipher(s.config.EncryptionKey); err != nil {
+			return fmt.Errorf("invalid STORE_ENCRYPTION_KEY: %w", err)
+		}
+	}
+
+	reportStore, err := store.New(records, blobs, cipher)
+	if err != nil {
+		return fmt.Errorf("failed to open report store: %w", err)
+	}
+	s.store = reportStore
+
+	// Archived reports keep their raw documents in cold storage when configured
+	if s.config.ArchiveDir != "" {
+		cold, err := blob.NewFileBackend(s.config.ArchiveDir)
+		if err != nil {
+			return fmt.Errorf("failed to open archive storage: %w", err)
+		}
+		reportStore.SetColdStorage(blob.Traced(cold))
+	}
+
+	// Load the cluster registry
+	registry, err := clusters.New(filepath.Join(s.config.DataDir, "clusters.json"), s.config.CredentialsDir, s.config.ScanLimits)
+	if err != nil {
+		return fmt.Errorf("failed to load cluster registry: %w", err)
 	}
+	s.clusters = registry
 
-	// Check if index.html exists in static directory
-	indexPath := filepath.Join(s.config.StaticDir, "index.html")
-	if _, err := os.Stat(indexPath); os.IsNotExist(err) {
-		return fmt.Errorf("index.html not found in static directory: %s", indexPath)
+	// Load the organizations
+	orgRegistry, err := orgs.New(filepath.Join(s.config.DataDir, "orgs.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load organizations: %w", err)
+	}
+	s.orgs = orgRegistry
+
+	// Load the waivers
+	waiverRegistry, err := waivers.New(filepath.Join(s.config.DataDir, "waivers.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load waivers: %w", err)
+	}
+	s.waivers = waiverRegistry
+
+	// Load the remediations
+	s.remediations, err = remediation.New(filepath.Join(s.config.DataDir, "remediations.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load remediations: %w", err)
+	}
+
+	// Load the template packs, kept in the blob backend
+	s.templates, err = templates.New(s.ctx, blobs)
+	if err != nil {
+		return fmt.Errorf("failed to load template packs: %w", err)
+	}
+
+	// Load the branding, kept in the blob backend as well
+	s.branding, err = branding.New(s.ctx, blobs)
+	if err != nil {
+		return fmt.Errorf("failed to load branding: %w", err)
+	}
+
+	// Open the chunked uploads, resuming those interrupted by a restart
+	s.uploads, err = uploads.New(filepath.Join(s.config.DataDir, "uploads"), s.config.UploadSessionTTL)
+	if err != nil {
+		return fmt.Errorf("failed to open upload sessions: %w", err)
+	}
+	s.startUploadExpiry()
+
+	// Connect to the cluster in live mode and start capturing events
+	if s.config.Live.Enabled {
+		clients, err := live.Connect(s.config.Live, s.config.ScanLimits)
+		if err != nil {
+			return fmt.Errorf("failed to connect to cluster: %w", err)
+		}
+		s.live = clients
+
+		go live.NewEventRecorder(clients, s.store).Run(s.ctx)
+		log.Printf("Live mode enabled for cluster %s", clients.ClusterName)
+	}
+
+	// Start the scan scheduler
+	sched, err := scheduler.New(s.runScheduledScan, s.config.SchedulesFile, filepath.Join(s.config.DataDir, "schedules.json"))
+	if err != nil {
+		return fmt.Errorf("failed to load scan schedules: %w", err)
+	}
+	s.scheduler = sched
+	s.scheduler.Start()
+
+	// Reconcile the registry and the schedules from a HealthDashboard resource
+	if err := s.startOperator(); err != nil {
+		return err
+	}
+
+	// Bring reports stored by older versions up to date in the background
+	s.startMigrations()
+
+	// Seed the synthetic fleet in demo mode
+	if s.config.DemoMode {
+		if err := s.seedDemo(s.ctx); err != nil {
+			return fmt.Errorf("failed to seed demo data: %w", err)
+		}
+	}
+
+	// Remove reports beyond the retention limits periodically
+	s.startJanitor()
+
+	// Open the isolated storage of every tenant
+	if s.config.Tenancy.Enabled() {
+		if err := s.startTenants(); err != nil {
+			return err
+		}
 	}
 
 	log.Printf("Initialization complete, server is ready")
@@ -74,9 +462,25 @@ func (s *Server) Initialize() error {
 func (s *Server) setupHandler() {
 	// Create a custom handler with logging
 	mux := http.NewServeMux()
+	s.mux = mux
+	s.limiter = newAPILimiter(s.config.RateLimit)
 
-	// Add API endpoints
-	mux.HandleFunc("/api/parse-report", s.HandleReportUpload)
+	// The GraphQL schema is parsed once, its route only added when enabled
+	if s.config.GraphQL {
+		s.graphQL = s.newGraphQLSchema()
+	}
+
+	// Add API endpoints, mounted per version, or route them to the tenants
+	if s.config.Tenancy.Enabled() {
+		s.setupTenants(mux)
+	} else {
+		s.setupAPI(mux)
+	}
+
+	// Public status pages only expose scores, but are still opt-in
+	if s.config.StatusPages && !s.config.Tenancy.Enabled() {
+		mux.HandleFunc("GET /status/{cluster}", s.HandleStatusPage)
+	}
 
 	// Health check endpoint for liveness probe
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
@@ -85,18 +489,18 @@ func (s *Server) setupHandler() {
 		w.Write([]byte(`{"status":"ok"}`))
 	})
 
-	// Readiness probe endpoint
-	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
+	// Readiness probe endpoint, checking the downstream dependencies
+	mux.HandleFunc("/readyz", s.handleReadyz)
 
-		if s.isReady.Load() {
-			w.WriteHeader(http.StatusOK)
-			w.Write([]byte(`{"status":"ready"}`))
-		} else {
-			w.WriteHeader(http.StatusServiceUnavailable)
-			w.Write([]byte(`{"status":"not ready"}`))
-		}
-	})
+	// Prefer the assets compiled into the binary over STATIC_DIR
+	if s.config.StaticAssets != StaticAssetsDisk {
+		s.assets = newStaticAssets(s.config.DebugMode)
+	}
+	if s.assets != nil {
+		mux.Handle("/", s.assets)
+		s.handler = tracing.Handler(compressHandler(s.limiter.limit(mux)))
+		return
+	}
 
 	// Set up static file serving
 	staticHandler := http.FileServer(http.Dir(s.config.StaticDir))
@@ -106,11 +510,6 @@ func (s *Server) setupHandler() {
 			log.Printf("%s - %s %s", r.RemoteAddr, r.Method, r.URL.Path)
 		}
 
-		// Add headers to prevent caching
-		w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
-		w.Header().Set("Pragma", "no-cache")
-		w.Header().Set("Expires", "0")
-
 		// For API requests, let them be handled by specific handlers
 		if strings.HasPrefix(r.URL.Path, "/api/") {
 			return
@@ -118,7 +517,7 @@ func (s *Server) setupHandler() {
 
 		// Check if the path exists
 		path := filepath.Join(s.config.StaticDir, r.URL.Path)
-		_, err := os.Stat(path)
+		info, err := os.Stat(path)
 
 		// Special handling for root path or index.html
 		if r.URL.Path == "/" || r.URL.Path == "/index.html" {
@@ -127,6 +526,7 @@ func (s *Server) setupHandler() {
 				if s.config.DebugMode {
 					log.Println("Serving index.html for root path")
 				}
+				w.Header().Set("Cache-Control", cacheIndex)
 				http.ServeFile(w, r, indexPath)
 				return
 			}
@@ -147,21 +547,43 @@ func (s *Server) setupHandler() {
 			if s.config.DebugMode {
 				log.Printf("Path not found: %s, serving index.html for SPA routing", path)
 			}
+			w.Header().Set("Cache-Control", cacheIndex)
 			http.ServeFile(w, r, filepath.Join(s.config.StaticDir, "index.html"))
 			return
 		}
 
-		// Serve the file
+		// Serve the file, letting browsers keep fingerprinted assets for good
+		if err == nil && !info.IsDir() {
+			if web.Fingerprinted(path) {
+				w.Header().Set("Cache-Control", cacheImmutable)
+			} else {
+				w.Header().Set("Cache-Control", cacheRevalidate)
+			}
+			w.Header().Set("ETag", fileETag(info))
+		}
 		staticHandler.ServeHTTP(w, r)
 	}))
 
-	// Store the handler
-	s.handler = mux
+	// Store the handler, compressing responses for clients that accept it
+	s.handler = tracing.Handler(compressHandler(s.limiter.limit(mux)))
 }
 
-// HandleReportUpload processes uploaded AsciiDoc reports
 // HandleReportUpload processes uploaded AsciiDoc reports
 func (s *Server) HandleReportUpload(w http.ResponseWriter, r *http.Request) {
+	s.handleReportUpload(w, r, func(report *store.Report) interface{} {
+		return s.presentSummary(report, report.Summary)
+	})
+}
+
+// HandleReportUploadV2 processes uploaded AsciiDoc reports and returns the v2 summary
+func (s *Server) HandleReportUploadV2(w http.ResponseWriter, r *http.Request) {
+	s.handleReportUpload(w, r, func(report *store.Report) interface{} {
+		return s.presentSummary(report, report.Summary).V2()
+	})
+}
+
+// handleReportUpload stores and parses an upload; render selects the response body
+func (s *Server) handleReportUpload(w http.ResponseWriter, r *http.Request, render func(*store.Report) interface{}) {
 	// Set content type header and CORS headers
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Access-Control-Allow-Origin", "*")
@@ -176,7 +598,7 @@ func (s *Server) HandleReportUpload(w http.ResponseWriter, r *http.Request) {
 
 	// Check if the request method is POST
 	if r.Method != "POST" {
-		http.Error(w, `{"error":"Method not allowed"}`, http.StatusMethodNotAllowed)
+		writeError(w, http.StatusMethodNotAllowed, "Method not allowed")
 		return
 	}
 
@@ -184,81 +606,244 @@ func (s *Server) HandleReportUpload(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Handling report upload request")
 	}
 
-	// Parse the multipart form with 10MB max memory
-	err := r.ParseMultipartForm(10 << 20)
-	if err != nil {
-		log.Printf("Error parsing form: %v", err)
-		http.Error(w, `{"error":"Failed to parse form"}`, http.StatusBadRequest)
+	parser, requestedProfile, ok := s.uploadOptions(w, r)
+	if !ok {
 		return
 	}
 
-	// Get the file from the form
-	file, header, err := r.FormFile("report")
+	// Stream the uploaded file straight into the blob backend
+	if s.config.RateLimit.MaxUploadSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, s.config.RateLimit.MaxUploadSize)
+	}
+	id := store.NewID()
+	upload, err := s.streamUpload(r, id)
 	if err != nil {
-		log.Printf("Error getting file: %v", err)
-		http.Error(w, `{"error":"Failed to get file"}`, http.StatusBadRequest)
+		log.Printf("Error receiving upload: %v", err)
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			writeErrorf(w, http.StatusRequestEntityTooLarge, "Upload exceeds the maximum size of %d bytes", tooLarge.Limit)
+		case errors.Is(err, errMissingFile):
+			writeError(w, http.StatusBadRequest, "Failed to get file")
+		case errors.Is(err, errInvalidFileType):
+			writeError(w, http.StatusBadRequest, invalidFileTypeMessage)
+		case errors.Is(err, errUploadStorage):
+			writeError(w, http.StatusInternalServerError, "Failed to process file")
+		case errors.Is(err, errInvalidSignature):
+			writeErrorf(w, http.StatusBadRequest, "Signatures are limited to %d bytes", signing.MaxSignatureSize)
+		default:
+			writeError(w, http.StatusBadRequest, "Failed to parse form")
+		}
 		return
 	}
-	defer file.Close()
 
-	log.Printf("Received file: %s, size: %d bytes", header.Filename, header.Size)
+	log.Printf("Received file: %s, size: %d bytes", upload.Filename, upload.Size)
+	s.parseUpload(w, r, id, upload, parser, requestedProfile, render)
+}
 
-	// Check file extension
-	if !utils.IsValidAsciiDocFile(header.Filename) {
-		http.Error(w, `{"error":"Invalid file type. Only .adoc or .asciidoc files are allowed"}`, http.StatusBadRequest)
-		return
+// uploadOptions reads the scoring preset and the parser profile requested for
+// an upload, writing an error response when either is unknown
+func (s *Server) uploadOptions(w http.ResponseWriter, r *http.Request) (parser, requestedProfile string, ok bool) {
+	// An explicit scoring preset overrides the cluster's and the server's default
+	requestedProfile = r.URL.Query().Get("scoring")
+	if requestedProfile != "" {
+		if _, err := scoring.Preset(requestedProfile); err != nil {
+			writeError(w, http.StatusBadRequest, "Unknown scoring preset")
+			return "", "", false
+		}
 	}
 
-	// Create a temporary file
-	tempFile, err := os.CreateTemp("", "report-*.adoc")
-	if err != nil {
-		log.Printf("Error creating temp file: %v", err)
-		http.Error(w, `{"error":"Failed to process file"}`, http.StatusInternalServerError)
-		return
+	// The parser profile is detected from the document unless one is named
+	parser = r.URL.Query().Get("parser")
+	if !s.parseOptions().HasParserProfile(parser) {
+		writeError(w, http.StatusBadRequest, "Unknown parser profile")
+		return "", "", false
+	}
+	return parser, requestedProfile, true
+}
+
+// parseUpload parses a document received into the blob backend and writes the
+// rendered report, or the job parsing it for asynchronous uploads
+func (s *Server) parseUpload(w http.ResponseWriter, r *http.Request, id string, upload *uploadedFile, parser, requestedProfile string, render func(*store.Report) interface{}) {
+	// Descriptions generated while parsing are written in the request language
+	language := locale(r)
+
+	process := func(ctx context.Context) (interface{}, error) {
+		report, err := s.processUpload(ctx, id, upload, parser, requestedProfile, language)
+		if err != nil {
+			return nil, err
+		}
+		return render(report), nil
 	}
-	defer os.Remove(tempFile.Name())
-	defer tempFile.Close()
 
-	// Copy the uploaded file to the temporary file
-	_, err = io.Copy(tempFile, file)
-	if err != nil {
-		log.Printf("Error copying file: %v", err)
-		http.Error(w, `{"error":"Failed to process file"}`, http.StatusInternalServerError)
+	// Asynchronous uploads return the job right away; its result is the summary.
+	// Parse in the interactive class so uploads are never stuck behind batch work.
+	if r.URL.Query().Get("async") == "true" {
+		job, err := s.queue.Submit(jobs.ClassInteractive, "parse", process)
+		if err != nil {
+			s.blobs.Delete(context.Background(), upload.Key)
+			writeError(w, http.StatusServiceUnavailable, "Job queue is not accepting work")
+			return
+		}
+		w.Header().Set("Location", pathPrefix(r)+"/api/"+w.Header().Get("API-Version")+"/jobs/"+job.ID)
+		snapshot, _ := s.queue.Get(job.ID)
+		writeJSON(w, http.StatusAccepted, snapshot)
 		return
 	}
 
-	// Ensure file is flushed
-	tempFile.Sync()
-
-	// Try using the enhanced report parser first
-	summary, err := utils.ParseAsciiDocExecutiveSummary(tempFile.Name())
+	result, err := s.queue.Run(r.Context(), jobs.ClassInteractive, "parse", process)
 	if err != nil {
-		log.Printf("Error parsing report: %v", err)
-		http.Error(w, fmt.Sprintf(`{"error":"Failed to parse report: %s"}`, err), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, errUnknownCluster):
+			writeError(w, http.StatusBadRequest, "Unknown cluster")
+		case errors.Is(err, errUnverifiedSignature):
+			writeError(w, http.StatusUnprocessableEntity, "The report signature is missing or could not be verified")
+		case errors.Is(err, errInvalidBundle):
+			writeError(w, http.StatusBadRequest, err.Error())
+		case errors.Is(err, errStoreReport):
+			writeError(w, http.StatusInternalServerError, "Failed to store report")
+		default:
+			writeErrorf(w, http.StatusInternalServerError, "Failed to parse report: %s", err)
+		}
 		return
 	}
 
-	// Validate and fix summary data to ensure we have valid values
-	validateAndFixSummary(summary)
-
 	// Return the summary as JSON
 	encoder := json.NewEncoder(w)
 	encoder.SetEscapeHTML(false)
 	encoder.SetIndent("", "  ")
 
-	if err := encoder.Encode(summary); err != nil {
+	if err := encoder.Encode(result); err != nil {
 		log.Printf("Error encoding JSON: %v", err)
-		http.Error(w, `{"error":"Failed to encode response"}`, http.StatusInternalServerError)
+		writeError(w, http.StatusInternalServerError, "Failed to encode response")
 		return
 	}
+}
+
+// processUpload parses a stored upload, scores it and stores the report; the
+// upload is removed from the blob backend when it fails
+func (s *Server) processUpload(ctx context.Context, id string, upload *uploadedFile, parser, requestedProfile, language string) (report *store.Report, err error) {
+	ctx, span := tracing.Start(ctx, "processUpload",
+		attribute.String("report.id", id), attribute.String("report.filename", upload.Filename))
+	defer func() { tracing.End(span, err) }()
+
+	// The signature covers the uploaded bytes, so it's checked before a bundle is unpacked
+	verification, err := s.verifyUpload(ctx, upload)
+	if err != nil {
+		s.blobs.Delete(context.Background(), upload.Key)
+		return nil, err
+	}
+
+	var attachments []store.Attachment
+	if bundle.IsBundle(upload.Key) {
+		if attachments, err = s.unpackBundle(ctx, id, upload); err != nil {
+			s.blobs.Delete(context.Background(), upload.Key)
+			log.Printf("Error unpacking bundle: %v", err)
+			return nil, err
+		}
+	}
+	discard := func() {
+		s.blobs.Delete(context.Background(), upload.Key)
+		s.store.DeleteAttachments(context.Background(), id, attachments)
+	}
+
+	summary, err := s.parseStoredDocument(ctx, upload.Key, parser, language)
+	if err != nil {
+		discard()
+		log.Printf("Error parsing report: %v", err)
+		return nil, err
+	}
+
+	// Attach the report to a registered cluster, either the one named in the
+	// form or the one matching the name found in the document
+	cluster := s.clusters.Resolve(summary.ClusterName)
+	if upload.Cluster != "" {
+		cluster = s.clusters.Resolve(upload.Cluster)
+		if cluster == "" {
+			discard()
+			return nil, errUnknownCluster
+		}
+	}
+
+	profile, _ := s.scoringProfile(requestedProfile, cluster)
+	s.applyScoring(summary, profile)
+
+	// Store the report so it can be retrieved and exported later, with the
+	// signature verification in the same write
+	report, err = s.store.Create(&store.Report{
+		ID:          id,
+		Filename:    upload.Filename,
+		RawKey:      upload.Key,
+		Cluster:     cluster,
+		Summary:     summary,
+		Signature:   verification,
+		Attachments: attachments,
+	})
+	if err != nil {
+		discard()
+		log.Printf("Error storing report: %v", err)
+		return nil, fmt.Errorf("%w: %v", errStoreReport, err)
+	}
+
+	s.notifyReport(report, notify.EventReportUploaded)
 
 	if s.config.DebugMode {
-		log.Printf("Successfully processed report: %s", header.Filename)
+		log.Printf("Successfully processed report: %s", upload.Filename)
 		log.Printf("Found %d required changes, %d recommended changes, %d advisory items",
 			len(summary.ItemsRequired), len(summary.ItemsRecommended), len(summary.ItemsAdvisory))
 	}
+	return report, nil
+}
+
+// parseStoredDocument streams a document from the blob backend into the parser
+func (s *Server) parseStoredDocument(ctx context.Context, key, parser, language string) (*types.ReportSummary, error) {
+	reader, err := s.blobs.Open(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return s.parseReport(ctx, reader, utils.FormatForFilename(key), parser, language)
+}
+
+// parseReport parses an AsciiDoc, Markdown or HTML document into a validated
+// summary with the named parser profile, generating missing descriptions in
+// the given language; a profile that is no longer configured is detected again
+func (s *Server) parseReport(ctx context.Context, r io.Reader, format, parser, language string) (summary *types.ReportSummary, err error) {
+	_, span := tracing.Start(ctx, "parseReport", attribute.String("parser.profile", parser),
+		attribute.String("report.format", format), attribute.String("language", language))
+	defer func() {
+		if summary != nil {
+			span.SetAttributes(attribute.String("parser.profile.used", summary.ParserProfile),
+				attribute.Int("report.findings", len(summary.Findings)))
+		}
+		tracing.End(span, err)
+	}()
+
+	options := s.parseOptions()
+	if !options.HasParserProfile(parser) {
+		log.Printf("Parser profile %s is no longer configured, detecting the dialect instead", parser)
+		parser = utils.ParserProfileAuto
+	}
+	options.Profile = parser
+	options.Language = language
+	options.Format = format
+	return utils.ParseReportWithOptions(r, options)
 }
 
+// Patterns of the direct AsciiDoc parser, compiled once
+var (
+	legacyItemNamePattern     = regexp.MustCompile(`<<([^>]+)>>`)
+	legacyClusterNamePattern  = regexp.MustCompile(`['"]([^'"]+)['"]|cluster\s+([a-zA-Z0-9_-]+)`)
+	legacyCustomerNamePattern = regexp.MustCompile(`conducted.*?([A-Za-z0-9_\s]+)'s`)
+	legacyOverallScorePattern = regexp.MustCompile(`Overall\s+Cluster\s+Health:\s+(\d+\.?\d*)%`)
+	legacyAltScorePattern     = regexp.MustCompile(`Overall Health Score.*?(\d+\.?\d*)%`)
+	legacyPercentPattern      = regexp.MustCompile(`(\d+)%`)
+
+	// legacyCategoryPatterns caches the "*Name*: NN%" patterns by category name
+	legacyCategoryPatterns sync.Map
+)
+
 // parseAsciiDocReport parses an AsciiDoc report directly
 func parseAsciiDocReport(content string) (*types.ReportSummary, error) {
 	// Split content into lines
@@ -338,7 +923,7 @@ func parseAsciiDocReport(content string) (*types.ReportSummary, error) {
 			// Look for name in previous or next lines
 			for j := i - 5; j <= i+5 && j < len(lines); j++ {
 				if j >= 0 && strings.Contains(lines[j], "<<") && strings.Contains(lines[j], ">>") {
-					nameMatch := regexp.MustCompile(`<<([^>]+)>>`).FindStringSubmatch(lines[j])
+					nameMatch := legacyItemNamePattern.FindStringSubmatch(lines[j])
 					if len(nameMatch) > 1 {
 						itemName := nameMatch[1]
 
@@ -375,7 +960,7 @@ func parseAsciiDocReport(content string) (*types.ReportSummary, error) {
 			// Look for name in previous or next lines
 			for j := i - 5; j <= i+5 && j < len(lines); j++ {
 				if j >= 0 && strings.Contains(lines[j], "<<") && strings.Contains(lines[j], ">>") {
-					nameMatch := regexp.MustCompile(`<<([^>]+)>>`).FindStringSubmatch(lines[j])
+					nameMatch := legacyItemNamePattern.FindStringSubmatch(lines[j])
 					if len(nameMatch) > 1 {
 						itemName := nameMatch[1]
 
@@ -412,7 +997,7 @@ func parseAsciiDocReport(content string) (*types.ReportSummary, error) {
 			// Look for name in previous or next lines
 			for j := i - 5; j <= i+5 && j < len(lines); j++ {
 				if j >= 0 && strings.Contains(lines[j], "<<") && strings.Contains(lines[j], ">>") {
-					nameMatch := regexp.MustCompile(`<<([^>]+)>>`).FindStringSubmatch(lines[j])
+					nameMatch := legacyItemNamePattern.FindStringSubmatch(lines[j])
 					if len(nameMatch) > 1 {
 						itemName := nameMatch[1]
 
@@ -482,8 +1067,7 @@ func parseAsciiDocReport(content string) (*types.ReportSummary, error) {
 func extractClusterName(lines []string) string {
 	for _, line := range lines {
 		if strings.Contains(line, "cluster") {
-			re := regexp.MustCompile(`['"]([^'"]+)['"]|cluster\s+([a-zA-Z0-9_-]+)`)
-			matches := re.FindStringSubmatch(line)
+			matches := legacyClusterNamePattern.FindStringSubmatch(line)
 			if len(matches) > 1 {
 				if matches[1] != "" {
 					return matches[1]
@@ -501,8 +1085,7 @@ func extractClusterName(lines []string) string {
 func extractCustomerName(lines []string) string {
 	for _, line := range lines {
 		if strings.Contains(line, "conducted") && strings.Contains(line, "health check") {
-			re := regexp.MustCompile(`conducted.*?([A-Za-z0-9_\s]+)'s`)
-			matches := re.FindStringSubmatch(line)
+			matches := legacyCustomerNamePattern.FindStringSubmatch(line)
 			if len(matches) > 1 {
 				return strings.TrimSpace(matches[1])
 			}
@@ -516,9 +1099,8 @@ func extractOverallScore(lines []string) float64 {
 	var score float64
 
 	// Look for explicit score notation
-	scorePattern := regexp.MustCompile(`Overall\s+Cluster\s+Health:\s+(\d+\.?\d*)%`)
 	for _, line := range lines {
-		matches := scorePattern.FindStringSubmatch(line)
+		matches := legacyOverallScorePattern.FindStringSubmatch(line)
 		if len(matches) > 1 {
 			fmt.Sscanf(matches[1], "%f", &score)
 			return score
@@ -526,9 +1108,8 @@ func extractOverallScore(lines []string) float64 {
 	}
 
 	// Check for alternative score format
-	altScorePattern := regexp.MustCompile(`Overall Health Score.*?(\d+\.?\d*)%`)
 	for _, line := range lines {
-		matches := altScorePattern.FindStringSubmatch(line)
+		matches := legacyAltScorePattern.FindStringSubmatch(line)
 		if len(matches) > 1 {
 			fmt.Sscanf(matches[1], "%f", &score)
 			return score
@@ -543,7 +1124,13 @@ func extractCategoryScore(lines []string, categoryName string) int {
 	var score int
 
 	// Look for category score in various formats
-	scorePattern := regexp.MustCompile(fmt.Sprintf(`\*%s\*:\s+(\d+)%%`, regexp.QuoteMeta(categoryName)))
+	var scorePattern *regexp.Regexp
+	if cached, ok := legacyCategoryPatterns.Load(categoryName); ok {
+		scorePattern = cached.(*regexp.Regexp)
+	} else {
+		scorePattern = regexp.MustCompile(fmt.Sprintf(`\*%s\*:\s+(\d+)%%`, regexp.QuoteMeta(categoryName)))
+		legacyCategoryPatterns.Store(categoryName, scorePattern)
+	}
 	for _, line := range lines {
 		matches := scorePattern.FindStringSubmatch(line)
 		if len(matches) > 1 {
@@ -555,8 +1142,7 @@ func extractCategoryScore(lines []string, categoryName string) int {
 	// Try partial matching if exact match not found
 	for _, line := range lines {
 		if strings.Contains(strings.ToLower(line), strings.ToLower(categoryName)) && strings.Contains(line, "%") {
-			re := regexp.MustCompile(`(\d+)%`)
-			matches := re.FindStringSubmatch(line)
+			matches := legacyPercentPattern.FindStringSubmatch(line)
 			if len(matches) > 1 {
 				fmt.Sscanf(matches[1], "%d", &score)
 				return score
@@ -591,166 +1177,73 @@ func extractCategoryDescription(lines []string, categoryName string) string {
 func (s *Server) Start() error {
 	// Create a custom server with timeouts
 	s.httpServer = &http.Server{
-		Addr:         fmt.Sprintf(":%s", s.config.Port),
+		Addr:         net.JoinHostPort(s.config.BindAddress, s.config.Port),
 		Handler:      s.handler,
 		ReadTimeout:  30 * time.Second,
 		WriteTimeout: 30 * time.Second,
 		IdleTimeout:  120 * time.Second,
+		Protocols:    new(http.Protocols),
 	}
+	s.httpServer.Protocols.SetHTTP1(true)
+	s.httpServer.Protocols.SetHTTP2(true)
 
-	log.Printf("Server starting on port %s", s.config.Port)
-
-	// Start the server
-	return s.httpServer.ListenAndServe()
-}
-
-// Shutdown gracefully shuts down the server
-func (s *Server) Shutdown(ctx context.Context) error {
-	log.Println("Shutting down server...")
-	if s.httpServer != nil {
-		return s.httpServer.Shutdown(ctx)
+	if s.certs == nil {
+		s.httpServer.Protocols.SetUnencryptedHTTP2(s.config.TLS.CleartextHTTP2)
+		log.Printf("Server starting on port %s", s.config.Port)
+		return s.httpServer.ListenAndServe()
 	}
-	return nil
-}
 
-// validateAndFixSummary ensures all summary fields have valid values
-func validateAndFixSummary(summary *types.ReportSummary) {
-	// Ensure we have a valid overall score
-	if summary.OverallScore <= 0 {
-		// Calculate from category scores
-		totalScore := float64(0)
-		categoryCount := 0
-
-		if summary.ScoreInfra > 0 {
-			totalScore += float64(summary.ScoreInfra)
-			categoryCount++
-		}
-		if summary.ScoreGovernance > 0 {
-			totalScore += float64(summary.ScoreGovernance)
-			categoryCount++
-		}
-		if summary.ScoreCompliance > 0 {
-			totalScore += float64(summary.ScoreCompliance)
-			categoryCount++
-		}
-		if summary.ScoreMonitoring > 0 {
-			totalScore += float64(summary.ScoreMonitoring)
-			categoryCount++
-		}
-		if summary.ScoreBuildSecurity > 0 {
-			totalScore += float64(summary.ScoreBuildSecurity)
-			categoryCount++
-		}
-
-		if categoryCount > 0 {
-			summary.OverallScore = totalScore / float64(categoryCount)
-		} else {
-			summary.OverallScore = 75.0 // Default to a reasonable score if none found
-		}
+	s.httpServer.TLSConfig = &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: s.certs.GetCertificate,
 	}
 
-	// Calculate the status counts
-	requiredCount := len(summary.ItemsRequired)
-	recommendedCount := len(summary.ItemsRecommended)
-	advisoryCount := len(summary.ItemsAdvisory)
-
-	// Use these counts for score adjustments if needed
-
-	// Ensure Infrastructure score is valid
-	if summary.ScoreInfra <= 0 {
-		// Weight: required=0%, recommended=50%, advisory=80%, noChange=100%
-		// For simplicity, we'll use a fallback formula based on item counts
-		if requiredCount > 0 {
-			summary.ScoreInfra = 60 // Some critical issues
-		} else if recommendedCount > 0 {
-			summary.ScoreInfra = 80 // Minor issues
-		} else {
-			summary.ScoreInfra = 91 // No major issues
-		}
-	}
-
-	// Ensure Governance score is valid
-	if summary.ScoreGovernance <= 0 {
-		if requiredCount > 0 {
-			summary.ScoreGovernance = 65
-		} else if recommendedCount > 0 {
-			summary.ScoreGovernance = 75
-		} else {
-			summary.ScoreGovernance = 85 // Better default if no issues
+	// Optionally redirect plain HTTP to HTTPS
+	if port := s.config.TLS.RedirectPort; port != "" {
+		s.redirect = &http.Server{
+			Addr:         net.JoinHostPort(s.config.BindAddress, port),
+			Handler:      httpsRedirect(s.config.Port),
+			ReadTimeout:  10 * time.Second,
+			WriteTimeout: 10 * time.Second,
 		}
+		go func() {
+			log.Printf("Redirecting HTTP on port %s to HTTPS", port)
+			if err := s.redirect.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				log.Printf("HTTP redirect server error: %v", err)
+			}
+		}()
 	}
 
-	// Ensure Compliance score is valid
-	if summary.ScoreCompliance <= 0 {
-		if recommendedCount > 0 {
-			summary.ScoreCompliance = 75
-		} else {
-			summary.ScoreCompliance = 85 // Better default if no issues
-		}
-	}
+	log.Printf("Server starting on port %s with TLS", s.config.Port)
+	return s.httpServer.ListenAndServeTLS("", "")
+}
 
-	// Ensure Monitoring score is valid
-	if summary.ScoreMonitoring <= 0 {
-		if recommendedCount > 0 {
-			summary.ScoreMonitoring = 66
-		} else {
-			summary.ScoreMonitoring = 80
-		}
+// Shutdown gracefully shuts down the server
+func (s *Server) Shutdown(ctx context.Context) error {
+	log.Println("Shutting down server...")
+	if s.redirect != nil {
+		s.redirect.Shutdown(ctx)
 	}
-
-	// Ensure Build/Deploy Security score is valid
-	if summary.ScoreBuildSecurity <= 0 {
-		if recommendedCount > 0 || advisoryCount > 0 {
-			summary.ScoreBuildSecurity = 70
-		} else {
-			summary.ScoreBuildSecurity = 85
+	if s.httpServer != nil {
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			return err
 		}
 	}
 
-	// Ensure we have descriptions for all categories
-	if summary.InfraDescription == "" {
-		summary.InfraDescription = utils.GenerateDescription("Infrastructure Setup", summary.ScoreInfra)
-	}
-	if summary.GovernanceDescription == "" {
-		summary.GovernanceDescription = utils.GenerateDescription("Policy Governance", summary.ScoreGovernance)
-	}
-	if summary.ComplianceDescription == "" {
-		summary.ComplianceDescription = utils.GenerateDescription("Compliance Benchmarking", summary.ScoreCompliance)
-	}
-	if summary.MonitoringDescription == "" {
-		summary.MonitoringDescription = utils.GenerateDescription("Monitoring", summary.ScoreMonitoring)
-	}
-	if summary.BuildSecurityDescription == "" {
-		summary.BuildSecurityDescription = utils.GenerateDescription("Build/Deploy Security", summary.ScoreBuildSecurity)
-	}
-
-	// Initialize arrays if they're nil
-	if summary.ItemsRequired == nil {
-		summary.ItemsRequired = []string{}
-	}
-	if summary.ItemsRecommended == nil {
-		summary.ItemsRecommended = []string{}
+	// Stop background work once no new requests can submit it
+	s.shutdownTenants(ctx)
+	s.cancel()
+	if s.scheduler != nil {
+		s.scheduler.Stop()
 	}
-	if summary.ItemsAdvisory == nil {
-		summary.ItemsAdvisory = []string{}
+	s.queue.Stop()
+	if s.db != nil {
+		s.db.Close()
 	}
-
-	// Ensure NoChangeCount has a reasonable value if it's zero
-	if summary.NoChangeCount <= 0 {
-		// If we have accurate counts from the document, use those
-		_, _, _, noChange, notApplicable := utils.CountAllStatusItems([]string{})
-		if noChange > 0 {
-			summary.NoChangeCount = noChange
-		} else {
-			// Otherwise estimate based on the adoc file content - from analysis of expected values
-			summary.NoChangeCount = 28
-		}
-
-		// Also set NotApplicableCount if needed
-		if summary.NotApplicableCount <= 0 && notApplicable > 0 {
-			summary.NotApplicableCount = notApplicable
-		} else if summary.NotApplicableCount <= 0 {
-			summary.NotApplicableCount = 7 // Average value from analysis
+	if s.stopTracing != nil {
+		if err := s.stopTracing(ctx); err != nil {
+			log.Printf("Error flushing traces: %v", err)
 		}
 	}
+	return nil
 }
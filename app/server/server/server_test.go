@@ -0,0 +1,61 @@
+// app/server/server/server_test.go
+package server
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// benchmarkStatusCells are the status cells cycled through by benchmarkDocument
+var benchmarkStatusCells = []string{
+	"|{set:cellbgcolor:#FF0000}",
+	"|{set:cellbgcolor:#FEFE20}",
+	"|{set:cellbgcolor:#80E5FF}",
+	"|{set:cellbgcolor:#00FF00}",
+}
+
+// benchmarkDocument generates a report of about the given number of lines in
+// the layout parseAsciiDocReport reads
+func benchmarkDocument(lineCount int) string {
+	var b strings.Builder
+	b.WriteString("Red Hat conducted a health check of Acme Corp's OpenShift cluster 'bench'.\n\n")
+	b.WriteString("= Summary\n\n|===\n|*Category* |*Item Evaluated* |*Observed Result*\n\n")
+	for i, lines := 0, 6; lines < lineCount; i, lines = i+1, lines+5 {
+		fmt.Fprintf(&b, "|Cluster Config\n|<<Item %d>>\n|Observation for item %d\n%s\n\n",
+			i, i, benchmarkStatusCells[i%len(benchmarkStatusCells)])
+	}
+	b.WriteString("|===\n")
+	return b.String()
+}
+
+// BenchmarkParseAsciiDocReport parses a 50k-line report with the direct parser
+func BenchmarkParseAsciiDocReport(b *testing.B) {
+	content := benchmarkDocument(50000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parseAsciiDocReport(content); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// FuzzParseAsciiDocReport feeds arbitrary documents to the direct parser,
+// which looks around each status cell and must stay within the document
+func FuzzParseAsciiDocReport(f *testing.F) {
+	f.Add(benchmarkDocument(60))
+	f.Add("= Summary\n|===\n|*Category* |*Item Evaluated*\n|{set:cellbgcolor:#FF0000}\n|<<A>>\n|===")
+	f.Add("= Summary\n|===\n|*Category*|*Item Evaluated*\n<<B>>\n{set:cellbgcolor:#FEFE20}\n{set:cellbgcolor:#80E5FF}")
+	f.Add("*Cluster Config*: 85%\nOverall Score: 90%\n= Summary")
+
+	f.Fuzz(func(t *testing.T, content string) {
+		summary, err := parseAsciiDocReport(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		lines := strings.Count(content, "\n") + 1
+		if items := len(summary.ItemsRequired) + len(summary.ItemsRecommended) + len(summary.ItemsAdvisory); items > lines {
+			t.Fatalf("%d items in %d lines", items, lines)
+		}
+	})
+}
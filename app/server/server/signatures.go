@@ -0,0 +1,47 @@
+// app/server/server/signatures.go
+package server
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/signing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// verifyUpload checks the detached signature of an upload against the trusted
+// keys. Uploads without a signature return nil, unless signatures are
+// required; then uploads without a verified signature are rejected.
+func (s *Server) verifyUpload(ctx context.Context, upload *uploadedFile) (*signing.Verification, error) {
+	if len(upload.Signature) == 0 {
+		if s.signing.Required() {
+			log.Printf("Rejected unsigned upload %s", upload.Filename)
+			return nil, errUnverifiedSignature
+		}
+		return nil, nil
+	}
+
+	reader, err := s.blobs.Open(ctx, upload.Key)
+	if err != nil {
+		return nil, err
+	}
+	document, err := io.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	_, span := tracing.Start(ctx, "verifySignature")
+	verification := s.signing.Verify(document, upload.Signature)
+	span.SetAttributes(attribute.String("signature.method", verification.Method),
+		attribute.String("signature.status", verification.Status))
+	span.End()
+	log.Printf("Signature of %s: %s (%s) %s", upload.Filename, verification.Status, verification.Method, verification.Error)
+	if s.signing.Required() && !verification.Verified() {
+		return nil, errUnverifiedSignature
+	}
+	return verification, nil
+}
@@ -0,0 +1,183 @@
+// app/server/server/simulate.go
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// simulateRequest lists the findings to treat as resolved, by ID or title
+type simulateRequest struct {
+	Resolved []string `json:"resolved"`
+}
+
+// scoreSet is the overall score with the category scores keyed by field
+type scoreSet struct {
+	Overall    float64        `json:"overall"`
+	Categories map[string]int `json:"categories"`
+}
+
+// findingGain is a resolved finding with the overall score it gains alone
+type findingGain struct {
+	ID       string          `json:"id,omitempty"`
+	Title    string          `json:"title"`
+	Category string          `json:"category"`
+	Status   types.ResultKey `json:"status"`
+	Gain     float64         `json:"gain"`
+}
+
+// simulateResponse compares the scores of a report with the scores it would
+// have with the findings resolved
+type simulateResponse struct {
+	Report  string   `json:"report"`
+	Profile string   `json:"profile"`
+	Before  scoreSet `json:"before"`
+	After   scoreSet `json:"after"`
+	Delta   scoreSet `json:"delta"`
+
+	// Resolved lists the matched findings, largest gain first
+	Resolved []findingGain `json:"resolved"`
+
+	// Unknown lists the requested findings that aren't open in the report
+	Unknown []string `json:"unknown,omitempty"`
+}
+
+// HandleSimulateReport recalculates the scores of a report as if the given
+// findings were resolved; nothing is stored. Waivers and imported findings
+// are applied as in the report view, and scores measured by an import, such
+// as the Compliance Operator score, are kept as they are.
+func (s *Server) HandleSimulateReport(w http.ResponseWriter, r *http.Request) {
+	report, ok := s.lookupReport(w, r)
+	if !ok {
+		return
+	}
+
+	var req simulateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(req.Resolved) == 0 {
+		writeError(w, http.StatusBadRequest, "List the findings to resolve")
+		return
+	}
+
+	summary := s.withWaivers(report, withImported(report, report.Summary))
+	if len(summary.Findings) == 0 {
+		writeError(w, http.StatusConflict, "Report has no findings to simulate; re-parse it first")
+		return
+	}
+	profile := s.summaryProfile(report, summary)
+
+	// Score the unchanged findings the same way, so the delta only reflects the resolved ones
+	score := func(resolved map[int]bool) scoreSet {
+		simulated := *summary
+		simulated.Findings = make([]types.Finding, len(summary.Findings))
+		copy(simulated.Findings, summary.Findings)
+		for i := range resolved {
+			simulated.Findings[i].Status = types.ResultKeyNoChange
+		}
+		scoring.Recompute(&simulated, profile)
+		applyImportedScores(report, &simulated)
+		return scoresOf(&simulated)
+	}
+
+	matched := make(map[int]bool)
+	var unknown []string
+	for _, key := range req.Resolved {
+		indexes := matchFindings(summary.Findings, key)
+		if len(indexes) == 0 {
+			unknown = append(unknown, key)
+		}
+		for _, i := range indexes {
+			matched[i] = true
+		}
+	}
+	if len(matched) == 0 {
+		writeError(w, http.StatusBadRequest, "None of the findings are in the report")
+		return
+	}
+
+	before := score(nil)
+	after := score(matched)
+
+	gains := make([]findingGain, 0, len(matched))
+	for i := range matched {
+		finding := summary.Findings[i]
+		alone := score(map[int]bool{i: true})
+		gains = append(gains, findingGain{
+			ID:       finding.ID,
+			Title:    strings.TrimSpace(finding.Title),
+			Category: finding.Category,
+			Status:   finding.Status,
+			Gain:     roundScore(alone.Overall - before.Overall),
+		})
+	}
+	sort.SliceStable(gains, func(i, j int) bool {
+		if gains[i].Gain != gains[j].Gain {
+			return gains[i].Gain > gains[j].Gain
+		}
+		return gains[i].Title < gains[j].Title
+	})
+
+	delta := scoreSet{
+		Overall:    roundScore(after.Overall - before.Overall),
+		Categories: make(map[string]int, len(groupCategories)),
+	}
+	for _, category := range groupCategories {
+		delta.Categories[category.Key] = after.Categories[category.Key] - before.Categories[category.Key]
+	}
+
+	writeJSON(w, http.StatusOK, simulateResponse{
+		Report:   report.ID,
+		Profile:  profile.Name,
+		Before:   before,
+		After:    after,
+		Delta:    delta,
+		Resolved: gains,
+		Unknown:  unknown,
+	})
+}
+
+// matchFindings returns the indexes of the open findings with the given ID or title
+func matchFindings(findings []types.Finding, key string) []int {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil
+	}
+
+	var indexes []int
+	for i, finding := range findings {
+		if finding.ID != key && !strings.EqualFold(strings.TrimSpace(finding.Title), key) {
+			continue
+		}
+		switch finding.Status {
+		case types.ResultKeyRequired, types.ResultKeyRecommended, types.ResultKeyAdvisory:
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+// scoresOf returns the scores of a summary
+func scoresOf(summary *types.ReportSummary) scoreSet {
+	scores := scoreSet{
+		Overall:    roundScore(summary.OverallScore),
+		Categories: make(map[string]int, len(groupCategories)),
+	}
+	for _, category := range groupCategories {
+		scores.Categories[category.Key] = category.score(summary)
+	}
+	return scores
+}
+
+// roundScore rounds a score to two decimals for display
+func roundScore(score float64) float64 {
+	return math.Round(score*100) / 100
+}
@@ -0,0 +1,165 @@
+// app/server/server/static.go
+package server
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/web"
+)
+
+// Static asset sources
+const (
+	StaticAssetsAuto     = "auto"
+	StaticAssetsEmbedded = "embedded"
+	StaticAssetsDisk     = "disk"
+)
+
+// staticAssets serves the generated dashboard assets compiled into the binary
+type staticAssets struct {
+	fsys     fs.FS
+	manifest *web.Manifest
+	debug    bool
+}
+
+// newStaticAssets returns the embedded assets, or nil when the binary was built
+// without them and files are served from STATIC_DIR instead
+func newStaticAssets(debug bool) *staticAssets {
+	fsys, ok := web.Embedded()
+	if !ok {
+		return nil
+	}
+
+	manifest, err := web.LoadManifest(fsys)
+	if err != nil {
+		log.Printf("Ignoring embedded assets: %v", err)
+		return nil
+	}
+
+	log.Printf("Serving %d embedded static assets", len(manifest.Assets))
+	return &staticAssets{fsys: fsys, manifest: manifest, debug: debug}
+}
+
+// checkStaticAssets verifies that the configured source of the dashboard UI is
+// usable. In auto mode a binary without embedded assets and without STATIC_DIR
+// still starts, serving only the API, so minimal deployments don't fail.
+func (s *Server) checkStaticAssets() error {
+	switch s.config.StaticAssets {
+	case "", StaticAssetsAuto, StaticAssetsDisk:
+	case StaticAssetsEmbedded:
+		if s.assets == nil {
+			return fmt.Errorf("STATIC_ASSETS=%s but this binary was built without embedded assets, rebuild with -tags embedassets", StaticAssetsEmbedded)
+		}
+	default:
+		return fmt.Errorf("invalid STATIC_ASSETS %q, expected %s, %s or %s",
+			s.config.StaticAssets, StaticAssetsAuto, StaticAssetsEmbedded, StaticAssetsDisk)
+	}
+
+	if s.assets != nil {
+		return nil
+	}
+
+	indexPath := filepath.Join(s.config.StaticDir, "index.html")
+	if _, err := os.Stat(indexPath); err != nil {
+		if s.config.StaticAssets == StaticAssetsDisk {
+			return fmt.Errorf("index.html not found in static directory: %s", indexPath)
+		}
+		log.Printf("No embedded assets and no index.html in %s, serving the API only", s.config.StaticDir)
+		return nil
+	}
+
+	log.Printf("Serving static assets from %s", s.config.StaticDir)
+	return nil
+}
+
+// ServeHTTP serves an asset, preferring its pre-compressed variant, and falls
+// back to index.html for SPA routes. Fingerprinted assets are cached forever,
+// everything else is revalidated against its content hash.
+func (a *staticAssets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if a.debug {
+		log.Printf("%s - %s %s", r.RemoteAddr, r.Method, r.URL.Path)
+	}
+
+	// For API requests, let them be handled by specific handlers
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		return
+	}
+
+	urlPath := r.URL.Path
+	if urlPath == "/" {
+		urlPath = "/index.html"
+	}
+
+	asset, ok := a.manifest.Lookup(urlPath)
+	if !ok {
+		// Missing files with an extension are real 404s; anything else is an SPA route
+		if path.Ext(urlPath) != "" {
+			http.NotFound(w, r)
+			return
+		}
+		asset, _ = a.manifest.Lookup("/index.html")
+	}
+
+	a.serveAsset(w, r, asset)
+}
+
+// serveAsset writes a single asset with the content type recorded in the manifest
+func (a *staticAssets) serveAsset(w http.ResponseWriter, r *http.Request, asset web.Asset) {
+	name := asset.Path
+	etag := `"` + asset.SHA256[:32] + `"`
+	w.Header().Set("Content-Type", asset.ContentType)
+
+	switch {
+	case name == "index.html":
+		w.Header().Set("Cache-Control", cacheIndex)
+	case asset.Immutable:
+		w.Header().Set("Cache-Control", cacheImmutable)
+	default:
+		w.Header().Set("Cache-Control", cacheRevalidate)
+	}
+
+	if asset.GzipSize > 0 {
+		addVary(w.Header(), "Accept-Encoding")
+		if acceptsGzip(r) {
+			name += ".gz"
+			etag = "W/" + etag
+			w.Header().Set("Content-Encoding", "gzip")
+		}
+	}
+	w.Header().Set("ETag", etag)
+
+	file, err := a.fsys.Open(name)
+	if err != nil {
+		log.Printf("Error opening embedded asset %s: %v", name, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer file.Close()
+
+	content, ok := file.(io.ReadSeeker)
+	if !ok {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+	http.ServeContent(w, r, "", time.Time{}, content)
+}
+
+// acceptsGzip reports whether the client accepts gzip-encoded responses
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.TrimSpace(coding) != "gzip" {
+			continue
+		}
+		return strings.ReplaceAll(strings.TrimSpace(params), " ", "") != "q=0"
+	}
+	return false
+}
@@ -0,0 +1,174 @@
+// app/server/server/status.go
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/branding"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// statusPageMaxAge is how long browsers and proxies may cache a status page
+const statusPageMaxAge = 5 * time.Minute
+
+// statusPageTemplate renders the public status page; it only shows scores, never findings
+var statusPageTemplate = template.Must(template.New("status").Parse(`<!doctype html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width,initial-scale=1">
+<title>{{.Cluster}} – {{with .Branding.Name}}{{.}} {{end}}OpenShift Health Status</title>
+<style>
+body{font-family:system-ui,-apple-system,"Segoe UI",sans-serif;margin:0;background:{{.Colors.Background}};color:#1f2933}
+main{max-width:640px;margin:48px auto;padding:32px;background:#fff;border-radius:8px;box-shadow:0 1px 3px rgba(0,0,0,.12);border-top:4px solid {{.Colors.Accent}}}
+h1{margin:0 0 4px;font-size:1.5rem;color:{{.Colors.Primary}}}
+.brand{display:flex;align-items:center;gap:12px;margin-bottom:24px;color:{{.Colors.Primary}};font-weight:600}
+.brand img{max-height:40px;max-width:200px}
+.classification{padding:4px;text-align:center;font-weight:700;letter-spacing:.05em;color:#fff;background:{{.Branding.ClassificationColor}}}
+footer{max-width:640px;margin:0 auto 48px;text-align:center;font-size:.85rem;color:#616e7c}
+.meta{color:#616e7c;margin:0 0 24px}
+.grade{display:inline-block;width:72px;height:72px;line-height:72px;text-align:center;font-size:2.5rem;font-weight:700;border-radius:8px;color:#fff;background:{{.GradeColor}}}
+.score{display:inline-block;margin-left:16px;font-size:1.25rem;vertical-align:middle}
+.status{display:inline-block;margin-left:12px;padding:2px 10px;border-radius:12px;font-size:.85rem;font-weight:600;color:#fff;vertical-align:middle;background:{{.StatusColor}}}
+.category{margin-top:20px}
+.label{display:flex;justify-content:space-between;font-size:.9rem;margin-bottom:4px}
+.bar{height:10px;background:#e4e7eb;border-radius:5px;overflow:hidden}
+.fill{height:100%}
+</style>
+</head>
+<body>
+{{with .Branding.ClassificationText}}<div class="classification">{{.}}</div>
+{{end}}<main>
+{{if or .Logo .Branding.Name}}<div class="brand">{{with .Logo}}<img src="{{.}}" alt="">{{end}}{{with .Branding.Name}}<span>{{.}}</span>{{end}}</div>
+{{end}}<h1>{{.Cluster}}</h1>
+<p class="meta">Last assessed {{.AssessedAt.Format "January 2, 2006"}}</p>
+<div><span class="grade">{{.Grade}}</span><span class="score">Overall score {{printf "%.0f" .OverallScore}}%</span><span class="status">{{.Status}}</span></div>
+{{range .Categories}}<div class="category">
+<div class="label"><span>{{.Name}}</span><span>{{.Score}}%</span></div>
+<div class="bar"><div class="fill" style="width:{{.Score}}%;background:{{.Color}}"></div></div>
+</div>
+{{end}}</main>
+{{with .Branding.Footer}}<footer>{{.}}</footer>
+{{end}}{{with .Branding.ClassificationText}}<div class="classification">{{.}}</div>
+{{end}}</body>
+</html>
+`))
+
+// statusCategory is a single bar on the status page
+type statusCategory struct {
+	Name  string
+	Score int
+	Color string
+}
+
+// statusPage holds the data shown on a public status page
+type statusPage struct {
+	Cluster      string
+	AssessedAt   time.Time
+	OverallScore float64
+	Grade        string
+	GradeColor   string
+	Status       string
+	StatusColor  string
+	Categories   []statusCategory
+
+	Branding branding.Branding
+	Colors   branding.Palette
+
+	// Logo is the logo as a data URI, so the public page needs no other route
+	Logo template.URL
+}
+
+// HandleStatusPage renders the public status page of a cluster from its latest report
+func (s *Server) HandleStatusPage(w http.ResponseWriter, r *http.Request) {
+	cluster := r.PathValue("cluster")
+	if registered := s.clusters.Resolve(cluster); registered != "" {
+		cluster = registered
+	}
+
+	report := s.latestReport(cluster)
+	if report == nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	page, err := s.renderStatusPage(cluster, report)
+	if err != nil {
+		log.Printf("Error rendering status page for %s: %v", cluster, err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Waivers, grade bands and branding change the page as much as a new
+	// report does, so the validator is the hash of the page itself
+	sum := sha256.Sum256(page)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(statusPageMaxAge.Seconds())))
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(page))
+}
+
+// renderStatusPage renders the status page of a cluster from a report, with
+// the waivers and imported results the dashboard and the badges show
+func (s *Server) renderStatusPage(cluster string, report *store.Report) ([]byte, error) {
+	summary := s.presentSummary(report, report.Summary)
+	grades := s.settings.Current().Grades
+	grade, status := grades.Letter(summary.OverallScore), grades.Status(summary.OverallScore)
+	page := statusPage{
+		Cluster:      cluster,
+		AssessedAt:   report.UploadedAt,
+		OverallScore: summary.OverallScore,
+		Grade:        grade.Grade,
+		GradeColor:   bandColor(grade),
+		Status:       status.Grade,
+		StatusColor:  bandColor(status),
+		Branding:     s.branding.Get(),
+		Logo:         template.URL(s.branding.LogoDataURI()),
+		Categories: []statusCategory{
+			{Name: "Infrastructure Setup", Score: summary.ScoreInfra},
+			{Name: "Policy Governance", Score: summary.ScoreGovernance},
+			{Name: "Compliance Benchmarking", Score: summary.ScoreCompliance},
+			{Name: "Central Monitoring", Score: summary.ScoreMonitoring},
+			{Name: "Build/Deploy Security", Score: summary.ScoreBuildSecurity},
+		},
+	}
+	page.Colors = page.Branding.Colors()
+	for i := range page.Categories {
+		page.Categories[i].Color = bandColor(grades.Letter(float64(page.Categories[i].Score)))
+	}
+
+	var buf bytes.Buffer
+	if err := statusPageTemplate.Execute(&buf, page); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// latestReport returns the newest report of a cluster, or nil if it has none
+func (s *Server) latestReport(cluster string) *store.Report {
+	// Reports are sorted newest first
+	for _, report := range s.activeReports() {
+		if strings.EqualFold(report.ClusterKey(), cluster) {
+			return report
+		}
+	}
+	return nil
+}
+
+// bandColor returns the color a grade band is drawn in, grey for bands
+// configured without one
+func bandColor(band scoring.Band) string {
+	if band.Color == "" {
+		return badgeUnknownColor
+	}
+	return band.Color
+}
@@ -0,0 +1,63 @@
+// app/server/server/storage.go
+package server
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/blob"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/sqlite"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// Store drivers
+const (
+	StoreDriverFile   = "file"
+	StoreDriverSQLite = "sqlite"
+)
+
+// databaseFile is the SQLite database in the data directory
+const databaseFile = "dashboard.db"
+
+// openStorage opens the report records and raw upload blobs of the configured driver
+func (s *Server) openStorage() (store.Records, blob.Backend, error) {
+	switch s.config.StoreDriver {
+	case "", StoreDriverFile:
+		records, err := store.NewFileRecords(s.config.DataDir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open report store: %w", err)
+		}
+		blobs, err := blob.NewFileBackend(s.config.BlobDir)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open blob storage: %w", err)
+		}
+		return records, blob.Traced(blobs), nil
+
+	case StoreDriverSQLite:
+		if err := os.MkdirAll(s.config.DataDir, 0o755); err != nil {
+			return nil, nil, fmt.Errorf("failed to create data directory: %w", err)
+		}
+
+		path := filepath.Join(s.config.DataDir, databaseFile)
+		db, err := sqlite.Open(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open report store: %w", err)
+		}
+		s.db = db
+
+		records, err := store.NewSQLRecords(db, path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open report store: %w", err)
+		}
+		blobs, err := blob.NewSQLBackend(db)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open blob storage: %w", err)
+		}
+		return records, blob.Traced(blobs), nil
+
+	default:
+		return nil, nil, fmt.Errorf("invalid STORE_DRIVER %q, expected %s or %s",
+			s.config.StoreDriver, StoreDriverFile, StoreDriverSQLite)
+	}
+}
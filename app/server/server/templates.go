@@ -0,0 +1,165 @@
+// app/server/server/templates.go
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scanner"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/templates"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// templatePackEntry is a template pack with whether it is the active one
+type templatePackEntry struct {
+	templates.Pack
+	Active bool `json:"active"`
+}
+
+// HandleListTemplatePacks returns the template packs ordered by name
+func (s *Server) HandleListTemplatePacks(w http.ResponseWriter, r *http.Request) {
+	active := s.activeTemplatePack()
+	entries := []templatePackEntry{}
+	for _, pack := range s.templates.List() {
+		entries = append(entries, templatePackEntry{Pack: pack, Active: pack.Name == active})
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// HandleGetTemplatePack returns a single template pack
+func (s *Server) HandleGetTemplatePack(w http.ResponseWriter, r *http.Request) {
+	pack, err := s.templates.Get(r.PathValue("name"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Template pack not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, templatePackEntry{Pack: pack, Active: pack.Name == s.activeTemplatePack()})
+}
+
+// HandlePutTemplatePack uploads a template pack, replacing the one with the
+// same name; changes to the active pack apply to the next report
+func (s *Server) HandlePutTemplatePack(w http.ResponseWriter, r *http.Request) {
+	var pack templates.Pack
+	if err := json.NewDecoder(r.Body).Decode(&pack); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	pack.Name = r.PathValue("name")
+
+	stored, replaced, err := s.templates.Put(r.Context(), pack)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	status := http.StatusCreated
+	if replaced {
+		status = http.StatusOK
+	}
+	log.Printf("Stored template pack %s: %d parser profiles", stored.Name, len(stored.ParserProfiles))
+	writeJSON(w, status, templatePackEntry{Pack: stored, Active: stored.Name == s.activeTemplatePack()})
+}
+
+// HandleDeleteTemplatePack removes a template pack that isn't active
+func (s *Server) HandleDeleteTemplatePack(w http.ResponseWriter, r *http.Request) {
+	err := s.templates.Delete(r.Context(), r.PathValue("name"))
+	switch {
+	case errors.Is(err, templates.ErrNotFound):
+		writeError(w, http.StatusNotFound, "Template pack not found")
+	case errors.Is(err, templates.ErrActive):
+		writeError(w, http.StatusConflict, "Template pack is active; deactivate it first")
+	case err != nil:
+		log.Printf("Error deleting template pack: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to delete template pack")
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// HandleActivateTemplatePack makes a template pack the active one
+func (s *Server) HandleActivateTemplatePack(w http.ResponseWriter, r *http.Request) {
+	s.setActiveTemplatePack(w, r, r.PathValue("name"))
+}
+
+// HandleDeactivateTemplatePack goes back to the built-in templates
+func (s *Server) HandleDeactivateTemplatePack(w http.ResponseWriter, r *http.Request) {
+	s.setActiveTemplatePack(w, r, "")
+}
+
+// setActiveTemplatePack switches the active pack and returns the packs
+func (s *Server) setActiveTemplatePack(w http.ResponseWriter, r *http.Request, name string) {
+	err := s.templates.Activate(r.Context(), name)
+	if errors.Is(err, templates.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Template pack not found")
+		return
+	}
+	if err != nil {
+		log.Printf("Error activating template pack: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to activate template pack")
+		return
+	}
+
+	if name == "" {
+		log.Printf("Deactivated the template pack, using the built-in templates")
+	} else {
+		log.Printf("Activated template pack %s", name)
+	}
+	s.HandleListTemplatePacks(w, r)
+}
+
+// activeTemplatePack returns the name of the active pack, empty when there is none
+func (s *Server) activeTemplatePack() string {
+	if pack := s.templates.Active(); pack != nil {
+		return pack.Name
+	}
+	return ""
+}
+
+// parseOptions returns the parser options of the settings with the parser
+// profiles of the active template pack; profiles of the settings file win
+// when both define the same name
+func (s *Server) parseOptions() utils.ParseOptions {
+	options := s.settings.Current().ParseOptions()
+	if s.config.IncludeDir != "" {
+		options.Includes = utils.DirIncludes(s.config.IncludeDir)
+	}
+	pack := s.templates.Active()
+	if pack == nil {
+		return options
+	}
+
+	profiles := append([]utils.ParserProfile(nil), options.Profiles...)
+	for _, profile := range pack.ParserProfiles {
+		if !options.HasParserProfile(profile.Name) {
+			profiles = append(profiles, profile)
+		}
+	}
+	options.Profiles = profiles
+	return options
+}
+
+// renderScan renders a scan with the document template of the active pack,
+// or as the built-in AsciiDoc document
+func (s *Server) renderScan(scan *scanner.Scan) ([]byte, error) {
+	pack := s.templates.Active()
+	if pack == nil || pack.Document == "" {
+		return scanner.RenderAsciiDoc(scan), nil
+	}
+
+	document, err := scanner.ParseTemplate(pack.Document)
+	if err != nil {
+		return nil, err
+	}
+	return scanner.RenderTemplate(document, scan)
+}
+
+// pageTemplate returns the Confluence body template of the active pack, empty
+// for the configured one
+func (s *Server) pageTemplate() string {
+	if pack := s.templates.Active(); pack != nil {
+		return pack.Confluence
+	}
+	return ""
+}
@@ -0,0 +1,252 @@
+// app/server/server/tenants.go
+package server
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/alerting"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/confluence"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/gitops"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/insights"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/jira"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/live"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/operator"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/tracing"
+)
+
+// Tenancy modes
+const (
+	// TenancyPath serves each tenant under its own prefix, e.g. /t/acme/api/reports
+	TenancyPath = "path"
+
+	// TenancyClaim picks the tenant from a claim of the OIDC access token the
+	// authenticating proxy in front of the dashboard forwards
+	TenancyClaim = "claim"
+)
+
+// DefaultTenantClaim is the token claim naming the tenant of a request
+const DefaultTenantClaim = "tenant"
+
+// tenantNamePattern restricts tenant names to values that are safe in URLs and directory names
+var tenantNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// TenancyConfig lets several customers or teams share a deployment. Every
+// tenant gets its own report store, blob storage, registries, settings file
+// and notification targets, so a request can only reach the data of its tenant.
+type TenancyConfig struct {
+	// Mode is TenancyPath or TenancyClaim; empty disables tenancy
+	Mode string
+
+	// Tenants lists the tenant names
+	Tenants []string
+
+	// Claim is the token claim naming the tenant, DefaultTenantClaim when
+	// empty; a list claim such as groups selects the first listed tenant
+	Claim string
+
+	// SettingsDir holds the settings file of each tenant as <tenant>.yaml;
+	// when empty it is settings.yaml in the data directory of the tenant
+	SettingsDir string
+}
+
+// Enabled reports whether the deployment is shared by tenants
+func (c TenancyConfig) Enabled() bool {
+	return c.Mode != ""
+}
+
+// Validate checks the mode and the tenant names
+func (c TenancyConfig) Validate() error {
+	if c.Mode != TenancyPath && c.Mode != TenancyClaim {
+		return fmt.Errorf("invalid tenancy mode %q, expected %s or %s", c.Mode, TenancyPath, TenancyClaim)
+	}
+	if len(c.Tenants) == 0 {
+		return errors.New("tenancy is enabled without tenants")
+	}
+	seen := make(map[string]bool)
+	for _, name := range c.Tenants {
+		if !tenantNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid tenant name %q: use lowercase letters, digits and dashes", name)
+		}
+		if seen[name] {
+			return fmt.Errorf("tenant %q is listed twice", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// tenantConfig derives the configuration of a tenant's server. The storage
+// is kept under tenants/<name> of the data and blob directories. Notification
+// targets and integrations belong to the deployment, so tenants only notify
+// the targets of their own settings file and have no Jira, Insights, GitOps
+// or Confluence integration.
+func (s *Server) tenantConfig(name string) Config {
+	config := s.config
+	config.DataDir = filepath.Join(s.config.DataDir, "tenants", name)
+	config.BlobDir = filepath.Join(s.config.BlobDir, "tenants", name)
+	config.SettingsFile = filepath.Join(config.DataDir, "settings.yaml")
+	if s.config.ArchiveDir != "" {
+		config.ArchiveDir = filepath.Join(s.config.ArchiveDir, "tenants", name)
+	}
+	if s.config.Tenancy.SettingsDir != "" {
+		config.SettingsFile = filepath.Join(s.config.Tenancy.SettingsDir, name+".yaml")
+	}
+	if s.config.PublicURL != "" && s.config.Tenancy.Mode == TenancyPath {
+		config.PublicURL = strings.TrimRight(s.config.PublicURL, "/") + tenantPrefix(name)
+	}
+
+	config.Tenancy = TenancyConfig{}
+	config.SchedulesFile = ""
+	config.DemoMode = false
+	config.TLS = TLSConfig{}
+	config.Live = live.Config{}
+	config.Operator = operator.Config{}
+	config.Notify = notify.Config{}
+	config.Alerting = alerting.Config{}
+	config.Jira = jira.Config{}
+	config.Insights = insights.Config{}
+	config.GitOps = gitops.Config{}
+	config.Confluence = confluence.Config{}
+
+	// The deployment sets up tracing once for every tenant
+	config.Tracing = tracing.Config{}
+	return config
+}
+
+// startTenants initializes the server of every tenant
+func (s *Server) startTenants() error {
+	s.tenants = make(map[string]*Server, len(s.config.Tenancy.Tenants))
+	for _, name := range s.config.Tenancy.Tenants {
+		tenant := NewServer(s.tenantConfig(name))
+		if err := tenant.Initialize(); err != nil {
+			return fmt.Errorf("failed to initialize tenant %s: %w", name, err)
+		}
+		s.tenants[name] = tenant
+		log.Printf("Serving tenant %s from %s", name, tenant.config.DataDir)
+	}
+	return nil
+}
+
+// shutdownTenants stops the background work of every tenant
+func (s *Server) shutdownTenants(ctx context.Context) {
+	for name, tenant := range s.tenants {
+		if err := tenant.Shutdown(ctx); err != nil {
+			log.Printf("Error shutting down tenant %s: %v", name, err)
+		}
+	}
+}
+
+// tenantPrefix is the path prefix of a tenant in path mode
+func tenantPrefix(name string) string {
+	return "/t/" + name
+}
+
+// setupTenants routes the API and the status pages to the server of the
+// request's tenant; nothing is served from the deployment's own store
+func (s *Server) setupTenants(mux *http.ServeMux) {
+	switch s.config.Tenancy.Mode {
+	case TenancyPath:
+		mux.HandleFunc("/t/{tenant}/", s.servePathTenant)
+		mux.HandleFunc("/api/", func(w http.ResponseWriter, r *http.Request) {
+			writeError(w, http.StatusNotFound, "A tenant is required; use /t/{tenant}/api/")
+		})
+
+	case TenancyClaim:
+		mux.HandleFunc("/api/", s.serveClaimTenant)
+		mux.HandleFunc("/status/", s.serveClaimTenant)
+	}
+}
+
+// servePathTenant serves /t/{tenant}/api/ and /t/{tenant}/status/ from the
+// server of the tenant
+func (s *Server) servePathTenant(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("tenant")
+	tenant, ok := s.tenants[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Tenant not found")
+		return
+	}
+
+	prefix := tenantPrefix(name)
+	path := strings.TrimPrefix(r.URL.Path, prefix)
+	if !strings.HasPrefix(path, "/api/") && !strings.HasPrefix(path, "/status/") {
+		http.NotFound(w, r)
+		return
+	}
+	r = r.WithContext(context.WithValue(r.Context(), pathPrefixKey{}, prefix))
+	http.StripPrefix(prefix, s.limiter.limit(tenant.mux)).ServeHTTP(w, r)
+}
+
+// pathPrefixKey is the context key of the tenant prefix stripped from the
+// path of a request
+type pathPrefixKey struct{}
+
+// pathPrefix returns the tenant prefix stripped from the path of a request,
+// which the URLs returned to the client must keep; "" outside path mode
+func pathPrefix(r *http.Request) string {
+	prefix, _ := r.Context().Value(pathPrefixKey{}).(string)
+	return prefix
+}
+
+// serveClaimTenant serves a request from the server of the tenant named in
+// its access token
+func (s *Server) serveClaimTenant(w http.ResponseWriter, r *http.Request) {
+	tenant, ok := s.tenants[s.requestTenant(r)]
+	if !ok {
+		writeError(w, http.StatusForbidden, "The access token names no tenant of this dashboard")
+		return
+	}
+	tenant.mux.ServeHTTP(w, r)
+}
+
+// requestTenant returns the first tenant named in the tenant claim of the
+// request's access token, or "" when it names none. The token is verified by
+// the authenticating proxy, such as oauth2-proxy, which forwards it in the
+// X-Forwarded-Access-Token or Authorization header; the dashboard must only
+// be reachable through that proxy.
+func (s *Server) requestTenant(r *http.Request) string {
+	token := r.Header.Get("X-Forwarded-Access-Token")
+	if token == "" {
+		token, _ = strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	}
+	parts := strings.Split(strings.TrimSpace(token), ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims map[string]interface{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return ""
+	}
+
+	claim := s.config.Tenancy.Claim
+	if claim == "" {
+		claim = DefaultTenantClaim
+	}
+	var names []interface{}
+	switch value := claims[claim].(type) {
+	case string:
+		names = []interface{}{value}
+	case []interface{}:
+		names = value
+	}
+	for _, name := range names {
+		if name, ok := name.(string); ok && s.tenants[name] != nil {
+			return name
+		}
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+// app/server/server/thresholds.go
+package server
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// notifyThresholds posts a threshold event for every category of a newly
+// stored report whose score fell below its threshold; a category that was
+// already below it in the previous report doesn't fire again
+func (s *Server) notifyThresholds(report *store.Report) {
+	config := s.notifier.Thresholds()
+	if len(config.CategoryThresholds) == 0 || len(config.WebhookURLs) == 0 {
+		return
+	}
+
+	summary := s.presentSummary(report, report.Summary)
+	previous := s.previousReport(report)
+	var previousSummary *types.ReportSummary
+	if previous != nil {
+		previousSummary = s.presentSummary(previous, previous.Summary)
+	}
+
+	var events []notify.ThresholdEvent
+	for _, category := range summary.Categories {
+		threshold, ok := config.Threshold(category.Name, category.Label)
+		if !ok || float64(category.Score) >= threshold {
+			continue
+		}
+
+		event := notify.ThresholdEvent{
+			Type:          notify.EventCategoryBelowThreshold,
+			Time:          time.Now().UTC(),
+			ReportID:      report.ID,
+			ReportURL:     s.reportURL(report.ID),
+			ClusterName:   strings.TrimSpace(report.ClusterKey()),
+			CustomerName:  summary.CustomerName,
+			Category:      category.Name,
+			CategoryLabel: category.Label,
+			Threshold:     threshold,
+			Score:         category.Score,
+			Findings:      contributingFindings(summary, previousSummary, category.Name),
+		}
+		if previousSummary != nil {
+			if score, ok := categoryScore(previousSummary, category.Name); ok {
+				if float64(score) < threshold {
+					continue
+				}
+				event.PreviousScore = &score
+			}
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(s.ctx, time.Minute)
+		defer cancel()
+		s.notifier.SendThreshold(ctx, events)
+	}()
+}
+
+// contributingFindings returns the required and recommended findings of a
+// category, marking those that weren't actionable in the previous summary
+func contributingFindings(summary, previous *types.ReportSummary, category string) []notify.ContributingFinding {
+	actionable := func(finding types.Finding) bool {
+		return strings.EqualFold(finding.Category, category) &&
+			(finding.Status == types.ResultKeyRequired || finding.Status == types.ResultKeyRecommended)
+	}
+
+	before := make(map[string]bool)
+	if previous != nil {
+		for _, finding := range previous.Findings {
+			if actionable(finding) {
+				before[findingIdentity(finding)] = true
+			}
+		}
+	}
+
+	findings := []notify.ContributingFinding{}
+	for _, finding := range summary.Findings {
+		if !actionable(finding) {
+			continue
+		}
+		findings = append(findings, notify.ContributingFinding{
+			ID:       finding.ID,
+			Title:    finding.Title,
+			Status:   string(finding.Status),
+			Severity: string(finding.Severity),
+			New:      previous != nil && !before[findingIdentity(finding)],
+		})
+	}
+	return findings
+}
+
+// categoryScore returns the score of a category in a summary
+func categoryScore(summary *types.ReportSummary, name string) (int, bool) {
+	for _, category := range summary.Categories {
+		if strings.EqualFold(category.Name, name) {
+			return category.Score, true
+		}
+	}
+	return 0, false
+}
+
+// findingIdentity identifies a finding across reports of a cluster
+func findingIdentity(finding types.Finding) string {
+	if finding.ID != "" {
+		return strings.ToLower(finding.ID)
+	}
+	return strings.ToLower(strings.TrimSpace(finding.Title))
+}
@@ -0,0 +1,88 @@
+// app/server/server/timeline.go
+package server
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+)
+
+// timelinePoint is a single report on a cluster timeline
+type timelinePoint struct {
+	ReportID     string    `json:"reportId"`
+	Time         time.Time `json:"time"`
+	OverallScore float64   `json:"overallScore"`
+	ScoreDelta   float64   `json:"scoreDelta"`
+
+	// EventsSincePrevious holds the events captured between the previous report and this one
+	EventsSincePrevious []store.ClusterEvent `json:"eventsSincePrevious"`
+}
+
+// timelineResponse is returned by the cluster timeline endpoint
+type timelineResponse struct {
+	Cluster string          `json:"cluster"`
+	Points  []timelinePoint `json:"points"`
+
+	// PendingEvents holds the events captured after the latest report
+	PendingEvents []store.ClusterEvent `json:"pendingEvents"`
+}
+
+// HandleClusterTimeline returns the report history of a cluster correlated with captured events
+func (s *Server) HandleClusterTimeline(w http.ResponseWriter, r *http.Request) {
+	cluster := r.PathValue("name")
+	if registered := s.clusters.Resolve(cluster); registered != "" {
+		cluster = registered
+	}
+
+	// Collect the cluster's reports, oldest first
+	var reports []*store.Report
+	for _, report := range s.activeReports() {
+		if strings.EqualFold(report.ClusterKey(), cluster) {
+			reports = append(reports, report)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].UploadedAt.Before(reports[j].UploadedAt)
+	})
+
+	events, err := s.store.ListEvents(cluster)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to load cluster events")
+		return
+	}
+
+	response := timelineResponse{
+		Cluster:       cluster,
+		Points:        []timelinePoint{},
+		PendingEvents: []store.ClusterEvent{},
+	}
+
+	// Walk reports and events together, attaching each event to the next report
+	next := 0
+	for i, report := range reports {
+		point := timelinePoint{
+			ReportID:            report.ID,
+			Time:                report.UploadedAt,
+			OverallScore:        report.Summary.OverallScore,
+			EventsSincePrevious: []store.ClusterEvent{},
+		}
+		if i > 0 {
+			point.ScoreDelta = report.Summary.OverallScore - reports[i-1].Summary.OverallScore
+		}
+
+		for next < len(events) && !events[next].Time.After(report.UploadedAt) {
+			if i > 0 {
+				point.EventsSincePrevious = append(point.EventsSincePrevious, events[next])
+			}
+			next++
+		}
+
+		response.Points = append(response.Points, point)
+	}
+	response.PendingEvents = append(response.PendingEvents, events[next:]...)
+
+	writeJSON(w, http.StatusOK, response)
+}
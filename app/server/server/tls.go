@@ -0,0 +1,156 @@
+// app/server/server/tls.go
+package server
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+// certCheckInterval is how often the certificate files are checked for rotation
+const certCheckInterval = 30 * time.Second
+
+// TLSConfig holds the native TLS settings
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+
+	// AutoReload picks up rotated certificate files without a restart
+	AutoReload bool
+
+	// RedirectPort, when set, serves plain HTTP on this port and redirects it to HTTPS
+	RedirectPort string
+
+	// CleartextHTTP2 accepts HTTP/2 without TLS (h2c), for proxies that speak it
+	CleartextHTTP2 bool
+}
+
+// Enabled reports whether the server terminates TLS itself
+func (c TLSConfig) Enabled() bool {
+	return c.CertFile != "" && c.KeyFile != ""
+}
+
+// certReloader serves the current certificate and reloads it when the files change,
+// which covers cert-manager and service CA rotation of a mounted secret
+type certReloader struct {
+	certFile   string
+	keyFile    string
+	autoReload bool
+
+	mu      sync.RWMutex
+	cert    *tls.Certificate
+	modTime time.Time
+	checked time.Time
+}
+
+// newCertReloader loads the certificate pair, failing if it is unusable
+func newCertReloader(config TLSConfig) (*certReloader, error) {
+	r := &certReloader{
+		certFile:   config.CertFile,
+		keyFile:    config.KeyFile,
+		autoReload: config.AutoReload,
+	}
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate returns the certificate for a handshake, reloading it first if it was rotated
+func (r *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	if r.autoReload {
+		r.maybeReload()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// maybeReload reloads the certificate when the files changed since the last
+// check; a broken rotation keeps the previous certificate in use
+func (r *certReloader) maybeReload() {
+	r.mu.RLock()
+	due := time.Since(r.checked) >= certCheckInterval
+	r.mu.RUnlock()
+	if !due {
+		return
+	}
+
+	modTime, err := r.latestModTime()
+
+	r.mu.Lock()
+	r.checked = time.Now()
+	changed := err == nil && modTime.After(r.modTime)
+	r.mu.Unlock()
+
+	if err != nil {
+		log.Printf("Error checking TLS certificate files: %v", err)
+		return
+	}
+	if !changed {
+		return
+	}
+
+	if err := r.load(); err != nil {
+		log.Printf("Keeping the current TLS certificate: %v", err)
+		return
+	}
+	log.Printf("Reloaded rotated TLS certificate from %s", r.certFile)
+}
+
+// load reads the certificate pair from disk
+func (r *certReloader) load() error {
+	modTime, err := r.latestModTime()
+	if err != nil {
+		return err
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("error loading TLS certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.modTime = modTime
+	r.checked = time.Now()
+	r.mu.Unlock()
+	return nil
+}
+
+// latestModTime returns the newer modification time of the certificate and key files
+func (r *certReloader) latestModTime() (time.Time, error) {
+	var latest time.Time
+	for _, file := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(file)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("error reading TLS file: %w", err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// httpsRedirect redirects plain HTTP requests to the same URL on the HTTPS port
+func httpsRedirect(httpsPort string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host := r.Host
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+		if httpsPort != "443" {
+			host = net.JoinHostPort(host, httpsPort)
+		}
+
+		// 308 keeps the method and body of redirected uploads
+		http.Redirect(w, r, "https://"+host+r.URL.RequestURI(), http.StatusPermanentRedirect)
+	})
+}
@@ -0,0 +1,256 @@
+// app/server/server/upload_sessions.go
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/bundle"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/signing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/uploads"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// uploadOffsetHeader carries the offset of a chunk and, in responses, the
+// number of bytes received, like in the tus protocol
+const uploadOffsetHeader = "Upload-Offset"
+
+// createUploadRequest opens a chunked upload
+type createUploadRequest struct {
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+
+	// SHA256 is the hex-encoded digest of the whole document, checked before parsing
+	SHA256 string `json:"sha256"`
+
+	Cluster string `json:"cluster,omitempty"`
+
+	// Signature is the base64-encoded detached signature of the document
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// uploadSessionResponse describes a chunked upload
+type uploadSessionResponse struct {
+	ID        string    `json:"id"`
+	Filename  string    `json:"filename"`
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+	Cluster   string    `json:"cluster,omitempty"`
+	Offset    int64     `json:"offset"`
+	Complete  bool      `json:"complete"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// newUploadSessionResponse describes a session
+func newUploadSessionResponse(session uploads.Session) uploadSessionResponse {
+	return uploadSessionResponse{
+		ID:        session.ID,
+		Filename:  session.Filename,
+		Size:      session.Size,
+		SHA256:    session.SHA256,
+		Cluster:   session.Cluster,
+		Offset:    session.Offset,
+		Complete:  session.Offset == session.Size,
+		ExpiresAt: session.ExpiresAt,
+	}
+}
+
+// writeUploadSession writes a session with its offset header
+func writeUploadSession(w http.ResponseWriter, status int, session uploads.Session) {
+	w.Header().Set(uploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+	writeJSON(w, status, newUploadSessionResponse(session))
+}
+
+// HandleCreateUpload opens a chunked upload for a document of a known size
+// and digest; the chunks are sent with PATCH and the upload is parsed once
+// completed
+func (s *Server) HandleCreateUpload(w http.ResponseWriter, r *http.Request) {
+	var request createUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if filename := filepath.Base(request.Filename); !utils.IsValidReportFile(filename) && !bundle.IsBundle(filename) {
+		writeError(w, http.StatusBadRequest, invalidFileTypeMessage)
+		return
+	}
+	if limit := s.config.RateLimit.MaxUploadSize; limit > 0 && request.Size > limit {
+		writeErrorf(w, http.StatusRequestEntityTooLarge, "Upload exceeds the maximum size of %d bytes", limit)
+		return
+	}
+	if len(request.Signature) > signing.MaxSignatureSize {
+		writeErrorf(w, http.StatusBadRequest, "Signatures are limited to %d bytes", signing.MaxSignatureSize)
+		return
+	}
+	// Check the cluster now rather than after the whole document arrived
+	if request.Cluster = strings.TrimSpace(request.Cluster); request.Cluster != "" && s.clusters.Resolve(request.Cluster) == "" {
+		writeError(w, http.StatusBadRequest, "Unknown cluster")
+		return
+	}
+
+	session, err := s.uploads.Create(uploads.Session{
+		Filename:  request.Filename,
+		Size:      request.Size,
+		SHA256:    request.SHA256,
+		Cluster:   request.Cluster,
+		Signature: request.Signature,
+	})
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Location", pathPrefix(r)+"/api/"+w.Header().Get("API-Version")+"/uploads/"+session.ID)
+	writeUploadSession(w, http.StatusCreated, session)
+}
+
+// HandleGetUpload returns the offset to resume a chunked upload from
+func (s *Server) HandleGetUpload(w http.ResponseWriter, r *http.Request) {
+	session, err := s.uploads.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Upload not found")
+		return
+	}
+	writeUploadSession(w, http.StatusOK, session)
+}
+
+// HandleUploadChunk appends the request body to a chunked upload at the
+// offset of the Upload-Offset header. A chunk that doesn't start at the
+// received offset is rejected with 409 and the offset to resume from.
+func (s *Server) HandleUploadChunk(w http.ResponseWriter, r *http.Request) {
+	offset, err := strconv.ParseInt(r.Header.Get(uploadOffsetHeader), 10, 64)
+	if err != nil || offset < 0 {
+		writeError(w, http.StatusBadRequest, "The Upload-Offset header must be the offset of the chunk")
+		return
+	}
+
+	session, err := s.uploads.Append(r.PathValue("id"), offset, r.Body)
+	switch {
+	case errors.Is(err, uploads.ErrNotFound):
+		writeError(w, http.StatusNotFound, "Upload not found")
+	case errors.Is(err, uploads.ErrOffsetMismatch):
+		w.Header().Set(uploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+		writeErrorf(w, http.StatusConflict, "The chunk must start at offset %d", session.Offset)
+	case errors.Is(err, uploads.ErrCompleting):
+		writeError(w, http.StatusConflict, "The upload is already being completed")
+	case errors.Is(err, uploads.ErrTooLarge):
+		w.Header().Set(uploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+		writeErrorf(w, http.StatusRequestEntityTooLarge, "Upload exceeds the maximum size of %d bytes", session.Size)
+	case err != nil:
+		// What arrived before the connection failed is kept; resume from the offset
+		log.Printf("Error receiving chunk of upload %s: %v", r.PathValue("id"), err)
+		w.Header().Set(uploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+		writeError(w, http.StatusInternalServerError, "Failed to store the chunk")
+	default:
+		writeUploadSession(w, http.StatusOK, session)
+	}
+}
+
+// HandleDeleteUpload abandons a chunked upload
+func (s *Server) HandleDeleteUpload(w http.ResponseWriter, r *http.Request) {
+	if err := s.uploads.Remove(r.PathValue("id")); err != nil {
+		if errors.Is(err, uploads.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "Upload not found")
+			return
+		}
+		log.Printf("Error removing upload %s: %v", r.PathValue("id"), err)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// HandleCompleteUpload checks a chunked upload against its digest and parses it
+func (s *Server) HandleCompleteUpload(w http.ResponseWriter, r *http.Request) {
+	s.completeUpload(w, r, func(report *store.Report) interface{} {
+		return s.presentSummary(report, report.Summary)
+	})
+}
+
+// HandleCompleteUploadV2 is HandleCompleteUpload returning the v2 summary
+func (s *Server) HandleCompleteUploadV2(w http.ResponseWriter, r *http.Request) {
+	s.completeUpload(w, r, func(report *store.Report) interface{} {
+		return s.presentSummary(report, report.Summary).V2()
+	})
+}
+
+// completeUpload moves a verified upload into the blob backend and parses it
+// like a direct upload with the same query parameters
+func (s *Server) completeUpload(w http.ResponseWriter, r *http.Request, render func(*store.Report) interface{}) {
+	parser, requestedProfile, ok := s.uploadOptions(w, r)
+	if !ok {
+		return
+	}
+
+	session, file, err := s.uploads.Open(r.PathValue("id"))
+	switch {
+	case errors.Is(err, uploads.ErrNotFound):
+		writeError(w, http.StatusNotFound, "Upload not found")
+		return
+	case errors.Is(err, uploads.ErrIncomplete):
+		w.Header().Set(uploadOffsetHeader, strconv.FormatInt(session.Offset, 10))
+		writeErrorf(w, http.StatusConflict, "The upload is incomplete: %d of %d bytes received", session.Offset, session.Size)
+		return
+	case errors.Is(err, uploads.ErrCompleting):
+		writeError(w, http.StatusConflict, "The upload is already being completed")
+		return
+	case errors.Is(err, uploads.ErrChecksum):
+		// Resuming cannot repair the data, so the session is of no further use
+		s.uploads.Remove(session.ID)
+		writeError(w, http.StatusUnprocessableEntity, "The upload does not match its SHA-256 digest; start a new upload")
+		return
+	case err != nil:
+		log.Printf("Error opening upload %s: %v", session.ID, err)
+		writeError(w, http.StatusInternalServerError, "Failed to process file")
+		return
+	}
+
+	id := store.NewID()
+	key := store.RawKey(id, strings.ToLower(filepath.Ext(session.Filename)))
+	size, err := s.blobs.Put(r.Context(), key, file)
+	file.Close()
+	if err != nil {
+		log.Printf("Error storing upload %s: %v", session.ID, err)
+		s.uploads.Release(session.ID)
+		writeError(w, http.StatusInternalServerError, "Failed to process file")
+		return
+	}
+	if err := s.uploads.Remove(session.ID); err != nil {
+		log.Printf("Error removing completed upload %s: %v", session.ID, err)
+	}
+
+	log.Printf("Assembled chunked upload %s: %s, size: %d bytes", session.ID, session.Filename, size)
+	upload := &uploadedFile{
+		Filename:  session.Filename,
+		Key:       key,
+		Size:      size,
+		Cluster:   session.Cluster,
+		Signature: session.Signature,
+	}
+	s.parseUpload(w, r, id, upload, parser, requestedProfile, render)
+}
+
+// startUploadExpiry removes abandoned chunked uploads until the server shuts down
+func (s *Server) startUploadExpiry() {
+	interval := s.uploads.TTL() / 4
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.ctx.Done():
+				return
+			case now := <-ticker.C:
+				if removed := s.uploads.Expire(now); removed > 0 {
+					log.Printf("Removed %d expired chunked uploads", removed)
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,122 @@
+// app/server/server/uploads.go
+package server
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/bundle"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/signing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+var (
+	// errMissingFile is returned when the form has no report part
+	errMissingFile = errors.New("no report file in form")
+
+	// errInvalidFileType is returned when the report part has an unsupported extension
+	errInvalidFileType = errors.New("invalid file type")
+
+	// errUploadStorage wraps failures writing the upload to the blob backend
+	errUploadStorage = errors.New("failed to store upload")
+
+	// errUnknownCluster is returned when the form names an unregistered cluster
+	errUnknownCluster = errors.New("unknown cluster")
+
+	// errStoreReport wraps failures storing the parsed report
+	errStoreReport = errors.New("failed to store report")
+
+	// errInvalidSignature is returned when the signature part cannot be read
+	errInvalidSignature = errors.New("invalid signature")
+
+	// errUnverifiedSignature is returned when signatures are required and the
+	// upload has none or it does not verify
+	errUnverifiedSignature = errors.New("report signature is missing or not verified")
+)
+
+// invalidFileTypeMessage is the error response for uploads of unsupported files
+const invalidFileTypeMessage = "Invalid file type. Only .adoc, .asciidoc, .md, .html or .zip files are allowed"
+
+// maxFieldSize bounds the plain form fields read alongside the report
+const maxFieldSize = 1024
+
+// uploadedFile describes a report file streamed into the blob backend
+type uploadedFile struct {
+	Filename string
+	Key      string
+	Size     int64
+
+	// Cluster is the optional "cluster" form field, which must precede the report part
+	Cluster string
+
+	// Signature is the optional detached signature of the document, from the
+	// "signature" part, which must precede the report part too
+	Signature []byte
+}
+
+// streamUpload reads the multipart body part by part and streams the "report"
+// part straight into the blob backend, so memory use is bounded by the copy
+// buffer rather than by the size of the upload
+func (s *Server) streamUpload(r *http.Request, id string) (*uploadedFile, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, err
+	}
+
+	var cluster string
+	var signature []byte
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, errMissingFile
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == "cluster" && part.FileName() == "" {
+			value, err := io.ReadAll(io.LimitReader(part, maxFieldSize))
+			part.Close()
+			if err != nil {
+				return nil, err
+			}
+			cluster = strings.TrimSpace(string(value))
+			continue
+		}
+
+		if part.FormName() == "signature" {
+			signature, err = signing.ReadSignature(part)
+			part.Close()
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", errInvalidSignature, err)
+			}
+			continue
+		}
+
+		// Skip any other form fields
+		if part.FormName() != "report" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		filename := filepath.Base(part.FileName())
+		if !utils.IsValidReportFile(filename) && !bundle.IsBundle(filename) {
+			part.Close()
+			return nil, errInvalidFileType
+		}
+
+		key := store.RawKey(id, strings.ToLower(filepath.Ext(filename)))
+		size, err := s.blobs.Put(r.Context(), key, part)
+		part.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errUploadStorage, err)
+		}
+
+		return &uploadedFile{Filename: filename, Key: key, Size: size, Cluster: cluster, Signature: signature}, nil
+	}
+}
@@ -0,0 +1,95 @@
+// app/server/server/validate.go
+package server
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"path/filepath"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// validateReportResponse is the result of checking a report document
+type validateReportResponse struct {
+	Filename string `json:"filename"`
+	Valid    bool   `json:"valid"`
+	*utils.LintResult
+}
+
+// HandleValidateReport checks an uploaded report document against the report
+// template and lists the problems found, without storing anything. A document
+// with errors still gets a 200 response; "valid" tells whether it parses as
+// intended.
+func (s *Server) HandleValidateReport(w http.ResponseWriter, r *http.Request) {
+	options := s.parseOptions()
+	parser := r.URL.Query().Get("parser")
+	if !options.HasParserProfile(parser) {
+		writeError(w, http.StatusBadRequest, "Unknown parser profile")
+		return
+	}
+	options.Profile = parser
+
+	if s.config.RateLimit.MaxUploadSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, s.config.RateLimit.MaxUploadSize)
+	}
+	filename, lines, err := readReportPart(r)
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		writeErrorf(w, http.StatusRequestEntityTooLarge, "Upload exceeds the maximum size of %d bytes", tooLarge.Limit)
+		return
+	case errors.Is(err, errMissingFile):
+		writeError(w, http.StatusBadRequest, "Failed to get file")
+		return
+	case errors.Is(err, errInvalidFileType):
+		writeError(w, http.StatusBadRequest, "Invalid file type. Only .adoc, .asciidoc, .md or .html files are allowed")
+		return
+	case err != nil:
+		writeError(w, http.StatusBadRequest, "Failed to parse form")
+		return
+	}
+
+	options.Format = utils.FormatForFilename(filename)
+	result, err := utils.LintReport(lines, options)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, validateReportResponse{Filename: filename, Valid: result.Valid(), LintResult: result})
+}
+
+// readReportPart reads the "report" part of a multipart upload into lines,
+// skipping the other form fields
+func readReportPart(r *http.Request) (string, []string, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return "", nil, err
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return "", nil, errMissingFile
+		}
+		if err != nil {
+			return "", nil, err
+		}
+
+		if part.FormName() != "report" || part.FileName() == "" {
+			part.Close()
+			continue
+		}
+
+		filename := filepath.Base(part.FileName())
+		if !utils.IsValidReportFile(filename) {
+			part.Close()
+			return "", nil, errInvalidFileType
+		}
+
+		lines, err := utils.ReadLines(part)
+		part.Close()
+		return filename, lines, err
+	}
+}
@@ -0,0 +1,174 @@
+// app/server/server/waivers.go
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/store"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/waivers"
+)
+
+// waiverEntry is a waiver with whether it is in effect
+type waiverEntry struct {
+	waivers.Waiver
+	Active bool `json:"active"`
+}
+
+// HandleListWaivers returns the waivers, newest first; ?cluster= limits them
+// to the waivers that apply to a cluster, and ?active=true to the ones in effect
+func (s *Server) HandleListWaivers(w http.ResponseWriter, r *http.Request) {
+	cluster := r.URL.Query().Get("cluster")
+	activeOnly := r.URL.Query().Get("active") == "true"
+	now := time.Now()
+
+	entries := []waiverEntry{}
+	for _, waiver := range s.waivers.List() {
+		if cluster != "" && waiver.Cluster != "" && !strings.EqualFold(waiver.Cluster, cluster) {
+			continue
+		}
+		active := waiver.Active(now)
+		if activeOnly && !active {
+			continue
+		}
+		entries = append(entries, waiverEntry{Waiver: waiver, Active: active})
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+// HandleCreateWaiver adds a waiver
+func (s *Server) HandleCreateWaiver(w http.ResponseWriter, r *http.Request) {
+	var waiver waivers.Waiver
+	if err := json.NewDecoder(r.Body).Decode(&waiver); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	created, err := s.waivers.Create(waiver)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	log.Printf("Created waiver %s: %s", created.ID, created.Justification)
+	writeJSON(w, http.StatusCreated, waiverEntry{Waiver: created, Active: created.Active(time.Now())})
+}
+
+// HandleGetWaiver returns a single waiver
+func (s *Server) HandleGetWaiver(w http.ResponseWriter, r *http.Request) {
+	waiver, err := s.waivers.Get(r.PathValue("id"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Waiver not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, waiverEntry{Waiver: waiver, Active: waiver.Active(time.Now())})
+}
+
+// HandleDeleteWaiver removes a waiver, restoring the findings it suppressed
+func (s *Server) HandleDeleteWaiver(w http.ResponseWriter, r *http.Request) {
+	err := s.waivers.Delete(r.PathValue("id"))
+	if errors.Is(err, waivers.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Waiver not found")
+		return
+	}
+	if err != nil {
+		log.Printf("Error deleting waiver: %v", err)
+		writeError(w, http.StatusInternalServerError, "Failed to delete waiver")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// presentSummary prepares a stored summary for an API response: imported
+// findings are merged, waived findings set aside, remediation guidance added
+// and the categories listed with their scores and grades
+func (s *Server) presentSummary(report *store.Report, summary *types.ReportSummary) *types.ReportSummary {
+	presented := s.knowledge.Enrich(s.withWaivers(report, withImported(report, summary)))
+	scoring.Categorize(presented, s.summaryProfile(report, presented))
+	scoring.Grade(presented, s.settings.Current().Grades)
+	return presented
+}
+
+// withWaivers returns a copy of a summary without the findings suppressed by
+// waivers, listing them under Waived and re-scoring the rest; the summary
+// itself is not changed
+func (s *Server) withWaivers(report *store.Report, summary *types.ReportSummary) *types.ReportSummary {
+	now := time.Now()
+	cluster := report.ClusterKey()
+
+	kept := make([]types.Finding, 0, len(summary.Findings))
+	waived := make(map[string]bool)
+	var waivedFindings []types.WaivedFinding
+	for _, finding := range summary.Findings {
+		waiver, ok := s.waivers.Find(finding, cluster, now)
+		if !ok {
+			kept = append(kept, finding)
+			continue
+		}
+		for _, key := range []string{finding.ID, strings.TrimSpace(finding.Title)} {
+			if key != "" {
+				waived[key] = true
+			}
+		}
+		waivedFindings = append(waivedFindings, types.WaivedFinding{
+			Finding:       finding,
+			Waiver:        waiver.ID,
+			Justification: waiver.Justification,
+			ExpiresAt:     waiver.ExpiresAt,
+		})
+	}
+	if len(waivedFindings) == 0 {
+		return summary
+	}
+
+	filtered := *summary
+	filtered.Findings = kept
+	filtered.Waived = waivedFindings
+	filtered.ItemsRequired = withoutItems(summary.ItemsRequired, waived)
+	filtered.ItemsRecommended = withoutItems(summary.ItemsRecommended, waived)
+	filtered.ItemsAdvisory = withoutItems(summary.ItemsAdvisory, waived)
+	for _, finding := range waivedFindings {
+		switch finding.Status {
+		case types.ResultKeyNoChange:
+			filtered.NoChangeCount--
+		case types.ResultKeyNotApplicable:
+			filtered.NotApplicableCount--
+		}
+	}
+
+	// Score the remaining findings with the profile the report was scored with
+	scoring.Recompute(&filtered, s.summaryProfile(report, summary))
+	applyImportedScores(report, &filtered)
+	return &filtered
+}
+
+// summaryProfile returns the profile a summary was scored with, or the
+// cluster's profile when that one no longer exists
+func (s *Server) summaryProfile(report *store.Report, summary *types.ReportSummary) scoring.Profile {
+	profile, err := scoring.Preset(summary.ScoringProfile)
+	if err != nil {
+		profile, _ = s.scoringProfile("", report.Cluster)
+	}
+	return profile
+}
+
+// withoutItems returns the "name: observation" items that aren't waived; the
+// name is a finding title or an "id,title" cross reference
+func withoutItems(items []string, waived map[string]bool) []string {
+	kept := make([]string, 0, len(items))
+	for _, item := range items {
+		name, _ := utils.SplitItem(item)
+		id, title, _ := strings.Cut(name, ",")
+		if !waived[name] && !waived[strings.TrimSpace(id)] && !waived[strings.TrimSpace(title)] {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,111 @@
+// app/server/settings/export.go
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/alerting"
+)
+
+// ErrNoFile is returned when saving settings without a settings file configured
+var ErrNoFile = errors.New("no settings file is configured")
+
+// Export returns the settings in effect in the layout of the settings file.
+// Notification URLs and alerting keys are included, so the result must be
+// handled like the settings file itself. Alerting targets of the environment
+// are left out because they are added to those of the file.
+func (m *Manager) Export() File {
+	current := m.Current()
+	weights := current.Scoring.Weights
+	f := File{
+		Scoring: &FileScoring{
+			Name:            current.Scoring.Name,
+			Weights:         &weights,
+			CategoryWeights: current.Scoring.CategoryWeights,
+		},
+		Grades: &FileGrades{
+			Letters: current.Grades.Letters,
+			RAG:     current.Grades.RAG,
+		},
+		Categories:       current.Categories,
+		CustomCategories: current.CustomCategories,
+		ParserProfiles:   current.ParserProfiles,
+		Notifications: &FileNotifications{
+			SlackWebhookURLs:   current.Notify.SlackWebhookURLs,
+			WebhookURLs:        current.Notify.WebhookURLs,
+			TeamsWebhooks:      current.Notify.TeamsWebhooks,
+			CategoryThresholds: current.Notify.CategoryThresholds,
+		},
+	}
+
+	for _, group := range current.Groups {
+		f.Groups = append(f.Groups, FileGroup{Name: group.Name, Description: group.Description, Selector: group.Selector})
+	}
+	if current.FallbackOrder != nil {
+		f.Extraction = &FileExtraction{FallbackOrder: current.FallbackOrder}
+	}
+
+	base := make(map[string]bool)
+	for _, target := range m.base.Alerting.PagerDuty {
+		base["pagerduty/"+target.Name] = true
+	}
+	for _, target := range m.base.Alerting.Opsgenie {
+		base["opsgenie/"+target.Name] = true
+	}
+	config := alerting.Config{Rules: current.Alerting.Rules}
+	for _, target := range current.Alerting.PagerDuty {
+		if !base["pagerduty/"+target.Name] {
+			config.PagerDuty = append(config.PagerDuty, target)
+		}
+	}
+	for _, target := range current.Alerting.Opsgenie {
+		if !base["opsgenie/"+target.Name] {
+			config.Opsgenie = append(config.Opsgenie, target)
+		}
+	}
+	if len(config.Rules)+len(config.PagerDuty)+len(config.Opsgenie) > 0 {
+		f.Alerting = &config
+	}
+	return f
+}
+
+// Validate checks a settings file without applying it
+func (m *Manager) Validate(f File) error {
+	_, err := m.build(f)
+	return err
+}
+
+// Save validates a settings file, writes it to the settings file path and
+// swaps it in; on error the current settings and file stay in effect
+func (m *Manager) Save(f File) (*Settings, error) {
+	if m.path == "" {
+		return nil, ErrNoFile
+	}
+	settings, err := m.build(f)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := yaml.Marshal(f)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding settings file: %w", err)
+	}
+
+	// Write to a temp file first so a crash never leaves a truncated file
+	if err := os.WriteFile(m.path+".tmp", data, 0o600); err != nil {
+		return nil, fmt.Errorf("error writing settings file: %w", err)
+	}
+	if err := os.Rename(m.path+".tmp", m.path); err != nil {
+		return nil, fmt.Errorf("error writing settings file: %w", err)
+	}
+
+	m.current.Store(settings)
+	log.Printf("Saved settings to %s: scoring profile %s, %d category mappings, %d notification targets, %d alert rules",
+		m.path, settings.Scoring.Name, len(settings.Categories), settings.Notify.Targets(), len(settings.Alerting.Rules))
+	return settings, nil
+}
@@ -0,0 +1,425 @@
+// app/server/settings/settings.go
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/alerting"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/groups"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/notify"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// Settings are the parts of the configuration that can change without a restart
+type Settings struct {
+	// Scoring is the default profile when neither the request nor the
+	// cluster's organization picks one
+	Scoring scoring.Profile `json:"scoring"`
+
+	// Grades are the bands scores are graded with in API responses and exports
+	Grades scoring.Grades `json:"grades"`
+
+	// Categories maps category names used by customized report templates to
+	// one of the standard template categories or a custom category
+	Categories map[string]string `json:"categories,omitempty"`
+
+	// CustomCategories are categories scored alongside the standard ones when
+	// the category column of a report holds them
+	CustomCategories []string `json:"customCategories,omitempty"`
+
+	// FallbackOrder is the order the parser tries its score extraction
+	// strategies in; empty uses the parser's default order
+	FallbackOrder []string `json:"fallbackOrder,omitempty"`
+
+	// ParserProfiles are the report dialects the parser can translate, in
+	// the order they are tried when detecting the dialect of a document
+	ParserProfiles []utils.ParserProfile `json:"parserProfiles,omitempty"`
+
+	// Groups are the cluster groups the rollup endpoints aggregate, by name
+	Groups []groups.Group `json:"groups,omitempty"`
+
+	// Notify holds the notification targets
+	Notify notify.Config `json:"-"`
+
+	// Alerting holds the alert rules and the services they page
+	Alerting alerting.Config `json:"-"`
+}
+
+// MapCategories renames the finding categories listed in the category mappings
+func (s *Settings) MapCategories(summary *types.ReportSummary) {
+	if len(s.Categories) == 0 {
+		return
+	}
+	for i := range summary.Findings {
+		if mapped, ok := s.Categories[summary.Findings[i].Category]; ok {
+			summary.Findings[i].Category = mapped
+		}
+	}
+}
+
+// File is the YAML layout of the settings file; every section is optional and
+// falls back to the settings from the environment
+type File struct {
+	Scoring *FileScoring `yaml:"scoring,omitempty"`
+
+	Grades *FileGrades `yaml:"grades,omitempty"`
+
+	Categories map[string]string `yaml:"categories,omitempty"`
+
+	CustomCategories []string `yaml:"customCategories,omitempty"`
+
+	ParserProfiles []utils.ParserProfile `yaml:"parserProfiles,omitempty"`
+
+	Groups []FileGroup `yaml:"groups,omitempty"`
+
+	Extraction *FileExtraction `yaml:"extraction,omitempty"`
+
+	Notifications *FileNotifications `yaml:"notifications,omitempty"`
+
+	// Alerting replaces the rules; its targets are added to those of the environment
+	Alerting *alerting.Config `yaml:"alerting,omitempty"`
+}
+
+// FileScoring is the scoring section of the settings file
+type FileScoring struct {
+	Preset          string             `yaml:"preset,omitempty"`
+	Name            string             `yaml:"name,omitempty"`
+	Weights         *scoring.Weights   `yaml:"weights,omitempty"`
+	CategoryWeights map[string]float64 `yaml:"categoryWeights,omitempty"`
+}
+
+// FileGrades is the grades section of the settings file; a list left out
+// keeps the bands of the environment
+type FileGrades struct {
+	Letters []scoring.Band `yaml:"letters,omitempty"`
+	RAG     []scoring.Band `yaml:"rag,omitempty"`
+}
+
+// FileGroup is a cluster group of the settings file
+type FileGroup struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+	Selector    string `yaml:"selector"`
+}
+
+// FileExtraction is the extraction section of the settings file
+type FileExtraction struct {
+	FallbackOrder []string `yaml:"fallbackOrder"`
+}
+
+// FileNotifications is the notifications section of the settings file
+type FileNotifications struct {
+	SlackWebhookURLs []string              `yaml:"slackWebhookUrls,omitempty"`
+	WebhookURLs      []string              `yaml:"webhookUrls,omitempty"`
+	TeamsWebhooks    []notify.TeamsWebhook `yaml:"teamsWebhooks,omitempty"`
+
+	// CategoryThresholds maps categories to the score below which the
+	// webhooks receive a threshold event
+	CategoryThresholds map[string]float64 `yaml:"categoryThresholds,omitempty"`
+}
+
+// standardCategories are the categories a mapping may point to
+var standardCategories = []string{
+	scoring.CategoryClusterConfig,
+	scoring.CategorySecurity,
+	scoring.CategoryPerformance,
+	scoring.CategoryOpReady,
+	scoring.CategoryApplications,
+}
+
+// Manager holds the current settings and swaps them atomically on reload, so
+// a request always sees either the old or the new settings, never a mix
+type Manager struct {
+	path    string
+	base    Settings
+	current atomic.Pointer[Settings]
+}
+
+// NewManager loads the settings file at path on top of the base settings from
+// the environment; an empty path uses the base settings only
+func NewManager(path string, base Settings) (*Manager, error) {
+	m := &Manager{path: path, base: base}
+	if _, err := m.Reload(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Current returns the settings in effect
+func (m *Manager) Current() *Settings {
+	return m.current.Load()
+}
+
+// Reload re-reads the settings file and swaps it in if it is valid; on error
+// the current settings stay in effect
+func (m *Manager) Reload() (*Settings, error) {
+	settings, err := m.load()
+	if err != nil {
+		return nil, err
+	}
+
+	m.current.Store(settings)
+	return settings, nil
+}
+
+// load builds the settings from the base and the settings file
+func (m *Manager) load() (*Settings, error) {
+	settings := m.base
+	if m.path == "" {
+		return &settings, nil
+	}
+
+	data, err := os.ReadFile(m.path)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Printf("Settings file %s does not exist, using environment settings", m.path)
+		return &settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading settings file: %w", err)
+	}
+
+	var f File
+	if err := yaml.UnmarshalStrict(data, &f); err != nil {
+		return nil, fmt.Errorf("error parsing settings file: %w", err)
+	}
+
+	built, err := m.build(f)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Loaded settings from %s: scoring profile %s, %d category mappings, %d notification targets, %d alert rules",
+		m.path, built.Scoring.Name, len(built.Categories), built.Notify.Targets(), len(built.Alerting.Rules))
+	return built, nil
+}
+
+// build applies the sections of a settings file to the base settings
+func (m *Manager) build(f File) (*Settings, error) {
+	settings := m.base
+	var err error
+	if f.Scoring != nil {
+		profile := settings.Scoring
+		if f.Scoring.Preset != "" {
+			if profile, err = scoring.Preset(f.Scoring.Preset); err != nil {
+				return nil, err
+			}
+		}
+		if f.Scoring.Weights != nil || f.Scoring.CategoryWeights != nil {
+			profile.Name = "custom"
+		}
+		if f.Scoring.Weights != nil {
+			profile.Weights = *f.Scoring.Weights
+		}
+		if f.Scoring.CategoryWeights != nil {
+			profile.CategoryWeights = f.Scoring.CategoryWeights
+		}
+		if f.Scoring.Name != "" {
+			profile.Name = f.Scoring.Name
+		}
+		if err := profile.Validate(); err != nil {
+			return nil, err
+		}
+		settings.Scoring = profile
+	}
+
+	if f.Grades != nil {
+		grades := settings.Grades
+		if f.Grades.Letters != nil {
+			grades.Letters = f.Grades.Letters
+		}
+		if f.Grades.RAG != nil {
+			grades.RAG = f.Grades.RAG
+		}
+		if err := grades.Validate(); err != nil {
+			return nil, err
+		}
+		settings.Grades = grades
+	}
+
+	if f.CustomCategories != nil {
+		seen := make(map[string]bool)
+		for _, category := range f.CustomCategories {
+			switch {
+			case strings.TrimSpace(category) == "":
+				return nil, errors.New("custom category names must not be empty")
+			case isStandardCategory(category):
+				return nil, fmt.Errorf("custom category %q is a standard category", category)
+			case seen[category]:
+				return nil, fmt.Errorf("custom category %q is defined twice", category)
+			}
+			seen[category] = true
+		}
+		settings.CustomCategories = f.CustomCategories
+	}
+
+	if f.Categories != nil {
+		targets := append(append([]string{}, standardCategories...), settings.CustomCategories...)
+		for from, to := range f.Categories {
+			if !isStandardCategory(to) && !settings.isCustomCategory(to) {
+				return nil, fmt.Errorf("category %q is mapped to %q, which is not one of %s",
+					from, to, strings.Join(targets, ", "))
+			}
+		}
+		settings.Categories = f.Categories
+	}
+
+	if f.ParserProfiles != nil {
+		seen := make(map[string]bool)
+		for i := range f.ParserProfiles {
+			profile := &f.ParserProfiles[i]
+			if err := profile.Validate(); err != nil {
+				return nil, err
+			}
+			if seen[profile.Name] {
+				return nil, fmt.Errorf("parser profile %q is defined twice", profile.Name)
+			}
+			seen[profile.Name] = true
+		}
+		settings.ParserProfiles = f.ParserProfiles
+	}
+
+	if f.Groups != nil {
+		settings.Groups = make([]groups.Group, 0, len(f.Groups))
+		seen := make(map[string]bool)
+		for _, definition := range f.Groups {
+			group, err := groups.New(definition.Name, definition.Description, definition.Selector)
+			if err != nil {
+				return nil, err
+			}
+			if seen[group.Name] {
+				return nil, fmt.Errorf("group %q is defined twice", group.Name)
+			}
+			seen[group.Name] = true
+			settings.Groups = append(settings.Groups, group)
+		}
+		sort.Slice(settings.Groups, func(i, j int) bool { return settings.Groups[i].Name < settings.Groups[j].Name })
+	}
+
+	if f.Extraction != nil && f.Extraction.FallbackOrder != nil {
+		if err := utils.ValidateFallbackOrder(f.Extraction.FallbackOrder); err != nil {
+			return nil, err
+		}
+		settings.FallbackOrder = f.Extraction.FallbackOrder
+	}
+
+	if f.Notifications != nil {
+		for _, webhook := range f.Notifications.TeamsWebhooks {
+			if webhook.URL == "" {
+				return nil, fmt.Errorf("a Teams webhook has no url")
+			}
+		}
+		for category, threshold := range f.Notifications.CategoryThresholds {
+			if threshold <= 0 || threshold > 100 {
+				return nil, fmt.Errorf("threshold %g of category %q must be between 0 and 100", threshold, category)
+			}
+		}
+		settings.Notify = notify.Config{
+			SlackWebhookURLs: f.Notifications.SlackWebhookURLs,
+			WebhookURLs:      f.Notifications.WebhookURLs,
+			TeamsWebhooks:    f.Notifications.TeamsWebhooks,
+
+			CategoryThresholds: f.Notifications.CategoryThresholds,
+		}
+	}
+
+	if f.Alerting != nil {
+		config := alerting.Config{
+			Rules:     f.Alerting.Rules,
+			PagerDuty: append(append([]alerting.PagerDutyTarget{}, settings.Alerting.PagerDuty...), f.Alerting.PagerDuty...),
+			Opsgenie:  append(append([]alerting.OpsgenieTarget{}, settings.Alerting.Opsgenie...), f.Alerting.Opsgenie...),
+		}
+		if err := config.Validate(); err != nil {
+			return nil, err
+		}
+		settings.Alerting = config
+	}
+
+	return &settings, nil
+}
+
+// Describe lists the reloadable settings in a form safe to return from the API;
+// notification URLs carry credentials, so only their count is included
+func (s *Settings) Describe() map[string]interface{} {
+	mapped := make([]string, 0, len(s.Categories))
+	for from := range s.Categories {
+		mapped = append(mapped, from)
+	}
+	sort.Strings(mapped)
+
+	return map[string]interface{}{
+		"scoringProfile":      s.Scoring.Name,
+		"grades":              s.Grades,
+		"categoryMappings":    mapped,
+		"customCategories":    append([]string{}, s.CustomCategories...),
+		"fallbackOrder":       s.ParseOptions().FallbackOrder,
+		"groups":              len(s.Groups),
+		"parserProfiles":      len(s.ParserProfiles),
+		"notificationTargets": s.Notify.Targets(),
+		"alertRules":          len(s.Alerting.Rules),
+		"categoryThresholds":  s.Notify.CategoryThresholds,
+	}
+}
+
+// Group returns the cluster group with the given name
+func (s *Settings) Group(name string) (groups.Group, bool) {
+	for _, group := range s.Groups {
+		if group.Name == name {
+			return group, true
+		}
+	}
+	return groups.Group{}, false
+}
+
+// ParseOptions returns the parser options of the settings
+func (s *Settings) ParseOptions() utils.ParseOptions {
+	order := s.FallbackOrder
+	if len(order) == 0 {
+		order = utils.DefaultFallbackOrder
+	}
+	return utils.ParseOptions{FallbackOrder: order, Profiles: s.ParserProfiles, Categories: s.parsedCategories()}
+}
+
+// parsedCategories are the category column values the parser recognizes
+// besides the template categories: the custom categories and the names the
+// mappings rename
+func (s *Settings) parsedCategories() []string {
+	if len(s.CustomCategories) == 0 && len(s.Categories) == 0 {
+		return nil
+	}
+	categories := append([]string{}, s.CustomCategories...)
+	for from := range s.Categories {
+		categories = append(categories, from)
+	}
+	sort.Strings(categories)
+	return categories
+}
+
+// isCustomCategory reports whether a category is one of the custom categories
+func (s *Settings) isCustomCategory(category string) bool {
+	for _, custom := range s.CustomCategories {
+		if category == custom {
+			return true
+		}
+	}
+	return false
+}
+
+// isStandardCategory reports whether a category is one of the template categories
+func isStandardCategory(category string) bool {
+	for _, standard := range standardCategories {
+		if category == standard {
+			return true
+		}
+	}
+	return false
+}
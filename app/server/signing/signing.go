@@ -0,0 +1,311 @@
+// app/server/signing/signing.go
+
+// Package signing verifies detached signatures of uploaded reports against
+// trusted keys. Two kinds of signature are accepted: OpenPGP signatures made
+// with gpg --detach-sign, armored or binary, and sigstore signatures made with
+// cosign sign-blob --key, as the base64 signature or the JSON bundle.
+package signing
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	pgperrors "github.com/ProtonMail/go-crypto/openpgp/errors"
+)
+
+// Signature methods
+const (
+	MethodGPG      = "gpg"
+	MethodSigstore = "sigstore"
+)
+
+// Verification statuses
+const (
+	// StatusVerified means a trusted key made the signature over the document
+	StatusVerified = "verified"
+
+	// StatusUntrusted means the signature was made with a key that is not trusted
+	StatusUntrusted = "untrusted"
+
+	// StatusInvalid means the signature does not match the document or is malformed
+	StatusInvalid = "invalid"
+)
+
+// MaxSignatureSize bounds the size of a detached signature
+const MaxSignatureSize = 64 << 10
+
+// Config holds the trusted keys
+type Config struct {
+	// GPGKeyring is a file of trusted OpenPGP public keys, armored or binary
+	GPGKeyring string
+
+	// SigstoreKeys are PEM files of trusted cosign public keys
+	SigstoreKeys []string
+
+	// Required rejects uploads without a verified signature
+	Required bool
+}
+
+// Verification is the outcome of checking a report's signature
+type Verification struct {
+	Status string `json:"status"`
+	Method string `json:"method"`
+
+	// Signer identifies the key that made the signature: the fingerprint and
+	// user ID of an OpenPGP key, or the file name and fingerprint of a cosign key
+	Signer string `json:"signer,omitempty"`
+
+	// Digest is the SHA-256 digest of the signed document
+	Digest string `json:"digest"`
+
+	// Error explains why a signature was not verified
+	Error string `json:"error,omitempty"`
+
+	VerifiedAt time.Time `json:"verifiedAt"`
+}
+
+// Verified reports whether the signature was made by a trusted key
+func (v *Verification) Verified() bool {
+	return v != nil && v.Status == StatusVerified
+}
+
+// trustedKey is a cosign public key
+type trustedKey struct {
+	name        string
+	fingerprint string
+	key         crypto.PublicKey
+}
+
+// Verifier checks signatures against the configured keys
+type Verifier struct {
+	required bool
+	keyring  openpgp.EntityList
+	keys     []trustedKey
+}
+
+// New loads the trusted keys of the configuration
+func New(config Config) (*Verifier, error) {
+	v := &Verifier{required: config.Required}
+
+	if config.GPGKeyring != "" {
+		keyring, err := readKeyring(config.GPGKeyring)
+		if err != nil {
+			return nil, fmt.Errorf("error loading GPG keyring %s: %w", config.GPGKeyring, err)
+		}
+		v.keyring = keyring
+	}
+
+	for _, path := range config.SigstoreKeys {
+		key, err := readPublicKey(path)
+		if err != nil {
+			return nil, fmt.Errorf("error loading sigstore key %s: %w", path, err)
+		}
+		v.keys = append(v.keys, key)
+	}
+
+	if config.Required && len(v.keyring) == 0 && len(v.keys) == 0 {
+		return nil, errors.New("signatures are required, but no trusted keys are configured")
+	}
+	return v, nil
+}
+
+// Required reports whether uploads must carry a verified signature
+func (v *Verifier) Required() bool {
+	return v.required
+}
+
+// Verify checks a detached signature over a document
+func (v *Verifier) Verify(document, signature []byte) *Verification {
+	digest := sha256.Sum256(document)
+	result := &Verification{Digest: hex.EncodeToString(digest[:]), VerifiedAt: time.Now().UTC()}
+
+	trimmed := bytes.TrimSpace(signature)
+	switch {
+	case bytes.HasPrefix(trimmed, []byte("-----BEGIN PGP SIGNATURE-----")):
+		result.Method = MethodGPG
+		v.verifyGPG(result, document, trimmed, true)
+	case len(signature) > 0 && signature[0]&0x80 != 0:
+		// Binary OpenPGP packets start with the high bit set, which base64 and JSON never do
+		result.Method = MethodGPG
+		v.verifyGPG(result, document, signature, false)
+	default:
+		result.Method = MethodSigstore
+		v.verifySigstore(result, document, digest[:], trimmed)
+	}
+	return result
+}
+
+// verifyGPG checks an OpenPGP signature against the keyring
+func (v *Verifier) verifyGPG(result *Verification, document, signature []byte, armored bool) {
+	if len(v.keyring) == 0 {
+		result.Status = StatusUntrusted
+		result.Error = "no trusted GPG keys are configured"
+		return
+	}
+
+	check := openpgp.CheckDetachedSignature
+	if armored {
+		check = openpgp.CheckArmoredDetachedSignature
+	}
+	// A nil config checks expiry and revocation against the current time
+	signer, err := check(v.keyring, bytes.NewReader(document), bytes.NewReader(signature), nil)
+	switch {
+	case errors.Is(err, pgperrors.ErrUnknownIssuer):
+		result.Status = StatusUntrusted
+		result.Error = "the signing key is not in the trusted keyring"
+	case errors.Is(err, pgperrors.ErrKeyRevoked):
+		result.Status = StatusUntrusted
+		result.Error = "the signing key is revoked"
+	case errors.Is(err, pgperrors.ErrKeyExpired):
+		result.Status = StatusUntrusted
+		result.Error = "the signing key is expired"
+	case errors.Is(err, pgperrors.ErrSignatureExpired):
+		result.Status = StatusInvalid
+		result.Error = "the signature is expired"
+	case err != nil:
+		result.Status = StatusInvalid
+		result.Error = err.Error()
+	default:
+		result.Status = StatusVerified
+		result.Signer = strings.ToUpper(hex.EncodeToString(signer.PrimaryKey.Fingerprint))
+		names := make([]string, 0, len(signer.Identities))
+		for name := range signer.Identities {
+			names = append(names, name)
+		}
+		if len(names) > 0 {
+			sort.Strings(names)
+			result.Signer += " " + names[0]
+		}
+	}
+}
+
+// cosignBundle is the part of a cosign bundle that holds the signature
+type cosignBundle struct {
+	Base64Signature string `json:"base64Signature"`
+}
+
+// verifySigstore checks a cosign signature against the trusted keys
+func (v *Verifier) verifySigstore(result *Verification, document, digest, signature []byte) {
+	encoded := string(signature)
+	if bytes.HasPrefix(signature, []byte("{")) {
+		var bundle cosignBundle
+		if err := json.Unmarshal(signature, &bundle); err != nil || bundle.Base64Signature == "" {
+			result.Status = StatusInvalid
+			result.Error = "the signature bundle has no base64Signature"
+			return
+		}
+		encoded = bundle.Base64Signature
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		result.Status = StatusInvalid
+		result.Error = "the signature is neither an OpenPGP signature nor base64"
+		return
+	}
+
+	if len(v.keys) == 0 {
+		result.Status = StatusUntrusted
+		result.Error = "no trusted sigstore keys are configured"
+		return
+	}
+
+	for _, key := range v.keys {
+		if verifyWithKey(key.key, document, digest, raw) {
+			result.Status = StatusVerified
+			result.Signer = key.name + " " + key.fingerprint
+			return
+		}
+	}
+	result.Status = StatusInvalid
+	result.Error = "no trusted key matches the signature"
+}
+
+// verifyWithKey checks a raw signature the way cosign makes it: over the
+// SHA-256 digest for ECDSA and RSA keys, over the document for Ed25519 keys
+func verifyWithKey(key crypto.PublicKey, document, digest, signature []byte) bool {
+	switch key := key.(type) {
+	case *ecdsa.PublicKey:
+		return ecdsa.VerifyASN1(key, digest, signature)
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest, signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, document, signature)
+	}
+	return false
+}
+
+// readKeyring reads an armored or binary OpenPGP keyring
+func readKeyring(path string) (openpgp.EntityList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var keyring openpgp.EntityList
+	if bytes.Contains(data, []byte("-----BEGIN PGP")) {
+		keyring, err = openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	} else {
+		keyring, err = openpgp.ReadKeyRing(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(keyring) == 0 {
+		return nil, errors.New("the keyring holds no keys")
+	}
+	return keyring, nil
+}
+
+// readPublicKey reads a PEM encoded public key, as written by cosign generate-key-pair
+func readPublicKey(path string) (trustedKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return trustedKey{}, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return trustedKey{}, errors.New("no PEM block found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return trustedKey{}, err
+	}
+
+	fingerprint := sha256.Sum256(block.Bytes)
+	return trustedKey{
+		name:        filepath.Base(path),
+		fingerprint: "SHA256:" + hex.EncodeToString(fingerprint[:8]),
+		key:         key,
+	}, nil
+}
+
+// ReadSignature reads a detached signature, failing when it exceeds MaxSignatureSize
+func ReadSignature(r io.Reader) ([]byte, error) {
+	signature, err := io.ReadAll(io.LimitReader(r, MaxSignatureSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(signature) > MaxSignatureSize {
+		return nil, fmt.Errorf("the signature exceeds %d bytes", MaxSignatureSize)
+	}
+	return signature, nil
+}
@@ -0,0 +1,188 @@
+// app/server/signing/signing_test.go
+package signing
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+)
+
+// signedDocument is the document the tests sign
+var signedDocument = []byte("= Health Check Report\n\nRed Hat conducted a health check of ocp-prod-01.\n")
+
+// tamperedDocument differs from signedDocument in one character
+var tamperedDocument = bytes.Replace(signedDocument, []byte("ocp-prod-01"), []byte("ocp-prod-02"), 1)
+
+// gpgConfig makes small, fast keys; now, when set, is the time keys are
+// created and signatures made at
+func gpgConfig(now time.Time, lifetime time.Duration) *packet.Config {
+	config := &packet.Config{Algorithm: packet.PubKeyAlgoEdDSA, KeyLifetimeSecs: uint32(lifetime.Seconds())}
+	if !now.IsZero() {
+		config.Time = func() time.Time { return now }
+	}
+	return config
+}
+
+// newGPGKey generates an OpenPGP key
+func newGPGKey(t *testing.T, config *packet.Config) *openpgp.Entity {
+	t.Helper()
+	entity, err := openpgp.NewEntity("Report Signer", "", "signer@example.com", config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entity
+}
+
+// writeKeyring writes the public keys of entities as an armored keyring
+func writeKeyring(t *testing.T, entities ...*openpgp.Entity) string {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entity := range entities {
+		if err := entity.Serialize(w); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Close()
+
+	path := filepath.Join(t.TempDir(), "keyring.asc")
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// gpgSign makes a detached signature, armored or binary
+func gpgSign(t *testing.T, entity *openpgp.Entity, armored bool, config *packet.Config) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	sign := openpgp.DetachSign
+	if armored {
+		sign = openpgp.ArmoredDetachSign
+	}
+	if err := sign(&buf, entity, bytes.NewReader(signedDocument), config); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestVerifyGPG(t *testing.T) {
+	trusted := newGPGKey(t, gpgConfig(time.Time{}, 0))
+	other := newGPGKey(t, gpgConfig(time.Time{}, 0))
+
+	// A key that expired an hour after it was made a day ago
+	created := time.Now().Add(-24 * time.Hour)
+	expired := newGPGKey(t, gpgConfig(created, time.Hour))
+
+	revoked := newGPGKey(t, gpgConfig(time.Time{}, 0))
+	revokedSignature := gpgSign(t, revoked, true, nil)
+	if err := revoked.RevokeKey(packet.KeyCompromised, "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	verifier, err := New(Config{GPGKeyring: writeKeyring(t, trusted, expired, revoked)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		document  []byte
+		signature []byte
+		status    string
+	}{
+		{"armored", signedDocument, gpgSign(t, trusted, true, nil), StatusVerified},
+		{"binary", signedDocument, gpgSign(t, trusted, false, nil), StatusVerified},
+		{"unknown key", signedDocument, gpgSign(t, other, true, nil), StatusUntrusted},
+		{"tampered", tamperedDocument, gpgSign(t, trusted, true, nil), StatusInvalid},
+		{"expired key", signedDocument, gpgSign(t, expired, true, gpgConfig(created.Add(time.Minute), 0)), StatusUntrusted},
+		{"revoked key", signedDocument, revokedSignature, StatusUntrusted},
+	}
+	for _, test := range tests {
+		result := verifier.Verify(test.document, test.signature)
+		if result.Method != MethodGPG || result.Status != test.status {
+			t.Errorf("%s: %s %s (%s), want gpg %s", test.name, result.Method, result.Status, result.Error, test.status)
+		}
+		if test.status == StatusVerified && !bytes.Contains([]byte(result.Signer), []byte("signer@example.com")) {
+			t.Errorf("%s: signer %q does not name the key", test.name, result.Signer)
+		}
+	}
+}
+
+// newCosignKey generates an ECDSA key like cosign generate-key-pair and
+// writes its public key as PEM
+func newCosignKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "cosign.pub")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return key, path
+}
+
+// cosignSign signs the digest of the document like cosign sign-blob
+func cosignSign(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	digest := sha256.Sum256(signedDocument)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestVerifySigstore(t *testing.T) {
+	trusted, path := newCosignKey(t)
+	other, _ := newCosignKey(t)
+
+	verifier, err := New(Config{SigstoreKeys: []string{path}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := cosignSign(t, trusted)
+
+	tests := []struct {
+		name      string
+		verifier  *Verifier
+		document  []byte
+		signature string
+		status    string
+	}{
+		{"base64", verifier, signedDocument, signature, StatusVerified},
+		{"bundle", verifier, signedDocument, `{"base64Signature":"` + signature + `"}`, StatusVerified},
+		{"unknown key", verifier, signedDocument, cosignSign(t, other), StatusInvalid},
+		{"no trusted keys", &Verifier{}, signedDocument, signature, StatusUntrusted},
+		{"tampered", verifier, tamperedDocument, signature, StatusInvalid},
+		{"not a signature", verifier, signedDocument, "not base64!", StatusInvalid},
+	}
+	for _, test := range tests {
+		result := test.verifier.Verify(test.document, []byte(test.signature))
+		if result.Method != MethodSigstore || result.Status != test.status {
+			t.Errorf("%s: %s %s (%s), want sigstore %s", test.name, result.Method, result.Status, result.Error, test.status)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// app/server/sqlite/nosqlite.go
+//go:build !sqlite
+
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// Available reports whether the binary was built with SQLite support
+const Available = false
+
+// Open fails in builds without SQLite support; build with -tags sqlite
+func Open(path string) (*sql.DB, error) {
+	return nil, errors.New("this binary was built without SQLite support, rebuild with -tags sqlite")
+}
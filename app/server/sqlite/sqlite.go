@@ -0,0 +1,36 @@
+// app/server/sqlite/sqlite.go
+//go:build sqlite
+
+// Package sqlite opens the embedded SQLite database used by the single-binary
+// profile. The driver is pure Go, so binaries stay static with CGO_ENABLED=0.
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// Available reports whether the binary was built with SQLite support
+const Available = true
+
+// Open opens or creates the database file at path
+func Open(path string) (*sql.DB, error) {
+	// WAL lets readers proceed during writes; the busy timeout covers the
+	// short write locks taken by concurrent uploads
+	dsn := "file:" + path + "?_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)"
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %w", err)
+	}
+
+	// SQLite allows a single writer; one connection avoids lock contention
+	db.SetMaxOpenConns(1)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error opening database %s: %w", path, err)
+	}
+	return db, nil
+}
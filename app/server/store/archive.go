@@ -0,0 +1,189 @@
+// app/server/store/archive.go
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/blob"
+)
+
+// ErrArchived is returned when archiving a report that is already archived
+var ErrArchived = errors.New("report is already archived")
+
+// ErrNotArchived is returned when restoring a report that is not archived
+var ErrNotArchived = errors.New("report is not archived")
+
+// Archive records that a report was taken out of trends and overviews. The
+// report stays retrievable by ID; with cold storage configured its raw
+// document is moved there.
+type Archive struct {
+	ArchivedAt time.Time `json:"archivedAt"`
+	ArchivedBy string    `json:"archivedBy,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+
+	// ColdKey is the key of the raw document in cold storage, if it was exported
+	ColdKey string `json:"coldKey,omitempty"`
+}
+
+// Archived reports whether the report is archived
+func (r *Report) Archived() bool {
+	return r.Archive != nil
+}
+
+// rawKey returns the blob key of the report's raw document
+func (r *Report) rawKey() string {
+	if r.RawKey == "" {
+		// Reports stored before blob keys were recorded used a fixed layout
+		return RawKey(r.ID, ".adoc")
+	}
+	return r.RawKey
+}
+
+// SetColdStorage sets the backend the raw documents of archived reports are
+// exported to; without one they stay in the primary blob backend
+func (s *Store) SetColdStorage(cold blob.Backend) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cold = cold
+}
+
+// ErrArchiving is returned while another request archives or restores the report
+var ErrArchiving = errors.New("report is being archived or restored")
+
+// claimArchive marks a report as being archived or restored and returns it
+// with the cold storage backend; release must be called once done
+func (s *Store) claimArchive(id string) (*Report, blob.Backend, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	report, ok := s.reports[id]
+	if !ok {
+		return nil, nil, ErrNotFound
+	}
+	if s.archiving[id] {
+		return nil, nil, ErrArchiving
+	}
+	s.archiving[id] = true
+	return report, s.cold, nil
+}
+
+// releaseArchive ends archiving or restoring a report
+func (s *Store) releaseArchive(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.archiving, id)
+}
+
+// Archive takes a report out of trends and overviews, exporting its raw
+// document to cold storage when one is set
+func (s *Store) Archive(ctx context.Context, id, by, reason string) (*Report, error) {
+	report, cold, err := s.claimArchive(id)
+	if err != nil {
+		return nil, err
+	}
+	defer s.releaseArchive(id)
+	if report.Archived() {
+		return nil, ErrArchived
+	}
+
+	archive := &Archive{
+		ArchivedAt: time.Now().UTC(),
+		ArchivedBy: by,
+		Reason:     reason,
+	}
+	if cold != nil {
+		if err := copyBlob(ctx, s.blobs, cold, report.rawKey()); err != nil {
+			return nil, fmt.Errorf("error exporting raw document to cold storage: %w", err)
+		}
+		archive.ColdKey = report.rawKey()
+	}
+
+	updated, err := s.Mutate(id, func(report *Report) error {
+		if report.Archived() {
+			return ErrArchived
+		}
+		report.Archive = archive
+		return nil
+	})
+	if err != nil {
+		// An archived report's record already points at the cold copy
+		if archive.ColdKey != "" && !errors.Is(err, ErrArchived) {
+			cold.Delete(ctx, archive.ColdKey)
+		}
+		return nil, err
+	}
+
+	// The record points at the cold copy now, so the primary one can go
+	if archive.ColdKey != "" {
+		if err := s.blobs.Delete(ctx, archive.ColdKey); err != nil {
+			log.Printf("Error removing exported raw document of report %s: %v", id, err)
+		}
+	}
+	return updated, nil
+}
+
+// Unarchive returns an archived report to trends and overviews, bringing its
+// raw document back from cold storage
+func (s *Store) Unarchive(ctx context.Context, id string) (*Report, error) {
+	report, cold, err := s.claimArchive(id)
+	if err != nil {
+		return nil, err
+	}
+	defer s.releaseArchive(id)
+	if !report.Archived() {
+		return nil, ErrNotArchived
+	}
+
+	coldKey := report.Archive.ColdKey
+	if coldKey != "" {
+		if cold == nil {
+			return nil, errors.New("the raw document is in cold storage, which is not configured")
+		}
+		if err := copyBlob(ctx, cold, s.blobs, coldKey); err != nil {
+			return nil, fmt.Errorf("error restoring raw document from cold storage: %w", err)
+		}
+	}
+
+	updated, err := s.Mutate(id, func(report *Report) error {
+		if !report.Archived() {
+			return ErrNotArchived
+		}
+		report.Archive = nil
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if coldKey != "" {
+		if err := cold.Delete(ctx, coldKey); err != nil {
+			log.Printf("Error removing cold copy of raw document of report %s: %v", id, err)
+		}
+	}
+	return updated, nil
+}
+
+// rawBackend returns the backend holding the report's raw document and its
+// key; s.mu must be held
+func (s *Store) rawBackend(report *Report) (blob.Backend, string) {
+	if report.Archived() && report.Archive.ColdKey != "" && s.cold != nil {
+		return s.cold, report.Archive.ColdKey
+	}
+	return s.blobs, report.rawKey()
+}
+
+// copyBlob copies the object named key from one backend to another
+func copyBlob(ctx context.Context, from, to blob.Backend, key string) error {
+	reader, err := from.Open(ctx, key)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	_, err = to.Put(ctx, key, reader)
+	return err
+}
@@ -0,0 +1,53 @@
+// app/server/store/attachments.go
+package store
+
+import (
+	"context"
+	"io"
+	"log"
+)
+
+// Attachment is a file uploaded with a report in a bundle, such as a figure,
+// kept so exports can embed the original
+type Attachment struct {
+	// Path is the path of the file in the bundle
+	Path        string `json:"path"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+}
+
+// AttachmentKey returns the blob key of an attachment of a report
+func AttachmentKey(id, path string) string {
+	return "attachments/" + id + "/" + path
+}
+
+// Attachment returns the attachment of a report with the given path
+func (r *Report) Attachment(path string) (Attachment, bool) {
+	for _, attachment := range r.Attachments {
+		if attachment.Path == path {
+			return attachment, true
+		}
+	}
+	return Attachment{}, false
+}
+
+// OpenAttachment returns a reader for an attachment of a report
+func (s *Store) OpenAttachment(ctx context.Context, id, path string) (io.ReadCloser, error) {
+	report, err := s.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := report.Attachment(path); !ok {
+		return nil, ErrNotFound
+	}
+	return s.blobs.Open(ctx, AttachmentKey(id, path))
+}
+
+// DeleteAttachments removes the stored attachments of a report
+func (s *Store) DeleteAttachments(ctx context.Context, id string, attachments []Attachment) {
+	for _, attachment := range attachments {
+		if err := s.blobs.Delete(ctx, AttachmentKey(id, attachment.Path)); err != nil {
+			log.Printf("Error removing attachment %s of report %s: %v", attachment.Path, id, err)
+		}
+	}
+}
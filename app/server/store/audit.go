@@ -0,0 +1,73 @@
+// app/server/store/audit.go
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// auditLogName is the event log the audit entries are kept in; cluster event
+// logs are named after clusters, whose names start with a letter or digit
+const auditLogName = "_audit"
+
+// AuditEntry records an action taken on behalf of a user that changed a cluster
+// or could have, with its outcome
+type AuditEntry struct {
+	Time    time.Time `json:"time"`
+	Actor   string    `json:"actor"`
+	Action  string    `json:"action"`
+	Cluster string    `json:"cluster,omitempty"`
+
+	// Target is what the action was taken on, such as a remediation ID
+	Target  string `json:"target"`
+	Outcome string `json:"outcome"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// AppendAudit adds an entry to the audit log
+func (s *Store) AppendAudit(entry AuditEntry) error {
+	if s.cipher != nil {
+		entry.Cluster = s.cipher.Seal(entry.Cluster)
+		entry.Detail = s.cipher.Seal(entry.Detail)
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("error encoding audit entry: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.records.AppendEvents(auditLogName, [][]byte{data})
+}
+
+// ListAudit returns the audit log, oldest entry first
+func (s *Store) ListAudit() ([]AuditEntry, error) {
+	s.mu.Lock()
+	records, err := s.records.LoadEvents(auditLogName)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]AuditEntry, 0, len(records))
+	for _, data := range records {
+		var entry AuditEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			log.Printf("Skipping invalid audit entry: %v", err)
+			continue
+		}
+		if s.cipher != nil {
+			if entry.Cluster, err = s.cipher.Open(entry.Cluster); err != nil {
+				return nil, fmt.Errorf("error decrypting the audit log, check the encryption key: %w", err)
+			}
+			if entry.Detail, err = s.cipher.Open(entry.Detail); err != nil {
+				return nil, fmt.Errorf("error decrypting the audit log, check the encryption key: %w", err)
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
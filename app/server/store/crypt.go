@@ -0,0 +1,128 @@
+// app/server/store/crypt.go
+package store
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// sealedPrefix marks a field value written by a Cipher
+const sealedPrefix = "enc:v1:"
+
+// ErrDecrypt is returned when a sealed field can't be opened, usually because
+// the store was written with a different key
+var ErrDecrypt = errors.New("error decrypting stored field")
+
+// Cipher encrypts the identifying fields of stored reports: the customer name,
+// the cluster names and the uploaded file name. Encryption is deterministic (the
+// nonce is derived from the plaintext, as in AES-SIV), so equal values produce
+// equal ciphertexts and exact-match filters work on a dump without the key.
+// Raw documents in the blob backend are not covered.
+type Cipher struct {
+	aead   cipher.AEAD
+	macKey []byte
+}
+
+// NewCipher creates a cipher from a base64-encoded 32-byte key
+func NewCipher(encodedKey string) (*Cipher, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encodedKey))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+
+	// Separate keys for encryption and nonce derivation
+	block, err := aes.NewCipher(deriveKey(key, "encrypt"))
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+
+	return &Cipher{aead: aead, macKey: deriveKey(key, "nonce")}, nil
+}
+
+// Seal encrypts a field value; empty values are left empty
+func (c *Cipher) Seal(value string) string {
+	if value == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, c.macKey)
+	mac.Write([]byte(value))
+	nonce := mac.Sum(nil)[:c.aead.NonceSize()]
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), nil)
+	return sealedPrefix + base64.RawURLEncoding.EncodeToString(sealed)
+}
+
+// Open decrypts a field value; values that were never sealed are returned as is
+func (c *Cipher) Open(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, sealedPrefix)
+	if !ok {
+		return value, nil
+	}
+
+	sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", ErrDecrypt
+	}
+
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	plain, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", ErrDecrypt
+	}
+	return string(plain), nil
+}
+
+// sealReport returns a copy of a report with its identifying fields encrypted
+func (c *Cipher) sealReport(report *Report) *Report {
+	sealed := *report
+	sealed.Filename = c.Seal(report.Filename)
+	sealed.Cluster = c.Seal(report.Cluster)
+
+	if report.Summary != nil {
+		summary := *report.Summary
+		summary.ClusterName = c.Seal(summary.ClusterName)
+		summary.CustomerName = c.Seal(summary.CustomerName)
+		sealed.Summary = &summary
+	}
+	return &sealed
+}
+
+// openReport decrypts the identifying fields of a report in place and reports
+// whether any of them were still stored in plaintext
+func (c *Cipher) openReport(report *Report) (plaintext bool, err error) {
+	fields := []*string{&report.Filename, &report.Cluster}
+	if report.Summary != nil {
+		fields = append(fields, &report.Summary.ClusterName, &report.Summary.CustomerName)
+	}
+
+	for _, field := range fields {
+		if *field != "" && !strings.HasPrefix(*field, sealedPrefix) {
+			plaintext = true
+		}
+		if *field, err = c.Open(*field); err != nil {
+			return false, err
+		}
+	}
+	return plaintext, nil
+}
+
+// deriveKey derives a purpose-specific key from the master key
+func deriveKey(key []byte, purpose string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("health-dashboard store " + purpose))
+	return mac.Sum(nil)
+}
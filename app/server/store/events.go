@@ -0,0 +1,203 @@
+// app/server/store/events.go
+package store
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxEventsPerCluster bounds the number of events kept for each cluster
+const maxEventsPerCluster = 5000
+
+// eventCompactionSlack is how many records an event log may hold past
+// maxEventsPerCluster before it is compacted, so appends rarely rewrite it
+const eventCompactionSlack = maxEventsPerCluster / 10
+
+// ClusterEvent is a notable change observed on a live cluster
+type ClusterEvent struct {
+	Time     time.Time `json:"time"`
+	Cluster  string    `json:"cluster"`
+	Kind     string    `json:"kind"`
+	Object   string    `json:"object"`
+	Reason   string    `json:"reason"`
+	Message  string    `json:"message"`
+	Severity string    `json:"severity"`
+}
+
+// AppendEvent records a cluster event
+func (s *Store) AppendEvent(event ClusterEvent) error {
+	data, err := s.encodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.ToLower(event.Cluster)
+	if err := s.loadEventsLocked(key); err != nil {
+		return err
+	}
+
+	if err := s.records.AppendEvents(s.eventsName(key), [][]byte{data}); err != nil {
+		return err
+	}
+
+	events := append(s.events[key], event)
+	if len(events) > maxEventsPerCluster {
+		events = events[len(events)-maxEventsPerCluster:]
+	}
+	s.events[key] = events
+
+	s.stored[key]++
+	if s.stored[key] > maxEventsPerCluster+eventCompactionSlack {
+		return s.compactEventsLocked(key)
+	}
+	return nil
+}
+
+// ListEvents returns the events recorded for a cluster, oldest first
+func (s *Store) ListEvents(cluster string) ([]ClusterEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := strings.ToLower(cluster)
+	if err := s.loadEventsLocked(key); err != nil {
+		return nil, err
+	}
+
+	events := make([]ClusterEvent, len(s.events[key]))
+	copy(events, s.events[key])
+	return events, nil
+}
+
+// loadEventsLocked reads a cluster's event log into memory on first use; key is the
+// lower-cased cluster name and s.mu must be held
+func (s *Store) loadEventsLocked(cluster string) error {
+	if _, loaded := s.events[cluster]; loaded {
+		return nil
+	}
+
+	if err := s.migrateEventsLocked(cluster); err != nil {
+		return err
+	}
+
+	events, err := s.readEvents(s.eventsName(cluster))
+	if err != nil {
+		return err
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	s.stored[cluster] = len(events)
+	if len(events) > maxEventsPerCluster {
+		events = events[len(events)-maxEventsPerCluster:]
+	}
+
+	s.events[cluster] = events
+	if s.stored[cluster] > maxEventsPerCluster {
+		return s.compactEventsLocked(cluster)
+	}
+	return nil
+}
+
+// compactEventsLocked rewrites a cluster's event log with the events kept in
+// memory, dropping the older ones; s.mu must be held
+func (s *Store) compactEventsLocked(cluster string) error {
+	events := s.events[cluster]
+	records := make([][]byte, 0, len(events))
+	for _, event := range events {
+		data, err := s.encodeEvent(event)
+		if err != nil {
+			return err
+		}
+		records = append(records, data)
+	}
+	if err := s.records.ReplaceEvents(s.eventsName(cluster), records); err != nil {
+		return err
+	}
+
+	log.Printf("Compacted an event log from %d to %d events", s.stored[cluster], len(records))
+	s.stored[cluster] = len(records)
+	return nil
+}
+
+// encodeEvent encodes an event record, encrypting the cluster name when a
+// cipher is set
+func (s *Store) encodeEvent(event ClusterEvent) ([]byte, error) {
+	if s.cipher != nil {
+		event.Cluster = s.cipher.Seal(event.Cluster)
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding event: %w", err)
+	}
+	return data, nil
+}
+
+// readEvents reads an event log, decrypting the cluster names
+func (s *Store) readEvents(name string) ([]ClusterEvent, error) {
+	records, err := s.records.LoadEvents(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []ClusterEvent
+	for _, data := range records {
+		var event ClusterEvent
+		if err := json.Unmarshal(data, &event); err != nil {
+			log.Printf("Skipping invalid event in %s: %v", name, err)
+			continue
+		}
+		if s.cipher != nil {
+			if event.Cluster, err = s.cipher.Open(event.Cluster); err != nil {
+				return nil, fmt.Errorf("error decrypting event log %s, check the encryption key: %w", name, err)
+			}
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// migrateEventsLocked appends a cluster's plaintext event log, written before
+// encryption was enabled, to its encrypted log and removes it; s.mu must be held
+func (s *Store) migrateEventsLocked(cluster string) error {
+	if s.cipher == nil {
+		return nil
+	}
+
+	events, err := s.readEvents(cluster)
+	if err != nil || len(events) == 0 {
+		return err
+	}
+
+	records := make([][]byte, 0, len(events))
+	for _, event := range events {
+		data, err := s.encodeEvent(event)
+		if err != nil {
+			return err
+		}
+		records = append(records, data)
+	}
+	if err := s.records.AppendEvents(s.eventsName(cluster), records); err != nil {
+		return err
+	}
+
+	log.Printf("Encrypted %d events of a cluster written without encryption", len(events))
+	return s.records.DeleteEvents(cluster)
+}
+
+// eventsName returns the name the event log of a lower-cased cluster name is
+// stored under; with a cipher, the log is named after the encrypted name
+func (s *Store) eventsName(cluster string) string {
+	if s.cipher != nil {
+		return s.cipher.Seal(cluster)
+	}
+	return cluster
+}
@@ -0,0 +1,192 @@
+// app/server/store/records.go
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// Records persists report records and cluster event logs as opaque JSON
+// documents; the Store keeps everything in memory and writes through
+type Records interface {
+	// LoadReports returns every stored report record
+	LoadReports() ([][]byte, error)
+
+	// SaveReport creates or replaces the record of a report
+	SaveReport(id string, data []byte) error
+
+	// DeleteReport removes the record of a report; a missing record is not an error
+	DeleteReport(id string) error
+
+	// LoadEvents returns the event records stored under a name, oldest first
+	LoadEvents(name string) ([][]byte, error)
+
+	// AppendEvents adds event records under a name
+	AppendEvents(name string, events [][]byte) error
+
+	// ReplaceEvents replaces every event record stored under a name at once
+	ReplaceEvents(name string, events [][]byte) error
+
+	// DeleteEvents removes every event record stored under a name
+	DeleteEvents(name string) error
+
+	// Ping checks that records can still be written
+	Ping(ctx context.Context) error
+
+	// Location describes where the records are kept, for log messages
+	Location() string
+}
+
+// unsafeFileChars matches characters that are not allowed in event file names
+var unsafeFileChars = regexp.MustCompile(`[^a-zA-Z0-9_.-]+`)
+
+// FileRecords keeps one JSON file per report and one JSON lines file per event log
+type FileRecords struct {
+	dir string
+}
+
+// NewFileRecords creates the record layout below dir
+func NewFileRecords(dir string) (*FileRecords, error) {
+	for _, sub := range []string{"reports", "events"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0o755); err != nil {
+			return nil, fmt.Errorf("error creating store directory: %w", err)
+		}
+	}
+	return &FileRecords{dir: dir}, nil
+}
+
+// LoadReports reads every report file, skipping unreadable ones
+func (f *FileRecords) LoadReports() ([][]byte, error) {
+	entries, err := os.ReadDir(filepath.Join(f.dir, "reports"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading store directory: %w", err)
+	}
+
+	var records [][]byte
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(f.dir, "reports", entry.Name()))
+		if err != nil {
+			log.Printf("Skipping unreadable report %s: %v", entry.Name(), err)
+			continue
+		}
+		records = append(records, data)
+	}
+	return records, nil
+}
+
+// SaveReport writes a report file
+func (f *FileRecords) SaveReport(id string, data []byte) error {
+	// Write to a temp file first so a crash never leaves a truncated record
+	path := filepath.Join(f.dir, "reports", id+".json")
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("error writing report: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("error writing report: %w", err)
+	}
+	return nil
+}
+
+// DeleteReport removes a report file
+func (f *FileRecords) DeleteReport(id string) error {
+	if err := os.Remove(filepath.Join(f.dir, "reports", id+".json")); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing report: %w", err)
+	}
+	return nil
+}
+
+// LoadEvents reads an event log; a missing log has no events
+func (f *FileRecords) LoadEvents(name string) ([][]byte, error) {
+	data, err := os.ReadFile(f.eventsPath(name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error opening event log: %w", err)
+	}
+
+	var records [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if len(bytes.TrimSpace(scanner.Bytes())) > 0 {
+			records = append(records, bytes.Clone(scanner.Bytes()))
+		}
+	}
+	return records, scanner.Err()
+}
+
+// AppendEvents appends lines to an event log
+func (f *FileRecords) AppendEvents(name string, events [][]byte) error {
+	file, err := os.OpenFile(f.eventsPath(name), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("error opening event log: %w", err)
+	}
+	defer file.Close()
+
+	for _, event := range events {
+		if _, err := file.Write(append(event, '\n')); err != nil {
+			return fmt.Errorf("error writing event: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReplaceEvents rewrites an event log
+func (f *FileRecords) ReplaceEvents(name string, events [][]byte) error {
+	var buf bytes.Buffer
+	for _, event := range events {
+		buf.Write(event)
+		buf.WriteByte('\n')
+	}
+
+	// Write to a temp file first so a crash never leaves a truncated log
+	path := f.eventsPath(name)
+	if err := os.WriteFile(path+".tmp", buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("error writing event log: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("error writing event log: %w", err)
+	}
+	return nil
+}
+
+// DeleteEvents removes an event log
+func (f *FileRecords) DeleteEvents(name string) error {
+	if err := os.Remove(f.eventsPath(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("error removing event log: %w", err)
+	}
+	return nil
+}
+
+// Ping writes and removes a probe file, which catches full and read-only volumes
+func (f *FileRecords) Ping(ctx context.Context) error {
+	path := filepath.Join(f.dir, "reports", ".ping")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		return fmt.Errorf("store directory is not writable: %w", err)
+	}
+	return os.Remove(path)
+}
+
+// Location returns the store directory
+func (f *FileRecords) Location() string {
+	return f.dir
+}
+
+// eventsPath returns the event log file for a name
+func (f *FileRecords) eventsPath(name string) string {
+	file := unsafeFileChars.ReplaceAllString(name, "_")
+	if file == "" {
+		file = "_unknown"
+	}
+	return filepath.Join(f.dir, "events", file+".jsonl")
+}
@@ -0,0 +1,151 @@
+// app/server/store/sqlrecords.go
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// sqlSchema creates the tables of the SQL record store
+const sqlSchema = `
+CREATE TABLE IF NOT EXISTS reports (
+	id   TEXT PRIMARY KEY,
+	data BLOB NOT NULL
+);
+CREATE TABLE IF NOT EXISTS events (
+	seq  INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	data BLOB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS events_name ON events (name, seq);
+`
+
+// SQLRecords keeps report and event records in a SQL database, for
+// deployments that run from a single file instead of a directory tree
+type SQLRecords struct {
+	db       *sql.DB
+	location string
+}
+
+// NewSQLRecords creates the record tables in db if needed; location
+// describes the database in log messages
+func NewSQLRecords(db *sql.DB, location string) (*SQLRecords, error) {
+	if _, err := db.Exec(sqlSchema); err != nil {
+		return nil, fmt.Errorf("error creating store tables: %w", err)
+	}
+	return &SQLRecords{db: db, location: location}, nil
+}
+
+// LoadReports reads every report row
+func (q *SQLRecords) LoadReports() ([][]byte, error) {
+	rows, err := q.db.Query(`SELECT data FROM reports`)
+	if err != nil {
+		return nil, fmt.Errorf("error reading reports: %w", err)
+	}
+	return scanRecords(rows)
+}
+
+// SaveReport inserts or replaces a report row
+func (q *SQLRecords) SaveReport(id string, data []byte) error {
+	_, err := q.db.Exec(`INSERT INTO reports (id, data) VALUES (?, ?)
+		ON CONFLICT (id) DO UPDATE SET data = excluded.data`, id, data)
+	if err != nil {
+		return fmt.Errorf("error writing report: %w", err)
+	}
+	return nil
+}
+
+// DeleteReport removes a report row
+func (q *SQLRecords) DeleteReport(id string) error {
+	if _, err := q.db.Exec(`DELETE FROM reports WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("error removing report: %w", err)
+	}
+	return nil
+}
+
+// Ping checks the database connection
+func (q *SQLRecords) Ping(ctx context.Context) error {
+	if err := q.db.PingContext(ctx); err != nil {
+		return fmt.Errorf("error connecting to the database: %w", err)
+	}
+	return nil
+}
+
+// LoadEvents reads the event rows stored under a name in insertion order
+func (q *SQLRecords) LoadEvents(name string) ([][]byte, error) {
+	rows, err := q.db.Query(`SELECT data FROM events WHERE name = ? ORDER BY seq`, name)
+	if err != nil {
+		return nil, fmt.Errorf("error reading events: %w", err)
+	}
+	return scanRecords(rows)
+}
+
+// AppendEvents inserts event rows in a single transaction
+func (q *SQLRecords) AppendEvents(name string, events [][]byte) error {
+	tx, err := q.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error writing event: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, event := range events {
+		if _, err := tx.Exec(`INSERT INTO events (name, data) VALUES (?, ?)`, name, event); err != nil {
+			return fmt.Errorf("error writing event: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error writing event: %w", err)
+	}
+	return nil
+}
+
+// ReplaceEvents replaces the event rows stored under a name in a single transaction
+func (q *SQLRecords) ReplaceEvents(name string, events [][]byte) error {
+	tx, err := q.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error writing event: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM events WHERE name = ?`, name); err != nil {
+		return fmt.Errorf("error removing events: %w", err)
+	}
+	for _, event := range events {
+		if _, err := tx.Exec(`INSERT INTO events (name, data) VALUES (?, ?)`, name, event); err != nil {
+			return fmt.Errorf("error writing event: %w", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error writing event: %w", err)
+	}
+	return nil
+}
+
+// DeleteEvents removes the event rows stored under a name
+func (q *SQLRecords) DeleteEvents(name string) error {
+	if _, err := q.db.Exec(`DELETE FROM events WHERE name = ?`, name); err != nil {
+		return fmt.Errorf("error removing events: %w", err)
+	}
+	return nil
+}
+
+// Location returns the database description
+func (q *SQLRecords) Location() string {
+	return q.location
+}
+
+// scanRecords collects the single data column of each row
+func scanRecords(rows *sql.Rows) ([][]byte, error) {
+	defer rows.Close()
+
+	var records [][]byte
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("error reading record: %w", err)
+		}
+		records = append(records, data)
+	}
+	return records, rows.Err()
+}
@@ -0,0 +1,464 @@
+// app/server/store/store.go
+package store
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/blob"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/signing"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/version"
+)
+
+// ErrNotFound is returned when a report does not exist in the store
+var ErrNotFound = errors.New("report not found")
+
+// Report is a parsed report together with its storage metadata
+type Report struct {
+	ID         string               `json:"id"`
+	Filename   string               `json:"filename"`
+	UploadedAt time.Time            `json:"uploadedAt"`
+	UpdatedAt  *time.Time           `json:"updatedAt,omitempty"`
+	Summary    *types.ReportSummary `json:"summary"`
+
+	// Cluster is the registered cluster the report belongs to, if any
+	Cluster string `json:"cluster,omitempty"`
+
+	// Labels are the labels set through the API; they override the labels of
+	// the cluster and of the document
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// RawKey is the blob key of the uploaded document
+	RawKey string `json:"rawKey,omitempty"`
+
+	// Scope is the namespace scope of the live scan that produced the report,
+	// nil for uploads and unscoped scans
+	Scope *types.ScanScope `json:"scope,omitempty"`
+
+	// MergedFrom lists the reports a consolidated report was merged from
+	MergedFrom []string `json:"mergedFrom,omitempty"`
+
+	// Attachments are the files other than AsciiDoc documents of the bundle
+	// the report was uploaded in
+	Attachments []Attachment `json:"attachments,omitempty"`
+
+	// JiraIssues maps the dedupe key of each exported item to its Jira issue key
+	JiraIssues map[string]string `json:"jiraIssues,omitempty"`
+
+	// GitExport records the last commit of the summary to the GitOps repository
+	GitExport *GitExport `json:"gitExport,omitempty"`
+
+	// ConfluencePage records the page the executive summary was last published to
+	ConfluencePage *ConfluencePage `json:"confluencePage,omitempty"`
+
+	// Review is set when the report was held for sign-off before notifications and exports
+	Review *Review `json:"review,omitempty"`
+
+	// Archive is set while the report is archived
+	Archive *Archive `json:"archive,omitempty"`
+
+	// Signature records the verification of the detached signature uploaded
+	// with the document, if there was one
+	Signature *signing.Verification `json:"signature,omitempty"`
+
+	// Imported holds findings imported from other systems, such as Insights,
+	// by source; they survive re-parsing the document
+	Imported map[string]*Import `json:"imported,omitempty"`
+
+	// Migrations lists the one-time data migrations applied to the record
+	Migrations []string `json:"migrations,omitempty"`
+
+	// ServerVersion is the version of the server that last parsed the
+	// document; records without one predate this field
+	ServerVersion string `json:"serverVersion,omitempty"`
+}
+
+// GitExport records the commit a summary was published to a Git repository in
+type GitExport struct {
+	Commit     string    `json:"commit"`
+	ExportedAt time.Time `json:"exportedAt"`
+}
+
+// ConfluencePage records a version of the Confluence page of a cluster
+type ConfluencePage struct {
+	ID          string    `json:"id"`
+	Version     int       `json:"version"`
+	URL         string    `json:"url"`
+	PublishedAt time.Time `json:"publishedAt"`
+}
+
+// Migrated reports whether the named migration was applied to the report
+func (r *Report) Migrated(name string) bool {
+	for _, applied := range r.Migrations {
+		if applied == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Review statuses
+const (
+	ReviewPending  = "pending"
+	ReviewApproved = "approved"
+)
+
+// Review records the sign-off of a report held by the review gate
+type Review struct {
+	Status string `json:"status"`
+
+	// HeldEvent is the notification withheld until the report is approved
+	HeldEvent string `json:"heldEvent,omitempty"`
+
+	Reviewer   string     `json:"reviewer,omitempty"`
+	Comment    string     `json:"comment,omitempty"`
+	ReviewedAt *time.Time `json:"reviewedAt,omitempty"`
+}
+
+// Import is a set of findings imported from another system
+type Import struct {
+	ImportedAt time.Time       `json:"importedAt"`
+	Findings   []types.Finding `json:"findings"`
+
+	// Scores replace category scores of the summary, by score field such as scoreCompliance
+	Scores map[string]int `json:"scores,omitempty"`
+}
+
+// AwaitingReview reports whether the report is held until a reviewer approves it
+func (r *Report) AwaitingReview() bool {
+	return r.Review != nil && r.Review.Status == ReviewPending
+}
+
+// ClusterKey returns the name the report is grouped under: the registered
+// cluster when known, otherwise the name extracted from the document
+func (r *Report) ClusterKey() string {
+	if r.Cluster != "" {
+		return r.Cluster
+	}
+	return r.Summary.ClusterName
+}
+
+// LastModified returns when the report was last written, for HTTP caching
+func (r *Report) LastModified() time.Time {
+	if r.UpdatedAt != nil {
+		return *r.UpdatedAt
+	}
+	return r.UploadedAt
+}
+
+// Store keeps parsed reports in memory, persisting their records through a
+// Records implementation and their raw documents in a blob backend
+type Store struct {
+	records Records
+	blobs   blob.Backend
+	cold    blob.Backend
+	cipher  *Cipher
+	mu      sync.RWMutex
+	reports map[string]*Report
+	events  map[string][]ClusterEvent
+
+	// stored counts the records of each loaded event log, which grows past
+	// the events kept in memory until it is compacted
+	stored map[string]int
+
+	// archiving holds the reports being archived or restored, so only one
+	// request moves the raw document of a report at a time
+	archiving map[string]bool
+}
+
+// New creates a store and loads any previously saved reports; with a cipher,
+// identifying fields are encrypted in the records
+func New(records Records, blobs blob.Backend, cipher *Cipher) (*Store, error) {
+	s := &Store{
+		records: records,
+		blobs:   blobs,
+		cipher:  cipher,
+		reports: make(map[string]*Report),
+		events:  make(map[string][]ClusterEvent),
+		stored:  make(map[string]int),
+
+		archiving: make(map[string]bool),
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// load reads all report records into memory
+func (s *Store) load() error {
+	records, err := s.records.LoadReports()
+	if err != nil {
+		return err
+	}
+
+	var plaintext []*Report
+
+	for _, data := range records {
+		var report Report
+		if err := json.Unmarshal(data, &report); err != nil {
+			log.Printf("Skipping invalid report record: %v", err)
+			continue
+		}
+
+		if s.cipher != nil {
+			unsealed, err := s.cipher.openReport(&report)
+			if err != nil {
+				// Skipping would silently hide every report after a key change
+				return fmt.Errorf("error decrypting report %s, check the encryption key: %w", report.ID, err)
+			}
+			if unsealed {
+				plaintext = append(plaintext, &report)
+			}
+		}
+		s.reports[report.ID] = &report
+	}
+
+	log.Printf("Loaded %d stored reports from %s", len(s.reports), s.records.Location())
+
+	// Encrypt records written before encryption was enabled
+	if len(plaintext) > 0 {
+		log.Printf("Encrypting %d stored reports written without encryption", len(plaintext))
+		for _, report := range plaintext {
+			if err := s.write(report); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Create stores a newly parsed report whose raw document was already written
+// to its RawKey, with everything known about it at upload, so the record is
+// written once; UploadedAt defaults to now
+func (s *Store) Create(report *Report) (*Report, error) {
+	created := *report
+	if created.UploadedAt.IsZero() {
+		created.UploadedAt = time.Now().UTC()
+	}
+	created.ServerVersion = version.Version
+	created.Summary.ReportID = created.ID
+
+	if err := s.Update(&created); err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+// Update persists a report record. The store keeps the report, so it must be
+// a new value, not one handed out by Get or List; use Mutate to change a
+// stored report.
+func (s *Store) Update(report *Report) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.updateLocked(report)
+}
+
+// Mutate changes a stored report and saves it. change edits a copy of the
+// current version, which replaces the stored report once it is saved, so
+// readers holding the old one never see a partial change. Nothing is saved
+// when change returns an error. The summary is shared with the old version:
+// change must replace it rather than edit it.
+func (s *Store) Mutate(id string, change func(*Report) error) (*Report, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	existing, ok := s.reports[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	updated := existing.clone()
+	if err := change(updated); err != nil {
+		return nil, err
+	}
+	if err := s.updateLocked(updated); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// updateLocked saves a report record and makes it the stored version; s.mu
+// must be held
+func (s *Store) updateLocked(report *Report) error {
+	now := time.Now().UTC()
+	report.UpdatedAt = &now
+
+	if err := s.write(report); err != nil {
+		return err
+	}
+
+	s.reports[report.ID] = report
+	return nil
+}
+
+// clone copies a report with its maps, slices and records, so editing the
+// copy leaves the original as it was; the summary is not copied
+func (r *Report) clone() *Report {
+	c := *r
+	c.Labels = cloneMap(r.Labels)
+	c.JiraIssues = cloneMap(r.JiraIssues)
+	c.Imported = cloneMap(r.Imported)
+	c.MergedFrom = cloneSlice(r.MergedFrom)
+	c.Attachments = cloneSlice(r.Attachments)
+	c.Migrations = cloneSlice(r.Migrations)
+	c.UpdatedAt = clonePointer(r.UpdatedAt)
+	c.Scope = clonePointer(r.Scope)
+	c.GitExport = clonePointer(r.GitExport)
+	c.ConfluencePage = clonePointer(r.ConfluencePage)
+	c.Review = clonePointer(r.Review)
+	c.Archive = clonePointer(r.Archive)
+	c.Signature = clonePointer(r.Signature)
+	return &c
+}
+
+// cloneMap copies a map, keeping nil maps nil
+func cloneMap[K comparable, V any](m map[K]V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	c := make(map[K]V, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// cloneSlice copies a slice, keeping nil slices nil
+func cloneSlice[T any](s []T) []T {
+	if s == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(s)), s...)
+}
+
+// clonePointer copies the value a pointer points to, keeping nil pointers nil
+func clonePointer[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	c := *p
+	return &c
+}
+
+// write saves a report record, encrypting its identifying fields when a cipher is set
+func (s *Store) write(report *Report) error {
+	record := report
+	if s.cipher != nil {
+		record = s.cipher.sealReport(report)
+	}
+
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding report: %w", err)
+	}
+
+	return s.records.SaveReport(report.ID, data)
+}
+
+// Delete removes a report record with its raw document and attachments
+func (s *Store) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	report, ok := s.reports[id]
+	if !ok {
+		s.mu.Unlock()
+		return ErrNotFound
+	}
+	if err := s.records.DeleteReport(id); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	delete(s.reports, id)
+	blobs, key := s.rawBackend(report)
+	s.mu.Unlock()
+
+	if err := blobs.Delete(ctx, key); err != nil {
+		// The record is gone, so the document is only wasted space now
+		log.Printf("Error removing raw document of report %s: %v", id, err)
+	}
+	s.DeleteAttachments(ctx, id, report.Attachments)
+	return nil
+}
+
+// pingKey is the blob written by Ping
+const pingKey = "health/ping"
+
+// Ping checks that the records and the blob backend accept writes
+func (s *Store) Ping(ctx context.Context) error {
+	if err := s.records.Ping(ctx); err != nil {
+		return err
+	}
+	if _, err := s.blobs.Put(ctx, pingKey, strings.NewReader("ok")); err != nil {
+		return fmt.Errorf("blob backend is not writable: %w", err)
+	}
+	return s.blobs.Delete(ctx, pingKey)
+}
+
+// Get returns the report with the given ID
+func (s *Store) Get(id string) (*Report, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	report, ok := s.reports[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return report, nil
+}
+
+// List returns all stored reports, newest first
+func (s *Store) List() []*Report {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	reports := make([]*Report, 0, len(s.reports))
+	for _, report := range s.reports {
+		reports = append(reports, report)
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].UploadedAt.After(reports[j].UploadedAt)
+	})
+	return reports
+}
+
+// OpenRaw returns a reader for the raw document a report was parsed from
+func (s *Store) OpenRaw(ctx context.Context, id string) (io.ReadCloser, error) {
+	s.mu.RLock()
+	report, ok := s.reports[id]
+	if !ok {
+		s.mu.RUnlock()
+		return nil, ErrNotFound
+	}
+	blobs, key := s.rawBackend(report)
+	s.mu.RUnlock()
+
+	return blobs.Open(ctx, key)
+}
+
+// RawKey returns the blob key for the raw document of a report
+func RawKey(id, ext string) string {
+	return "raw/" + id + ext
+}
+
+// NewID generates a sortable, unique report ID
+func NewID() string {
+	buf := make([]byte, 4)
+	rand.Read(buf)
+	return strings.ToLower(time.Now().UTC().Format("20060102t150405")) + "-" + hex.EncodeToString(buf)
+}
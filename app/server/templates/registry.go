@@ -0,0 +1,278 @@
+// app/server/templates/registry.go
+
+// Package templates manages template packs: named sets of parser profiles
+// and report templates that consulting practices upload at runtime. At most
+// one pack is active; its parser profiles add to those of the settings file
+// and its templates replace the built-in document and page templates.
+package templates
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/blob"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/integrations/confluence"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scanner"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// key is the blob holding the packs, so every replica sharing the blob
+// backend sees the same packs
+const key = "templates/packs.json"
+
+var (
+	// ErrNotFound is returned when a pack does not exist
+	ErrNotFound = errors.New("template pack not found")
+
+	// ErrActive is returned when deleting the active pack
+	ErrActive = errors.New("template pack is active")
+)
+
+// namePattern restricts pack names to values that are safe in URLs
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// Pack is a named set of parser profiles and report templates
+type Pack struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// ParserProfiles are the report dialects of the practice
+	ParserProfiles []utils.ParserProfile `json:"parserProfiles,omitempty"`
+
+	// Document is the text/template live scans are rendered with, see
+	// scanner.ParseTemplate; empty keeps the built-in AsciiDoc document
+	Document string `json:"document,omitempty"`
+
+	// Confluence is the storage-format body of published pages; empty keeps
+	// the configured page template
+	Confluence string `json:"confluence,omitempty"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Validate checks the name, the parser profiles and that the templates parse
+func (p *Pack) Validate() error {
+	if !namePattern.MatchString(p.Name) {
+		return fmt.Errorf("invalid template pack name %q: use lowercase letters, digits and dashes", p.Name)
+	}
+
+	seen := make(map[string]bool)
+	for i := range p.ParserProfiles {
+		profile := &p.ParserProfiles[i]
+		if err := profile.Validate(); err != nil {
+			return err
+		}
+		if seen[profile.Name] {
+			return fmt.Errorf("parser profile %q is defined twice", profile.Name)
+		}
+		seen[profile.Name] = true
+	}
+
+	if p.Document != "" {
+		if _, err := scanner.ParseTemplate(p.Document); err != nil {
+			return err
+		}
+	}
+	if p.Confluence != "" {
+		if err := confluence.ValidateTemplate(p.Confluence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// state is the stored form of the registry
+type state struct {
+	Active string  `json:"active,omitempty"`
+	Packs  []*Pack `json:"packs"`
+}
+
+// Registry persists the template packs in the blob backend
+type Registry struct {
+	blobs blob.Backend
+
+	mu     sync.RWMutex
+	packs  map[string]*Pack
+	active string
+}
+
+// New loads the packs stored in the blob backend
+func New(ctx context.Context, blobs blob.Backend) (*Registry, error) {
+	r := &Registry{blobs: blobs}
+	if err := r.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload re-reads the packs, replacing the registry contents only if they parse
+func (r *Registry) Reload(ctx context.Context) error {
+	var stored state
+	reader, err := r.blobs.Open(ctx, key)
+	if err != nil && !errors.Is(err, blob.ErrNotFound) {
+		return fmt.Errorf("error reading template packs: %w", err)
+	}
+	if err == nil {
+		data, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			return fmt.Errorf("error reading template packs: %w", err)
+		}
+		if err := json.Unmarshal(data, &stored); err != nil {
+			return fmt.Errorf("error parsing template packs: %w", err)
+		}
+	}
+
+	packs := make(map[string]*Pack, len(stored.Packs))
+	for _, pack := range stored.Packs {
+		packs[pack.Name] = pack
+	}
+	if stored.Active != "" && packs[stored.Active] == nil {
+		log.Printf("Active template pack %s no longer exists, using the built-in templates", stored.Active)
+		stored.Active = ""
+	}
+	if len(packs) > 0 {
+		log.Printf("Loaded %d template packs, active: %q", len(packs), stored.Active)
+	}
+
+	r.mu.Lock()
+	r.packs = packs
+	r.active = stored.Active
+	r.mu.Unlock()
+	return nil
+}
+
+// List returns all packs ordered by name
+func (r *Registry) List() []Pack {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	packs := make([]Pack, 0, len(r.packs))
+	for _, pack := range r.packs {
+		packs = append(packs, *pack)
+	}
+	sort.Slice(packs, func(i, j int) bool { return packs[i].Name < packs[j].Name })
+	return packs
+}
+
+// Get returns a pack by name
+func (r *Registry) Get(name string) (Pack, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pack, ok := r.packs[name]
+	if !ok {
+		return Pack{}, ErrNotFound
+	}
+	return *pack, nil
+}
+
+// Put creates a pack or replaces the one with the same name; replacing the
+// active pack takes effect right away
+func (r *Registry) Put(ctx context.Context, pack Pack) (Pack, bool, error) {
+	if err := pack.Validate(); err != nil {
+		return Pack{}, false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now().UTC()
+	existing, replaced := r.packs[pack.Name]
+	pack.CreatedAt, pack.UpdatedAt = now, now
+	if replaced {
+		pack.CreatedAt = existing.CreatedAt
+	}
+
+	r.packs[pack.Name] = &pack
+	if err := r.saveLocked(ctx); err != nil {
+		if replaced {
+			r.packs[pack.Name] = existing
+		} else {
+			delete(r.packs, pack.Name)
+		}
+		return Pack{}, false, err
+	}
+	return pack, replaced, nil
+}
+
+// Delete removes a pack; the active pack has to be deactivated first
+func (r *Registry) Delete(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.packs[name]
+	if !ok {
+		return ErrNotFound
+	}
+	if r.active == name {
+		return ErrActive
+	}
+
+	delete(r.packs, name)
+	if err := r.saveLocked(ctx); err != nil {
+		r.packs[name] = existing
+		return err
+	}
+	return nil
+}
+
+// Activate makes a pack the active one; an empty name goes back to the
+// built-in templates
+func (r *Registry) Activate(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if name != "" && r.packs[name] == nil {
+		return ErrNotFound
+	}
+
+	previous := r.active
+	r.active = name
+	if err := r.saveLocked(ctx); err != nil {
+		r.active = previous
+		return err
+	}
+	return nil
+}
+
+// Active returns the active pack, or nil when the built-in templates are used
+func (r *Registry) Active() *Pack {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	pack, ok := r.packs[r.active]
+	if !ok {
+		return nil
+	}
+	copied := *pack
+	return &copied
+}
+
+// saveLocked writes the packs to the blob backend; callers hold the lock
+func (r *Registry) saveLocked(ctx context.Context) error {
+	stored := state{Active: r.active, Packs: make([]*Pack, 0, len(r.packs))}
+	for _, pack := range r.packs {
+		stored.Packs = append(stored.Packs, pack)
+	}
+	sort.Slice(stored.Packs, func(i, j int) bool { return stored.Packs[i].Name < stored.Packs[j].Name })
+
+	data, err := json.MarshalIndent(stored, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding template packs: %w", err)
+	}
+	if _, err := r.blobs.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		return fmt.Errorf("error saving template packs: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// app/server/tracing/tracing.go
+
+// Package tracing instruments the server with OpenTelemetry spans. Spans are
+// always created, but are only recorded and exported over OTLP once Setup ran
+// with an endpoint; until then the global no-op provider makes them free.
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentation names the tracer of the dashboard's own spans
+const instrumentation = "github.com/ayaseen/openshift-health-dashboard"
+
+// DefaultServiceName is reported when no service name is configured
+const DefaultServiceName = "openshift-health-dashboard"
+
+// Config holds the OTLP export settings
+type Config struct {
+	// Endpoint is the OTLP/HTTP collector URL, e.g. http://otel-collector:4318,
+	// to which /v1/traces is added when it has no path; tracing is disabled
+	// without one
+	Endpoint string
+
+	// ServiceName identifies the dashboard in the tracing backend
+	ServiceName string
+
+	// SampleRatio is the fraction of new traces recorded, from 0 to 1; traces
+	// started by an upstream service follow its sampling decision
+	SampleRatio float64
+}
+
+// Enabled reports whether spans are exported
+func (c Config) Enabled() bool {
+	return c.Endpoint != ""
+}
+
+// Setup installs the global tracer provider exporting to the configured
+// endpoint and the W3C trace context propagator. The returned function flushes
+// pending spans and must be called on shutdown.
+func Setup(ctx context.Context, config Config) (func(context.Context) error, error) {
+	if !config.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("sample ratio %g is not between 0 and 1", config.SampleRatio)
+	}
+	if config.ServiceName == "" {
+		config.ServiceName = DefaultServiceName
+	}
+
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "http" && endpoint.Scheme != "https") {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q", config.Endpoint)
+	}
+	if endpoint.Path == "" || endpoint.Path == "/" {
+		endpoint.Path = "/v1/traces"
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint.String()))
+	if err != nil {
+		return nil, fmt.Errorf("error creating OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(config.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("error describing the service: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// Start begins a span of the dashboard's own work
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentation).Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+// End finishes a span, marking it failed when err is set. Cancellations are
+// recorded but don't fail the span, as the caller gave up rather than the work.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		if !errors.Is(err, context.Canceled) {
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}
+	span.End()
+}
+
+// Handler traces incoming requests, continuing traces started by the caller
+func Handler(next http.Handler) http.Handler {
+	return otelhttp.NewHandler(next, "http.server",
+		otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string {
+			// Routers rename the span to their route pattern once it is known
+			return r.Method
+		}))
+}
+
+// Route names the span of the current request after the route pattern that
+// matched it, e.g. "GET /api/v1/reports/{id}"
+func Route(r *http.Request, route string) {
+	span := trace.SpanFromContext(r.Context())
+	span.SetName(r.Method + " " + route)
+	span.SetAttributes(semconv.HTTPRoute(route))
+}
+
+// Transport traces outgoing requests of an integration client and passes the
+// trace context on; a nil base uses the default transport
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return otelhttp.NewTransport(base)
+}
@@ -1,8 +1,11 @@
 // app/server/types/types.go
 package types
 
+import "time"
+
 // ReportSummary represents the extracted summary data from an AsciiDoc report
 type ReportSummary struct {
+	ReportID                 string   `json:"reportId,omitempty"`
 	ClusterName              string   `json:"clusterName"`
 	CustomerName             string   `json:"customerName"`
 	OverallScore             float64  `json:"overallScore"`
@@ -21,6 +24,157 @@ type ReportSummary struct {
 	ItemsAdvisory            []string `json:"itemsAdvisory"`
 	NoChangeCount            int      `json:"noChangeCount"`
 	NotApplicableCount       int      `json:"notApplicableCount"` // Added for tracking N/A items
+
+	// Grade and RAG are the letter grade and red/amber/green status of the
+	// overall score by the configured grade bands; set in API responses and
+	// exports, never stored
+	Grade string `json:"grade,omitempty"`
+	RAG   string `json:"rag,omitempty"`
+
+	// Categories lists every category of the report with its score; the five
+	// standard categories come first and mirror the score and description fields
+	Categories []CategoryScore `json:"categories,omitempty"`
+
+	// Labels are the labels listed in the labels attribute of the document
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// ScoringProfile names the scoring profile the scores were computed with
+	ScoringProfile string `json:"scoringProfile,omitempty"`
+
+	// Findings holds every evaluated item with the detail from its section in the document
+	Findings []Finding `json:"findings"`
+
+	// Extraction maps each score field to the parser strategy that produced it
+	Extraction map[string]string `json:"extraction,omitempty"`
+
+	// ParserProfile names the report dialect the document was parsed as
+	ParserProfile string `json:"parserProfile,omitempty"`
+
+	// Language is the language the descriptions were generated in
+	Language string `json:"language,omitempty"`
+
+	// Waived lists the findings suppressed by waivers; they are added to API
+	// responses, left out of Findings and the scores, and never stored
+	Waived []WaivedFinding `json:"waived,omitempty"`
+}
+
+// Finding is a single evaluated item from the summary table, enriched with the
+// observation, recommendation and references from its detail section
+type Finding struct {
+	ID             string    `json:"id"`
+	Title          string    `json:"title"`
+	Category       string    `json:"category"`
+	Status         ResultKey `json:"status"`
+	Severity       Severity  `json:"severity"`
+	Observation    string    `json:"observation"`
+	Recommendation string    `json:"recommendation,omitempty"`
+	References     []string  `json:"references,omitempty"`
+
+	// Impact and Effort are low, medium or high when the summary table has
+	// impact or effort columns
+	Impact string `json:"impact,omitempty"`
+	Effort string `json:"effort,omitempty"`
+
+	// Section locates the detail section in the source document, if there is one
+	Section *SectionRef `json:"section,omitempty"`
+
+	// Source names the system a finding was imported from, such as "insights";
+	// empty for findings of the report document
+	Source string `json:"source,omitempty"`
+
+	// Guidance is remediation guidance from the knowledge base; it is added to
+	// API responses and never stored with the report
+	Guidance *Guidance `json:"guidance,omitempty"`
+}
+
+// WaivedFinding is a finding suppressed by a waiver
+type WaivedFinding struct {
+	Finding
+	Waiver        string     `json:"waiver"`
+	Justification string     `json:"justification"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty"`
+}
+
+// Guidance describes how to remediate a known finding
+type Guidance struct {
+	Steps      []string `json:"steps"`
+	References []string `json:"references,omitempty"`
+
+	// Effort is the estimated effort: low, medium or high
+	Effort string `json:"effort,omitempty"`
+
+	// Remediation is true when suggested manifests or commands are available
+	// at /api/findings/{id}/remediation
+	Remediation bool `json:"remediation,omitempty"`
+}
+
+// Remediation is a suggested fix for a finding; the dashboard never applies it
+type Remediation struct {
+	// Title is the knowledge base entry the finding matched
+	Title     string     `json:"title"`
+	Manifests []Manifest `json:"manifests,omitempty"`
+
+	// Commands are oc commands to run in order, after applying the manifests
+	Commands []string `json:"commands,omitempty"`
+}
+
+// Manifest is a suggested YAML manifest
+type Manifest struct {
+	Description string `json:"description"`
+	YAML        string `json:"yaml"`
+}
+
+// SectionRef points at a section of the source document
+type SectionRef struct {
+	Anchor string `json:"anchor"`
+	Title  string `json:"title"`
+	Line   int    `json:"line"`
+}
+
+// Severity ranks findings for sorting and filtering
+type Severity string
+
+const (
+	// SeverityHigh is used for items where changes are required
+	SeverityHigh Severity = "high"
+
+	// SeverityMedium is used for items where changes are recommended
+	SeverityMedium Severity = "medium"
+
+	// SeverityLow is used for advisory items
+	SeverityLow Severity = "low"
+
+	// SeverityNone is used for items that need no action
+	SeverityNone Severity = "none"
+
+	// SeverityUnknown is used for items that have not been evaluated
+	SeverityUnknown Severity = "unknown"
+)
+
+// CategoryScore is the score of one report category together with the
+// number of its findings of each status
+type CategoryScore struct {
+	// Name is the value of the category column, such as "Cluster Config"
+	Name string `json:"name"`
+
+	// Label is the display name, such as "Infrastructure Setup"
+	Label string `json:"label"`
+
+	// Field names the legacy score field of a standard category; empty for
+	// custom categories
+	Field string `json:"field,omitempty"`
+
+	Score         int    `json:"score"`
+	Description   string `json:"description"`
+	Required      int    `json:"required"`
+	Recommended   int    `json:"recommended"`
+	Advisory      int    `json:"advisory"`
+	NoChange      int    `json:"noChange"`
+	NotApplicable int    `json:"notApplicable"`
+
+	// Grade and RAG grade the score like the overall score of the summary
+	Grade string `json:"grade,omitempty"`
+	RAG   string `json:"rag,omitempty"`
 }
 
 // Category represents a category in the health check report
@@ -72,3 +226,24 @@ const (
 	// ResultKeyEvaluate indicates the result needs evaluation
 	ResultKeyEvaluate ResultKey = "eval"
 )
+
+// ScanScope limits the workload-level checks of a live scan to some
+// namespaces; platform namespaces are never in scope
+type ScanScope struct {
+	// IncludeNamespaces are the namespaces, or glob patterns such as "team-*",
+	// the checks look at; empty includes all of them
+	IncludeNamespaces []string `json:"includeNamespaces,omitempty"`
+
+	// ExcludeNamespaces are the namespaces, or glob patterns, left out even
+	// when included
+	ExcludeNamespaces []string `json:"excludeNamespaces,omitempty"`
+
+	// NamespaceSelector is a label selector, such as "env!=sandbox", the
+	// labels of included namespaces must match
+	NamespaceSelector string `json:"namespaceSelector,omitempty"`
+}
+
+// Empty reports whether the scope covers every namespace
+func (s ScanScope) Empty() bool {
+	return len(s.IncludeNamespaces) == 0 && len(s.ExcludeNamespaces) == 0 && s.NamespaceSelector == ""
+}
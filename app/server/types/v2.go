@@ -0,0 +1,196 @@
+// app/server/types/v2.go
+package types
+
+import (
+	"sort"
+	"strings"
+)
+
+// ReportSummaryV2 is the v2 API form of a report summary, where the actionable
+// items are structured findings instead of "name: observation" strings
+type ReportSummaryV2 struct {
+	ReportID                 string    `json:"reportId,omitempty"`
+	ClusterName              string    `json:"clusterName"`
+	CustomerName             string    `json:"customerName"`
+	OverallScore             float64   `json:"overallScore"`
+	ScoreInfra               int       `json:"scoreInfra"`
+	ScoreGovernance          int       `json:"scoreGovernance"`
+	ScoreCompliance          int       `json:"scoreCompliance"`
+	ScoreMonitoring          int       `json:"scoreMonitoring"`
+	ScoreBuildSecurity       int       `json:"scoreBuildSecurity"`
+	InfraDescription         string    `json:"infraDescription"`
+	GovernanceDescription    string    `json:"governanceDescription"`
+	ComplianceDescription    string    `json:"complianceDescription"`
+	MonitoringDescription    string    `json:"monitoringDescription"`
+	BuildSecurityDescription string    `json:"buildSecurityDescription"`
+	ItemsRequired            []Finding `json:"itemsRequired"`
+	ItemsRecommended         []Finding `json:"itemsRecommended"`
+	ItemsAdvisory            []Finding `json:"itemsAdvisory"`
+	NoChangeCount            int       `json:"noChangeCount"`
+	NotApplicableCount       int       `json:"notApplicableCount"`
+
+	// Grade and RAG are the letter grade and red/amber/green status of the overall score
+	Grade string `json:"grade,omitempty"`
+	RAG   string `json:"rag,omitempty"`
+
+	// Categories lists every category of the report with its score
+	Categories []CategoryScore `json:"categories,omitempty"`
+
+	// FindingsByCategory groups the actionable findings by their category
+	FindingsByCategory []CategoryFindings `json:"findingsByCategory"`
+
+	// Extraction maps each score field to the parser strategy that produced it
+	Extraction map[string]string `json:"extraction,omitempty"`
+
+	// ParserProfile names the report dialect the document was parsed as
+	ParserProfile string `json:"parserProfile,omitempty"`
+
+	// Language is the language the descriptions were generated in
+	Language string `json:"language,omitempty"`
+
+	// Labels are the labels listed in the labels attribute of the document
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Waived lists the findings suppressed by waivers
+	Waived []WaivedFinding `json:"waived,omitempty"`
+}
+
+// CategoryFindings are the actionable findings of one category
+type CategoryFindings struct {
+	// Name is the value of the category column; empty for findings without one
+	Name string `json:"name"`
+
+	// Label is the display name of the category, like in Categories
+	Label string `json:"label"`
+
+	Required    []Finding `json:"required"`
+	Recommended []Finding `json:"recommended"`
+	Advisory    []Finding `json:"advisory"`
+}
+
+// V2 converts a summary to the v2 schema. Summaries stored before findings were
+// extracted get findings built from their item strings.
+func (s *ReportSummary) V2() *ReportSummaryV2 {
+	v2 := &ReportSummaryV2{
+		ReportID:                 s.ReportID,
+		ClusterName:              s.ClusterName,
+		CustomerName:             s.CustomerName,
+		OverallScore:             s.OverallScore,
+		ScoreInfra:               s.ScoreInfra,
+		ScoreGovernance:          s.ScoreGovernance,
+		ScoreCompliance:          s.ScoreCompliance,
+		ScoreMonitoring:          s.ScoreMonitoring,
+		ScoreBuildSecurity:       s.ScoreBuildSecurity,
+		InfraDescription:         s.InfraDescription,
+		GovernanceDescription:    s.GovernanceDescription,
+		ComplianceDescription:    s.ComplianceDescription,
+		MonitoringDescription:    s.MonitoringDescription,
+		BuildSecurityDescription: s.BuildSecurityDescription,
+		ItemsRequired:            []Finding{},
+		ItemsRecommended:         []Finding{},
+		ItemsAdvisory:            []Finding{},
+		NoChangeCount:            s.NoChangeCount,
+		NotApplicableCount:       s.NotApplicableCount,
+		Grade:                    s.Grade,
+		RAG:                      s.RAG,
+		Categories:               s.Categories,
+		Extraction:               s.Extraction,
+		ParserProfile:            s.ParserProfile,
+		Language:                 s.Language,
+		Labels:                   s.Labels,
+		Waived:                   s.Waived,
+	}
+
+	if len(s.Findings) == 0 {
+		v2.ItemsRequired = findingsFromItems(s.ItemsRequired, ResultKeyRequired, SeverityHigh)
+		v2.ItemsRecommended = findingsFromItems(s.ItemsRecommended, ResultKeyRecommended, SeverityMedium)
+		v2.ItemsAdvisory = findingsFromItems(s.ItemsAdvisory, ResultKeyAdvisory, SeverityLow)
+		v2.FindingsByCategory = groupByCategory(v2, s.Categories)
+		return v2
+	}
+
+	for _, finding := range s.Findings {
+		switch finding.Status {
+		case ResultKeyRequired:
+			v2.ItemsRequired = append(v2.ItemsRequired, finding)
+		case ResultKeyRecommended:
+			v2.ItemsRecommended = append(v2.ItemsRecommended, finding)
+		case ResultKeyAdvisory:
+			v2.ItemsAdvisory = append(v2.ItemsAdvisory, finding)
+		}
+	}
+	v2.FindingsByCategory = groupByCategory(v2, s.Categories)
+	return v2
+}
+
+// groupByCategory groups the actionable findings of a summary by category.
+// Categories come in the order of the summary's categories, then any others by
+// name, then the findings without a category; categories without actionable
+// findings are left out.
+func groupByCategory(v2 *ReportSummaryV2, categories []CategoryScore) []CategoryFindings {
+	groups := make(map[string]*CategoryFindings)
+	group := func(name string) *CategoryFindings {
+		if g, ok := groups[name]; ok {
+			return g
+		}
+		g := &CategoryFindings{Name: name, Label: name, Required: []Finding{}, Recommended: []Finding{}, Advisory: []Finding{}}
+		if name == "" {
+			g.Label = "Uncategorized"
+		}
+		groups[name] = g
+		return g
+	}
+	for _, finding := range v2.ItemsRequired {
+		g := group(finding.Category)
+		g.Required = append(g.Required, finding)
+	}
+	for _, finding := range v2.ItemsRecommended {
+		g := group(finding.Category)
+		g.Recommended = append(g.Recommended, finding)
+	}
+	for _, finding := range v2.ItemsAdvisory {
+		g := group(finding.Category)
+		g.Advisory = append(g.Advisory, finding)
+	}
+
+	grouped := make([]CategoryFindings, 0, len(groups))
+	for _, category := range categories {
+		if g, ok := groups[category.Name]; ok && category.Name != "" {
+			if category.Label != "" {
+				g.Label = category.Label
+			}
+			grouped = append(grouped, *g)
+			delete(groups, category.Name)
+		}
+	}
+	var rest []string
+	for name := range groups {
+		if name != "" {
+			rest = append(rest, name)
+		}
+	}
+	sort.Strings(rest)
+	for _, name := range rest {
+		grouped = append(grouped, *groups[name])
+	}
+	if g, ok := groups[""]; ok {
+		grouped = append(grouped, *g)
+	}
+	return grouped
+}
+
+// findingsFromItems builds minimal findings from "name: observation" item strings
+func findingsFromItems(items []string, status ResultKey, severity Severity) []Finding {
+	findings := make([]Finding, 0, len(items))
+	for _, item := range items {
+		title, observation, _ := strings.Cut(item, ": ")
+		findings = append(findings, Finding{
+			ID:          title,
+			Title:       title,
+			Status:      status,
+			Severity:    severity,
+			Observation: observation,
+		})
+	}
+	return findings
+}
@@ -0,0 +1,422 @@
+// app/server/uploads/sessions.go
+
+// Package uploads receives reports in chunks over unreliable networks. A
+// client opens a session with the size and SHA-256 digest of the document,
+// sends the chunks in order and resumes after a failure from the offset the
+// server reports. The chunks are kept on local disk, since blob backends such
+// as S3 cannot append, and are checked against the digest before the
+// assembled document is handed on for parsing.
+package uploads
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTTL is how long an unfinished session is kept when no TTL is configured
+const DefaultTTL = 24 * time.Hour
+
+var (
+	// ErrNotFound is returned for unknown and expired sessions
+	ErrNotFound = errors.New("upload session not found")
+
+	// ErrOffsetMismatch is returned when a chunk doesn't start where the
+	// received data ends, e.g. after a lost response; the client resumes from
+	// the session's offset
+	ErrOffsetMismatch = errors.New("chunk does not start at the upload offset")
+
+	// ErrTooLarge is returned when a chunk goes past the announced size
+	ErrTooLarge = errors.New("chunk exceeds the announced upload size")
+
+	// ErrIncomplete is returned when a session is completed before all data arrived
+	ErrIncomplete = errors.New("upload is incomplete")
+
+	// ErrChecksum is returned when the assembled document doesn't match its digest
+	ErrChecksum = errors.New("upload does not match its SHA-256 digest")
+
+	// ErrCompleting is returned while another request completes the session
+	ErrCompleting = errors.New("upload is being completed")
+)
+
+// digestPattern matches a hex-encoded SHA-256 digest
+var digestPattern = regexp.MustCompile(`^[0-9a-f]{64}$`)
+
+// Session is an upload in progress
+type Session struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
+	Size     int64  `json:"size"`
+	SHA256   string `json:"sha256"`
+
+	// Cluster and Signature are passed on like the form fields of a direct upload
+	Cluster   string `json:"cluster,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+
+	// Offset is the number of bytes received so far
+	Offset int64 `json:"offset"`
+
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Manager keeps the sessions and their data in a directory
+type Manager struct {
+	dir string
+	ttl time.Duration
+
+	mu       sync.Mutex
+	sessions map[string]*Session
+
+	// locks serializes the chunks of each session
+	locks map[string]*sync.Mutex
+
+	// completing holds the sessions opened by Open and not removed or
+	// released yet, so each document is stored once
+	completing map[string]bool
+}
+
+// New opens the sessions stored in dir, so uploads survive a restart
+func New(dir string, ttl time.Duration) (*Manager, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("error creating upload directory: %w", err)
+	}
+	m := &Manager{
+		dir:        dir,
+		ttl:        ttl,
+		sessions:   make(map[string]*Session),
+		locks:      make(map[string]*sync.Mutex),
+		completing: make(map[string]bool),
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading upload session: %w", err)
+		}
+		var session Session
+		if err := json.Unmarshal(data, &session); err != nil {
+			log.Printf("Skipping unreadable upload session %s: %v", path, err)
+			continue
+		}
+		// The data file is the truth: a crash may have hit between a write and the metadata update
+		info, err := os.Stat(m.dataPath(session.ID))
+		if err != nil {
+			log.Printf("Skipping upload session %s without data: %v", session.ID, err)
+			continue
+		}
+		session.Offset = info.Size()
+		m.sessions[session.ID] = &session
+		m.locks[session.ID] = &sync.Mutex{}
+	}
+	if len(m.sessions) > 0 {
+		log.Printf("Resumed %d upload sessions from %s", len(m.sessions), dir)
+	}
+	return m, nil
+}
+
+// TTL returns how long an unfinished session is kept
+func (m *Manager) TTL() time.Duration {
+	return m.ttl
+}
+
+// Create opens a session for a document of the given size and digest
+func (m *Manager) Create(session Session) (Session, error) {
+	session.Filename = filepath.Base(strings.TrimSpace(session.Filename))
+	session.SHA256 = strings.ToLower(strings.TrimSpace(session.SHA256))
+	switch {
+	case session.Filename == "." || session.Filename == "/":
+		return Session{}, fmt.Errorf("filename is required")
+	case session.Size <= 0:
+		return Session{}, fmt.Errorf("size must be positive")
+	case !digestPattern.MatchString(session.SHA256):
+		return Session{}, fmt.Errorf("sha256 must be a hex-encoded SHA-256 digest")
+	}
+
+	id, err := newID()
+	if err != nil {
+		return Session{}, err
+	}
+	now := time.Now().UTC()
+	session.ID = id
+	session.Offset = 0
+	session.CreatedAt = now
+	session.ExpiresAt = now.Add(m.ttl)
+
+	file, err := os.OpenFile(m.dataPath(id), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o640)
+	if err != nil {
+		return Session{}, err
+	}
+	file.Close()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.saveLocked(&session); err != nil {
+		os.Remove(m.dataPath(id))
+		return Session{}, err
+	}
+	m.sessions[id] = &session
+	m.locks[id] = &sync.Mutex{}
+	return session, nil
+}
+
+// Get returns a session
+func (m *Manager) Get(id string) (Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[id]
+	if !ok {
+		return Session{}, ErrNotFound
+	}
+	return *session, nil
+}
+
+// Append writes a chunk at offset, which must be the session's offset, and
+// returns the session with its new offset. A chunk cut short by the network
+// keeps what arrived, so the client resumes after it.
+func (m *Manager) Append(id string, offset int64, chunk io.Reader) (Session, error) {
+	lock, err := m.lock(id)
+	if err != nil {
+		return Session{}, err
+	}
+	defer lock.Unlock()
+
+	session, err := m.get(id)
+	if err != nil {
+		return session, err
+	}
+	if offset != session.Offset {
+		return session, ErrOffsetMismatch
+	}
+
+	file, err := os.OpenFile(m.dataPath(id), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return session, err
+	}
+	// Read one byte past the announced size to detect oversized chunks
+	remaining := session.Size - session.Offset
+	written, copyErr := io.Copy(file, io.LimitReader(chunk, remaining+1))
+	if written > remaining {
+		written = remaining
+		copyErr = ErrTooLarge
+	}
+	if err := file.Truncate(session.Offset + written); err != nil && copyErr == nil {
+		copyErr = err
+	}
+	if err := file.Close(); err != nil && copyErr == nil {
+		copyErr = err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stored, ok := m.sessions[id]
+	if !ok {
+		return Session{}, ErrNotFound
+	}
+	stored.Offset += written
+	if err := m.saveLocked(stored); err != nil && copyErr == nil {
+		copyErr = err
+	}
+	return *stored, copyErr
+}
+
+// Open verifies a completely received document against its digest and opens
+// it for reading. The session is claimed for the caller, which removes it once
+// the document is stored or releases it to let the client retry; until then
+// Open returns ErrCompleting for it.
+func (m *Manager) Open(id string) (Session, *os.File, error) {
+	lock, err := m.lock(id)
+	if err != nil {
+		return Session{}, nil, err
+	}
+	defer lock.Unlock()
+
+	session, err := m.get(id)
+	if err != nil {
+		return session, nil, err
+	}
+	if session.Offset != session.Size {
+		return session, nil, ErrIncomplete
+	}
+
+	file, err := os.Open(m.dataPath(id))
+	if err != nil {
+		return session, nil, err
+	}
+	digest := sha256.New()
+	if _, err := io.Copy(digest, file); err != nil {
+		file.Close()
+		return session, nil, err
+	}
+	if hex.EncodeToString(digest.Sum(nil)) != session.SHA256 {
+		file.Close()
+		return session, nil, ErrChecksum
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		return session, nil, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[id]; !ok {
+		file.Close()
+		return Session{}, nil, ErrNotFound
+	}
+	m.completing[id] = true
+	return session, file, nil
+}
+
+// Release ends the claim of Open on a session whose document couldn't be
+// stored, so it can be completed again
+func (m *Manager) Release(id string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.completing, id)
+}
+
+// get returns a session that isn't being completed
+func (m *Manager) get(id string) (Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[id]
+	switch {
+	case !ok:
+		return Session{}, ErrNotFound
+	case m.completing[id]:
+		return *session, ErrCompleting
+	}
+	return *session, nil
+}
+
+// Remove deletes a session and its data
+func (m *Manager) Remove(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.sessions[id]; !ok {
+		return ErrNotFound
+	}
+	delete(m.sessions, id)
+	delete(m.locks, id)
+	delete(m.completing, id)
+	return m.removeFiles(id)
+}
+
+// Expire removes the sessions that expired before now and returns how many.
+// Each is removed under its own lock, so no chunk is being written to it, and
+// sessions being completed are left to the request completing them.
+func (m *Manager) Expire(now time.Time) int {
+	m.mu.Lock()
+	var expired []string
+	for id, session := range m.sessions {
+		if !now.Before(session.ExpiresAt) && !m.completing[id] {
+			expired = append(expired, id)
+		}
+	}
+	m.mu.Unlock()
+
+	removed := 0
+	for _, id := range expired {
+		if m.expire(id) {
+			removed++
+		}
+	}
+	return removed
+}
+
+// expire removes an expired session unless it was removed or claimed while
+// waiting for its lock
+func (m *Manager) expire(id string) bool {
+	lock, err := m.lock(id)
+	if err != nil {
+		return false
+	}
+	defer lock.Unlock()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.sessions[id]; !ok || m.completing[id] {
+		return false
+	}
+	delete(m.sessions, id)
+	delete(m.locks, id)
+	if err := m.removeFiles(id); err != nil {
+		log.Printf("Error removing expired upload session %s: %v", id, err)
+	}
+	return true
+}
+
+// lock takes the lock serializing the chunks of a session
+func (m *Manager) lock(id string) (*sync.Mutex, error) {
+	m.mu.Lock()
+	lock, ok := m.locks[id]
+	m.mu.Unlock()
+	if !ok {
+		return nil, ErrNotFound
+	}
+	lock.Lock()
+	return lock, nil
+}
+
+// saveLocked writes the metadata of a session; m.mu must be held
+func (m *Manager) saveLocked(session *Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	tmp := m.metadataPath(session.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o640); err != nil {
+		return fmt.Errorf("error saving upload session: %w", err)
+	}
+	return os.Rename(tmp, m.metadataPath(session.ID))
+}
+
+// removeFiles deletes the data and metadata of a session
+func (m *Manager) removeFiles(id string) error {
+	err := os.Remove(m.dataPath(id))
+	if errors.Is(err, os.ErrNotExist) {
+		err = nil
+	}
+	if metaErr := os.Remove(m.metadataPath(id)); metaErr != nil && !errors.Is(metaErr, os.ErrNotExist) && err == nil {
+		err = metaErr
+	}
+	return err
+}
+
+func (m *Manager) dataPath(id string) string {
+	return filepath.Join(m.dir, id+".part")
+}
+
+func (m *Manager) metadataPath(id string) string {
+	return filepath.Join(m.dir, id+".json")
+}
+
+// newID generates a random session ID
+func newID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
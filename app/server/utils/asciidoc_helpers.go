@@ -2,10 +2,26 @@
 package utils
 
 import (
-	"fmt"
+	"log"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/i18n"
+)
+
+var (
+	// clusterNamePattern matches a quoted cluster name or the word after
+	// "cluster"; an apostrophe within a word, as in "Acme's", opens no quote
+	clusterNamePattern = regexp.MustCompile(`(?:^|[^A-Za-z0-9])['"]([^'"]+)['"]|cluster\s+([a-zA-Z0-9_-]+)`)
+
+	// customerNamePattern matches the customer of "conducted ... Customer's"
+	customerNamePattern = regexp.MustCompile(`conducted.*?([A-Za-z0-9_\s]+)'s`)
+
+	// percentPattern matches the first percentage of a line
+	percentPattern = regexp.MustCompile(`(\d+)%`)
 )
 
 // IsValidAsciiDocFile checks if a filename has a valid AsciiDoc extension
@@ -15,15 +31,16 @@ func IsValidAsciiDocFile(filename string) bool {
 
 // Helper functions for extracting data from AsciiDoc content
 
-// ExtractClusterName extracts the cluster name from the report
+// ExtractClusterName extracts the cluster name from the report; typographic
+// quotes count as plain ones
 func ExtractClusterName(lines []string) string {
 	clusterName := ""
 
 	for _, line := range lines {
 		if strings.Contains(line, "cluster") {
+			line = normalizeQuotes(line)
 			// Look for quoted cluster name or after keywords
-			re := regexp.MustCompile(`['"]([^'"]+)['"]|cluster\s+([a-zA-Z0-9_-]+)`)
-			matches := re.FindStringSubmatch(line)
+			matches := clusterNamePattern.FindStringSubmatch(line)
 			if len(matches) > 1 {
 				if matches[1] != "" {
 					clusterName = matches[1]
@@ -40,14 +57,15 @@ func ExtractClusterName(lines []string) string {
 	return clusterName
 }
 
-// ExtractCustomerName extracts the customer name from the report
+// ExtractCustomerName extracts the customer name from the report; a
+// typographic apostrophe counts as a plain one
 func ExtractCustomerName(lines []string) string {
 	customerName := ""
 
 	for _, line := range lines {
 		if strings.Contains(line, "conducted") && strings.Contains(line, "health check") {
-			re := regexp.MustCompile(`conducted.*?([A-Za-z0-9_\s]+)'s`)
-			matches := re.FindStringSubmatch(line)
+			line = normalizeQuotes(line)
+			matches := customerNamePattern.FindStringSubmatch(line)
 			if len(matches) > 1 {
 				customerName = strings.TrimSpace(matches[1])
 				break
@@ -58,27 +76,42 @@ func ExtractCustomerName(lines []string) string {
 	return customerName
 }
 
-// ExtractOverallScore extracts the overall score from the report
-func ExtractOverallScore(lines []string) float64 {
-	var score float64
+// labelsAttribute is the document attribute listing the labels of a report,
+// e.g. ":labels: environment=prod, region=emea"
+const labelsAttribute = ":labels:"
 
-	scorePattern := regexp.MustCompile(`Overall\s+Cluster\s+Health:\s+(\d+\.?\d*)%`)
+// ExtractLabels extracts the labels listed in the labels attribute of the
+// report; pairs that aren't valid Kubernetes labels are skipped
+func ExtractLabels(lines []string) map[string]string {
+	var labels map[string]string
 	for _, line := range lines {
-		matches := scorePattern.FindStringSubmatch(line)
-		if len(matches) > 1 {
-			score, _ = strconv.ParseFloat(matches[1], 64)
-			return score
+		value, ok := strings.CutPrefix(strings.TrimSpace(line), labelsAttribute)
+		if !ok {
+			continue
+		}
+		for _, pair := range strings.Split(value, ",") {
+			key, value, found := strings.Cut(pair, "=")
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			if key == "" {
+				continue
+			}
+			if !found || len(validation.IsQualifiedName(key)) > 0 || len(validation.IsValidLabelValue(value)) > 0 {
+				log.Printf("Skipping invalid label %q in the document", strings.TrimSpace(pair))
+				continue
+			}
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[key] = value
 		}
 	}
+	return labels
+}
 
-	// Check for a score in the health-check-report itself
-	healthScorePattern := regexp.MustCompile(`Overall Health Score.*?(\d+\.?\d*)%`)
-	for _, line := range lines {
-		matches := healthScorePattern.FindStringSubmatch(line)
-		if len(matches) > 1 {
-			score, _ = strconv.ParseFloat(matches[1], 64)
-			return score
-		}
+// ExtractOverallScore extracts the overall score from the report
+func ExtractOverallScore(lines []string) float64 {
+	if score := extractExplicitOverallScore(lines); score > 0 {
+		return score
 	}
 
 	// If no explicit score is found, calculate from status counts in the Summary section
@@ -113,207 +146,13 @@ func CalculateScoreFromStatusCounts(lines []string) float64 {
 // CountAllStatusItems counts items by their color status in the Summary section
 // Returns counts for required, recommended, advisory, noChange, and notApplicable
 func CountAllStatusItems(lines []string) (int, int, int, int, int) {
-	required := 0
-	recommended := 0
-	advisory := 0
-	noChange := 0
-	notApplicable := 0
-
-	// Find summary section boundaries
-	summaryStartIndex := -1
-	summaryEndIndex := -1
-
-	for i, line := range lines {
-		if strings.TrimSpace(line) == "= Summary" {
-			summaryStartIndex = i
-			break
-		}
-	}
-
-	if summaryStartIndex == -1 {
-		return 0, 0, 0, 0, 0 // Can't find summary section
-	}
-
-	// Find end of summary (next section or end of file)
-	for i := summaryStartIndex + 1; i < len(lines); i++ {
-		if strings.HasPrefix(strings.TrimSpace(lines[i]), "=") &&
-			!strings.Contains(lines[i], "= Summary") {
-			summaryEndIndex = i
-			break
-		}
-	}
-
-	if summaryEndIndex == -1 {
-		summaryEndIndex = len(lines) // Use end of file if no next section
-	}
-
-	// Process the summary section for color codes
-	inItem := false
-	inTable := false
-
-	for i := summaryStartIndex; i < summaryEndIndex; i++ {
-		line := lines[i]
-
-		// Start of an item block (if using item blocks)
-		if strings.Contains(line, "// ------------------------ITEM START") {
-			inItem = true
-			continue
-		}
-
-		// End of an item block
-		if strings.Contains(line, "// ------------------------ITEM END") {
-			inItem = false
-			continue
-		}
-
-		// Detect start of table
-		if strings.Contains(line, "|===") && !inTable {
-			inTable = true
-			continue
-		}
-
-		// Detect end of table
-		if strings.Contains(line, "|===") && inTable {
-			inTable = false
-			break
-		}
-
-		// Skip header/legend rows
-		if inTable && (strings.Contains(line, "*Category*") ||
-			strings.Contains(line, "Indicates Changes Required") ||
-			strings.Contains(line, "Indicates Changes Recommended") ||
-			strings.Contains(line, "No advise given") ||
-			strings.Contains(line, "No change required") ||
-			strings.Contains(line, "Not yet evaluated")) {
-			continue
-		}
-
-		// Count by color codes - for table cells and items
-		if (inTable || inItem) && !strings.Contains(line, "Description") {
-			if strings.Contains(line, "{set:cellbgcolor:#FF0000}") {
-				required++
-			} else if strings.Contains(line, "{set:cellbgcolor:#FEFE20}") {
-				recommended++
-			} else if strings.Contains(line, "{set:cellbgcolor:#80E5FF}") {
-				advisory++
-			} else if strings.Contains(line, "{set:cellbgcolor:#00FF00}") {
-				noChange++
-			} else if strings.Contains(line, "{set:cellbgcolor:#A6B9BF}") {
-				notApplicable++
-			}
-		}
-	}
-
-	return required, recommended, advisory, noChange, notApplicable
+	scan := ScanSummary(lines)
+	return scan.Required, scan.Recommended, scan.Advisory, scan.NoChange, scan.NotApplicable
 }
 
 // CountStatusByCategory counts items by category and status
 func CountStatusByCategory(lines []string) *ItemsByCategory {
-	result := &ItemsByCategory{
-		Required:      make(map[string]int),
-		Recommended:   make(map[string]int),
-		Advisory:      make(map[string]int),
-		NoChange:      make(map[string]int),
-		NotApplicable: make(map[string]int),
-	}
-
-	// Find summary section boundaries
-	summaryStartIndex := -1
-	summaryEndIndex := -1
-
-	for i, line := range lines {
-		if strings.TrimSpace(line) == "= Summary" {
-			summaryStartIndex = i
-			break
-		}
-	}
-
-	if summaryStartIndex == -1 {
-		return result // Can't find summary section
-	}
-
-	// Find end of summary (next section or end of file)
-	for i := summaryStartIndex + 1; i < len(lines); i++ {
-		if strings.HasPrefix(strings.TrimSpace(lines[i]), "=") &&
-			!strings.Contains(lines[i], "= Summary") {
-			summaryEndIndex = i
-			break
-		}
-	}
-
-	if summaryEndIndex == -1 {
-		summaryEndIndex = len(lines) // Use end of file if no next section
-	}
-
-	// Process the summary section
-	var currentCategory string
-	var currentStatus string
-	inTable := false
-
-	for i := summaryStartIndex; i < summaryEndIndex; i++ {
-		line := strings.TrimSpace(lines[i])
-
-		// Detect start/end of table
-		if strings.Contains(line, "|===") {
-			inTable = !inTable
-			continue
-		}
-
-		if !inTable {
-			continue
-		}
-
-		// Extract category
-		if strings.HasPrefix(line, "|") && !strings.Contains(line, "cellbgcolor") {
-			currentCategory = strings.TrimSpace(strings.TrimPrefix(line, "|"))
-			continue
-		}
-
-		// Determine status by color code
-		if strings.Contains(line, "{set:cellbgcolor:#FF0000}") {
-			currentStatus = "required"
-		} else if strings.Contains(line, "{set:cellbgcolor:#FEFE20}") {
-			currentStatus = "recommended"
-		} else if strings.Contains(line, "{set:cellbgcolor:#80E5FF}") {
-			currentStatus = "advisory"
-		} else if strings.Contains(line, "{set:cellbgcolor:#00FF00}") {
-			currentStatus = "nochange"
-		} else if strings.Contains(line, "{set:cellbgcolor:#A6B9BF}") {
-			currentStatus = "notapplicable"
-		}
-
-		// Only count if we have both category and status
-		if currentCategory != "" && currentStatus != "" {
-			// Skip header/legend rows
-			if strings.Contains(line, "Indicates Changes Required") ||
-				strings.Contains(line, "Indicates Changes Recommended") ||
-				strings.Contains(line, "No advise given") ||
-				strings.Contains(line, "No change required") ||
-				strings.Contains(line, "Not yet evaluated") {
-				currentStatus = ""
-				continue
-			}
-
-			// Count item by category and status
-			switch currentStatus {
-			case "required":
-				result.Required[currentCategory]++
-			case "recommended":
-				result.Recommended[currentCategory]++
-			case "advisory":
-				result.Advisory[currentCategory]++
-			case "nochange":
-				result.NoChange[currentCategory]++
-			case "notapplicable":
-				result.NotApplicable[currentCategory]++
-			}
-
-			// Reset status to avoid double counting
-			currentStatus = ""
-		}
-	}
-
-	return result
+	return ScanSummary(lines).ByCategory
 }
 
 // CalculateCategoryScore calculates score for a given category using item counts
@@ -339,15 +178,8 @@ func CalculateCategoryScore(categoryItems map[string]int, categoryName string) i
 
 // ExtractCategoryScore extracts the score for a specific category
 func ExtractCategoryScore(lines []string, categoryName string) int {
-	var score int
-
-	scorePattern := regexp.MustCompile(fmt.Sprintf(`\*%s\*:\s+(\d+)%%`, regexp.QuoteMeta(categoryName)))
-	for _, line := range lines {
-		matches := scorePattern.FindStringSubmatch(line)
-		if len(matches) > 1 {
-			score, _ = strconv.Atoi(matches[1])
-			return score
-		}
+	if score := extractExplicitCategoryScore(lines, categoryName); score > 0 {
+		return score
 	}
 
 	// If not found with exact name, try partial matching
@@ -358,16 +190,23 @@ func ExtractCategoryScore(lines []string, categoryName string) int {
 func ExtractGeneralCategoryScore(lines []string, keywords ...string) int {
 	var score int
 
-	// Search for lines containing any of the keywords and a percentage
-	percentPattern := regexp.MustCompile(`(\d+)%`)
+	lowered := make([]string, len(keywords))
+	for i, keyword := range keywords {
+		lowered[i] = strings.ToLower(keyword)
+	}
 
+	// Search for lines containing any of the keywords and a percentage
 	for _, line := range lines {
+		// Only lines with a percentage can hold a score
+		if !strings.Contains(line, "%") {
+			continue
+		}
 		lowercase := strings.ToLower(line)
 
 		// Check if line contains any keyword
 		foundKeyword := false
-		for _, keyword := range keywords {
-			if strings.Contains(lowercase, strings.ToLower(keyword)) {
+		for _, keyword := range lowered {
+			if strings.Contains(lowercase, keyword) {
 				foundKeyword = true
 				break
 			}
@@ -423,340 +262,42 @@ func ExtractCategoryDescription(lines []string, categoryName string) string {
 
 // GenerateDescription generates a description based on the category and score
 func GenerateDescription(categoryName string, score int) string {
-	if score >= 90 {
-		return fmt.Sprintf("%s is excellent with best practices in place.", categoryName)
-	} else if score >= 80 {
-		return fmt.Sprintf("%s is well-configured with only minor improvements needed.", categoryName)
-	} else if score >= 70 {
-		return fmt.Sprintf("%s meets most requirements but has some areas that could be improved.", categoryName)
-	} else if score >= 60 {
-		return fmt.Sprintf("%s has several areas that need attention to meet best practices.", categoryName)
-	} else if score > 0 {
-		return fmt.Sprintf("%s requires significant improvements to ensure stability and security.", categoryName)
-	}
+	return GenerateDescriptionIn(i18n.DefaultLanguage, categoryName, score)
+}
 
-	return ""
+// GenerateDescriptionIn generates the description of a category in a language
+func GenerateDescriptionIn(language, categoryName string, score int) string {
+	var message string
+	switch {
+	case score >= 90:
+		message = "%s is excellent with best practices in place."
+	case score >= 80:
+		message = "%s is well-configured with only minor improvements needed."
+	case score >= 70:
+		message = "%s meets most requirements but has some areas that could be improved."
+	case score >= 60:
+		message = "%s has several areas that need attention to meet best practices."
+	case score > 0:
+		message = "%s requires significant improvements to ensure stability and security."
+	default:
+		return ""
+	}
+	return i18n.T(language, message, i18n.T(language, categoryName))
 }
 
 // ExtractRequiredChanges extracts items marked as "Changes Required" from Summary section
 func ExtractRequiredChanges(lines []string) []string {
-	var requiredItems []string
-
-	// Find summary section boundaries first
-	summaryStartIndex := -1
-	summaryEndIndex := -1
-
-	for i, line := range lines {
-		if strings.TrimSpace(line) == "= Summary" {
-			summaryStartIndex = i
-			break
-		}
-	}
-
-	if summaryStartIndex == -1 {
-		return requiredItems // Empty list, summary not found
-	}
-
-	// Find end of summary (next section or end of file)
-	for i := summaryStartIndex + 1; i < len(lines); i++ {
-		if strings.HasPrefix(strings.TrimSpace(lines[i]), "=") &&
-			!strings.Contains(lines[i], "= Summary") {
-			summaryEndIndex = i
-			break
-		}
-	}
-
-	if summaryEndIndex == -1 {
-		summaryEndIndex = len(lines) // Use end of file if no next section
-	}
-
-	// Now process only the lines in the Summary section
-	summaryLines := lines[summaryStartIndex:summaryEndIndex]
-
-	// Find ITEM blocks in the Summary section
-	var currentItem string
-	var itemName string
-	var observation string
-	inItem := false
-
-	for _, line := range summaryLines {
-		// Detect ITEM start
-		if strings.Contains(line, "// ------------------------ITEM START") {
-			inItem = true
-			itemName = ""
-			observation = ""
-			continue
-		}
-
-		// Detect ITEM end
-		if strings.Contains(line, "// ------------------------ITEM END") {
-			if inItem && itemName != "" {
-				if observation != "" {
-					currentItem = fmt.Sprintf("%s: %s", itemName, observation)
-				} else {
-					currentItem = itemName
-				}
-
-				if currentItem != "" {
-					requiredItems = append(requiredItems, currentItem)
-				}
-			}
-			inItem = false
-			continue
-		}
-
-		if !inItem {
-			continue
-		}
-
-		// Extract item name
-		if strings.Contains(line, "<<") && strings.Contains(line, ">>") {
-			re := regexp.MustCompile(`<<([^>]+)>>`)
-			matches := re.FindStringSubmatch(line)
-			if len(matches) > 1 {
-				itemName = strings.TrimSpace(matches[1])
-			}
-			continue
-		}
-
-		// Extract observation
-		if itemName != "" && observation == "" &&
-			!strings.HasPrefix(line, "//") && !strings.Contains(line, "{set:cellbgcolor") {
-			if strings.HasPrefix(line, "|") {
-				line = strings.TrimSpace(line[1:])
-			}
-			if line != "" {
-				observation = line
-			}
-			continue
-		}
-
-		// Check for required status
-		if strings.Contains(line, "{set:cellbgcolor:#FF0000}") &&
-			!strings.Contains(line, "Indicates Changes Required") {
-			// This is a "Changes Required" item - keep it in the list
-			continue
-		} else if strings.Contains(line, "set:cellbgcolor:") {
-			// This item has a different status - remove it from consideration
-			inItem = false
-		}
-	}
-
-	return requiredItems
+	return ScanSummary(lines).ItemsRequired
 }
 
 // ExtractRecommendedChanges extracts items marked as "Changes Recommended" from Summary section
 func ExtractRecommendedChanges(lines []string) []string {
-	var recommendedItems []string
-
-	// Find summary section boundaries first
-	summaryStartIndex := -1
-	summaryEndIndex := -1
-
-	for i, line := range lines {
-		if strings.TrimSpace(line) == "= Summary" {
-			summaryStartIndex = i
-			break
-		}
-	}
-
-	if summaryStartIndex == -1 {
-		return recommendedItems // Empty list, summary not found
-	}
-
-	// Find end of summary (next section or end of file)
-	for i := summaryStartIndex + 1; i < len(lines); i++ {
-		if strings.HasPrefix(strings.TrimSpace(lines[i]), "=") &&
-			!strings.Contains(lines[i], "= Summary") {
-			summaryEndIndex = i
-			break
-		}
-	}
-
-	if summaryEndIndex == -1 {
-		summaryEndIndex = len(lines) // Use end of file if no next section
-	}
-
-	// Now process only the lines in the Summary section
-	summaryLines := lines[summaryStartIndex:summaryEndIndex]
-
-	// Find ITEM blocks in the Summary section
-	var currentItem string
-	var itemName string
-	var observation string
-	inItem := false
-
-	for _, line := range summaryLines {
-		// Detect ITEM start
-		if strings.Contains(line, "// ------------------------ITEM START") {
-			inItem = true
-			itemName = ""
-			observation = ""
-			continue
-		}
-
-		// Detect ITEM end
-		if strings.Contains(line, "// ------------------------ITEM END") {
-			if inItem && itemName != "" {
-				if observation != "" {
-					currentItem = fmt.Sprintf("%s: %s", itemName, observation)
-				} else {
-					currentItem = itemName
-				}
-
-				if currentItem != "" {
-					recommendedItems = append(recommendedItems, currentItem)
-				}
-			}
-			inItem = false
-			continue
-		}
-
-		if !inItem {
-			continue
-		}
-
-		// Extract item name
-		if strings.Contains(line, "<<") && strings.Contains(line, ">>") {
-			re := regexp.MustCompile(`<<([^>]+)>>`)
-			matches := re.FindStringSubmatch(line)
-			if len(matches) > 1 {
-				itemName = strings.TrimSpace(matches[1])
-			}
-			continue
-		}
-
-		// Extract observation
-		if itemName != "" && observation == "" &&
-			!strings.HasPrefix(line, "//") && !strings.Contains(line, "{set:cellbgcolor") {
-			if strings.HasPrefix(line, "|") {
-				line = strings.TrimSpace(line[1:])
-			}
-			if line != "" {
-				observation = line
-			}
-			continue
-		}
-
-		// Check for recommended status
-		if strings.Contains(line, "{set:cellbgcolor:#FEFE20}") &&
-			!strings.Contains(line, "Indicates Changes Recommended") {
-			// This is a "Changes Recommended" item - keep it in the list
-			continue
-		} else if strings.Contains(line, "set:cellbgcolor:") {
-			// This item has a different status - remove it from consideration
-			inItem = false
-		}
-	}
-
-	return recommendedItems
+	return ScanSummary(lines).ItemsRecommended
 }
 
 // ExtractAdvisoryActions extracts items marked as "Advisory" from Summary section
 func ExtractAdvisoryActions(lines []string) []string {
-	var advisoryItems []string
-
-	// Find summary section boundaries first
-	summaryStartIndex := -1
-	summaryEndIndex := -1
-
-	for i, line := range lines {
-		if strings.TrimSpace(line) == "= Summary" {
-			summaryStartIndex = i
-			break
-		}
-	}
-
-	if summaryStartIndex == -1 {
-		return advisoryItems // Empty list, summary not found
-	}
-
-	// Find end of summary (next section or end of file)
-	for i := summaryStartIndex + 1; i < len(lines); i++ {
-		if strings.HasPrefix(strings.TrimSpace(lines[i]), "=") &&
-			!strings.Contains(lines[i], "= Summary") {
-			summaryEndIndex = i
-			break
-		}
-	}
-
-	if summaryEndIndex == -1 {
-		summaryEndIndex = len(lines) // Use end of file if no next section
-	}
-
-	// Now process only the lines in the Summary section
-	summaryLines := lines[summaryStartIndex:summaryEndIndex]
-
-	// Find ITEM blocks in the Summary section
-	var currentItem string
-	var itemName string
-	var observation string
-	inItem := false
-
-	for _, line := range summaryLines {
-		// Detect ITEM start
-		if strings.Contains(line, "// ------------------------ITEM START") {
-			inItem = true
-			itemName = ""
-			observation = ""
-			continue
-		}
-
-		// Detect ITEM end
-		if strings.Contains(line, "// ------------------------ITEM END") {
-			if inItem && itemName != "" {
-				if observation != "" {
-					currentItem = fmt.Sprintf("%s: %s", itemName, observation)
-				} else {
-					currentItem = itemName
-				}
-
-				if currentItem != "" {
-					advisoryItems = append(advisoryItems, currentItem)
-				}
-			}
-			inItem = false
-			continue
-		}
-
-		if !inItem {
-			continue
-		}
-
-		// Extract item name
-		if strings.Contains(line, "<<") && strings.Contains(line, ">>") {
-			re := regexp.MustCompile(`<<([^>]+)>>`)
-			matches := re.FindStringSubmatch(line)
-			if len(matches) > 1 {
-				itemName = strings.TrimSpace(matches[1])
-			}
-			continue
-		}
-
-		// Extract observation
-		if itemName != "" && observation == "" &&
-			!strings.HasPrefix(line, "//") && !strings.Contains(line, "{set:cellbgcolor") {
-			if strings.HasPrefix(line, "|") {
-				line = strings.TrimSpace(line[1:])
-			}
-			if line != "" {
-				observation = line
-			}
-			continue
-		}
-
-		// Check for advisory status
-		if strings.Contains(line, "{set:cellbgcolor:#80E5FF}") &&
-			!strings.Contains(line, "No advise given") {
-			// This is an "Advisory" item - keep it in the list
-			continue
-		} else if strings.Contains(line, "set:cellbgcolor:") {
-			// This item has a different status - remove it from consideration
-			inItem = false
-		}
-	}
-
-	return advisoryItems
+	return ScanSummary(lines).ItemsAdvisory
 }
 
 // CountNoChangeItems counts items marked as "No Change" in the Summary section
@@ -823,3 +364,12 @@ func CountNoChangeItems(lines []string) int {
 
 	return count
 }
+
+// SplitItem splits an extracted "name: observation" item into its two parts
+func SplitItem(item string) (string, string) {
+	name, observation, found := strings.Cut(item, ": ")
+	if !found {
+		return strings.TrimSpace(item), ""
+	}
+	return strings.TrimSpace(name), strings.TrimSpace(observation)
+}
@@ -0,0 +1,190 @@
+// app/server/utils/attributes.go
+package utils
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+)
+
+var (
+	// attributeEntryPattern matches an attribute entry such as ":name: value",
+	// or ":name!:" and ":!name:" unsetting the attribute
+	attributeEntryPattern = regexp.MustCompile(`^:(!?)([A-Za-z0-9_][A-Za-z0-9_-]*)(!?):(?:[ \t]+(.*?))?[ \t]*$`)
+
+	// attributeReferencePattern matches an attribute reference, optionally
+	// escaped with a backslash; inline entries like {set:cellbgcolor:...}
+	// don't match
+	attributeReferencePattern = regexp.MustCompile(`\\?\{([A-Za-z0-9_][A-Za-z0-9_-]*)\}`)
+)
+
+// builtinAttributes are the predefined character attributes that can't change
+// the structure of a line, e.g. {vbar} would add a table cell, so it's left alone
+var builtinAttributes = map[string]string{
+	"empty":  "",
+	"sp":     " ",
+	"nbsp":   "\u00a0",
+	"zwsp":   "\u200b",
+	"wj":     "\u2060",
+	"apos":   "'",
+	"quot":   `"`,
+	"lsquo":  "\u2018",
+	"rsquo":  "\u2019",
+	"ldquo":  "\u201c",
+	"rdquo":  "\u201d",
+	"deg":    "\u00b0",
+	"plus":   "+",
+	"amp":    "&",
+	"lt":     "<",
+	"gt":     ">",
+	"cpp":    "C++",
+	"brvbar": "\u00a6",
+}
+
+// maxAttributeSize bounds the value of an attribute with its references
+// expanded; values doubling at every entry would otherwise grow exponentially
+const maxAttributeSize = 64 << 10
+
+// maxExpandedSize bounds a document with its attribute references expanded
+const maxExpandedSize = 64 << 20
+
+// verbatimDelimiters open and close the blocks whose content is not
+// substituted: listing, literal, passthrough and comment blocks
+var verbatimDelimiters = []string{"----", "....", "++++", "////"}
+
+// attributes are the document attributes defined so far while reading a document
+type attributes map[string]string
+
+// define applies a line that is an attribute entry and reports whether it was one
+func (a attributes) define(line string) bool {
+	if !strings.HasPrefix(line, ":") {
+		return false
+	}
+	matches := attributeEntryPattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if matches == nil {
+		return false
+	}
+	name := strings.ToLower(matches[2])
+	if matches[1] == "!" || matches[3] == "!" {
+		delete(a, name)
+		return true
+	}
+	// A value that would grow past the bound keeps its references unexpanded
+	a[name], _ = a.expand(matches[4], maxAttributeSize)
+	return true
+}
+
+// expand replaces the references to defined attributes in text; escaped
+// references lose their backslash and references to undefined attributes are
+// kept, like Asciidoctor does by default. Text that would be longer than limit
+// once expanded is returned as it is, with false.
+func (a attributes) expand(text string, limit int) (string, bool) {
+	if !strings.Contains(text, "{") {
+		return text, true
+	}
+	size, exceeded := len(text), false
+	expanded := attributeReferencePattern.ReplaceAllStringFunc(text, func(reference string) string {
+		if exceeded {
+			return reference
+		}
+		value, ok := a.value(reference)
+		if !ok {
+			return reference
+		}
+		if size += len(value) - len(reference); size > limit {
+			exceeded = true
+			return reference
+		}
+		return value
+	})
+	if exceeded {
+		return text, false
+	}
+	return expanded, true
+}
+
+// value returns the replacement of an attribute reference, and false for
+// references to undefined attributes
+func (a attributes) value(reference string) (string, bool) {
+	if strings.HasPrefix(reference, `\`) {
+		return reference[1:], true
+	}
+	name := strings.ToLower(reference[1 : len(reference)-1])
+	if value, ok := a[name]; ok {
+		return value, true
+	}
+	value, ok := builtinAttributes[name]
+	return value, ok
+}
+
+// ExpandAttributes defines the attributes of the attribute entries of a
+// document and replaces the references to them in the lines that follow, so
+// sections and summary cells written with attributes read like plain text.
+// Lines of listing, literal, passthrough and comment blocks and comment lines
+// are kept as they are, and so are the lines that would make the document
+// longer than maxExpandedSize.
+func ExpandAttributes(lines []string) []string {
+	defined := make(attributes)
+	expanded := make([]string, len(lines))
+	verbatim := ""
+	size, truncated := 0, false
+	for i, line := range lines {
+		expanded[i] = line
+		if i > 0 {
+			size += len(expanded[i-1]) + 1
+		}
+
+		trimmed := strings.TrimRight(line, " \t\r")
+		if verbatim != "" {
+			if trimmed == verbatim {
+				verbatim = ""
+			}
+			continue
+		}
+		if delimiter, ok := verbatimDelimiter(trimmed); ok {
+			verbatim = delimiter
+			continue
+		}
+		if strings.HasPrefix(line, "//") || defined.define(line) {
+			continue
+		}
+		var ok bool
+		if expanded[i], ok = defined.expand(line, maxExpandedSize-size); !ok && !truncated {
+			log.Printf("Attribute references are left unexpanded from line %d: the document would grow past %d bytes", i+1, maxExpandedSize)
+			truncated = true
+		}
+	}
+	return expanded
+}
+
+// verbatimDelimiter returns the delimiter of a line opening a verbatim block
+func verbatimDelimiter(line string) (string, bool) {
+	for _, delimiter := range verbatimDelimiters {
+		if len(line) >= len(delimiter) && strings.Trim(line, delimiter[:1]) == "" {
+			return line, true
+		}
+	}
+	return "", false
+}
+
+// preprocessAsciiDoc resolves the include directives of a document with open,
+// unless it's nil, and expands its attribute references
+func preprocessAsciiDoc(lines []string, open IncludeOpener) ([]string, error) {
+	if open != nil {
+		for _, line := range lines {
+			if strings.HasPrefix(line, "include::") {
+				resolved, unresolved, err := ResolveIncludes("", []byte(strings.Join(lines, "\n")), open)
+				if err != nil {
+					return nil, fmt.Errorf("error resolving includes: %w", err)
+				}
+				for _, target := range unresolved {
+					log.Printf("Include %s not found", target)
+				}
+				lines = strings.Split(string(resolved), "\n")
+				break
+			}
+		}
+	}
+	return ExpandAttributes(lines), nil
+}
@@ -0,0 +1,39 @@
+// app/server/utils/attributes_test.go
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestExpandAttributesBounds(t *testing.T) {
+	// Every attribute doubles the previous one; expanded eagerly, a40 alone
+	// would take a terabyte
+	lines := []string{":a0: x"}
+	for i := 1; i <= 40; i++ {
+		lines = append(lines, fmt.Sprintf(":a%d: {a%d}{a%d}", i, i-1, i-1))
+	}
+	lines = append(lines, "{a10}", "{a40}")
+
+	expanded := ExpandAttributes(lines)
+	if got := expanded[len(expanded)-2]; got != strings.Repeat("x", 1<<10) {
+		t.Errorf("a10 expanded to %d bytes, want %d", len(got), 1<<10)
+	}
+	if got := expanded[len(expanded)-1]; len(got) > maxAttributeSize || !strings.Contains(got, "{a") {
+		t.Errorf("a40 expanded to %d bytes, want a value bounded by %d bytes with its references kept", len(got), maxAttributeSize)
+	}
+
+	// Lines referencing the largest value fill the document up to its bound
+	largest := "{a15}"
+	for range maxExpandedSize/maxAttributeSize + 10 {
+		lines = append(lines, largest+largest)
+	}
+	size := 0
+	for _, line := range ExpandAttributes(lines) {
+		size += len(line) + 1
+	}
+	if size > maxExpandedSize+1 {
+		t.Errorf("expanded document of %d bytes, want at most %d", size, maxExpandedSize)
+	}
+}
@@ -0,0 +1,109 @@
+// app/server/utils/corpus_test.go
+package utils
+
+import (
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// corpusDir holds real-world variants of the report template
+const corpusDir = "testdata/corpus"
+
+// corpusReports are the documents of the corpus with what the parser extracts
+// from them. A change of these counts is a change of what users see on the
+// dashboard; update them only when that change is intended.
+var corpusReports = []struct {
+	file          string
+	cluster       string
+	labels        int
+	required      int
+	recommended   int
+	advisory      int
+	noChange      int
+	notApplicable int
+	findings      int
+}{
+	// The template as delivered, one item of every status
+	{"standard.adoc", "ocp-prod-01", 2, 2, 1, 1, 2, 1, 7},
+
+	// The same document saved on Windows
+	{"crlf.adoc", "ocp-prod-01", 2, 2, 1, 1, 2, 1, 7},
+
+	// Edited in Word: typographic quotes, saved as Windows-1252
+	{"windows-1252.adoc", "ocp-prod-01", 2, 2, 1, 1, 2, 1, 7},
+
+	// Exported by Word as Unicode text, UTF-16 with a byte order mark
+	{"utf-16.adoc", "ocp-utf16", 2, 2, 1, 1, 2, 1, 7},
+
+	// Split into partials with attributes; one include is missing and one
+	// is in a listing block
+	{"modular/index.adoc", "ocp-stage", 0, 1, 1, 0, 1, 0, 3},
+
+	// Converted to Markdown, with emojis, labels and a bare warning sign as status
+	{"markdown.md", "ocp-dev", 0, 1, 2, 1, 1, 1, 6},
+
+	// Rendered by Asciidoctor as a book
+	{"rendered.html", "ocp-edge", 0, 1, 1, 0, 1, 0, 3},
+
+	// A draft without a Summary section; only the legend has a status color
+	{"draft.adoc", "ocp-draft", 0, 0, 0, 0, 0, 0, 0},
+}
+
+// parseCorpusReport parses a document of the corpus like an upload of it,
+// without the fallback values ValidateAndFixSummary fills in
+func parseCorpusReport(t testing.TB, name string) *types.ReportSummary {
+	t.Helper()
+	path := filepath.Join(corpusDir, filepath.FromSlash(name))
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	summary, err := ParseAsciiDocReaderWithOptions(file, ParseOptions{
+		Format:   FormatForFilename(name),
+		Includes: DirIncludes(filepath.Dir(path)),
+	})
+	if err != nil {
+		t.Fatalf("%s: %v", name, err)
+	}
+	return summary
+}
+
+func TestCorpusExtraction(t *testing.T) {
+	output := log.Writer()
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(output) })
+
+	for _, tt := range corpusReports {
+		t.Run(tt.file, func(t *testing.T) {
+			summary := parseCorpusReport(t, tt.file)
+
+			if summary.ClusterName != tt.cluster {
+				t.Errorf("cluster = %q, want %q", summary.ClusterName, tt.cluster)
+			}
+			counts := []struct {
+				name      string
+				got, want int
+			}{
+				{"labels", len(summary.Labels), tt.labels},
+				{"required", len(summary.ItemsRequired), tt.required},
+				{"recommended", len(summary.ItemsRecommended), tt.recommended},
+				{"advisory", len(summary.ItemsAdvisory), tt.advisory},
+				{"no change", summary.NoChangeCount, tt.noChange},
+				{"not applicable", summary.NotApplicableCount, tt.notApplicable},
+				{"findings", len(summary.Findings), tt.findings},
+			}
+			for _, count := range counts {
+				if count.got != count.want {
+					t.Errorf("%s = %d, want %d", count.name, count.got, count.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// app/server/utils/encoding.go
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/unicode"
+	"golang.org/x/text/transform"
+)
+
+// sniffSize is how much of a document is looked at to detect UTF-16 without
+// a byte order mark
+const sniffSize = 512
+
+// quoteReplacer turns the typographic quotes and spaces word processors write
+// into the plain characters the extraction patterns look for
+var quoteReplacer = strings.NewReplacer(
+	"\u2018", "'", "\u2019", "'", "\u201a", "'", "\u201b", "'", "\u2032", "'",
+	"\u201c", `"`, "\u201d", `"`, "\u201e", `"`, "\u201f", `"`, "\u2033", `"`,
+	"\u00a0", " ", "\u202f", " ",
+)
+
+// normalizeQuotes replaces the typographic quotes of a line by plain ones
+func normalizeQuotes(line string) string {
+	return quoteReplacer.Replace(line)
+}
+
+// decodeReader returns a reader of a document as UTF-8. UTF-16 is detected by
+// its byte order mark or, without one, by the zero bytes of mostly ASCII text;
+// a UTF-8 byte order mark is dropped.
+func decodeReader(r *bufio.Reader) io.Reader {
+	head, _ := r.Peek(sniffSize)
+	switch {
+	case bytes.HasPrefix(head, []byte{0xef, 0xbb, 0xbf}):
+		r.Discard(3)
+		return r
+	case bytes.HasPrefix(head, []byte{0xff, 0xfe}), bytes.HasPrefix(head, []byte{0xfe, 0xff}):
+		return transform.NewReader(r, unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM).NewDecoder())
+	}
+
+	// ASCII text in UTF-16 has a zero in every other byte
+	var even, odd int
+	for i, b := range head {
+		if b == 0 {
+			if i%2 == 0 {
+				even++
+			} else {
+				odd++
+			}
+		}
+	}
+	switch pairs := len(head) / 2; {
+	case pairs == 0:
+	case odd > pairs*3/4 && even == 0:
+		return transform.NewReader(r, unicode.UTF16(unicode.LittleEndian, unicode.IgnoreBOM).NewDecoder())
+	case even > pairs*3/4 && odd == 0:
+		return transform.NewReader(r, unicode.UTF16(unicode.BigEndian, unicode.IgnoreBOM).NewDecoder())
+	}
+	return r
+}
+
+// decodeLine reads a line that isn't valid UTF-8 as Windows-1252, the
+// encoding Word saves plain text in on western systems
+func decodeLine(line string) string {
+	if utf8.ValidString(line) {
+		return line
+	}
+	decoded, err := charmap.Windows1252.NewDecoder().String(line)
+	if err != nil {
+		return strings.ToValidUTF8(line, "\ufffd")
+	}
+	return decoded
+}
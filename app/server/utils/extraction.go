@@ -0,0 +1,210 @@
+// app/server/utils/extraction.go
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/i18n"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// Extraction strategies that can produce a score
+const (
+	// StrategyExplicit reads a score stated in the document
+	StrategyExplicit = "explicit"
+
+	// StrategyStatusCounts calculates a score from the status counts of the summary table
+	StrategyStatusCounts = "status-counts"
+
+	// StrategyKeyword takes the first percentage on a line mentioning the field
+	StrategyKeyword = "keyword"
+
+	// StrategyDefault marks a value filled in when no strategy produced one;
+	// it always runs last and can't be reordered
+	StrategyDefault = "default"
+)
+
+// Score fields whose strategy is recorded
+const (
+	FieldOverallScore       = "overallScore"
+	FieldScoreInfra         = "scoreInfra"
+	FieldScoreGovernance    = "scoreGovernance"
+	FieldScoreCompliance    = "scoreCompliance"
+	FieldScoreMonitoring    = "scoreMonitoring"
+	FieldScoreBuildSecurity = "scoreBuildSecurity"
+)
+
+// ScoreFields lists the fields whose extraction strategy is recorded
+var ScoreFields = []string{
+	FieldOverallScore,
+	FieldScoreInfra,
+	FieldScoreGovernance,
+	FieldScoreCompliance,
+	FieldScoreMonitoring,
+	FieldScoreBuildSecurity,
+}
+
+// DefaultFallbackOrder is the order the strategies are tried in unless configured otherwise
+var DefaultFallbackOrder = []string{StrategyStatusCounts, StrategyExplicit, StrategyKeyword}
+
+// ParseOptions tune how a report is parsed
+type ParseOptions struct {
+	// FallbackOrder is the order the score strategies are tried in;
+	// empty uses DefaultFallbackOrder
+	FallbackOrder []string
+
+	// Profiles are the configured report dialects
+	Profiles []ParserProfile
+
+	// Profile names the profile to parse with; empty or "auto" detects it
+	Profile string
+
+	// Language is the language of the generated descriptions; empty or
+	// unsupported languages use English
+	Language string
+
+	// Categories are values of the category column recognized besides the
+	// template categories, such as custom categories and mapped names
+	Categories []string
+
+	// Format is the format of the document, FormatAsciiDoc, FormatMarkdown or
+	// FormatHTML; empty reads AsciiDoc
+	Format string
+
+	// Includes reads the targets of the include directives of AsciiDoc
+	// documents; nil leaves the directives as they are
+	Includes IncludeOpener
+}
+
+// language returns the supported language of the options
+func (o ParseOptions) language() string {
+	if language, ok := i18n.Match(o.Language); ok {
+		return language
+	}
+	return i18n.DefaultLanguage
+}
+
+// ValidateFallbackOrder checks that an order lists known strategies at most once
+func ValidateFallbackOrder(order []string) error {
+	if len(order) == 0 {
+		return fmt.Errorf("the fallback order lists no strategies")
+	}
+
+	seen := make(map[string]bool)
+	for _, strategy := range order {
+		if !isOrderableStrategy(strategy) {
+			return fmt.Errorf("unknown extraction strategy %q, expected one of %s",
+				strategy, strings.Join(DefaultFallbackOrder, ", "))
+		}
+		if seen[strategy] {
+			return fmt.Errorf("extraction strategy %q is listed twice", strategy)
+		}
+		seen[strategy] = true
+	}
+	return nil
+}
+
+// isOrderableStrategy reports whether a strategy can appear in a fallback order
+func isOrderableStrategy(strategy string) bool {
+	for _, known := range DefaultFallbackOrder {
+		if strategy == known {
+			return true
+		}
+	}
+	return false
+}
+
+// fallbackOrder returns the configured order or the default one
+func (o ParseOptions) fallbackOrder() []string {
+	if len(o.FallbackOrder) == 0 {
+		return DefaultFallbackOrder
+	}
+	return o.FallbackOrder
+}
+
+// overallScorePatterns match an overall score stated in the document
+var overallScorePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`Overall\s+Cluster\s+Health:\s+(\d+\.?\d*)%`),
+	regexp.MustCompile(`Overall Health Score.*?(\d+\.?\d*)%`),
+}
+
+// extractExplicitOverallScore returns the overall score stated in the document, or 0
+func extractExplicitOverallScore(lines []string) float64 {
+	for _, pattern := range overallScorePatterns {
+		for _, line := range lines {
+			matches := pattern.FindStringSubmatch(line)
+			if len(matches) > 1 {
+				score, _ := strconv.ParseFloat(matches[1], 64)
+				return score
+			}
+		}
+	}
+	return 0
+}
+
+// categoryScorePatterns caches the "*Name*: NN%" patterns by category name
+var categoryScorePatterns sync.Map
+
+// categoryScorePattern returns the compiled "*Name*: NN%" pattern of a category
+func categoryScorePattern(categoryName string) *regexp.Regexp {
+	if pattern, ok := categoryScorePatterns.Load(categoryName); ok {
+		return pattern.(*regexp.Regexp)
+	}
+	pattern := regexp.MustCompile(fmt.Sprintf(`\*%s\*:\s+(\d+)%%`, regexp.QuoteMeta(categoryName)))
+	categoryScorePatterns.Store(categoryName, pattern)
+	return pattern
+}
+
+// extractExplicitCategoryScore returns a category score stated as "*Name*: NN%", or 0
+func extractExplicitCategoryScore(lines []string, categoryName string) int {
+	scorePattern := categoryScorePattern(categoryName)
+	for _, line := range lines {
+		matches := scorePattern.FindStringSubmatch(line)
+		if len(matches) > 1 {
+			score, _ := strconv.Atoi(matches[1])
+			return score
+		}
+	}
+	return 0
+}
+
+// firstScore runs the strategies in order and returns the first positive
+// score with the strategy that produced it
+func firstScore(order []string, strategies map[string]func() float64) (float64, string) {
+	for _, strategy := range order {
+		extract, ok := strategies[strategy]
+		if !ok {
+			continue
+		}
+		if score := extract(); score > 0 {
+			return score, strategy
+		}
+	}
+	return 0, ""
+}
+
+// CategoryScore returns the category score field of a summary named by one of
+// ScoreFields, or nil for the overall score and unknown fields
+func CategoryScore(summary *types.ReportSummary, field string) *int {
+	for _, category := range parserCategories {
+		if category.field == field {
+			return category.score(summary)
+		}
+	}
+	return nil
+}
+
+// recordStrategy notes which strategy produced a field of the summary
+func recordStrategy(summary *types.ReportSummary, field, strategy string) {
+	if strategy == "" {
+		return
+	}
+	if summary.Extraction == nil {
+		summary.Extraction = make(map[string]string)
+	}
+	summary.Extraction[field] = strategy
+}
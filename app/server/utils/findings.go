@@ -0,0 +1,391 @@
+// app/server/utils/findings.go
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+var (
+	// xrefPattern matches cross-references like <<Title>> or <<anchor,Title>>
+	xrefPattern = regexp.MustCompile(`<<([^,>]+)(?:,\s*([^>]+))?>>`)
+
+	// headingPattern matches section titles like "= Title" or "=== Title"
+	headingPattern = regexp.MustCompile(`^(=+)\s+(\S.*)$`)
+
+	// anchorPattern matches block anchors like [[anchor]] or [#anchor]
+	anchorPattern = regexp.MustCompile(`^\[(?:\[([^\],]+)[^\]]*\]|#([^\].]+)[^\]]*)\]$`)
+
+	// urlPattern matches bare URLs and the targets of link macros
+	urlPattern = regexp.MustCompile(`https?://[^\s\[\]<>"|]+`)
+
+	// labelPattern matches the labels that split a detail section, like *Observation* or .Recommendation
+	labelPattern = regexp.MustCompile(`^(?:\*{1,2}|\.|=+\s+)?(Observations?|Recommendations?|References?|Reference Links|Links)\s*:?\s*(?:\*{1,2})?$`)
+
+	// headerCellPattern matches the bold header cells of the summary table, like |*Category*
+	headerCellPattern = regexp.MustCompile(`^\|\s*\*{1,2}([^*]+)\*{1,2}\s*$`)
+)
+
+// statusColors maps the summary table cell colors to item statuses
+var statusColors = map[string]types.ResultKey{
+	"#FF0000": types.ResultKeyRequired,
+	"#FEFE20": types.ResultKeyRecommended,
+	"#80E5FF": types.ResultKeyAdvisory,
+	"#00FF00": types.ResultKeyNoChange,
+	"#A6B9BF": types.ResultKeyNotApplicable,
+	"#FFFFFF": types.ResultKeyEvaluate,
+}
+
+// Impact and effort levels of findings
+const (
+	LevelLow    = "low"
+	LevelMedium = "medium"
+	LevelHigh   = "high"
+)
+
+// knownCategories are the values of the category column in the report template
+var knownCategories = []string{"Cluster Config", "Security", "Performance", "Op-Ready", "Applications"}
+
+// section is a titled part of the document
+type section struct {
+	title string
+	ids   []string
+	line  int
+	start int
+	end   int
+}
+
+// SeverityForStatus returns the severity used for items with the given status
+func SeverityForStatus(status types.ResultKey) types.Severity {
+	switch status {
+	case types.ResultKeyRequired:
+		return types.SeverityHigh
+	case types.ResultKeyRecommended:
+		return types.SeverityMedium
+	case types.ResultKeyAdvisory:
+		return types.SeverityLow
+	case types.ResultKeyNoChange, types.ResultKeyNotApplicable:
+		return types.SeverityNone
+	default:
+		return types.SeverityUnknown
+	}
+}
+
+// ParseLevel reads an impact or effort cell as low, medium or high; other
+// values give an empty level
+func ParseLevel(value string) string {
+	switch strings.ToLower(strings.Trim(strings.TrimSpace(value), "*_")) {
+	case "low", "l", "minor", "small":
+		return LevelLow
+	case "medium", "med", "m", "moderate":
+		return LevelMedium
+	case "high", "h", "major", "large", "critical":
+		return LevelHigh
+	}
+	return ""
+}
+
+// ExtractFindings extracts every item of the Summary table together with the
+// observation, recommendation and references from its detail section
+func ExtractFindings(lines []string) []types.Finding {
+	return ExtractFindingsWithCategories(lines, nil)
+}
+
+// ExtractFindingsWithCategories is ExtractFindings recognizing additional
+// values of the category column
+func ExtractFindingsWithCategories(lines []string, categories []string) []types.Finding {
+	findings := []types.Finding{}
+	sections := indexSections(lines)
+
+	inSummary := false
+	inTable := false
+	inItem := false
+	var header []string
+	var cells []string
+	var column int
+	var finding types.Finding
+
+	for _, raw := range lines {
+		line := strings.TrimSpace(raw)
+
+		// Only the Summary section holds the item table
+		if match := headingPattern.FindStringSubmatch(raw); match != nil {
+			inSummary = strings.TrimSpace(match[2]) == "Summary"
+			continue
+		}
+		if !inSummary {
+			continue
+		}
+
+		if line == "|===" && !inItem {
+			inTable = !inTable
+			header = nil
+			continue
+		}
+		if match := headerCellPattern.FindStringSubmatch(line); match != nil && inTable && !inItem {
+			header = append(header, strings.ToLower(strings.TrimSpace(match[1])))
+			continue
+		}
+
+		if strings.Contains(line, "// ------------------------ITEM START") {
+			inItem = true
+			cells = nil
+			column = 0
+			finding = types.Finding{Status: types.ResultKeyEvaluate}
+			continue
+		}
+
+		if strings.Contains(line, "// ------------------------ITEM END") {
+			if inItem && finding.Title != "" {
+				findings = append(findings, completeFinding(lines, sections, finding, cells, categories))
+			}
+			inItem = false
+			continue
+		}
+
+		if !inItem || line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		// Optional impact and effort columns are told apart by their header
+		if strings.HasPrefix(line, "|") {
+			column++
+			if column <= len(header) {
+				value := strings.TrimSpace(strings.TrimPrefix(line, "|"))
+				switch {
+				case strings.Contains(header[column-1], "impact"):
+					finding.Impact = ParseLevel(value)
+					continue
+				case strings.Contains(header[column-1], "effort"):
+					finding.Effort = ParseLevel(value)
+					continue
+				}
+			}
+		}
+
+		if strings.Contains(line, "{set:cellbgcolor:") {
+			for color, status := range statusColors {
+				if strings.Contains(line, "{set:cellbgcolor:"+color+"}") {
+					finding.Status = status
+				}
+			}
+			continue
+		}
+
+		if match := xrefPattern.FindStringSubmatch(line); match != nil && finding.Title == "" {
+			finding.ID = strings.TrimSpace(match[1])
+			finding.Title = strings.TrimSpace(match[1])
+			if match[2] != "" {
+				finding.Title = strings.TrimSpace(match[2])
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "|") {
+			cells = append(cells, strings.TrimSpace(strings.TrimPrefix(line, "|")))
+		}
+	}
+
+	return findings
+}
+
+// completeFinding fills in the category, severity and detail section of a summary item
+func completeFinding(lines []string, sections []section, finding types.Finding, cells, categories []string) types.Finding {
+	finding.Severity = SeverityForStatus(finding.Status)
+
+	// The category is one of the known column values; the first other cell is the observation
+	for _, cell := range cells {
+		if finding.Category == "" && isKnownCategory(cell, categories) {
+			finding.Category = cell
+		} else if finding.Observation == "" && cell != "" {
+			finding.Observation = cell
+		}
+	}
+
+	sec := findSection(sections, finding.ID)
+	if sec == nil {
+		return finding
+	}
+
+	finding.Section = &types.SectionRef{Anchor: sec.ids[0], Title: sec.title, Line: sec.line}
+
+	labelled := splitLabelledText(lines[sec.start:sec.end])
+	if text := labelled["observation"]; text != "" {
+		finding.Observation = text
+	}
+	finding.Recommendation = labelled["recommendation"]
+	finding.References = extractURLs(lines[sec.start:sec.end])
+
+	return finding
+}
+
+// indexSections lists the titled sections of the document with the IDs they can be referenced by
+func indexSections(lines []string) []section {
+	var sections []section
+	pendingAnchor := ""
+
+	for i, raw := range lines {
+		line := strings.TrimSpace(raw)
+
+		if match := anchorPattern.FindStringSubmatch(line); match != nil {
+			pendingAnchor = match[1] + match[2]
+			continue
+		}
+
+		match := headingPattern.FindStringSubmatch(raw)
+		if match == nil {
+			if line != "" {
+				pendingAnchor = ""
+			}
+			continue
+		}
+
+		title := strings.TrimSpace(match[2])
+
+		// A section's own text ends at the next heading, including its subsections
+		if len(sections) > 0 {
+			sections[len(sections)-1].end = i
+		}
+
+		ids := []string{}
+		if pendingAnchor != "" {
+			ids = append(ids, pendingAnchor)
+		}
+		ids = append(ids, autoSectionID(title), title)
+
+		sections = append(sections, section{title: title, ids: ids, line: i + 1, start: i + 1})
+		pendingAnchor = ""
+	}
+
+	if len(sections) > 0 {
+		sections[len(sections)-1].end = len(lines)
+	}
+	return sections
+}
+
+// findSection returns the section a cross-reference points to, skipping the summary itself
+func findSection(sections []section, target string) *section {
+	for i := range sections {
+		if sections[i].title == "Summary" {
+			continue
+		}
+		for _, id := range sections[i].ids {
+			if strings.EqualFold(id, target) {
+				return &sections[i]
+			}
+		}
+	}
+	return nil
+}
+
+// autoSectionID returns the ID Asciidoctor generates for a section title
+func autoSectionID(title string) string {
+	var b strings.Builder
+	b.WriteString("_")
+	lastUnderscore := true
+	for _, r := range strings.ToLower(title) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			b.WriteRune(r)
+			lastUnderscore = false
+		} else if !lastUnderscore {
+			b.WriteRune('_')
+			lastUnderscore = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "_")
+}
+
+// splitLabelledText splits a detail section into its labelled parts, keyed by
+// "observation", "recommendation" and "references"
+func splitLabelledText(lines []string) map[string]string {
+	parts := make(map[string][]string)
+	current := ""
+
+	for _, raw := range lines {
+		line := strings.TrimSpace(raw)
+
+		if match := labelPattern.FindStringSubmatch(line); match != nil {
+			label := strings.ToLower(match[1])
+			switch {
+			case strings.HasPrefix(label, "observation"):
+				current = "observation"
+			case strings.HasPrefix(label, "recommendation"):
+				current = "recommendation"
+			default:
+				current = "references"
+			}
+			continue
+		}
+
+		if current == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+		parts[current] = append(parts[current], line)
+	}
+
+	texts := make(map[string]string)
+	for label, partLines := range parts {
+		texts[label] = joinParagraphs(partLines)
+	}
+	return texts
+}
+
+// joinParagraphs joins lines into text, keeping single blank lines between paragraphs
+func joinParagraphs(lines []string) string {
+	var paragraphs []string
+	var current []string
+
+	for _, line := range lines {
+		if line == "" {
+			if len(current) > 0 {
+				paragraphs = append(paragraphs, strings.Join(current, "\n"))
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		paragraphs = append(paragraphs, strings.Join(current, "\n"))
+	}
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// extractURLs returns the distinct URLs in the given lines
+func extractURLs(lines []string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "//") {
+			continue
+		}
+		for _, url := range urlPattern.FindAllString(line, -1) {
+			url = strings.TrimRight(url, ".,;:)")
+			if !seen[url] {
+				seen[url] = true
+				urls = append(urls, url)
+			}
+		}
+	}
+	return urls
+}
+
+// isKnownCategory reports whether a cell holds one of the template categories
+// or one of the additional categories
+func isKnownCategory(cell string, additional []string) bool {
+	for _, category := range knownCategories {
+		if strings.EqualFold(cell, category) {
+			return true
+		}
+	}
+	for _, category := range additional {
+		if strings.EqualFold(cell, category) {
+			return true
+		}
+	}
+	return false
+}
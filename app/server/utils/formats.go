@@ -0,0 +1,55 @@
+// app/server/utils/formats.go
+package utils
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Report formats the parser reads
+const (
+	// FormatAsciiDoc is the standard report template
+	FormatAsciiDoc = "asciidoc"
+
+	// FormatMarkdown is the template converted to Markdown: the Summary is a
+	// Markdown table whose status column holds an emoji or a status label
+	// instead of a cell color
+	FormatMarkdown = "markdown"
+
+	// FormatHTML is the template as rendered by Asciidoctor, with the cell
+	// colors as inline background colors
+	FormatHTML = "html"
+)
+
+// FormatForFilename returns the format of a report by the extension of its
+// file name; anything else is read as AsciiDoc
+func FormatForFilename(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".md", ".markdown":
+		return FormatMarkdown
+	case ".html", ".htm":
+		return FormatHTML
+	}
+	return FormatAsciiDoc
+}
+
+// IsValidReportFile checks if a filename has the extension of a format the parser reads
+func IsValidReportFile(filename string) bool {
+	return IsValidAsciiDocFile(filename) || FormatForFilename(filename) != FormatAsciiDoc
+}
+
+// translateFormat brings a document in another format to the standard
+// AsciiDoc template. It also returns a function mapping the index of a
+// translated line to the 1-based line of the original document, which is 0
+// for formats without lines.
+func translateFormat(lines []string, format string) ([]string, func(int) int, error) {
+	switch format {
+	case FormatMarkdown:
+		translated, origins := translateMarkdown(lines)
+		return translated, func(i int) int { return origins[i] + 1 }, nil
+	case FormatHTML:
+		translated, err := TranslateHTML(strings.Join(lines, "\n"))
+		return translated, func(int) int { return 0 }, err
+	}
+	return lines, func(i int) int { return i + 1 }, nil
+}
@@ -0,0 +1,173 @@
+// app/server/utils/fuzz_test.go
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// fuzzFormats are the formats every fuzzed document is parsed as
+var fuzzFormats = []string{FormatAsciiDoc, FormatMarkdown, FormatHTML}
+
+// fuzzStatuses are the statuses a finding may have
+var fuzzStatuses = map[types.ResultKey]bool{
+	types.ResultKeyRequired:      true,
+	types.ResultKeyRecommended:   true,
+	types.ResultKeyAdvisory:      true,
+	types.ResultKeyNoChange:      true,
+	types.ResultKeyNotApplicable: true,
+	types.ResultKeyEvaluate:      true,
+}
+
+// addCorpusSeeds seeds a fuzz target with the documents of the corpus and a
+// generated report
+func addCorpusSeeds(f *testing.F) {
+	err := filepath.WalkDir(corpusDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		f.Add(content)
+		return nil
+	})
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add([]byte(strings.Join(benchmarkReport(200), "\n")))
+}
+
+// silenceLog drops the parser's log output for the rest of the test
+func silenceLog(tb testing.TB) {
+	output := log.Writer()
+	log.SetOutput(io.Discard)
+	tb.Cleanup(func() { log.SetOutput(output) })
+}
+
+// FuzzParseReport parses arbitrary documents in every format with includes
+// resolved against the document itself, which must never panic or loop
+func FuzzParseReport(f *testing.F) {
+	addCorpusSeeds(f)
+	f.Add([]byte("include::self.adoc[]\ninclude::self.adoc[]\n"))
+	f.Add([]byte{0xff, 0xfe, '=', 0, ' ', 0, 'S', 0})
+	silenceLog(f)
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		self := func(string) ([]byte, error) { return content, nil }
+		for _, format := range fuzzFormats {
+			summary, err := ParseReportWithOptions(bytes.NewReader(content), ParseOptions{Format: format, Includes: self})
+			if err != nil {
+				if format == FormatHTML {
+					continue
+				}
+				t.Fatalf("%s: %v", format, err)
+			}
+			for _, finding := range summary.Findings {
+				if !fuzzStatuses[finding.Status] {
+					t.Errorf("%s: finding %q has status %q", format, finding.Title, finding.Status)
+				}
+			}
+			if summary.OverallScore < 0 || summary.OverallScore > 100 {
+				t.Errorf("%s: overall score %v out of range", format, summary.OverallScore)
+			}
+		}
+	})
+}
+
+// FuzzReadLines checks that documents in UTF-8 are split exactly like
+// strings.Split and that anything else is read as valid UTF-8
+func FuzzReadLines(f *testing.F) {
+	addCorpusSeeds(f)
+	f.Add([]byte("caf\xe9 \x93quoted\x94\r\n"))
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		lines, err := ReadLines(bytes.NewReader(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, line := range lines {
+			if !utf8.ValidString(line) {
+				t.Fatalf("line %d %q isn't valid UTF-8", i+1, line)
+			}
+		}
+
+		// Zero bytes and byte order marks select another encoding
+		if utf8.Valid(content) && !bytes.ContainsRune(content, 0) && !bytes.HasPrefix(content, []byte{0xef, 0xbb, 0xbf}) {
+			if joined := strings.Join(lines, "\n"); joined != string(content) {
+				t.Fatalf("lines join to %q, want %q", joined, content)
+			}
+		}
+	})
+}
+
+// FuzzExtractors runs the extractors on arbitrary lines
+func FuzzExtractors(f *testing.F) {
+	addCorpusSeeds(f)
+	f.Add([]byte(":labels: a=b, =c, d=\n:x: {x}{x}\n{set:cellbgcolor:#FF0000}\n<<>>"))
+
+	f.Fuzz(func(t *testing.T, content []byte) {
+		lines := strings.Split(string(content), "\n")
+
+		ExtractClusterName(lines)
+		ExtractCustomerName(lines)
+		ExtractLabels(lines)
+		ExtractOverallScore(lines)
+		ExtractFindings(lines)
+
+		scan := ScanSummary(lines)
+		counts := []int{scan.Required, scan.Recommended, scan.Advisory, scan.NoChange, scan.NotApplicable}
+		for _, count := range counts {
+			if count < 0 || count > len(lines) {
+				t.Fatalf("status counts %v out of range for %d lines", counts, len(lines))
+			}
+		}
+
+		if expanded := ExpandAttributes(lines); len(expanded) != len(lines) {
+			t.Fatalf("expanding attributes made %d lines of %d", len(expanded), len(lines))
+		}
+	})
+}
+
+// FuzzResolveIncludes resolves a document including a partial that may
+// include itself or the main document
+func FuzzResolveIncludes(f *testing.F) {
+	f.Add("include::partial.adoc[]", "include::partial.adoc[]\ninclude::partial.adoc[]")
+	f.Add(":dir: parts\ninclude::{dir}/../partial.adoc[]", "include::../main.adoc[]")
+	f.Add("include::https://example.com/a.adoc[]\ninclude::/etc/passwd[]", "")
+
+	f.Fuzz(func(t *testing.T, main, partial string) {
+		documents := map[string]string{"main.adoc": main, "partial.adoc": partial, "parts/partial.adoc": partial}
+		open := func(name string) ([]byte, error) {
+			content, ok := documents[name]
+			if !ok {
+				return nil, fmt.Errorf("%s not found", name)
+			}
+			return []byte(content), nil
+		}
+
+		resolved, unresolved, err := ResolveIncludes("main.adoc", []byte(main), open)
+		if err != nil {
+			return
+		}
+		if len(IncludeTargets("main.adoc", []byte(main))) == 0 {
+			if string(resolved) != main {
+				t.Fatalf("document without includes changed to %q", resolved)
+			}
+			if len(unresolved) > 0 {
+				t.Fatalf("document without includes has unresolved includes %v", unresolved)
+			}
+		}
+	})
+}
@@ -0,0 +1,237 @@
+// app/server/utils/html.go
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	// htmlBackgroundPattern matches the background of an inline style
+	htmlBackgroundPattern = regexp.MustCompile(`(?i)background(?:-color)?\s*:\s*([^;]+)`)
+
+	// htmlHexColorPattern matches colors like "#FF0000" or "#F00"
+	htmlHexColorPattern = regexp.MustCompile(`#([0-9A-Fa-f]{6}|[0-9A-Fa-f]{3})\b`)
+
+	// htmlRGBColorPattern matches colors like "rgb(255, 0, 0)"
+	htmlRGBColorPattern = regexp.MustCompile(`(?i)rgba?\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})`)
+)
+
+// htmlSkipped are the parts of a rendered report that hold no report content
+var htmlSkipped = map[string]bool{
+	"head":   true,
+	"script": true,
+	"style":  true,
+	"nav":    true,
+}
+
+// TranslateHTML rewrites a report rendered to HTML by Asciidoctor back to the
+// standard AsciiDoc template: headings, paragraphs, lists and listings become
+// their AsciiDoc form, and every table row with a cell background color
+// becomes an item block carrying that color as its status marker, so the
+// parser profiles' custom colors keep working.
+func TranslateHTML(document string) ([]string, error) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(document))
+	if err != nil {
+		return nil, fmt.Errorf("error reading HTML report: %w", err)
+	}
+
+	var lines []string
+	walkHTML(doc.Find("body"), &lines)
+	return lines, nil
+}
+
+// walkHTML translates the block elements below a selection in document order
+func walkHTML(s *goquery.Selection, lines *[]string) {
+	s.Children().Each(func(_ int, child *goquery.Selection) {
+		name := goquery.NodeName(child)
+		if htmlSkipped[name] {
+			return
+		}
+		// Asciidoctor's table of contents and footer repeat or add nothing
+		if id, _ := child.Attr("id"); id == "toc" || id == "footer" {
+			return
+		}
+
+		switch name {
+		case "h1", "h2", "h3", "h4", "h5", "h6":
+			if title := htmlText(child); title != "" {
+				level := int(name[1] - '0')
+				*lines = append(*lines, "", strings.Repeat("=", level)+" "+title, "")
+			}
+		case "p":
+			if text := htmlText(child); text != "" {
+				*lines = append(*lines, text, "")
+			}
+		case "li":
+			// The text of an item is its own paragraph; nested lists follow it
+			text := htmlText(child.ChildrenFiltered("p"))
+			if text == "" && child.ChildrenFiltered("ul, ol").Length() == 0 {
+				text = htmlText(child)
+			}
+			*lines = append(*lines, "* "+text)
+			walkHTML(child.ChildrenFiltered("ul, ol, div"), lines)
+		case "pre":
+			*lines = append(*lines, "----")
+			*lines = append(*lines, strings.Split(strings.TrimRight(child.Text(), "\n"), "\n")...)
+			*lines = append(*lines, "----", "")
+		case "table":
+			*lines = append(*lines, translateHTMLTable(child)...)
+		default:
+			walkHTML(child, lines)
+		}
+	})
+}
+
+// translateHTMLTable converts a table to an AsciiDoc table with the header
+// cells in bold, like the Summary table of the template
+func translateHTMLTable(table *goquery.Selection) []string {
+	rows := table.ChildrenFiltered("thead, tbody, tfoot").ChildrenFiltered("tr")
+	if rows.Length() == 0 {
+		return nil
+	}
+
+	// The header is the head of the table, or a first row of header cells
+	header := table.ChildrenFiltered("thead").ChildrenFiltered("tr").First()
+	if header.Length() == 0 {
+		if first := rows.First(); first.ChildrenFiltered("td").Length() == 0 {
+			header = first
+		}
+	}
+
+	columns := rows.First().ChildrenFiltered("td, th").Length()
+	cols := make([]string, columns)
+	for i := range cols {
+		cols[i] = "1"
+	}
+	out := []string{"", `[cols="` + strings.Join(cols, ",") + `", options=header]`, "|==="}
+	header.ChildrenFiltered("td, th").Each(func(_ int, cell *goquery.Selection) {
+		out = append(out, "|*"+strings.Trim(htmlText(cell), "*")+"*")
+	})
+	out = append(out, "")
+
+	rows.Each(func(_ int, row *goquery.Selection) {
+		if header.Length() > 0 && row.IsSelection(header) {
+			return
+		}
+		cells := row.ChildrenFiltered("td, th")
+
+		colored, legend := false, false
+		cells.Each(func(_ int, cell *goquery.Selection) {
+			if _, ok := htmlCellColor(cell); ok {
+				colored = true
+			}
+			text := htmlText(cell)
+			for _, legendText := range legendTexts {
+				if strings.Contains(text, legendText) {
+					legend = true
+				}
+			}
+		})
+
+		// Rows without a status, and the legend, are copied as plain cells
+		item := colored && !legend
+		if item {
+			out = append(out, templateItemStart)
+		}
+		cells.Each(func(_ int, cell *goquery.Selection) {
+			text := htmlText(cell)
+			if color, ok := htmlCellColor(cell); ok {
+				out = append(out, "|{set:cellbgcolor:"+color+"}")
+				if text != "" {
+					out = append(out, text)
+				}
+				return
+			}
+			out = append(out, "|"+text)
+		})
+		if item {
+			out = append(out, templateItemEnd)
+		}
+		out = append(out, "")
+	})
+	return append(out, "|===", "")
+}
+
+// htmlCellColor returns the background color of a cell in the form of the
+// template's cell colors, "#RRGGBB" in upper case
+func htmlCellColor(cell *goquery.Selection) (string, bool) {
+	value, _ := cell.Attr("bgcolor")
+	if style, ok := cell.Attr("style"); ok {
+		if match := htmlBackgroundPattern.FindStringSubmatch(style); match != nil {
+			value = match[1]
+		}
+	}
+
+	if match := htmlHexColorPattern.FindStringSubmatch(value); match != nil {
+		hex := strings.ToUpper(match[1])
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		return "#" + hex, true
+	}
+	if match := htmlRGBColorPattern.FindStringSubmatch(value); match != nil {
+		var rgb [3]int
+		for i := range rgb {
+			rgb[i], _ = strconv.Atoi(match[i+1])
+			if rgb[i] > 255 {
+				return "", false
+			}
+		}
+		return fmt.Sprintf("#%02X%02X%02X", rgb[0], rgb[1], rgb[2]), true
+	}
+	return "", false
+}
+
+// htmlText returns the text of the elements on one line, with bold text and
+// cross references in their AsciiDoc form
+func htmlText(s *goquery.Selection) string {
+	var out strings.Builder
+	s.Each(func(i int, element *goquery.Selection) {
+		if i > 0 {
+			out.WriteString(" ")
+		}
+		writeHTMLInline(&out, element)
+	})
+	// Asciidoctor renders apostrophes as typographic ones, which the
+	// customer name extraction doesn't expect
+	text := strings.ReplaceAll(out.String(), "\u2019", "'")
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// writeHTMLInline writes the inline content of an element
+func writeHTMLInline(out *strings.Builder, s *goquery.Selection) {
+	s.Contents().Each(func(_ int, node *goquery.Selection) {
+		switch goquery.NodeName(node) {
+		case "#text":
+			out.WriteString(node.Text())
+		case "strong", "b":
+			if text := htmlText(node); text != "" {
+				out.WriteString("*" + text + "*")
+			}
+		case "a":
+			text := strings.Trim(htmlText(node), "[]")
+			href, _ := node.Attr("href")
+			switch {
+			case text == "":
+				// Asciidoctor's section anchors have no text
+			case strings.HasPrefix(href, "#"):
+				out.WriteString("<<" + text + ">>")
+			case href != "":
+				out.WriteString(href + "[" + text + "]")
+			default:
+				out.WriteString(text)
+			}
+		case "br", "p", "div":
+			out.WriteString(" ")
+			writeHTMLInline(out, node)
+		case "script", "style":
+		default:
+			writeHTMLInline(out, node)
+		}
+	})
+}
@@ -0,0 +1,185 @@
+// app/server/utils/includes.go
+package utils
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// includePattern matches an include directive, capturing its target and attributes
+var includePattern = regexp.MustCompile(`^include::([^\[\s]+)\[(.*)\]\s*$`)
+
+// maxIncludeDepth bounds nested includes like Asciidoctor does, which also
+// stops include cycles
+const maxIncludeDepth = 64
+
+// maxIncludes bounds the documents included in total; a document including
+// itself twice would otherwise be read 2^64 times before the depth bound stops it
+const maxIncludes = 1024
+
+// maxIncludeSize bounds a document read from the include directory
+const maxIncludeSize = 64 << 20
+
+// maxResolvedSize bounds a document with its includes resolved; small
+// documents included many times would otherwise add up to gigabytes
+const maxResolvedSize = 64 << 20
+
+// IncludeOpener reads the document at a clean, slash-separated path resolved
+// against the directory of the including document
+type IncludeOpener func(name string) ([]byte, error)
+
+// DirIncludes returns an opener reading includes from a directory; targets
+// can't leave it, not even through symbolic links
+func DirIncludes(dir string) IncludeOpener {
+	return func(name string) ([]byte, error) {
+		root, err := os.OpenRoot(dir)
+		if err != nil {
+			return nil, err
+		}
+		defer root.Close()
+
+		file, err := root.Open(filepath.FromSlash(name))
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(io.LimitReader(file, maxIncludeSize))
+	}
+}
+
+// FallbackIncludes returns an opener trying each opener in turn; nil openers are skipped
+func FallbackIncludes(openers ...IncludeOpener) IncludeOpener {
+	return func(name string) ([]byte, error) {
+		err := fmt.Errorf("%s not found", name)
+		for _, open := range openers {
+			if open == nil {
+				continue
+			}
+			var content []byte
+			if content, err = open(name); err == nil {
+				return content, nil
+			}
+		}
+		return nil, err
+	}
+}
+
+// includeTarget returns the path an include directive line of the document
+// named name refers to, and false for any other line
+func includeTarget(name, line string) (string, bool) {
+	matches := includePattern.FindStringSubmatch(strings.TrimRight(line, "\r"))
+	if matches == nil {
+		return "", false
+	}
+	return path.Join(path.Dir(name), matches[1]), true
+}
+
+// IncludeTargets returns the paths the include directives of the document
+// named name refer to, with the attributes defined in the document expanded
+func IncludeTargets(name string, content []byte) []string {
+	defined := make(attributes)
+	var targets []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if defined.define(line) {
+			continue
+		}
+		line, _ = defined.expand(line, maxAttributeSize)
+		if target, ok := includeTarget(name, line); ok {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// ResolveIncludes replaces the include directives of the AsciiDoc document
+// named name with the lines of the documents they include, recursively.
+// Targets may refer to the attributes defined before the directive, such as
+// {partialsdir}. The attributes of a directive, such as leveloffset or tags,
+// are ignored and the whole document is included. A directive whose target
+// can't be read is replaced by the warning Asciidoctor writes in its place and
+// its target is returned as unresolved, as are the directives past the first
+// maxIncludes. Resolving fails when the result grows past maxResolvedSize.
+func ResolveIncludes(name string, content []byte, open IncludeOpener) ([]byte, []string, error) {
+	resolver := &includeResolver{open: open, defined: make(attributes)}
+	resolver.resolve(name, string(content), 0)
+	if resolver.err != nil {
+		return nil, nil, resolver.err
+	}
+	return []byte(resolver.b.String()), resolver.unresolved, nil
+}
+
+// includeResolver holds the state of resolving the includes of a document;
+// defined collects the attributes of the included documents too
+type includeResolver struct {
+	b          strings.Builder
+	open       IncludeOpener
+	defined    attributes
+	unresolved []string
+
+	// included counts the documents included so far
+	included int
+
+	// err stops resolving once a bound is exceeded
+	err error
+}
+
+// resolve writes a document with its includes resolved
+func (r *includeResolver) resolve(name, content string, depth int) {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if i > 0 {
+			r.b.WriteByte('\n')
+		}
+
+		if r.defined.define(line) {
+			r.b.WriteString(line)
+			continue
+		}
+		directive, _ := r.defined.expand(line, maxAttributeSize)
+		target, ok := includeTarget(name, directive)
+		if !ok {
+			r.b.WriteString(line)
+			continue
+		}
+
+		var included []byte
+		var err error
+		switch {
+		case strings.Contains(directive, "://"):
+			err = fmt.Errorf("remote includes are not read")
+		case depth >= maxIncludeDepth:
+			err = fmt.Errorf("includes are nested more than %d levels deep", maxIncludeDepth)
+		case r.included >= maxIncludes:
+			err = fmt.Errorf("more than %d documents are included", maxIncludes)
+		case path.IsAbs(target) || target == ".." || strings.HasPrefix(target, "../"):
+			err = fmt.Errorf("target is outside the document tree")
+		case r.open == nil:
+			err = fmt.Errorf("includes are not resolved")
+		default:
+			included, err = r.open(target)
+		}
+		if err == nil && r.b.Len()+len(included) > maxResolvedSize {
+			r.err = fmt.Errorf("the document with its includes resolved is larger than %d bytes", maxResolvedSize)
+			return
+		}
+		if err != nil {
+			source := name
+			if source == "" {
+				source = "<stdin>"
+			}
+			r.unresolved = append(r.unresolved, target)
+			fmt.Fprintf(&r.b, "Unresolved directive in %s - %s", source, strings.TrimRight(directive, "\r"))
+			continue
+		}
+		r.included++
+		r.resolve(target, strings.TrimSuffix(string(included), "\n"), depth+1)
+		if r.err != nil {
+			return
+		}
+	}
+}
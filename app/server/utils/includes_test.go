@@ -0,0 +1,49 @@
+// app/server/utils/includes_test.go
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// mapIncludes returns an opener reading the documents of a map
+func mapIncludes(documents map[string]string) IncludeOpener {
+	return func(name string) ([]byte, error) {
+		content, ok := documents[name]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", name)
+		}
+		return []byte(content), nil
+	}
+}
+
+func TestResolveIncludesBounds(t *testing.T) {
+	// A partial including itself twice stops at the include count bound
+	open := mapIncludes(map[string]string{"partial.adoc": "text\ninclude::partial.adoc[]\ninclude::partial.adoc[]"})
+	resolved, unresolved, err := ResolveIncludes("main.adoc", []byte("include::partial.adoc[]"), open)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(resolved), "text"); got != maxIncludes {
+		t.Errorf("resolved %d copies of the partial, want %d", got, maxIncludes)
+	}
+	if len(unresolved) == 0 {
+		t.Error("includes past the count bound are not reported as unresolved")
+	}
+
+	// Large partials included fewer times than the count bound still fail
+	// once the result grows past the size bound
+	partial := strings.Repeat("x", 1<<20)
+	main := strings.Repeat("include::partial.adoc[]\n", maxResolvedSize>>20+1)
+	open = mapIncludes(map[string]string{"partial.adoc": partial})
+	if _, _, err := ResolveIncludes("main.adoc", []byte(main), open); err == nil {
+		t.Errorf("resolved a document larger than %d bytes", maxResolvedSize)
+	}
+
+	// Up to the size bound they're resolved
+	main = strings.Repeat("include::partial.adoc[]\n", maxResolvedSize>>20-1)
+	if _, _, err := ResolveIncludes("main.adoc", []byte(main), open); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,114 @@
+// app/server/utils/legacy.go
+package utils
+
+import (
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// LegacyMatch counts how the flat item strings of a stored summary were
+// matched to freshly parsed findings
+type LegacyMatch struct {
+	// Matched items were found among the parsed findings
+	Matched int `json:"matched"`
+	// Unmatched items had no parsed counterpart and were kept as minimal findings
+	Unmatched int `json:"unmatched"`
+	// Skipped counts parsed actionable findings that were not in the stored lists
+	Skipped int `json:"skipped"`
+}
+
+// MergeLegacyItems builds the findings of a summary stored before findings were
+// extracted, from findings parsed out of its raw document. The stored
+// "name: observation" strings remain authoritative: a parsed finding with the
+// same title takes the stored status, stored items without a counterpart are
+// kept as minimal findings, and parsed actionable findings the stored summary
+// never listed are left out, so scores, counts and Jira export keys carry over.
+func MergeLegacyItems(summary *types.ReportSummary, parsed []types.Finding) LegacyMatch {
+	var match LegacyMatch
+
+	// Index the stored items by normalized name
+	type legacyItem struct {
+		name        string
+		observation string
+		status      types.ResultKey
+		used        bool
+	}
+	var legacy []*legacyItem
+	byName := make(map[string][]*legacyItem)
+	for _, list := range []struct {
+		items  []string
+		status types.ResultKey
+	}{
+		{summary.ItemsRequired, types.ResultKeyRequired},
+		{summary.ItemsRecommended, types.ResultKeyRecommended},
+		{summary.ItemsAdvisory, types.ResultKeyAdvisory},
+	} {
+		for _, item := range list.items {
+			name, observation := SplitItem(item)
+			entry := &legacyItem{name: name, observation: observation, status: list.status}
+			legacy = append(legacy, entry)
+			key := normalizeTitle(name)
+			byName[key] = append(byName[key], entry)
+		}
+	}
+
+	findings := make([]types.Finding, 0, len(parsed)+len(legacy))
+	for _, finding := range parsed {
+		// Older parsers kept the raw xref text, e.g. "kubeadmin,Kubeadmin user"
+		var entry *legacyItem
+		for _, key := range []string{finding.Title, finding.ID + "," + finding.Title, finding.ID} {
+			for _, candidate := range byName[normalizeTitle(key)] {
+				if entry == nil && !candidate.used {
+					entry = candidate
+				}
+			}
+		}
+
+		switch {
+		case entry != nil:
+			entry.used = true
+			finding.Status = entry.status
+			finding.Severity = SeverityForStatus(entry.status)
+			if finding.Observation == "" {
+				finding.Observation = entry.observation
+			}
+			match.Matched++
+		case isActionable(finding.Status):
+			match.Skipped++
+			continue
+		}
+		findings = append(findings, finding)
+	}
+
+	for _, entry := range legacy {
+		if entry.used {
+			continue
+		}
+		findings = append(findings, types.Finding{
+			ID:          entry.name,
+			Title:       entry.name,
+			Status:      entry.status,
+			Severity:    SeverityForStatus(entry.status),
+			Observation: entry.observation,
+		})
+		match.Unmatched++
+	}
+
+	summary.Findings = findings
+	return match
+}
+
+// isActionable reports whether a status is listed among the summary items
+func isActionable(status types.ResultKey) bool {
+	switch status {
+	case types.ResultKeyRequired, types.ResultKeyRecommended, types.ResultKeyAdvisory:
+		return true
+	}
+	return false
+}
+
+// normalizeTitle folds case and whitespace so titles compare equal across parser versions
+func normalizeTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
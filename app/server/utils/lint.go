@@ -0,0 +1,233 @@
+// app/server/utils/lint.go
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Lint severities
+const (
+	// LintError marks problems that make the parser drop or misread content
+	LintError = "error"
+
+	// LintWarning marks content the parser reads, but probably not as intended
+	LintWarning = "warning"
+)
+
+// Lint issue codes
+const (
+	LintMissingSummary  = "missing-summary"
+	LintMissingTable    = "missing-table"
+	LintNoItems         = "no-items"
+	LintUnclosedItem    = "unclosed-item"
+	LintMissingColor    = "missing-color"
+	LintUnknownColor    = "unknown-color"
+	LintMissingXref     = "missing-xref"
+	LintDanglingXref    = "dangling-xref"
+	LintUnknownCategory = "unknown-category"
+	LintDuplicateItem   = "duplicate-item"
+)
+
+// cellColorPattern matches the color of a table cell
+var cellColorPattern = regexp.MustCompile(`\{set:cellbgcolor:(#[0-9A-Fa-f]{6})\}`)
+
+// LintIssue is a problem found in a report document
+type LintIssue struct {
+	Severity string `json:"severity"`
+	Code     string `json:"code"`
+
+	// Line is the 1-based line the issue was found at; 0 for the whole document
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// LintResult lists the issues of a document
+type LintResult struct {
+	// ParserProfile names the dialect the document was checked as
+	ParserProfile string      `json:"parserProfile"`
+	Items         int         `json:"items"`
+	Errors        int         `json:"errors"`
+	Warnings      int         `json:"warnings"`
+	Issues        []LintIssue `json:"issues"`
+}
+
+// Valid reports whether the document has no errors; warnings are allowed
+func (r *LintResult) Valid() bool {
+	return r.Errors == 0
+}
+
+// add records an issue
+func (r *LintResult) add(severity, code string, line int, format string, args ...interface{}) {
+	r.Issues = append(r.Issues, LintIssue{Severity: severity, Code: code, Line: line, Message: fmt.Sprintf(format, args...)})
+	if severity == LintError {
+		r.Errors++
+	} else {
+		r.Warnings++
+	}
+}
+
+// lintItem collects an item block of the Summary table while it is checked
+type lintItem struct {
+	line     int
+	title    string
+	colored  bool
+	category bool
+}
+
+// LintReport checks a document against the report template the way the parser
+// reads it: the Summary section and its item table, the status color and
+// cross-reference of every item, and its category. A document in another
+// dialect is translated by its parser profile first, a Markdown or HTML
+// document to the template; line numbers still refer to the original
+// document, except in HTML, which has none.
+func LintReport(lines []string, options ParseOptions) (*LintResult, error) {
+	lines, source, err := translateFormat(lines, options.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := options.selectProfile(lines)
+	if err != nil {
+		return nil, err
+	}
+	result := &LintResult{ParserProfile: ParserProfileDefault, Issues: []LintIssue{}}
+	if profile != nil {
+		result.ParserProfile = profile.Name
+		lines = profile.translate(lines)
+	}
+
+	start, end := -1, len(lines)
+	for i, raw := range lines {
+		match := headingPattern.FindStringSubmatch(raw)
+		if match == nil {
+			continue
+		}
+		if start >= 0 {
+			end = i
+			break
+		}
+		if strings.TrimSpace(match[2]) == "Summary" {
+			start = i
+		}
+	}
+	if start < 0 {
+		result.add(LintError, LintMissingSummary, 0, `The document has no "= Summary" section, so no items or scores can be read`)
+		return result, nil
+	}
+
+	sections := indexSections(lines)
+	seen := make(map[string]int)
+	hasTable := false
+	var item *lintItem
+
+	closeItem := func() {
+		if !item.colored {
+			result.add(LintError, LintMissingColor, item.line,
+				"Item %s has no status color; the parser counts it as not evaluated", item.describe())
+		}
+		if item.title == "" {
+			result.add(LintError, LintMissingXref, item.line,
+				"Item has no cross-reference like <<Title>>, so it is left out of the findings")
+		} else if !item.category {
+			result.add(LintWarning, LintUnknownCategory, item.line,
+				"Item %s has no known category; expected one of %s",
+				item.describe(), strings.Join(append(append([]string{}, knownCategories...), options.Categories...), ", "))
+		}
+		result.Items++
+		item = nil
+	}
+
+	for i := start + 1; i < end; i++ {
+		line := strings.TrimSpace(lines[i])
+
+		switch {
+		case strings.Contains(line, "|==="):
+			hasTable = true
+			continue
+		case strings.Contains(line, "// ------------------------ITEM START"):
+			if item != nil {
+				result.add(LintError, LintUnclosedItem, item.line, "Item %s is not closed before the next item starts", item.describe())
+				closeItem()
+			}
+			item = &lintItem{line: source(i)}
+			continue
+		case strings.Contains(line, "// ------------------------ITEM END"):
+			if item != nil {
+				closeItem()
+			}
+			continue
+		}
+		if item == nil || line == "" || strings.HasPrefix(line, "//") {
+			continue
+		}
+
+		if match := cellColorPattern.FindStringSubmatch(line); match != nil {
+			item.colored = true
+			// The parser matches the colors exactly, in upper case
+			if _, ok := statusColors[match[1]]; !ok {
+				result.add(LintWarning, LintUnknownColor, source(i),
+					"Item %s uses the color %s, which is not one of the status colors %s",
+					item.describe(), match[1], strings.Join(statusColorList(), ", "))
+			}
+			continue
+		}
+
+		if match := xrefPattern.FindStringSubmatch(line); match != nil && item.title == "" {
+			target := strings.TrimSpace(match[1])
+			item.title = target
+			if match[2] != "" {
+				item.title = strings.TrimSpace(match[2])
+			}
+			if findSection(sections, target) == nil {
+				result.add(LintWarning, LintDanglingXref, source(i),
+					"The cross-reference <<%s>> points to no section, so the item has no detail", target)
+			}
+			if first, ok := seen[strings.ToLower(target)]; ok {
+				result.add(LintWarning, LintDuplicateItem, source(i), "Item %s is listed again, first at line %d", item.describe(), first)
+			} else {
+				seen[strings.ToLower(target)] = source(i)
+			}
+			continue
+		}
+
+		if strings.HasPrefix(line, "|") && isKnownCategory(strings.TrimSpace(strings.TrimPrefix(line, "|")), options.Categories) {
+			item.category = true
+		}
+	}
+	if item != nil {
+		result.add(LintError, LintUnclosedItem, item.line, "Item %s is never closed", item.describe())
+		closeItem()
+	}
+
+	if !hasTable {
+		result.add(LintError, LintMissingTable, source(start), "The Summary section has no table delimited by |===")
+	}
+	if result.Items == 0 {
+		result.add(LintWarning, LintNoItems, source(start), "The Summary section lists no items between ITEM START and ITEM END markers")
+	}
+
+	// Item issues are recorded when the item closes; list them in document order
+	sort.SliceStable(result.Issues, func(i, j int) bool { return result.Issues[i].Line < result.Issues[j].Line })
+	return result, nil
+}
+
+// describe names an item in issue messages
+func (i *lintItem) describe() string {
+	if i.title == "" {
+		return fmt.Sprintf("at line %d", i.line)
+	}
+	return fmt.Sprintf("%q", i.title)
+}
+
+// statusColorList lists the status colors in a stable order
+func statusColorList() []string {
+	colors := make([]string, 0, len(statusColors))
+	for color := range statusColors {
+		colors = append(colors, color)
+	}
+	sort.Strings(colors)
+	return colors
+}
@@ -0,0 +1,298 @@
+// app/server/utils/markdown.go
+package utils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+var (
+	// markdownHeadingPattern matches ATX headings like "## Title"
+	markdownHeadingPattern = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+
+	// markdownSeparatorPattern matches the row between the header and the body of a table
+	markdownSeparatorPattern = regexp.MustCompile(`^\|?\s*:?-{3,}:?\s*(\|\s*:?-{3,}:?\s*)*\|?$`)
+
+	// markdownBoldPattern matches **strong** and __strong__ text
+	markdownBoldPattern = regexp.MustCompile(`\*\*([^*]+)\*\*|__([^_]+)__`)
+
+	// markdownLinkPattern matches [text](target) links
+	markdownLinkPattern = regexp.MustCompile(`\[([^\]]+)\]\(([^)]*)\)`)
+)
+
+// markdownStatusEmojis are the emojis marking the status of an item, checked
+// in order so the first match wins
+var markdownStatusEmojis = []struct {
+	emoji  string
+	status types.ResultKey
+}{
+	{"🔴", types.ResultKeyRequired},
+	{"❌", types.ResultKeyRequired},
+	{"⛔", types.ResultKeyRequired},
+	{"🟡", types.ResultKeyRecommended},
+	{"🟠", types.ResultKeyRecommended},
+	{"⚠️", types.ResultKeyRecommended},
+	{"⚠", types.ResultKeyRecommended},
+	{"🔵", types.ResultKeyAdvisory},
+	{"ℹ️", types.ResultKeyAdvisory},
+	{"ℹ", types.ResultKeyAdvisory},
+	{"🟢", types.ResultKeyNoChange},
+	{"✅", types.ResultKeyNoChange},
+	{"✔️", types.ResultKeyNoChange},
+	{"⚪", types.ResultKeyNotApplicable},
+	{"➖", types.ResultKeyNotApplicable},
+}
+
+// markdownStatusLabels maps the status labels of the template, in lower case,
+// to their status
+var markdownStatusLabels = map[string]types.ResultKey{
+	"changes required":    types.ResultKeyRequired,
+	"required":            types.ResultKeyRequired,
+	"changes recommended": types.ResultKeyRecommended,
+	"recommended":         types.ResultKeyRecommended,
+	"advisory":            types.ResultKeyAdvisory,
+	"no change":           types.ResultKeyNoChange,
+	"no changes":          types.ResultKeyNoChange,
+	"not applicable":      types.ResultKeyNotApplicable,
+	"n/a":                 types.ResultKeyNotApplicable,
+}
+
+// templateStatusLabels are the labels under the cell colors of the standard template
+var templateStatusLabels = map[types.ResultKey]string{
+	types.ResultKeyRequired:      "Changes Required",
+	types.ResultKeyRecommended:   "Changes Recommended",
+	types.ResultKeyAdvisory:      "Advisory",
+	types.ResultKeyNoChange:      "No Change",
+	types.ResultKeyNotApplicable: "Not Applicable",
+}
+
+// TranslateMarkdown rewrites a Markdown report to the standard AsciiDoc
+// template: headings, bold text and tables are converted, and every table row
+// with a status becomes an item block whose status cell carries the cell
+// color of the status. Code blocks are copied unchanged.
+func TranslateMarkdown(lines []string) []string {
+	translated, _ := translateMarkdown(lines)
+	return translated
+}
+
+// translateMarkdown is TranslateMarkdown, also returning the index of the
+// Markdown line each translated line comes from
+func translateMarkdown(lines []string) ([]string, []int) {
+	translated := make([]string, 0, len(lines))
+	origins := make([]int, 0, len(lines))
+	inCode := false
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inCode = !inCode
+			translated, origins = append(translated, "----"), append(origins, i)
+			continue
+		}
+		if inCode {
+			translated, origins = append(translated, line), append(origins, i)
+			continue
+		}
+
+		// A table is a header row followed by a separator row
+		if strings.HasPrefix(trimmed, "|") && i+1 < len(lines) &&
+			markdownSeparatorPattern.MatchString(strings.TrimSpace(lines[i+1])) {
+			end := i + 2
+			for end < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[end]), "|") {
+				end++
+			}
+			table, rows := translateMarkdownTable(lines[i], lines[i+2:end])
+			translated = append(translated, table...)
+			for _, row := range rows {
+				// The header is line i and the rows start after the separator
+				if row > 0 {
+					row++
+				}
+				origins = append(origins, i+row)
+			}
+			i = end - 1
+			continue
+		}
+
+		if match := markdownHeadingPattern.FindStringSubmatch(trimmed); match != nil {
+			line = strings.Repeat("=", len(match[1])) + " " + markdownInline(match[2])
+		} else {
+			line = markdownInline(line)
+		}
+		translated, origins = append(translated, line), append(origins, i)
+	}
+	return translated, origins
+}
+
+// translateMarkdownTable converts a Markdown table to an AsciiDoc table with
+// the header cells in bold, like the Summary table of the template. It also
+// returns the row each line comes from: 0 for the header, n for the nth row.
+func translateMarkdownTable(header string, rows []string) ([]string, []int) {
+	headers := markdownCells(header)
+	itemColumn := markdownItemColumn(headers)
+
+	cols := make([]string, len(headers))
+	for i := range cols {
+		cols[i] = "1"
+	}
+	out := []string{`[cols="` + strings.Join(cols, ",") + `", options=header]`, "|==="}
+	for _, cell := range headers {
+		out = append(out, "|*"+markdownPlain(cell)+"*")
+	}
+	out = append(out, "")
+
+	var sources []int
+	mark := func(row int) {
+		for len(sources) < len(out) {
+			sources = append(sources, row)
+		}
+	}
+	mark(0)
+
+	for r, row := range rows {
+		cells := markdownCells(row)
+		statusColumn, status := -1, types.ResultKey("")
+		legend := false
+		for i, cell := range cells {
+			for _, text := range legendTexts {
+				if strings.Contains(cell, text) {
+					legend = true
+				}
+			}
+			if statusColumn == -1 {
+				if found, ok := markdownStatus(cell); ok {
+					statusColumn, status = i, found
+				}
+			}
+		}
+
+		// Rows without a status, and the legend, are copied as plain cells
+		if statusColumn == -1 || legend {
+			for i, cell := range cells {
+				if i == statusColumn {
+					out = append(out, "|{set:cellbgcolor:"+templateStatusColors[status]+"}", markdownPlain(stripStatusEmoji(cell)))
+					continue
+				}
+				out = append(out, "|"+markdownInline(cell))
+			}
+			out = append(out, "")
+			mark(r + 1)
+			continue
+		}
+
+		item := itemColumn
+		if item < 0 {
+			item = markdownLinkColumn(cells, statusColumn)
+		}
+		out = append(out, templateItemStart)
+		for i, cell := range cells {
+			switch {
+			case i == statusColumn:
+				out = append(out, "|{set:cellbgcolor:"+templateStatusColors[status]+"}", templateStatusLabels[status])
+			case i == item:
+				out = append(out, "|<<"+markdownPlain(cell)+">>")
+			default:
+				out = append(out, "|"+markdownPlain(cell))
+			}
+		}
+		out = append(out, templateItemEnd, "")
+		mark(r + 1)
+	}
+	out = append(out, "|===")
+	mark(len(rows))
+	return out, sources
+}
+
+// markdownItemColumn returns the column naming the items, by its header, or -1
+func markdownItemColumn(headers []string) int {
+	for i, header := range headers {
+		name := strings.ToLower(markdownPlain(header))
+		if strings.HasPrefix(name, "item") || strings.HasPrefix(name, "check") {
+			return i
+		}
+	}
+	return -1
+}
+
+// markdownLinkColumn returns the column of a row naming its item when the
+// header doesn't say: the first link, else the second column as in the template
+func markdownLinkColumn(cells []string, statusColumn int) int {
+	for i, cell := range cells {
+		if i != statusColumn && markdownLinkPattern.MatchString(cell) {
+			return i
+		}
+	}
+	if len(cells) > 2 && statusColumn != 1 {
+		return 1
+	}
+	return 0
+}
+
+// markdownStatus returns the status marked in a cell by an emoji or a label
+func markdownStatus(cell string) (types.ResultKey, bool) {
+	for _, marker := range markdownStatusEmojis {
+		if strings.Contains(cell, marker.emoji) {
+			return marker.status, true
+		}
+	}
+	status, ok := markdownStatusLabels[strings.ToLower(markdownPlain(cell))]
+	return status, ok
+}
+
+// stripStatusEmoji removes the status emojis from a cell
+func stripStatusEmoji(cell string) string {
+	for _, marker := range markdownStatusEmojis {
+		cell = strings.ReplaceAll(cell, marker.emoji, "")
+	}
+	return strings.TrimSpace(cell)
+}
+
+// markdownCells splits a table row into its cells, keeping escaped pipes
+func markdownCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = row[:len(row)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// markdownInline converts bold text and links to their AsciiDoc form; links
+// to anchors become cross references
+func markdownInline(text string) string {
+	text = markdownBoldPattern.ReplaceAllString(text, "*$1$2*")
+	return markdownLinkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := markdownLinkPattern.FindStringSubmatch(link)
+		if strings.HasPrefix(match[2], "#") || match[2] == "" {
+			return "<<" + match[1] + ">>"
+		}
+		return match[2] + "[" + match[1] + "]"
+	})
+}
+
+// markdownPlain returns the text of a cell without emphasis and link targets
+func markdownPlain(text string) string {
+	text = markdownLinkPattern.ReplaceAllString(text, "$1")
+	text = markdownBoldPattern.ReplaceAllString(text, "$1$2")
+	text = strings.Trim(text, "*_` ")
+	return strings.TrimSpace(text)
+}
@@ -0,0 +1,206 @@
+// app/server/utils/profiles.go
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// Parser profile names with a special meaning
+const (
+	// ParserProfileDefault parses the standard report template as is
+	ParserProfileDefault = "default"
+
+	// ParserProfileAuto picks the first profile whose detection markers are
+	// all in the document, falling back to the default profile
+	ParserProfileAuto = "auto"
+)
+
+// Markers of the standard report template that profiles translate to
+const (
+	templateSummaryHeading = "= Summary"
+	templateItemStart      = "// ------------------------ITEM START"
+	templateItemEnd        = "// ------------------------ITEM END"
+)
+
+// ErrUnknownParserProfile is returned when a requested parser profile is not configured
+var ErrUnknownParserProfile = errors.New("unknown parser profile")
+
+// profileNamePattern restricts profile names to values that are safe in query strings
+var profileNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// ParserProfile describes a report dialect: a template that differs from the
+// standard one in its section names, status markers or table contents. The
+// parser translates a document in the dialect to the standard template, so
+// every extraction strategy works on it unchanged.
+type ParserProfile struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description"`
+
+	// Detect lists text that must all appear in a document for the profile
+	// to be picked automatically; a profile without markers is never auto-detected
+	Detect []string `json:"detect,omitempty" yaml:"detect"`
+
+	// SummaryHeading is the title of the section holding the item table,
+	// e.g. "Executive Summary", at any heading level
+	SummaryHeading string `json:"summaryHeading,omitempty" yaml:"summaryHeading"`
+
+	// ItemStart and ItemEnd are the comment lines around each item of the table
+	ItemStart string `json:"itemStart,omitempty" yaml:"itemStart"`
+	ItemEnd   string `json:"itemEnd,omitempty" yaml:"itemEnd"`
+
+	// StatusMarkers maps text marking the status of an item, such as a cell
+	// color like "{set:cellbgcolor:#E06666}", to a status
+	StatusMarkers map[string]types.ResultKey `json:"statusMarkers,omitempty" yaml:"statusMarkers"`
+
+	// Categories maps the values of the category column to the template categories
+	Categories map[string]string `json:"categories,omitempty" yaml:"categories"`
+}
+
+// Validate checks that a profile is complete enough to be used
+func (p *ParserProfile) Validate() error {
+	if p.Name == ParserProfileDefault || p.Name == ParserProfileAuto {
+		return fmt.Errorf("parser profile name %q is reserved", p.Name)
+	}
+	if !profileNamePattern.MatchString(p.Name) {
+		return fmt.Errorf("invalid parser profile name %q: use lowercase letters, digits and dashes", p.Name)
+	}
+
+	for marker, status := range p.StatusMarkers {
+		if marker == "" {
+			return fmt.Errorf("parser profile %q has an empty status marker", p.Name)
+		}
+		if _, ok := templateStatusColors[status]; !ok {
+			return fmt.Errorf("parser profile %q maps %q to unknown status %q", p.Name, marker, status)
+		}
+	}
+	for from, to := range p.Categories {
+		if !isKnownCategory(to, nil) {
+			return fmt.Errorf("parser profile %q maps category %q to %q, which is not one of %s",
+				p.Name, from, to, strings.Join(knownCategories, ", "))
+		}
+	}
+	return nil
+}
+
+// templateStatusColors are the cell colors of the standard template by status
+var templateStatusColors = func() map[types.ResultKey]string {
+	colors := make(map[types.ResultKey]string, len(statusColors))
+	for color, status := range statusColors {
+		colors[status] = color
+	}
+	return colors
+}()
+
+// detects reports whether every detection marker of the profile is in the document
+func (p *ParserProfile) detects(lines []string) bool {
+	if len(p.Detect) == 0 {
+		return false
+	}
+
+	found := make([]bool, len(p.Detect))
+	remaining := len(p.Detect)
+	for _, line := range lines {
+		for i, marker := range p.Detect {
+			if !found[i] && strings.Contains(line, marker) {
+				found[i] = true
+				remaining--
+			}
+		}
+		if remaining == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// translate rewrites the lines of a document in the profile's dialect to the standard template
+func (p *ParserProfile) translate(lines []string) []string {
+	// Replace longer markers first so a marker containing another one wins
+	markers := make([]string, 0, len(p.StatusMarkers))
+	for marker := range p.StatusMarkers {
+		markers = append(markers, marker)
+	}
+	sort.Slice(markers, func(i, j int) bool {
+		if len(markers[i]) != len(markers[j]) {
+			return len(markers[i]) > len(markers[j])
+		}
+		return markers[i] < markers[j]
+	})
+
+	translated := make([]string, len(lines))
+	for i, line := range lines {
+		translated[i] = p.translateLine(line, markers)
+	}
+	return translated
+}
+
+// translateLine rewrites a single line
+func (p *ParserProfile) translateLine(line string, markers []string) string {
+	trimmed := strings.TrimSpace(line)
+
+	if p.SummaryHeading != "" {
+		if match := headingPattern.FindStringSubmatch(trimmed); match != nil && strings.TrimSpace(match[2]) == p.SummaryHeading {
+			return templateSummaryHeading
+		}
+	}
+	if p.ItemStart != "" && strings.Contains(line, p.ItemStart) {
+		return templateItemStart
+	}
+	if p.ItemEnd != "" && strings.Contains(line, p.ItemEnd) {
+		return templateItemEnd
+	}
+
+	for _, marker := range markers {
+		if strings.Contains(line, marker) {
+			line = strings.ReplaceAll(line, marker, "{set:cellbgcolor:"+templateStatusColors[p.StatusMarkers[marker]]+"}")
+		}
+	}
+
+	if strings.HasPrefix(trimmed, "|") {
+		if mapped, ok := p.Categories[strings.TrimSpace(strings.TrimPrefix(trimmed, "|"))]; ok {
+			return "|" + mapped
+		}
+	}
+	return line
+}
+
+// selectProfile returns the profile to parse a document with, or nil for the standard template
+func (o ParseOptions) selectProfile(lines []string) (*ParserProfile, error) {
+	switch o.Profile {
+	case ParserProfileDefault:
+		return nil, nil
+	case "", ParserProfileAuto:
+		for i := range o.Profiles {
+			if o.Profiles[i].detects(lines) {
+				return &o.Profiles[i], nil
+			}
+		}
+		return nil, nil
+	}
+
+	for i := range o.Profiles {
+		if o.Profiles[i].Name == o.Profile {
+			return &o.Profiles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownParserProfile, o.Profile)
+}
+
+// HasParserProfile reports whether a requested profile name can be parsed with
+func (o ParseOptions) HasParserProfile(name string) bool {
+	if name == "" || name == ParserProfileAuto || name == ParserProfileDefault {
+		return true
+	}
+	for _, profile := range o.Profiles {
+		if profile.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -2,10 +2,11 @@
 package utils
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"regexp"
 	"strconv"
 	"strings"
 
@@ -14,141 +15,170 @@ import (
 
 // ParseAsciiDocExecutiveSummary parses an AsciiDoc file and extracts the executive summary
 func ParseAsciiDocExecutiveSummary(filePath string) (*types.ReportSummary, error) {
-	// Read the file content
-	content, err := os.ReadFile(filePath)
+	// Open the file and stream it into the parser
+	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("error reading file: %w", err)
 	}
+	defer file.Close()
 
-	// Convert content to string and split into lines
-	fileContent := string(content)
-	lines := strings.Split(fileContent, "\n")
+	return ParseAsciiDocReader(file)
+}
+
+// ParseAsciiDocContent extracts the executive summary from raw AsciiDoc content
+func ParseAsciiDocContent(content []byte) (*types.ReportSummary, error) {
+	return parseAsciiDocLines(strings.Split(string(content), "\n"), ParseOptions{})
+}
+
+// ParseAsciiDocReader extracts the executive summary from a document read line by line,
+// so the raw bytes never have to be held in memory alongside the parsed lines
+func ParseAsciiDocReader(r io.Reader) (*types.ReportSummary, error) {
+	return ParseAsciiDocReaderWithOptions(r, ParseOptions{})
+}
+
+// ParseAsciiDocReaderWithOptions is ParseAsciiDocReader with parser options
+func ParseAsciiDocReaderWithOptions(r io.Reader, options ParseOptions) (*types.ReportSummary, error) {
+	lines, err := ReadLines(r)
+	if err != nil {
+		return nil, fmt.Errorf("error reading report: %w", err)
+	}
+
+	return parseAsciiDocLines(lines, options)
+}
 
+// ReadLines splits a document into lines exactly like strings.Split(content, "\n").
+// Documents in UTF-16 are transcoded to UTF-8 first and lines that aren't valid
+// UTF-8 are read as Windows-1252, as documents edited in Word often are.
+func ReadLines(r io.Reader) ([]string, error) {
+	var lines []string
+	reader := bufio.NewReaderSize(decodeReader(bufio.NewReaderSize(r, 64*1024)), 64*1024)
+
+	for {
+		line, err := reader.ReadString('\n')
+		if err == io.EOF {
+			return append(lines, decodeLine(line)), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, decodeLine(strings.TrimSuffix(line, "\n")))
+	}
+}
+
+// parseAsciiDocLines extracts the executive summary from the lines of a document
+func parseAsciiDocLines(lines []string, options ParseOptions) (*types.ReportSummary, error) {
 	log.Printf("Processing AsciiDoc report with %d lines", len(lines))
 
+	// Resolve the includes and attributes of modular documents first, so their
+	// sections and summary rows are found like in a single document
+	if options.Format == "" || options.Format == FormatAsciiDoc {
+		var err error
+		if lines, err = preprocessAsciiDoc(lines, options.Includes); err != nil {
+			return nil, err
+		}
+	}
+
+	// Bring Markdown and HTML documents to the template
+	lines, _, err := translateFormat(lines, options.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	// Bring documents written in another dialect to the standard template first
+	profile, err := options.selectProfile(lines)
+	if err != nil {
+		return nil, err
+	}
+	profileName := ParserProfileDefault
+	if profile != nil {
+		profileName = profile.Name
+		lines = profile.translate(lines)
+		log.Printf("Parsing with parser profile %s", profile.Name)
+	}
+
 	// Initialize the report summary
 	summary := &types.ReportSummary{
 		ItemsRequired:      []string{},
 		ItemsRecommended:   []string{},
 		ItemsAdvisory:      []string{},
+		Findings:           []types.Finding{},
 		NoChangeCount:      0,
 		NotApplicableCount: 0,
+		ParserProfile:      profileName,
+		Language:           options.language(),
 	}
 
 	// Extract cluster and customer information
 	summary.ClusterName = ExtractClusterName(lines)
 	summary.CustomerName = ExtractCustomerName(lines)
+	summary.Labels = ExtractLabels(lines)
 
-	// Count items by status and category
-	required, recommended, advisory, noChange, notApplicable := CountAllStatusItems(lines)
+	// Count items by status and category and collect the items in one pass
+	scan := ScanSummary(lines)
+	required, recommended, advisory, noChange, notApplicable :=
+		scan.Required, scan.Recommended, scan.Advisory, scan.NoChange, scan.NotApplicable
 
 	// Set item counts
 	summary.NoChangeCount = noChange
 	summary.NotApplicableCount = notApplicable
 
-	// Calculate overall score - exclude Not Applicable items from the calculation
-	totalValidItems := required + recommended + advisory + noChange
-	if totalValidItems > 0 {
-		weightedSum := float64(noChange*100 + advisory*80 + recommended*50)
-		summary.OverallScore = weightedSum / float64(totalValidItems)
-	} else {
-		summary.OverallScore = 0
-	}
+	// Score the document with the strategies in the configured fallback order
+	order := options.fallbackOrder()
+	score, strategy := firstScore(order, map[string]func() float64{
+		StrategyStatusCounts: func() float64 {
+			// Exclude Not Applicable items from the calculation
+			totalValidItems := required + recommended + advisory + noChange
+			if totalValidItems == 0 {
+				return 0
+			}
+			weightedSum := float64(noChange*100 + advisory*80 + recommended*50)
+			return weightedSum / float64(totalValidItems)
+		},
+		StrategyExplicit: func() float64 { return extractExplicitOverallScore(lines) },
+		StrategyKeyword:  func() float64 { return float64(ExtractGeneralCategoryScore(lines, "overall")) },
+	})
+	summary.OverallScore = score
+	recordStrategy(summary, FieldOverallScore, strategy)
 
 	// Calculate category scores
-	categoryItems := CountStatusByCategory(lines)
-
-	// Set category scores based on actual item counts by category
-	// Infrastructure Setup
-	infraItems := make(map[string]int)
-	infraItems["required"] = categoryItemCount(categoryItems.Required, "Cluster Config")
-	infraItems["recommended"] = categoryItemCount(categoryItems.Recommended, "Cluster Config")
-	infraItems["advisory"] = categoryItemCount(categoryItems.Advisory, "Cluster Config")
-	infraItems["nochange"] = categoryItemCount(categoryItems.NoChange, "Cluster Config")
-	summary.ScoreInfra = CalculateCategoryScore(infraItems, "Infrastructure Setup")
-
-	// Policy Governance
-	govItems := make(map[string]int)
-	govItems["required"] = categoryItemCount(categoryItems.Required, "Security")
-	govItems["recommended"] = categoryItemCount(categoryItems.Recommended, "Security")
-	govItems["advisory"] = categoryItemCount(categoryItems.Advisory, "Security")
-	govItems["nochange"] = categoryItemCount(categoryItems.NoChange, "Security")
-	summary.ScoreGovernance = CalculateCategoryScore(govItems, "Policy Governance")
-
-	// Compliance Benchmarking
-	compItems := make(map[string]int)
-	compItems["required"] = 0 // Direct compliance items are less common
-	compItems["recommended"] = categoryItemCount(categoryItems.Recommended, "Performance")
-	compItems["advisory"] = categoryItemCount(categoryItems.Advisory, "Performance")
-	compItems["nochange"] = categoryItemCount(categoryItems.NoChange, "Performance")
-	summary.ScoreCompliance = CalculateCategoryScore(compItems, "Compliance Benchmarking")
-
-	// Monitoring
-	monItems := make(map[string]int)
-	monItems["required"] = 0
-	monItems["recommended"] = categoryItemCount(categoryItems.Recommended, "Op-Ready")
-	monItems["advisory"] = categoryItemCount(categoryItems.Advisory, "Op-Ready")
-	monItems["nochange"] = categoryItemCount(categoryItems.NoChange, "Op-Ready")
-	summary.ScoreMonitoring = CalculateCategoryScore(monItems, "Monitoring")
-
-	// Build/Deploy Security
-	buildItems := make(map[string]int)
-	buildItems["required"] = 0
-	buildItems["recommended"] = categoryItemCount(categoryItems.Recommended, "Applications")
-	buildItems["advisory"] = categoryItemCount(categoryItems.Advisory, "Applications")
-	buildItems["nochange"] = categoryItemCount(categoryItems.NoChange, "Applications")
-	summary.ScoreBuildSecurity = CalculateCategoryScore(buildItems, "Build/Deploy Security")
-
-	// If calculated scores are still 0, try falling back to extracted scores
-	if summary.ScoreInfra == 0 {
-		summary.ScoreInfra = ExtractCategoryScore(lines, "Infrastructure Setup")
-	}
-	if summary.ScoreGovernance == 0 {
-		summary.ScoreGovernance = ExtractCategoryScore(lines, "Policy Governance")
-	}
-	if summary.ScoreCompliance == 0 {
-		summary.ScoreCompliance = ExtractCategoryScore(lines, "Compliance Benchmarking")
-	}
-	if summary.ScoreMonitoring == 0 {
-		summary.ScoreMonitoring = ExtractCategoryScore(lines, "Central Monitoring")
-		if summary.ScoreMonitoring == 0 {
-			summary.ScoreMonitoring = ExtractCategoryScore(lines, "Monitoring")
-		}
-	}
-	if summary.ScoreBuildSecurity == 0 {
-		summary.ScoreBuildSecurity = ExtractCategoryScore(lines, "Build/Deploy Security")
+	categoryItems := scan.ByCategory
+	for _, category := range parserCategories {
+		score, strategy := firstScore(order, category.strategies(lines, categoryItems))
+		*category.score(summary) = int(score)
+		recordStrategy(summary, category.field, strategy)
 	}
 
 	// Extract or generate category descriptions
 	summary.InfraDescription = ExtractCategoryDescription(lines, "Infrastructure Setup")
 	if summary.InfraDescription == "" {
-		summary.InfraDescription = GenerateDescription("Infrastructure Setup", summary.ScoreInfra)
+		summary.InfraDescription = GenerateDescriptionIn(summary.Language, "Infrastructure Setup", summary.ScoreInfra)
 	}
 
 	summary.GovernanceDescription = ExtractCategoryDescription(lines, "Policy Governance")
 	if summary.GovernanceDescription == "" {
-		summary.GovernanceDescription = GenerateDescription("Policy Governance", summary.ScoreGovernance)
+		summary.GovernanceDescription = GenerateDescriptionIn(summary.Language, "Policy Governance", summary.ScoreGovernance)
 	}
 
 	summary.ComplianceDescription = ExtractCategoryDescription(lines, "Compliance Benchmarking")
 	if summary.ComplianceDescription == "" {
-		summary.ComplianceDescription = GenerateDescription("Compliance Benchmarking", summary.ScoreCompliance)
+		summary.ComplianceDescription = GenerateDescriptionIn(summary.Language, "Compliance Benchmarking", summary.ScoreCompliance)
 	}
 
 	summary.MonitoringDescription = ExtractCategoryDescription(lines, "Central Monitoring")
 	if summary.MonitoringDescription == "" {
-		summary.MonitoringDescription = GenerateDescription("Monitoring", summary.ScoreMonitoring)
+		summary.MonitoringDescription = GenerateDescriptionIn(summary.Language, "Monitoring", summary.ScoreMonitoring)
 	}
 
 	summary.BuildSecurityDescription = ExtractCategoryDescription(lines, "Build/Deploy Security")
 	if summary.BuildSecurityDescription == "" {
-		summary.BuildSecurityDescription = GenerateDescription("Build/Deploy Security", summary.ScoreBuildSecurity)
+		summary.BuildSecurityDescription = GenerateDescriptionIn(summary.Language, "Build/Deploy Security", summary.ScoreBuildSecurity)
 	}
 
 	// Extract items from the Summary section
-	summary.ItemsRequired = ExtractRequiredChanges(lines)
-	summary.ItemsRecommended = ExtractRecommendedChanges(lines)
-	summary.ItemsAdvisory = ExtractAdvisoryActions(lines)
+	summary.ItemsRequired = scan.ItemsRequired
+	summary.ItemsRecommended = scan.ItemsRecommended
+	summary.ItemsAdvisory = scan.ItemsAdvisory
+	summary.Findings = ExtractFindingsWithCategories(lines, options.Categories)
 
 	// If we have no items, use counts to create placeholder items
 	if len(summary.ItemsRequired) == 0 && required > 0 {
@@ -191,6 +221,79 @@ func categoryItemCount(items map[string]int, category string) int {
 	return count
 }
 
+// parserCategory describes how the score of one template category is extracted
+type parserCategory struct {
+	field string
+
+	// names are the headings the document may state the score under, in order
+	names []string
+
+	// countsAs is the category name of the summary table rows; required items
+	// only count against categories where the template uses them
+	countsAs        string
+	countsRequired  bool
+	calculationName string
+
+	score func(summary *types.ReportSummary) *int
+}
+
+// parserCategories are the template categories in the order they are scored
+var parserCategories = []parserCategory{
+	{
+		field: FieldScoreInfra, names: []string{"Infrastructure Setup"},
+		countsAs: "Cluster Config", countsRequired: true, calculationName: "Infrastructure Setup",
+		score: func(s *types.ReportSummary) *int { return &s.ScoreInfra },
+	},
+	{
+		field: FieldScoreGovernance, names: []string{"Policy Governance"},
+		countsAs: "Security", countsRequired: true, calculationName: "Policy Governance",
+		score: func(s *types.ReportSummary) *int { return &s.ScoreGovernance },
+	},
+	{
+		field: FieldScoreCompliance, names: []string{"Compliance Benchmarking"},
+		countsAs: "Performance", calculationName: "Compliance Benchmarking",
+		score: func(s *types.ReportSummary) *int { return &s.ScoreCompliance },
+	},
+	{
+		field: FieldScoreMonitoring, names: []string{"Central Monitoring", "Monitoring"},
+		countsAs: "Op-Ready", calculationName: "Monitoring",
+		score: func(s *types.ReportSummary) *int { return &s.ScoreMonitoring },
+	},
+	{
+		field: FieldScoreBuildSecurity, names: []string{"Build/Deploy Security"},
+		countsAs: "Applications", calculationName: "Build/Deploy Security",
+		score: func(s *types.ReportSummary) *int { return &s.ScoreBuildSecurity },
+	},
+}
+
+// strategies returns the score strategies of a category
+func (c parserCategory) strategies(lines []string, items *ItemsByCategory) map[string]func() float64 {
+	return map[string]func() float64{
+		StrategyStatusCounts: func() float64 {
+			counts := map[string]int{
+				"recommended": categoryItemCount(items.Recommended, c.countsAs),
+				"advisory":    categoryItemCount(items.Advisory, c.countsAs),
+				"nochange":    categoryItemCount(items.NoChange, c.countsAs),
+			}
+			if c.countsRequired {
+				counts["required"] = categoryItemCount(items.Required, c.countsAs)
+			}
+			return float64(CalculateCategoryScore(counts, c.calculationName))
+		},
+		StrategyExplicit: func() float64 {
+			for _, name := range c.names {
+				if score := extractExplicitCategoryScore(lines, name); score > 0 {
+					return float64(score)
+				}
+			}
+			return 0
+		},
+		StrategyKeyword: func() float64 {
+			return float64(ExtractGeneralCategoryScore(lines, strings.Split(c.names[0], " ")...))
+		},
+	}
+}
+
 // Enhanced item extraction from sections
 func enhancedItemExtraction(lines []string) ([]string, []string, []string) {
 	var requiredItems, recommendedItems, advisoryItems []string
@@ -331,10 +434,9 @@ func calculateFallbackScore(lines []string) float64 {
 	categoryCount := 0
 
 	// Look for any percentage in the document that might indicate a score
-	re := regexp.MustCompile(`(\d+)%`)
 	for _, line := range lines {
 		if !strings.Contains(line, "cellbgcolor") && strings.Contains(line, "%") {
-			matches := re.FindStringSubmatch(line)
+			matches := percentPattern.FindStringSubmatch(line)
 			if len(matches) > 1 {
 				score, err := strconv.ParseFloat(matches[1], 64)
 				if err == nil && score > 0 && score <= 100 {
@@ -384,8 +486,7 @@ func extractItemsByColorCode(lines []string, colorCode string, itemType string)
 			// Look up a few lines for item name
 			for j := max(0, i-5); j < i; j++ {
 				if strings.Contains(lines[j], "<<") && strings.Contains(lines[j], ">>") {
-					re := regexp.MustCompile(`<<([^>]+)>>`)
-					matches := re.FindStringSubmatch(lines[j])
+					matches := itemNamePattern.FindStringSubmatch(lines[j])
 					if len(matches) > 1 {
 						itemName = matches[1]
 						break
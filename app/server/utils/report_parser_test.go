@@ -0,0 +1,43 @@
+// app/server/utils/report_parser_test.go
+package utils
+
+import (
+	"io"
+	"log"
+	"testing"
+)
+
+// BenchmarkParseReport parses a 50k-line report end to end
+func BenchmarkParseReport(b *testing.B) {
+	lines := benchmarkReport(50000)
+	output := log.Writer()
+	log.SetOutput(io.Discard)
+	b.Cleanup(func() { log.SetOutput(output) })
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parseAsciiDocLines(lines, ParseOptions{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkExtractCategoryScore looks up the scores of every category in a
+// report that doesn't state them, so the keyword search runs over every line
+func BenchmarkExtractCategoryScore(b *testing.B) {
+	lines := benchmarkReport(50000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, category := range parserCategories {
+			ExtractCategoryScore(lines, category.names[0])
+		}
+	}
+}
+
+// BenchmarkExtractItemsByColorCode extracts the required items by their marker
+func BenchmarkExtractItemsByColorCode(b *testing.B) {
+	lines := benchmarkReport(50000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		extractItemsByColorCode(lines, markerRequired, "Required")
+	}
+}
@@ -0,0 +1,278 @@
+// app/server/utils/summary_scan.go
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Status color markers of the Summary table
+const (
+	markerRequired      = "{set:cellbgcolor:#FF0000}"
+	markerRecommended   = "{set:cellbgcolor:#FEFE20}"
+	markerAdvisory      = "{set:cellbgcolor:#80E5FF}"
+	markerNoChange      = "{set:cellbgcolor:#00FF00}"
+	markerNotApplicable = "{set:cellbgcolor:#A6B9BF}"
+
+	itemStartMarker = "// ------------------------ITEM START"
+	itemEndMarker   = "// ------------------------ITEM END"
+)
+
+// itemNamePattern matches the cross reference naming a Summary item
+var itemNamePattern = regexp.MustCompile(`<<([^>]+)>>`)
+
+// legendTexts are the texts of the legend rows of the Summary table
+var legendTexts = []string{
+	"Indicates Changes Required",
+	"Indicates Changes Recommended",
+	"No advise given",
+	"No change required",
+	"Not yet evaluated",
+}
+
+// SummaryScan holds the status counts and the items of the Summary section
+type SummaryScan struct {
+	Required      int
+	Recommended   int
+	Advisory      int
+	NoChange      int
+	NotApplicable int
+
+	ByCategory *ItemsByCategory
+
+	ItemsRequired    []string
+	ItemsRecommended []string
+	ItemsAdvisory    []string
+}
+
+// itemList is an item status with the marker that keeps an item in its list
+type itemList struct {
+	marker string
+	legend string
+}
+
+// itemLists are the item statuses extracted from the Summary section
+var itemLists = [3]itemList{
+	{marker: markerRequired, legend: "Indicates Changes Required"},
+	{marker: markerRecommended, legend: "Indicates Changes Recommended"},
+	{marker: markerAdvisory, legend: "No advise given"},
+}
+
+// ScanSummary reads the status counts, the counts by category and the
+// required, recommended and advisory items of the Summary section in a single
+// traversal. The results match those of CountAllStatusItems,
+// CountStatusByCategory and the Extract*Changes functions.
+func ScanSummary(lines []string) *SummaryScan {
+	scan := &SummaryScan{
+		ByCategory: &ItemsByCategory{
+			Required:      make(map[string]int),
+			Recommended:   make(map[string]int),
+			Advisory:      make(map[string]int),
+			NoChange:      make(map[string]int),
+			NotApplicable: make(map[string]int),
+		},
+	}
+
+	start, end := summaryBounds(lines)
+	if start == -1 {
+		return scan
+	}
+
+	// Status counts: item blocks and the first table
+	countItem, countTable, countDone := false, false, false
+
+	// Counts by category: every table, with the category of the last plain cell
+	categoryTable := false
+	var currentCategory, currentStatus string
+
+	// Items: each list follows the item blocks until another status shows up
+	var alive [len(itemLists)]bool
+	var items [len(itemLists)][]string
+	var itemName, observation string
+
+	for _, line := range lines[start:end] {
+		trimmed := strings.TrimSpace(line)
+		isItemStart := strings.Contains(line, itemStartMarker)
+		isItemEnd := strings.Contains(line, itemEndMarker)
+		isTable := strings.Contains(line, "|===")
+
+		if !countDone {
+			scan.countStatus(line, isItemStart, isItemEnd, isTable, &countItem, &countTable, &countDone)
+		}
+
+		if isTable {
+			categoryTable = !categoryTable
+		} else if categoryTable {
+			scan.countCategory(trimmed, &currentCategory, &currentStatus)
+		}
+
+		switch {
+		case isItemStart:
+			for i := range alive {
+				alive[i] = true
+			}
+			itemName, observation = "", ""
+		case isItemEnd:
+			if itemName != "" {
+				item := itemName
+				if observation != "" {
+					item = fmt.Sprintf("%s: %s", itemName, observation)
+				}
+				for i := range alive {
+					if alive[i] {
+						items[i] = append(items[i], item)
+					}
+				}
+			}
+			alive = [len(itemLists)]bool{}
+		case alive != [len(itemLists)]bool{}:
+			if strings.Contains(line, "<<") && strings.Contains(line, ">>") {
+				if matches := itemNamePattern.FindStringSubmatch(line); len(matches) > 1 {
+					itemName = strings.TrimSpace(matches[1])
+				}
+				continue
+			}
+			if itemName != "" && observation == "" &&
+				!strings.HasPrefix(line, "//") && !strings.Contains(line, "{set:cellbgcolor") {
+				if strings.HasPrefix(line, "|") {
+					line = strings.TrimSpace(line[1:])
+				}
+				if line != "" {
+					observation = line
+				}
+				continue
+			}
+			if !strings.Contains(line, "set:cellbgcolor:") {
+				continue
+			}
+			for i, list := range itemLists {
+				if !strings.Contains(line, list.marker) || strings.Contains(line, list.legend) {
+					alive[i] = false
+				}
+			}
+		}
+	}
+
+	scan.ItemsRequired = items[0]
+	scan.ItemsRecommended = items[1]
+	scan.ItemsAdvisory = items[2]
+	return scan
+}
+
+// countStatus counts the status markers of a line in the item blocks and the
+// first table, which ends the counting
+func (s *SummaryScan) countStatus(line string, isItemStart, isItemEnd, isTable bool, inItem, inTable, done *bool) {
+	switch {
+	case isItemStart:
+		*inItem = true
+		return
+	case isItemEnd:
+		*inItem = false
+		return
+	case isTable && !*inTable:
+		*inTable = true
+		return
+	case isTable:
+		*inTable = false
+		*done = true
+		return
+	}
+
+	if *inTable && (strings.Contains(line, "*Category*") || containsLegend(line)) {
+		return
+	}
+	if (!*inTable && !*inItem) || strings.Contains(line, "Description") {
+		return
+	}
+
+	switch {
+	case strings.Contains(line, markerRequired):
+		s.Required++
+	case strings.Contains(line, markerRecommended):
+		s.Recommended++
+	case strings.Contains(line, markerAdvisory):
+		s.Advisory++
+	case strings.Contains(line, markerNoChange):
+		s.NoChange++
+	case strings.Contains(line, markerNotApplicable):
+		s.NotApplicable++
+	}
+}
+
+// countCategory counts a status marker of a table line under the category of
+// the last plain cell
+func (s *SummaryScan) countCategory(line string, category, status *string) {
+	if strings.HasPrefix(line, "|") && !strings.Contains(line, "cellbgcolor") {
+		*category = strings.TrimSpace(strings.TrimPrefix(line, "|"))
+		return
+	}
+
+	switch {
+	case strings.Contains(line, markerRequired):
+		*status = "required"
+	case strings.Contains(line, markerRecommended):
+		*status = "recommended"
+	case strings.Contains(line, markerAdvisory):
+		*status = "advisory"
+	case strings.Contains(line, markerNoChange):
+		*status = "nochange"
+	case strings.Contains(line, markerNotApplicable):
+		*status = "notapplicable"
+	}
+
+	if *category == "" || *status == "" {
+		return
+	}
+	if containsLegend(line) {
+		*status = ""
+		return
+	}
+
+	switch *status {
+	case "required":
+		s.ByCategory.Required[*category]++
+	case "recommended":
+		s.ByCategory.Recommended[*category]++
+	case "advisory":
+		s.ByCategory.Advisory[*category]++
+	case "nochange":
+		s.ByCategory.NoChange[*category]++
+	case "notapplicable":
+		s.ByCategory.NotApplicable[*category]++
+	}
+	*status = ""
+}
+
+// summaryBounds returns the line range of the Summary section, which ends at
+// the next section heading; start is -1 without a Summary section
+func summaryBounds(lines []string) (int, int) {
+	start := -1
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "= Summary" {
+			start = i
+			break
+		}
+	}
+	if start == -1 {
+		return -1, -1
+	}
+
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "=") &&
+			!strings.Contains(lines[i], "= Summary") {
+			return start, i
+		}
+	}
+	return start, len(lines)
+}
+
+// containsLegend reports whether a line is a legend row of the Summary table
+func containsLegend(line string) bool {
+	for _, legend := range legendTexts {
+		if strings.Contains(line, legend) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+// app/server/utils/summary_scan_test.go
+package utils
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// benchmarkStatuses are the status cells cycled through by benchmarkReport
+var benchmarkStatuses = []string{
+	markerRequired + "\nChanges Required",
+	markerRecommended + "\nChanges Recommended",
+	markerAdvisory + "\nAdvisory",
+	markerNoChange + "\nNo Change",
+	markerNotApplicable + "\nNot Applicable",
+}
+
+// benchmarkCategories are the categories cycled through by benchmarkReport
+var benchmarkCategories = []string{"Cluster Config", "Security", "Performance", "Op-Ready", "Applications"}
+
+// benchmarkReport generates a report of about the given number of lines in the
+// standard template, with most of them in the Summary table
+func benchmarkReport(lineCount int) []string {
+	lines := []string{
+		"= Health Check Report",
+		"",
+		"Red Hat conducted a health check of Acme Corp's OpenShift cluster 'bench'.",
+		"",
+		"= Summary",
+		"",
+		`[cols="1,2,2,3", options=header]`,
+		"|===",
+		"|*Category*",
+		"|*Item Evaluated*",
+		"|*Observed Result*",
+		"|*Recommendation*",
+		"",
+	}
+
+	// Leave a tenth of the document to the detail sections
+	var names []string
+	for i := 0; len(lines) < lineCount*9/10; i++ {
+		name := fmt.Sprintf("Item %d", i)
+		names = append(names, name)
+		lines = append(lines,
+			"// ------------------------ITEM START",
+			"|"+benchmarkCategories[i%len(benchmarkCategories)],
+			"|<<"+name+">>",
+			fmt.Sprintf("|Observation for item %d", i),
+			"|"+benchmarkStatuses[i%len(benchmarkStatuses)],
+			"// ------------------------ITEM END",
+			"",
+		)
+	}
+	lines = append(lines, "|===", "")
+
+	for i := 0; len(lines) < lineCount; i++ {
+		lines = append(lines, "= "+names[i%len(names)], "", "Details of the item.", "")
+	}
+	return lines
+}
+
+// summaryScanBaselines are what the separate scanners the parser used before
+// ScanSummary, CountAllStatusItems, CountStatusByCategory and the
+// Extract*Changes functions, returned for the documents of the corpus;
+// ScanSummary must return the same
+var summaryScanBaselines = []struct {
+	file          string
+	required      int
+	recommended   int
+	advisory      int
+	noChange      int
+	notApplicable int
+
+	itemsRequired    []string
+	itemsRecommended []string
+	itemsAdvisory    []string
+}{
+	{"standard.adoc", 2, 1, 1, 2, 1,
+		[]string{"Cluster Version: The cluster runs an unsupported version.", "Identity Providers: kubeadmin is still present."},
+		[]string{"Node Sizing: Worker nodes are undersized for the workload."},
+		[]string{"Network Policies: Default deny policies are missing in some namespaces."}},
+	{"crlf.adoc", 2, 1, 1, 2, 1,
+		[]string{"Cluster Version: The cluster runs an unsupported version.", "Identity Providers: kubeadmin is still present."},
+		[]string{"Node Sizing: Worker nodes are undersized for the workload."},
+		[]string{"Network Policies: Default deny policies are missing in some namespaces."}},
+	{"windows-1252.adoc", 2, 1, 1, 2, 1,
+		[]string{"Cluster Version: The cluster runs an \u201cunsupported\u201d version.", "Identity Providers: kubeadmin is still present."},
+		[]string{"Node Sizing: Worker nodes are undersized for the workload."},
+		[]string{"Network Policies: Default deny policies are missing in some namespaces."}},
+	{"utf-16.adoc", 2, 1, 1, 2, 1,
+		[]string{"Cluster Version: The cluster runs an unsupported version.", "Identity Providers: kubeadmin is still present."},
+		[]string{"Node Sizing: Worker nodes are undersized for the workload."},
+		[]string{"Network Policies: Default deny policies are missing in some namespaces."}},
+	{"modular/index.adoc", 1, 1, 0, 1, 0,
+		[]string{"Audit Logging: Audit log forwarding is not configured for ocp-stage."},
+		[]string{"Machine Config Pools: Pools are paused."},
+		nil},
+	{"markdown.md", 1, 2, 1, 1, 1,
+		[]string{"Etcd Backup: No scheduled backup"},
+		[]string{"Image Registries: Insecure registries allowed", "SCC Usage: anyuid granted to 2 service accounts"},
+		[]string{"Resource Quotas: Quotas set on most projects"}},
+	{"rendered.html", 1, 1, 0, 1, 0,
+		[]string{"Ingress Controller: A single replica serves all routes."},
+		[]string{"API Certificates: Self-signed certificates are in use."},
+		nil},
+	{"draft.adoc", 0, 0, 0, 0, 0, nil, nil, nil},
+}
+
+// summaryLines returns the lines of a corpus document as the parser hands
+// them to ScanSummary: with includes resolved and translated to the template
+func summaryLines(t *testing.T, name string) []string {
+	t.Helper()
+	path := filepath.Join(corpusDir, filepath.FromSlash(name))
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	lines, err := ReadLines(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	format := FormatForFilename(name)
+	if format == "" || format == FormatAsciiDoc {
+		if lines, err = preprocessAsciiDoc(lines, DirIncludes(filepath.Dir(path))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lines, _, err = translateFormat(lines, format)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
+func TestScanSummaryBaselines(t *testing.T) {
+	output := log.Writer()
+	log.SetOutput(io.Discard)
+	t.Cleanup(func() { log.SetOutput(output) })
+
+	for _, baseline := range summaryScanBaselines {
+		t.Run(baseline.file, func(t *testing.T) {
+			scan := ScanSummary(summaryLines(t, baseline.file))
+
+			counts := []struct {
+				status     string
+				got, want  int
+				byCategory map[string]int
+			}{
+				{"required", scan.Required, baseline.required, scan.ByCategory.Required},
+				{"recommended", scan.Recommended, baseline.recommended, scan.ByCategory.Recommended},
+				{"advisory", scan.Advisory, baseline.advisory, scan.ByCategory.Advisory},
+				{"no change", scan.NoChange, baseline.noChange, scan.ByCategory.NoChange},
+				{"not applicable", scan.NotApplicable, baseline.notApplicable, scan.ByCategory.NotApplicable},
+			}
+			for _, count := range counts {
+				if count.got != count.want {
+					t.Errorf("%s: %d items, want %d", count.status, count.got, count.want)
+				}
+				total := 0
+				for _, n := range count.byCategory {
+					total += n
+				}
+				if total != count.want {
+					t.Errorf("%s: %d items by category, want %d", count.status, total, count.want)
+				}
+			}
+
+			items := []struct {
+				status    string
+				got, want []string
+			}{
+				{"required", scan.ItemsRequired, baseline.itemsRequired},
+				{"recommended", scan.ItemsRecommended, baseline.itemsRecommended},
+				{"advisory", scan.ItemsAdvisory, baseline.itemsAdvisory},
+			}
+			for _, list := range items {
+				if !reflect.DeepEqual(list.got, list.want) {
+					t.Errorf("%s items:\n got: %q\nwant: %q", list.status, list.got, list.want)
+				}
+			}
+		})
+	}
+}
+
+// TestScanSummaryGenerated checks ScanSummary on a large generated report,
+// where the separate scanners found every fifth item of each status
+func TestScanSummaryGenerated(t *testing.T) {
+	scan := ScanSummary(benchmarkReport(2000))
+	if scan.Required != 52 || scan.Recommended != 51 || scan.Advisory != 51 || scan.NoChange != 51 || scan.NotApplicable != 51 {
+		t.Errorf("counts %d/%d/%d/%d/%d, want 52/51/51/51/51",
+			scan.Required, scan.Recommended, scan.Advisory, scan.NoChange, scan.NotApplicable)
+	}
+	if len(scan.ItemsRequired) != 52 || scan.ItemsRequired[1] != "Item 5: Observation for item 5" {
+		t.Errorf("required items %q", scan.ItemsRequired)
+	}
+	if len(scan.ItemsRecommended) != 51 || len(scan.ItemsAdvisory) != 51 {
+		t.Errorf("%d recommended and %d advisory items, want 51 and 51", len(scan.ItemsRecommended), len(scan.ItemsAdvisory))
+	}
+}
+
+// BenchmarkScanSummary scans the Summary section of a large report
+func BenchmarkScanSummary(b *testing.B) {
+	lines := benchmarkReport(50000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ScanSummary(lines)
+	}
+}
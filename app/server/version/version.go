@@ -0,0 +1,74 @@
+// app/server/version/version.go
+
+// Package version describes the build of the running binary. Release builds
+// set the variables with the linker, e.g.
+//
+//	go build -ldflags "-X github.com/ayaseen/openshift-health-dashboard/app/server/version.Version=v0.2.0"
+//
+// Builds without them fall back to the VCS details Go records in the binary.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Set with -ldflags "-X ..." at build time
+var (
+	// Version is the release version, e.g. v0.2.0
+	Version = "dev"
+
+	// Commit is the git commit the binary was built from
+	Commit = ""
+
+	// BuildDate is when the binary was built, in RFC 3339 format
+	BuildDate = ""
+)
+
+// Info describes the build of the running binary
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	BuildDate string `json:"buildDate,omitempty"`
+	GoVersion string `json:"goVersion"`
+
+	// Modified is set when the binary was built from a working tree with changes
+	Modified bool `json:"modified,omitempty"`
+}
+
+// Get returns the build information, filling in the commit and date from the
+// VCS details recorded by go build when the linker didn't set them
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, BuildDate: BuildDate, GoVersion: runtime.Version()}
+
+	build, ok := debug.ReadBuildInfo()
+	if !ok {
+		return info
+	}
+	for _, setting := range build.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			if info.Commit == "" {
+				info.Commit = setting.Value
+			}
+		case "vcs.time":
+			if info.BuildDate == "" {
+				info.BuildDate = setting.Value
+			}
+		case "vcs.modified":
+			info.Modified = setting.Value == "true"
+		}
+	}
+	return info
+}
+
+// String formats the build for log messages, e.g. "v0.2.0 (1a2b3c4)"
+func (i Info) String() string {
+	if len(i.Commit) > 7 {
+		return i.Version + " (" + i.Commit[:7] + ")"
+	}
+	if i.Commit != "" {
+		return i.Version + " (" + i.Commit + ")"
+	}
+	return i.Version
+}
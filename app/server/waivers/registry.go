@@ -0,0 +1,303 @@
+// app/server/waivers/registry.go
+
+// Package waivers suppresses accepted findings. A waiver matches findings by
+// ID, title or category, on every cluster or on one, until it expires;
+// suppressed findings are left out of the scores and listed separately.
+package waivers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// ErrNotFound is returned when a waiver does not exist
+var ErrNotFound = errors.New("waiver not found")
+
+// Waiver suppresses the findings matching all of its set criteria
+type Waiver struct {
+	ID string `json:"id" yaml:"id"`
+
+	// FindingID, Title and Category select findings; at least one is required
+	FindingID string `json:"findingId,omitempty" yaml:"findingId,omitempty"`
+	Title     string `json:"title,omitempty" yaml:"title,omitempty"`
+	Category  string `json:"category,omitempty" yaml:"category,omitempty"`
+
+	// Cluster limits the waiver to one cluster; empty waives on every cluster
+	Cluster string `json:"cluster,omitempty" yaml:"cluster,omitempty"`
+
+	Justification string     `json:"justification" yaml:"justification"`
+	ExpiresAt     *time.Time `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty"`
+	CreatedBy     string     `json:"createdBy,omitempty" yaml:"createdBy,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt" yaml:"createdAt"`
+}
+
+// Active reports whether the waiver is in effect at the given time
+func (w Waiver) Active(now time.Time) bool {
+	return w.ExpiresAt == nil || now.Before(*w.ExpiresAt)
+}
+
+// Matches reports whether the waiver suppresses a finding of a cluster
+func (w Waiver) Matches(finding types.Finding, cluster string) bool {
+	if w.Cluster != "" && !strings.EqualFold(w.Cluster, cluster) {
+		return false
+	}
+	if w.FindingID != "" && w.FindingID != finding.ID {
+		return false
+	}
+	if w.Title != "" && !strings.EqualFold(w.Title, strings.TrimSpace(finding.Title)) {
+		return false
+	}
+	if w.Category != "" && !strings.EqualFold(w.Category, finding.Category) {
+		return false
+	}
+	return true
+}
+
+// Registry persists waivers in a JSON file
+type Registry struct {
+	path    string
+	mu      sync.RWMutex
+	waivers map[string]*Waiver
+}
+
+// New loads the waivers stored at path
+func New(path string) (*Registry, error) {
+	r := &Registry{path: path}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload re-reads the waivers file, replacing the registry contents only if it parses
+func (r *Registry) Reload() error {
+	waivers := make(map[string]*Waiver)
+
+	data, err := os.ReadFile(r.path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error reading waivers: %w", err)
+	}
+	if err == nil {
+		var list []*Waiver
+		if err := json.Unmarshal(data, &list); err != nil {
+			return fmt.Errorf("error parsing waivers: %w", err)
+		}
+		for _, waiver := range list {
+			waivers[waiver.ID] = waiver
+		}
+		log.Printf("Loaded %d waivers from %s", len(waivers), r.path)
+	}
+
+	r.mu.Lock()
+	r.waivers = waivers
+	r.mu.Unlock()
+	return nil
+}
+
+// List returns all waivers, expired ones included, newest first
+func (r *Registry) List() []Waiver {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	waivers := make([]Waiver, 0, len(r.waivers))
+	for _, waiver := range r.waivers {
+		waivers = append(waivers, *waiver)
+	}
+	sort.Slice(waivers, func(i, j int) bool {
+		if !waivers[i].CreatedAt.Equal(waivers[j].CreatedAt) {
+			return waivers[i].CreatedAt.After(waivers[j].CreatedAt)
+		}
+		return waivers[i].ID < waivers[j].ID
+	})
+	return waivers
+}
+
+// Get returns a waiver by ID
+func (r *Registry) Get(id string) (Waiver, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	waiver, ok := r.waivers[id]
+	if !ok {
+		return Waiver{}, ErrNotFound
+	}
+	return *waiver, nil
+}
+
+// Create adds a new waiver with a generated ID
+func (r *Registry) Create(waiver Waiver) (Waiver, error) {
+	if err := Validate(&waiver); err != nil {
+		return Waiver{}, err
+	}
+	waiver.ID = newID()
+	waiver.CreatedAt = time.Now().UTC()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.waivers[waiver.ID] = &waiver
+	if err := r.saveLocked(); err != nil {
+		delete(r.waivers, waiver.ID)
+		return Waiver{}, err
+	}
+	return waiver, nil
+}
+
+// Delete removes a waiver, restoring the findings it suppressed
+func (r *Registry) Delete(id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.waivers[id]
+	if !ok {
+		return ErrNotFound
+	}
+
+	delete(r.waivers, id)
+	if err := r.saveLocked(); err != nil {
+		r.waivers[id] = existing
+		return err
+	}
+	return nil
+}
+
+// Find returns the first waiver in effect at the given time that suppresses a finding
+func (r *Registry) Find(finding types.Finding, cluster string, now time.Time) (Waiver, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Prefer the oldest waiver so the reported one doesn't change as waivers are added
+	var found *Waiver
+	for _, waiver := range r.waivers {
+		if !waiver.Active(now) || !waiver.Matches(finding, cluster) {
+			continue
+		}
+		if found == nil || waiver.CreatedAt.Before(found.CreatedAt) ||
+			waiver.CreatedAt.Equal(found.CreatedAt) && waiver.ID < found.ID {
+			found = waiver
+		}
+	}
+	if found == nil {
+		return Waiver{}, false
+	}
+	return *found, true
+}
+
+// Replace replaces every waiver with the given ones, keeping their IDs and
+// creation times. Expired waivers are accepted so their history carries over.
+// Nothing is replaced when a waiver is invalid.
+func (r *Registry) Replace(list []Waiver) error {
+	waivers, err := prepareReplacement(list)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	previous := r.waivers
+	r.waivers = waivers
+	if err := r.saveLocked(); err != nil {
+		r.waivers = previous
+		return err
+	}
+	return nil
+}
+
+// CheckReplacement validates the waivers of a replacement without applying it
+func CheckReplacement(list []Waiver) error {
+	_, err := prepareReplacement(list)
+	return err
+}
+
+// prepareReplacement validates the waivers of a replacement, filling in
+// missing IDs and creation times
+func prepareReplacement(list []Waiver) (map[string]*Waiver, error) {
+	now := time.Now().UTC()
+	waivers := make(map[string]*Waiver, len(list))
+	for _, waiver := range list {
+		if err := validateDefinition(&waiver); err != nil {
+			return nil, err
+		}
+		if waiver.ID == "" {
+			waiver.ID = newID()
+		}
+		if waiver.CreatedAt.IsZero() {
+			waiver.CreatedAt = now
+		}
+		if _, ok := waivers[waiver.ID]; ok {
+			return nil, fmt.Errorf("waiver %s is listed twice", waiver.ID)
+		}
+		waivers[waiver.ID] = &waiver
+	}
+	return waivers, nil
+}
+
+// Validate checks and normalizes a waiver definition
+func Validate(waiver *Waiver) error {
+	if err := validateDefinition(waiver); err != nil {
+		return err
+	}
+	if waiver.ExpiresAt != nil && !waiver.ExpiresAt.After(time.Now()) {
+		return errors.New("expiresAt must be in the future")
+	}
+	return nil
+}
+
+// validateDefinition checks and normalizes what a waiver matches and why
+func validateDefinition(waiver *Waiver) error {
+	waiver.FindingID = strings.TrimSpace(waiver.FindingID)
+	waiver.Title = strings.TrimSpace(waiver.Title)
+	waiver.Category = strings.TrimSpace(waiver.Category)
+	waiver.Cluster = strings.TrimSpace(waiver.Cluster)
+	waiver.Justification = strings.TrimSpace(waiver.Justification)
+
+	if waiver.FindingID == "" && waiver.Title == "" && waiver.Category == "" {
+		return errors.New("a waiver needs a findingId, title or category to match")
+	}
+	if waiver.Justification == "" {
+		return errors.New("a waiver needs a justification")
+	}
+	return nil
+}
+
+// saveLocked writes the waivers to disk; r.mu must be held
+func (r *Registry) saveLocked() error {
+	waivers := make([]*Waiver, 0, len(r.waivers))
+	for _, waiver := range r.waivers {
+		waivers = append(waivers, waiver)
+	}
+	sort.Slice(waivers, func(i, j int) bool { return waivers[i].ID < waivers[j].ID })
+
+	data, err := json.MarshalIndent(waivers, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding waivers: %w", err)
+	}
+
+	// Write to a temp file first so a crash never leaves a truncated file
+	if err := os.WriteFile(r.path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("error writing waivers: %w", err)
+	}
+	if err := os.Rename(r.path+".tmp", r.path); err != nil {
+		return fmt.Errorf("error writing waivers: %w", err)
+	}
+	return nil
+}
+
+// newID returns a random waiver ID
+func newID() string {
+	buf := make([]byte, 6)
+	rand.Read(buf)
+	return "w-" + hex.EncodeToString(buf)
+}
@@ -0,0 +1,223 @@
+// app/web/assetgen/main.go
+//
+// assetgen prepares the dashboard's static files for embedding: it copies them
+// to the output directory, writes pre-gzipped variants of compressible files,
+// adds Subresource Integrity attributes to index.html and writes a manifest
+// with the content type, fingerprint and sizes of every asset.
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"io/fs"
+	"log"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/web"
+)
+
+const (
+	// minGzipSize is the smallest file worth compressing
+	minGzipSize = 1024
+
+	// minGzipSaving is the fraction a compressed file must save to be kept
+	minGzipSaving = 0.1
+)
+
+var (
+	// assetRef matches script and stylesheet references in index.html
+	assetRef = regexp.MustCompile(`<(script|link)\b[^>]*?\b(src|href)="(/[^"]+\.(?:js|css))"[^>]*?>`)
+)
+
+// compressible lists the content type prefixes worth compressing
+var compressible = []string{"text/", "application/javascript", "application/json", "image/svg+xml"}
+
+func main() {
+	src := flag.String("src", "static", "directory holding the built dashboard")
+	out := flag.String("out", "dist", "directory to write the generated assets to")
+	flag.Parse()
+
+	if err := os.RemoveAll(*out); err != nil {
+		log.Fatalf("Error cleaning %s: %v", *out, err)
+	}
+
+	manifest := web.Manifest{Assets: make(map[string]web.Asset)}
+	var index []byte
+
+	err := filepath.WalkDir(*src, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(*src, file)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		// index.html is written last, once the integrity of everything it loads is known
+		if name == "index.html" {
+			index = data
+			return nil
+		}
+
+		asset, err := writeAsset(*out, name, data)
+		if err != nil {
+			return err
+		}
+		manifest.Assets[name] = asset
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Error processing %s: %v", *src, err)
+	}
+
+	if index == nil {
+		log.Fatalf("index.html not found in %s", *src)
+	}
+	asset, err := writeAsset(*out, "index.html", addIntegrity(index, &manifest))
+	if err != nil {
+		log.Fatalf("Error writing index.html: %v", err)
+	}
+	manifest.Assets["index.html"] = asset
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		log.Fatalf("Error encoding manifest: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*out, web.ManifestFile), data, 0o644); err != nil {
+		log.Fatalf("Error writing manifest: %v", err)
+	}
+
+	printSummary(&manifest)
+}
+
+// writeAsset copies a file to the output directory with its gzip variant and returns its manifest entry
+func writeAsset(out, name string, data []byte) (web.Asset, error) {
+	target := filepath.Join(out, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return web.Asset{}, err
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return web.Asset{}, err
+	}
+
+	sum := sha256.Sum256(data)
+	sri := sha512.Sum384(data)
+	asset := web.Asset{
+		Path:        name,
+		ContentType: contentType(name),
+		Size:        int64(len(data)),
+		SHA256:      hex.EncodeToString(sum[:]),
+		Integrity:   "sha384-" + base64.StdEncoding.EncodeToString(sri[:]),
+		Immutable:   web.Fingerprinted(name),
+	}
+
+	if !isCompressible(asset.ContentType) || len(data) < minGzipSize {
+		return asset, nil
+	}
+
+	var buf bytes.Buffer
+	gz, err := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+	if err != nil {
+		return web.Asset{}, err
+	}
+	gz.Write(data)
+	if err := gz.Close(); err != nil {
+		return web.Asset{}, err
+	}
+
+	if float64(buf.Len()) > float64(len(data))*(1-minGzipSaving) {
+		return asset, nil
+	}
+	if err := os.WriteFile(target+".gz", buf.Bytes(), 0o644); err != nil {
+		return web.Asset{}, err
+	}
+	asset.GzipSize = int64(buf.Len())
+
+	return asset, nil
+}
+
+// addIntegrity adds integrity and crossorigin attributes to the script and
+// stylesheet tags of index.html that load generated assets
+func addIntegrity(index []byte, manifest *web.Manifest) []byte {
+	return assetRef.ReplaceAllFunc(index, func(tag []byte) []byte {
+		if bytes.Contains(tag, []byte("integrity=")) {
+			return tag
+		}
+
+		match := assetRef.FindSubmatch(tag)
+		asset, ok := manifest.Lookup(string(match[3]))
+		if !ok {
+			return tag
+		}
+
+		attrs := ` integrity="` + asset.Integrity + `" crossorigin="anonymous"`
+		end := len(tag) - 1
+		if bytes.HasSuffix(tag, []byte("/>")) {
+			end--
+		}
+		return append(append(append([]byte{}, tag[:end]...), attrs...), tag[end:]...)
+	})
+}
+
+// contentType returns the content type for a file name
+func contentType(name string) string {
+	if ct := mime.TypeByExtension(path.Ext(name)); ct != "" {
+		return ct
+	}
+	if strings.HasSuffix(name, ".LICENSE.txt") {
+		return "text/plain; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
+
+// isCompressible reports whether a content type benefits from gzip
+func isCompressible(ct string) bool {
+	for _, prefix := range compressible {
+		if strings.HasPrefix(ct, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// printSummary logs the generated assets and the compression savings
+func printSummary(manifest *web.Manifest) {
+	names := make([]string, 0, len(manifest.Assets))
+	for name := range manifest.Assets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var total, compressed int64
+	for _, name := range names {
+		asset := manifest.Assets[name]
+		total += asset.Size
+		if asset.GzipSize > 0 {
+			compressed += asset.GzipSize
+			log.Printf("%-40s %8d -> %8d bytes", name, asset.Size, asset.GzipSize)
+		} else {
+			compressed += asset.Size
+			log.Printf("%-40s %8d bytes", name, asset.Size)
+		}
+	}
+	log.Printf("Generated %d assets, %d bytes (%d bytes compressed)", len(names), total, compressed)
+}
@@ -0,0 +1,24 @@
+// app/web/embed.go
+//go:build embedassets
+
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// dist holds the output of the generate step; build with -tags embedassets after
+// running go generate ./app/web
+//
+//go:embed all:dist
+var dist embed.FS
+
+// Embedded returns the generated assets compiled into the binary
+func Embedded() (fs.FS, bool) {
+	sub, err := fs.Sub(dist, "dist")
+	if err != nil {
+		return nil, false
+	}
+	return sub, true
+}
@@ -0,0 +1,70 @@
+// app/web/manifest.go
+package web
+
+//go:generate go run ./assetgen -src static -out dist
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ManifestFile is the name of the manifest written next to the generated assets
+const ManifestFile = "manifest.json"
+
+// fingerprintPattern matches file names that already carry a content hash, like main.ac385a1b.js
+var fingerprintPattern = regexp.MustCompile(`\.[0-9a-f]{8,}\.[a-z0-9]+$`)
+
+// Asset describes a single generated static file
+type Asset struct {
+	Path        string `json:"path"`
+	ContentType string `json:"contentType"`
+	Size        int64  `json:"size"`
+
+	// SHA256 is the hex content hash, used as the asset fingerprint
+	SHA256 string `json:"sha256"`
+
+	// Integrity is the Subresource Integrity value for script and style tags
+	Integrity string `json:"integrity"`
+
+	// Immutable is set when the file name already carries a content hash
+	Immutable bool `json:"immutable"`
+
+	// GzipSize is the size of the pre-compressed "<path>.gz" file, or 0 when
+	// compression did not pay off
+	GzipSize int64 `json:"gzipSize,omitempty"`
+}
+
+// Manifest lists the generated assets by URL path
+type Manifest struct {
+	Assets map[string]Asset `json:"assets"`
+}
+
+// LoadManifest reads the manifest from a generated asset tree
+func LoadManifest(fsys fs.FS) (*Manifest, error) {
+	data, err := fs.ReadFile(fsys, ManifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("error reading asset manifest: %w", err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("error parsing asset manifest: %w", err)
+	}
+	return &manifest, nil
+}
+
+// Lookup returns the asset served at a URL path
+func (m *Manifest) Lookup(urlPath string) (Asset, bool) {
+	asset, ok := m.Assets[strings.TrimPrefix(urlPath, "/")]
+	return asset, ok
+}
+
+// Fingerprinted reports whether a file name carries a content hash, so the
+// file can be cached forever
+func Fingerprinted(name string) bool {
+	return fingerprintPattern.MatchString(path.Base(name))
+}
@@ -0,0 +1,11 @@
+// app/web/noembed.go
+//go:build !embedassets
+
+package web
+
+import "io/fs"
+
+// Embedded reports that no assets were compiled in; the server falls back to STATIC_DIR
+func Embedded() (fs.FS, bool) {
+	return nil, false
+}
@@ -80,9 +80,18 @@ if [ ! -f "app/web/static/index.html" ]; then
     exit 1
 fi
 
+# Fingerprint, compress and index the web assets so they can be embedded
+echo "Generating embedded web assets..."
+go generate ./app/web
+
+# Stamp the binary with its version, served by /api/version
+VERSION_PKG="github.com/ayaseen/openshift-health-dashboard/app/server/version"
+VERSION="${VERSION:-$(git describe --tags --always --dirty 2>/dev/null || echo dev)}"
+LDFLAGS="-X ${VERSION_PKG}.Version=${VERSION} -X ${VERSION_PKG}.Commit=$(git rev-parse HEAD 2>/dev/null) -X ${VERSION_PKG}.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+
 # Build the Go binary with correct architecture
-echo "Building Go binary for Linux/amd64..."
-GOOS=linux GOARCH=amd64 CGO_ENABLED=0 go build -o bin/manager app/server/main.go
+echo "Building Go binary ${VERSION} for Linux/amd64..."
+GOOS=linux GOARCH=amd64 CGO_ENABLED=0 go build -tags embedassets -ldflags "$LDFLAGS" -o bin/manager ./app/server
 
 
 
@@ -90,15 +99,22 @@ GOOS=linux GOARCH=amd64 CGO_ENABLED=0 go build -o bin/manager app/server/main.go
 file bin/manager
 echo "Binaries built successfully"
 
-# Create Dockerfile with Ruby 3.0
+# Create Dockerfile for the scratch runtime image
 echo "Creating Dockerfile..."
 cat > Dockerfile << 'INNEREOF'
-FROM registry.access.redhat.com/ubi8:latest
+# The dashboard binary is fully static and embeds its web assets, so the runtime
+# image only needs CA certificates and a writable data directory
+FROM registry.access.redhat.com/ubi9/ubi-minimal:latest AS rootfs
 
-# Set non-root user ID for OpenShift compatibility
-# Use a fixed UID/GID that works with OpenShift's random UID assignment
-ENV USER_ID=1001 \
-    GROUP_ID=0
+# Prepare the runtime filesystem: CA bundle for outbound HTTPS and a data
+# directory writable by OpenShift's random UID (group 0)
+RUN mkdir -p /rootfs/etc/ssl/certs /rootfs/tmp/health-reports && \
+    cp /etc/pki/ca-trust/extracted/pem/tls-ca-bundle.pem /rootfs/etc/ssl/certs/ca-certificates.crt && \
+    chmod 1777 /rootfs/tmp && \
+    chgrp -R 0 /rootfs/tmp/health-reports && \
+    chmod -R g=u /rootfs/tmp/health-reports
+
+FROM scratch
 
 # Labels required by OpenShift
 LABEL name="openshift-health-operator" \
@@ -109,68 +125,25 @@ LABEL name="openshift-health-operator" \
       summary="OpenShift Health Check Operator" \
       description="Provides comprehensive health checks for OpenShift clusters"
 
-# Set up Go and Ruby in a single layer to reduce image size
-RUN yum module reset -y ruby && \
-    yum module enable -y ruby:3.0 && \
-    yum install -y ruby ruby-devel \
-        gcc gcc-c++ make zlib-devel \
-        redhat-rpm-config tar gzip diffutils curl procps-ng && \
-    curl -LO https://go.dev/dl/go1.24.2.linux-amd64.tar.gz && \
-    tar -C /usr/local -xzf go1.24.2.linux-amd64.tar.gz && \
-    rm go1.24.2.linux-amd64.tar.gz && \
-    gem install asciidoctor --no-document && \
-    gem install asciidoctor-pdf --no-document && \
-    yum clean all && \
-    rm -rf /var/cache/yum
-
-# Set Go environment variables
-ENV PATH=$PATH:/usr/local/go/bin \
-    GOROOT=/usr/local/go
-
-# Create directories with proper permissions
-RUN mkdir -p /tmp/health-reports /tmp/health-checks /web/static && \
-    chgrp -R 0 /tmp/health-reports /tmp/health-checks /web/static && \
-    chmod -R g=u /tmp/health-reports /tmp/health-checks /web/static
-
-# Configure working directory
-WORKDIR /opt/app-root/src
-
-# Copy the binary
-COPY bin/manager /usr/local/bin/manager
-
-# Clear and recreate web directory to ensure fresh files
-RUN rm -rf /web/static && mkdir -p /web/static
-
-# Copy web assets for dashboard
-COPY app/web/static/ /web/static/
-
-# Set permissions for OpenShift random UID compatibility
-RUN chmod -R g+rwX /web/static && \
-    chmod +x /usr/local/bin/manager && \
-    chgrp -R 0 /usr/local/bin/manager && \
-    chmod -R g=u /usr/local/bin/manager
-
-# Create startup script with proper permissions
-RUN echo '#!/bin/bash' > /usr/local/bin/start.sh && \
-    echo 'echo "Starting OpenShift Health Dashboard on port $PORT"' >> /usr/local/bin/start.sh && \
-    echo 'exec /usr/local/bin/manager' >> /usr/local/bin/start.sh && \
-    chmod +x /usr/local/bin/start.sh && \
-    chgrp 0 /usr/local/bin/start.sh && \
-    chmod g=u /usr/local/bin/start.sh
+COPY --from=rootfs /rootfs/ /
+
+# Copy the binary, built with go generate ./app/web and -tags embedassets
+COPY bin/manager /manager
 
 # Expose port
-EXPOSE 8082
+EXPOSE 8080
 
 # Set environment variables for the dashboard
-ENV STATIC_DIR=/web/static \
-    PORT=8080 \
-    DEBUG=false
+ENV PORT=8080 \
+    DEBUG=false \
+    DATA_DIR=/tmp/health-reports \
+    STATIC_ASSETS=embedded
 
-# Switch to non-root user
-USER ${USER_ID}
+# Run as a non-root user; OpenShift replaces the UID but keeps group 0
+USER 1001:0
 
 # Start the server
-ENTRYPOINT ["/usr/local/bin/start.sh"]
+ENTRYPOINT ["/manager"]
 
 INNEREOF
 
@@ -0,0 +1,45 @@
+#!/bin/bash
+set -e
+
+# Builds the dashboard as one static binary for running locally on a laptop,
+# e.g. during on-site engagements. The binary embeds the web assets and keeps
+# reports in an embedded SQLite database, so it needs no container runtime,
+# database or static files. By default it listens on 127.0.0.1:8080 and keeps
+# its data in the user's config directory (e.g. ~/.config/health-dashboard);
+# PORT, DATA_DIR, BIND_ADDRESS and STORE_DRIVER still override the defaults.
+
+OUTPUT_DIR="bin"
+TAGS="standalone sqlite embedassets"
+
+# Build for the local platform unless GOOS/GOARCH are set
+GOOS="${GOOS:-$(go env GOOS)}"
+GOARCH="${GOARCH:-$(go env GOARCH)}"
+OUTPUT="${OUTPUT_DIR}/health-dashboard-${GOOS}-${GOARCH}"
+if [ "$GOOS" = "windows" ]; then
+  OUTPUT="${OUTPUT}.exe"
+fi
+
+echo "=== Building standalone OpenShift Health Dashboard ==="
+
+# The web assets must be built first, see build-image.sh
+if [ ! -f "app/web/static/index.html" ]; then
+  echo "ERROR: The dashboard file (app/web/static/index.html) is missing."
+  echo "Build the dashboard first so the assets can be embedded."
+  exit 1
+fi
+
+# Fingerprint, compress and index the web assets so they can be embedded
+echo "Generating embedded web assets..."
+go generate ./app/web
+
+# Stamp the binary with its version, served by /api/version
+VERSION_PKG="github.com/ayaseen/openshift-health-dashboard/app/server/version"
+VERSION="${VERSION:-$(git describe --tags --always --dirty 2>/dev/null || echo dev)}"
+LDFLAGS="-s -w -X ${VERSION_PKG}.Version=${VERSION} -X ${VERSION_PKG}.Commit=$(git rev-parse HEAD 2>/dev/null) -X ${VERSION_PKG}.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+
+# The SQLite driver is pure Go, so the binary stays static without cgo
+echo "Building ${OUTPUT} ${VERSION}..."
+mkdir -p "$OUTPUT_DIR"
+GOOS="$GOOS" GOARCH="$GOARCH" CGO_ENABLED=0 go build -trimpath -tags "$TAGS" -ldflags "$LDFLAGS" -o "$OUTPUT" ./app/server
+
+echo "=== Build complete: ${OUTPUT} ==="
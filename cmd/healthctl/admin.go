@@ -0,0 +1,261 @@
+// cmd/healthctl/admin.go
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/client"
+)
+
+// adminUsage is printed for "healthctl admin -h" and unknown admin commands
+const adminUsage = `Usage: healthctl admin <command> [flags]
+
+Commands:
+  orgs     List the organizations
+  rescore  Queue every stored report for re-parsing with the current parser
+  reload   Reload the settings file, the registries and the knowledge base
+  verify   Check every stored report and its raw document
+  backup   Download a backup archive of the reports and registries
+  cleanup  Remove the reports beyond the retention limits now
+
+Run "healthctl admin <command> -h" for the flags of a command.
+`
+
+// adminCommands maps admin subcommand names to their implementation
+var adminCommands = map[string]command{
+	"orgs":    runAdminOrgs,
+	"rescore": runAdminRescore,
+	"reload":  runAdminReload,
+	"verify":  runAdminVerify,
+	"backup":  runAdminBackup,
+	"cleanup": runAdminCleanup,
+}
+
+// runAdmin dispatches the day-2 operations subcommands
+func runAdmin(args []string) error {
+	if len(args) == 0 || args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Print(adminUsage)
+		return nil
+	}
+
+	run, ok := adminCommands[args[0]]
+	if !ok {
+		fmt.Fprint(os.Stderr, adminUsage)
+		return fmt.Errorf("unknown admin command %q", args[0])
+	}
+	return run(args[1:])
+}
+
+// runAdminOrgs lists the organizations
+func runAdminOrgs(args []string) error {
+	flags := newFlagSet("admin orgs", "")
+	conn := addClientFlags(flags)
+	output := flags.String("o", outputTable, "output format: table or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	organizations, err := conn.client().ListOrgs(context.Background())
+	if err != nil {
+		return err
+	}
+
+	switch *output {
+	case outputJSON:
+		return printJSON(os.Stdout, organizations)
+	case outputTable:
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "NAME\tDISPLAY NAME\tINTEGRATIONS\tCREATED")
+		for _, org := range organizations {
+			var enabled []string
+			for _, integration := range org.Integrations {
+				if integration.Enabled {
+					enabled = append(enabled, integration.Type)
+				}
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", org.Name, org.DisplayName, strings.Join(enabled, ","),
+				org.CreatedAt.Local().Format("2006-01-02 15:04"))
+		}
+		return table.Flush()
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+}
+
+// runAdminRescore queues every stored report for re-scoring
+func runAdminRescore(args []string) error {
+	flags := newFlagSet("admin rescore", "")
+	conn := addClientFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	queued, err := conn.client().Rescore(context.Background())
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Queued %d reports for re-scoring\n", queued)
+	return nil
+}
+
+// runAdminReload reloads the dashboard configuration
+func runAdminReload(args []string) error {
+	flags := newFlagSet("admin reload", "")
+	conn := addClientFlags(flags)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := conn.client().Reload(context.Background())
+	if result == nil {
+		return err
+	}
+
+	fmt.Printf("Reloaded: %s\n", strings.Join(result.Reloaded, ", "))
+	parts := make([]string, 0, len(result.Errors))
+	for part := range result.Errors {
+		parts = append(parts, part)
+	}
+	sort.Strings(parts)
+	for _, part := range parts {
+		fmt.Printf("Failed:   %s: %s\n", part, result.Errors[part])
+	}
+	return err
+}
+
+// runAdminVerify checks the integrity of the stored reports and fails when
+// problems are found, so it can gate scripted maintenance
+func runAdminVerify(args []string) error {
+	flags := newFlagSet("admin verify", "")
+	conn := addClientFlags(flags)
+	output := flags.String("o", outputTable, "output format: table or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := conn.client().VerifyIntegrity(context.Background())
+	if err != nil {
+		return err
+	}
+
+	switch *output {
+	case outputJSON:
+		if err := printJSON(os.Stdout, report); err != nil {
+			return err
+		}
+	case outputTable:
+		if len(report.Problems) > 0 {
+			table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(table, "REPORT\tPROBLEM")
+			for _, problem := range report.Problems {
+				fmt.Fprintf(table, "%s\t%s\n", problem.ReportID, problem.Problem)
+			}
+			if err := table.Flush(); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("Checked %d reports, %d problems found\n", report.Checked, len(report.Problems))
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+
+	if len(report.Problems) > 0 {
+		return errors.New("integrity check failed")
+	}
+	return nil
+}
+
+// runAdminBackup downloads a backup archive
+func runAdminBackup(args []string) error {
+	flags := newFlagSet("admin backup", "")
+	conn := addClientFlags(flags)
+	path := flags.String("f", "", "archive file to write, - for stdout; defaults to a timestamped name")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *path == "" {
+		*path = "health-dashboard-" + time.Now().UTC().Format("20060102-150405") + ".tar.gz"
+	}
+
+	if *path == "-" {
+		_, err := conn.client().Backup(context.Background(), os.Stdout)
+		return err
+	}
+	return writeBackupFile(conn.client(), *path)
+}
+
+// runAdminCleanup enforces the retention limits
+func runAdminCleanup(args []string) error {
+	flags := newFlagSet("admin cleanup", "")
+	conn := addClientFlags(flags)
+	dryRun := flags.Bool("dry-run", false, "only list the reports that would be removed")
+	output := flags.String("o", outputTable, "output format: table or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	run, err := conn.client().RunRetention(context.Background(), *dryRun)
+	if err != nil {
+		return err
+	}
+
+	switch *output {
+	case outputJSON:
+		return printJSON(os.Stdout, run)
+	case outputTable:
+		if len(run.Removed) > 0 {
+			table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(table, "REPORT\tCLUSTER\tUPLOADED\tREASON")
+			for _, removed := range run.Removed {
+				fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", removed.ReportID, removed.Cluster,
+					removed.UploadedAt.Local().Format("2006-01-02 15:04"), removed.Reason)
+			}
+			if err := table.Flush(); err != nil {
+				return err
+			}
+		}
+		verb := "Removed"
+		if run.DryRun {
+			verb = "Would remove"
+		}
+		fmt.Printf("%s %d of %d reports\n", verb, len(run.Removed), run.Checked)
+		for _, problem := range run.Errors {
+			fmt.Printf("Failed: %s\n", problem)
+		}
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+
+	if len(run.Errors) > 0 {
+		return errors.New("cleanup failed for some reports")
+	}
+	return nil
+}
+
+// writeBackupFile downloads a backup into a file, removing it again when the
+// download fails so a truncated archive isn't mistaken for a good one
+func writeBackupFile(c *client.Client, path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	size, err := c.Backup(context.Background(), file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %s (%d bytes)\n", path, size)
+	return nil
+}
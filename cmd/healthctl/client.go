@@ -0,0 +1,27 @@
+// cmd/healthctl/client.go
+package main
+
+import (
+	"flag"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/client"
+)
+
+// connection holds the flags that select the dashboard of a remote command
+type connection struct {
+	server string
+	apiKey string
+}
+
+// addClientFlags registers the connection flags shared by the remote commands
+func addClientFlags(flags *flag.FlagSet) *connection {
+	conn := &connection{}
+	flags.StringVar(&conn.server, "server", getEnv("HEALTHCTL_SERVER", "http://localhost:8080"), "dashboard URL")
+	flags.StringVar(&conn.apiKey, "api-key", getEnv("HEALTHCTL_API_KEY", ""), "API key sent as X-API-Key")
+	return conn
+}
+
+// client returns an API client for the selected dashboard; call it after the flags are parsed
+func (conn *connection) client() *client.Client {
+	return client.New(conn.server, conn.apiKey)
+}
@@ -0,0 +1,93 @@
+// cmd/healthctl/main.go
+
+// Command healthctl parses health check reports locally and talks to a
+// running dashboard: uploading reports, listing them, triggering scans and
+// day-2 administration.
+// Local parsing uses the server's parser package, so the results match what
+// the dashboard shows for the same document.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+)
+
+// usage is printed for -h and unknown commands
+const usage = `Usage: healthctl <command> [flags] [args]
+
+Commands:
+  parse      Parse an AsciiDoc, Markdown or HTML report locally
+  upload     Upload a report to a dashboard
+  list       List the reports stored by a dashboard
+  scan       Run a live scan of a registered cluster
+  admin      Day-2 operations: organizations, re-scoring, reload, integrity, backup, cleanup
+  version    Print the version of healthctl and of the dashboard
+  manifests  Print the manifests deploying the dashboard on OpenShift
+
+Remote commands read the dashboard URL from --server or HEALTHCTL_SERVER and
+an optional API key from --api-key or HEALTHCTL_API_KEY.
+
+Run "healthctl <command> -h" for the flags of a command.
+`
+
+// command runs a subcommand with its arguments
+type command func(args []string) error
+
+// commands maps subcommand names to their implementation
+var commands = map[string]command{
+	"parse":     runParse,
+	"upload":    runUpload,
+	"list":      runList,
+	"scan":      runScan,
+	"admin":     runAdmin,
+	"version":   runVersion,
+	"manifests": runManifests,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	name := os.Args[1]
+	if name == "-h" || name == "--help" || name == "help" {
+		fmt.Print(usage)
+		return
+	}
+
+	run, ok := commands[name]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "healthctl: unknown command %q\n\n%s", name, usage)
+		os.Exit(2)
+	}
+
+	if err := run(os.Args[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "healthctl %s: %v\n", name, err)
+		os.Exit(1)
+	}
+}
+
+// newFlagSet returns the flag set of a subcommand
+func newFlagSet(name, args string) *flag.FlagSet {
+	flags := flag.NewFlagSet(name, flag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: healthctl %s [flags] %s\n\nFlags:\n", name, args)
+		flags.PrintDefaults()
+	}
+	return flags
+}
+
+// getEnv gets an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
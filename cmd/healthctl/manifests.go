@@ -0,0 +1,82 @@
+// cmd/healthctl/manifests.go
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/deploy"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/settings"
+)
+
+// variableFlag collects repeated NAME=VALUE flags
+type variableFlag map[string]string
+
+func (v variableFlag) String() string {
+	return ""
+}
+
+func (v variableFlag) Set(value string) error {
+	name, val, ok := strings.Cut(value, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("%q is not NAME=VALUE", value)
+	}
+	v[name] = val
+	return nil
+}
+
+// runManifests prints the manifests deploying the dashboard, e.g.
+// "healthctl manifests -n health | oc apply -f -"
+func runManifests(args []string) error {
+	flags := newFlagSet("manifests", "")
+	var options deploy.Options
+	env, secrets := variableFlag{}, variableFlag{}
+	flags.StringVar(&options.Name, "name", deploy.DefaultName, "name of the generated objects")
+	flags.StringVar(&options.Namespace, "n", "", "namespace of the generated objects")
+	flags.StringVar(&options.Image, "image", deploy.DefaultImage, "dashboard image")
+	flags.StringVar(&options.RouteHost, "route-host", "", "host of the route; empty lets the router pick one")
+	flags.StringVar(&options.StorageSize, "storage-size", deploy.DefaultStorageSize, "size of the report volume")
+	flags.StringVar(&options.StorageClass, "storage-class", "", "storage class of the report volume")
+	flags.BoolVar(&options.Template, "template", false, "generate an OpenShift template instead of objects")
+	flags.BoolVar(&options.Operator, "operator", false, "run in operator mode, adding the HealthDashboard CRD and its permissions")
+	flags.Var(env, "set", "set a server variable, NAME=VALUE; may be repeated")
+	flags.Var(secrets, "secret", "set a secret server variable, NAME=VALUE; may be repeated")
+	settingsFile := flags.String("settings", "", "settings file for the settings ConfigMap")
+	list := flags.Bool("list", false, "list the server variables instead")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *list {
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "NAME\tDEFAULT\tSECRET\tDESCRIPTION")
+		for _, variable := range deploy.Variables {
+			fmt.Fprintf(table, "%s\t%s\t%t\t%s\n", variable.Name, variable.Default, variable.Secret, variable.Description)
+		}
+		return table.Flush()
+	}
+
+	if *settingsFile != "" {
+		// Load the file like the server would, so mistakes show up now
+		// rather than in the pod's log
+		if _, err := settings.NewManager(*settingsFile, settings.Settings{Scoring: scoring.Default()}); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(*settingsFile)
+		if err != nil {
+			return err
+		}
+		options.Settings = data
+	}
+	options.Env, options.Secrets = env, secrets
+
+	manifests, err := deploy.Generate(options)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(manifests)
+	return err
+}
@@ -0,0 +1,179 @@
+// cmd/healthctl/parse.go
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/scoring"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/settings"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/utils"
+)
+
+// Output formats
+const (
+	outputJSON      = "json"
+	outputScorecard = "scorecard"
+	outputTable     = "table"
+)
+
+// runParse parses a report locally
+func runParse(args []string) error {
+	flags := newFlagSet("parse", "<report.adoc|report.md|report.html>")
+	output := flags.String("o", outputScorecard, "output format: scorecard or json")
+	preset := flags.String("scoring", "", "scoring preset; defaults to the settings file's profile")
+	parser := flags.String("parser", "", `parser profile of the report dialect from the settings file; "default" for the standard template, detected if empty`)
+	settingsFile := flags.String("settings", "", "dashboard settings file with the scoring profile, category mappings, extraction order and parser profiles")
+	schema := flags.String("schema", "v2", "JSON summary schema: v1 or v2")
+	verbose := flags.Bool("v", false, "show the parser's log output")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected one report file")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	manager, err := settings.NewManager(*settingsFile, settings.Settings{Scoring: scoring.Default()})
+	if err != nil {
+		return fmt.Errorf("invalid settings file: %w", err)
+	}
+	current := manager.Current()
+
+	options := current.ParseOptions()
+	options.Profile = *parser
+	options.Format = utils.FormatForFilename(flags.Arg(0))
+	summary, err := utils.ParseReportWithOptions(file, options)
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", flags.Arg(0), err)
+	}
+
+	// Score like the server does on upload: map categories, apply the profile, then list the categories
+	profile := current.Scoring
+	if *preset != "" {
+		if profile, err = scoring.Preset(*preset); err != nil {
+			return err
+		}
+	}
+	current.MapCategories(summary)
+	scoring.Apply(summary, profile)
+	scoring.Categorize(summary, profile)
+
+	switch *output {
+	case outputJSON:
+		switch *schema {
+		case "v1":
+			return printJSON(os.Stdout, summary)
+		case "v2":
+			return printJSON(os.Stdout, summary.V2())
+		default:
+			return fmt.Errorf("unknown schema %q", *schema)
+		}
+	case outputScorecard:
+		printScorecard(os.Stdout, summary.V2())
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+}
+
+// printJSON writes v as indented JSON
+func printJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// scorecardBarWidth is the width of the score bars in characters
+const scorecardBarWidth = 30
+
+// maxScorecardItems bounds the items listed per status on the scorecard
+const maxScorecardItems = 10
+
+// printScorecard writes a terminal summary of a report, with the category
+// names used by the dashboard
+func printScorecard(w io.Writer, summary *types.ReportSummaryV2) {
+	fmt.Fprintf(w, "Cluster:   %s\n", summary.ClusterName)
+	fmt.Fprintf(w, "Customer:  %s\n", summary.CustomerName)
+	if summary.ReportID != "" {
+		fmt.Fprintf(w, "Report:    %s\n", summary.ReportID)
+	}
+	fmt.Fprintf(w, "\nOverall    %s %5.1f%%  %s\n\n", scoreBar(summary.OverallScore), summary.OverallScore, scoreRating(summary.OverallScore))
+
+	for _, category := range []struct {
+		name  string
+		score int
+	}{
+		{"Infrastructure Setup", summary.ScoreInfra},
+		{"Policy Governance", summary.ScoreGovernance},
+		{"Compliance Benchmarking", summary.ScoreCompliance},
+		{"Monitoring", summary.ScoreMonitoring},
+		{"Build/Deploy Security", summary.ScoreBuildSecurity},
+	} {
+		fmt.Fprintf(w, "%-24s %s %3d%%\n", category.name, scoreBar(float64(category.score)), category.score)
+	}
+
+	fmt.Fprintf(w, "\nRequired: %d  Recommended: %d  Advisory: %d  No change: %d  N/A: %d\n",
+		len(summary.ItemsRequired), len(summary.ItemsRecommended), len(summary.ItemsAdvisory),
+		summary.NoChangeCount, summary.NotApplicableCount)
+
+	printItems(w, "Changes required", summary.ItemsRequired)
+	printItems(w, "Changes recommended", summary.ItemsRecommended)
+}
+
+// printItems lists the findings of one status
+func printItems(w io.Writer, heading string, findings []types.Finding) {
+	if len(findings) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\n%s:\n", heading)
+	for i, finding := range findings {
+		if i == maxScorecardItems {
+			fmt.Fprintf(w, "  ... and %d more\n", len(findings)-maxScorecardItems)
+			break
+		}
+		if finding.Category != "" {
+			fmt.Fprintf(w, "  - %s (%s)\n", finding.Title, finding.Category)
+		} else {
+			fmt.Fprintf(w, "  - %s\n", finding.Title)
+		}
+	}
+}
+
+// scoreBar draws a score out of 100 as a bar
+func scoreBar(score float64) string {
+	filled := int(score / 100 * scorecardBarWidth)
+	filled = max(0, min(filled, scorecardBarWidth))
+	return strings.Repeat("█", filled) + strings.Repeat("░", scorecardBarWidth-filled)
+}
+
+// scoreRating labels a score like the dashboard does
+func scoreRating(score float64) string {
+	switch {
+	case score >= 90:
+		return "Excellent"
+	case score >= 75:
+		return "Good"
+	case score >= 60:
+		return "Fair"
+	case score >= 40:
+		return "Poor"
+	default:
+		return "Critical"
+	}
+}
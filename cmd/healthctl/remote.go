@@ -0,0 +1,131 @@
+// cmd/healthctl/remote.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/client"
+	"github.com/ayaseen/openshift-health-dashboard/app/server/types"
+)
+
+// runUpload uploads a report to the dashboard
+func runUpload(args []string) error {
+	flags := newFlagSet("upload", "<report.adoc|report.md|report.html>")
+	conn := addClientFlags(flags)
+	cluster := flags.String("cluster", "", "registered cluster the report belongs to")
+	preset := flags.String("scoring", "", "scoring preset to score the report with")
+	parser := flags.String("parser", "", "parser profile of the report dialect; detected if empty")
+	async := flags.Bool("async", false, "parse in a background job and wait for it")
+	signatureFile := flags.String("signature", "", "detached GPG or sigstore signature of the report")
+	chunkSize := flags.Int("chunk-size", 0, "upload in resumable chunks of this many MiB, e.g. over an unreliable VPN; 0 sends a single request")
+	output := flags.String("o", outputScorecard, "output format: scorecard or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return fmt.Errorf("expected one report file")
+	}
+
+	file, err := os.Open(flags.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	options := client.UploadOptions{Cluster: *cluster, Scoring: *preset, Parser: *parser, Async: *async}
+	if *signatureFile != "" {
+		if options.Signature, err = os.ReadFile(*signatureFile); err != nil {
+			return err
+		}
+	}
+
+	var summary *types.ReportSummaryV2
+	if *chunkSize > 0 {
+		info, err := file.Stat()
+		if err != nil {
+			return err
+		}
+		summary, err = conn.client().UploadReportChunked(context.Background(), filepath.Base(flags.Arg(0)), file, info.Size(), options, int64(*chunkSize)<<20)
+		if err != nil {
+			return err
+		}
+	} else if summary, err = conn.client().UploadReport(context.Background(), filepath.Base(flags.Arg(0)), file, options); err != nil {
+		return err
+	}
+	return printSummary(*output, summary)
+}
+
+// runList lists the stored reports
+func runList(args []string) error {
+	flags := newFlagSet("list", "")
+	conn := addClientFlags(flags)
+	customer := flags.String("customer", "", "only reports of this customer")
+	cluster := flags.String("cluster", "", "only reports of this cluster")
+	output := flags.String("o", outputTable, "output format: table or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	reports, err := conn.client().ListReports(context.Background(), client.ReportFilter{Customer: *customer, Cluster: *cluster})
+	if err != nil {
+		return err
+	}
+
+	switch *output {
+	case outputJSON:
+		return printJSON(os.Stdout, reports)
+	case outputTable:
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "ID\tUPLOADED\tCUSTOMER\tCLUSTER\tSCORE\tREVIEW")
+		for _, report := range reports {
+			cluster := report.Cluster
+			if cluster == "" {
+				cluster = report.ClusterName
+			}
+			fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%.1f\t%s\n", report.ID, report.UploadedAt.Local().Format("2006-01-02 15:04"),
+				report.CustomerName, cluster, report.OverallScore, report.ReviewStatus)
+		}
+		return table.Flush()
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+}
+
+// runScan triggers a live scan of a cluster and waits for its result
+func runScan(args []string) error {
+	flags := newFlagSet("scan", "")
+	conn := addClientFlags(flags)
+	cluster := flags.String("cluster", "", "registered cluster to scan (required)")
+	output := flags.String("o", outputScorecard, "output format: scorecard or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *cluster == "" {
+		flags.Usage()
+		return fmt.Errorf("--cluster is required")
+	}
+
+	summary, err := conn.client().Scan(context.Background(), *cluster)
+	if err != nil {
+		return err
+	}
+	return printSummary(*output, summary.V2())
+}
+
+// printSummary prints a summary returned by the dashboard
+func printSummary(output string, summary *types.ReportSummaryV2) error {
+	switch output {
+	case outputJSON:
+		return printJSON(os.Stdout, summary)
+	case outputScorecard:
+		printScorecard(os.Stdout, summary)
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q", output)
+	}
+}
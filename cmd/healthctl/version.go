@@ -0,0 +1,44 @@
+// cmd/healthctl/version.go
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/ayaseen/openshift-health-dashboard/app/server/version"
+)
+
+// runVersion prints the version of healthctl and of the dashboard it talks to
+func runVersion(args []string) error {
+	flags := newFlagSet("version", "")
+	conn := addClientFlags(flags)
+	output := flags.String("o", outputTable, "output format: table or json")
+	clientOnly := flags.Bool("client", false, "only print the version of healthctl")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	local := version.Get()
+	versions := map[string]*version.Info{"client": &local}
+
+	var serverErr error
+	if !*clientOnly {
+		versions["server"], serverErr = conn.client().ServerVersion(context.Background())
+	}
+
+	switch *output {
+	case outputJSON:
+		if err := printJSON(os.Stdout, versions); err != nil {
+			return err
+		}
+	case outputTable:
+		fmt.Printf("Client: %s %s\n", local, local.GoVersion)
+		if server := versions["server"]; server != nil {
+			fmt.Printf("Server: %s %s\n", server, server.GoVersion)
+		}
+	default:
+		return fmt.Errorf("unknown output format %q", *output)
+	}
+	return serverErr
+}